@@ -35,6 +35,9 @@ const (
 	getStatePath             = "/eth/v2/debug/beacon/states"
 	getNodeVersionPath       = "/eth/v1/node/version"
 	changeBLStoExecutionPath = "/eth/v1/beacon/pool/bls_to_execution_changes"
+	getValidatorsPath        = "/eth/v1/beacon/states/{{.Id}}/validators"
+	getValidatorBalancesPath = "/eth/v1/beacon/states/{{.Id}}/validator_balances"
+	getCommitteesPath        = "/eth/v1/beacon/states/{{.Id}}/committees"
 )
 
 // StateOrBlockId represents the block_id / state_id parameters that several of the Eth Beacon API methods accept.
@@ -90,7 +93,9 @@ type Client struct {
 }
 
 // NewClient returns a new Client that includes functions for rest calls to Beacon API.
+// Responses are requested as SSZ, with a fallback to JSON for the endpoints which only support JSON.
 func NewClient(host string, opts ...client.ClientOpt) (*Client, error) {
+	opts = append([]client.ClientOpt{client.WithRequestOptions(client.WithSSZPreference())}, opts...)
 	c, err := client.NewClient(host, opts...)
 	if err != nil {
 		return nil, err
@@ -334,6 +339,72 @@ func (c *Client) GetBLStoExecutionChanges(ctx context.Context) (*structs.BLSToEx
 	return poolResponse, nil
 }
 
+var getValidatorsTpl = idTemplate(getValidatorsPath)
+
+// GetValidators retrieves the validators of the state identified by stateId, filtered by the given ids
+// (indices or hex encoded public keys) and statuses. Empty filters match all validators.
+func (c *Client) GetValidators(ctx context.Context, stateId StateOrBlockId, ids, statuses []string) ([]*structs.ValidatorContainer, error) {
+	body, err := json.Marshal(&structs.GetValidatorsRequest{Ids: ids, Statuses: statuses})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal JSON")
+	}
+	resp, err := c.Request(ctx, http.MethodPost, getValidatorsTpl(stateId), body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error requesting validators by state id = %s", stateId)
+	}
+	return decodeListResponse(resp, structs.ValidatorContainerFromSSZ)
+}
+
+var getValidatorBalancesTpl = idTemplate(getValidatorBalancesPath)
+
+// GetValidatorBalances retrieves the balances of the validators of the state identified by stateId with the given
+// ids (indices or hex encoded public keys), or of all validators if ids is empty.
+func (c *Client) GetValidatorBalances(ctx context.Context, stateId StateOrBlockId, ids []string) ([]*structs.ValidatorBalance, error) {
+	if ids == nil {
+		ids = []string{}
+	}
+	body, err := json.Marshal(ids)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal JSON")
+	}
+	resp, err := c.Request(ctx, http.MethodPost, getValidatorBalancesTpl(stateId), body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error requesting validator balances by state id = %s", stateId)
+	}
+	return decodeListResponse(resp, structs.ValidatorBalanceFromSSZ)
+}
+
+var getCommitteesTpl = idTemplate(getCommitteesPath)
+
+// GetCommittees retrieves the committees of the given epoch computed from the state identified by stateId.
+func (c *Client) GetCommittees(ctx context.Context, stateId StateOrBlockId, epoch primitives.Epoch) ([]*structs.Committee, error) {
+	q := url.Values{"epoch": {strconv.FormatUint(uint64(epoch), 10)}}
+	resp, err := c.Request(ctx, http.MethodGet, getCommitteesTpl(stateId), nil, client.WithQuery(q))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error requesting committees by state id = %s", stateId)
+	}
+	return decodeListResponse(resp, structs.CommitteeFromSSZ)
+}
+
+// decodeListResponse decodes the data of a list response, which is either ssz encoded or a JSON object.
+func decodeListResponse[S any, PS interface {
+	*S
+	structs.SSZElement
+}, T any](resp *client.Response, fromSSZ func(PS) T) ([]T, error) {
+	if resp.IsSSZ() {
+		data, err := structs.UnmarshalSSZList(resp.Body, fromSSZ)
+		if err != nil {
+			return nil, errors.Wrap(err, "error decoding ssz response")
+		}
+		return data, nil
+	}
+	d := &struct{ Data []T }{}
+	if err := json.Unmarshal(resp.Body, d); err != nil {
+		return nil, errors.Wrap(err, "error decoding json response")
+	}
+	return d.Data, nil
+}
+
 type forkScheduleResponse struct {
 	Data []structs.Fork
 }
@@ -1,10 +1,17 @@
 package beacon
 
 import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
 	"net/url"
 	"testing"
 
+	"github.com/prysmaticlabs/prysm/v5/api"
 	"github.com/prysmaticlabs/prysm/v5/api/client"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 )
 
@@ -137,3 +144,57 @@ func TestValidHostname(t *testing.T) {
 		})
 	}
 }
+
+func TestGetValidatorLists(t *testing.T) {
+	balances := []*structs.ValidatorBalance{{Index: "1", Balance: "32"}, {Index: "2", Balance: "31"}}
+	committees := []*structs.Committee{{Index: "0", Slot: "64", Validators: []string{"4", "1"}}}
+	for _, ssz := range []bool{true, false} {
+		t.Run(fmt.Sprintf("ssz=%v", ssz), func(t *testing.T) {
+			trans := &testRT{rt: func(req *http.Request) (*http.Response, error) {
+				require.Equal(t, "application/octet-stream;q=1.0,application/json;q=0.9", req.Header.Get("Accept"))
+				var data any
+				var enc []byte
+				var err error
+				switch req.URL.Path {
+				case getValidatorBalancesTpl(IdHead):
+					require.Equal(t, http.MethodPost, req.Method)
+					body, err := io.ReadAll(req.Body)
+					require.NoError(t, err)
+					require.Equal(t, `["1","2"]`, string(body))
+					data = balances
+					enc, err = structs.MarshalSSZList(balances, (*structs.ValidatorBalance).ToSSZ)
+					require.NoError(t, err)
+				case getCommitteesTpl(IdFinalized):
+					require.Equal(t, "epoch=2", req.URL.RawQuery)
+					data = committees
+					enc, err = structs.MarshalSSZList(committees, (*structs.Committee).ToSSZ)
+					require.NoError(t, err)
+				default:
+					return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(bytes.NewBuffer(nil)), Request: req}, nil
+				}
+				res := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Request: req}
+				if ssz {
+					res.Header.Set("Content-Type", api.OctetStreamMediaType)
+				} else {
+					res.Header.Set("Content-Type", api.JsonMediaType)
+					enc, err = marshalToEnvelope(data)
+					require.NoError(t, err)
+				}
+				res.Body = io.NopCloser(bytes.NewBuffer(enc))
+				return res, nil
+			}}
+			c, err := NewClient("http://localhost:3500", client.WithRoundTripper(trans))
+			require.NoError(t, err)
+			ctx := context.Background()
+
+			gotBalances, err := c.GetValidatorBalances(ctx, IdHead, []string{"1", "2"})
+			require.NoError(t, err)
+			require.DeepEqual(t, balances, gotBalances)
+			gotCommittees, err := c.GetCommittees(ctx, IdFinalized, 2)
+			require.NoError(t, err)
+			require.DeepEqual(t, committees, gotCommittees)
+			_, err = c.GetValidators(ctx, IdHead, nil, nil)
+			require.ErrorIs(t, err, client.ErrNotFound)
+		})
+	}
+}
@@ -1,13 +1,16 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"io"
+	"mime"
 	"net"
 	"net/http"
 	"net/url"
 
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api"
 )
 
 // Client is a wrapper object around the HTTP client.
@@ -15,6 +18,19 @@ type Client struct {
 	hc      *http.Client
 	baseURL *url.URL
 	token   string
+	reqOpts []ReqOption
+}
+
+// Response is the body and headers of a successful response.
+type Response struct {
+	Body   []byte
+	Header http.Header
+}
+
+// IsSSZ returns true if the body of the response is ssz encoded.
+func (r *Response) IsSSZ() bool {
+	mt, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && mt == api.OctetStreamMediaType
 }
 
 // NewClient constructs a new client with the provided options (ex WithTimeout).
@@ -71,11 +87,31 @@ func (c *Client) NodeURL() string {
 
 // Get is a generic, opinionated GET function to reduce boilerplate amongst the getters in this package.
 func (c *Client) Get(ctx context.Context, path string, opts ...ReqOption) ([]byte, error) {
+	resp, err := c.Request(ctx, http.MethodGet, path, nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// Request sends a request with the given method and body, which is sent as JSON if not nil. The default request
+// options of the client are applied before opts. An error is returned if the response status is not 200 OK.
+func (c *Client) Request(ctx context.Context, method, path string, body []byte, opts ...ReqOption) (*Response, error) {
 	u := c.baseURL.ResolveReference(&url.URL{Path: path})
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), reqBody)
 	if err != nil {
 		return nil, err
 	}
+	if body != nil {
+		req.Header.Set("Content-Type", api.JsonMediaType)
+	}
+	for _, o := range c.reqOpts {
+		o(req)
+	}
 	for _, o := range opts {
 		o(req)
 	}
@@ -93,5 +129,5 @@ func (c *Client) Get(ctx context.Context, path string, opts ...ReqOption) ([]byt
 	if err != nil {
 		return nil, errors.Wrap(err, "error reading http response body")
 	}
-	return b, nil
+	return &Response{Body: b, Header: r.Header}, nil
 }
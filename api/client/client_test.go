@@ -1,9 +1,14 @@
 package client
 
 import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"testing"
 
+	"github.com/prysmaticlabs/prysm/v5/api"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 )
 
@@ -46,3 +51,47 @@ func TestBaseURL(t *testing.T) {
 	require.Equal(t, "www.offchainlabs.com", cl.BaseURL().Hostname())
 	require.Equal(t, "3500", cl.BaseURL().Port())
 }
+
+func TestRequest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ssz":
+			require.Equal(t, "application/octet-stream;q=1.0,application/json;q=0.9", r.Header.Get("Accept"))
+			require.Equal(t, "a=1&a=2", r.URL.RawQuery)
+			w.Header().Set("Content-Type", api.OctetStreamMediaType)
+			_, err := w.Write([]byte{1, 2})
+			require.NoError(t, err)
+		case "/json":
+			require.Equal(t, http.MethodPost, r.Method)
+			require.Equal(t, api.JsonMediaType, r.Header.Get("Content-Type"))
+			// Request options override the default options of the client.
+			require.Equal(t, api.JsonMediaType, r.Header.Get("Accept"))
+			b, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			_, err = w.Write(b)
+			require.NoError(t, err)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	cl, err := NewClient(srv.URL, WithRequestOptions(WithSSZPreference()))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	resp, err := cl.Request(ctx, http.MethodGet, "/ssz", nil, WithQuery(url.Values{"a": {"1", "2"}}))
+	require.NoError(t, err)
+	require.Equal(t, true, resp.IsSSZ())
+	require.DeepEqual(t, []byte{1, 2}, resp.Body)
+
+	resp, err = cl.Request(ctx, http.MethodPost, "/json", []byte(`["1"]`), func(r *http.Request) {
+		r.Header.Set("Accept", api.JsonMediaType)
+	})
+	require.NoError(t, err)
+	require.Equal(t, false, resp.IsSSZ())
+	require.Equal(t, `["1"]`, string(resp.Body))
+
+	_, err = cl.Get(ctx, "/missing")
+	require.ErrorIs(t, err, ErrNotFound)
+}
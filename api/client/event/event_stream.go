@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/api"
@@ -28,10 +29,21 @@ const (
 	EventLightClientOptimisticUpdate = "light_client_optimistic_update"
 	EventPayloadAttributes           = "payload_attributes"
 	EventBlobSidecar                 = "blob_sidecar"
+	EventValidatorLifecycle          = "validator_lifecycle"
+	EventBlockGossip                 = "block_gossip"
 	EventError                       = "error"
 	EventConnectionError             = "connection_error"
 )
 
+const (
+	// resumeBackoff is the wait before reopening an event stream which ended, doubled each time the stream ends
+	// again shortly after being reopened.
+	resumeBackoff = time.Second
+	// maxResumeBackoff caps the wait before reopening an event stream. A stream which stayed open for longer is
+	// reopened after resumeBackoff again.
+	maxResumeBackoff = 30 * time.Second
+)
+
 var (
 	_ = EventStreamClient(&EventStream{})
 )
@@ -50,10 +62,13 @@ type Event struct {
 // EventStream is responsible for subscribing to the Beacon API events endpoint
 // and dispatching received events to subscribers.
 type EventStream struct {
-	ctx        context.Context
-	httpClient *http.Client
-	host       string
-	topics     []string
+	ctx              context.Context
+	httpClient       *http.Client
+	host             string
+	topics           []string
+	lastEventID      string
+	resumeBackoff    time.Duration
+	maxResumeBackoff time.Duration
 }
 
 func NewEventStream(ctx context.Context, httpClient *http.Client, host string, topics []string) (*EventStream, error) {
@@ -67,16 +82,46 @@ func NewEventStream(ctx context.Context, httpClient *http.Client, host string, t
 	}
 
 	return &EventStream{
-		ctx:        ctx,
-		httpClient: httpClient,
-		host:       host,
-		topics:     topics,
+		ctx:              ctx,
+		httpClient:       httpClient,
+		host:             host,
+		topics:           topics,
+		resumeBackoff:    resumeBackoff,
+		maxResumeBackoff: maxResumeBackoff,
 	}, nil
 }
 
+// Subscribe dispatches the events of the stream to eventsChannel. The ID of the last received event is kept, and
+// if the stream ends after delivering events with IDs, it is reopened with the Last-Event-ID header so that the
+// beacon node replays the events sent in between. Streams which keep ending are reopened with an exponential backoff.
 func (h *EventStream) Subscribe(eventsChannel chan<- *Event) {
 	allTopics := strings.Join(h.topics, ",")
 	log.WithField("topics", allTopics).Info("Listening to Beacon API events")
+	backoff := h.resumeBackoff
+	for {
+		opened := time.Now()
+		if !h.subscribe(eventsChannel, allTopics) {
+			return
+		}
+		if time.Since(opened) > h.maxResumeBackoff {
+			backoff = h.resumeBackoff
+		}
+		log.WithFields(log.Fields{
+			"lastEventId": h.lastEventID,
+			"backoff":     backoff,
+		}).Info("Event stream ended, resuming from the last received event")
+		select {
+		case <-h.ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, h.maxResumeBackoff)
+	}
+}
+
+// subscribe reads the event stream until it ends. It returns true if the stream can be resumed, which is when it
+// delivered an event with an ID and was not ended by the context.
+func (h *EventStream) subscribe(eventsChannel chan<- *Event, allTopics string) bool {
 	fullUrl := h.host + "/eth/v1/events?topics=" + allTopics
 	req, err := http.NewRequestWithContext(h.ctx, http.MethodGet, fullUrl, nil)
 	if err != nil {
@@ -84,15 +129,20 @@ func (h *EventStream) Subscribe(eventsChannel chan<- *Event) {
 			EventType: EventConnectionError,
 			Data:      []byte(errors.Wrap(err, "failed to create HTTP request").Error()),
 		}
+		return false
 	}
 	req.Header.Set("Accept", api.EventStreamMediaType)
 	req.Header.Set("Connection", api.KeepAlive)
+	if h.lastEventID != "" {
+		req.Header.Set(api.LastEventIDHeader, h.lastEventID)
+	}
 	resp, err := h.httpClient.Do(req)
 	if err != nil {
 		eventsChannel <- &Event{
 			EventType: EventConnectionError,
 			Data:      []byte(errors.Wrap(err, client.ErrConnectionIssue.Error()).Error()),
 		}
+		return false
 	}
 
 	defer func() {
@@ -100,12 +150,19 @@ func (h *EventStream) Subscribe(eventsChannel chan<- *Event) {
 			log.WithError(closeErr).Error("Failed to close events response body")
 		}
 	}()
+	// The beacon node restarted since the last event was received, so the events in between are lost.
+	if resp.StatusCode == http.StatusConflict && h.lastEventID != "" {
+		log.WithField("lastEventId", h.lastEventID).Warn("Beacon node no longer has the events after the last received event, some events were missed")
+		h.lastEventID = ""
+		return h.ctx.Err() == nil
+	}
 	// Create a new scanner to read lines from the response body
 	scanner := bufio.NewScanner(resp.Body)
 	// Set the split function for the scanning operation
 	scanner.Split(scanLinesWithCarriage)
 
-	var eventType, data string // Variables to store event type and data
+	var eventType, data, id string // Variables to store event type, data and ID
+	resumable := false
 
 	// Iterate over lines of the event stream
 	for scanner.Scan() {
@@ -113,19 +170,23 @@ func (h *EventStream) Subscribe(eventsChannel chan<- *Event) {
 		case <-h.ctx.Done():
 			log.Info("Context canceled, stopping event stream")
 			close(eventsChannel)
-			return
+			return false
 		default:
 			line := scanner.Text()
 			// Handle the event based on your specific format
 			if line == "" {
 				// Empty line indicates the end of an event
+				if id != "" {
+					h.lastEventID = id
+					resumable = true
+				}
 				if eventType != "" && data != "" {
 					// Process the event when both eventType and data are set
 					eventsChannel <- &Event{EventType: eventType, Data: []byte(data)}
 				}
 
-				// Reset eventType and data for the next event
-				eventType, data = "", ""
+				// Reset eventType, data and ID for the next event
+				eventType, data, id = "", "", ""
 				continue
 			}
 			et, ok := strings.CutPrefix(line, "event: ")
@@ -138,13 +199,22 @@ func (h *EventStream) Subscribe(eventsChannel chan<- *Event) {
 				// Extract data from the "data" field
 				data = d
 			}
+			i, ok := strings.CutPrefix(line, "id: ")
+			if ok {
+				// Extract the event ID from the "id" field
+				id = i
+			}
 		}
 	}
 
+	if resumable && h.ctx.Err() == nil {
+		return true
+	}
 	if err := scanner.Err(); err != nil {
 		eventsChannel <- &Event{
 			EventType: EventConnectionError,
 			Data:      []byte(errors.Wrap(err, errors.Wrap(client.ErrConnectionIssue, "scanner failed").Error()).Error()),
 		}
 	}
+	return false
 }
@@ -79,3 +79,66 @@ func TestEventStream(t *testing.T) {
 		}
 	}
 }
+
+func TestEventStream_Resume(t *testing.T) {
+	var lastEventIDs []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/eth/v1/events", func(w http.ResponseWriter, r *http.Request) {
+		lastEventIDs = append(lastEventIDs, r.Header.Get("Last-Event-ID"))
+		switch len(lastEventIDs) {
+		case 1:
+			_, err := fmt.Fprint(w, "id: 1\nevent: head\ndata: data1\n\nid: 2\nevent: head\ndata: data2\n\n")
+			require.NoError(t, err)
+		case 2:
+			_, err := fmt.Fprint(w, "id: 3\nevent: head\ndata: data3\n\n")
+			require.NoError(t, err)
+		}
+		// Returning ends the stream, as when the connection to the beacon node is lost.
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	eventsChannel := make(chan *Event, 3)
+	stream, err := NewEventStream(context.Background(), http.DefaultClient, server.URL, []string{"head"})
+	require.NoError(t, err)
+	stream.resumeBackoff = time.Millisecond
+	// Subscribe returns once a stream ends without delivering new events.
+	stream.Subscribe(eventsChannel)
+
+	require.Equal(t, 3, len(eventsChannel))
+	for i := 1; i <= 3; i++ {
+		event := <-eventsChannel
+		require.Equal(t, fmt.Sprintf("data%d", i), string(event.Data))
+	}
+	require.DeepEqual(t, []string{"", "2", "3"}, lastEventIDs)
+}
+
+func TestEventStream_ResumeAfterRestart(t *testing.T) {
+	var lastEventIDs []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/eth/v1/events", func(w http.ResponseWriter, r *http.Request) {
+		lastEventIDs = append(lastEventIDs, r.Header.Get("Last-Event-ID"))
+		switch len(lastEventIDs) {
+		case 1:
+			_, err := fmt.Fprint(w, "id: a-1\nevent: head\ndata: data1\n\n")
+			require.NoError(t, err)
+		case 2:
+			// The beacon node restarted, so it does not know the ID.
+			w.WriteHeader(http.StatusConflict)
+		case 3:
+			_, err := fmt.Fprint(w, "id: b-1\nevent: head\ndata: data2\n\n")
+			require.NoError(t, err)
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	eventsChannel := make(chan *Event, 2)
+	stream, err := NewEventStream(context.Background(), http.DefaultClient, server.URL, []string{"head"})
+	require.NoError(t, err)
+	stream.resumeBackoff = time.Millisecond
+	stream.Subscribe(eventsChannel)
+
+	require.Equal(t, 2, len(eventsChannel))
+	require.DeepEqual(t, []string{"", "a-1", "", "b-1"}, lastEventIDs)
+}
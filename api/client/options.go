@@ -3,6 +3,7 @@ package client
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"time"
 )
 
@@ -16,6 +17,21 @@ func WithSSZEncoding() ReqOption {
 	}
 }
 
+// WithSSZPreference is a request functional option that asks for an SSZ encoded response, falling back to JSON
+// for endpoints which do not support SSZ.
+func WithSSZPreference() ReqOption {
+	return func(req *http.Request) {
+		req.Header.Set("Accept", "application/octet-stream;q=1.0,application/json;q=0.9")
+	}
+}
+
+// WithQuery is a request functional option that sets the query parameters of the request.
+func WithQuery(q url.Values) ReqOption {
+	return func(req *http.Request) {
+		req.URL.RawQuery = q.Encode()
+	}
+}
+
 // WithAuthorizationToken is a request functional option that adds header for authorization token.
 func WithAuthorizationToken(token string) ReqOption {
 	return func(req *http.Request) {
@@ -46,3 +62,11 @@ func WithAuthenticationToken(token string) ClientOpt {
 		c.token = token
 	}
 }
+
+// WithRequestOptions sets request options which are applied to every request of the client,
+// before the options of the request.
+func WithRequestOptions(opts ...ReqOption) ClientOpt {
+	return func(c *Client) {
+		c.reqOpts = append(c.reqOpts, opts...)
+	}
+}
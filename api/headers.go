@@ -7,6 +7,10 @@ const (
 	ExecutionPayloadBlindedHeader = "Eth-Execution-Payload-Blinded"
 	ExecutionPayloadValueHeader   = "Eth-Execution-Payload-Value"
 	ConsensusBlockValueHeader     = "Eth-Consensus-Block-Value"
+	ExecutionOptimisticHeader     = "Eth-Execution-Optimistic"
+	FinalizedHeader               = "Eth-Finalized"
+	DependentRootHeader           = "Eth-Dependent-Root"
+	LastEventIDHeader             = "Last-Event-ID"
 	JsonMediaType                 = "application/json"
 	OctetStreamMediaType          = "application/octet-stream"
 	EventStreamMediaType          = "text/event-stream"
@@ -0,0 +1,216 @@
+// Package auth authenticates the clients of the beacon node HTTP API with bearer tokens, and limits the rate of
+// their requests.
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	errMissingToken = errors.New("no bearer token in Authorization header")
+	errInvalidToken = errors.New("invalid bearer token")
+)
+
+// Client is the API client identified by the token of a request.
+type Client struct {
+	// Name identifies the client, it is the name of a static token or the subject of a JWT.
+	Name string
+	Role Role
+}
+
+type clientKey struct{}
+
+// ClientFromContext returns the client of an authenticated request.
+func ClientFromContext(ctx context.Context) (*Client, bool) {
+	c, ok := ctx.Value(clientKey{}).(*Client)
+	return c, ok
+}
+
+// Claims are the claims of the JWTs accepted by the authenticator.
+type Claims struct {
+	Role Role `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// NewToken returns a JWT for the given client, signed with the secret. A zero ttl creates a token which does not expire.
+func NewToken(secret []byte, subject string, role Role, ttl time.Duration) (string, error) {
+	if subject == "" {
+		return "", errors.New("token subject is required")
+	}
+	if _, err := ParseRole(string(role)); err != nil {
+		return "", err
+	}
+	now := time.Now()
+	claims := &Claims{
+		Role: role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:  subject,
+			IssuedAt: jwt.NewNumericDate(now),
+		},
+	}
+	if ttl > 0 {
+		claims.ExpiresAt = jwt.NewNumericDate(now.Add(ttl))
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+}
+
+// staticToken is a bearer token listed in the tokens file.
+type staticToken struct {
+	token  []byte
+	client *Client
+}
+
+// tokenFileEntry is an entry of the tokens file.
+type tokenFileEntry struct {
+	Name  string `yaml:"name"`
+	Token string `yaml:"token"`
+	Role  string `yaml:"role"`
+}
+
+// Authenticator identifies the client of API requests from their bearer token, which is either a static token listed
+// in a file or a JWT signed with a secret.
+type Authenticator struct {
+	tokens []staticToken
+	secret []byte
+}
+
+// Option is an authenticator functional parameter type.
+type Option func(a *Authenticator) error
+
+// WithTokensFile reads the static tokens from a YAML file listing the name, token and role of each client.
+func WithTokensFile(path string) Option {
+	return func(a *Authenticator) error {
+		enc, err := file.ReadFileAsBytes(path)
+		if err != nil {
+			return errors.Wrap(err, "could not read tokens file")
+		}
+		var entries []tokenFileEntry
+		if err := yaml.UnmarshalStrict(enc, &entries); err != nil {
+			return errors.Wrap(err, "could not parse tokens file")
+		}
+		names := make(map[string]bool, len(entries))
+		for i, e := range entries {
+			if e.Name == "" || e.Token == "" {
+				return errors.Errorf("entry %d of tokens file must have a name and a token", i)
+			}
+			if names[e.Name] {
+				return errors.Errorf("duplicate token name %s in tokens file", e.Name)
+			}
+			names[e.Name] = true
+			role, err := ParseRole(e.Role)
+			if err != nil {
+				return errors.Wrapf(err, "invalid role of token %s", e.Name)
+			}
+			a.tokens = append(a.tokens, staticToken{token: []byte(e.Token), client: &Client{Name: e.Name, Role: role}})
+		}
+		return nil
+	}
+}
+
+// WithJWTSecret accepts the JWTs signed with the given HMAC secret.
+func WithJWTSecret(secret []byte) Option {
+	return func(a *Authenticator) error {
+		if len(secret) < 32 {
+			return errors.New("JWT secret must be at least 32 bytes")
+		}
+		a.secret = secret
+		return nil
+	}
+}
+
+// NewAuthenticator returns an authenticator accepting the tokens of the given options.
+func NewAuthenticator(opts ...Option) (*Authenticator, error) {
+	a := &Authenticator{}
+	for _, opt := range opts {
+		if err := opt(a); err != nil {
+			return nil, err
+		}
+	}
+	if len(a.tokens) == 0 && a.secret == nil {
+		return nil, errors.New("no static token or JWT secret configured")
+	}
+	return a, nil
+}
+
+// Authenticate returns the client of the bearer token of a request.
+func (a *Authenticator) Authenticate(r *http.Request) (*Client, error) {
+	header := r.Header.Get("Authorization")
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok || strings.TrimSpace(token) == "" {
+		return nil, errMissingToken
+	}
+	token = strings.TrimSpace(token)
+
+	// Every static token is compared, so that the time taken does not depend on which token matched.
+	var client *Client
+	for _, t := range a.tokens {
+		if subtle.ConstantTimeCompare(t.token, []byte(token)) == 1 {
+			client = t.client
+		}
+	}
+	if client != nil {
+		return client, nil
+	}
+	if a.secret == nil {
+		return nil, errInvalidToken
+	}
+	return a.parseJWT(token)
+}
+
+func (a *Authenticator) parseJWT(token string) (*Client, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.Errorf("unexpected signing method %v", t.Header["alg"])
+		}
+		return a.secret, nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(errInvalidToken, err.Error())
+	}
+	if claims.Subject == "" {
+		return nil, errors.Wrap(errInvalidToken, "token has no subject")
+	}
+	role, err := ParseRole(string(claims.Role))
+	if err != nil {
+		return nil, errors.Wrap(errInvalidToken, err.Error())
+	}
+	return &Client{Name: claims.Subject, Role: role}, nil
+}
+
+// Middleware rejects the requests without a valid token, and the requests to routes which the role of their token
+// cannot reach. The client of accepted requests is added to their context.
+func (a *Authenticator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client, err := a.Authenticate(r)
+		if err != nil {
+			reason := "invalid_token"
+			if errors.Is(err, errMissingToken) {
+				reason = "missing_token"
+			}
+			authFailures.WithLabelValues(reason).Inc()
+			log.WithError(err).WithField("path", r.URL.Path).Debug("Rejected unauthenticated API request")
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			httputil.HandleError(w, "Unauthorized: "+err.Error(), http.StatusUnauthorized)
+			return
+		}
+		group := RouteGroupOf(r)
+		if !client.Role.CanAccess(group) {
+			authFailures.WithLabelValues("forbidden").Inc()
+			httputil.HandleError(w, "Forbidden: role "+string(client.Role)+" cannot reach "+group.String()+" routes", http.StatusForbidden)
+			return
+		}
+		authenticatedRequests.WithLabelValues(string(client.Role), group.String()).Inc()
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), clientKey{}, client)))
+	})
+}
@@ -0,0 +1,120 @@
+package auth
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+const testTokensFile = `
+- name: dashboard
+  token: dashboard-token
+  role: read-only
+- name: validator
+  token: validator-token
+  role: validator-duty
+`
+
+func writeTokensFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "tokens.yaml")
+	require.NoError(t, file.WriteFile(path, []byte(content)))
+	return path
+}
+
+func TestNewAuthenticator(t *testing.T) {
+	_, err := NewAuthenticator()
+	assert.ErrorContains(t, "no static token or JWT secret", err)
+	_, err = NewAuthenticator(WithJWTSecret(make([]byte, 31)))
+	assert.ErrorContains(t, "at least 32 bytes", err)
+	_, err = NewAuthenticator(WithTokensFile(writeTokensFile(t, "- name: a\n  token: b\n  role: root\n")))
+	assert.ErrorContains(t, "invalid role of token a", err)
+	_, err = NewAuthenticator(WithTokensFile(writeTokensFile(t, "- name: a\n  token: b\n  role: admin\n- name: a\n  token: c\n  role: admin\n")))
+	assert.ErrorContains(t, "duplicate token name a", err)
+	_, err = NewAuthenticator(WithTokensFile(writeTokensFile(t, "- name: a\n  role: admin\n")))
+	assert.ErrorContains(t, "must have a name and a token", err)
+}
+
+func TestAuthenticator_Middleware(t *testing.T) {
+	secret := bytes.Repeat([]byte{0x42}, 32)
+	a, err := NewAuthenticator(WithTokensFile(writeTokensFile(t, testTokensFile)), WithJWTSecret(secret))
+	require.NoError(t, err)
+	var served *Client
+	handler := a.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, ok := ClientFromContext(r.Context())
+		require.Equal(t, true, ok)
+		served = c
+	}))
+	serve := func(method, path, token string) int {
+		served = nil
+		r := httptest.NewRequest(method, "http://example.com"+path, nil)
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	adminToken, err := NewToken(secret, "operator", RoleAdmin, time.Hour)
+	require.NoError(t, err)
+	expiredToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &Claims{
+		Role: RoleAdmin,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "operator",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Hour)),
+		},
+	}).SignedString(secret)
+	require.NoError(t, err)
+	otherSecretToken, err := NewToken(bytes.Repeat([]byte{0x43}, 32), "operator", RoleAdmin, 0)
+	require.NoError(t, err)
+	noneToken, err := jwt.NewWithClaims(jwt.SigningMethodNone, &Claims{Role: RoleAdmin, RegisteredClaims: jwt.RegisteredClaims{Subject: "operator"}}).
+		SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		code   int
+		client string
+	}{
+		{name: "no token", method: http.MethodGet, path: "/eth/v1/node/version", code: http.StatusUnauthorized},
+		{name: "unknown token", method: http.MethodGet, path: "/eth/v1/node/version", token: "foo", code: http.StatusUnauthorized},
+		{name: "static read", method: http.MethodGet, path: "/eth/v1/node/version", token: "dashboard-token", code: http.StatusOK, client: "dashboard"},
+		{name: "static read-only duty", method: http.MethodGet, path: "/eth/v1/validator/duties/proposer/1", token: "dashboard-token", code: http.StatusForbidden},
+		{name: "static duty", method: http.MethodPost, path: "/eth/v1/beacon/pool/attestations", token: "validator-token", code: http.StatusOK, client: "validator"},
+		{name: "static duty admin", method: http.MethodPost, path: "/prysm/v1/node/trusted_peers", token: "validator-token", code: http.StatusForbidden},
+		{name: "jwt admin", method: http.MethodPost, path: "/prysm/v1/node/trusted_peers", token: adminToken, code: http.StatusOK, client: "operator"},
+		{name: "expired jwt", method: http.MethodGet, path: "/eth/v1/node/version", token: expiredToken, code: http.StatusUnauthorized},
+		{name: "jwt of other secret", method: http.MethodGet, path: "/eth/v1/node/version", token: otherSecretToken, code: http.StatusUnauthorized},
+		{name: "unsigned jwt", method: http.MethodGet, path: "/eth/v1/node/version", token: noneToken, code: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.code, serve(tt.method, tt.path, tt.token))
+			if tt.client != "" {
+				require.NotNil(t, served)
+				assert.Equal(t, tt.client, served.Name)
+			} else {
+				assert.Equal(t, true, served == nil)
+			}
+		})
+	}
+}
+
+func TestNewToken(t *testing.T) {
+	secret := bytes.Repeat([]byte{0x42}, 32)
+	_, err := NewToken(secret, "", RoleAdmin, 0)
+	assert.ErrorContains(t, "subject is required", err)
+	_, err = NewToken(secret, "operator", Role("root"), 0)
+	assert.ErrorContains(t, "unknown role", err)
+}
@@ -0,0 +1,5 @@
+package auth
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "api-auth")
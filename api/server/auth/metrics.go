@@ -0,0 +1,30 @@
+package auth
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	authFailures = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "http_api_auth_failures_total",
+			Help: "Number of API requests rejected by authentication, by reason: missing_token, invalid_token or forbidden.",
+		},
+		[]string{"reason"},
+	)
+	authenticatedRequests = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "http_api_authenticated_requests_total",
+			Help: "Number of authenticated API requests, by role of the token and route group.",
+		},
+		[]string{"role", "group"},
+	)
+	rateLimitedRequests = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "http_api_rate_limited_requests_total",
+			Help: "Number of API requests rejected by the rate limiter, by cost class.",
+		},
+		[]string{"class"},
+	)
+)
@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	leakybucket "github.com/prysmaticlabs/prysm/v5/container/leaky-bucket"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+)
+
+// CostClass is the class of the cost of serving a request. Each class has its own rate limit.
+type CostClass int
+
+const (
+	// CostLight is the class of every route not in another class.
+	CostLight CostClass = iota
+	// CostHeavy is the class of the routes which may load or replay a state, or return large objects.
+	CostHeavy
+)
+
+// String returns the name of the cost class.
+func (c CostClass) String() string {
+	if c == CostHeavy {
+		return "heavy"
+	}
+	return "light"
+}
+
+var heavyRoutes = regexp.MustCompile(
+	`^/eth/v\d+/(beacon/states/|beacon/rewards/|beacon/blob_sidecars/|debug/)|^/prysm/v\d+/(beacon/states/|graphql)`,
+)
+
+// CostClassOf returns the cost class of a request.
+func CostClassOf(r *http.Request) CostClass {
+	if heavyRoutes.MatchString(r.URL.Path) {
+		return CostHeavy
+	}
+	return CostLight
+}
+
+// RateLimiter limits the rate of requests of each client, with a token bucket for each client and cost class.
+// Authenticated clients are identified by name, other clients by IP address.
+type RateLimiter struct {
+	collectors map[CostClass]*leakybucket.Collector
+}
+
+// NewRateLimiter returns a rate limiter allowing each client the given number of requests per second of each cost
+// class, with bursts of up to twice the rate. Classes without a positive rate are not limited.
+func NewRateLimiter(rates map[CostClass]float64) *RateLimiter {
+	l := &RateLimiter{collectors: make(map[CostClass]*leakybucket.Collector)}
+	for class, rate := range rates {
+		if rate <= 0 {
+			continue
+		}
+		burst := int64(math.Max(1, math.Ceil(2*rate)))
+		l.collectors[class] = leakybucket.NewCollector(rate, burst, time.Second, true /* deleteEmptyBuckets */)
+	}
+	return l
+}
+
+// Middleware rejects the requests of clients exceeding the rate of the cost class of the request, with a 429 status
+// and a Retry-After header.
+func (l *RateLimiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		class := CostClassOf(r)
+		collector, ok := l.collectors[class]
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if collector.Add(bucketKey(r), 1) < 1 {
+			rateLimitedRequests.WithLabelValues(class.String()).Inc()
+			// A request can be made again once a single token has leaked from the bucket.
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(1/collector.Rate()))))
+			httputil.HandleError(w, "Too many requests, the rate limit of "+class.String()+" routes is exceeded", http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// bucketKey returns the key of the buckets of the client of a request.
+func bucketKey(r *http.Request) string {
+	if c, ok := ClientFromContext(r.Context()); ok {
+		return "client:" + c.Name
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
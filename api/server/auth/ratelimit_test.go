@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+)
+
+func TestRateLimiter_Middleware(t *testing.T) {
+	l := NewRateLimiter(map[CostClass]float64{CostLight: 0, CostHeavy: 0.5})
+	handler := l.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	serve := func(path, remoteAddr string, client *Client) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com"+path, nil)
+		r.RemoteAddr = remoteAddr
+		if client != nil {
+			r = r.WithContext(context.WithValue(r.Context(), clientKey{}, client))
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	// Light routes are not limited.
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, http.StatusOK, serve("/eth/v1/node/version", "10.0.0.1:1000", nil).Code)
+	}
+	// The burst of heavy routes is a single request.
+	assert.Equal(t, http.StatusOK, serve("/eth/v1/beacon/states/head/validators", "10.0.0.1:1000", nil).Code)
+	w := serve("/eth/v1/debug/beacon/states/head", "10.0.0.1:2000", nil)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "2", w.Header().Get("Retry-After"))
+	// Other IP addresses and clients have their own buckets.
+	assert.Equal(t, http.StatusOK, serve("/eth/v1/beacon/states/head/validators", "10.0.0.2:1000", nil).Code)
+	client := &Client{Name: "dashboard", Role: RoleReadOnly}
+	assert.Equal(t, http.StatusOK, serve("/prysm/v1/graphql", "10.0.0.1:1000", client).Code)
+	assert.Equal(t, http.StatusTooManyRequests, serve("/prysm/v1/graphql", "10.0.0.3:1000", client).Code)
+}
+
+func TestCostClassOf(t *testing.T) {
+	for path, class := range map[string]CostClass{
+		"/eth/v1/node/version":                  CostLight,
+		"/eth/v2/beacon/blocks/head":            CostLight,
+		"/eth/v1/beacon/states/head/validators": CostHeavy,
+		"/eth/v1/beacon/rewards/blocks/head":    CostHeavy,
+		"/eth/v1/beacon/blob_sidecars/head":     CostHeavy,
+		"/eth/v2/debug/beacon/states/head":      CostHeavy,
+		"/prysm/v1/graphql":                     CostHeavy,
+	} {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com"+path, nil)
+		assert.Equal(t, class, CostClassOf(r), path)
+	}
+}
@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Role is the role carried by an API token, which limits the route groups the token can reach.
+type Role string
+
+const (
+	// RoleReadOnly reaches the routes reading chain data.
+	RoleReadOnly Role = "read-only"
+	// RoleValidatorDuty also reaches the routes used by validator clients to perform their duties.
+	RoleValidatorDuty Role = "validator-duty"
+	// RoleAdmin reaches every route.
+	RoleAdmin Role = "admin"
+)
+
+// ParseRole returns the role with the given name.
+func ParseRole(name string) (Role, error) {
+	switch r := Role(strings.ToLower(strings.TrimSpace(name))); r {
+	case RoleReadOnly, RoleValidatorDuty, RoleAdmin:
+		return r, nil
+	default:
+		return "", errors.Errorf("unknown role %q, expected %s, %s or %s", name, RoleReadOnly, RoleValidatorDuty, RoleAdmin)
+	}
+}
+
+// RouteGroup is a group of routes reachable by the same roles.
+type RouteGroup int
+
+const (
+	// GroupRead holds the routes reading chain data, which are every route not in another group.
+	GroupRead RouteGroup = iota
+	// GroupValidatorDuty holds the validator routes, and the routes publishing blocks and pool operations.
+	GroupValidatorDuty
+	// GroupAdmin holds the routes changing the configuration of the node.
+	GroupAdmin
+)
+
+// String returns the name of the route group.
+func (g RouteGroup) String() string {
+	switch g {
+	case GroupValidatorDuty:
+		return "validator-duty"
+	case GroupAdmin:
+		return "admin"
+	default:
+		return "read"
+	}
+}
+
+var (
+	validatorRoutes  = regexp.MustCompile(`^/eth/v\d+/validator/`)
+	publishRoutes    = regexp.MustCompile(`^/eth/v\d+/beacon/(blocks|blinded_blocks|pool/)`)
+	adminRoutes      = regexp.MustCompile(`^/prysm/v\d+/admin/`)
+	trustedPeerRoute = regexp.MustCompile(`^/prysm/(v\d+/)?node/trusted_peers`)
+)
+
+// RouteGroupOf returns the route group of a request.
+func RouteGroupOf(r *http.Request) RouteGroup {
+	path := r.URL.Path
+	switch {
+	case adminRoutes.MatchString(path):
+		return GroupAdmin
+	case r.Method != http.MethodGet && trustedPeerRoute.MatchString(path):
+		return GroupAdmin
+	case validatorRoutes.MatchString(path):
+		return GroupValidatorDuty
+	case r.Method != http.MethodGet && publishRoutes.MatchString(path):
+		return GroupValidatorDuty
+	default:
+		return GroupRead
+	}
+}
+
+// CanAccess returns true if tokens of the role can reach the routes of the group.
+func (r Role) CanAccess(g RouteGroup) bool {
+	switch r {
+	case RoleAdmin:
+		return true
+	case RoleValidatorDuty:
+		return g == GroupRead || g == GroupValidatorDuty
+	case RoleReadOnly:
+		return g == GroupRead
+	default:
+		return false
+	}
+}
@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestParseRole(t *testing.T) {
+	role, err := ParseRole(" Validator-Duty ")
+	require.NoError(t, err)
+	assert.Equal(t, RoleValidatorDuty, role)
+	_, err = ParseRole("root")
+	assert.ErrorContains(t, "unknown role", err)
+}
+
+func TestRouteGroupOf(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		group  RouteGroup
+	}{
+		{method: http.MethodGet, path: "/eth/v1/beacon/states/head/validators", group: GroupRead},
+		{method: http.MethodPost, path: "/eth/v1/beacon/states/head/validators", group: GroupRead},
+		{method: http.MethodGet, path: "/eth/v2/beacon/blocks/head", group: GroupRead},
+		{method: http.MethodPost, path: "/eth/v2/beacon/blocks", group: GroupValidatorDuty},
+		{method: http.MethodPost, path: "/eth/v1/beacon/pool/attestations", group: GroupValidatorDuty},
+		{method: http.MethodGet, path: "/eth/v1/beacon/pool/attestations", group: GroupRead},
+		{method: http.MethodGet, path: "/eth/v1/validator/duties/proposer/1", group: GroupValidatorDuty},
+		{method: http.MethodGet, path: "/prysm/v1/node/trusted_peers", group: GroupRead},
+		{method: http.MethodPost, path: "/prysm/v1/node/trusted_peers", group: GroupAdmin},
+		{method: http.MethodDelete, path: "/prysm/node/trusted_peers/abc", group: GroupAdmin},
+		{method: http.MethodGet, path: "/prysm/v1/admin/peers", group: GroupAdmin},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(tt.method, "http://example.com"+tt.path, nil)
+		assert.Equal(t, tt.group, RouteGroupOf(r), tt.method+" "+tt.path)
+	}
+}
+
+func TestRole_CanAccess(t *testing.T) {
+	assert.Equal(t, true, RoleReadOnly.CanAccess(GroupRead))
+	assert.Equal(t, false, RoleReadOnly.CanAccess(GroupValidatorDuty))
+	assert.Equal(t, false, RoleReadOnly.CanAccess(GroupAdmin))
+	assert.Equal(t, true, RoleValidatorDuty.CanAccess(GroupRead))
+	assert.Equal(t, true, RoleValidatorDuty.CanAccess(GroupValidatorDuty))
+	assert.Equal(t, false, RoleValidatorDuty.CanAccess(GroupAdmin))
+	assert.Equal(t, true, RoleAdmin.CanAccess(GroupAdmin))
+	assert.Equal(t, false, Role("").CanAccess(GroupRead))
+}
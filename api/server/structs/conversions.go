@@ -34,6 +34,47 @@ func ValidatorFromConsensus(v *eth.Validator) *Validator {
 	}
 }
 
+func (v *Validator) ToConsensus() (*eth.Validator, error) {
+	pubkey, err := bytesutil.DecodeHexWithLength(v.Pubkey, fieldparams.BLSPubkeyLength)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Pubkey")
+	}
+	creds, err := bytesutil.DecodeHexWithLength(v.WithdrawalCredentials, fieldparams.RootLength)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "WithdrawalCredentials")
+	}
+	effectiveBalance, err := strconv.ParseUint(v.EffectiveBalance, 10, 64)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "EffectiveBalance")
+	}
+	eligibility, err := strconv.ParseUint(v.ActivationEligibilityEpoch, 10, 64)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "ActivationEligibilityEpoch")
+	}
+	activation, err := strconv.ParseUint(v.ActivationEpoch, 10, 64)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "ActivationEpoch")
+	}
+	exit, err := strconv.ParseUint(v.ExitEpoch, 10, 64)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "ExitEpoch")
+	}
+	withdrawable, err := strconv.ParseUint(v.WithdrawableEpoch, 10, 64)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "WithdrawableEpoch")
+	}
+	return &eth.Validator{
+		PublicKey:                  pubkey,
+		WithdrawalCredentials:      creds,
+		EffectiveBalance:           effectiveBalance,
+		Slashed:                    v.Slashed,
+		ActivationEligibilityEpoch: primitives.Epoch(eligibility),
+		ActivationEpoch:            primitives.Epoch(activation),
+		ExitEpoch:                  primitives.Epoch(exit),
+		WithdrawableEpoch:          primitives.Epoch(withdrawable),
+	}, nil
+}
+
 func PendingAttestationFromConsensus(a *eth.PendingAttestation) *PendingAttestation {
 	return &PendingAttestation{
 		AggregationBits: hexutil.Encode(a.AggregationBits),
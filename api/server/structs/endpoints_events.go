@@ -100,3 +100,17 @@ type LightClientOptimisticUpdateEvent struct {
 	Version string                       `json:"version"`
 	Data    *LightClientOptimisticUpdate `json:"data"`
 }
+
+type ValidatorLifecycleEvent struct {
+	Epoch          string `json:"epoch"`
+	ValidatorIndex string `json:"validator_index"`
+	Pubkey         string `json:"pubkey"`
+	Transition     string `json:"transition"`
+}
+
+type BlockGossipEvent struct {
+	Slot          string `json:"slot"`
+	Block         string `json:"block"`
+	ProposerIndex string `json:"proposer_index"`
+	ArrivalTime   string `json:"arrival_time"`
+}
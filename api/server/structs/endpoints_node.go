@@ -71,3 +71,139 @@ type AddrRequest struct {
 type PeersResponse struct {
 	Peers []*Peer `json:"peers"`
 }
+
+type StorageUsageResponse struct {
+	Data *StorageUsage `json:"data"`
+}
+
+type StorageUsage struct {
+	UpdatedAt string            `json:"updated_at"`
+	Buckets   []*BucketUsage    `json:"buckets"`
+	Blocks    []*ForkUsage      `json:"blocks"`
+	States    []*ForkUsage      `json:"states"`
+	Blobs     []*BlobEpochUsage `json:"blobs"`
+}
+
+type BucketUsage struct {
+	Name  string `json:"name"`
+	Keys  string `json:"keys"`
+	Bytes string `json:"bytes"`
+}
+
+type ForkUsage struct {
+	Fork  string `json:"fork"`
+	Count string `json:"count"`
+	Bytes string `json:"bytes"`
+}
+
+type BlobEpochUsage struct {
+	Epoch string `json:"epoch"`
+	Roots string `json:"roots"`
+	Blobs string `json:"blobs"`
+	Bytes string `json:"bytes"`
+}
+
+type AdminPeersResponse struct {
+	Data []*AdminPeer `json:"data"`
+}
+
+type AdminPeer struct {
+	PeerId     string      `json:"peer_id"`
+	Enr        string      `json:"enr"`
+	Address    string      `json:"address"`
+	State      string      `json:"state"`
+	Direction  string      `json:"direction"`
+	Trusted    bool        `json:"trusted"`
+	Bad        bool        `json:"bad"`
+	BanExpiry  string      `json:"ban_expiry"`
+	Scores     *PeerScores `json:"scores"`
+	MeshTopics []string    `json:"mesh_topics"`
+}
+
+type PeerScores struct {
+	Total                  string `json:"total"`
+	BadResponses           string `json:"bad_responses"`
+	BlockProvider          string `json:"block_provider"`
+	PeerStatus             string `json:"peer_status"`
+	Gossip                 string `json:"gossip"`
+	GossipBehaviourPenalty string `json:"gossip_behaviour_penalty"`
+}
+
+type BanPeerRequest struct {
+	PeerId   string `json:"peer_id"`
+	Cidr     string `json:"cidr"`
+	Duration string `json:"duration"`
+}
+
+type PeerBansResponse struct {
+	Data *PeerBans `json:"data"`
+}
+
+type PeerBans struct {
+	Peers   []*PeerBan   `json:"peers"`
+	Subnets []*SubnetBan `json:"subnets"`
+}
+
+type PeerBan struct {
+	PeerId string `json:"peer_id"`
+	Expiry string `json:"expiry"`
+}
+
+type SubnetBan struct {
+	Cidr   string `json:"cidr"`
+	Expiry string `json:"expiry"`
+}
+
+type DisconnectPeerRequest struct {
+	PeerId string `json:"peer_id"`
+	Code   string `json:"code"`
+}
+
+type AddStaticPeerRequest struct {
+	Addr     string `json:"addr"`
+	Duration string `json:"duration"`
+}
+
+type GossipDiagnosticsResponse struct {
+	Data *GossipDiagnostics `json:"data"`
+}
+
+type GossipDiagnostics struct {
+	Time          string                    `json:"time"`
+	WindowSeconds string                    `json:"window_seconds"`
+	IwantReceived string                    `json:"iwant_received"`
+	IwantSent     string                    `json:"iwant_sent"`
+	Topics        []*GossipTopicDiagnostics `json:"topics"`
+	ScoresTime    string                    `json:"scores_time"`
+	PeerScores    []*GossipPeerScore        `json:"peer_scores"`
+}
+
+type GossipTopicDiagnostics struct {
+	Topic               string   `json:"topic"`
+	Mesh                []string `json:"mesh"`
+	Delivered           string   `json:"delivered"`
+	Duplicates          string   `json:"duplicates"`
+	Late                string   `json:"late"`
+	Rejected            string   `json:"rejected"`
+	DuplicatesPerSecond string   `json:"duplicates_per_second"`
+	LatePerSecond       string   `json:"late_per_second"`
+	IhaveReceived       string   `json:"ihave_received"`
+	IhaveSent           string   `json:"ihave_sent"`
+}
+
+type GossipPeerScore struct {
+	PeerId             string              `json:"peer_id"`
+	Score              string              `json:"score"`
+	AppSpecificScore   string              `json:"app_specific_score"`
+	IpColocationFactor string              `json:"ip_colocation_factor"`
+	BehaviourPenalty   string              `json:"behaviour_penalty"`
+	Topics             []*GossipTopicScore `json:"topics"`
+}
+
+type GossipTopicScore struct {
+	Topic                    string `json:"topic"`
+	TimeInMeshSeconds        string `json:"time_in_mesh_seconds"`
+	FirstMessageDeliveries   string `json:"first_message_deliveries"`
+	MeshMessageDeliveries    string `json:"mesh_message_deliveries"`
+	InvalidMessageDeliveries string `json:"invalid_message_deliveries"`
+}
@@ -102,6 +102,21 @@ type ValidatorCount struct {
 	Count  string `json:"count"`
 }
 
+type QueryValidatorsResponse struct {
+	Epoch         string              `json:"epoch"`
+	PreviousEpoch string              `json:"previous_epoch"`
+	Data          []*QueriedValidator `json:"data"`
+}
+
+type QueriedValidator struct {
+	Index                 string `json:"index"`
+	Pubkey                string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	EffectiveBalance      string `json:"effective_balance"`
+	Status                string `json:"status"`
+	PreviousStatus        string `json:"previous_status,omitempty"`
+}
+
 type GetValidatorPerformanceRequest struct {
 	PublicKeys [][]byte                    `json:"public_keys,omitempty"`
 	Indices    []primitives.ValidatorIndex `json:"indices,omitempty"`
@@ -149,3 +164,23 @@ type ActiveSetChanges struct {
 	EjectedPublicKeys   []string `json:"ejected_public_keys"`
 	EjectedIndices      []string `json:"ejected_indices"`
 }
+
+type GetValidatorHistoryResponse struct {
+	ValidatorIndex string                  `json:"validator_index"`
+	Data           []*ValidatorEpochRecord `json:"data"`
+}
+
+type ValidatorEpochRecord struct {
+	Epoch            string `json:"epoch"`
+	Active           bool   `json:"active"`
+	CorrectSource    bool   `json:"correct_source"`
+	CorrectTarget    bool   `json:"correct_target"`
+	CorrectHead      bool   `json:"correct_head"`
+	Included         bool   `json:"included"`
+	InclusionDelay   string `json:"inclusion_delay"`
+	DutiesKnown      bool   `json:"duties_known"`
+	BlocksProposed   string `json:"blocks_proposed"`
+	BlocksMissed     string `json:"blocks_missed"`
+	SyncParticipated string `json:"sync_participated"`
+	SyncMissed       string `json:"sync_missed"`
+}
@@ -0,0 +1,875 @@
+package structs
+
+import (
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	ssz "github.com/prysmaticlabs/fastssz"
+	"github.com/prysmaticlabs/prysm/v5/api/server"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/validator"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+)
+
+// The ssz encoding of a list endpoint is the list of the ssz encodings of the elements of its data field. The other
+// fields of the JSON response are returned in the headers of the response, as the elements of a list are not
+// wrapped in a container. The data of the sync committees response is a container, and is encoded as such.
+
+// SSZElement is implemented by the ssz encodings of the elements of list responses.
+type SSZElement interface {
+	SizeSSZ() int
+	MarshalSSZTo(dst []byte) ([]byte, error)
+	UnmarshalSSZ(buf []byte) error
+	// fixedSizeSSZ returns the size of the encoding of the element, or zero if the size is variable.
+	fixedSizeSSZ() int
+}
+
+// MarshalSSZList converts the elements of a JSON list response and returns the ssz encoding of the list.
+func MarshalSSZList[T any, S SSZElement](items []T, toSSZ func(T) (S, error)) ([]byte, error) {
+	elems := make([]S, len(items))
+	size := 0
+	for i, item := range items {
+		e, err := toSSZ(item)
+		if err != nil {
+			return nil, server.NewDecodeError(err, "Data["+strconv.Itoa(i)+"]")
+		}
+		elems[i] = e
+		size += e.SizeSSZ()
+		if e.fixedSizeSSZ() == 0 {
+			size += 4
+		}
+	}
+	dst := make([]byte, 0, size)
+	if len(elems) > 0 && elems[0].fixedSizeSSZ() == 0 {
+		// Variable size elements are preceded by a table of their offsets.
+		offset := 4 * len(elems)
+		for _, e := range elems {
+			dst = ssz.WriteOffset(dst, offset)
+			offset += e.SizeSSZ()
+		}
+	}
+	var err error
+	for _, e := range elems {
+		if dst, err = e.MarshalSSZTo(dst); err != nil {
+			return nil, err
+		}
+	}
+	return dst, nil
+}
+
+// UnmarshalSSZList decodes the ssz encoding of a list response and converts its elements.
+func UnmarshalSSZList[S any, PS interface {
+	*S
+	SSZElement
+}, T any](buf []byte, fromSSZ func(PS) T) ([]T, error) {
+	var parts [][]byte
+	if size := PS(new(S)).fixedSizeSSZ(); size > 0 {
+		n, err := ssz.DivideInt2(len(buf), size, len(buf)/size+1)
+		if err != nil {
+			return nil, err
+		}
+		parts = make([][]byte, n)
+		for i := range parts {
+			parts[i] = buf[i*size : (i+1)*size]
+		}
+	} else {
+		var err error
+		if parts, err = splitOffsets(buf); err != nil {
+			return nil, err
+		}
+	}
+	items := make([]T, len(parts))
+	for i, p := range parts {
+		e := PS(new(S))
+		if err := e.UnmarshalSSZ(p); err != nil {
+			return nil, errors.Wrapf(err, "could not decode element %d", i)
+		}
+		items[i] = fromSSZ(e)
+	}
+	return items, nil
+}
+
+// splitOffsets splits the ssz encoding of a list of variable size elements, which starts with the table of their
+// offsets.
+func splitOffsets(buf []byte) ([][]byte, error) {
+	if len(buf) == 0 {
+		return nil, nil
+	}
+	if len(buf) < 4 {
+		return nil, ssz.ErrSize
+	}
+	first := ssz.ReadOffset(buf)
+	if first%4 != 0 || first == 0 || first > uint64(len(buf)) {
+		return nil, ssz.ErrOffset
+	}
+	n := int(first / 4)
+	parts := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		start := ssz.ReadOffset(buf[i*4:])
+		end := uint64(len(buf))
+		if i+1 < n {
+			end = ssz.ReadOffset(buf[(i+1)*4:])
+		}
+		if start > end || end > uint64(len(buf)) {
+			return nil, ssz.ErrOffset
+		}
+		parts[i] = buf[start:end]
+	}
+	return parts, nil
+}
+
+// marshalUint64List appends the ssz encoding of a list of uint64 given as decimal strings.
+func marshalUint64List(dst []byte, values []string, name string) ([]byte, error) {
+	for i, v := range values {
+		u, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return nil, server.NewDecodeError(err, name+"["+strconv.Itoa(i)+"]")
+		}
+		dst = ssz.MarshalUint64(dst, u)
+	}
+	return dst, nil
+}
+
+func unmarshalUint64List(buf []byte) ([]uint64, error) {
+	if len(buf)%8 != 0 {
+		return nil, ssz.ErrSize
+	}
+	values := make([]uint64, len(buf)/8)
+	for i := range values {
+		values[i] = ssz.UnmarshallUint64(buf[i*8:])
+	}
+	return values, nil
+}
+
+func unmarshalBool(b byte) (bool, error) {
+	if b > 1 {
+		return false, ssz.ErrInvalidEncoding
+	}
+	return b == 1, nil
+}
+
+func formatUint64List(values []uint64) []string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = strconv.FormatUint(v, 10)
+	}
+	return s
+}
+
+// ValidatorContainerSSZ is the ssz encoding of an element of the validators response.
+// Status is the validator.Status of the validator.
+type ValidatorContainerSSZ struct {
+	Index     uint64
+	Balance   uint64
+	Status    uint8
+	Validator *eth.Validator
+}
+
+const validatorContainerSSZSize = 8 + 8 + 1 + 121
+
+func (*ValidatorContainerSSZ) fixedSizeSSZ() int { return validatorContainerSSZSize }
+
+// SizeSSZ returns the size of the ssz encoding.
+func (*ValidatorContainerSSZ) SizeSSZ() int { return validatorContainerSSZSize }
+
+// MarshalSSZTo appends the ssz encoding to dst.
+func (c *ValidatorContainerSSZ) MarshalSSZTo(dst []byte) ([]byte, error) {
+	dst = ssz.MarshalUint64(dst, c.Index)
+	dst = ssz.MarshalUint64(dst, c.Balance)
+	dst = ssz.MarshalUint8(dst, c.Status)
+	if c.Validator == nil {
+		c.Validator = &eth.Validator{}
+	}
+	return c.Validator.MarshalSSZTo(dst)
+}
+
+// UnmarshalSSZ decodes the ssz encoding.
+func (c *ValidatorContainerSSZ) UnmarshalSSZ(buf []byte) error {
+	if len(buf) != validatorContainerSSZSize {
+		return ssz.ErrSize
+	}
+	c.Index = ssz.UnmarshallUint64(buf[0:8])
+	c.Balance = ssz.UnmarshallUint64(buf[8:16])
+	c.Status = ssz.UnmarshallUint8(buf[16:17])
+	c.Validator = &eth.Validator{}
+	return c.Validator.UnmarshalSSZ(buf[17:])
+}
+
+// ToSSZ converts the container to its ssz encoding.
+func (c *ValidatorContainer) ToSSZ() (*ValidatorContainerSSZ, error) {
+	index, err := strconv.ParseUint(c.Index, 10, 64)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Index")
+	}
+	balance, err := strconv.ParseUint(c.Balance, 10, 64)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Balance")
+	}
+	ok, status := validator.StatusFromString(c.Status)
+	if !ok || status > validator.WithdrawalDone {
+		return nil, server.NewDecodeError(errors.Errorf("invalid status %s", c.Status), "Status")
+	}
+	if c.Validator == nil {
+		return nil, server.NewDecodeError(errNilValue, "Validator")
+	}
+	v, err := c.Validator.ToConsensus()
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Validator")
+	}
+	return &ValidatorContainerSSZ{Index: index, Balance: balance, Status: uint8(status), Validator: v}, nil
+}
+
+// ValidatorContainerFromSSZ converts the ssz encoding of a container.
+func ValidatorContainerFromSSZ(c *ValidatorContainerSSZ) *ValidatorContainer {
+	return &ValidatorContainer{
+		Index:     strconv.FormatUint(c.Index, 10),
+		Balance:   strconv.FormatUint(c.Balance, 10),
+		Status:    validator.Status(c.Status).String(),
+		Validator: ValidatorFromConsensus(c.Validator),
+	}
+}
+
+// ValidatorBalanceSSZ is the ssz encoding of an element of the validator balances response.
+type ValidatorBalanceSSZ struct {
+	Index   uint64
+	Balance uint64
+}
+
+const validatorBalanceSSZSize = 16
+
+func (*ValidatorBalanceSSZ) fixedSizeSSZ() int { return validatorBalanceSSZSize }
+
+// SizeSSZ returns the size of the ssz encoding.
+func (*ValidatorBalanceSSZ) SizeSSZ() int { return validatorBalanceSSZSize }
+
+// MarshalSSZTo appends the ssz encoding to dst.
+func (b *ValidatorBalanceSSZ) MarshalSSZTo(dst []byte) ([]byte, error) {
+	dst = ssz.MarshalUint64(dst, b.Index)
+	return ssz.MarshalUint64(dst, b.Balance), nil
+}
+
+// UnmarshalSSZ decodes the ssz encoding.
+func (b *ValidatorBalanceSSZ) UnmarshalSSZ(buf []byte) error {
+	if len(buf) != validatorBalanceSSZSize {
+		return ssz.ErrSize
+	}
+	b.Index = ssz.UnmarshallUint64(buf[0:8])
+	b.Balance = ssz.UnmarshallUint64(buf[8:16])
+	return nil
+}
+
+// ToSSZ converts the balance to its ssz encoding.
+func (b *ValidatorBalance) ToSSZ() (*ValidatorBalanceSSZ, error) {
+	index, err := strconv.ParseUint(b.Index, 10, 64)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Index")
+	}
+	balance, err := strconv.ParseUint(b.Balance, 10, 64)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Balance")
+	}
+	return &ValidatorBalanceSSZ{Index: index, Balance: balance}, nil
+}
+
+// ValidatorBalanceFromSSZ converts the ssz encoding of a balance.
+func ValidatorBalanceFromSSZ(b *ValidatorBalanceSSZ) *ValidatorBalance {
+	return &ValidatorBalance{
+		Index:   strconv.FormatUint(b.Index, 10),
+		Balance: strconv.FormatUint(b.Balance, 10),
+	}
+}
+
+// CommitteeSSZ is the ssz encoding of an element of the committees response.
+type CommitteeSSZ struct {
+	Index      uint64
+	Slot       uint64
+	Validators []uint64
+}
+
+func (*CommitteeSSZ) fixedSizeSSZ() int { return 0 }
+
+// SizeSSZ returns the size of the ssz encoding.
+func (c *CommitteeSSZ) SizeSSZ() int { return 8 + 8 + 4 + 8*len(c.Validators) }
+
+// MarshalSSZTo appends the ssz encoding to dst.
+func (c *CommitteeSSZ) MarshalSSZTo(dst []byte) ([]byte, error) {
+	dst = ssz.MarshalUint64(dst, c.Index)
+	dst = ssz.MarshalUint64(dst, c.Slot)
+	dst = ssz.WriteOffset(dst, 20)
+	for _, v := range c.Validators {
+		dst = ssz.MarshalUint64(dst, v)
+	}
+	return dst, nil
+}
+
+// UnmarshalSSZ decodes the ssz encoding.
+func (c *CommitteeSSZ) UnmarshalSSZ(buf []byte) error {
+	if len(buf) < 20 {
+		return ssz.ErrSize
+	}
+	if ssz.ReadOffset(buf[16:20]) != 20 {
+		return ssz.ErrOffset
+	}
+	c.Index = ssz.UnmarshallUint64(buf[0:8])
+	c.Slot = ssz.UnmarshallUint64(buf[8:16])
+	var err error
+	c.Validators, err = unmarshalUint64List(buf[20:])
+	return err
+}
+
+// ToSSZ converts the committee to its ssz encoding.
+func (c *Committee) ToSSZ() (*CommitteeSSZ, error) {
+	index, err := strconv.ParseUint(c.Index, 10, 64)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Index")
+	}
+	slot, err := strconv.ParseUint(c.Slot, 10, 64)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Slot")
+	}
+	enc, err := marshalUint64List(nil, c.Validators, "Validators")
+	if err != nil {
+		return nil, err
+	}
+	validators, err := unmarshalUint64List(enc)
+	if err != nil {
+		return nil, err
+	}
+	return &CommitteeSSZ{Index: index, Slot: slot, Validators: validators}, nil
+}
+
+// CommitteeFromSSZ converts the ssz encoding of a committee.
+func CommitteeFromSSZ(c *CommitteeSSZ) *Committee {
+	return &Committee{
+		Index:      strconv.FormatUint(c.Index, 10),
+		Slot:       strconv.FormatUint(c.Slot, 10),
+		Validators: formatUint64List(c.Validators),
+	}
+}
+
+// AttesterDutySSZ is the ssz encoding of an element of the attester duties response.
+type AttesterDutySSZ struct {
+	Pubkey                  [fieldparams.BLSPubkeyLength]byte
+	ValidatorIndex          uint64
+	CommitteeIndex          uint64
+	CommitteeLength         uint64
+	CommitteesAtSlot        uint64
+	ValidatorCommitteeIndex uint64
+	Slot                    uint64
+}
+
+const attesterDutySSZSize = fieldparams.BLSPubkeyLength + 6*8
+
+func (*AttesterDutySSZ) fixedSizeSSZ() int { return attesterDutySSZSize }
+
+// SizeSSZ returns the size of the ssz encoding.
+func (*AttesterDutySSZ) SizeSSZ() int { return attesterDutySSZSize }
+
+// MarshalSSZTo appends the ssz encoding to dst.
+func (d *AttesterDutySSZ) MarshalSSZTo(dst []byte) ([]byte, error) {
+	dst = append(dst, d.Pubkey[:]...)
+	dst = ssz.MarshalUint64(dst, d.ValidatorIndex)
+	dst = ssz.MarshalUint64(dst, d.CommitteeIndex)
+	dst = ssz.MarshalUint64(dst, d.CommitteeLength)
+	dst = ssz.MarshalUint64(dst, d.CommitteesAtSlot)
+	dst = ssz.MarshalUint64(dst, d.ValidatorCommitteeIndex)
+	return ssz.MarshalUint64(dst, d.Slot), nil
+}
+
+// UnmarshalSSZ decodes the ssz encoding.
+func (d *AttesterDutySSZ) UnmarshalSSZ(buf []byte) error {
+	if len(buf) != attesterDutySSZSize {
+		return ssz.ErrSize
+	}
+	copy(d.Pubkey[:], buf)
+	buf = buf[fieldparams.BLSPubkeyLength:]
+	d.ValidatorIndex = ssz.UnmarshallUint64(buf[0:8])
+	d.CommitteeIndex = ssz.UnmarshallUint64(buf[8:16])
+	d.CommitteeLength = ssz.UnmarshallUint64(buf[16:24])
+	d.CommitteesAtSlot = ssz.UnmarshallUint64(buf[24:32])
+	d.ValidatorCommitteeIndex = ssz.UnmarshallUint64(buf[32:40])
+	d.Slot = ssz.UnmarshallUint64(buf[40:48])
+	return nil
+}
+
+// ToSSZ converts the duty to its ssz encoding.
+func (d *AttesterDuty) ToSSZ() (*AttesterDutySSZ, error) {
+	pubkey, err := bytesutil.DecodeHexWithLength(d.Pubkey, fieldparams.BLSPubkeyLength)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Pubkey")
+	}
+	enc, err := marshalUint64List(nil, []string{
+		d.ValidatorIndex, d.CommitteeIndex, d.CommitteeLength, d.CommitteesAtSlot, d.ValidatorCommitteeIndex, d.Slot,
+	}, "Duty")
+	if err != nil {
+		return nil, err
+	}
+	duty := &AttesterDutySSZ{}
+	if err := duty.UnmarshalSSZ(append(pubkey, enc...)); err != nil {
+		return nil, err
+	}
+	return duty, nil
+}
+
+// AttesterDutyFromSSZ converts the ssz encoding of a duty.
+func AttesterDutyFromSSZ(d *AttesterDutySSZ) *AttesterDuty {
+	return &AttesterDuty{
+		Pubkey:                  hexutil.Encode(d.Pubkey[:]),
+		ValidatorIndex:          strconv.FormatUint(d.ValidatorIndex, 10),
+		CommitteeIndex:          strconv.FormatUint(d.CommitteeIndex, 10),
+		CommitteeLength:         strconv.FormatUint(d.CommitteeLength, 10),
+		CommitteesAtSlot:        strconv.FormatUint(d.CommitteesAtSlot, 10),
+		ValidatorCommitteeIndex: strconv.FormatUint(d.ValidatorCommitteeIndex, 10),
+		Slot:                    strconv.FormatUint(d.Slot, 10),
+	}
+}
+
+// ProposerDutySSZ is the ssz encoding of an element of the proposer duties response.
+type ProposerDutySSZ struct {
+	Pubkey         [fieldparams.BLSPubkeyLength]byte
+	ValidatorIndex uint64
+	Slot           uint64
+}
+
+const proposerDutySSZSize = fieldparams.BLSPubkeyLength + 2*8
+
+func (*ProposerDutySSZ) fixedSizeSSZ() int { return proposerDutySSZSize }
+
+// SizeSSZ returns the size of the ssz encoding.
+func (*ProposerDutySSZ) SizeSSZ() int { return proposerDutySSZSize }
+
+// MarshalSSZTo appends the ssz encoding to dst.
+func (d *ProposerDutySSZ) MarshalSSZTo(dst []byte) ([]byte, error) {
+	dst = append(dst, d.Pubkey[:]...)
+	dst = ssz.MarshalUint64(dst, d.ValidatorIndex)
+	return ssz.MarshalUint64(dst, d.Slot), nil
+}
+
+// UnmarshalSSZ decodes the ssz encoding.
+func (d *ProposerDutySSZ) UnmarshalSSZ(buf []byte) error {
+	if len(buf) != proposerDutySSZSize {
+		return ssz.ErrSize
+	}
+	copy(d.Pubkey[:], buf)
+	buf = buf[fieldparams.BLSPubkeyLength:]
+	d.ValidatorIndex = ssz.UnmarshallUint64(buf[0:8])
+	d.Slot = ssz.UnmarshallUint64(buf[8:16])
+	return nil
+}
+
+// ToSSZ converts the duty to its ssz encoding.
+func (d *ProposerDuty) ToSSZ() (*ProposerDutySSZ, error) {
+	pubkey, err := bytesutil.DecodeHexWithLength(d.Pubkey, fieldparams.BLSPubkeyLength)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Pubkey")
+	}
+	index, err := strconv.ParseUint(d.ValidatorIndex, 10, 64)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "ValidatorIndex")
+	}
+	slot, err := strconv.ParseUint(d.Slot, 10, 64)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Slot")
+	}
+	return &ProposerDutySSZ{Pubkey: bytesutil.ToBytes48(pubkey), ValidatorIndex: index, Slot: slot}, nil
+}
+
+// ProposerDutyFromSSZ converts the ssz encoding of a duty.
+func ProposerDutyFromSSZ(d *ProposerDutySSZ) *ProposerDuty {
+	return &ProposerDuty{
+		Pubkey:         hexutil.Encode(d.Pubkey[:]),
+		ValidatorIndex: strconv.FormatUint(d.ValidatorIndex, 10),
+		Slot:           strconv.FormatUint(d.Slot, 10),
+	}
+}
+
+// SyncCommitteeDutySSZ is the ssz encoding of an element of the sync committee duties response.
+type SyncCommitteeDutySSZ struct {
+	Pubkey                        [fieldparams.BLSPubkeyLength]byte
+	ValidatorIndex                uint64
+	ValidatorSyncCommitteeIndices []uint64
+}
+
+const syncCommitteeDutySSZFixedSize = fieldparams.BLSPubkeyLength + 8 + 4
+
+func (*SyncCommitteeDutySSZ) fixedSizeSSZ() int { return 0 }
+
+// SizeSSZ returns the size of the ssz encoding.
+func (d *SyncCommitteeDutySSZ) SizeSSZ() int {
+	return syncCommitteeDutySSZFixedSize + 8*len(d.ValidatorSyncCommitteeIndices)
+}
+
+// MarshalSSZTo appends the ssz encoding to dst.
+func (d *SyncCommitteeDutySSZ) MarshalSSZTo(dst []byte) ([]byte, error) {
+	dst = append(dst, d.Pubkey[:]...)
+	dst = ssz.MarshalUint64(dst, d.ValidatorIndex)
+	dst = ssz.WriteOffset(dst, syncCommitteeDutySSZFixedSize)
+	for _, i := range d.ValidatorSyncCommitteeIndices {
+		dst = ssz.MarshalUint64(dst, i)
+	}
+	return dst, nil
+}
+
+// UnmarshalSSZ decodes the ssz encoding.
+func (d *SyncCommitteeDutySSZ) UnmarshalSSZ(buf []byte) error {
+	if len(buf) < syncCommitteeDutySSZFixedSize {
+		return ssz.ErrSize
+	}
+	if ssz.ReadOffset(buf[fieldparams.BLSPubkeyLength+8:]) != syncCommitteeDutySSZFixedSize {
+		return ssz.ErrOffset
+	}
+	copy(d.Pubkey[:], buf)
+	d.ValidatorIndex = ssz.UnmarshallUint64(buf[fieldparams.BLSPubkeyLength:])
+	var err error
+	d.ValidatorSyncCommitteeIndices, err = unmarshalUint64List(buf[syncCommitteeDutySSZFixedSize:])
+	return err
+}
+
+// ToSSZ converts the duty to its ssz encoding.
+func (d *SyncCommitteeDuty) ToSSZ() (*SyncCommitteeDutySSZ, error) {
+	pubkey, err := bytesutil.DecodeHexWithLength(d.Pubkey, fieldparams.BLSPubkeyLength)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Pubkey")
+	}
+	index, err := strconv.ParseUint(d.ValidatorIndex, 10, 64)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "ValidatorIndex")
+	}
+	enc, err := marshalUint64List(nil, d.ValidatorSyncCommitteeIndices, "ValidatorSyncCommitteeIndices")
+	if err != nil {
+		return nil, err
+	}
+	indices, err := unmarshalUint64List(enc)
+	if err != nil {
+		return nil, err
+	}
+	return &SyncCommitteeDutySSZ{Pubkey: bytesutil.ToBytes48(pubkey), ValidatorIndex: index, ValidatorSyncCommitteeIndices: indices}, nil
+}
+
+// SyncCommitteeDutyFromSSZ converts the ssz encoding of a duty.
+func SyncCommitteeDutyFromSSZ(d *SyncCommitteeDutySSZ) *SyncCommitteeDuty {
+	return &SyncCommitteeDuty{
+		Pubkey:                        hexutil.Encode(d.Pubkey[:]),
+		ValidatorIndex:                strconv.FormatUint(d.ValidatorIndex, 10),
+		ValidatorSyncCommitteeIndices: formatUint64List(d.ValidatorSyncCommitteeIndices),
+	}
+}
+
+// AttestationSSZ is the ssz encoding of an element of the pool attestations response before electra.
+type AttestationSSZ struct {
+	*eth.Attestation
+}
+
+func (*AttestationSSZ) fixedSizeSSZ() int { return 0 }
+
+// UnmarshalSSZ decodes the ssz encoding.
+func (a *AttestationSSZ) UnmarshalSSZ(buf []byte) error {
+	a.Attestation = &eth.Attestation{}
+	return a.Attestation.UnmarshalSSZ(buf)
+}
+
+// ToSSZ converts the attestation to its ssz encoding.
+func (a *Attestation) ToSSZ() (*AttestationSSZ, error) {
+	att, err := a.ToConsensus()
+	if err != nil {
+		return nil, err
+	}
+	return &AttestationSSZ{Attestation: att}, nil
+}
+
+// AttestationFromSSZ converts the ssz encoding of an attestation.
+func AttestationFromSSZ(a *AttestationSSZ) *Attestation {
+	return AttFromConsensus(a.Attestation)
+}
+
+// AttestationElectraSSZ is the ssz encoding of an element of the pool attestations response since electra.
+type AttestationElectraSSZ struct {
+	*eth.AttestationElectra
+}
+
+func (*AttestationElectraSSZ) fixedSizeSSZ() int { return 0 }
+
+// UnmarshalSSZ decodes the ssz encoding.
+func (a *AttestationElectraSSZ) UnmarshalSSZ(buf []byte) error {
+	a.AttestationElectra = &eth.AttestationElectra{}
+	return a.AttestationElectra.UnmarshalSSZ(buf)
+}
+
+// ToSSZ converts the attestation to its ssz encoding.
+func (a *AttestationElectra) ToSSZ() (*AttestationElectraSSZ, error) {
+	att, err := a.ToConsensus()
+	if err != nil {
+		return nil, err
+	}
+	return &AttestationElectraSSZ{AttestationElectra: att}, nil
+}
+
+// AttestationElectraFromSSZ converts the ssz encoding of an attestation.
+func AttestationElectraFromSSZ(a *AttestationElectraSSZ) *AttestationElectra {
+	return AttElectraFromConsensus(a.AttestationElectra)
+}
+
+// AttesterSlashingSSZ is the ssz encoding of an element of the pool attester slashings response before electra.
+type AttesterSlashingSSZ struct {
+	*eth.AttesterSlashing
+}
+
+func (*AttesterSlashingSSZ) fixedSizeSSZ() int { return 0 }
+
+// UnmarshalSSZ decodes the ssz encoding.
+func (s *AttesterSlashingSSZ) UnmarshalSSZ(buf []byte) error {
+	s.AttesterSlashing = &eth.AttesterSlashing{}
+	return s.AttesterSlashing.UnmarshalSSZ(buf)
+}
+
+// ToSSZ converts the slashing to its ssz encoding.
+func (s *AttesterSlashing) ToSSZ() (*AttesterSlashingSSZ, error) {
+	slashing, err := s.ToConsensus()
+	if err != nil {
+		return nil, err
+	}
+	return &AttesterSlashingSSZ{AttesterSlashing: slashing}, nil
+}
+
+// AttesterSlashingFromSSZ converts the ssz encoding of a slashing.
+func AttesterSlashingFromSSZ(s *AttesterSlashingSSZ) *AttesterSlashing {
+	return AttesterSlashingFromConsensus(s.AttesterSlashing)
+}
+
+// AttesterSlashingElectraSSZ is the ssz encoding of an element of the pool attester slashings response since electra.
+type AttesterSlashingElectraSSZ struct {
+	*eth.AttesterSlashingElectra
+}
+
+func (*AttesterSlashingElectraSSZ) fixedSizeSSZ() int { return 0 }
+
+// UnmarshalSSZ decodes the ssz encoding.
+func (s *AttesterSlashingElectraSSZ) UnmarshalSSZ(buf []byte) error {
+	s.AttesterSlashingElectra = &eth.AttesterSlashingElectra{}
+	return s.AttesterSlashingElectra.UnmarshalSSZ(buf)
+}
+
+// ToSSZ converts the slashing to its ssz encoding.
+func (s *AttesterSlashingElectra) ToSSZ() (*AttesterSlashingElectraSSZ, error) {
+	slashing, err := s.ToConsensus()
+	if err != nil {
+		return nil, err
+	}
+	return &AttesterSlashingElectraSSZ{AttesterSlashingElectra: slashing}, nil
+}
+
+// AttesterSlashingElectraFromSSZ converts the ssz encoding of a slashing.
+func AttesterSlashingElectraFromSSZ(s *AttesterSlashingElectraSSZ) *AttesterSlashingElectra {
+	return AttesterSlashingElectraFromConsensus(s.AttesterSlashingElectra)
+}
+
+// BlockHeaderContainerSSZ is the ssz encoding of an element of the block headers response.
+type BlockHeaderContainerSSZ struct {
+	Root      [fieldparams.RootLength]byte
+	Canonical bool
+	Header    *eth.SignedBeaconBlockHeader
+}
+
+const blockHeaderContainerSSZSize = fieldparams.RootLength + 1 + 208
+
+func (*BlockHeaderContainerSSZ) fixedSizeSSZ() int { return blockHeaderContainerSSZSize }
+
+// SizeSSZ returns the size of the ssz encoding.
+func (*BlockHeaderContainerSSZ) SizeSSZ() int { return blockHeaderContainerSSZSize }
+
+// MarshalSSZTo appends the ssz encoding to dst.
+func (c *BlockHeaderContainerSSZ) MarshalSSZTo(dst []byte) ([]byte, error) {
+	dst = append(dst, c.Root[:]...)
+	dst = ssz.MarshalBool(dst, c.Canonical)
+	if c.Header == nil {
+		c.Header = &eth.SignedBeaconBlockHeader{}
+	}
+	return c.Header.MarshalSSZTo(dst)
+}
+
+// UnmarshalSSZ decodes the ssz encoding.
+func (c *BlockHeaderContainerSSZ) UnmarshalSSZ(buf []byte) error {
+	if len(buf) != blockHeaderContainerSSZSize {
+		return ssz.ErrSize
+	}
+	copy(c.Root[:], buf)
+	var err error
+	if c.Canonical, err = unmarshalBool(buf[fieldparams.RootLength]); err != nil {
+		return err
+	}
+	c.Header = &eth.SignedBeaconBlockHeader{}
+	return c.Header.UnmarshalSSZ(buf[fieldparams.RootLength+1:])
+}
+
+// ToSSZ converts the container to its ssz encoding.
+func (c *SignedBeaconBlockHeaderContainer) ToSSZ() (*BlockHeaderContainerSSZ, error) {
+	root, err := bytesutil.DecodeHexWithLength(c.Root, fieldparams.RootLength)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Root")
+	}
+	if c.Header == nil {
+		return nil, server.NewDecodeError(errNilValue, "Header")
+	}
+	header, err := c.Header.ToConsensus()
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Header")
+	}
+	return &BlockHeaderContainerSSZ{Root: bytesutil.ToBytes32(root), Canonical: c.Canonical, Header: header}, nil
+}
+
+// BlockHeaderContainerFromSSZ converts the ssz encoding of a container.
+func BlockHeaderContainerFromSSZ(c *BlockHeaderContainerSSZ) *SignedBeaconBlockHeaderContainer {
+	return &SignedBeaconBlockHeaderContainer{
+		Header:    SignedBeaconBlockHeaderFromConsensus(c.Header),
+		Root:      hexutil.Encode(c.Root[:]),
+		Canonical: c.Canonical,
+	}
+}
+
+// LivenessSSZ is the ssz encoding of an element of the liveness response.
+type LivenessSSZ struct {
+	Index  uint64
+	IsLive bool
+}
+
+const livenessSSZSize = 8 + 1
+
+func (*LivenessSSZ) fixedSizeSSZ() int { return livenessSSZSize }
+
+// SizeSSZ returns the size of the ssz encoding.
+func (*LivenessSSZ) SizeSSZ() int { return livenessSSZSize }
+
+// MarshalSSZTo appends the ssz encoding to dst.
+func (l *LivenessSSZ) MarshalSSZTo(dst []byte) ([]byte, error) {
+	dst = ssz.MarshalUint64(dst, l.Index)
+	return ssz.MarshalBool(dst, l.IsLive), nil
+}
+
+// UnmarshalSSZ decodes the ssz encoding.
+func (l *LivenessSSZ) UnmarshalSSZ(buf []byte) error {
+	if len(buf) != livenessSSZSize {
+		return ssz.ErrSize
+	}
+	var err error
+	if l.IsLive, err = unmarshalBool(buf[8]); err != nil {
+		return err
+	}
+	l.Index = ssz.UnmarshallUint64(buf[0:8])
+	return nil
+}
+
+// ToSSZ converts the liveness to its ssz encoding.
+func (l *Liveness) ToSSZ() (*LivenessSSZ, error) {
+	index, err := strconv.ParseUint(l.Index, 10, 64)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Index")
+	}
+	return &LivenessSSZ{Index: index, IsLive: l.IsLive}, nil
+}
+
+// LivenessFromSSZ converts the ssz encoding of a liveness.
+func LivenessFromSSZ(l *LivenessSSZ) *Liveness {
+	return &Liveness{
+		Index:  strconv.FormatUint(l.Index, 10),
+		IsLive: l.IsLive,
+	}
+}
+
+// SyncCommitteeValidatorsSSZ is the ssz encoding of the data of the sync committees response, which is a container
+// rather than a list.
+type SyncCommitteeValidatorsSSZ struct {
+	Validators          []uint64
+	ValidatorAggregates [][]uint64
+}
+
+// SizeSSZ returns the size of the ssz encoding.
+func (c *SyncCommitteeValidatorsSSZ) SizeSSZ() int {
+	size := 4 + 4 + 8*len(c.Validators)
+	for _, a := range c.ValidatorAggregates {
+		size += 4 + 8*len(a)
+	}
+	return size
+}
+
+// MarshalSSZ returns the ssz encoding.
+func (c *SyncCommitteeValidatorsSSZ) MarshalSSZ() ([]byte, error) {
+	dst := make([]byte, 0, c.SizeSSZ())
+	dst = ssz.WriteOffset(dst, 8)
+	dst = ssz.WriteOffset(dst, 8+8*len(c.Validators))
+	for _, v := range c.Validators {
+		dst = ssz.MarshalUint64(dst, v)
+	}
+	offset := 4 * len(c.ValidatorAggregates)
+	for _, a := range c.ValidatorAggregates {
+		dst = ssz.WriteOffset(dst, offset)
+		offset += 8 * len(a)
+	}
+	for _, a := range c.ValidatorAggregates {
+		for _, v := range a {
+			dst = ssz.MarshalUint64(dst, v)
+		}
+	}
+	return dst, nil
+}
+
+// UnmarshalSSZ decodes the ssz encoding.
+func (c *SyncCommitteeValidatorsSSZ) UnmarshalSSZ(buf []byte) error {
+	if len(buf) < 8 {
+		return ssz.ErrSize
+	}
+	start, end := ssz.ReadOffset(buf[0:4]), ssz.ReadOffset(buf[4:8])
+	if start != 8 || end < start || end > uint64(len(buf)) {
+		return ssz.ErrOffset
+	}
+	var err error
+	if c.Validators, err = unmarshalUint64List(buf[start:end]); err != nil {
+		return err
+	}
+	parts, err := splitOffsets(buf[end:])
+	if err != nil {
+		return err
+	}
+	c.ValidatorAggregates = make([][]uint64, len(parts))
+	for i, p := range parts {
+		if c.ValidatorAggregates[i], err = unmarshalUint64List(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ToSSZ converts the sync committee to its ssz encoding.
+func (c *SyncCommitteeValidators) ToSSZ() (*SyncCommitteeValidatorsSSZ, error) {
+	enc, err := marshalUint64List(nil, c.Validators, "Validators")
+	if err != nil {
+		return nil, err
+	}
+	validators, err := unmarshalUint64List(enc)
+	if err != nil {
+		return nil, err
+	}
+	aggregates := make([][]uint64, len(c.ValidatorAggregates))
+	for i, a := range c.ValidatorAggregates {
+		if enc, err = marshalUint64List(nil, a, "ValidatorAggregates["+strconv.Itoa(i)+"]"); err != nil {
+			return nil, err
+		}
+		if aggregates[i], err = unmarshalUint64List(enc); err != nil {
+			return nil, err
+		}
+	}
+	return &SyncCommitteeValidatorsSSZ{Validators: validators, ValidatorAggregates: aggregates}, nil
+}
+
+// SyncCommitteeValidatorsFromSSZ converts the ssz encoding of a sync committee.
+func SyncCommitteeValidatorsFromSSZ(c *SyncCommitteeValidatorsSSZ) *SyncCommitteeValidators {
+	aggregates := make([][]string, len(c.ValidatorAggregates))
+	for i, a := range c.ValidatorAggregates {
+		aggregates[i] = formatUint64List(a)
+	}
+	return &SyncCommitteeValidators{
+		Validators:          formatUint64List(c.Validators),
+		ValidatorAggregates: aggregates,
+	}
+}
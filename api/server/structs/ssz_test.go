@@ -0,0 +1,230 @@
+package structs
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+var testPubkey = hexutil.Encode(make([]byte, 48))
+
+func TestSSZList_Validators(t *testing.T) {
+	v := &Validator{
+		Pubkey:                     testPubkey,
+		WithdrawalCredentials:      hexutil.Encode(make([]byte, 32)),
+		EffectiveBalance:           "32000000000",
+		Slashed:                    true,
+		ActivationEligibilityEpoch: "1",
+		ActivationEpoch:            "2",
+		ExitEpoch:                  "3",
+		WithdrawableEpoch:          "4",
+	}
+	containers := []*ValidatorContainer{
+		{Index: "0", Balance: "31000000000", Status: "active_ongoing", Validator: v},
+		{Index: "7", Balance: "0", Status: "withdrawal_done", Validator: v},
+	}
+	enc, err := MarshalSSZList(containers, (*ValidatorContainer).ToSSZ)
+	require.NoError(t, err)
+	require.Equal(t, 2*validatorContainerSSZSize, len(enc))
+	got, err := UnmarshalSSZList(enc, ValidatorContainerFromSSZ)
+	require.NoError(t, err)
+	require.DeepEqual(t, containers, got)
+
+	_, err = UnmarshalSSZList(enc[1:], ValidatorContainerFromSSZ)
+	require.ErrorContains(t, "not evenly", err)
+	containers[0].Status = "active"
+	_, err = MarshalSSZList(containers, (*ValidatorContainer).ToSSZ)
+	require.ErrorContains(t, "Data[0].Status", err)
+}
+
+func TestSSZList_ValidatorBalances(t *testing.T) {
+	balances := []*ValidatorBalance{{Index: "1", Balance: "2"}, {Index: "3", Balance: "4"}}
+	enc, err := MarshalSSZList(balances, (*ValidatorBalance).ToSSZ)
+	require.NoError(t, err)
+	require.Equal(t, 32, len(enc))
+	got, err := UnmarshalSSZList(enc, ValidatorBalanceFromSSZ)
+	require.NoError(t, err)
+	require.DeepEqual(t, balances, got)
+
+	got, err = UnmarshalSSZList(nil, ValidatorBalanceFromSSZ)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(got))
+}
+
+func TestSSZList_Committees(t *testing.T) {
+	committees := []*Committee{
+		{Index: "0", Slot: "32", Validators: []string{"5", "9", "2"}},
+		{Index: "1", Slot: "32", Validators: []string{}},
+		{Index: "0", Slot: "33", Validators: []string{"1"}},
+	}
+	enc, err := MarshalSSZList(committees, (*Committee).ToSSZ)
+	require.NoError(t, err)
+	require.Equal(t, 3*4+3*20+4*8, len(enc))
+	got, err := UnmarshalSSZList(enc, CommitteeFromSSZ)
+	require.NoError(t, err)
+	require.DeepEqual(t, committees, got)
+
+	// The first offset must point after the offset table.
+	bad := append([]byte{}, enc...)
+	bad[0] = 3
+	_, err = UnmarshalSSZList(bad, CommitteeFromSSZ)
+	require.ErrorContains(t, "incorrect offset", err)
+	_, err = UnmarshalSSZList(enc[:len(enc)-1], CommitteeFromSSZ)
+	require.ErrorContains(t, "could not decode element 2", err)
+}
+
+func TestSSZList_Duties(t *testing.T) {
+	attester := []*AttesterDuty{{
+		Pubkey:                  testPubkey,
+		ValidatorIndex:          "1",
+		CommitteeIndex:          "2",
+		CommitteeLength:         "3",
+		CommitteesAtSlot:        "4",
+		ValidatorCommitteeIndex: "5",
+		Slot:                    "6",
+	}}
+	enc, err := MarshalSSZList(attester, (*AttesterDuty).ToSSZ)
+	require.NoError(t, err)
+	require.Equal(t, attesterDutySSZSize, len(enc))
+	gotAttester, err := UnmarshalSSZList(enc, AttesterDutyFromSSZ)
+	require.NoError(t, err)
+	require.DeepEqual(t, attester, gotAttester)
+
+	proposer := []*ProposerDuty{{Pubkey: testPubkey, ValidatorIndex: "1", Slot: "2"}}
+	enc, err = MarshalSSZList(proposer, (*ProposerDuty).ToSSZ)
+	require.NoError(t, err)
+	require.Equal(t, proposerDutySSZSize, len(enc))
+	gotProposer, err := UnmarshalSSZList(enc, ProposerDutyFromSSZ)
+	require.NoError(t, err)
+	require.DeepEqual(t, proposer, gotProposer)
+
+	sync := []*SyncCommitteeDuty{
+		{Pubkey: testPubkey, ValidatorIndex: "1", ValidatorSyncCommitteeIndices: []string{"3", "400"}},
+		{Pubkey: testPubkey, ValidatorIndex: "2", ValidatorSyncCommitteeIndices: []string{"7"}},
+	}
+	enc, err = MarshalSSZList(sync, (*SyncCommitteeDuty).ToSSZ)
+	require.NoError(t, err)
+	gotSync, err := UnmarshalSSZList(enc, SyncCommitteeDutyFromSSZ)
+	require.NoError(t, err)
+	require.DeepEqual(t, sync, gotSync)
+
+	proposer[0].Pubkey = "0x01"
+	_, err = MarshalSSZList(proposer, (*ProposerDuty).ToSSZ)
+	require.ErrorContains(t, "Data[0].Pubkey", err)
+}
+
+func testAttData(slot primitives.Slot) *eth.AttestationData {
+	return &eth.AttestationData{
+		Slot:            slot,
+		BeaconBlockRoot: make([]byte, 32),
+		Source:          &eth.Checkpoint{Epoch: 1, Root: make([]byte, 32)},
+		Target:          &eth.Checkpoint{Epoch: 2, Root: make([]byte, 32)},
+	}
+}
+
+func TestSSZList_PoolOperations(t *testing.T) {
+	atts := []*Attestation{
+		AttFromConsensus(&eth.Attestation{AggregationBits: []byte{0b101}, Data: testAttData(1), Signature: make([]byte, 96)}),
+		AttFromConsensus(&eth.Attestation{AggregationBits: []byte{0b1, 0b11}, Data: testAttData(2), Signature: make([]byte, 96)}),
+	}
+	enc, err := MarshalSSZList(atts, (*Attestation).ToSSZ)
+	require.NoError(t, err)
+	gotAtts, err := UnmarshalSSZList(enc, AttestationFromSSZ)
+	require.NoError(t, err)
+	require.DeepEqual(t, atts, gotAtts)
+
+	attsElectra := []*AttestationElectra{AttElectraFromConsensus(&eth.AttestationElectra{
+		AggregationBits: []byte{0b101},
+		Data:            testAttData(3),
+		Signature:       make([]byte, 96),
+		CommitteeBits:   make([]byte, 8),
+	})}
+	enc, err = MarshalSSZList(attsElectra, (*AttestationElectra).ToSSZ)
+	require.NoError(t, err)
+	gotAttsElectra, err := UnmarshalSSZList(enc, AttestationElectraFromSSZ)
+	require.NoError(t, err)
+	require.DeepEqual(t, attsElectra, gotAttsElectra)
+
+	indexed := &eth.IndexedAttestation{AttestingIndices: []uint64{1, 2}, Data: testAttData(4), Signature: make([]byte, 96)}
+	slashings := []*AttesterSlashing{AttesterSlashingFromConsensus(&eth.AttesterSlashing{Attestation_1: indexed, Attestation_2: indexed})}
+	enc, err = MarshalSSZList(slashings, (*AttesterSlashing).ToSSZ)
+	require.NoError(t, err)
+	gotSlashings, err := UnmarshalSSZList(enc, AttesterSlashingFromSSZ)
+	require.NoError(t, err)
+	require.DeepEqual(t, slashings, gotSlashings)
+
+	indexedElectra := &eth.IndexedAttestationElectra{AttestingIndices: []uint64{3}, Data: testAttData(5), Signature: make([]byte, 96)}
+	slashingsElectra := []*AttesterSlashingElectra{AttesterSlashingElectraFromConsensus(&eth.AttesterSlashingElectra{
+		Attestation_1: indexedElectra,
+		Attestation_2: indexedElectra,
+	})}
+	enc, err = MarshalSSZList(slashingsElectra, (*AttesterSlashingElectra).ToSSZ)
+	require.NoError(t, err)
+	gotSlashingsElectra, err := UnmarshalSSZList(enc, AttesterSlashingElectraFromSSZ)
+	require.NoError(t, err)
+	require.DeepEqual(t, slashingsElectra, gotSlashingsElectra)
+
+	atts[1].Signature = "0x01"
+	_, err = MarshalSSZList(atts, (*Attestation).ToSSZ)
+	require.ErrorContains(t, "Data[1].Signature", err)
+}
+
+func TestSSZList_BlockHeaders(t *testing.T) {
+	header := SignedBeaconBlockHeaderFromConsensus(&eth.SignedBeaconBlockHeader{
+		Header: &eth.BeaconBlockHeader{
+			Slot:          5,
+			ProposerIndex: 6,
+			ParentRoot:    make([]byte, 32),
+			StateRoot:     make([]byte, 32),
+			BodyRoot:      make([]byte, 32),
+		},
+		Signature: make([]byte, 96),
+	})
+	headers := []*SignedBeaconBlockHeaderContainer{
+		{Header: header, Root: hexutil.Encode(make([]byte, 32)), Canonical: true},
+		{Header: header, Root: hexutil.Encode(make([]byte, 32)), Canonical: false},
+	}
+	enc, err := MarshalSSZList(headers, (*SignedBeaconBlockHeaderContainer).ToSSZ)
+	require.NoError(t, err)
+	require.Equal(t, 2*blockHeaderContainerSSZSize, len(enc))
+	got, err := UnmarshalSSZList(enc, BlockHeaderContainerFromSSZ)
+	require.NoError(t, err)
+	require.DeepEqual(t, headers, got)
+
+	enc[32] = 2
+	_, err = UnmarshalSSZList(enc, BlockHeaderContainerFromSSZ)
+	require.ErrorContains(t, "could not decode element 0", err)
+}
+
+func TestSSZList_Liveness(t *testing.T) {
+	liveness := []*Liveness{{Index: "1", IsLive: true}, {Index: "2", IsLive: false}}
+	enc, err := MarshalSSZList(liveness, (*Liveness).ToSSZ)
+	require.NoError(t, err)
+	require.Equal(t, 2*livenessSSZSize, len(enc))
+	got, err := UnmarshalSSZList(enc, LivenessFromSSZ)
+	require.NoError(t, err)
+	require.DeepEqual(t, liveness, got)
+}
+
+func TestSSZ_SyncCommitteeValidators(t *testing.T) {
+	committee := &SyncCommitteeValidators{
+		Validators:          []string{"4", "8", "15", "16"},
+		ValidatorAggregates: [][]string{{"4", "8"}, {}, {"15", "16"}},
+	}
+	c, err := committee.ToSSZ()
+	require.NoError(t, err)
+	enc, err := c.MarshalSSZ()
+	require.NoError(t, err)
+	require.Equal(t, c.SizeSSZ(), len(enc))
+	got := &SyncCommitteeValidatorsSSZ{}
+	require.NoError(t, got.UnmarshalSSZ(enc))
+	require.DeepEqual(t, committee, SyncCommitteeValidatorsFromSSZ(got))
+
+	require.ErrorContains(t, "incorrect size", got.UnmarshalSSZ(enc[:len(enc)-1]))
+	committee.ValidatorAggregates[2][0] = "x"
+	_, err = committee.ToSSZ()
+	require.ErrorContains(t, "ValidatorAggregates[2][0]", err)
+}
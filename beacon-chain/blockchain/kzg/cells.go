@@ -0,0 +1,507 @@
+package kzg
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"math/bits"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	GoKZG "github.com/crate-crypto/go-kzg-4844"
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+)
+
+const (
+	bytesPerFieldElement    = 32
+	bytesPerCommitment      = 48
+	fieldElementsPerBlob    = fieldparams.BlobLength / bytesPerFieldElement
+	fieldElementsPerExtBlob = 2 * fieldElementsPerBlob
+	fieldElementsPerCell    = fieldparams.FieldElementsPerCell
+	cellsPerExtBlob         = fieldparams.CellsPerExtBlob
+
+	// primitiveRootOfUnity is the generator used by the consensus specs to derive the roots of unity.
+	primitiveRootOfUnity = 7
+	// cellBatchChallengeDomain is the domain separator of the challenge of a cell batch verification.
+	cellBatchChallengeDomain = "RCKZGCBATCH__V1_"
+)
+
+var (
+	errInvalidBlobLength = errors.New("invalid blob length")
+	errInvalidCellIndex  = errors.New("cell index out of range")
+	errInvalidFieldElem  = errors.New("field element is not canonical")
+	errInvalidPoint      = errors.New("invalid compressed G1 point")
+	errMismatchedLengths = errors.New("mismatched commitment, index, cell and proof counts")
+	errTooFewCells       = errors.New("not enough cells to recover the extended blob")
+	errTooManyCells      = errors.New("more cells than in an extended blob")
+	errDuplicateCell     = errors.New("duplicate cell index")
+)
+
+// Blob is the serialized form of a blob as carried by blob sidecars.
+type Blob [fieldparams.BlobLength]byte
+
+// Cell is one of the CELLS_PER_EXT_BLOB chunks of an extended blob.
+type Cell [fieldparams.BytesPerCell]byte
+
+// Commitment is a compressed KZG commitment to a blob.
+type Commitment [bytesPerCommitment]byte
+
+// Proof is a compressed KZG proof for a single cell.
+type Proof [bytesPerCommitment]byte
+
+// CellsAndProofs holds the cells of an extended blob together with their KZG proofs, ordered by cell index.
+type CellsAndProofs struct {
+	Cells  []Cell
+	Proofs []Proof
+}
+
+// cellSetup holds the monomial form of the trusted setup and the roots of unity needed
+// to compute and verify cell proofs. It is only loaded when cell proofs are first used.
+type cellSetup struct {
+	g1Monomial []bls12381.G1Affine // [τ^i]_1 for i in [0, FIELD_ELEMENTS_PER_BLOB)
+	g2Gen      bls12381.G2Affine   // [1]_2
+	g2TauCell  bls12381.G2Affine   // [τ^FIELD_ELEMENTS_PER_CELL]_2
+	extRoots   []fr.Element        // roots of unity of order FIELD_ELEMENTS_PER_EXT_BLOB in natural order
+	extRootsBR []fr.Element        // extRoots in bit reversed order
+	blobRoots  []fr.Element        // roots of unity of order FIELD_ELEMENTS_PER_BLOB in natural order
+	cellRoots  []fr.Element        // roots of unity of order FIELD_ELEMENTS_PER_CELL in natural order
+}
+
+var (
+	cellSetupOnce sync.Once
+	cellSetupVal  *cellSetup
+	cellSetupErr  error
+)
+
+func loadCellSetup() (*cellSetup, error) {
+	cellSetupOnce.Do(func() {
+		cellSetupVal, cellSetupErr = newCellSetup(embeddedTrustedSetup)
+	})
+	return cellSetupVal, cellSetupErr
+}
+
+func newCellSetup(setupJSON []byte) (*cellSetup, error) {
+	parsed := struct {
+		G1Monomial []string `json:"g1_monomial"`
+		G2Monomial []string `json:"g2_monomial"`
+	}{}
+	if err := json.Unmarshal(setupJSON, &parsed); err != nil {
+		return nil, errors.Wrap(err, "could not parse trusted setup JSON")
+	}
+	if len(parsed.G1Monomial) != fieldElementsPerBlob || len(parsed.G2Monomial) <= fieldElementsPerCell {
+		return nil, errors.New("trusted setup does not contain the monomial points needed for cell proofs")
+	}
+	s := &cellSetup{g1Monomial: make([]bls12381.G1Affine, fieldElementsPerBlob)}
+	for i, p := range parsed.G1Monomial {
+		b, err := bytesutil.DecodeHexWithLength(p, bls12381.SizeOfG1AffineCompressed)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not decode G1 monomial point %d", i)
+		}
+		if _, err := s.g1Monomial[i].SetBytes(b); err != nil {
+			return nil, errors.Wrapf(err, "invalid G1 monomial point %d", i)
+		}
+	}
+	for i, dst := range map[int]*bls12381.G2Affine{0: &s.g2Gen, fieldElementsPerCell: &s.g2TauCell} {
+		b, err := bytesutil.DecodeHexWithLength(parsed.G2Monomial[i], bls12381.SizeOfG2AffineCompressed)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not decode G2 monomial point %d", i)
+		}
+		if _, err := dst.SetBytes(b); err != nil {
+			return nil, errors.Wrapf(err, "invalid G2 monomial point %d", i)
+		}
+	}
+
+	s.extRoots = rootsOfUnity(fieldElementsPerExtBlob)
+	s.extRootsBR = bitReversed(s.extRoots)
+	s.blobRoots = make([]fr.Element, fieldElementsPerBlob)
+	for i := range s.blobRoots {
+		s.blobRoots[i] = s.extRoots[2*i]
+	}
+	s.cellRoots = make([]fr.Element, fieldElementsPerCell)
+	for i := range s.cellRoots {
+		s.cellRoots[i] = s.extRoots[cellsPerExtBlob*i]
+	}
+	return s, nil
+}
+
+// BlobToKZGCommitment computes the KZG commitment to the given blob.
+func BlobToKZGCommitment(blob *Blob) (Commitment, error) {
+	if kzgContext == nil {
+		return Commitment{}, errors.New("kzg context is not initialized")
+	}
+	commitment, err := kzgContext.BlobToKZGCommitment(GoKZG.Blob(*blob), 0)
+	if err != nil {
+		return Commitment{}, err
+	}
+	return Commitment(commitment), nil
+}
+
+// ComputeCellsAndKZGProofs extends the given blob and splits it into cells, computing a KZG proof for each
+// cell against the blob's commitment.
+//
+// Spec pseudocode definition:
+//
+//	def compute_cells_and_kzg_proofs(blob: Blob) -> Tuple[
+//	        Vector[Cell, CELLS_PER_EXT_BLOB],
+//	        Vector[KZGProof, CELLS_PER_EXT_BLOB]]:
+//	    polynomial = blob_to_polynomial(blob)
+//	    polynomial_coeff = polynomial_eval_to_coeff(polynomial)
+//	    return compute_cells_and_kzg_proofs_polynomialcoeff(polynomial_coeff)
+func ComputeCellsAndKZGProofs(blob *Blob) (CellsAndProofs, error) {
+	s, err := loadCellSetup()
+	if err != nil {
+		return CellsAndProofs{}, err
+	}
+	if blob == nil {
+		return CellsAndProofs{}, errInvalidBlobLength
+	}
+	evals := make([]fr.Element, fieldElementsPerBlob)
+	for i := range evals {
+		if err := evals[i].SetBytesCanonical(blob[i*bytesPerFieldElement : (i+1)*bytesPerFieldElement]); err != nil {
+			return CellsAndProofs{}, errors.Wrapf(errInvalidFieldElem, "blob element %d", i)
+		}
+	}
+	// Blobs hold evaluations over the blob domain in bit reversed order.
+	coeffs := bitReversed(evals)
+	ifft(coeffs, s.blobRoots)
+	return s.cellsAndProofs(coeffs)
+}
+
+// cellsAndProofs extends the polynomial given in coefficient form over the extended blob domain and splits it
+// into cells, computing the proof of each cell.
+//
+// Spec pseudocode definition:
+//
+//	def compute_cells_and_kzg_proofs_polynomialcoeff(polynomial_coeff: PolynomialCoeff) -> Tuple[
+//	        Vector[Cell, CELLS_PER_EXT_BLOB],
+//	        Vector[KZGProof, CELLS_PER_EXT_BLOB]]:
+func (s *cellSetup) cellsAndProofs(coeffs []fr.Element) (CellsAndProofs, error) {
+	extended := make([]fr.Element, fieldElementsPerExtBlob)
+	copy(extended, coeffs)
+	fft(extended, s.extRoots)
+	extended = bitReversed(extended)
+
+	cells := make([]Cell, cellsPerExtBlob)
+	for i := range cells {
+		for j := 0; j < fieldElementsPerCell; j++ {
+			b := extended[i*fieldElementsPerCell+j].Bytes()
+			copy(cells[i][j*bytesPerFieldElement:], b[:])
+		}
+	}
+	proofs, err := s.computeCellProofs(coeffs[:fieldElementsPerBlob])
+	if err != nil {
+		return CellsAndProofs{}, err
+	}
+	return CellsAndProofs{Cells: cells, Proofs: proofs}, nil
+}
+
+// VerifyCellKZGProofBatch verifies that each cell is the evaluation of the polynomial committed to by the
+// corresponding commitment over the cell's coset. The cells are checked at once with a single pairing check on a
+// random linear combination of the proofs.
+//
+// Spec pseudocode definition:
+//
+//	def verify_cell_kzg_proof_batch(commitments_bytes: Sequence[Bytes48],
+//	                                cell_indices: Sequence[CellIndex],
+//	                                cells: Sequence[Cell],
+//	                                proofs_bytes: Sequence[Bytes48]) -> bool:
+func VerifyCellKZGProofBatch(commitments []Commitment, cellIndices []uint64, cells []Cell, proofs []Proof) (bool, error) {
+	if len(commitments) != len(cellIndices) || len(cellIndices) != len(cells) || len(cells) != len(proofs) {
+		return false, errMismatchedLengths
+	}
+	s, err := loadCellSetup()
+	if err != nil {
+		return false, err
+	}
+	if len(cells) == 0 {
+		return true, nil
+	}
+
+	// Commitments shared by several cells, such as the commitment of a blob sampled at several columns, are
+	// only decoded and weighted once.
+	var dedupCommitments []Commitment
+	var commitmentPoints []bls12381.G1Affine
+	commitmentIndices := make([]uint64, len(commitments))
+	seen := make(map[Commitment]uint64)
+	for i, c := range commitments {
+		idx, ok := seen[c]
+		if !ok {
+			var point bls12381.G1Affine
+			if _, err := point.SetBytes(c[:]); err != nil {
+				return false, errors.Wrap(errInvalidPoint, err.Error())
+			}
+			idx = uint64(len(dedupCommitments))
+			seen[c] = idx
+			dedupCommitments = append(dedupCommitments, c)
+			commitmentPoints = append(commitmentPoints, point)
+		}
+		commitmentIndices[i] = idx
+	}
+	cosetsEvals := make([][]fr.Element, len(cells))
+	proofPoints := make([]bls12381.G1Affine, len(proofs))
+	for i := range cells {
+		if cellIndices[i] >= cellsPerExtBlob {
+			return false, errors.Wrapf(errInvalidCellIndex, "index %d", cellIndices[i])
+		}
+		cosetsEvals[i], err = cellEvals(&cells[i])
+		if err != nil {
+			return false, errors.Wrapf(err, "cell %d", i)
+		}
+		if _, err := proofPoints[i].SetBytes(proofs[i][:]); err != nil {
+			return false, errors.Wrap(errInvalidPoint, err.Error())
+		}
+	}
+	return s.verifyCellBatch(dedupCommitments, commitmentPoints, commitmentIndices, cellIndices, cosetsEvals, proofs, proofPoints)
+}
+
+// verifyCellBatch checks e(Σ r^k·π_k, [τ^n]_2) == e(Σ r^k·(C_k - [I_k(τ)]_1 + h_k^n·π_k), [1]_2), where for the
+// k-th cell I_k is the interpolation polynomial of its evaluations over its coset h_k·ω^j, π_k its proof, C_k its
+// commitment, n = FIELD_ELEMENTS_PER_CELL and r a Fiat-Shamir challenge over all the inputs.
+//
+// Spec pseudocode definition:
+//
+//	def verify_cell_kzg_proof_batch_impl(commitments: Sequence[KZGCommitment],
+//	                                     commitment_indices: Sequence[CommitmentIndex],
+//	                                     cell_indices: Sequence[CellIndex],
+//	                                     cosets_evals: Sequence[CosetEvals],
+//	                                     proofs: Sequence[KZGProof]) -> bool:
+func (s *cellSetup) verifyCellBatch(
+	commitments []Commitment,
+	commitmentPoints []bls12381.G1Affine,
+	commitmentIndices []uint64,
+	cellIndices []uint64,
+	cosetsEvals [][]fr.Element,
+	proofs []Proof,
+	proofPoints []bls12381.G1Affine,
+) (bool, error) {
+	r := verifyCellBatchChallenge(commitments, commitmentIndices, cellIndices, cosetsEvals, proofs)
+	rPowers := make([]fr.Element, len(cosetsEvals))
+	rPowers[0].SetOne()
+	for k := 1; k < len(rPowers); k++ {
+		rPowers[k].Mul(&rPowers[k-1], &r)
+	}
+
+	// LL = Σ r^k·π_k
+	var ll bls12381.G1Jac
+	if _, err := ll.MultiExp(proofPoints, rPowers, ecc.MultiExpConfig{}); err != nil {
+		return false, errors.Wrap(err, "could not combine proofs")
+	}
+
+	// RLC = Σ_i w_i·C_i, where w_i is the sum of the powers of r of the cells of the i-th commitment.
+	weights := make([]fr.Element, len(commitmentPoints))
+	for k, i := range commitmentIndices {
+		weights[i].Add(&weights[i], &rPowers[k])
+	}
+	var rl bls12381.G1Jac
+	if _, err := rl.MultiExp(commitmentPoints, weights, ecc.MultiExpConfig{}); err != nil {
+		return false, errors.Wrap(err, "could not combine commitments")
+	}
+
+	// RLI = [Σ r^k·I_k(τ)]_1. The interpolation is linear, so the evaluations of the cells over the same coset
+	// are combined before being interpolated once.
+	combined := make(map[uint64][]fr.Element)
+	for k, idx := range cellIndices {
+		evals, ok := combined[idx]
+		if !ok {
+			evals = make([]fr.Element, fieldElementsPerCell)
+			combined[idx] = evals
+		}
+		for j := range evals {
+			var t fr.Element
+			t.Mul(&cosetsEvals[k][j], &rPowers[k])
+			evals[j].Add(&evals[j], &t)
+		}
+	}
+	interpSum := make([]fr.Element, fieldElementsPerCell)
+	for idx, evals := range combined {
+		interp := s.interpolateCell(idx, evals)
+		for j := range interpSum {
+			interpSum[j].Add(&interpSum[j], &interp[j])
+		}
+	}
+	var rli bls12381.G1Jac
+	if _, err := rli.MultiExp(s.g1Monomial[:fieldElementsPerCell], interpSum, ecc.MultiExpConfig{}); err != nil {
+		return false, errors.Wrap(err, "could not commit to cell interpolation polynomials")
+	}
+
+	// RLP = Σ r^k·h_k^n·π_k
+	proofWeights := make([]fr.Element, len(proofPoints))
+	for k, idx := range cellIndices {
+		shift := s.cosetShift(idx)
+		var shiftPow fr.Element
+		shiftPow.Exp(shift, big.NewInt(fieldElementsPerCell))
+		proofWeights[k].Mul(&rPowers[k], &shiftPow)
+	}
+	var rlp bls12381.G1Jac
+	if _, err := rlp.MultiExp(proofPoints, proofWeights, ecc.MultiExpConfig{}); err != nil {
+		return false, errors.Wrap(err, "could not combine shifted proofs")
+	}
+
+	// RL = RLC - RLI + RLP
+	rl.SubAssign(&rli)
+	rl.AddAssign(&rlp)
+	var llAff, rlAff bls12381.G1Affine
+	llAff.FromJacobian(&ll)
+	rlAff.FromJacobian(&rl)
+	rlAff.Neg(&rlAff)
+	return bls12381.PairingCheck(
+		[]bls12381.G1Affine{llAff, rlAff},
+		[]bls12381.G2Affine{s.g2TauCell, s.g2Gen},
+	)
+}
+
+// verifyCellBatchChallenge derives the challenge of a cell batch verification from all of its inputs.
+//
+// Spec pseudocode definition:
+//
+//	def compute_verify_cell_kzg_proof_batch_challenge(commitments: Sequence[KZGCommitment],
+//	                                                  commitment_indices: Sequence[CommitmentIndex],
+//	                                                  cell_indices: Sequence[CellIndex],
+//	                                                  cosets_evals: Sequence[CosetEvals],
+//	                                                  proofs: Sequence[KZGProof]) -> BLSFieldElement:
+func verifyCellBatchChallenge(
+	commitments []Commitment,
+	commitmentIndices []uint64,
+	cellIndices []uint64,
+	cosetsEvals [][]fr.Element,
+	proofs []Proof,
+) fr.Element {
+	h := sha256.New()
+	writeUint64 := func(v uint64) {
+		var b [8]byte
+		binary.BigEndian.PutUint64(b[:], v)
+		h.Write(b[:])
+	}
+	h.Write([]byte(cellBatchChallengeDomain))
+	writeUint64(fieldElementsPerBlob)
+	writeUint64(fieldElementsPerCell)
+	writeUint64(uint64(len(commitments)))
+	writeUint64(uint64(len(cellIndices)))
+	for _, c := range commitments {
+		h.Write(c[:])
+	}
+	for k, evals := range cosetsEvals {
+		writeUint64(commitmentIndices[k])
+		writeUint64(cellIndices[k])
+		for j := range evals {
+			b := evals[j].Bytes()
+			h.Write(b[:])
+		}
+		h.Write(proofs[k][:])
+	}
+	var r fr.Element
+	r.SetBytes(h.Sum(nil))
+	return r
+}
+
+// interpolateCell returns the coefficients of the polynomial of degree lower than FIELD_ELEMENTS_PER_CELL taking
+// the given evaluations over the coset of the cell.
+func (s *cellSetup) interpolateCell(index uint64, evals []fr.Element) []fr.Element {
+	shift := s.cosetShift(index)
+
+	// The cell holds I(h·ω^brp(j)) in bit reversed order; interpolating over the plain roots
+	// yields the coefficients of I(hX), which are then scaled back by h^-k.
+	interp := bitReversed(evals)
+	ifft(interp, s.cellRoots)
+	var shiftInv, scale fr.Element
+	shiftInv.Inverse(&shift)
+	scale.SetOne()
+	for k := range interp {
+		interp[k].Mul(&interp[k], &scale)
+		scale.Mul(&scale, &shiftInv)
+	}
+	return interp
+}
+
+// cellEvals decodes the field elements of a cell.
+func cellEvals(cell *Cell) ([]fr.Element, error) {
+	evals := make([]fr.Element, fieldElementsPerCell)
+	for j := range evals {
+		if err := evals[j].SetBytesCanonical(cell[j*bytesPerFieldElement : (j+1)*bytesPerFieldElement]); err != nil {
+			return nil, errors.Wrapf(errInvalidFieldElem, "element %d", j)
+		}
+	}
+	return evals, nil
+}
+
+// cosetShift returns the first point of the coset the given cell is evaluated over.
+func (s *cellSetup) cosetShift(index uint64) fr.Element {
+	return s.extRootsBR[index*fieldElementsPerCell]
+}
+
+// rootsOfUnity returns the n roots of unity of order n in natural order, derived from the
+// primitive root of unity used by the consensus specs.
+func rootsOfUnity(n uint64) []fr.Element {
+	exp := new(big.Int).Sub(fr.Modulus(), big.NewInt(1))
+	exp.Div(exp, new(big.Int).SetUint64(n))
+	var gen, root fr.Element
+	gen.SetUint64(primitiveRootOfUnity)
+	root.Exp(gen, exp)
+	roots := make([]fr.Element, n)
+	roots[0].SetOne()
+	for i := uint64(1); i < n; i++ {
+		roots[i].Mul(&roots[i-1], &root)
+	}
+	return roots
+}
+
+// bitReversalIndices returns the bit reversal permutation of the indices of a power of two sized slice.
+func bitReversalIndices(n int) []int {
+	indices := make([]int, n)
+	shift := 64 - bits.TrailingZeros(uint(n))
+	for i := range indices {
+		indices[i] = int(bits.Reverse64(uint64(i)) >> shift)
+	}
+	return indices
+}
+
+// bitReversed returns a copy of the given power of two sized slice in bit reversed order.
+func bitReversed(in []fr.Element) []fr.Element {
+	out := make([]fr.Element, len(in))
+	for i, j := range bitReversalIndices(len(in)) {
+		out[j] = in[i]
+	}
+	return out
+}
+
+// fft evaluates the polynomial in coefficient form at the given roots of unity, in place.
+func fft(vals, roots []fr.Element) {
+	n := len(vals)
+	rootStride := len(roots) / n
+	copy(vals, bitReversed(vals))
+	for size := 2; size <= n; size <<= 1 {
+		half := size >> 1
+		step := rootStride * (n / size)
+		for start := 0; start < n; start += size {
+			for j := 0; j < half; j++ {
+				var t fr.Element
+				t.Mul(&roots[j*step], &vals[start+j+half])
+				u := vals[start+j]
+				vals[start+j].Add(&u, &t)
+				vals[start+j+half].Sub(&u, &t)
+			}
+		}
+	}
+}
+
+// ifft interpolates the evaluations at the given roots of unity into coefficient form, in place.
+func ifft(vals, roots []fr.Element) {
+	n := len(vals)
+	inverse := make([]fr.Element, len(roots))
+	inverse[0] = roots[0]
+	for i := 1; i < len(roots); i++ {
+		inverse[i] = roots[len(roots)-i]
+	}
+	fft(vals, inverse)
+	var nInv fr.Element
+	nInv.SetUint64(uint64(n))
+	nInv.Inverse(&nInv)
+	for i := range vals {
+		vals[i].Mul(&vals[i], &nInv)
+	}
+}
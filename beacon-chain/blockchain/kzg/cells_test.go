@@ -0,0 +1,70 @@
+package kzg
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestComputeCellsAndKZGProofs(t *testing.T) {
+	require.NoError(t, Start())
+
+	blob := Blob(GetRandBlob(123))
+	commitment, err := BlobToKZGCommitment(&blob)
+	require.NoError(t, err)
+	cellsAndProofs, err := ComputeCellsAndKZGProofs(&blob)
+	require.NoError(t, err)
+	require.Equal(t, cellsPerExtBlob, len(cellsAndProofs.Cells))
+	require.Equal(t, cellsPerExtBlob, len(cellsAndProofs.Proofs))
+
+	// The first half of the extended blob in bit reversed order is the original blob.
+	for i := 0; i < cellsPerExtBlob/2; i++ {
+		require.DeepEqual(t, blob[i*len(Cell{}):(i+1)*len(Cell{})], cellsAndProofs.Cells[i][:])
+	}
+
+	indices := []uint64{0, 1, 63, 64, 127}
+	commitments := make([]Commitment, len(indices))
+	cells := make([]Cell, len(indices))
+	proofs := make([]Proof, len(indices))
+	for i, idx := range indices {
+		commitments[i] = commitment
+		cells[i] = cellsAndProofs.Cells[idx]
+		proofs[i] = cellsAndProofs.Proofs[idx]
+	}
+	ok, err := VerifyCellKZGProofBatch(commitments, indices, cells, proofs)
+	require.NoError(t, err)
+	require.Equal(t, true, ok)
+
+	t.Run("wrong cell index", func(t *testing.T) {
+		swapped := []uint64{1, 0, 63, 64, 127}
+		ok, err := VerifyCellKZGProofBatch(commitments, swapped, cells, proofs)
+		require.NoError(t, err)
+		require.Equal(t, false, ok)
+	})
+	t.Run("wrong commitment", func(t *testing.T) {
+		other := Blob(GetRandBlob(456))
+		otherCommitment, err := BlobToKZGCommitment(&other)
+		require.NoError(t, err)
+		wrong := append([]Commitment{otherCommitment}, commitments[1:]...)
+		ok, err := VerifyCellKZGProofBatch(wrong, indices, cells, proofs)
+		require.NoError(t, err)
+		require.Equal(t, false, ok)
+	})
+	t.Run("index out of range", func(t *testing.T) {
+		_, err := VerifyCellKZGProofBatch(commitments[:1], []uint64{cellsPerExtBlob}, cells[:1], proofs[:1])
+		require.ErrorIs(t, err, errInvalidCellIndex)
+	})
+	t.Run("mismatched lengths", func(t *testing.T) {
+		_, err := VerifyCellKZGProofBatch(commitments, indices[:1], cells, proofs)
+		require.ErrorIs(t, err, errMismatchedLengths)
+	})
+}
+
+func TestComputeCellsAndKZGProofs_NonCanonicalBlob(t *testing.T) {
+	var blob Blob
+	for i := range blob[:bytesPerFieldElement] {
+		blob[i] = 0xff
+	}
+	_, err := ComputeCellsAndKZGProofs(&blob)
+	require.ErrorIs(t, err, errInvalidFieldElem)
+}
@@ -0,0 +1,176 @@
+package kzg
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/pkg/errors"
+)
+
+// The FK20 method computes all cell proofs of a blob at once. The quotient of a polynomial f
+// by X^l - c is sum_m c^m * (f div X^(l*(m+1))), so every cell proof is an evaluation of the
+// same G1-valued polynomial H(Y) = sum_m [(f div X^(l*(m+1)))(τ)]_1 * Y^m at c = h^l, where h
+// is the shift of the cell's coset. The coefficients of H are a Toeplitz matrix-vector product
+// between the blob coefficients and the setup, computed through circulant embedding, and H is
+// evaluated over all cosets at once with a G1 FFT.
+//
+// With l = FIELD_ELEMENTS_PER_CELL and k = FIELD_ELEMENTS_PER_BLOB / l, the blob coefficient
+// f_(l*a+b) is written x^b_a. The coefficient H_m is sum_b sum_(d=0)^(k-2-m) x^b_(m+1+d) * s^b_d
+// with s^b_d = [τ^(l*d+b)]_1, which is the (k-2-m)-th entry of the convolution of s^b with the
+// reversed x^b.
+
+const (
+	fk20Blocks     = fieldElementsPerBlob / fieldElementsPerCell // k
+	fk20CircleSize = 2 * fk20Blocks
+)
+
+type fk20Tables struct {
+	// points[e][b] is the e-th entry of the G1 FFT of the setup vector s^b.
+	points [fk20CircleSize][]bls12381.G1Affine
+	// roots are the roots of unity of order 2k, which also equal h^l for every coset in natural order.
+	roots []fr.Element
+}
+
+var (
+	fk20Once sync.Once
+	fk20Val  *fk20Tables
+	fk20Err  error
+)
+
+func loadFK20Tables(s *cellSetup) (*fk20Tables, error) {
+	fk20Once.Do(func() {
+		fk20Val, fk20Err = newFK20Tables(s)
+	})
+	return fk20Val, fk20Err
+}
+
+func newFK20Tables(s *cellSetup) (*fk20Tables, error) {
+	if cellsPerExtBlob != fk20CircleSize {
+		return nil, errors.New("fk20 requires the number of cells to equal twice the number of blob blocks")
+	}
+	t := &fk20Tables{roots: make([]fr.Element, fk20CircleSize)}
+	for i := range t.roots {
+		t.roots[i] = s.extRoots[i*fieldElementsPerCell]
+	}
+	for e := range t.points {
+		t.points[e] = make([]bls12381.G1Affine, fieldElementsPerCell)
+	}
+	for b := 0; b < fieldElementsPerCell; b++ {
+		vec := make([]bls12381.G1Jac, fk20CircleSize)
+		for d := 0; d < fk20Blocks-1; d++ {
+			vec[d].FromAffine(&s.g1Monomial[fieldElementsPerCell*d+b])
+		}
+		g1FFT(vec, t.roots)
+		affine := bls12381.BatchJacobianToAffineG1(vec)
+		for e := range affine {
+			t.points[e][b] = affine[e]
+		}
+	}
+	return t, nil
+}
+
+// computeCellProofs returns the compressed proofs of all cells of the polynomial given in
+// coefficient form, ordered by cell index.
+func (s *cellSetup) computeCellProofs(coeffs []fr.Element) ([]Proof, error) {
+	t, err := loadFK20Tables(s)
+	if err != nil {
+		return nil, err
+	}
+	scalars := make([][]fr.Element, fk20CircleSize)
+	for e := range scalars {
+		scalars[e] = make([]fr.Element, fieldElementsPerCell)
+	}
+	x := make([]fr.Element, fk20CircleSize)
+	for b := 0; b < fieldElementsPerCell; b++ {
+		for u := range x {
+			x[u].SetZero()
+		}
+		for u := 0; u < fk20Blocks; u++ {
+			x[u] = coeffs[fieldElementsPerCell*(fk20Blocks-1-u)+b]
+		}
+		fft(x, t.roots)
+		for e := range x {
+			scalars[e][b] = x[e]
+		}
+	}
+
+	conv := make([]bls12381.G1Jac, fk20CircleSize)
+	for e := range conv {
+		if _, err := conv[e].MultiExp(t.points[e], scalars[e], ecc.MultiExpConfig{}); err != nil {
+			return nil, errors.Wrap(err, "could not compute toeplitz product")
+		}
+	}
+	g1IFFT(conv, t.roots)
+
+	h := make([]bls12381.G1Jac, fk20CircleSize)
+	for m := 0; m < fk20Blocks-1; m++ {
+		h[m] = conv[fk20Blocks-2-m]
+	}
+	g1FFT(h, t.roots)
+	evals := bls12381.BatchJacobianToAffineG1(h)
+
+	// Cell i lives on the coset with h^l = ω^brp(i), so its proof is the bit reversed evaluation.
+	proofs := make([]Proof, cellsPerExtBlob)
+	for i, p := range bitReversedG1(evals) {
+		proofs[i] = p.Bytes()
+	}
+	return proofs, nil
+}
+
+// g1FFT evaluates the G1-valued polynomial in coefficient form at the given roots of unity, in place.
+func g1FFT(vals []bls12381.G1Jac, roots []fr.Element) {
+	n := len(vals)
+	rootStride := len(roots) / n
+	bigRoots := make([]big.Int, n/2)
+	for j := range bigRoots {
+		roots[j*rootStride].BigInt(&bigRoots[j])
+	}
+	for i, j := range bitReversalIndices(n) {
+		if i < j {
+			vals[i], vals[j] = vals[j], vals[i]
+		}
+	}
+	for size := 2; size <= n; size <<= 1 {
+		half := size >> 1
+		step := n / size
+		for start := 0; start < n; start += size {
+			for j := 0; j < half; j++ {
+				var t bls12381.G1Jac
+				t.ScalarMultiplication(&vals[start+j+half], &bigRoots[j*step])
+				u := vals[start+j]
+				vals[start+j].Set(&u).AddAssign(&t)
+				vals[start+j+half].Set(&u).SubAssign(&t)
+			}
+		}
+	}
+}
+
+// g1IFFT interpolates the G1-valued evaluations at the given roots of unity into coefficient form, in place.
+func g1IFFT(vals []bls12381.G1Jac, roots []fr.Element) {
+	n := len(vals)
+	inverse := make([]fr.Element, len(roots))
+	inverse[0] = roots[0]
+	for i := 1; i < len(roots); i++ {
+		inverse[i] = roots[len(roots)-i]
+	}
+	g1FFT(vals, inverse)
+	var nInv fr.Element
+	nInv.SetUint64(uint64(n))
+	nInv.Inverse(&nInv)
+	var nInvBig big.Int
+	nInv.BigInt(&nInvBig)
+	for i := range vals {
+		vals[i].ScalarMultiplication(&vals[i], &nInvBig)
+	}
+}
+
+func bitReversedG1(in []bls12381.G1Affine) []bls12381.G1Affine {
+	out := make([]bls12381.G1Affine, len(in))
+	for i, j := range bitReversalIndices(len(in)) {
+		out[j] = in[i]
+	}
+	return out
+}
@@ -0,0 +1,148 @@
+package kzg
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/pkg/errors"
+)
+
+// RecoverCellsAndKZGProofs recovers all the cells of an extended blob and their proofs from at least half of them,
+// given in any order along with their indices.
+//
+// Spec pseudocode definition:
+//
+//	def recover_cells_and_kzg_proofs(cell_indices: Sequence[CellIndex],
+//	                                 cells: Sequence[Cell]) -> Tuple[
+//	        Vector[Cell, CELLS_PER_EXT_BLOB],
+//	        Vector[KZGProof, CELLS_PER_EXT_BLOB]]:
+func RecoverCellsAndKZGProofs(cellIndices []uint64, cells []Cell) (CellsAndProofs, error) {
+	if len(cellIndices) != len(cells) {
+		return CellsAndProofs{}, errMismatchedLengths
+	}
+	if len(cells) < cellsPerExtBlob/2 {
+		return CellsAndProofs{}, errors.Wrapf(errTooFewCells, "got %d cells", len(cells))
+	}
+	if len(cells) > cellsPerExtBlob {
+		return CellsAndProofs{}, errors.Wrapf(errTooManyCells, "got %d cells", len(cells))
+	}
+	s, err := loadCellSetup()
+	if err != nil {
+		return CellsAndProofs{}, err
+	}
+	present := make([]bool, cellsPerExtBlob)
+	for _, idx := range cellIndices {
+		if idx >= cellsPerExtBlob {
+			return CellsAndProofs{}, errors.Wrapf(errInvalidCellIndex, "index %d", idx)
+		}
+		if present[idx] {
+			return CellsAndProofs{}, errors.Wrapf(errDuplicateCell, "index %d", idx)
+		}
+		present[idx] = true
+	}
+	cosetsEvals := make([][]fr.Element, len(cells))
+	for i := range cells {
+		cosetsEvals[i], err = cellEvals(&cells[i])
+		if err != nil {
+			return CellsAndProofs{}, errors.Wrapf(err, "cell %d", i)
+		}
+	}
+	return s.cellsAndProofs(s.recoverPolynomial(cellIndices, cosetsEvals, present))
+}
+
+// recoverPolynomial returns the coefficients of the blob polynomial P from the evaluations of the given cells.
+// With E the polynomial taking the evaluations of the cells and zero on the missing ones over the extended domain,
+// and Z the polynomial vanishing on the missing cells, E·Z and P·Z agree over the extended domain and P·Z is
+// recovered by interpolation. P is then obtained by dividing by Z over a coset of the domain, where Z has no root.
+//
+// Spec pseudocode definition:
+//
+//	def recover_polynomialcoeff(cell_indices: Sequence[CellIndex],
+//	                            cosets_evals: Sequence[CosetEvals]) -> PolynomialCoeff:
+func (s *cellSetup) recoverPolynomial(cellIndices []uint64, cosetsEvals [][]fr.Element, present []bool) []fr.Element {
+	extendedRBO := make([]fr.Element, fieldElementsPerExtBlob)
+	for i, idx := range cellIndices {
+		copy(extendedRBO[idx*fieldElementsPerCell:], cosetsEvals[i])
+	}
+	extended := bitReversed(extendedRBO)
+
+	zeroCoeffs := s.vanishingPolynomial(present)
+	zeroEvals := make([]fr.Element, fieldElementsPerExtBlob)
+	copy(zeroEvals, zeroCoeffs)
+	fft(zeroEvals, s.extRoots)
+
+	for i := range extended {
+		extended[i].Mul(&extended[i], &zeroEvals[i])
+	}
+	ifft(extended, s.extRoots)
+
+	cosetFFT(extended, s.extRoots)
+	zeroOverCoset := make([]fr.Element, fieldElementsPerExtBlob)
+	copy(zeroOverCoset, zeroCoeffs)
+	cosetFFT(zeroOverCoset, s.extRoots)
+	zeroOverCoset = fr.BatchInvert(zeroOverCoset)
+	for i := range extended {
+		extended[i].Mul(&extended[i], &zeroOverCoset[i])
+	}
+	cosetIFFT(extended, s.extRoots)
+	return extended[:fieldElementsPerBlob]
+}
+
+// vanishingPolynomial returns the coefficients of the polynomial vanishing on the cosets of the missing cells.
+// The polynomial vanishing on the roots of unity of order CELLS_PER_EXT_BLOB associated with the missing cells is
+// evaluated at X^FIELD_ELEMENTS_PER_CELL, which vanishes on the whole coset of each missing cell.
+//
+// Spec pseudocode definition:
+//
+//	def construct_vanishing_polynomial(missing_cell_indices: Sequence[CellIndex]) -> Sequence[BLSFieldElement]:
+func (s *cellSetup) vanishingPolynomial(present []bool) []fr.Element {
+	brp := bitReversalIndices(cellsPerExtBlob)
+	short := []fr.Element{fr.One()}
+	for idx, ok := range present {
+		if ok {
+			continue
+		}
+		// Multiply by (X - root), where root is the root of unity of order CELLS_PER_EXT_BLOB of the cell.
+		root := s.extRoots[brp[idx]*fieldElementsPerCell]
+		next := make([]fr.Element, len(short)+1)
+		for i := range short {
+			var t fr.Element
+			t.Mul(&short[i], &root)
+			next[i].Sub(&next[i], &t)
+			next[i+1].Add(&next[i+1], &short[i])
+		}
+		short = next
+	}
+	coeffs := make([]fr.Element, fieldElementsPerExtBlob)
+	for i := range short {
+		coeffs[i*fieldElementsPerCell] = short[i]
+	}
+	return coeffs
+}
+
+// cosetFFT evaluates the polynomial in coefficient form over the coset of the given roots of unity shifted by the
+// primitive root of unity, in place.
+func cosetFFT(vals, roots []fr.Element) {
+	var shift fr.Element
+	shift.SetUint64(primitiveRootOfUnity)
+	scale(vals, shift)
+	fft(vals, roots)
+}
+
+// cosetIFFT interpolates the evaluations over the coset of the given roots of unity shifted by the primitive root
+// of unity into coefficient form, in place.
+func cosetIFFT(vals, roots []fr.Element) {
+	ifft(vals, roots)
+	var shift fr.Element
+	shift.SetUint64(primitiveRootOfUnity)
+	shift.Inverse(&shift)
+	scale(vals, shift)
+}
+
+// scale multiplies the i-th value by factor^i, in place.
+func scale(vals []fr.Element, factor fr.Element) {
+	var pow fr.Element
+	pow.SetOne()
+	for i := range vals {
+		vals[i].Mul(&vals[i], &pow)
+		pow.Mul(&pow, &factor)
+	}
+}
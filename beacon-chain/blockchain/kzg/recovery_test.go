@@ -0,0 +1,45 @@
+package kzg
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestRecoverCellsAndKZGProofs(t *testing.T) {
+	require.NoError(t, Start())
+
+	blob := Blob(GetRandBlob(789))
+	cellsAndProofs, err := ComputeCellsAndKZGProofs(&blob)
+	require.NoError(t, err)
+
+	// Every other cell, in descending order.
+	var indices []uint64
+	var cells []Cell
+	for i := cellsPerExtBlob - 1; i >= 0; i -= 2 {
+		indices = append(indices, uint64(i))
+		cells = append(cells, cellsAndProofs.Cells[i])
+	}
+	recovered, err := RecoverCellsAndKZGProofs(indices, cells)
+	require.NoError(t, err)
+	require.DeepEqual(t, cellsAndProofs, recovered)
+
+	t.Run("too few cells", func(t *testing.T) {
+		_, err := RecoverCellsAndKZGProofs(indices[1:], cells[1:])
+		require.ErrorIs(t, err, errTooFewCells)
+	})
+	t.Run("duplicate index", func(t *testing.T) {
+		duplicate := append([]uint64{indices[1]}, indices[1:]...)
+		_, err := RecoverCellsAndKZGProofs(duplicate, cells)
+		require.ErrorIs(t, err, errDuplicateCell)
+	})
+	t.Run("index out of range", func(t *testing.T) {
+		outOfRange := append([]uint64{cellsPerExtBlob}, indices[1:]...)
+		_, err := RecoverCellsAndKZGProofs(outOfRange, cells)
+		require.ErrorIs(t, err, errInvalidCellIndex)
+	})
+	t.Run("mismatched lengths", func(t *testing.T) {
+		_, err := RecoverCellsAndKZGProofs(indices, cells[1:])
+		require.ErrorIs(t, err, errMismatchedLengths)
+	})
+}
@@ -0,0 +1,136 @@
+package kzg
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ghodss/yaml"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+// The files in testdata are cases of the KZG consensus spec tests (general/eip7594/kzg, kzg-mainnet), gzipped, so that
+// the cells, proofs and recovery of this package are checked against the spec without the spec test archives.
+
+// readSpecVector decodes the data.yaml of a spec test case kept in testdata.
+func readSpecVector(t *testing.T, name string, v any) {
+	f, err := os.Open(filepath.Join("testdata", name+".yaml.gz"))
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, f.Close())
+	}()
+	r, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, yaml.Unmarshal(b, v))
+}
+
+// decodeFixed decodes a hex string of the length of out into out.
+func decodeFixed(t *testing.T, s string, out []byte) {
+	b, err := hexutil.Decode(s)
+	require.NoError(t, err)
+	require.Equal(t, len(out), len(b))
+	copy(out, b)
+}
+
+func TestSpecVectors_ComputeCellsAndKZGProofs(t *testing.T) {
+	require.NoError(t, Start())
+	test := &struct {
+		Input struct {
+			Blob string `json:"blob"`
+		} `json:"input"`
+		Output [][]string `json:"output"`
+	}{}
+	readSpecVector(t, "compute_cells_and_kzg_proofs_case_valid_6e773f256383918c", test)
+	var blob Blob
+	decodeFixed(t, test.Input.Blob, blob[:])
+	require.Equal(t, 2, len(test.Output))
+	require.Equal(t, cellsPerExtBlob, len(test.Output[0]))
+	require.Equal(t, cellsPerExtBlob, len(test.Output[1]))
+	want := CellsAndProofs{Cells: make([]Cell, cellsPerExtBlob), Proofs: make([]Proof, cellsPerExtBlob)}
+	for i := range want.Cells {
+		decodeFixed(t, test.Output[0][i], want.Cells[i][:])
+		decodeFixed(t, test.Output[1][i], want.Proofs[i][:])
+	}
+
+	got, err := ComputeCellsAndKZGProofs(&blob)
+	require.NoError(t, err)
+	require.DeepEqual(t, want, got)
+
+	// The cells and proofs of the spec are recovered from any half of the cells, and verify against the commitment.
+	t.Run("recover every other cell", func(t *testing.T) {
+		var indices []uint64
+		var cells []Cell
+		for i := 1; i < cellsPerExtBlob; i += 2 {
+			indices = append(indices, uint64(i))
+			cells = append(cells, want.Cells[i])
+		}
+		recovered, err := RecoverCellsAndKZGProofs(indices, cells)
+		require.NoError(t, err)
+		require.DeepEqual(t, want, recovered)
+	})
+	t.Run("recover extension", func(t *testing.T) {
+		var indices []uint64
+		for i := cellsPerExtBlob / 2; i < cellsPerExtBlob; i++ {
+			indices = append(indices, uint64(i))
+		}
+		recovered, err := RecoverCellsAndKZGProofs(indices, want.Cells[cellsPerExtBlob/2:])
+		require.NoError(t, err)
+		require.DeepEqual(t, want, recovered)
+	})
+	t.Run("verify", func(t *testing.T) {
+		commitment, err := BlobToKZGCommitment(&blob)
+		require.NoError(t, err)
+		commitments := make([]Commitment, cellsPerExtBlob)
+		indices := make([]uint64, cellsPerExtBlob)
+		for i := range indices {
+			commitments[i], indices[i] = commitment, uint64(i)
+		}
+		ok, err := VerifyCellKZGProofBatch(commitments, indices, want.Cells, want.Proofs)
+		require.NoError(t, err)
+		require.Equal(t, true, ok)
+	})
+}
+
+func TestSpecVectors_VerifyCellKZGProofBatch(t *testing.T) {
+	require.NoError(t, Start())
+	for _, name := range []string{
+		"verify_cell_kzg_proof_batch_case_valid_multiple_blobs_9a17d94b4a0da274",
+		"verify_cell_kzg_proof_batch_case_valid_same_cell_multiple_times_e08731c0853b0a0b",
+		"verify_cell_kzg_proof_batch_case_incorrect_cell_48bcbf9c0aafbbf3",
+		"verify_cell_kzg_proof_batch_case_incorrect_commitment_659a51e9e8c9643a",
+		"verify_cell_kzg_proof_batch_case_incorrect_proof_ba29feeee48d58ec",
+	} {
+		t.Run(name, func(t *testing.T) {
+			test := &struct {
+				Input struct {
+					Commitments []string `json:"commitments"`
+					CellIndices []uint64 `json:"cell_indices"`
+					Cells       []string `json:"cells"`
+					Proofs      []string `json:"proofs"`
+				} `json:"input"`
+				Output bool `json:"output"`
+			}{}
+			readSpecVector(t, name, test)
+			commitments := make([]Commitment, len(test.Input.Commitments))
+			for i, c := range test.Input.Commitments {
+				decodeFixed(t, c, commitments[i][:])
+			}
+			cells := make([]Cell, len(test.Input.Cells))
+			for i, c := range test.Input.Cells {
+				decodeFixed(t, c, cells[i][:])
+			}
+			proofs := make([]Proof, len(test.Input.Proofs))
+			for i, p := range test.Input.Proofs {
+				decodeFixed(t, p, proofs[i][:])
+			}
+			ok, err := VerifyCellKZGProofBatch(commitments, test.Input.CellIndices, cells, proofs)
+			require.NoError(t, err)
+			require.Equal(t, test.Output, ok)
+		})
+	}
+}
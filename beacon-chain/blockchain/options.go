@@ -16,6 +16,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 )
 
@@ -85,6 +86,14 @@ func WithTrackedValidatorsCache(c *cache.TrackedValidatorsCache) Option {
 	}
 }
 
+// WithValidatorIndexCache for the index of validators served by the validator query endpoint.
+func WithValidatorIndexCache(c *cache.ValidatorIndexCache) Option {
+	return func(s *Service) error {
+		s.cfg.ValidatorIndexCache = c
+		return nil
+	}
+}
+
 // WithAttestationPool for attestation lifecycle after chain inclusion.
 func WithAttestationPool(p attestations.Pool) Option {
 	return func(s *Service) error {
@@ -199,9 +208,44 @@ func WithBlobStorage(b *filesystem.BlobStorage) Option {
 	}
 }
 
+// WithDataColumnStorage sets the data column storage backend for the blockchain service.
+func WithDataColumnStorage(b *filesystem.DataColumnStorage) Option {
+	return func(s *Service) error {
+		s.dataColumnStorage = b
+		return nil
+	}
+}
+
+// WithCustodyColumns sets the data columns the node custodies, which must be available for a block to be imported
+// once PeerDAS is active.
+func WithCustodyColumns(columns map[uint64]bool) Option {
+	return func(s *Service) error {
+		s.custodyColumns = columns
+		return nil
+	}
+}
+
 func WithSyncChecker(checker Checker) Option {
 	return func(s *Service) error {
 		s.cfg.SyncChecker = checker
 		return nil
 	}
 }
+
+// WithValidatorHistory saves the duty records of every validator for each epoch, keeping the records of the given
+// number of most recent epochs, or every record if retention is zero.
+func WithValidatorHistory(retention primitives.Epoch) Option {
+	return func(s *Service) error {
+		s.validatorHistory = &validatorHistory{retention: retention}
+		return nil
+	}
+}
+
+// WithRewardSummaries saves the attestation and sync committee rewards of every validator for each epoch, keeping
+// the rewards of the given number of most recent epochs, or every reward if retention is zero.
+func WithRewardSummaries(retention primitives.Epoch) Option {
+	return func(s *Service) error {
+		s.rewardSummaries = &rewardSummaries{retention: retention}
+		return nil
+	}
+}
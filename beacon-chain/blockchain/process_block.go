@@ -339,8 +339,8 @@ func (s *Service) updateEpochBoundaryCaches(ctx context.Context, st state.Beacon
 	return nil
 }
 
-// Epoch boundary tasks: it copies the headState and updates the epoch boundary
-// caches.
+// Epoch boundary tasks: it saves the validator duty records and rewards of the ending epoch, copies the headState,
+// and updates the epoch boundary caches and the validator index.
 func (s *Service) handleEpochBoundary(ctx context.Context, slot primitives.Slot, headState state.BeaconState, blockRoot []byte) error {
 	ctx, span := trace.StartSpan(ctx, "blockChain.handleEpochBoundary")
 	defer span.End()
@@ -351,11 +351,16 @@ func (s *Service) handleEpochBoundary(ctx context.Context, slot primitives.Slot,
 	if !slots.IsEpochEnd(slot) {
 		return nil
 	}
+	if slots.ToEpoch(headState.Slot()) == slots.ToEpoch(slot) {
+		s.saveValidatorHistory(headState, bytesutil.ToBytes32(blockRoot))
+		s.saveRewardSummaries(headState, bytesutil.ToBytes32(blockRoot))
+	}
 	copied := headState.Copy()
 	copied, err := transition.ProcessSlotsUsingNextSlotCache(ctx, copied, blockRoot, slot+1)
 	if err != nil {
 		return err
 	}
+	s.updateValidatorIndex(copied)
 	return s.updateEpochBoundaryCaches(ctx, copied)
 }
 
@@ -530,6 +535,9 @@ func (s *Service) isDataAvailable(ctx context.Context, root [32]byte, signed int
 	if block == nil {
 		return errors.New("invalid nil beacon block")
 	}
+	if s.dataColumnStorage != nil && params.PeerDASActive(slots.ToEpoch(block.Slot())) {
+		return s.areDataColumnsAvailable(ctx, root, signed)
+	}
 	// We are only required to check within MIN_EPOCHS_FOR_BLOB_SIDECARS_REQUESTS
 	if !params.WithinDAPeriod(slots.ToEpoch(block.Slot()), slots.ToEpoch(s.CurrentSlot())) {
 		return nil
@@ -593,6 +601,50 @@ func (s *Service) isDataAvailable(ctx context.Context, root [32]byte, signed int
 	}
 }
 
+// areDataColumnsAvailable is the PeerDAS counterpart of isDataAvailable. It blocks until every column the node
+// custodies for the block is stored, or the context is done. Sampling the remaining columns is the job of the
+// das.DataColumnSampler used by the sync service.
+func (s *Service) areDataColumnsAvailable(ctx context.Context, root [32]byte, signed interfaces.ReadOnlySignedBeaconBlock) error {
+	block := signed.Block()
+	if !s.dataColumnStorage.WithinRetentionPeriod(slots.ToEpoch(block.Slot()), slots.ToEpoch(s.CurrentSlot())) {
+		return nil
+	}
+	body := block.Body()
+	if body == nil {
+		return errors.New("invalid nil beacon block body")
+	}
+	kzgCommitments, err := body.BlobKzgCommitments()
+	if err != nil {
+		return errors.Wrap(err, "could not get KZG commitments")
+	}
+	if len(kzgCommitments) == 0 {
+		return nil
+	}
+
+	// Subscribe before reading the stored indices, so that no column saved in between is missed.
+	idents := make(chan filesystem.DataColumnIdent, fieldparams.NumberOfColumns)
+	sub := s.dataColumnStorage.Subscribe(idents)
+	defer sub.Unsubscribe()
+	stored := s.dataColumnStorage.Indices(root)
+	missing := make(map[uint64]bool, len(s.custodyColumns))
+	for c := range s.custodyColumns {
+		if !stored[c] {
+			missing[c] = true
+		}
+	}
+	for len(missing) > 0 {
+		select {
+		case id := <-idents:
+			if id.Root == root {
+				delete(missing, id.Index)
+			}
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), "context deadline waiting for %d data column sidecars slot: %d, BlockRoot: %#x", len(missing), block.Slot(), root)
+		}
+	}
+	return nil
+}
+
 func daCheckLogFields(root [32]byte, slot primitives.Slot, expected, missing int) logrus.Fields {
 	return logrus.Fields{
 		"slot":          slot,
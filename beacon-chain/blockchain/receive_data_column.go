@@ -0,0 +1,17 @@
+package blockchain
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+)
+
+// ReceiveDataColumn saves the data column sidecar to the data column storage, which notifies
+// the DA check waiting on the column.
+func (s *Service) ReceiveDataColumn(_ context.Context, dc blocks.VerifiedRODataColumn) error {
+	if s.dataColumnStorage == nil {
+		return errors.New("data column storage is not configured")
+	}
+	return s.dataColumnStorage.Save(dc)
+}
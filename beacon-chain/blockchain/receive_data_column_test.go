@@ -0,0 +1,65 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestService_ReceiveDataColumn(t *testing.T) {
+	_, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 1, 1)
+	t.Run("no storage", func(t *testing.T) {
+		s, _ := minimalTestService(t)
+		require.ErrorContains(t, "data column storage is not configured", s.ReceiveDataColumn(context.Background(), blocks.NewVerifiedRODataColumn(columns[0])))
+	})
+	t.Run("saved to storage", func(t *testing.T) {
+		store := filesystem.NewEphemeralDataColumnStorage(t)
+		s, _ := minimalTestService(t, WithDataColumnStorage(store))
+		require.NoError(t, s.ReceiveDataColumn(context.Background(), blocks.NewVerifiedRODataColumn(columns[3])))
+		require.DeepEqual(t, map[uint64]bool{3: true}, store.Indices(columns[3].BlockRoot()))
+	})
+}
+
+func TestService_areDataColumnsAvailable(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.DenebForkEpoch = 0
+	cfg.Eip7594ForkEpoch = 0
+	params.OverrideBeaconConfig(cfg)
+
+	blk, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 1, 2)
+	custody := map[uint64]bool{0: true, 5: true}
+
+	t.Run("custody columns arrive", func(t *testing.T) {
+		store := filesystem.NewEphemeralDataColumnStorage(t)
+		s, _ := minimalTestService(t, WithDataColumnStorage(store), WithCustodyColumns(custody))
+		require.NoError(t, s.ReceiveDataColumn(context.Background(), blocks.NewVerifiedRODataColumn(columns[0])))
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			// Columns outside of the custody set do not satisfy the check.
+			if err := s.ReceiveDataColumn(context.Background(), blocks.NewVerifiedRODataColumn(columns[6])); err != nil {
+				t.Error(err)
+			}
+			if err := s.ReceiveDataColumn(context.Background(), blocks.NewVerifiedRODataColumn(columns[5])); err != nil {
+				t.Error(err)
+			}
+		}()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		require.NoError(t, s.isDataAvailable(ctx, blk.Root(), blk))
+	})
+	t.Run("custody column missing", func(t *testing.T) {
+		store := filesystem.NewEphemeralDataColumnStorage(t)
+		s, _ := minimalTestService(t, WithDataColumnStorage(store), WithCustodyColumns(custody))
+		require.NoError(t, s.ReceiveDataColumn(context.Background(), blocks.NewVerifiedRODataColumn(columns[0])))
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		require.ErrorContains(t, "context deadline waiting for 1 data column sidecars", s.isDataAvailable(ctx, blk.Root(), blk))
+	})
+}
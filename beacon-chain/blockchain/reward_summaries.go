@@ -0,0 +1,79 @@
+package blockchain
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/rewardsummary"
+	coreTime "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/time"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+)
+
+// rewardSummaries tracks the saving of the rewards of every validator, which is done when the head reaches the last
+// slot of an epoch for the attestation rewards of the previous epoch and the sync committee rewards of the epoch.
+type rewardSummaries struct {
+	// retention is the number of epochs of rewards kept, or zero to keep every reward.
+	retention primitives.Epoch
+	// saved is the head state the rewards were last saved from.
+	saved savedHead
+	// lock serializes the saving of rewards, which is done in the background.
+	lock sync.Mutex
+}
+
+// saveRewardSummaries saves the rewards computed from a head state at the last slot of its epoch. Each epoch is
+// saved in the background, and saved again if the head of the epoch changes.
+func (s *Service) saveRewardSummaries(headState state.BeaconState, headRoot [32]byte) {
+	r := s.rewardSummaries
+	if r == nil || headState.Version() < version.Altair {
+		return
+	}
+	epoch := coreTime.CurrentEpoch(headState)
+	seq, ok := r.saved.schedule(headState.Slot(), headRoot)
+	if !ok {
+		return
+	}
+	st := headState.Copy()
+	go func() {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		if !r.saved.latest(seq) {
+			return
+		}
+		if err := s.computeRewardSummaries(s.ctx, st); err != nil {
+			log.WithError(err).WithField("epoch", epoch).Error("Could not save reward summaries")
+		}
+	}()
+}
+
+func (s *Service) computeRewardSummaries(ctx context.Context, st state.BeaconState) error {
+	epoch := coreTime.CurrentEpoch(st)
+	syncRewards, err := rewardsummary.CurrentSyncCommitteeRewards(st)
+	if err != nil {
+		return errors.Wrap(err, "could not compute sync committee rewards")
+	}
+	if err := s.cfg.BeaconDB.SaveSyncCommitteeRewards(ctx, epoch, syncRewards); err != nil {
+		return errors.Wrap(err, "could not save sync committee rewards")
+	}
+	// Attestation rewards are not served for phase 0 epochs.
+	prevEpoch := coreTime.PrevEpoch(st)
+	if epoch > 0 && prevEpoch >= params.BeaconConfig().AltairForkEpoch {
+		rewards, ideal, err := rewardsummary.AttestationRewards(ctx, st)
+		if err != nil {
+			return errors.Wrap(err, "could not compute attestation rewards")
+		}
+		if err := s.cfg.BeaconDB.SaveAttestationRewards(ctx, prevEpoch, rewards, ideal); err != nil {
+			return errors.Wrap(err, "could not save attestation rewards")
+		}
+	}
+	retention := s.rewardSummaries.retention
+	if retention > 0 && epoch >= retention {
+		if err := s.cfg.BeaconDB.DeleteRewardsBefore(ctx, epoch+1-retention); err != nil {
+			return errors.Wrap(err, "could not prune rewards")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/rewardsummary"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestComputeRewardSummaries(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	params.OverrideBeaconConfig(cfg)
+
+	service, tr := minimalTestService(t, WithRewardSummaries(2))
+	ctx := tr.ctx
+	spe := params.BeaconConfig().SlotsPerEpoch
+
+	st, _ := util.DeterministicGenesisStateAltair(t, 64)
+	syncCommittee, err := altair.NextSyncCommittee(ctx, st)
+	require.NoError(t, err)
+	require.NoError(t, st.SetCurrentSyncCommittee(syncCommittee))
+	participation := make([]byte, 64)
+	for i := range participation {
+		participation[i] = 0b111
+	}
+	participation[3] = 0
+	require.NoError(t, st.SetPreviousParticipationBits(participation))
+
+	require.NoError(t, st.SetSlot(2*spe-1))
+	require.NoError(t, service.computeRewardSummaries(ctx, st))
+	rewards, ideal, err := tr.db.AttestationRewards(ctx, 0)
+	require.NoError(t, err)
+	require.Equal(t, 64, len(rewards))
+	assert.Equal(t, true, rewards[0].Head > 0)
+	assert.Equal(t, true, rewards[3].Source < 0)
+	assert.Equal(t, rewards[0], rewards[1])
+	require.Equal(t, 1, len(ideal))
+	assert.Equal(t, rewards[0].Head, ideal[0].Head)
+	syncRewards, err := tr.db.SyncCommitteeRewards(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int(params.BeaconConfig().SyncCommitteeSize), len(syncRewards.Members))
+	assert.NotEqual(t, uint64(0), syncRewards.ParticipantReward)
+
+	// Saving the rewards of epoch 2 prunes the rewards of epoch 0 with a retention of 2 epochs.
+	require.NoError(t, st.SetSlot(3*spe-1))
+	require.NoError(t, service.computeRewardSummaries(ctx, st))
+	attRange, err := tr.db.AttestationRewardsRange(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, &rewardsummary.EpochRange{Earliest: 1, Latest: 1}, attRange)
+	syncRange, err := tr.db.SyncCommitteeRewardsRange(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, &rewardsummary.EpochRange{Earliest: 1, Latest: 2}, syncRange)
+	_, _, err = tr.db.AttestationRewards(ctx, 0)
+	require.ErrorIs(t, err, db.ErrNotFoundRewards)
+	_, err = tr.db.SyncCommitteeRewards(ctx, primitives.Epoch(0))
+	require.ErrorIs(t, err, db.ErrNotFoundRewards)
+}
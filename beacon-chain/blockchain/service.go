@@ -67,7 +67,12 @@ type Service struct {
 	blobNotifiers                 *blobNotifierMap
 	blockBeingSynced              *currentlySyncingBlock
 	blobStorage                   *filesystem.BlobStorage
+	dataColumnStorage             *filesystem.DataColumnStorage
+	custodyColumns                map[uint64]bool
 	lastPublishedLightClientEpoch primitives.Epoch
+	validatorLifecycle            validatorLifecycle
+	validatorHistory              *validatorHistory
+	rewardSummaries               *rewardSummaries
 }
 
 // config options for the service.
@@ -78,6 +83,7 @@ type config struct {
 	DepositCache            cache.DepositCache
 	PayloadIDCache          *cache.PayloadIDCache
 	TrackedValidatorsCache  *cache.TrackedValidatorsCache
+	ValidatorIndexCache     *cache.ValidatorIndexCache
 	AttPool                 attestations.Pool
 	ExitPool                voluntaryexits.PoolManager
 	SlashingPool            slashings.PoolManager
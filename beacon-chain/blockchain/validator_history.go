@@ -0,0 +1,141 @@
+package blockchain
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/dutyhistory"
+	coreTime "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/time"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// validatorHistory tracks the saving of the duty records of every validator, which is done for the previous epoch
+// when the head reaches the last slot of an epoch.
+type validatorHistory struct {
+	// retention is the number of epochs of records kept, or zero to keep every record.
+	retention primitives.Epoch
+	// saved is the head state the records were last saved from.
+	saved savedHead
+	// lock serializes the saving of records, which is done in the background, and guards duties.
+	lock sync.Mutex
+	// duties are the assignments of the epochs of the last head states the records were saved from, which are needed
+	// to find the missed proposals and sync committee signatures of an epoch once it is the previous epoch. The
+	// duties of the previous epoch are kept so that the records of an epoch can be saved again from a later head.
+	duties map[primitives.Epoch]*dutyhistory.Duties
+}
+
+// savedHead identifies the head state that the records of an epoch were last scheduled to be saved from.
+type savedHead struct {
+	sync.Mutex
+	epoch primitives.Epoch
+	slot  primitives.Slot
+	root  [32]byte
+	seq   uint64
+}
+
+// schedule returns the sequence number of a save from the head state of the given slot and block root, or false if
+// its epoch was already saved from this head or from a later one. The head state at the last slot of an epoch may be
+// stale when the block of that slot arrives late, so a later head of the same epoch saves the epoch again.
+func (h *savedHead) schedule(slot primitives.Slot, root [32]byte) (uint64, bool) {
+	h.Lock()
+	defer h.Unlock()
+	epoch := slots.ToEpoch(slot)
+	if h.seq > 0 && (epoch < h.epoch || (epoch == h.epoch && (slot < h.slot || root == h.root))) {
+		return 0, false
+	}
+	h.epoch, h.slot, h.root = epoch, slot, root
+	h.seq++
+	return h.seq, true
+}
+
+// latest returns whether the given save is the last one scheduled, as the saves from stale head states which have
+// been superseded by the time they run are skipped.
+func (h *savedHead) latest(seq uint64) bool {
+	h.Lock()
+	defer h.Unlock()
+	return seq == h.seq
+}
+
+// saveValidatorHistory saves the duty records of the previous epoch of a head state at the last slot of its epoch.
+// Each epoch is saved in the background, and saved again if the head of the epoch changes.
+func (s *Service) saveValidatorHistory(headState state.BeaconState, headRoot [32]byte) {
+	h := s.validatorHistory
+	if h == nil || headState.Version() < version.Altair {
+		return
+	}
+	epoch := coreTime.CurrentEpoch(headState)
+	if epoch == 0 {
+		return
+	}
+	seq, ok := h.saved.schedule(headState.Slot(), headRoot)
+	if !ok {
+		return
+	}
+	st := headState.Copy()
+	go func() {
+		h.lock.Lock()
+		defer h.lock.Unlock()
+		if !h.saved.latest(seq) {
+			return
+		}
+		if err := s.computeValidatorHistory(s.ctx, st, headRoot); err != nil {
+			log.WithError(err).WithField("epoch", epoch-1).Error("Could not save validator history")
+		}
+	}()
+}
+
+func (s *Service) computeValidatorHistory(ctx context.Context, st state.BeaconState, headRoot [32]byte) error {
+	h := s.validatorHistory
+	prevEpoch := coreTime.PrevEpoch(st)
+	blks, err := s.canonicalBlocksSince(ctx, headRoot, prevEpoch)
+	if err != nil {
+		return err
+	}
+	prevDuties := h.duties[prevEpoch]
+	records, err := dutyhistory.PreviousEpochRecords(ctx, st, blks, prevDuties)
+	if err != nil {
+		return errors.Wrap(err, "could not compute duty records")
+	}
+	duties, err := dutyhistory.CurrentEpochDuties(ctx, st)
+	if err != nil {
+		return errors.Wrap(err, "could not compute duties")
+	}
+	h.duties = map[primitives.Epoch]*dutyhistory.Duties{prevEpoch: prevDuties, duties.Epoch: duties}
+	if err := s.cfg.BeaconDB.SaveValidatorHistory(ctx, prevEpoch, records); err != nil {
+		return errors.Wrap(err, "could not save duty records")
+	}
+	if h.retention > 0 && prevEpoch >= h.retention {
+		if err := s.cfg.BeaconDB.DeleteValidatorHistoryBefore(ctx, prevEpoch+1-h.retention); err != nil {
+			return errors.Wrap(err, "could not prune duty records")
+		}
+	}
+	return nil
+}
+
+// canonicalBlocksSince returns the blocks from the start of an epoch to the given block, following parent roots.
+func (s *Service) canonicalBlocksSince(ctx context.Context, root [32]byte, epoch primitives.Epoch) ([]interfaces.ReadOnlySignedBeaconBlock, error) {
+	start, err := slots.EpochStart(epoch)
+	if err != nil {
+		return nil, err
+	}
+	var blks []interfaces.ReadOnlySignedBeaconBlock
+	for {
+		blk, err := s.getBlock(ctx, root)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get block %#x", root)
+		}
+		if blk.Block().Slot() < start {
+			return blks, nil
+		}
+		blks = append(blks, blk)
+		if blk.Block().Slot() == 0 {
+			return blks, nil
+		}
+		root = blk.Block().ParentRoot()
+	}
+}
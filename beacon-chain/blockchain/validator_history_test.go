@@ -0,0 +1,104 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestComputeValidatorHistory(t *testing.T) {
+	service, tr := minimalTestService(t, WithValidatorHistory(2))
+	ctx := tr.ctx
+	spe := params.BeaconConfig().SlotsPerEpoch
+
+	st, _ := util.DeterministicGenesisStateAltair(t, 64)
+	syncCommittee, err := altair.NextSyncCommittee(ctx, st)
+	require.NoError(t, err)
+	require.NoError(t, st.SetCurrentSyncCommittee(syncCommittee))
+
+	var parent [32]byte
+	addBlock := func(slot primitives.Slot, proposer primitives.ValidatorIndex) [32]byte {
+		b := util.NewBeaconBlockAltair()
+		b.Block.Slot = slot
+		b.Block.ProposerIndex = proposer
+		b.Block.ParentRoot = parent[:]
+		util.SaveBlock(t, ctx, tr.db, b)
+		root, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		parent = root
+		return root
+	}
+	addBlock(0, 0)
+	addBlock(3, 7)
+	head := addBlock(spe+2, 8)
+
+	require.NoError(t, st.SetSlot(2*spe-1))
+	require.NoError(t, service.computeValidatorHistory(ctx, st, head))
+	history, err := tr.db.ValidatorHistory(ctx, 7, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(history))
+	assert.Equal(t, primitives.Epoch(0), history[0].Epoch)
+	assert.Equal(t, uint8(1), history[0].Record.Proposed)
+	// The duties of the first epoch are not known, as no records were saved in the epoch before.
+	assert.Equal(t, false, history[0].Record.DutiesKnown)
+
+	head = addBlock(2*spe+1, 9)
+	require.NoError(t, st.SetSlot(3*spe-1))
+	require.NoError(t, service.computeValidatorHistory(ctx, st, head))
+	history, err = tr.db.ValidatorHistory(ctx, 8, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(history))
+	assert.Equal(t, primitives.Epoch(1), history[1].Epoch)
+	assert.Equal(t, uint8(1), history[1].Record.Proposed)
+	assert.Equal(t, true, history[1].Record.DutiesKnown)
+
+	// Saving epoch 1 again from a later head of the same epoch, such as the post-state of a late block at the
+	// last slot, overwrites its records with the duties still known.
+	head = addBlock(3*spe-1, 11)
+	require.NoError(t, service.computeValidatorHistory(ctx, st, head))
+	history, err = tr.db.ValidatorHistory(ctx, 8, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(history))
+	assert.Equal(t, true, history[1].Record.DutiesKnown)
+	assert.Equal(t, uint8(1), history[1].Record.Proposed)
+
+	// Saving the records of epoch 2 prunes the records of epoch 0 with a retention of 2 epochs.
+	head = addBlock(3*spe+1, 10)
+	require.NoError(t, st.SetSlot(4*spe-1))
+	require.NoError(t, service.computeValidatorHistory(ctx, st, head))
+	history, err = tr.db.ValidatorHistory(ctx, 0, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(history))
+	assert.Equal(t, primitives.Epoch(1), history[0].Epoch)
+	assert.Equal(t, primitives.Epoch(2), history[1].Epoch)
+}
+
+func TestSavedHead_Schedule(t *testing.T) {
+	spe := params.BeaconConfig().SlotsPerEpoch
+	h := &savedHead{}
+	stale, late := [32]byte{'a'}, [32]byte{'b'}
+
+	// The stale head of an epoch whose last block has not arrived yet.
+	seq, ok := h.schedule(2*spe-3, stale)
+	require.Equal(t, true, ok)
+	_, ok = h.schedule(2*spe-3, stale)
+	assert.Equal(t, false, ok, "The same head must not be saved twice")
+
+	// The late block of the last slot saves the epoch again, superseding the save from the stale head.
+	next, ok := h.schedule(2*spe-1, late)
+	require.Equal(t, true, ok)
+	assert.Equal(t, false, h.latest(seq))
+	assert.Equal(t, true, h.latest(next))
+	_, ok = h.schedule(2*spe-3, stale)
+	assert.Equal(t, false, ok, "An earlier head of the epoch must not be saved")
+	_, ok = h.schedule(spe-1, [32]byte{'c'})
+	assert.Equal(t, false, ok, "A past epoch must not be saved")
+
+	_, ok = h.schedule(3*spe-1, late)
+	assert.Equal(t, true, ok)
+}
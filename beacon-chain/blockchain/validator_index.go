@@ -0,0 +1,23 @@
+package blockchain
+
+import (
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// updateValidatorIndex rebuilds the validator index from the state at the start of a new epoch. The index is built
+// in the background to stay out of the block processing path, and only once for each epoch.
+func (s *Service) updateValidatorIndex(st state.ReadOnlyBeaconState) {
+	c := s.cfg.ValidatorIndexCache
+	if c == nil {
+		return
+	}
+	if index := c.Index(); index != nil && slots.ToEpoch(st.Slot()) <= index.Epoch {
+		return
+	}
+	go func() {
+		if err := c.Update(st); err != nil {
+			log.WithError(err).Error("Could not update validator index")
+		}
+	}()
+}
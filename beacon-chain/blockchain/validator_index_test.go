@@ -0,0 +1,35 @@
+package blockchain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+func TestUpdateValidatorIndex(t *testing.T) {
+	// Nothing is done without a cache.
+	s := &Service{cfg: &config{}}
+	st, _ := util.DeterministicGenesisState(t, 16)
+	s.updateValidatorIndex(st)
+
+	c := cache.NewValidatorIndexCache()
+	s.cfg.ValidatorIndexCache = c
+	start, err := slots.EpochStart(3)
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(start))
+	s.updateValidatorIndex(st)
+
+	var index *cache.ValidatorIndex
+	for deadline := time.Now().Add(time.Second); index == nil && time.Now().Before(deadline); {
+		time.Sleep(10 * time.Millisecond)
+		index = c.Index()
+	}
+	require.NotNil(t, index)
+	require.Equal(t, primitives.Epoch(3), index.Epoch)
+	require.Equal(t, 16, index.NumValidators())
+}
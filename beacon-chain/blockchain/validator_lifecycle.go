@@ -0,0 +1,107 @@
+package blockchain
+
+import (
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
+	coreTime "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/time"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// validatorLifecycle tracks the validator lifecycle transitions notified for the head.
+type validatorLifecycle struct {
+	// epoch is the current epoch of the last head state whose transitions were notified.
+	epoch primitives.Epoch
+	// done is closed once the last notification has been sent.
+	done chan struct{}
+	// slashed are the slashed flags of the validators in the last head state whose transitions were notified. They
+	// are only used by the notifications, which run one after the other.
+	slashed []bool
+}
+
+// validatorLifecycleChanges returns the lifecycle transitions of the epochs after since, up to the current epoch of
+// the state, by epoch, along with the slashed flags of the validators in the state.
+//
+// Slashings are found by comparing the slashed flags with those of a state at an earlier epoch transition, and are
+// reported at the current epoch. No slashings are reported without earlier flags.
+func validatorLifecycleChanges(
+	st state.ReadOnlyBeaconState,
+	since primitives.Epoch,
+	slashed []bool,
+) (map[primitives.Epoch][]*statefeed.ValidatorLifecycleChange, []bool, error) {
+	epoch := coreTime.CurrentEpoch(st)
+	changes := make(map[primitives.Epoch][]*statefeed.ValidatorLifecycleChange)
+	flags := make([]bool, st.NumValidators())
+	err := st.ReadFromEveryValidator(func(idx int, val state.ReadOnlyValidator) error {
+		add := func(e primitives.Epoch, t statefeed.ValidatorLifecycleTransition) {
+			if e <= since || e > epoch {
+				return
+			}
+			changes[e] = append(changes[e], &statefeed.ValidatorLifecycleChange{
+				Index:      primitives.ValidatorIndex(idx),
+				Pubkey:     val.PublicKey(),
+				Transition: t,
+			})
+		}
+		add(val.ActivationEligibilityEpoch(), statefeed.ActivationEligible)
+		add(val.ActivationEpoch(), statefeed.Activated)
+		flags[idx] = val.Slashed()
+		if slashed != nil && val.Slashed() && (idx >= len(slashed) || !slashed[idx]) {
+			add(epoch, statefeed.Slashed)
+		}
+		add(val.ExitEpoch(), statefeed.Exited)
+		add(val.WithdrawableEpoch(), statefeed.Withdrawable)
+		return nil
+	})
+	return changes, flags, err
+}
+
+// notifyValidatorLifecycle sends the lifecycle transitions of the epochs crossed by a new head state on the state
+// feed, one event per epoch. These are the epochs after the last notified one, including those without blocks. When
+// the new head is on another chain, the transitions of the epochs after the common ancestor of the old and new head
+// at forkSlot are computed again. The first head only notifies its current epoch. This function requires a lock on
+// forkchoice.
+func (s *Service) notifyValidatorLifecycle(st state.ReadOnlyBeaconState, forkSlot primitives.Slot) {
+	l := &s.validatorLifecycle
+	epoch := coreTime.CurrentEpoch(st)
+	since := l.epoch
+	if forkEpoch := slots.ToEpoch(forkSlot); forkEpoch < since {
+		since = forkEpoch
+	}
+	if l.done == nil && epoch > 0 {
+		since = epoch - 1
+	}
+	if epoch <= since {
+		return
+	}
+	l.epoch = epoch
+	prev, done := l.done, make(chan struct{})
+	l.done = done
+	// Reading every validator is done in the background to stay out of the block processing path, once the previous
+	// notification has been sent so that the events and slashed flags follow the head.
+	go func() {
+		defer close(done)
+		if prev != nil {
+			<-prev
+		}
+		changes, slashed, err := validatorLifecycleChanges(st, since, l.slashed)
+		if err != nil {
+			log.WithError(err).Error("Could not compute validator lifecycle transitions")
+			return
+		}
+		l.slashed = slashed
+		for e := since + 1; e <= epoch; e++ {
+			if len(changes[e]) == 0 {
+				continue
+			}
+			s.cfg.StateNotifier.StateFeed().Send(&feed.Event{
+				Type: statefeed.ValidatorLifecycle,
+				Data: &statefeed.ValidatorLifecycleData{
+					Epoch:       e,
+					Transitions: changes[e],
+				},
+			})
+		}
+	}()
+}
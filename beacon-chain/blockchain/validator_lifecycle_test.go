@@ -0,0 +1,117 @@
+package blockchain
+
+import (
+	"testing"
+	"time"
+
+	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+func TestNotifyValidatorLifecycle(t *testing.T) {
+	far := params.BeaconConfig().FarFutureEpoch
+	validator := func(eligible, active, exit, withdrawable primitives.Epoch, slashed bool) *ethpb.Validator {
+		return &ethpb.Validator{
+			PublicKey:                  make([]byte, 48),
+			WithdrawalCredentials:      make([]byte, 32),
+			ActivationEligibilityEpoch: eligible,
+			ActivationEpoch:            active,
+			ExitEpoch:                  exit,
+			WithdrawableEpoch:          withdrawable,
+			Slashed:                    slashed,
+		}
+	}
+	headState := func(epoch primitives.Epoch, vals ...*ethpb.Validator) state.BeaconState {
+		st, err := util.NewBeaconState()
+		require.NoError(t, err)
+		require.NoError(t, st.SetValidators(vals))
+		start, err := slots.EpochStart(epoch)
+		require.NoError(t, err)
+		require.NoError(t, st.SetSlot(start))
+		return st
+	}
+
+	notifier := &mock.MockStateNotifier{RecordEvents: true}
+	s := &Service{cfg: &config{StateNotifier: notifier}}
+	// Subscribe the recording channel of the mock before events are sent.
+	notifier.StateFeed()
+	received := func(n int) []*statefeed.ValidatorLifecycleData {
+		var events []*feed.Event
+		for deadline := time.Now().Add(time.Second); len(events) < n && time.Now().Before(deadline); {
+			time.Sleep(10 * time.Millisecond)
+			events = notifier.ReceivedEvents()
+		}
+		require.Equal(t, n, len(events))
+		data := make([]*statefeed.ValidatorLifecycleData, 0, n)
+		for _, e := range events {
+			require.Equal(t, feed.EventType(statefeed.ValidatorLifecycle), e.Type)
+			d, ok := e.Data.(*statefeed.ValidatorLifecycleData)
+			require.Equal(t, true, ok)
+			data = append(data, d)
+		}
+		return data
+	}
+
+	// The first head only notifies its current epoch, and slashings are found from the next epoch transition.
+	s.notifyValidatorLifecycle(headState(9,
+		validator(0, 0, far, far, true),
+		validator(9, far, far, far, false),
+		validator(0, 0, far, far, false),
+	), 0)
+	events := received(1)
+	require.Equal(t, primitives.Epoch(9), events[0].Epoch)
+	require.DeepEqual(t, []*statefeed.ValidatorLifecycleChange{{Index: 1, Transition: statefeed.ActivationEligible}}, events[0].Transitions)
+
+	// The epochs crossed by the head are all notified, including the epochs without blocks.
+	epoch9Slot, err := slots.EpochStart(9)
+	require.NoError(t, err)
+	s.notifyValidatorLifecycle(headState(11,
+		validator(0, 0, far, far, true),
+		validator(9, 10, far, far, false),
+		validator(0, 0, 11, 11+256, true),
+		validator(0, 0, 5, 10, false),
+	), epoch9Slot)
+	events = received(3)[1:]
+	require.Equal(t, primitives.Epoch(10), events[0].Epoch)
+	require.DeepEqual(t, []*statefeed.ValidatorLifecycleChange{
+		{Index: 1, Transition: statefeed.Activated},
+		{Index: 3, Transition: statefeed.Withdrawable},
+	}, events[0].Transitions)
+	require.Equal(t, primitives.Epoch(11), events[1].Epoch)
+	require.DeepEqual(t, []*statefeed.ValidatorLifecycleChange{
+		{Index: 2, Transition: statefeed.Slashed},
+		{Index: 2, Transition: statefeed.Exited},
+	}, events[1].Transitions)
+
+	// The same epoch is only notified once.
+	epoch11Slot, err := slots.EpochStart(11)
+	require.NoError(t, err)
+	s.notifyValidatorLifecycle(headState(11,
+		validator(0, 0, far, far, true),
+	), epoch11Slot)
+
+	// A reorg from epoch 10 notifies the epochs of the new chain again.
+	epoch10Slot, err := slots.EpochStart(10)
+	require.NoError(t, err)
+	s.notifyValidatorLifecycle(headState(11,
+		validator(0, 0, far, far, true),
+		validator(9, 11, far, far, false),
+		validator(0, 0, far, far, false),
+		validator(0, 0, 5, 10, false),
+		validator(0, 0, far, far, true),
+	), epoch10Slot)
+	events = received(4)[3:]
+	require.Equal(t, primitives.Epoch(11), events[0].Epoch)
+	require.DeepEqual(t, []*statefeed.ValidatorLifecycleChange{
+		{Index: 1, Transition: statefeed.Activated},
+		{Index: 4, Transition: statefeed.Slashed},
+	}, events[0].Transitions)
+}
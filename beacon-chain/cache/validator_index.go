@@ -0,0 +1,218 @@
+package cache
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// ValidatorIndexEntry is the part of a validator record kept in the validator index. It implements
+// state.ReadOnlyValidator, so the status of the validator can be computed from it.
+type ValidatorIndexEntry struct {
+	pubkey                     [fieldparams.BLSPubkeyLength]byte
+	withdrawalCredentials      [32]byte
+	effectiveBalance           uint64
+	activationEligibilityEpoch primitives.Epoch
+	activationEpoch            primitives.Epoch
+	exitEpoch                  primitives.Epoch
+	withdrawableEpoch          primitives.Epoch
+	slashed                    bool
+}
+
+var _ state.ReadOnlyValidator = (*ValidatorIndexEntry)(nil)
+
+func newValidatorIndexEntry(val state.ReadOnlyValidator) ValidatorIndexEntry {
+	return ValidatorIndexEntry{
+		pubkey:                     val.PublicKey(),
+		withdrawalCredentials:      bytesutil.ToBytes32(val.GetWithdrawalCredentials()),
+		effectiveBalance:           val.EffectiveBalance(),
+		activationEligibilityEpoch: val.ActivationEligibilityEpoch(),
+		activationEpoch:            val.ActivationEpoch(),
+		exitEpoch:                  val.ExitEpoch(),
+		withdrawableEpoch:          val.WithdrawableEpoch(),
+		slashed:                    val.Slashed(),
+	}
+}
+
+// EffectiveBalance returns the effective balance of the validator.
+func (e *ValidatorIndexEntry) EffectiveBalance() uint64 {
+	return e.effectiveBalance
+}
+
+// ActivationEligibilityEpoch returns the activation eligibility epoch of the validator.
+func (e *ValidatorIndexEntry) ActivationEligibilityEpoch() primitives.Epoch {
+	return e.activationEligibilityEpoch
+}
+
+// ActivationEpoch returns the activation epoch of the validator.
+func (e *ValidatorIndexEntry) ActivationEpoch() primitives.Epoch {
+	return e.activationEpoch
+}
+
+// WithdrawableEpoch returns the withdrawable epoch of the validator.
+func (e *ValidatorIndexEntry) WithdrawableEpoch() primitives.Epoch {
+	return e.withdrawableEpoch
+}
+
+// ExitEpoch returns the exit epoch of the validator.
+func (e *ValidatorIndexEntry) ExitEpoch() primitives.Epoch {
+	return e.exitEpoch
+}
+
+// PublicKey returns the public key of the validator.
+func (e *ValidatorIndexEntry) PublicKey() [fieldparams.BLSPubkeyLength]byte {
+	return e.pubkey
+}
+
+// GetWithdrawalCredentials returns the withdrawal credentials of the validator.
+func (e *ValidatorIndexEntry) GetWithdrawalCredentials() []byte {
+	creds := e.withdrawalCredentials
+	return creds[:]
+}
+
+// Copy returns a validator record holding the fields of the entry.
+func (e *ValidatorIndexEntry) Copy() *ethpb.Validator {
+	return &ethpb.Validator{
+		PublicKey:                  e.pubkey[:],
+		WithdrawalCredentials:      e.GetWithdrawalCredentials(),
+		EffectiveBalance:           e.effectiveBalance,
+		Slashed:                    e.slashed,
+		ActivationEligibilityEpoch: e.activationEligibilityEpoch,
+		ActivationEpoch:            e.activationEpoch,
+		ExitEpoch:                  e.exitEpoch,
+		WithdrawableEpoch:          e.withdrawableEpoch,
+	}
+}
+
+// Slashed returns true if the validator was slashed.
+func (e *ValidatorIndexEntry) Slashed() bool {
+	return e.slashed
+}
+
+// IsNil returns true if the entry is nil.
+func (e *ValidatorIndexEntry) IsNil() bool {
+	return e == nil
+}
+
+// WithdrawalAddress returns the execution address of the withdrawal credentials, and false if the credentials are
+// BLS credentials, which have no address.
+func (e *ValidatorIndexEntry) WithdrawalAddress() ([20]byte, bool) {
+	switch e.withdrawalCredentials[0] {
+	case params.BeaconConfig().ETH1AddressWithdrawalPrefixByte, params.BeaconConfig().CompoundingWithdrawalPrefixByte:
+		return bytesutil.ToBytes20(e.withdrawalCredentials[12:]), true
+	}
+	return [20]byte{}, false
+}
+
+// ValidatorIndex is a snapshot of the validators of the state at the start of an epoch, with the validators grouped
+// by withdrawal address. It also keeps the entries that changed since the previous snapshot, so that the validators
+// whose status changed between the two epochs can be found. A snapshot is never modified once built.
+type ValidatorIndex struct {
+	// Epoch is the epoch of the state the snapshot was built from.
+	Epoch primitives.Epoch
+	// PreviousEpoch is the epoch of the previous snapshot, which is Epoch for the first snapshot.
+	PreviousEpoch primitives.Epoch
+
+	entries             []ValidatorIndexEntry
+	previous            map[primitives.ValidatorIndex]*ValidatorIndexEntry
+	previousCount       int
+	byWithdrawalAddress map[[20]byte][]primitives.ValidatorIndex
+}
+
+// NumValidators returns the number of validators in the snapshot.
+func (x *ValidatorIndex) NumValidators() int {
+	return len(x.entries)
+}
+
+// Entry returns the entry of a validator, which must be lower than NumValidators.
+func (x *ValidatorIndex) Entry(idx primitives.ValidatorIndex) *ValidatorIndexEntry {
+	return &x.entries[idx]
+}
+
+// PreviousEntry returns the entry of a validator in the previous snapshot, and false if the validator was not in
+// the previous snapshot.
+func (x *ValidatorIndex) PreviousEntry(idx primitives.ValidatorIndex) (*ValidatorIndexEntry, bool) {
+	if uint64(idx) >= uint64(x.previousCount) {
+		return nil, false
+	}
+	if e, ok := x.previous[idx]; ok {
+		return e, true
+	}
+	return &x.entries[idx], true
+}
+
+// ByWithdrawalAddress returns the validators whose withdrawal credentials hold an execution address, in index order.
+func (x *ValidatorIndex) ByWithdrawalAddress(addr [20]byte) []primitives.ValidatorIndex {
+	return x.byWithdrawalAddress[addr]
+}
+
+// ValidatorIndexCache holds the latest snapshot of the validator index, which is rebuilt at each epoch transition.
+type ValidatorIndexCache struct {
+	lock  sync.RWMutex
+	index *ValidatorIndex
+	// updateLock serializes updates, which read every validator of a state.
+	updateLock sync.Mutex
+}
+
+// NewValidatorIndexCache returns an empty validator index cache.
+func NewValidatorIndexCache() *ValidatorIndexCache {
+	return &ValidatorIndexCache{}
+}
+
+// Index returns the latest snapshot, or nil if no snapshot was built yet.
+func (c *ValidatorIndexCache) Index() *ValidatorIndex {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.index
+}
+
+// Update builds a snapshot from a state. States of an epoch that is not after the epoch of the latest snapshot are
+// ignored, so each epoch is indexed once.
+func (c *ValidatorIndexCache) Update(st state.ReadOnlyBeaconState) error {
+	c.updateLock.Lock()
+	defer c.updateLock.Unlock()
+	epoch := slots.ToEpoch(st.Slot())
+	prev := c.Index()
+	if prev != nil && epoch <= prev.Epoch {
+		return nil
+	}
+
+	index := &ValidatorIndex{
+		Epoch:               epoch,
+		PreviousEpoch:       epoch,
+		entries:             make([]ValidatorIndexEntry, 0, st.NumValidators()),
+		previous:            make(map[primitives.ValidatorIndex]*ValidatorIndexEntry),
+		byWithdrawalAddress: make(map[[20]byte][]primitives.ValidatorIndex),
+	}
+	if prev != nil {
+		index.PreviousEpoch = prev.Epoch
+		index.previousCount = len(prev.entries)
+	}
+	err := st.ReadFromEveryValidator(func(idx int, val state.ReadOnlyValidator) error {
+		e := newValidatorIndexEntry(val)
+		index.entries = append(index.entries, e)
+		if idx < index.previousCount && prev.entries[idx] != e {
+			p := prev.entries[idx]
+			index.previous[primitives.ValidatorIndex(idx)] = &p
+		}
+		if addr, ok := e.WithdrawalAddress(); ok {
+			index.byWithdrawalAddress[addr] = append(index.byWithdrawalAddress[addr], primitives.ValidatorIndex(idx))
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not read validators")
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.index = index
+	return nil
+}
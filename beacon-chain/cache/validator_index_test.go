@@ -0,0 +1,87 @@
+package cache_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
+	state_native "github.com/prysmaticlabs/prysm/v5/beacon-chain/state/state-native"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func validatorIndexTestState(t *testing.T, epoch primitives.Epoch, vals []*ethpb.Validator) *state_native.BeaconState {
+	st, err := state_native.InitializeFromProtoPhase0(&ethpb.BeaconState{
+		Slot:       params.BeaconConfig().SlotsPerEpoch.Mul(uint64(epoch)),
+		Validators: vals,
+		Balances:   make([]uint64, len(vals)),
+	})
+	require.NoError(t, err)
+	return st.(*state_native.BeaconState)
+}
+
+func validatorIndexTestValidator(prefix byte, addr byte) *ethpb.Validator {
+	creds := make([]byte, 32)
+	creds[0] = prefix
+	creds[31] = addr
+	return &ethpb.Validator{
+		PublicKey:                  bytes.Repeat([]byte{addr}, 48),
+		WithdrawalCredentials:      creds,
+		EffectiveBalance:           params.BeaconConfig().MaxEffectiveBalance,
+		ActivationEligibilityEpoch: 0,
+		ActivationEpoch:            0,
+		ExitEpoch:                  params.BeaconConfig().FarFutureEpoch,
+		WithdrawableEpoch:          params.BeaconConfig().FarFutureEpoch,
+	}
+}
+
+func TestValidatorIndexCache_Update(t *testing.T) {
+	cfg := params.BeaconConfig()
+	c := cache.NewValidatorIndexCache()
+	assert.Equal(t, true, c.Index() == nil)
+
+	vals := []*ethpb.Validator{
+		validatorIndexTestValidator(cfg.BLSWithdrawalPrefixByte, 1),
+		validatorIndexTestValidator(cfg.ETH1AddressWithdrawalPrefixByte, 2),
+		validatorIndexTestValidator(cfg.CompoundingWithdrawalPrefixByte, 2),
+	}
+	require.NoError(t, c.Update(validatorIndexTestState(t, 3, vals)))
+	index := c.Index()
+	require.NotNil(t, index)
+	assert.Equal(t, primitives.Epoch(3), index.Epoch)
+	assert.Equal(t, primitives.Epoch(3), index.PreviousEpoch)
+	assert.Equal(t, 3, index.NumValidators())
+	var addr [20]byte
+	addr[19] = 2
+	assert.DeepEqual(t, []primitives.ValidatorIndex{1, 2}, index.ByWithdrawalAddress(addr))
+	_, ok := index.Entry(0).WithdrawalAddress()
+	assert.Equal(t, false, ok)
+	_, ok = index.PreviousEntry(0)
+	assert.Equal(t, false, ok)
+
+	// Validator 1 exits and a validator is added.
+	exiting := validatorIndexTestValidator(cfg.ETH1AddressWithdrawalPrefixByte, 2)
+	exiting.ExitEpoch = 5
+	vals = []*ethpb.Validator{vals[0], exiting, vals[2], validatorIndexTestValidator(cfg.ETH1AddressWithdrawalPrefixByte, 3)}
+	require.NoError(t, c.Update(validatorIndexTestState(t, 4, vals)))
+	index = c.Index()
+	assert.Equal(t, primitives.Epoch(4), index.Epoch)
+	assert.Equal(t, primitives.Epoch(3), index.PreviousEpoch)
+	assert.Equal(t, 4, index.NumValidators())
+	assert.Equal(t, primitives.Epoch(5), index.Entry(1).ExitEpoch())
+	prev, ok := index.PreviousEntry(1)
+	require.Equal(t, true, ok)
+	assert.Equal(t, cfg.FarFutureEpoch, prev.ExitEpoch())
+	prev, ok = index.PreviousEntry(2)
+	require.Equal(t, true, ok)
+	assert.Equal(t, index.Entry(2), prev)
+	_, ok = index.PreviousEntry(3)
+	assert.Equal(t, false, ok)
+
+	// States of indexed epochs are ignored.
+	require.NoError(t, c.Update(validatorIndexTestState(t, 4, vals[:1])))
+	assert.Equal(t, index, c.Index())
+}
@@ -0,0 +1,162 @@
+package dutyhistory
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/time"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/attestation"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// ErrUnsupportedState is returned for states before altair, which have no participation flags.
+var ErrUnsupportedState = errors.New("duty records cannot be computed from a state before altair")
+
+// Duties are the proposer and sync committee assignments of an epoch.
+type Duties struct {
+	Epoch primitives.Epoch
+	// Proposers are the proposers of the slots of the epoch, in slot order.
+	Proposers []primitives.ValidatorIndex
+	// SyncCommittee are the members of the sync committee, in position order.
+	SyncCommittee []primitives.ValidatorIndex
+}
+
+// CurrentEpochDuties returns the assignments of the current epoch of a state.
+func CurrentEpochDuties(ctx context.Context, st state.ReadOnlyBeaconState) (*Duties, error) {
+	if st.Version() < version.Altair {
+		return nil, ErrUnsupportedState
+	}
+	epoch := time.CurrentEpoch(st)
+	active, err := helpers.ActiveValidatorIndices(ctx, st, epoch)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get active validator indices")
+	}
+	proposers, err := helpers.PrecomputeProposerIndices(st, active, epoch)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute proposer indices")
+	}
+	committee, err := st.CurrentSyncCommittee()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get current sync committee")
+	}
+	members := make([]primitives.ValidatorIndex, len(committee.Pubkeys))
+	for i, pk := range committee.Pubkeys {
+		idx, ok := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(pk))
+		if !ok {
+			return nil, errors.Errorf("sync committee member %#x is not a validator", pk)
+		}
+		members[i] = idx
+	}
+	return &Duties{Epoch: epoch, Proposers: proposers, SyncCommittee: members}, nil
+}
+
+// PreviousEpochRecords returns the records of every validator for the previous epoch of a state. The state must be
+// the last state of its epoch, so that no more attestations of the previous epoch can be included. blks must be the
+// canonical blocks of the previous and current epochs of the state. duties are the assignments of the previous
+// epoch, or nil if they are unknown.
+func PreviousEpochRecords(
+	ctx context.Context,
+	st state.BeaconState,
+	blks []interfaces.ReadOnlySignedBeaconBlock,
+	duties *Duties,
+) ([]Record, error) {
+	if st.Version() < version.Altair {
+		return nil, ErrUnsupportedState
+	}
+	vals, bal, err := altair.InitializePrecomputeValidators(ctx, st)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not initialize precompute validators")
+	}
+	vals, _, err = altair.ProcessEpochParticipation(ctx, st, bal, vals)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not process epoch participation")
+	}
+	records := make([]Record, len(vals))
+	for i, v := range vals {
+		records[i] = Record{
+			Active:        v.IsActivePrevEpoch,
+			CorrectSource: v.IsPrevEpochSourceAttester,
+			CorrectTarget: v.IsPrevEpochTargetAttester,
+			CorrectHead:   v.IsPrevEpochHeadAttester,
+		}
+	}
+
+	prevEpoch := time.PrevEpoch(st)
+	if duties != nil && duties.Epoch != prevEpoch {
+		duties = nil
+	}
+	proposed := make([]bool, params.BeaconConfig().SlotsPerEpoch)
+	for _, blk := range blks {
+		b := blk.Block()
+		for _, att := range b.Body().Attestations() {
+			data := att.GetData()
+			if data.Target.Epoch != prevEpoch || data.Slot >= b.Slot() {
+				continue
+			}
+			committees, err := helpers.AttestationCommittees(ctx, st, att)
+			if err != nil {
+				return nil, errors.Wrap(err, "could not get attestation committees")
+			}
+			indices, err := attestation.AttestingIndices(att, committees...)
+			if err != nil {
+				return nil, errors.Wrap(err, "could not get attesting indices")
+			}
+			delay := b.Slot() - data.Slot
+			for _, idx := range indices {
+				if idx >= uint64(len(records)) {
+					continue
+				}
+				r := &records[idx]
+				if !r.Included || delay < r.InclusionDelay {
+					r.Included = true
+					r.InclusionDelay = delay
+				}
+			}
+		}
+
+		if slots.ToEpoch(b.Slot()) != prevEpoch {
+			continue
+		}
+		if uint64(b.ProposerIndex()) < uint64(len(records)) {
+			increment(&records[b.ProposerIndex()].Proposed)
+		}
+		proposed[b.Slot()%params.BeaconConfig().SlotsPerEpoch] = true
+		if duties == nil {
+			continue
+		}
+		agg, err := b.Body().SyncAggregate()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get sync aggregate")
+		}
+		for pos, idx := range duties.SyncCommittee {
+			if uint64(idx) >= uint64(len(records)) {
+				continue
+			}
+			if agg.SyncCommitteeBits.BitAt(uint64(pos)) {
+				increment(&records[idx].SyncParticipated)
+			} else {
+				increment(&records[idx].SyncMissed)
+			}
+		}
+	}
+
+	if duties != nil {
+		for slot, idx := range duties.Proposers {
+			if !proposed[slot] && uint64(idx) < uint64(len(records)) {
+				increment(&records[idx].MissedProposals)
+			}
+		}
+		for i := range records {
+			records[i].DutiesKnown = true
+		}
+	}
+	return records, nil
+}
@@ -0,0 +1,132 @@
+package dutyhistory_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/dutyhistory"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestCurrentEpochDuties(t *testing.T) {
+	ctx := context.Background()
+	st, _ := util.DeterministicGenesisStateAltair(t, 64)
+	require.NoError(t, st.SetSlot(params.BeaconConfig().SlotsPerEpoch+3))
+	syncCommittee, err := altair.NextSyncCommittee(ctx, st)
+	require.NoError(t, err)
+	require.NoError(t, st.SetCurrentSyncCommittee(syncCommittee))
+
+	duties, err := dutyhistory.CurrentEpochDuties(ctx, st)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Epoch(1), duties.Epoch)
+	require.Equal(t, int(params.BeaconConfig().SlotsPerEpoch), len(duties.Proposers))
+	require.Equal(t, int(params.BeaconConfig().SyncCommitteeSize), len(duties.SyncCommittee))
+	committee, err := st.CurrentSyncCommittee()
+	require.NoError(t, err)
+	for i, idx := range duties.SyncCommittee {
+		val, err := st.ValidatorAtIndexReadOnly(idx)
+		require.NoError(t, err)
+		pk := val.PublicKey()
+		assert.DeepEqual(t, committee.Pubkeys[i], pk[:])
+	}
+
+	phase0, err := util.NewBeaconState()
+	require.NoError(t, err)
+	_, err = dutyhistory.CurrentEpochDuties(ctx, phase0)
+	require.ErrorIs(t, err, dutyhistory.ErrUnsupportedState)
+}
+
+func TestPreviousEpochRecords(t *testing.T) {
+	ctx := context.Background()
+	spe := params.BeaconConfig().SlotsPerEpoch
+	st, _ := util.DeterministicGenesisStateAltair(t, 64)
+	require.NoError(t, st.SetSlot(2*spe-1))
+	// Validator 0 is timely for every flag and validator 1 only for the source.
+	participation := make([]byte, 64)
+	participation[0] = 0b111
+	participation[1] = 0b001
+	require.NoError(t, st.SetPreviousParticipationBits(participation))
+
+	committee, err := helpers.BeaconCommitteeFromState(ctx, st, 2, 0)
+	require.NoError(t, err)
+	require.NotEqual(t, 0, len(committee))
+	bits := bitfield.NewBitlist(uint64(len(committee)))
+	bits.SetBitAt(0, true)
+	att := util.HydrateAttestation(&ethpb.Attestation{
+		AggregationBits: bits,
+		Data:            &ethpb.AttestationData{Slot: 2},
+	})
+
+	block := func(slot primitives.Slot, proposer primitives.ValidatorIndex, syncBits ...uint64) interfaces.ReadOnlySignedBeaconBlock {
+		b := util.NewBeaconBlockAltair()
+		b.Block.Slot = slot
+		b.Block.ProposerIndex = proposer
+		b.Block.Body.Attestations = []*ethpb.Attestation{att}
+		for _, bit := range syncBits {
+			b.Block.Body.SyncAggregate.SyncCommitteeBits.SetBitAt(bit, true)
+		}
+		sb, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		return sb
+	}
+	blks := []interfaces.ReadOnlySignedBeaconBlock{
+		block(5, 10, 0),
+		block(7, 10, 0, 1),
+		block(spe+1, 11),
+	}
+	duties := &dutyhistory.Duties{
+		Epoch:         0,
+		Proposers:     make([]primitives.ValidatorIndex, spe),
+		SyncCommittee: []primitives.ValidatorIndex{20, 21, 20},
+	}
+	for i := range duties.Proposers {
+		duties.Proposers[i] = 10
+	}
+	duties.Proposers[6] = 12
+
+	records, err := dutyhistory.PreviousEpochRecords(ctx, st, blks, duties)
+	require.NoError(t, err)
+	require.Equal(t, 64, len(records))
+	assert.Equal(t, true, records[0].Active)
+	assert.Equal(t, true, records[0].CorrectSource)
+	assert.Equal(t, true, records[0].CorrectTarget)
+	assert.Equal(t, true, records[0].CorrectHead)
+	assert.Equal(t, true, records[0].DutiesKnown)
+	assert.Equal(t, true, records[1].CorrectSource)
+	assert.Equal(t, false, records[1].CorrectTarget)
+
+	included := records[committee[0]]
+	assert.Equal(t, true, included.Included)
+	assert.Equal(t, primitives.Slot(3), included.InclusionDelay)
+	if len(committee) > 1 {
+		assert.Equal(t, false, records[committee[1]].Included)
+	}
+
+	assert.Equal(t, uint8(2), records[10].Proposed)
+	assert.Equal(t, uint8(spe-3), records[10].MissedProposals)
+	assert.Equal(t, uint8(0), records[11].Proposed)
+	assert.Equal(t, uint8(1), records[12].MissedProposals)
+	assert.Equal(t, uint8(2), records[20].SyncParticipated)
+	assert.Equal(t, uint8(2), records[20].SyncMissed)
+	assert.Equal(t, uint8(1), records[21].SyncParticipated)
+	assert.Equal(t, uint8(1), records[21].SyncMissed)
+
+	t.Run("unknown duties", func(t *testing.T) {
+		records, err := dutyhistory.PreviousEpochRecords(ctx, st, blks, &dutyhistory.Duties{Epoch: 1})
+		require.NoError(t, err)
+		assert.Equal(t, false, records[0].DutiesKnown)
+		assert.Equal(t, uint8(2), records[10].Proposed)
+		assert.Equal(t, uint8(0), records[12].MissedProposals)
+		assert.Equal(t, uint8(0), records[20].SyncParticipated)
+	})
+}
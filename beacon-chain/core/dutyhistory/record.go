@@ -0,0 +1,131 @@
+// Package dutyhistory computes the outcome of the duties of every validator in an epoch, namely the correctness
+// and inclusion delay of its attestation, its block proposals and its sync committee participation, and defines
+// the compact encoding in which these records are stored.
+package dutyhistory
+
+import (
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+// ChunkSize is the number of consecutive validators whose records are encoded together.
+const ChunkSize = 4096
+
+// recordSize is the size of an encoded record.
+const recordSize = 6
+
+const (
+	flagActive = 1 << iota
+	flagCorrectSource
+	flagCorrectTarget
+	flagCorrectHead
+	flagIncluded
+	flagDutiesKnown
+)
+
+var errRecordsSize = errors.New("encoded records size is not a multiple of the record size")
+
+// Record is the outcome of the duties of a validator in an epoch.
+type Record struct {
+	// Active is true if the validator was active in the epoch, and so was expected to attest.
+	Active bool
+	// CorrectSource, CorrectTarget and CorrectHead are true if the attestation of the validator was included in
+	// time to be rewarded for voting for the correct source, target and head.
+	CorrectSource bool
+	CorrectTarget bool
+	CorrectHead   bool
+	// Included is true if an attestation of the validator was found in a block, in which case InclusionDelay is
+	// the lowest number of slots between the attestation and a block including it.
+	Included       bool
+	InclusionDelay primitives.Slot
+	// DutiesKnown is true if the proposer and sync committee assignments of the epoch were known when the record was
+	// computed. Otherwise MissedProposals and SyncMissed are always zero.
+	DutiesKnown bool
+	// Proposed and MissedProposals are the numbers of blocks proposed and missed by the validator.
+	Proposed        uint8
+	MissedProposals uint8
+	// SyncParticipated and SyncMissed are the numbers of blocks of the epoch whose sync aggregate includes and lacks
+	// the signature of the validator, counted for each of its positions in the sync committee.
+	SyncParticipated uint8
+	SyncMissed       uint8
+}
+
+// EpochRecord is the record of a validator in an epoch.
+type EpochRecord struct {
+	Epoch  primitives.Epoch
+	Record Record
+}
+
+// Chunk returns the index of the chunk holding the record of a validator.
+func Chunk(idx primitives.ValidatorIndex) uint64 {
+	return uint64(idx) / ChunkSize
+}
+
+// EncodeChunk encodes and compresses the records of consecutive validators.
+func EncodeChunk(records []Record) []byte {
+	buf := make([]byte, 0, len(records)*recordSize)
+	for _, r := range records {
+		var flags byte
+		for _, f := range []struct {
+			set  bool
+			flag byte
+		}{
+			{r.Active, flagActive},
+			{r.CorrectSource, flagCorrectSource},
+			{r.CorrectTarget, flagCorrectTarget},
+			{r.CorrectHead, flagCorrectHead},
+			{r.Included, flagIncluded},
+			{r.DutiesKnown, flagDutiesKnown},
+		} {
+			if f.set {
+				flags |= f.flag
+			}
+		}
+		buf = append(buf, flags, saturate(uint64(r.InclusionDelay)), r.Proposed, r.MissedProposals, r.SyncParticipated, r.SyncMissed)
+	}
+	return snappy.Encode(nil, buf)
+}
+
+// DecodeChunk decodes the records of a chunk.
+func DecodeChunk(enc []byte) ([]Record, error) {
+	buf, err := snappy.Decode(nil, enc)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decompress records")
+	}
+	if len(buf)%recordSize != 0 {
+		return nil, errRecordsSize
+	}
+	records := make([]Record, len(buf)/recordSize)
+	for i := range records {
+		b := buf[i*recordSize : (i+1)*recordSize]
+		records[i] = Record{
+			Active:           b[0]&flagActive != 0,
+			CorrectSource:    b[0]&flagCorrectSource != 0,
+			CorrectTarget:    b[0]&flagCorrectTarget != 0,
+			CorrectHead:      b[0]&flagCorrectHead != 0,
+			Included:         b[0]&flagIncluded != 0,
+			DutiesKnown:      b[0]&flagDutiesKnown != 0,
+			InclusionDelay:   primitives.Slot(b[1]),
+			Proposed:         b[2],
+			MissedProposals:  b[3],
+			SyncParticipated: b[4],
+			SyncMissed:       b[5],
+		}
+	}
+	return records, nil
+}
+
+// saturate converts a count to a byte, capping it at the largest value of a byte.
+func saturate(n uint64) uint8 {
+	if n > 255 {
+		return 255
+	}
+	return uint8(n)
+}
+
+func increment(n *uint8) {
+	if *n < 255 {
+		*n++
+	}
+}
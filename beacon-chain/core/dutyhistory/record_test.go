@@ -0,0 +1,49 @@
+package dutyhistory
+
+import (
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestEncodeDecodeChunk(t *testing.T) {
+	records := []Record{
+		{},
+		{Active: true, CorrectSource: true, CorrectTarget: true, CorrectHead: true, Included: true, InclusionDelay: 1, DutiesKnown: true},
+		{Active: true, Included: true, InclusionDelay: 31, Proposed: 2, MissedProposals: 1, SyncParticipated: 30, SyncMissed: 2},
+		{Active: true, CorrectTarget: true, DutiesKnown: true, SyncMissed: 255},
+	}
+	decoded, err := DecodeChunk(EncodeChunk(records))
+	require.NoError(t, err)
+	assert.DeepEqual(t, records, decoded)
+
+	t.Run("saturates inclusion delay", func(t *testing.T) {
+		decoded, err := DecodeChunk(EncodeChunk([]Record{{Included: true, InclusionDelay: 1000}}))
+		require.NoError(t, err)
+		assert.Equal(t, primitives.Slot(255), decoded[0].InclusionDelay)
+	})
+	t.Run("empty", func(t *testing.T) {
+		decoded, err := DecodeChunk(EncodeChunk(nil))
+		require.NoError(t, err)
+		assert.Equal(t, 0, len(decoded))
+	})
+	t.Run("bad size", func(t *testing.T) {
+		_, err := DecodeChunk(snappy.Encode(nil, make([]byte, recordSize+1)))
+		require.ErrorIs(t, err, errRecordsSize)
+	})
+	t.Run("not compressed", func(t *testing.T) {
+		_, err := DecodeChunk([]byte{0xff, 0xff, 0xff})
+		require.ErrorContains(t, "could not decompress records", err)
+	})
+}
+
+func TestIncrement(t *testing.T) {
+	n := uint8(254)
+	increment(&n)
+	assert.Equal(t, uint8(255), n)
+	increment(&n)
+	assert.Equal(t, uint8(255), n)
+}
@@ -2,7 +2,10 @@
 package operation
 
 import (
+	"time"
+
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 )
 
@@ -32,6 +35,9 @@ const (
 
 	// AttesterSlashingReceived is sent after an attester slashing is received from gossip or rpc
 	AttesterSlashingReceived = 8
+
+	// BlockGossipReceived is sent after a block first received from gossip passes validation, before it is imported.
+	BlockGossipReceived = 9
 )
 
 // UnAggregatedAttReceivedData is the data sent with UnaggregatedAttReceived events.
@@ -77,3 +83,15 @@ type ProposerSlashingReceivedData struct {
 type AttesterSlashingReceivedData struct {
 	AttesterSlashing ethpb.AttSlashing
 }
+
+// BlockGossipReceivedData is the data sent with BlockGossipReceived events.
+type BlockGossipReceivedData struct {
+	// Slot is the slot of the block.
+	Slot primitives.Slot
+	// ProposerIndex is the index of the proposer of the block.
+	ProposerIndex primitives.ValidatorIndex
+	// BlockRoot is the root of the block.
+	BlockRoot [32]byte
+	// ArrivalTime is when the block was received from gossip.
+	ArrivalTime time.Time
+}
@@ -31,6 +31,25 @@ const (
 	LightClientFinalityUpdate
 	// LightClientOptimisticUpdate event
 	LightClientOptimisticUpdate
+	// ValidatorLifecycle is sent when validators change lifecycle status at the start of an epoch, and sent again
+	// for the epochs of the new chain after a reorg.
+	ValidatorLifecycle
+)
+
+// ValidatorLifecycleTransition is a change in the status of a validator.
+type ValidatorLifecycleTransition string
+
+const (
+	// ActivationEligible is when a validator becomes eligible to enter the activation queue.
+	ActivationEligible ValidatorLifecycleTransition = "activation_eligible"
+	// Activated is when a validator becomes active.
+	Activated ValidatorLifecycleTransition = "activated"
+	// Slashed is when a validator which was not slashed at the previous epoch transition is slashed.
+	Slashed ValidatorLifecycleTransition = "slashed"
+	// Exited is when a validator stops being active.
+	Exited ValidatorLifecycleTransition = "exited"
+	// Withdrawable is when the balance of a validator becomes withdrawable.
+	Withdrawable ValidatorLifecycleTransition = "withdrawable"
 )
 
 // BlockProcessedData is the data sent with BlockProcessed events.
@@ -66,3 +85,21 @@ type InitializedData struct {
 	// GenesisValidatorsRoot represents state.validators.HashTreeRoot().
 	GenesisValidatorsRoot []byte
 }
+
+// ValidatorLifecycleData is the data sent with ValidatorLifecycle events.
+type ValidatorLifecycleData struct {
+	// Epoch is the epoch at which the transitions took effect.
+	Epoch primitives.Epoch
+	// Transitions are the validator status changes, in validator index order.
+	Transitions []*ValidatorLifecycleChange
+}
+
+// ValidatorLifecycleChange is a lifecycle transition of a single validator.
+type ValidatorLifecycleChange struct {
+	// Index is the index of the validator.
+	Index primitives.ValidatorIndex
+	// Pubkey is the public key of the validator.
+	Pubkey [48]byte
+	// Transition is the change in the status of the validator.
+	Transition ValidatorLifecycleTransition
+}
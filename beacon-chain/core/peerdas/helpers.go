@@ -0,0 +1,246 @@
+package peerdas
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/holiman/uint256"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/kzg"
+	"github.com/prysmaticlabs/prysm/v5/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	ecdsaprysm "github.com/prysmaticlabs/prysm/v5/crypto/ecdsa"
+	"github.com/prysmaticlabs/prysm/v5/crypto/hash"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+)
+
+var (
+	// ErrCustodySubnetCountTooLarge is returned when a node claims to custody more subnets than exist.
+	ErrCustodySubnetCountTooLarge = errors.New("custody subnet count larger than data column sidecar subnet count")
+	// ErrIndexTooLarge is returned when a data column sidecar has an index outside of the extended matrix.
+	ErrIndexTooLarge = errors.New("column index is larger than the specified columns count")
+	// ErrNoKzgCommitments is returned when a data column sidecar carries no KZG commitments.
+	ErrNoKzgCommitments = errors.New("no KZG commitments found")
+	// ErrMismatchLength is returned when the cells, commitments and proofs of a data column sidecar differ in length.
+	ErrMismatchLength = errors.New("mismatch in the length of the column, commitments or proofs")
+	// ErrBlobCountMismatch is returned when the number of blobs doesn't match the block's KZG commitments.
+	ErrBlobCountMismatch = errors.New("number of blobs does not match the number of KZG commitments")
+
+	maxUint256 = &uint256.Int{math.MaxUint64, math.MaxUint64, math.MaxUint64, math.MaxUint64}
+)
+
+// CustodySubnetCount returns the number of data column subnets the node custodies, which is
+// advertised to peers in the ENR and the metadata.
+func CustodySubnetCount() uint64 {
+	if flags.Get().SubscribeAllDataSubnets {
+		return params.BeaconConfig().DataColumnSidecarSubnetCount
+	}
+	return params.BeaconConfig().CustodyRequirement
+}
+
+// ConvertPeerIDToNodeID converts a libp2p peer ID to the discv5 node ID of the same key, which is
+// the input of the custody column computation.
+func ConvertPeerIDToNodeID(pid peer.ID) (enode.ID, error) {
+	pubKey, err := pid.ExtractPublicKey()
+	if err != nil {
+		return enode.ID{}, errors.Wrap(err, "could not extract public key from peer id")
+	}
+	ecdsaKey, err := ecdsaprysm.ConvertFromInterfacePubKey(pubKey)
+	if err != nil {
+		return enode.ID{}, errors.Wrap(err, "could not convert public key")
+	}
+	return enode.PubkeyToIDV4(ecdsaKey), nil
+}
+
+// CustodyColumnSubnets computes the data column subnets a node with the given ID custodies.
+//
+// Spec pseudocode definition:
+//
+//	subnet_ids: List[uint64] = []
+//	current_id = uint256(node_id)
+//	while len(subnet_ids) < custody_subnet_count:
+//	    subnet_id = (
+//	        bytes_to_uint64(hash(uint_to_bytes(uint256(current_id)))[0:8])
+//	        % DATA_COLUMN_SIDECAR_SUBNET_COUNT
+//	    )
+//	    if subnet_id not in subnet_ids:
+//	        subnet_ids.append(subnet_id)
+//	    if current_id == UINT256_MAX:
+//	        # Overflow prevention
+//	        current_id = NodeID(0)
+//	    current_id += 1
+func CustodyColumnSubnets(nodeID enode.ID, custodySubnetCount uint64) (map[uint64]bool, error) {
+	subnetCount := params.BeaconConfig().DataColumnSidecarSubnetCount
+	if custodySubnetCount > subnetCount {
+		return nil, ErrCustodySubnetCountTooLarge
+	}
+	subnets := make(map[uint64]bool, custodySubnetCount)
+	one := uint256.NewInt(1)
+	currentID := new(uint256.Int).SetBytes(nodeID.Bytes())
+	for uint64(len(subnets)) < custodySubnetCount {
+		// uint_to_bytes is little endian, while uint256 serializes big endian.
+		idBytes := currentID.Bytes32()
+		for i, j := 0, len(idBytes)-1; i < j; i, j = i+1, j-1 {
+			idBytes[i], idBytes[j] = idBytes[j], idBytes[i]
+		}
+		h := hash.Hash(idBytes[:])
+		subnets[binary.LittleEndian.Uint64(h[:8])%subnetCount] = true
+
+		if currentID.Cmp(maxUint256) == 0 {
+			currentID = uint256.NewInt(0)
+		}
+		currentID.Add(currentID, one)
+	}
+	return subnets, nil
+}
+
+// CustodyColumns computes the data columns a node with the given ID custodies.
+//
+// Spec pseudocode definition:
+//
+//	columns_per_subnet = NUMBER_OF_COLUMNS // DATA_COLUMN_SIDECAR_SUBNET_COUNT
+//	return sorted([
+//	    ColumnIndex(DATA_COLUMN_SIDECAR_SUBNET_COUNT * i + subnet_id)
+//	    for i in range(columns_per_subnet)
+//	    for subnet_id in subnet_ids
+//	])
+func CustodyColumns(nodeID enode.ID, custodySubnetCount uint64) (map[uint64]bool, error) {
+	subnets, err := CustodyColumnSubnets(nodeID, custodySubnetCount)
+	if err != nil {
+		return nil, err
+	}
+	return ColumnsForSubnets(subnets), nil
+}
+
+// ColumnsForSubnets returns the data columns gossiped on the given data column subnets.
+func ColumnsForSubnets(subnets map[uint64]bool) map[uint64]bool {
+	subnetCount := params.BeaconConfig().DataColumnSidecarSubnetCount
+	columnsPerSubnet := params.BeaconConfig().NumberOfColumns / subnetCount
+	columns := make(map[uint64]bool, uint64(len(subnets))*columnsPerSubnet)
+	for i := uint64(0); i < columnsPerSubnet; i++ {
+		for subnet := range subnets {
+			columns[subnetCount*i+subnet] = true
+		}
+	}
+	return columns
+}
+
+// ComputeSubnetForDataColumnSidecar returns the subnet a data column sidecar with the given index is gossiped on.
+//
+// Spec pseudocode definition:
+//
+//	def compute_subnet_for_data_column_sidecar(column_index: ColumnIndex) -> SubnetID:
+//	    return SubnetID(column_index % DATA_COLUMN_SIDECAR_SUBNET_COUNT)
+func ComputeSubnetForDataColumnSidecar(columnIndex uint64) uint64 {
+	return columnIndex % params.BeaconConfig().DataColumnSidecarSubnetCount
+}
+
+// DataColumnSidecars computes the data column sidecars of a block from its blobs. The blobs must be
+// given in the order of the block's KZG commitments. A block without blobs has no data column sidecars.
+func DataColumnSidecars(signedBlock interfaces.ReadOnlySignedBeaconBlock, blobs []kzg.Blob) ([]*ethpb.DataColumnSidecar, error) {
+	if signedBlock == nil || signedBlock.IsNil() {
+		return nil, errors.New("nil signed block")
+	}
+	body := signedBlock.Block().Body()
+	commitments, err := body.BlobKzgCommitments()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get blob KZG commitments")
+	}
+	if len(commitments) != len(blobs) {
+		return nil, ErrBlobCountMismatch
+	}
+	if len(blobs) == 0 {
+		return nil, nil
+	}
+	header, err := signedBlock.Header()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get signed block header")
+	}
+	inclusionProof, err := blocks.MerkleProofKZGCommitments(body)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute KZG commitments inclusion proof")
+	}
+
+	cellsAndProofs := make([]kzg.CellsAndProofs, len(blobs))
+	for i := range blobs {
+		cellsAndProofs[i], err = kzg.ComputeCellsAndKZGProofs(&blobs[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not compute cells and proofs for blob %d", i)
+		}
+	}
+
+	numberOfColumns := params.BeaconConfig().NumberOfColumns
+	sidecars := make([]*ethpb.DataColumnSidecar, numberOfColumns)
+	for c := uint64(0); c < numberOfColumns; c++ {
+		column := make([][]byte, len(blobs))
+		proofs := make([][]byte, len(blobs))
+		for i := range cellsAndProofs {
+			column[i] = cellsAndProofs[i].Cells[c][:]
+			proofs[i] = cellsAndProofs[i].Proofs[c][:]
+		}
+		sidecars[c] = &ethpb.DataColumnSidecar{
+			ColumnIndex:                  c,
+			DataColumn:                   column,
+			KzgCommitments:               commitments,
+			KzgProof:                     proofs,
+			SignedBlockHeader:            header,
+			KzgCommitmentsInclusionProof: inclusionProof,
+		}
+	}
+	return sidecars, nil
+}
+
+// VerifyDataColumnSidecar performs the structural checks of a data column sidecar.
+//
+// Spec pseudocode definition:
+//
+//	def verify_data_column_sidecar(sidecar: DataColumnSidecar) -> bool:
+//	    # The sidecar index must be within the valid range
+//	    if sidecar.index >= NUMBER_OF_COLUMNS:
+//	        return False
+//	    # A sidecar for zero blobs is invalid
+//	    if len(sidecar.kzg_commitments) == 0:
+//	        return False
+//	    # The column length must be equal to the number of commitments/proofs
+//	    if len(sidecar.column) != len(sidecar.kzg_commitments) or len(sidecar.column) != len(sidecar.kzg_proofs):
+//	        return False
+//	    return True
+func VerifyDataColumnSidecar(sidecar blocks.RODataColumn) error {
+	if sidecar.ColumnIndex >= params.BeaconConfig().NumberOfColumns {
+		return ErrIndexTooLarge
+	}
+	if len(sidecar.KzgCommitments) == 0 {
+		return ErrNoKzgCommitments
+	}
+	if len(sidecar.DataColumn) != len(sidecar.KzgCommitments) || len(sidecar.DataColumn) != len(sidecar.KzgProof) {
+		return ErrMismatchLength
+	}
+	return nil
+}
+
+// VerifyDataColumnSidecarKZGProofs verifies the KZG proofs of every cell in a data column sidecar against
+// the corresponding blob KZG commitment.
+func VerifyDataColumnSidecarKZGProofs(sidecar blocks.RODataColumn) (bool, error) {
+	if err := VerifyDataColumnSidecar(sidecar); err != nil {
+		return false, err
+	}
+	n := len(sidecar.DataColumn)
+	commitments := make([]kzg.Commitment, n)
+	indices := make([]uint64, n)
+	cells := make([]kzg.Cell, n)
+	proofs := make([]kzg.Proof, n)
+	for i := 0; i < n; i++ {
+		if len(sidecar.DataColumn[i]) != len(kzg.Cell{}) {
+			return false, ErrMismatchLength
+		}
+		copy(commitments[i][:], sidecar.KzgCommitments[i])
+		indices[i] = sidecar.ColumnIndex
+		copy(cells[i][:], sidecar.DataColumn[i])
+		copy(proofs[i][:], sidecar.KzgProof[i])
+	}
+	return kzg.VerifyCellKZGProofBatch(commitments, indices, cells, proofs)
+}
@@ -0,0 +1,134 @@
+package peerdas_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/kzg"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/peerdas"
+	"github.com/prysmaticlabs/prysm/v5/cmd/beacon-chain/flags"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestCustodyColumnSubnets(t *testing.T) {
+	var maxID enode.ID
+	for i := range maxID {
+		maxID[i] = 0xff
+	}
+	var seqID enode.ID
+	for i := range seqID {
+		seqID[i] = byte(i)
+	}
+	tests := []struct {
+		name   string
+		nodeID enode.ID
+		want   []uint64
+	}{
+		{name: "zero node id", nodeID: enode.ID{}, want: []uint64{1, 17, 87, 102}},
+		{name: "max node id wraps around", nodeID: maxID, want: []uint64{1, 17, 47, 87}},
+		{name: "sequential node id", nodeID: seqID, want: []uint64{57, 84, 105, 113}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subnets, err := peerdas.CustodyColumnSubnets(tt.nodeID, 4)
+			require.NoError(t, err)
+			require.Equal(t, len(tt.want), len(subnets))
+			for _, s := range tt.want {
+				require.Equal(t, true, subnets[s])
+			}
+		})
+	}
+
+	_, err := peerdas.CustodyColumnSubnets(enode.ID{}, params.BeaconConfig().DataColumnSidecarSubnetCount+1)
+	require.ErrorIs(t, err, peerdas.ErrCustodySubnetCountTooLarge)
+
+	all, err := peerdas.CustodyColumnSubnets(enode.ID{}, params.BeaconConfig().DataColumnSidecarSubnetCount)
+	require.NoError(t, err)
+	require.Equal(t, int(params.BeaconConfig().DataColumnSidecarSubnetCount), len(all))
+}
+
+func TestCustodyColumns(t *testing.T) {
+	columns, err := peerdas.CustodyColumns(enode.ID{}, params.BeaconConfig().CustodyRequirement)
+	require.NoError(t, err)
+	columnsPerSubnet := params.BeaconConfig().NumberOfColumns / params.BeaconConfig().DataColumnSidecarSubnetCount
+	require.Equal(t, int(params.BeaconConfig().CustodyRequirement*columnsPerSubnet), len(columns))
+	for c := range columns {
+		require.Equal(t, true, c < params.BeaconConfig().NumberOfColumns)
+	}
+}
+
+func TestCustodySubnetCount(t *testing.T) {
+	resetFlags := flags.Get()
+	defer flags.Init(resetFlags)
+
+	flags.Init(&flags.GlobalFlags{})
+	require.Equal(t, params.BeaconConfig().CustodyRequirement, peerdas.CustodySubnetCount())
+	flags.Init(&flags.GlobalFlags{SubscribeAllDataSubnets: true})
+	require.Equal(t, params.BeaconConfig().DataColumnSidecarSubnetCount, peerdas.CustodySubnetCount())
+}
+
+func TestComputeSubnetForDataColumnSidecar(t *testing.T) {
+	subnetCount := params.BeaconConfig().DataColumnSidecarSubnetCount
+	require.Equal(t, uint64(3), peerdas.ComputeSubnetForDataColumnSidecar(3))
+	require.Equal(t, uint64(3), peerdas.ComputeSubnetForDataColumnSidecar(subnetCount+3))
+}
+
+func TestDataColumnSidecars(t *testing.T) {
+	require.NoError(t, kzg.Start())
+
+	blobs := make([]kzg.Blob, 2)
+	commitments := make([][]byte, len(blobs))
+	for i := range blobs {
+		// Small values are always canonical field elements.
+		for j := 0; j < len(blobs[i]); j += 32 {
+			blobs[i][j+31] = byte((j / 32) * (i + 1) * (i + 3))
+		}
+		c, err := kzg.BlobToKZGCommitment(&blobs[i])
+		require.NoError(t, err)
+		commitments[i] = c[:]
+	}
+	pb := util.NewBeaconBlockDeneb()
+	pb.Block.Body.BlobKzgCommitments = commitments
+	signed, err := blocks.NewSignedBeaconBlock(pb)
+	require.NoError(t, err)
+
+	_, err = peerdas.DataColumnSidecars(signed, blobs[:1])
+	require.ErrorIs(t, err, peerdas.ErrBlobCountMismatch)
+
+	sidecars, err := peerdas.DataColumnSidecars(signed, blobs)
+	require.NoError(t, err)
+	require.Equal(t, int(params.BeaconConfig().NumberOfColumns), len(sidecars))
+	root, err := signed.Block().HashTreeRoot()
+	require.NoError(t, err)
+	for _, i := range []int{0, 77, 127} {
+		sc, err := blocks.NewRODataColumn(sidecars[i])
+		require.NoError(t, err)
+		require.Equal(t, root, sc.BlockRoot())
+		require.Equal(t, uint64(i), sc.ColumnIndex)
+		require.Equal(t, fieldparams.KzgCommitmentsInclusionProofDepth, len(sc.KzgCommitmentsInclusionProof))
+		require.NoError(t, peerdas.VerifyDataColumnSidecar(sc))
+		require.NoError(t, blocks.VerifyKZGCommitmentsInclusionProof(sc))
+		ok, err := peerdas.VerifyDataColumnSidecarKZGProofs(sc)
+		require.NoError(t, err)
+		require.Equal(t, true, ok)
+	}
+
+	sc, err := blocks.NewRODataColumn(sidecars[5])
+	require.NoError(t, err)
+	sc.KzgProof[0], sc.KzgProof[1] = sc.KzgProof[1], sc.KzgProof[0]
+	ok, err := peerdas.VerifyDataColumnSidecarKZGProofs(sc)
+	require.NoError(t, err)
+	require.Equal(t, false, ok)
+
+	sc.ColumnIndex = params.BeaconConfig().NumberOfColumns
+	require.ErrorIs(t, peerdas.VerifyDataColumnSidecar(sc), peerdas.ErrIndexTooLarge)
+	sc.ColumnIndex = 5
+	sc.KzgProof = sc.KzgProof[:1]
+	require.ErrorIs(t, peerdas.VerifyDataColumnSidecar(sc), peerdas.ErrMismatchLength)
+	sc.KzgCommitments = nil
+	require.ErrorIs(t, peerdas.VerifyDataColumnSidecar(sc), peerdas.ErrNoKzgCommitments)
+}
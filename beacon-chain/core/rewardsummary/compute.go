@@ -0,0 +1,135 @@
+package rewardsummary
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/epoch/precompute"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+)
+
+// ErrUnsupportedState is returned for states before altair, whose rewards are not served.
+var ErrUnsupportedState = errors.New("rewards cannot be computed from a state before altair")
+
+// AttestationRewards returns the attestation rewards of every validator for the previous epoch of a state, and the
+// ideal rewards for the effective balances of the validators. The state must be the last state of its epoch, so that
+// every attestation of the previous epoch has had a chance of inclusion.
+func AttestationRewards(ctx context.Context, st state.BeaconState) ([]AttestationReward, []IdealAttestationReward, error) {
+	if st.Version() < version.Altair {
+		return nil, nil, ErrUnsupportedState
+	}
+	vals, bal, err := altair.InitializePrecomputeValidators(ctx, st)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not initialize precompute validators")
+	}
+	vals, bal, err = altair.ProcessEpochParticipation(ctx, st, bal, vals)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not process epoch participation")
+	}
+	deltas, err := altair.AttestationsDelta(st, bal, vals)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not get attestations delta")
+	}
+	rewards := make([]AttestationReward, len(deltas))
+	for i, d := range deltas {
+		head, source, target, inactivity := signedDeltas(d)
+		rewards[i] = AttestationReward{
+			EffectiveBalance: vals[i].CurrentEpochEffectiveBalance,
+			Head:             head,
+			Source:           source,
+			Target:           target,
+			Inactivity:       inactivity,
+		}
+	}
+	ideal, err := idealAttestationRewards(st, bal, vals)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rewards, ideal, nil
+}
+
+// idealAttestationRewards returns rewards for hypothetical, perfectly voting validators
+// whose effective balances are over EJECTION_BALANCE and match balances in passed in validators.
+func idealAttestationRewards(st state.BeaconState, bal *precompute.Balance, vals []*precompute.Validator) ([]IdealAttestationReward, error) {
+	idealValsCount := uint64(16)
+	minIdealBalance := uint64(17)
+	maxIdealBalance := minIdealBalance + idealValsCount - 1
+	idealVals := make([]*precompute.Validator, 0, idealValsCount)
+	increment := params.BeaconConfig().EffectiveBalanceIncrement
+	for i := minIdealBalance; i <= maxIdealBalance; i++ {
+		for _, v := range vals {
+			if v.CurrentEpochEffectiveBalance/1e9 == i {
+				idealVals = append(idealVals, &precompute.Validator{
+					IsActivePrevEpoch:            true,
+					IsSlashed:                    false,
+					CurrentEpochEffectiveBalance: i * increment,
+					IsPrevEpochSourceAttester:    true,
+					IsPrevEpochTargetAttester:    true,
+					IsPrevEpochHeadAttester:      true,
+				})
+				break
+			}
+		}
+	}
+	deltas, err := altair.AttestationsDelta(st, bal, idealVals)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get attestations delta")
+	}
+	ideal := make([]IdealAttestationReward, len(deltas))
+	for i, d := range deltas {
+		head, source, target, inactivity := signedDeltas(d)
+		ideal[i] = IdealAttestationReward{
+			EffectiveBalance: idealVals[i].CurrentEpochEffectiveBalance,
+			Head:             head,
+			Source:           source,
+			Target:           target,
+			Inactivity:       inactivity,
+		}
+	}
+	return ideal, nil
+}
+
+// signedDeltas returns the rewards of an attestation delta, with penalties as negative rewards.
+func signedDeltas(d *altair.AttDelta) (head, source, target, inactivity int64) {
+	signed := func(reward, penalty uint64) int64 {
+		if penalty > 0 {
+			return -int64(penalty) // lint:ignore uintcast -- Penalties are far below the max int64.
+		}
+		return int64(reward) // lint:ignore uintcast -- Rewards are far below the max int64.
+	}
+	return signed(d.HeadReward, 0), signed(d.SourceReward, d.SourcePenalty), signed(d.TargetReward, d.TargetPenalty), signed(0, d.InactivityPenalty)
+}
+
+// CurrentSyncCommitteeRewards returns the sync committee rewards of the current epoch of a state.
+func CurrentSyncCommitteeRewards(st state.ReadOnlyBeaconState) (*SyncCommitteeRewards, error) {
+	if st.Version() < version.Altair {
+		return nil, ErrUnsupportedState
+	}
+	activeBalance, err := helpers.TotalActiveBalance(st)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get total active balance")
+	}
+	_, participantReward, err := altair.SyncRewards(activeBalance)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get sync rewards")
+	}
+	committee, err := st.CurrentSyncCommittee()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get current sync committee")
+	}
+	members := make([]primitives.ValidatorIndex, len(committee.Pubkeys))
+	for i, pk := range committee.Pubkeys {
+		idx, ok := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(pk))
+		if !ok {
+			return nil, errors.Errorf("sync committee member %#x is not a validator", pk)
+		}
+		members[i] = idx
+	}
+	return &SyncCommitteeRewards{ParticipantReward: participantReward, Members: members}, nil
+}
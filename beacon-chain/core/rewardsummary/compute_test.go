@@ -0,0 +1,74 @@
+package rewardsummary_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/rewardsummary"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestAttestationRewards(t *testing.T) {
+	ctx := context.Background()
+	st, _ := util.DeterministicGenesisStateAltair(t, 64)
+	require.NoError(t, st.SetSlot(2*params.BeaconConfig().SlotsPerEpoch-1))
+	// Validator 0 is timely for every flag, validator 1 only for the source and the others for none.
+	participation := make([]byte, 64)
+	participation[0] = 0b111
+	participation[1] = 0b001
+	require.NoError(t, st.SetPreviousParticipationBits(participation))
+
+	rewards, ideal, err := rewardsummary.AttestationRewards(ctx, st)
+	require.NoError(t, err)
+	require.Equal(t, 64, len(rewards))
+	maxBalance := params.BeaconConfig().MaxEffectiveBalance
+	for _, r := range rewards {
+		assert.Equal(t, maxBalance, r.EffectiveBalance)
+	}
+	assert.Equal(t, true, rewards[0].Head > 0 && rewards[0].Source > 0 && rewards[0].Target > 0)
+	assert.Equal(t, true, rewards[1].Source > 0 && rewards[1].Target < 0)
+	assert.Equal(t, int64(0), rewards[1].Head)
+	assert.Equal(t, true, rewards[2].Source < 0 && rewards[2].Target < 0)
+
+	// Every validator has the same effective balance, so there is a single ideal reward, earned by validator 0.
+	require.Equal(t, 1, len(ideal))
+	assert.DeepEqual(t, rewardsummary.IdealAttestationReward{
+		EffectiveBalance: maxBalance,
+		Head:             rewards[0].Head,
+		Source:           rewards[0].Source,
+		Target:           rewards[0].Target,
+	}, ideal[0])
+
+	phase0, err := util.NewBeaconState()
+	require.NoError(t, err)
+	_, _, err = rewardsummary.AttestationRewards(ctx, phase0)
+	require.ErrorIs(t, err, rewardsummary.ErrUnsupportedState)
+}
+
+func TestCurrentSyncCommitteeRewards(t *testing.T) {
+	ctx := context.Background()
+	st, _ := util.DeterministicGenesisStateAltair(t, 64)
+	syncCommittee, err := altair.NextSyncCommittee(ctx, st)
+	require.NoError(t, err)
+	require.NoError(t, st.SetCurrentSyncCommittee(syncCommittee))
+
+	rewards, err := rewardsummary.CurrentSyncCommitteeRewards(st)
+	require.NoError(t, err)
+	activeBalance, err := helpers.TotalActiveBalance(st)
+	require.NoError(t, err)
+	_, participantReward, err := altair.SyncRewards(activeBalance)
+	require.NoError(t, err)
+	assert.Equal(t, participantReward, rewards.ParticipantReward)
+	require.Equal(t, len(syncCommittee.Pubkeys), len(rewards.Members))
+	for i, idx := range rewards.Members {
+		val, err := st.ValidatorAtIndexReadOnly(idx)
+		require.NoError(t, err)
+		pk := val.PublicKey()
+		assert.DeepEqual(t, syncCommittee.Pubkeys[i], pk[:])
+	}
+}
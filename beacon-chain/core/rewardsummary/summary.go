@@ -0,0 +1,209 @@
+// Package rewardsummary computes the attestation and sync committee rewards of every validator for an epoch, as
+// served by the rewards endpoints of the beacon API, and defines the compact encoding in which they are stored so
+// that rewards of old epochs can be served without replaying states.
+package rewardsummary
+
+import (
+	"encoding/binary"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+// ChunkSize is the number of consecutive validators whose attestation rewards are encoded together.
+const ChunkSize = 4096
+
+var errTruncated = errors.New("encoded rewards are truncated")
+
+// AttestationReward is the attestation reward of a validator for an epoch, in Gwei. Penalties are negative.
+type AttestationReward struct {
+	// EffectiveBalance is the effective balance of the validator the reward is computed from.
+	EffectiveBalance uint64
+	Head             int64
+	Source           int64
+	Target           int64
+	Inactivity       int64
+}
+
+// IdealAttestationReward is the attestation reward of a validator which voted perfectly in an epoch, for an
+// effective balance.
+type IdealAttestationReward struct {
+	EffectiveBalance uint64
+	Head             int64
+	Source           int64
+	Target           int64
+	Inactivity       int64
+}
+
+// SyncCommitteeRewards are the sync committee rewards of an epoch. Each member of the sync committee is rewarded
+// ParticipantReward for each block of the epoch its signature is included in, and penalized as much for each block
+// it is missing from.
+type SyncCommitteeRewards struct {
+	ParticipantReward uint64
+	// Members are the members of the sync committee of the epoch, in position order.
+	Members []primitives.ValidatorIndex
+}
+
+// EpochRange is a range of epochs, both included.
+type EpochRange struct {
+	Earliest primitives.Epoch
+	Latest   primitives.Epoch
+}
+
+// Chunk returns the index of the chunk holding the attestation reward of a validator.
+func Chunk(idx primitives.ValidatorIndex) uint64 {
+	return uint64(idx) / ChunkSize
+}
+
+// EncodeAttestationRewards encodes and compresses the attestation rewards of consecutive validators. Validators
+// with the same effective balance and participation have the same rewards, so the distinct rewards are encoded
+// once and each validator is encoded as the position of its reward among them.
+func EncodeAttestationRewards(rewards []AttestationReward) []byte {
+	positions := make(map[AttestationReward]uint64)
+	var distinct []AttestationReward
+	for _, r := range rewards {
+		if _, ok := positions[r]; !ok {
+			positions[r] = uint64(len(distinct))
+			distinct = append(distinct, r)
+		}
+	}
+	buf := binary.AppendUvarint(nil, uint64(len(distinct)))
+	for _, r := range distinct {
+		buf = appendReward(buf, r.EffectiveBalance, r.Head, r.Source, r.Target, r.Inactivity)
+	}
+	buf = binary.AppendUvarint(buf, uint64(len(rewards)))
+	for _, r := range rewards {
+		buf = binary.AppendUvarint(buf, positions[r])
+	}
+	return snappy.Encode(nil, buf)
+}
+
+// DecodeAttestationRewards decodes the attestation rewards of a chunk.
+func DecodeAttestationRewards(enc []byte) ([]AttestationReward, error) {
+	buf, err := snappy.Decode(nil, enc)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decompress rewards")
+	}
+	d := &decoder{buf: buf}
+	distinct := make([]AttestationReward, d.length())
+	for i := range distinct {
+		r := &distinct[i]
+		r.EffectiveBalance, r.Head, r.Source, r.Target, r.Inactivity = d.reward()
+	}
+	rewards := make([]AttestationReward, d.length())
+	for i := range rewards {
+		pos := d.uvarint()
+		if d.err != nil {
+			return nil, d.err
+		}
+		if pos >= uint64(len(distinct)) {
+			return nil, errors.Errorf("reward position %d is out of range", pos)
+		}
+		rewards[i] = distinct[pos]
+	}
+	return rewards, d.finish()
+}
+
+// EncodeIdealAttestationRewards encodes the ideal attestation rewards of an epoch.
+func EncodeIdealAttestationRewards(rewards []IdealAttestationReward) []byte {
+	buf := binary.AppendUvarint(nil, uint64(len(rewards)))
+	for _, r := range rewards {
+		buf = appendReward(buf, r.EffectiveBalance, r.Head, r.Source, r.Target, r.Inactivity)
+	}
+	return buf
+}
+
+// DecodeIdealAttestationRewards decodes the ideal attestation rewards of an epoch.
+func DecodeIdealAttestationRewards(buf []byte) ([]IdealAttestationReward, error) {
+	d := &decoder{buf: buf}
+	rewards := make([]IdealAttestationReward, d.length())
+	for i := range rewards {
+		r := &rewards[i]
+		r.EffectiveBalance, r.Head, r.Source, r.Target, r.Inactivity = d.reward()
+	}
+	return rewards, d.finish()
+}
+
+// EncodeSyncCommitteeRewards encodes the sync committee rewards of an epoch.
+func EncodeSyncCommitteeRewards(rewards *SyncCommitteeRewards) []byte {
+	buf := binary.AppendUvarint(nil, rewards.ParticipantReward)
+	buf = binary.AppendUvarint(buf, uint64(len(rewards.Members)))
+	for _, idx := range rewards.Members {
+		buf = binary.AppendUvarint(buf, uint64(idx))
+	}
+	return buf
+}
+
+// DecodeSyncCommitteeRewards decodes the sync committee rewards of an epoch.
+func DecodeSyncCommitteeRewards(buf []byte) (*SyncCommitteeRewards, error) {
+	d := &decoder{buf: buf}
+	rewards := &SyncCommitteeRewards{ParticipantReward: d.uvarint()}
+	rewards.Members = make([]primitives.ValidatorIndex, d.length())
+	for i := range rewards.Members {
+		rewards.Members[i] = primitives.ValidatorIndex(d.uvarint())
+	}
+	return rewards, d.finish()
+}
+
+func appendReward(buf []byte, effectiveBalance uint64, head, source, target, inactivity int64) []byte {
+	buf = binary.AppendUvarint(buf, effectiveBalance)
+	for _, v := range []int64{head, source, target, inactivity} {
+		buf = binary.AppendVarint(buf, v)
+	}
+	return buf
+}
+
+// decoder reads varints from a buffer, keeping the first error so that it only has to be checked once.
+type decoder struct {
+	buf []byte
+	err error
+}
+
+func (d *decoder) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(d.buf)
+	if n <= 0 {
+		d.err = errTruncated
+		return 0
+	}
+	d.buf = d.buf[n:]
+	return v
+}
+
+func (d *decoder) varint() int64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(d.buf)
+	if n <= 0 {
+		d.err = errTruncated
+		return 0
+	}
+	d.buf = d.buf[n:]
+	return v
+}
+
+// length reads the length of a list, which cannot exceed the number of remaining bytes as every element takes at
+// least one byte.
+func (d *decoder) length() int {
+	n := d.uvarint()
+	if n > uint64(len(d.buf)) {
+		d.err = errTruncated
+		return 0
+	}
+	return int(n)
+}
+
+func (d *decoder) reward() (effectiveBalance uint64, head, source, target, inactivity int64) {
+	return d.uvarint(), d.varint(), d.varint(), d.varint(), d.varint()
+}
+
+func (d *decoder) finish() error {
+	if d.err == nil && len(d.buf) != 0 {
+		return errors.New("encoded rewards have trailing bytes")
+	}
+	return d.err
+}
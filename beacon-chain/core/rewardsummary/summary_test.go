@@ -0,0 +1,65 @@
+package rewardsummary
+
+import (
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestAttestationRewards_EncodeDecode(t *testing.T) {
+	rewards := make([]AttestationReward, 1000)
+	for i := range rewards {
+		rewards[i] = AttestationReward{
+			EffectiveBalance: 32_000_000_000,
+			Head:             int64(i % 2 * 1000),
+			Source:           -2000,
+			Target:           int64(3000 - i%3*6000),
+			Inactivity:       int64(-(i % 5)),
+		}
+	}
+	enc := EncodeAttestationRewards(rewards)
+	dec, err := DecodeAttestationRewards(enc)
+	require.NoError(t, err)
+	assert.DeepEqual(t, rewards, dec)
+	// Validators share few distinct rewards, which are stored once.
+	assert.Equal(t, true, len(enc) < len(rewards))
+
+	empty, err := DecodeAttestationRewards(EncodeAttestationRewards(nil))
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(empty))
+
+	_, err = DecodeAttestationRewards(snappy.Encode(nil, []byte{5}))
+	require.ErrorIs(t, err, errTruncated)
+	_, err = DecodeAttestationRewards([]byte{0xff})
+	require.ErrorContains(t, "could not decompress", err)
+}
+
+func TestIdealAttestationRewards_EncodeDecode(t *testing.T) {
+	ideal := []IdealAttestationReward{
+		{EffectiveBalance: 17_000_000_000, Head: 10, Source: 20, Target: 30},
+		{EffectiveBalance: 32_000_000_000, Head: 20, Source: 40, Target: 60, Inactivity: -5},
+	}
+	dec, err := DecodeIdealAttestationRewards(EncodeIdealAttestationRewards(ideal))
+	require.NoError(t, err)
+	assert.DeepEqual(t, ideal, dec)
+
+	_, err = DecodeIdealAttestationRewards(append(EncodeIdealAttestationRewards(ideal), 0))
+	require.ErrorContains(t, "trailing", err)
+}
+
+func TestSyncCommitteeRewards_EncodeDecode(t *testing.T) {
+	rewards := &SyncCommitteeRewards{
+		ParticipantReward: 12345,
+		Members:           []primitives.ValidatorIndex{7, 3, 7, 100_000},
+	}
+	dec, err := DecodeSyncCommitteeRewards(EncodeSyncCommitteeRewards(rewards))
+	require.NoError(t, err)
+	assert.DeepEqual(t, rewards, dec)
+
+	enc := EncodeSyncCommitteeRewards(rewards)
+	_, err = DecodeSyncCommitteeRewards(enc[:len(enc)-1])
+	require.ErrorIs(t, err, errTruncated)
+}
@@ -22,11 +22,14 @@ var (
 
 // LazilyPersistentStore is an implementation of AvailabilityStore to be used when batch syncing.
 // This implementation will hold any blobs passed to Persist until the IsDataAvailable is called for their
-// block, at which time they will undergo full verification and be saved to the disk.
+// block, at which time they will undergo full verification and be saved to the disk. Created WithDataColumns, it
+// does the same with the data columns passed to PersistColumns for the blocks of the PeerDAS fork.
 type LazilyPersistentStore struct {
-	store    *filesystem.BlobStorage
-	cache    *cache
-	verifier BlobBatchVerifier
+	store       *filesystem.BlobStorage
+	cache       *cache
+	verifier    BlobBatchVerifier
+	fullHistory bool
+	columns     *columnStore
 }
 
 var _ AvailabilityStore = &LazilyPersistentStore{}
@@ -40,14 +43,29 @@ type BlobBatchVerifier interface {
 	VerifiedROBlobs(ctx context.Context, blk blocks.ROBlock, sc []blocks.ROBlob) ([]blocks.VerifiedROBlob, error)
 }
 
+// StoreOption is a functional option for configuring a LazilyPersistentStore.
+type StoreOption func(*LazilyPersistentStore)
+
+// WithFullHistory makes the store check and save the blobs of every block since the Deneb fork, rather than only
+// blocks within MIN_EPOCHS_FOR_BLOB_SIDECARS_REQUESTS of the current slot. This is used to fill a blob archive.
+func WithFullHistory() StoreOption {
+	return func(s *LazilyPersistentStore) {
+		s.fullHistory = true
+	}
+}
+
 // NewLazilyPersistentStore creates a new LazilyPersistentStore. This constructor should always be used
 // when creating a LazilyPersistentStore because it needs to initialize the cache under the hood.
-func NewLazilyPersistentStore(store *filesystem.BlobStorage, verifier BlobBatchVerifier) *LazilyPersistentStore {
-	return &LazilyPersistentStore{
+func NewLazilyPersistentStore(store *filesystem.BlobStorage, verifier BlobBatchVerifier, opts ...StoreOption) *LazilyPersistentStore {
+	s := &LazilyPersistentStore{
 		store:    store,
 		cache:    newCache(),
 		verifier: verifier,
 	}
+	for _, o := range opts {
+		o(s)
+	}
+	return s
 }
 
 // Persist adds blobs to the working blob cache. Blobs stored in this cache will be persisted
@@ -65,7 +83,7 @@ func (s *LazilyPersistentStore) Persist(current primitives.Slot, sc ...blocks.RO
 			}
 		}
 	}
-	if !params.WithinDAPeriod(slots.ToEpoch(sc[0].Slot()), slots.ToEpoch(current)) {
+	if !s.fullHistory && !params.WithinDAPeriod(slots.ToEpoch(sc[0].Slot()), slots.ToEpoch(current)) {
 		return nil
 	}
 	key := keyFromSidecar(sc[0])
@@ -81,7 +99,10 @@ func (s *LazilyPersistentStore) Persist(current primitives.Slot, sc ...blocks.RO
 // IsDataAvailable returns nil if all the commitments in the given block are persisted to the db and have been verified.
 // BlobSidecars already in the db are assumed to have been previously verified against the block.
 func (s *LazilyPersistentStore) IsDataAvailable(ctx context.Context, current primitives.Slot, b blocks.ROBlock) error {
-	blockCommitments, err := commitmentsToCheck(b, current)
+	if s.checksColumns(b) {
+		return s.columns.isDataAvailable(ctx, current, b)
+	}
+	blockCommitments, err := s.commitmentsToCheck(b, current)
 	if err != nil {
 		return errors.Wrapf(err, "could check data availability for block %#x", b.Root())
 	}
@@ -137,15 +158,27 @@ func (s *LazilyPersistentStore) IsDataAvailable(ctx context.Context, current pri
 	return nil
 }
 
+func (s *LazilyPersistentStore) commitmentsToCheck(b blocks.ROBlock, current primitives.Slot) (safeCommitmentArray, error) {
+	if s.fullHistory {
+		return blockCommitments(b)
+	}
+	return commitmentsToCheck(b, current)
+}
+
 func commitmentsToCheck(b blocks.ROBlock, current primitives.Slot) (safeCommitmentArray, error) {
+	// We are only required to check within MIN_EPOCHS_FOR_BLOB_SIDECARS_REQUESTS
+	if b.Version() >= version.Deneb && !params.WithinDAPeriod(slots.ToEpoch(b.Block().Slot()), slots.ToEpoch(current)) {
+		return safeCommitmentArray{}, nil
+	}
+	return blockCommitments(b)
+}
+
+// blockCommitments returns all the kzg commitments in the block, regardless of its slot.
+func blockCommitments(b blocks.ROBlock) (safeCommitmentArray, error) {
 	var ar safeCommitmentArray
 	if b.Version() < version.Deneb {
 		return ar, nil
 	}
-	// We are only required to check within MIN_EPOCHS_FOR_BLOB_SIDECARS_REQUESTS
-	if !params.WithinDAPeriod(slots.ToEpoch(b.Block().Slot()), slots.ToEpoch(current)) {
-		return ar, nil
-	}
 	kc, err := b.Block().Body().BlobKzgCommitments()
 	if err != nil {
 		return ar, err
@@ -0,0 +1,107 @@
+package das
+
+import (
+	"context"
+
+	errors "github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// WithDataColumns makes the store check the data columns of the blocks of the PeerDAS fork, rather than their blobs.
+// Such a block is available once every column the node custodies is stored, the missing ones being taken from the
+// columns passed to PersistColumns, which are verified and saved to the DataColumnStorage.
+func WithDataColumns(store *filesystem.DataColumnStorage, verifier DataColumnBatchVerifier, custody map[uint64]bool) StoreOption {
+	return func(s *LazilyPersistentStore) {
+		s.columns = &columnStore{
+			store:    store,
+			verifier: verifier,
+			custody:  custody,
+			cache:    make(map[cacheKey][]blocks.RODataColumn),
+		}
+	}
+}
+
+// PersistColumns adds data column sidecars to the working cache, where they are held until IsDataAvailable is called
+// for their block. Columns are dropped when the store does not check data columns, or when they are outside of the
+// retention period.
+func (s *LazilyPersistentStore) PersistColumns(current primitives.Slot, sc ...blocks.RODataColumn) error {
+	if s.columns == nil {
+		return nil
+	}
+	for i := range sc {
+		if !s.columns.store.WithinRetentionPeriod(slots.ToEpoch(sc[i].Slot()), slots.ToEpoch(current)) {
+			continue
+		}
+		key := cacheKey{slot: sc[i].Slot(), root: sc[i].BlockRoot()}
+		s.columns.cache[key] = append(s.columns.cache[key], sc[i])
+	}
+	return nil
+}
+
+// checksColumns tells if the availability of the block is given by its data columns.
+func (s *LazilyPersistentStore) checksColumns(b blocks.ROBlock) bool {
+	return s.columns != nil && b.Version() >= version.Deneb && params.PeerDASActive(slots.ToEpoch(b.Block().Slot()))
+}
+
+type columnStore struct {
+	store    *filesystem.DataColumnStorage
+	verifier DataColumnBatchVerifier
+	custody  map[uint64]bool
+	cache    map[cacheKey][]blocks.RODataColumn
+}
+
+// isDataAvailable returns nil once every custody column of the block is stored, verifying and saving the stashed
+// columns which are not. Like the blobs, the columns of a batch are expected in full, so a missing custody column fails
+// the check rather than being waited for.
+func (c *columnStore) isDataAvailable(ctx context.Context, current primitives.Slot, b blocks.ROBlock) error {
+	key := keyFromBlock(b)
+	defer delete(c.cache, key)
+	if !c.store.WithinRetentionPeriod(slots.ToEpoch(b.Block().Slot()), slots.ToEpoch(current)) {
+		return nil
+	}
+	commitments, err := b.Block().Body().BlobKzgCommitments()
+	if err != nil {
+		return errors.Wrap(err, "could not get KZG commitments")
+	}
+	if len(commitments) == 0 {
+		return nil
+	}
+
+	root := b.Root()
+	stashed := make(map[uint64]blocks.RODataColumn, len(c.cache[key]))
+	for _, sc := range c.cache[key] {
+		stashed[sc.ColumnIndex] = sc
+	}
+	stored := c.store.Indices(root)
+	var needed []blocks.RODataColumn
+	var missing []uint64
+	for _, idx := range sortedIndices(c.custody) {
+		if stored[idx] {
+			continue
+		}
+		sc, ok := stashed[idx]
+		if !ok {
+			missing = append(missing, idx)
+			continue
+		}
+		needed = append(needed, sc)
+	}
+	if len(missing) > 0 {
+		return errors.Wrapf(errColumnsUnavailable, "block %#x, missing columns %v", root, missing)
+	}
+	verified, err := c.verifier.VerifiedRODataColumns(ctx, b, needed)
+	if err != nil {
+		return errors.Wrapf(err, "invalid DataColumnSidecars received for block %#x", root)
+	}
+	for i := range verified {
+		if err := c.store.Save(verified[i]); err != nil {
+			return errors.Wrapf(err, "failed to save DataColumnSidecar index %d for block %#x", verified[i].ColumnIndex, root)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package das
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestLazilyPersistentStore_DataColumns(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.DenebForkEpoch = 0
+	cfg.Eip7594ForkEpoch = 0
+	params.OverrideBeaconConfig(cfg)
+
+	custody := map[uint64]bool{1: true, 2: true, 3: true, 4: true}
+	blk, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 1, 2)
+	newStore := func(cs *filesystem.DataColumnStorage) *LazilyPersistentStore {
+		// Without blob storage, checking the blobs of the block would fail.
+		return NewLazilyPersistentStore(nil, &mockBlobBatchVerifier{}, WithDataColumns(cs, mockColumnBatchVerifier{}, custody))
+	}
+
+	t.Run("no commitments", func(t *testing.T) {
+		empty, _ := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 1, 0)
+		require.NoError(t, newStore(filesystem.NewEphemeralDataColumnStorage(t)).IsDataAvailable(context.Background(), 1, empty))
+	})
+	t.Run("custody columns persisted", func(t *testing.T) {
+		cs := filesystem.NewEphemeralDataColumnStorage(t)
+		s := newStore(cs)
+		require.NoError(t, s.PersistColumns(1, columns[:8]...))
+		require.NoError(t, s.IsDataAvailable(context.Background(), 1, blk))
+		require.DeepEqual(t, custody, cs.Indices(blk.Root()))
+	})
+	t.Run("custody columns already stored", func(t *testing.T) {
+		cs := filesystem.NewEphemeralDataColumnStorage(t)
+		require.NoError(t, cs.Save(blocks.NewVerifiedRODataColumn(columns[1])))
+		require.NoError(t, cs.Save(blocks.NewVerifiedRODataColumn(columns[2])))
+		s := newStore(cs)
+		require.NoError(t, s.PersistColumns(1, columns[3], columns[4]))
+		require.NoError(t, s.IsDataAvailable(context.Background(), 1, blk))
+		require.DeepEqual(t, custody, cs.Indices(blk.Root()))
+	})
+	t.Run("missing custody column", func(t *testing.T) {
+		cs := filesystem.NewEphemeralDataColumnStorage(t)
+		s := newStore(cs)
+		require.NoError(t, s.PersistColumns(1, columns[1], columns[2], columns[3]))
+		require.ErrorIs(t, s.IsDataAvailable(context.Background(), 1, blk), errColumnsUnavailable)
+		require.Equal(t, 0, len(cs.Indices(blk.Root())))
+		// The stashed columns are dropped once the block is checked.
+		require.NoError(t, s.PersistColumns(1, columns[4]))
+		require.ErrorIs(t, s.IsDataAvailable(context.Background(), 1, blk), errColumnsUnavailable)
+	})
+}
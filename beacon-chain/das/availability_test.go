@@ -201,3 +201,24 @@ func (m *mockBlobBatchVerifier) MarkVerified(root [32]byte, slot primitives.Slot
 	}
 	m.verified[root] = slot
 }
+
+func TestLazilyPersistentStore_FullHistory(t *testing.T) {
+	ctx := context.Background()
+	blk, blobSidecars := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, 0, 1)
+	current := primitives.Slot(params.BeaconConfig().MinEpochsForBlobsSidecarsRequest+100) * params.BeaconConfig().SlotsPerEpoch
+	co, err := commitmentsToCheck(blk, current)
+	require.NoError(t, err)
+	require.Equal(t, 0, co.count())
+
+	store := filesystem.NewEphemeralBlobStorage(t)
+	as := NewLazilyPersistentStore(store, &mockBlobBatchVerifier{t: t, scs: blobSidecars}, WithFullHistory())
+	co, err = as.commitmentsToCheck(blk, current)
+	require.NoError(t, err)
+	require.Equal(t, 1, co.count())
+
+	require.NoError(t, as.Persist(current, blobSidecars...))
+	require.NoError(t, as.IsDataAvailable(ctx, current, blk))
+	idx, err := store.Indices(blk.Root())
+	require.NoError(t, err)
+	require.Equal(t, true, idx[0])
+}
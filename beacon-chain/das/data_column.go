@@ -0,0 +1,160 @@
+package das
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	errors "github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/crypto/rand"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	log "github.com/sirupsen/logrus"
+)
+
+var errColumnsUnavailable = errors.New("data columns not available")
+
+// DataColumnFetcher requests the data column sidecars with the given indices for a block root from the network.
+// Implementations may return a subset of the requested columns, but must never return columns that were not requested.
+type DataColumnFetcher interface {
+	FetchDataColumns(ctx context.Context, root [32]byte, indices map[uint64]bool) ([]blocks.RODataColumn, error)
+}
+
+// DataColumnBatchVerifier enables DataColumnSampler to upgrade the columns it fetches for a block to
+// VerifiedRODataColumns, in the same way BlobBatchVerifier does for LazilyPersistentStore.
+type DataColumnBatchVerifier interface {
+	VerifiedRODataColumns(ctx context.Context, blk blocks.ROBlock, sc []blocks.RODataColumn) ([]blocks.VerifiedRODataColumn, error)
+}
+
+// DataColumnSampler is the PeerDAS counterpart to LazilyPersistentStore. Instead of requiring every blob of a block,
+// a block is considered available once the node holds all of the columns it custodies, along with
+// SAMPLES_PER_SLOT columns picked at random for each block. Columns that have not arrived through gossip are
+// requested from peers, verified and saved to the DataColumnStorage.
+type DataColumnSampler struct {
+	store    *filesystem.DataColumnStorage
+	fetcher  DataColumnFetcher
+	verifier DataColumnBatchVerifier
+	custody  map[uint64]bool
+}
+
+// NewDataColumnSampler creates a new DataColumnSampler for a node custodying the given set of columns.
+func NewDataColumnSampler(store *filesystem.DataColumnStorage, fetcher DataColumnFetcher, verifier DataColumnBatchVerifier, custody map[uint64]bool) *DataColumnSampler {
+	return &DataColumnSampler{
+		store:    store,
+		fetcher:  fetcher,
+		verifier: verifier,
+		custody:  custody,
+	}
+}
+
+// IsDataAvailable returns nil once every custody column and a random sample of the other columns of the given block
+// are persisted. Columns that are still missing after querying peers are waited for until the context is done.
+func (d *DataColumnSampler) IsDataAvailable(ctx context.Context, current primitives.Slot, b blocks.ROBlock) error {
+	if b.Version() < version.Deneb {
+		return nil
+	}
+	epoch := slots.ToEpoch(b.Block().Slot())
+	if !params.PeerDASActive(epoch) || !d.store.WithinRetentionPeriod(epoch, slots.ToEpoch(current)) {
+		return nil
+	}
+	commitments, err := b.Block().Body().BlobKzgCommitments()
+	if err != nil {
+		return errors.Wrap(err, "could not get KZG commitments")
+	}
+	if len(commitments) == 0 {
+		return nil
+	}
+
+	root := b.Root()
+	missing := d.missing(root, d.columnsToSample())
+	if len(missing) == 0 {
+		return nil
+	}
+	if err := d.fetch(ctx, b, missing); err != nil {
+		return err
+	}
+	// Subscribe before looking at the storage again, so that columns arriving through gossip in the meantime are
+	// not missed. This happens after fetching, since the feed blocks on subscribers that are not reading.
+	idents := make(chan filesystem.DataColumnIdent, fieldparams.NumberOfColumns)
+	sub := d.store.Subscribe(idents)
+	defer sub.Unsubscribe()
+	missing = d.missing(root, missing)
+	for len(missing) > 0 {
+		select {
+		case id := <-idents:
+			if id.Root == root {
+				delete(missing, id.Index)
+			}
+		case <-ctx.Done():
+			return errors.Wrapf(errColumnsUnavailable, "block %#x, missing columns %v: %v", root, sortedIndices(missing), ctx.Err())
+		}
+	}
+	return nil
+}
+
+// fetch requests the missing columns from peers, then verifies and saves whichever of them were returned.
+func (d *DataColumnSampler) fetch(ctx context.Context, b blocks.ROBlock, missing map[uint64]bool) error {
+	root := b.Root()
+	columns, err := d.fetcher.FetchDataColumns(ctx, root, missing)
+	if err != nil {
+		log.WithError(err).WithField("root", fmt.Sprintf("%#x", root)).Debug("Could not fetch data columns from peers")
+	}
+	if len(columns) == 0 {
+		return nil
+	}
+	verified, err := d.verifier.VerifiedRODataColumns(ctx, b, columns)
+	if err != nil {
+		return errors.Wrapf(err, "invalid DataColumnSidecars received for block %#x", root)
+	}
+	for i := range verified {
+		if err := d.store.Save(verified[i]); err != nil {
+			return errors.Wrapf(err, "failed to save DataColumnSidecar index %d for block %#x", verified[i].ColumnIndex, root)
+		}
+	}
+	return nil
+}
+
+// columnsToSample returns the custody columns of the node, along with SAMPLES_PER_SLOT other columns chosen at random.
+func (d *DataColumnSampler) columnsToSample() map[uint64]bool {
+	columns := make(map[uint64]bool, len(d.custody)+int(params.BeaconConfig().SamplesPerSlot))
+	for c := range d.custody {
+		columns[c] = true
+	}
+	samples := uint64(0)
+	for _, c := range rand.NewGenerator().Perm(fieldparams.NumberOfColumns) {
+		if samples == params.BeaconConfig().SamplesPerSlot {
+			break
+		}
+		if columns[uint64(c)] {
+			continue
+		}
+		columns[uint64(c)] = true
+		samples++
+	}
+	return columns
+}
+
+func (d *DataColumnSampler) missing(root [32]byte, want map[uint64]bool) map[uint64]bool {
+	stored := d.store.Indices(root)
+	missing := make(map[uint64]bool)
+	for c := range want {
+		if !stored[c] {
+			missing[c] = true
+		}
+	}
+	return missing
+}
+
+func sortedIndices(m map[uint64]bool) []uint64 {
+	indices := make([]uint64, 0, len(m))
+	for i := range m {
+		indices = append(indices, i)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	return indices
+}
@@ -0,0 +1,111 @@
+package das
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+type mockColumnFetcher struct {
+	columns   []blocks.RODataColumn
+	requested map[uint64]bool
+}
+
+func (m *mockColumnFetcher) FetchDataColumns(_ context.Context, _ [32]byte, indices map[uint64]bool) ([]blocks.RODataColumn, error) {
+	m.requested = indices
+	var found []blocks.RODataColumn
+	for _, c := range m.columns {
+		if indices[c.ColumnIndex] {
+			found = append(found, c)
+		}
+	}
+	return found, nil
+}
+
+type mockColumnBatchVerifier struct{}
+
+func (mockColumnBatchVerifier) VerifiedRODataColumns(_ context.Context, _ blocks.ROBlock, sc []blocks.RODataColumn) ([]blocks.VerifiedRODataColumn, error) {
+	vcs := make([]blocks.VerifiedRODataColumn, len(sc))
+	for i := range sc {
+		vcs[i] = blocks.NewVerifiedRODataColumn(sc[i])
+	}
+	return vcs, nil
+}
+
+func TestDataColumnSampler_IsDataAvailable(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.DenebForkEpoch = 0
+	cfg.Eip7594ForkEpoch = 0
+	params.OverrideBeaconConfig(cfg)
+
+	custody := map[uint64]bool{1: true, 2: true, 3: true, 4: true}
+	blk, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 1, 2)
+
+	t.Run("no commitments", func(t *testing.T) {
+		empty, _ := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 1, 0)
+		fetcher := &mockColumnFetcher{}
+		s := NewDataColumnSampler(filesystem.NewEphemeralDataColumnStorage(t), fetcher, mockColumnBatchVerifier{}, custody)
+		require.NoError(t, s.IsDataAvailable(context.Background(), 1, empty))
+		require.Equal(t, 0, len(fetcher.requested))
+	})
+	t.Run("fetched from peers", func(t *testing.T) {
+		store := filesystem.NewEphemeralDataColumnStorage(t)
+		fetcher := &mockColumnFetcher{columns: columns}
+		s := NewDataColumnSampler(store, fetcher, mockColumnBatchVerifier{}, custody)
+		require.NoError(t, s.IsDataAvailable(context.Background(), 1, blk))
+		require.Equal(t, len(custody)+int(params.BeaconConfig().SamplesPerSlot), len(fetcher.requested))
+		for c := range custody {
+			require.Equal(t, true, fetcher.requested[c])
+		}
+		require.DeepEqual(t, fetcher.requested, store.Indices(blk.Root()))
+	})
+	t.Run("already stored", func(t *testing.T) {
+		store := filesystem.NewEphemeralDataColumnStorage(t)
+		for i := range columns {
+			require.NoError(t, store.Save(blocks.NewVerifiedRODataColumn(columns[i])))
+		}
+		fetcher := &mockColumnFetcher{}
+		s := NewDataColumnSampler(store, fetcher, mockColumnBatchVerifier{}, custody)
+		require.NoError(t, s.IsDataAvailable(context.Background(), 1, blk))
+		require.Equal(t, 0, len(fetcher.requested))
+	})
+	t.Run("arrives after fetch", func(t *testing.T) {
+		store := filesystem.NewEphemeralDataColumnStorage(t)
+		all := make(map[uint64]bool, fieldparams.NumberOfColumns)
+		for i := uint64(0); i < fieldparams.NumberOfColumns; i++ {
+			all[i] = true
+		}
+		s := NewDataColumnSampler(store, &mockColumnFetcher{}, mockColumnBatchVerifier{}, all)
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			for i := range columns {
+				if err := store.Save(blocks.NewVerifiedRODataColumn(columns[i])); err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+		require.NoError(t, s.IsDataAvailable(context.Background(), 1, blk))
+	})
+	t.Run("unavailable", func(t *testing.T) {
+		s := NewDataColumnSampler(filesystem.NewEphemeralDataColumnStorage(t), &mockColumnFetcher{}, mockColumnBatchVerifier{}, custody)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, s.IsDataAvailable(ctx, 1, blk), errColumnsUnavailable)
+	})
+}
+
+func TestDataColumnSampler_PeerDASInactive(t *testing.T) {
+	blk, _ := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 1, 2)
+	fetcher := &mockColumnFetcher{}
+	s := NewDataColumnSampler(filesystem.NewEphemeralDataColumnStorage(t), fetcher, mockColumnBatchVerifier{}, map[uint64]bool{})
+	require.NoError(t, s.IsDataAvailable(context.Background(), 1, blk))
+	require.Equal(t, 0, len(fetcher.requested))
+}
@@ -0,0 +1,127 @@
+package engine
+
+import (
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	prombolt "github.com/prysmaticlabs/prombbolt"
+	bolt "go.etcd.io/bbolt"
+)
+
+type boltDB struct {
+	db        *bolt.DB
+	collector prometheus.Collector
+}
+
+// NewBolt returns the bbolt database as a DB. The metrics of the database leave out the per-bucket statistics of
+// the unmeasured buckets, which are too large to walk on every collection.
+func NewBolt(db *bolt.DB, unmeasured ...[]byte) DB {
+	return &boltDB{db: db, collector: prombolt.New("boltDB", db, unmeasured...)}
+}
+
+func (b *boltDB) View(fn func(Tx) error) error {
+	return boltError(b.db.View(func(tx *bolt.Tx) error {
+		return fn(boltTx{tx: tx})
+	}))
+}
+
+func (b *boltDB) Update(fn func(Tx) error) error {
+	return boltError(b.db.Update(func(tx *bolt.Tx) error {
+		return fn(boltTx{tx: tx})
+	}))
+}
+
+func (*boltDB) Engine() string {
+	return Bolt
+}
+
+func (b *boltDB) Collector() prometheus.Collector {
+	return b.collector
+}
+
+func (b *boltDB) Close() error {
+	return b.db.Close()
+}
+
+type boltTx struct {
+	tx *bolt.Tx
+}
+
+func (t boltTx) Bucket(name []byte) Bucket {
+	b := t.tx.Bucket(name)
+	if b == nil {
+		return nil
+	}
+	return boltBucket{b: b}
+}
+
+func (t boltTx) CreateBucket(name []byte) (Bucket, error) {
+	b, err := t.tx.CreateBucket(name)
+	if err != nil {
+		return nil, boltError(err)
+	}
+	return boltBucket{b: b}, nil
+}
+
+func (t boltTx) CreateBucketIfNotExists(name []byte) (Bucket, error) {
+	b, err := t.tx.CreateBucketIfNotExists(name)
+	if err != nil {
+		return nil, boltError(err)
+	}
+	return boltBucket{b: b}, nil
+}
+
+func (t boltTx) DeleteBucket(name []byte) error {
+	return boltError(t.tx.DeleteBucket(name))
+}
+
+func (t boltTx) ForEach(fn func(name []byte, b Bucket) error) error {
+	return t.tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+		return fn(name, boltBucket{b: b})
+	})
+}
+
+func (t boltTx) Writable() bool {
+	return t.tx.Writable()
+}
+
+type boltBucket struct {
+	b *bolt.Bucket
+}
+
+func (b boltBucket) Get(key []byte) []byte {
+	return b.b.Get(key)
+}
+
+func (b boltBucket) Put(key, value []byte) error {
+	return boltError(b.b.Put(key, value))
+}
+
+func (b boltBucket) Delete(key []byte) error {
+	return boltError(b.b.Delete(key))
+}
+
+func (b boltBucket) Cursor() Cursor {
+	return b.b.Cursor()
+}
+
+func (b boltBucket) ForEach(fn func(k, v []byte) error) error {
+	return b.b.ForEach(fn)
+}
+
+// boltError translates the errors of bbolt which are part of the DB interface.
+func boltError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, bolt.ErrBucketNotFound):
+		return ErrBucketNotFound
+	case errors.Is(err, bolt.ErrBucketExists):
+		return ErrBucketExists
+	case errors.Is(err, bolt.ErrTxNotWritable):
+		return ErrTxNotWritable
+	case errors.Is(err, bolt.ErrDatabaseNotOpen):
+		return ErrDatabaseNotOpen
+	default:
+		return err
+	}
+}
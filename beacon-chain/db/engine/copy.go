@@ -0,0 +1,102 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+type keyValue struct {
+	key   []byte
+	value []byte
+}
+
+// Copy copies every bucket of src into dst, and returns the number of keys copied. Keys are read and written in
+// batches of batchSize, so that neither database holds a long-running transaction.
+func Copy(ctx context.Context, src, dst DB, batchSize int) (uint64, error) {
+	if batchSize <= 0 {
+		return 0, errors.New("batch size must be positive")
+	}
+	var names [][]byte
+	if err := src.View(func(tx Tx) error {
+		return tx.ForEach(func(name []byte, _ Bucket) error {
+			names = append(names, bytes.Clone(name))
+			return nil
+		})
+	}); err != nil {
+		return 0, err
+	}
+	var total uint64
+	for _, name := range names {
+		n, err := copyBucket(ctx, src, dst, name, batchSize)
+		total += n
+		if err != nil {
+			return total, errors.Wrapf(err, "could not copy bucket %s", name)
+		}
+		log.WithField("bucket", string(name)).WithField("keys", n).Debug("Copied bucket")
+	}
+	return total, nil
+}
+
+func copyBucket(ctx context.Context, src, dst DB, name []byte, batchSize int) (uint64, error) {
+	if err := dst.Update(func(tx Tx) error {
+		_, err := tx.CreateBucketIfNotExists(name)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+	var copied uint64
+	var last []byte
+	for {
+		if ctx.Err() != nil {
+			return copied, ctx.Err()
+		}
+		batch := make([]keyValue, 0, batchSize)
+		if err := src.View(func(tx Tx) error {
+			b := tx.Bucket(name)
+			if b == nil {
+				return nil
+			}
+			c := b.Cursor()
+			var k, v []byte
+			if last == nil {
+				k, v = c.First()
+			} else {
+				k, v = c.Seek(last)
+				if bytes.Equal(k, last) {
+					k, v = c.Next()
+				}
+			}
+			for ; k != nil && len(batch) < batchSize; k, v = c.Next() {
+				// Nested buckets have a nil value, and are not part of the DB interface.
+				if v == nil {
+					continue
+				}
+				batch = append(batch, keyValue{key: bytes.Clone(k), value: bytes.Clone(v)})
+			}
+			return nil
+		}); err != nil {
+			return copied, err
+		}
+		if len(batch) == 0 {
+			return copied, nil
+		}
+		if err := dst.Update(func(tx Tx) error {
+			b := tx.Bucket(name)
+			for _, kv := range batch {
+				if err := b.Put(kv.key, kv.value); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return copied, err
+		}
+		copied += uint64(len(batch))
+		last = batch[len(batch)-1].key
+		if len(batch) < batchSize {
+			return copied, nil
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// Package engine defines the embedded key-value storage engines which the beacon database can be kept in.
+// Every engine exposes the same model: named buckets of ordered keys, read-only transactions which see a
+// consistent snapshot of the database, and serializable read-write transactions.
+package engine
+
+import (
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Bolt is the name of the bbolt engine, a single file B+tree which is the default engine.
+	Bolt = "bolt"
+	// Pebble is the name of the pebble engine, a log-structured merge tree which reclaims the space of deleted
+	// keys through background compactions.
+	Pebble = "pebble"
+)
+
+var (
+	// ErrBucketNotFound is returned when deleting a bucket which does not exist.
+	ErrBucketNotFound = errors.New("bucket not found")
+	// ErrBucketExists is returned when creating a bucket which already exists.
+	ErrBucketExists = errors.New("bucket already exists")
+	// ErrTxNotWritable is returned when writing in a read-only transaction.
+	ErrTxNotWritable = errors.New("tx not writable")
+	// ErrDatabaseNotOpen is returned when using a database after it was closed.
+	ErrDatabaseNotOpen = errors.New("database not open")
+	// ErrUnknownEngine is returned for an engine name which is not Bolt or Pebble.
+	ErrUnknownEngine = errors.New("unknown database engine")
+)
+
+// DB is an embedded key-value store.
+type DB interface {
+	// View runs fn in a read-only transaction.
+	View(fn func(Tx) error) error
+	// Update runs fn in a read-write transaction, which is committed if fn returns nil and rolled back otherwise.
+	// Read-write transactions are serialized.
+	Update(fn func(Tx) error) error
+	// Engine returns the name of the engine.
+	Engine() string
+	// Collector returns the prometheus collector of the engine metrics, or nil if the engine has none.
+	Collector() prometheus.Collector
+	Close() error
+}
+
+// Tx is a transaction. Keys and values returned within a transaction are only valid until it ends.
+type Tx interface {
+	// Bucket returns the bucket with the given name, or nil if it does not exist.
+	Bucket(name []byte) Bucket
+	CreateBucket(name []byte) (Bucket, error)
+	CreateBucketIfNotExists(name []byte) (Bucket, error)
+	DeleteBucket(name []byte) error
+	// ForEach calls fn for every bucket, in name order.
+	ForEach(fn func(name []byte, b Bucket) error) error
+	Writable() bool
+}
+
+// Bucket is a named set of ordered keys.
+type Bucket interface {
+	// Get returns the value of the key, or nil if the key does not exist.
+	Get(key []byte) []byte
+	Put(key, value []byte) error
+	Delete(key []byte) error
+	Cursor() Cursor
+	// ForEach calls fn for every key in the bucket, in key order.
+	ForEach(fn func(k, v []byte) error) error
+}
+
+// Cursor iterates over the keys of a bucket in order. Every method returns a nil key once the cursor moves past
+// the first or last key.
+type Cursor interface {
+	First() (key, value []byte)
+	Last() (key, value []byte)
+	Next() (key, value []byte)
+	Prev() (key, value []byte)
+	// Seek moves the cursor to the first key which is greater than or equal to seek.
+	Seek(seek []byte) (key, value []byte)
+}
+
+// Validate returns ErrUnknownEngine if name is not the name of an engine.
+func Validate(name string) error {
+	switch name {
+	case Bolt, Pebble:
+		return nil
+	default:
+		return errors.Wrap(ErrUnknownEngine, name)
+	}
+}
@@ -0,0 +1,281 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+var errRollback = errors.New("rollback")
+
+func openTestDB(t *testing.T, name string) DB {
+	dir := t.TempDir()
+	var d DB
+	switch name {
+	case Bolt:
+		// A large initial mmap keeps bolt from remapping, which would block writers behind open readers.
+		b, err := bolt.Open(filepath.Join(dir, "test.db"), 0600, &bolt.Options{InitialMmapSize: 1 << 26})
+		require.NoError(t, err)
+		d = NewBolt(b)
+	case Pebble:
+		var err error
+		d, err = OpenPebble(dir)
+		require.NoError(t, err)
+	}
+	t.Cleanup(func() {
+		if err := d.Close(); err != nil && !errors.Is(err, ErrDatabaseNotOpen) {
+			t.Error(err)
+		}
+	})
+	return d
+}
+
+// TestConformance runs the same behaviors against every engine, so that the beacon database does not depend on
+// the engine it is stored in.
+func TestConformance(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, d DB)
+	}{
+		{name: "buckets", fn: testBuckets},
+		{name: "get put delete", fn: testGetPutDelete},
+		{name: "cursor", fn: testCursor},
+		{name: "rollback", fn: testRollback},
+		{name: "read your writes", fn: testReadYourWrites},
+		{name: "snapshot isolation", fn: testSnapshotIsolation},
+		{name: "read only", fn: testReadOnly},
+		{name: "closed", fn: testClosed},
+	}
+	for _, e := range []string{Bolt, Pebble} {
+		for _, tt := range tests {
+			t.Run(fmt.Sprintf("%s/%s", e, tt.name), func(t *testing.T) {
+				tt.fn(t, openTestDB(t, e))
+			})
+		}
+	}
+}
+
+func testBuckets(t *testing.T, d DB) {
+	require.NoError(t, d.Update(func(tx Tx) error {
+		assert.Equal(t, nil, tx.Bucket([]byte("a")))
+		for _, name := range []string{"b", "a", "ab"} {
+			if _, err := tx.CreateBucket([]byte(name)); err != nil {
+				return err
+			}
+		}
+		_, err := tx.CreateBucket([]byte("a"))
+		require.ErrorIs(t, err, ErrBucketExists)
+		b, err := tx.CreateBucketIfNotExists([]byte("a"))
+		require.NoError(t, err)
+		return b.Put([]byte("k"), []byte("v"))
+	}))
+	require.NoError(t, d.View(func(tx Tx) error {
+		var names []string
+		require.NoError(t, tx.ForEach(func(name []byte, _ Bucket) error {
+			names = append(names, string(name))
+			return nil
+		}))
+		assert.DeepEqual(t, []string{"a", "ab", "b"}, names)
+		// Keys of a bucket must not show up in a bucket whose name it prefixes.
+		var keys int
+		require.NoError(t, tx.Bucket([]byte("ab")).ForEach(func(_, _ []byte) error {
+			keys++
+			return nil
+		}))
+		assert.Equal(t, 0, keys)
+		return nil
+	}))
+	require.NoError(t, d.Update(func(tx Tx) error {
+		require.ErrorIs(t, tx.DeleteBucket([]byte("c")), ErrBucketNotFound)
+		require.NoError(t, tx.DeleteBucket([]byte("a")))
+		assert.Equal(t, nil, tx.Bucket([]byte("a")))
+		b, err := tx.CreateBucket([]byte("a"))
+		require.NoError(t, err)
+		assert.DeepEqual(t, []byte(nil), b.Get([]byte("k")))
+		return nil
+	}))
+}
+
+func testGetPutDelete(t *testing.T, d DB) {
+	require.NoError(t, d.Update(func(tx Tx) error {
+		b, err := tx.CreateBucket([]byte("a"))
+		require.NoError(t, err)
+		require.NoError(t, b.Put([]byte("k1"), []byte("v1")))
+		require.NoError(t, b.Put([]byte("k2"), []byte{}))
+		require.NoError(t, b.Put([]byte("k3"), []byte("v3")))
+		return b.Put([]byte("k1"), []byte("v1'"))
+	}))
+	require.NoError(t, d.Update(func(tx Tx) error {
+		return tx.Bucket([]byte("a")).Delete([]byte("k3"))
+	}))
+	require.NoError(t, d.View(func(tx Tx) error {
+		b := tx.Bucket([]byte("a"))
+		assert.DeepEqual(t, []byte("v1'"), b.Get([]byte("k1")))
+		// An empty value is not the same as a missing key.
+		v := b.Get([]byte("k2"))
+		assert.NotNil(t, v)
+		assert.Equal(t, 0, len(v))
+		assert.DeepEqual(t, []byte(nil), b.Get([]byte("k3")))
+		return nil
+	}))
+}
+
+func testCursor(t *testing.T, d DB) {
+	require.NoError(t, d.Update(func(tx Tx) error {
+		b, err := tx.CreateBucket([]byte("a"))
+		require.NoError(t, err)
+		for _, k := range []string{"d", "b", "f"} {
+			require.NoError(t, b.Put([]byte(k), []byte("v"+k)))
+		}
+		other, err := tx.CreateBucket([]byte("b"))
+		require.NoError(t, err)
+		return other.Put([]byte("a"), []byte("other"))
+	}))
+	require.NoError(t, d.View(func(tx Tx) error {
+		c := tx.Bucket([]byte("a")).Cursor()
+		var keys []string
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			assert.Equal(t, "v"+string(k), string(v))
+			keys = append(keys, string(k))
+		}
+		assert.DeepEqual(t, []string{"b", "d", "f"}, keys)
+
+		k, _ := c.Seek([]byte("c"))
+		assert.Equal(t, "d", string(k))
+		k, _ = c.Seek([]byte("d"))
+		assert.Equal(t, "d", string(k))
+		k, _ = c.Prev()
+		assert.Equal(t, "b", string(k))
+		k, _ = c.Prev()
+		assert.DeepEqual(t, []byte(nil), k)
+		k, _ = c.Seek([]byte("g"))
+		assert.DeepEqual(t, []byte(nil), k)
+		k, _ = c.Last()
+		assert.Equal(t, "f", string(k))
+		k, _ = c.Next()
+		assert.DeepEqual(t, []byte(nil), k)
+		return nil
+	}))
+}
+
+func testRollback(t *testing.T, d DB) {
+	require.NoError(t, d.Update(func(tx Tx) error {
+		_, err := tx.CreateBucket([]byte("a"))
+		return err
+	}))
+	err := d.Update(func(tx Tx) error {
+		require.NoError(t, tx.Bucket([]byte("a")).Put([]byte("k"), []byte("v")))
+		_, err := tx.CreateBucket([]byte("b"))
+		require.NoError(t, err)
+		return errRollback
+	})
+	require.ErrorIs(t, err, errRollback)
+	require.NoError(t, d.View(func(tx Tx) error {
+		assert.DeepEqual(t, []byte(nil), tx.Bucket([]byte("a")).Get([]byte("k")))
+		assert.Equal(t, nil, tx.Bucket([]byte("b")))
+		return nil
+	}))
+}
+
+func testReadYourWrites(t *testing.T, d DB) {
+	require.NoError(t, d.Update(func(tx Tx) error {
+		b, err := tx.CreateBucket([]byte("a"))
+		require.NoError(t, err)
+		require.NoError(t, b.Put([]byte("k1"), []byte("v1")))
+		assert.DeepEqual(t, []byte("v1"), b.Get([]byte("k1")))
+		require.NoError(t, b.Put([]byte("k2"), []byte("v2")))
+		require.NoError(t, b.Delete([]byte("k1")))
+		k, v := b.Cursor().First()
+		assert.Equal(t, "k2", string(k))
+		assert.Equal(t, "v2", string(v))
+		return nil
+	}))
+}
+
+func testSnapshotIsolation(t *testing.T, d DB) {
+	require.NoError(t, d.Update(func(tx Tx) error {
+		b, err := tx.CreateBucket([]byte("a"))
+		require.NoError(t, err)
+		return b.Put([]byte("k"), []byte("old"))
+	}))
+	require.NoError(t, d.View(func(tx Tx) error {
+		done := make(chan error)
+		go func() {
+			done <- d.Update(func(tx Tx) error {
+				return tx.Bucket([]byte("a")).Put([]byte("k"), []byte("new"))
+			})
+		}()
+		require.NoError(t, <-done)
+		assert.DeepEqual(t, []byte("old"), tx.Bucket([]byte("a")).Get([]byte("k")))
+		return nil
+	}))
+	require.NoError(t, d.View(func(tx Tx) error {
+		assert.DeepEqual(t, []byte("new"), tx.Bucket([]byte("a")).Get([]byte("k")))
+		return nil
+	}))
+}
+
+func testReadOnly(t *testing.T, d DB) {
+	require.NoError(t, d.Update(func(tx Tx) error {
+		assert.Equal(t, true, tx.Writable())
+		_, err := tx.CreateBucket([]byte("a"))
+		return err
+	}))
+	require.NoError(t, d.View(func(tx Tx) error {
+		assert.Equal(t, false, tx.Writable())
+		require.ErrorIs(t, tx.Bucket([]byte("a")).Put([]byte("k"), []byte("v")), ErrTxNotWritable)
+		_, err := tx.CreateBucket([]byte("b"))
+		require.ErrorIs(t, err, ErrTxNotWritable)
+		return nil
+	}))
+}
+
+func testClosed(t *testing.T, d DB) {
+	require.NoError(t, d.Close())
+	require.ErrorIs(t, d.View(func(Tx) error { return nil }), ErrDatabaseNotOpen)
+	require.ErrorIs(t, d.Update(func(Tx) error { return nil }), ErrDatabaseNotOpen)
+}
+
+func TestCopy(t *testing.T) {
+	src := openTestDB(t, Bolt)
+	dst := openTestDB(t, Pebble)
+	require.NoError(t, src.Update(func(tx Tx) error {
+		for i := 0; i < 3; i++ {
+			b, err := tx.CreateBucket([]byte{byte('a' + i)})
+			if err != nil {
+				return err
+			}
+			for j := 0; j < 25; j++ {
+				if err := b.Put([]byte{byte(j)}, []byte{byte(i), byte(j)}); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}))
+	n, err := Copy(context.Background(), src, dst, 10)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(75), n)
+	require.NoError(t, dst.View(func(tx Tx) error {
+		for i := 0; i < 3; i++ {
+			b := tx.Bucket([]byte{byte('a' + i)})
+			require.NotNil(t, b)
+			var keys int
+			require.NoError(t, b.ForEach(func(k, v []byte) error {
+				assert.DeepEqual(t, []byte{byte(i), k[0]}, v)
+				keys++
+				return nil
+			}))
+			assert.Equal(t, 25, keys)
+		}
+		return nil
+	}))
+	_, err = Copy(context.Background(), src, dst, 0)
+	require.ErrorContains(t, "batch size must be positive", err)
+}
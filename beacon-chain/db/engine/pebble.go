@@ -0,0 +1,378 @@
+package engine
+
+import (
+	"bytes"
+	"io"
+	"sync"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Pebble has a single keyspace, so buckets are kept as key prefixes. The names of the buckets are stored under the
+// bucket prefix, and the keys of a bucket are stored under the key prefix followed by the length of the bucket name
+// and the name itself, so that the keys of a bucket can never run into the keys of another bucket.
+const (
+	pebbleBucketPrefix byte = iota
+	pebbleKeyPrefix
+)
+
+// maxPebbleBucketName is the longest bucket name, as its length is stored in a single byte.
+const maxPebbleBucketName = 255
+
+type pebbleDB struct {
+	db *pebble.DB
+	// writer serializes read-write transactions, which read their own writes from an indexed batch.
+	writer sync.Mutex
+	// lock is held for reading by transactions, and for writing by Close.
+	lock   sync.RWMutex
+	closed bool
+}
+
+// OpenPebble opens or creates the pebble database in the directory dir.
+func OpenPebble(dir string) (DB, error) {
+	db, err := pebble.Open(dir, &pebble.Options{Logger: pebbleLogger{}})
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not open pebble database in %s", dir)
+	}
+	return &pebbleDB{db: db}, nil
+}
+
+func (p *pebbleDB) View(fn func(Tx) error) error {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	if p.closed {
+		return ErrDatabaseNotOpen
+	}
+	snap := p.db.NewSnapshot()
+	tx := &pebbleTx{r: snap}
+	defer func() {
+		tx.close()
+		if err := snap.Close(); err != nil {
+			log.WithError(err).Error("Could not close pebble snapshot")
+		}
+	}()
+	return fn(tx)
+}
+
+func (p *pebbleDB) Update(fn func(Tx) error) error {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	if p.closed {
+		return ErrDatabaseNotOpen
+	}
+	p.writer.Lock()
+	defer p.writer.Unlock()
+	batch := p.db.NewIndexedBatch()
+	tx := &pebbleTx{r: batch, w: batch}
+	defer func() {
+		tx.close()
+		if err := batch.Close(); err != nil {
+			log.WithError(err).Error("Could not close pebble batch")
+		}
+	}()
+	if err := fn(tx); err != nil {
+		return err
+	}
+	tx.close()
+	return batch.Commit(pebble.Sync)
+}
+
+func (*pebbleDB) Engine() string {
+	return Pebble
+}
+
+func (*pebbleDB) Collector() prometheus.Collector {
+	return nil
+}
+
+func (p *pebbleDB) Close() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.closed {
+		return ErrDatabaseNotOpen
+	}
+	p.closed = true
+	return p.db.Close()
+}
+
+type pebbleReader interface {
+	Get(key []byte) ([]byte, io.Closer, error)
+	NewIter(o *pebble.IterOptions) (*pebble.Iterator, error)
+}
+
+type pebbleTx struct {
+	r     pebbleReader
+	w     *pebble.Batch
+	iters []*pebble.Iterator
+}
+
+func (t *pebbleTx) Bucket(name []byte) Bucket {
+	if !t.has(pebbleBucketKey(name)) {
+		return nil
+	}
+	return &pebbleBucket{tx: t, prefix: pebbleKeysPrefix(name)}
+}
+
+func (t *pebbleTx) CreateBucket(name []byte) (Bucket, error) {
+	if t.w == nil {
+		return nil, ErrTxNotWritable
+	}
+	if len(name) == 0 || len(name) > maxPebbleBucketName {
+		return nil, errors.Errorf("bucket name must be between 1 and %d bytes", maxPebbleBucketName)
+	}
+	if t.has(pebbleBucketKey(name)) {
+		return nil, ErrBucketExists
+	}
+	if err := t.w.Set(pebbleBucketKey(name), []byte{}, nil); err != nil {
+		return nil, err
+	}
+	return &pebbleBucket{tx: t, prefix: pebbleKeysPrefix(name)}, nil
+}
+
+func (t *pebbleTx) CreateBucketIfNotExists(name []byte) (Bucket, error) {
+	b, err := t.CreateBucket(name)
+	if errors.Is(err, ErrBucketExists) {
+		return t.Bucket(name), nil
+	}
+	return b, err
+}
+
+func (t *pebbleTx) DeleteBucket(name []byte) error {
+	if t.w == nil {
+		return ErrTxNotWritable
+	}
+	if !t.has(pebbleBucketKey(name)) {
+		return ErrBucketNotFound
+	}
+	if err := t.w.Delete(pebbleBucketKey(name), nil); err != nil {
+		return err
+	}
+	prefix := pebbleKeysPrefix(name)
+	return t.w.DeleteRange(prefix, prefixEnd(prefix), nil)
+}
+
+func (t *pebbleTx) ForEach(fn func(name []byte, b Bucket) error) error {
+	c := t.cursor([]byte{pebbleBucketPrefix})
+	for k, _ := c.First(); k != nil; k, _ = c.Next() {
+		if err := fn(k, &pebbleBucket{tx: t, prefix: pebbleKeysPrefix(k)}); err != nil {
+			return err
+		}
+	}
+	return c.err()
+}
+
+func (t *pebbleTx) Writable() bool {
+	return t.w != nil
+}
+
+func (t *pebbleTx) has(key []byte) bool {
+	_, closer, err := t.r.Get(key)
+	if err != nil {
+		return false
+	}
+	if err := closer.Close(); err != nil {
+		log.WithError(err).Error("Could not release pebble value")
+	}
+	return true
+}
+
+func (t *pebbleTx) cursor(prefix []byte) *pebbleCursor {
+	return &pebbleCursor{tx: t, prefix: prefix}
+}
+
+// close releases the iterators of the transaction. Iterators must be closed before their batch is committed.
+func (t *pebbleTx) close() {
+	for _, it := range t.iters {
+		if err := it.Close(); err != nil {
+			log.WithError(err).Error("Could not close pebble iterator")
+		}
+	}
+	t.iters = nil
+}
+
+type pebbleBucket struct {
+	tx     *pebbleTx
+	prefix []byte
+}
+
+func (b *pebbleBucket) Get(key []byte) []byte {
+	v, closer, err := b.tx.r.Get(b.key(key))
+	if err != nil {
+		if !errors.Is(err, pebble.ErrNotFound) {
+			log.WithError(err).Error("Could not read from pebble")
+		}
+		return nil
+	}
+	defer func() {
+		if err := closer.Close(); err != nil {
+			log.WithError(err).Error("Could not release pebble value")
+		}
+	}()
+	return clone(v)
+}
+
+func (b *pebbleBucket) Put(key, value []byte) error {
+	if b.tx.w == nil {
+		return ErrTxNotWritable
+	}
+	if len(key) == 0 {
+		return errors.New("key required")
+	}
+	return b.tx.w.Set(b.key(key), value, nil)
+}
+
+func (b *pebbleBucket) Delete(key []byte) error {
+	if b.tx.w == nil {
+		return ErrTxNotWritable
+	}
+	return b.tx.w.Delete(b.key(key), nil)
+}
+
+func (b *pebbleBucket) Cursor() Cursor {
+	return b.tx.cursor(b.prefix)
+}
+
+func (b *pebbleBucket) ForEach(fn func(k, v []byte) error) error {
+	c := b.tx.cursor(b.prefix)
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		if err := fn(k, v); err != nil {
+			return err
+		}
+	}
+	return c.err()
+}
+
+func (b *pebbleBucket) key(k []byte) []byte {
+	key := make([]byte, len(b.prefix)+len(k))
+	copy(key, b.prefix)
+	copy(key[len(b.prefix):], k)
+	return key
+}
+
+// pebbleCursor iterates over the keys with a prefix. The iterator is opened on first use, and the keys and values
+// it returns are copies, as pebble reuses the buffers of an iterator as it moves.
+type pebbleCursor struct {
+	tx     *pebbleTx
+	prefix []byte
+	it     *pebble.Iterator
+	failed error
+}
+
+func (c *pebbleCursor) First() ([]byte, []byte) {
+	if !c.open() {
+		return nil, nil
+	}
+	return c.entry(c.it.First())
+}
+
+func (c *pebbleCursor) Last() ([]byte, []byte) {
+	if !c.open() {
+		return nil, nil
+	}
+	return c.entry(c.it.Last())
+}
+
+func (c *pebbleCursor) Next() ([]byte, []byte) {
+	if !c.open() {
+		return nil, nil
+	}
+	return c.entry(c.it.Next())
+}
+
+func (c *pebbleCursor) Prev() ([]byte, []byte) {
+	if !c.open() {
+		return nil, nil
+	}
+	return c.entry(c.it.Prev())
+}
+
+func (c *pebbleCursor) Seek(seek []byte) ([]byte, []byte) {
+	if !c.open() {
+		return nil, nil
+	}
+	key := make([]byte, len(c.prefix)+len(seek))
+	copy(key, c.prefix)
+	copy(key[len(c.prefix):], seek)
+	return c.entry(c.it.SeekGE(key))
+}
+
+func (c *pebbleCursor) open() bool {
+	if c.it != nil {
+		return true
+	}
+	if c.failed != nil {
+		return false
+	}
+	it, err := c.tx.r.NewIter(&pebble.IterOptions{LowerBound: c.prefix, UpperBound: prefixEnd(c.prefix)})
+	if err != nil {
+		log.WithError(err).Error("Could not open pebble iterator")
+		c.failed = err
+		return false
+	}
+	c.tx.iters = append(c.tx.iters, it)
+	c.it = it
+	return true
+}
+
+func (c *pebbleCursor) entry(valid bool) ([]byte, []byte) {
+	if !valid {
+		return nil, nil
+	}
+	v, err := c.it.ValueAndErr()
+	if err != nil {
+		log.WithError(err).Error("Could not read from pebble")
+		c.failed = err
+		return nil, nil
+	}
+	return clone(c.it.Key()[len(c.prefix):]), clone(v)
+}
+
+func (c *pebbleCursor) err() error {
+	if c.failed != nil {
+		return c.failed
+	}
+	if c.it != nil {
+		return c.it.Error()
+	}
+	return nil
+}
+
+func pebbleBucketKey(name []byte) []byte {
+	return append([]byte{pebbleBucketPrefix}, name...)
+}
+
+func pebbleKeysPrefix(name []byte) []byte {
+	return append([]byte{pebbleKeyPrefix, byte(len(name))}, name...)
+}
+
+// prefixEnd returns the smallest key which is greater than every key with the prefix.
+func prefixEnd(prefix []byte) []byte {
+	end := bytes.Clone(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		end[i]++
+		if end[i] != 0 {
+			return end[:i+1]
+		}
+	}
+	return nil
+}
+
+// clone copies b into a new slice, which is never nil.
+func clone(b []byte) []byte {
+	c := make([]byte, len(b))
+	copy(c, b)
+	return c
+}
+
+// pebbleLogger writes the logs of pebble to the db logger.
+type pebbleLogger struct{}
+
+func (pebbleLogger) Infof(format string, args ...interface{}) {
+	log.Debugf(format, args...)
+}
+
+func (pebbleLogger) Fatalf(format string, args ...interface{}) {
+	log.Fatalf(format, args...)
+}
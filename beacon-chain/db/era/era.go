@@ -0,0 +1,324 @@
+// Package era reads and writes era files, a portable format for finalized beacon chain history. Each era file
+// holds the blocks of one SLOTS_PER_HISTORICAL_ROOT sized era, followed by the state at the end of that era:
+//
+//	Version | CompressedSignedBeaconBlock* | CompressedBeaconState | SlotIndex(block)? | SlotIndex(state)
+//
+// Blocks and states are ssz encoded and snappy framed. The slot indices allow any block, or the state, to be read
+// without scanning the whole file. Era 0 holds only the genesis state. Era N > 0 holds the blocks for slots
+// [(N-1)*SLOTS_PER_HISTORICAL_ROOT, N*SLOTS_PER_HISTORICAL_ROOT) and the state at slot N*SLOTS_PER_HISTORICAL_ROOT,
+// after processing empty slots up to the era boundary but before any block at that slot is applied.
+// See https://github.com/status-im/nimbus-eth2/blob/stable/docs/e2store.md#era-files for the full specification.
+package era
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stateutil"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/crypto/hash"
+	"github.com/prysmaticlabs/prysm/v5/encoding/ssz/detect"
+	"github.com/prysmaticlabs/prysm/v5/io/e2store"
+)
+
+var (
+	compressedBlockType = e2store.Type{0x01, 0x00}
+	compressedStateType = e2store.Type{0x02, 0x00}
+	slotIndexType       = e2store.Type{0x69, 0x32}
+)
+
+// stateIndexSize is the size of the slot index entry for the state, which is always the last entry in the file.
+const stateIndexSize = e2store.HeaderSize + 3*8
+
+var (
+	ErrInvalidEraFile = errors.New("invalid era file")
+	errWriterFinished = errors.New("era writer has already written the state")
+)
+
+// Slots returns the first slot of the blocks held in the given era, and the slot of the era state. Era 0 has no
+// blocks, so both values are 0.
+func Slots(era uint64) (primitives.Slot, primitives.Slot) {
+	sphr := params.BeaconConfig().SlotsPerHistoricalRoot
+	if era == 0 {
+		return 0, 0
+	}
+	return primitives.Slot(era-1) * sphr, primitives.Slot(era) * sphr
+}
+
+// Filename returns the standard name of an era file, <config-name>-<era-number>-<short-historical-root>.era.
+// The short historical root is the first 4 bytes of the root that the era adds to the historical roots or
+// summaries of the state, or of the genesis validators root for era 0.
+func Filename(era uint64, st state.ReadOnlyBeaconState) (string, error) {
+	short := st.GenesisValidatorsRoot()
+	if era > 0 {
+		r, err := historicalRoot(st)
+		if err != nil {
+			return "", err
+		}
+		short = r[:]
+	}
+	return fmt.Sprintf("%s-%05d-%x.era", params.BeaconConfig().ConfigName, era, short[:4]), nil
+}
+
+// historicalRoot computes hash_tree_root(HistoricalBatch) from the block and state roots of an era state. This is
+// equal to the hash_tree_root of the HistoricalSummary added for the era after the capella fork.
+func historicalRoot(st state.ReadOnlyBeaconState) ([32]byte, error) {
+	sphr := uint64(params.BeaconConfig().SlotsPerHistoricalRoot)
+	br, err := stateutil.ArraysRoot(st.BlockRoots(), sphr)
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "could not compute block roots root")
+	}
+	sr, err := stateutil.ArraysRoot(st.StateRoots(), sphr)
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "could not compute state roots root")
+	}
+	return hash.Hash(append(br[:], sr[:]...)), nil
+}
+
+// Writer writes a single era file. Blocks must be written in slot order, followed by a call to Finish.
+type Writer struct {
+	e        *e2store.Writer
+	era      uint64
+	start    primitives.Slot
+	offsets  []int64
+	lastSlot primitives.Slot
+	written  bool
+	finished bool
+}
+
+// NewWriter creates a Writer for the given era and writes the version entry that starts the file.
+func NewWriter(w io.Writer, era uint64) (*Writer, error) {
+	e := e2store.NewWriter(w)
+	if _, err := e.WriteEntry(e2store.VersionType, nil); err != nil {
+		return nil, errors.Wrap(err, "could not write era version entry")
+	}
+	start, _ := Slots(era)
+	ew := &Writer{e: e, era: era, start: start}
+	if era > 0 {
+		ew.offsets = make([]int64, params.BeaconConfig().SlotsPerHistoricalRoot)
+	}
+	return ew, nil
+}
+
+// WriteBlock appends a block to the era file. Blocks outside of the era, or at or below the slot of the
+// previously written block, are rejected.
+func (w *Writer) WriteBlock(b interfaces.ReadOnlySignedBeaconBlock) error {
+	if w.finished {
+		return errWriterFinished
+	}
+	slot := b.Block().Slot()
+	if slot < w.start || uint64(slot-w.start) >= uint64(len(w.offsets)) {
+		return errors.Errorf("block at slot %d is outside of era %d", slot, w.era)
+	}
+	if w.written && slot <= w.lastSlot {
+		return errors.Errorf("block at slot %d written after block at slot %d", slot, w.lastSlot)
+	}
+	enc, err := b.MarshalSSZ()
+	if err != nil {
+		return errors.Wrapf(err, "could not marshal block at slot %d", slot)
+	}
+	data, err := compress(enc)
+	if err != nil {
+		return err
+	}
+	off, err := w.e.WriteEntry(compressedBlockType, data)
+	if err != nil {
+		return errors.Wrapf(err, "could not write block at slot %d", slot)
+	}
+	w.offsets[slot-w.start] = off
+	w.lastSlot, w.written = slot, true
+	return nil
+}
+
+// Finish writes the era state followed by the slot indices, completing the file.
+func (w *Writer) Finish(st state.ReadOnlyBeaconState) error {
+	if w.finished {
+		return errWriterFinished
+	}
+	_, stateSlot := Slots(w.era)
+	if st.Slot() != stateSlot {
+		return errors.Errorf("state slot %d does not match the end of era %d at slot %d", st.Slot(), w.era, stateSlot)
+	}
+	enc, err := st.MarshalSSZ()
+	if err != nil {
+		return errors.Wrap(err, "could not marshal era state")
+	}
+	data, err := compress(enc)
+	if err != nil {
+		return err
+	}
+	stateOffset, err := w.e.WriteEntry(compressedStateType, data)
+	if err != nil {
+		return errors.Wrap(err, "could not write era state")
+	}
+	if w.era > 0 {
+		if err := w.writeIndex(w.start, w.offsets); err != nil {
+			return errors.Wrap(err, "could not write block index")
+		}
+	}
+	if err := w.writeIndex(stateSlot, []int64{stateOffset}); err != nil {
+		return errors.Wrap(err, "could not write state index")
+	}
+	w.finished = true
+	return nil
+}
+
+// writeIndex writes a slot index entry. Offsets in the index are relative to the start of the index entry,
+// with 0 marking a slot without a block.
+func (w *Writer) writeIndex(start primitives.Slot, offsets []int64) error {
+	base := w.e.Offset()
+	data := make([]byte, 8*(len(offsets)+2))
+	binary.LittleEndian.PutUint64(data[0:8], uint64(start))
+	for i, off := range offsets {
+		if off != 0 {
+			off -= base
+		}
+		binary.LittleEndian.PutUint64(data[8*(i+1):], uint64(off))
+	}
+	binary.LittleEndian.PutUint64(data[len(data)-8:], uint64(len(offsets)))
+	_, err := w.e.WriteEntry(slotIndexType, data)
+	return err
+}
+
+// File provides random access to the blocks and state of an era file.
+type File struct {
+	r     io.ReaderAt
+	Era   uint64
+	start primitives.Slot
+	// blocks holds the absolute offset of the block at each slot of the era, or 0 for empty slots.
+	blocks []int64
+	state  int64
+}
+
+// Open reads the slot indices at the end of an era file of the given size.
+func Open(r io.ReaderAt, size int64) (*File, error) {
+	if size < stateIndexSize {
+		return nil, errors.Wrap(ErrInvalidEraFile, "file is too small")
+	}
+	sphr := params.BeaconConfig().SlotsPerHistoricalRoot
+	stateIndexOffset := size - stateIndexSize
+	stateSlot, offsets, err := readIndex(r, stateIndexOffset)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read state index")
+	}
+	if len(offsets) != 1 || offsets[0] == 0 || stateSlot%sphr != 0 {
+		return nil, errors.Wrap(ErrInvalidEraFile, "malformed state index")
+	}
+	f := &File{r: r, Era: uint64(stateSlot / sphr), state: offsets[0]}
+	if f.Era == 0 {
+		return f, nil
+	}
+	blockIndexOffset := stateIndexOffset - int64(e2store.HeaderSize) - 8*(int64(sphr)+2)
+	if blockIndexOffset < 0 {
+		return nil, errors.Wrap(ErrInvalidEraFile, "file is too small for a block index")
+	}
+	start, offsets, err := readIndex(r, blockIndexOffset)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read block index")
+	}
+	if want, _ := Slots(f.Era); start != want || uint64(len(offsets)) != uint64(sphr) {
+		return nil, errors.Wrapf(ErrInvalidEraFile, "block index for slot %d with %d entries does not match era %d", start, len(offsets), f.Era)
+	}
+	f.start, f.blocks = start, offsets
+	return f, nil
+}
+
+// readIndex reads the slot index entry at the given offset, returning the starting slot and the absolute offsets
+// of the indexed entries.
+func readIndex(r io.ReaderAt, at int64) (primitives.Slot, []int64, error) {
+	e, err := e2store.ReadEntryAt(r, at)
+	if err != nil {
+		return 0, nil, err
+	}
+	if e.Type != slotIndexType || len(e.Data) < 16 || len(e.Data)%8 != 0 {
+		return 0, nil, errors.Wrapf(ErrInvalidEraFile, "no slot index at offset %d", at)
+	}
+	count := binary.LittleEndian.Uint64(e.Data[len(e.Data)-8:])
+	if count != uint64(len(e.Data)/8-2) {
+		return 0, nil, errors.Wrapf(ErrInvalidEraFile, "slot index count %d does not match its length", count)
+	}
+	start := primitives.Slot(binary.LittleEndian.Uint64(e.Data[0:8]))
+	offsets := make([]int64, count)
+	for i := range offsets {
+		rel := int64(binary.LittleEndian.Uint64(e.Data[8*(i+1):]))
+		if rel == 0 {
+			continue
+		}
+		offsets[i] = at + rel
+		if offsets[i] < 0 || offsets[i] >= at {
+			return 0, nil, errors.Wrapf(ErrInvalidEraFile, "slot index entry %d points outside of the file", i)
+		}
+	}
+	return start, offsets, nil
+}
+
+// State reads the era state.
+func (f *File) State() (state.BeaconState, error) {
+	enc, err := f.read(f.state, compressedStateType)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read era state")
+	}
+	vu, err := detect.FromState(enc)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not detect era state version")
+	}
+	return vu.UnmarshalBeaconState(enc)
+}
+
+// Block reads the block at the given slot, returning nil if the era has no block at that slot.
+func (f *File) Block(slot primitives.Slot) (interfaces.ReadOnlySignedBeaconBlock, error) {
+	if slot < f.start || uint64(slot-f.start) >= uint64(len(f.blocks)) {
+		return nil, errors.Errorf("slot %d is outside of era %d", slot, f.Era)
+	}
+	off := f.blocks[slot-f.start]
+	if off == 0 {
+		return nil, nil
+	}
+	enc, err := f.read(off, compressedBlockType)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read block at slot %d", slot)
+	}
+	vu, err := detect.FromBlock(enc)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not detect version of block at slot %d", slot)
+	}
+	return vu.UnmarshalBeaconBlock(enc)
+}
+
+func (f *File) read(off int64, t e2store.Type) ([]byte, error) {
+	e, err := e2store.ReadEntryAt(f.r, off)
+	if err != nil {
+		return nil, err
+	}
+	if e.Type != t {
+		return nil, errors.Wrapf(ErrInvalidEraFile, "entry at offset %d has type %#x, expected %#x", off, e.Type, t)
+	}
+	return decompress(e.Data)
+}
+
+func compress(b []byte) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	w := snappy.NewBufferedWriter(buf)
+	if _, err := w.Write(b); err != nil {
+		return nil, errors.Wrap(err, "could not compress era entry")
+	}
+	if err := w.Close(); err != nil {
+		return nil, errors.Wrap(err, "could not compress era entry")
+	}
+	return buf.Bytes(), nil
+}
+
+func decompress(b []byte) ([]byte, error) {
+	dec, err := io.ReadAll(snappy.NewReader(bytes.NewReader(b)))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decompress era entry")
+	}
+	return dec, nil
+}
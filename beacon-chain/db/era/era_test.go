@@ -0,0 +1,243 @@
+package era
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/iface"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	dbtest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+type testChain struct {
+	genesis  state.BeaconState
+	era      state.BeaconState
+	blocks   []interfaces.ReadOnlySignedBeaconBlock
+	roots    [][32]byte
+	lastRoot [32]byte
+}
+
+// setupChain saves a chain of blocks at the given slots in the first era, along with a finalized era state whose
+// block roots match the chain. The blocks are not valid state transitions, so the era state must be read from the
+// database rather than regenerated.
+func setupChain(t *testing.T, beaconDB iface.HeadAccessDatabase, blockSlots []primitives.Slot) *testChain {
+	ctx := context.Background()
+	sphr := params.BeaconConfig().SlotsPerHistoricalRoot
+	genesis, _ := util.DeterministicGenesisState(t, 32)
+	require.NoError(t, beaconDB.SaveGenesisData(ctx, genesis))
+	gRoot, err := beaconDB.GenesisBlockRoot(ctx)
+	require.NoError(t, err)
+
+	c := &testChain{genesis: genesis}
+	parent := gRoot
+	byRoot := make(map[primitives.Slot][32]byte)
+	var header *ethpb.SignedBeaconBlockHeader
+	for i, s := range blockSlots {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = s
+		b.Block.ParentRoot = parent[:]
+		b.Block.StateRoot = bytesutil.PadTo([]byte{byte(i + 1)}, 32)
+		wsb, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		require.NoError(t, beaconDB.SaveBlock(ctx, wsb))
+		root, err := wsb.Block().HashTreeRoot()
+		require.NoError(t, err)
+		c.blocks = append(c.blocks, wsb)
+		c.roots = append(c.roots, root)
+		byRoot[s] = root
+		parent = root
+		header, err = wsb.Header()
+		require.NoError(t, err)
+	}
+	c.lastRoot = parent
+
+	c.era = genesis.Copy()
+	require.NoError(t, c.era.SetSlot(sphr))
+	current := gRoot
+	for s := primitives.Slot(0); s < sphr; s++ {
+		if r, ok := byRoot[s]; ok {
+			current = r
+		}
+		require.NoError(t, c.era.UpdateBlockRootAtIndex(uint64(s), current))
+	}
+	require.NoError(t, c.era.SetLatestBlockHeader(header.Header))
+	require.NoError(t, beaconDB.SaveState(ctx, c.era, c.lastRoot))
+	require.NoError(t, beaconDB.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: sphr, Root: c.lastRoot[:]}))
+	require.NoError(t, beaconDB.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: slots.ToEpoch(sphr), Root: c.lastRoot[:]}))
+	return c
+}
+
+// sourceDB opens a database that is closed by the test once it has been exported from. Only one database can be
+// open at a time, since each registers its metrics.
+func sourceDB(t *testing.T) *kv.Store {
+	// The embedded genesis state of a named network would be returned in place of the test genesis state.
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.ConfigName = "era-test"
+	params.OverrideBeaconConfig(cfg)
+	s, err := kv.NewKVStore(context.Background(), t.TempDir())
+	require.NoError(t, err)
+	return s
+}
+
+func openEra(t *testing.T, p string) *File {
+	b, err := os.ReadFile(p)
+	require.NoError(t, err)
+	f, err := Open(bytes.NewReader(b), int64(len(b)))
+	require.NoError(t, err)
+	return f
+}
+
+func TestExportImport(t *testing.T) {
+	ctx := context.Background()
+	src := sourceDB(t)
+	blockSlots := []primitives.Slot{1, 2, 10, 100, params.BeaconConfig().SlotsPerHistoricalRoot - 1}
+	c := setupChain(t, src, blockSlots)
+	dir := t.TempDir()
+
+	p0, err := Export(ctx, src, 0, dir)
+	require.NoError(t, err)
+	require.Equal(t, true, strings.HasPrefix(filepath.Base(p0), params.BeaconConfig().ConfigName+"-00000-"))
+	p1, err := Export(ctx, src, 1, dir)
+	require.NoError(t, err)
+	require.Equal(t, true, strings.HasPrefix(filepath.Base(p1), params.BeaconConfig().ConfigName+"-00001-"))
+	_, err = Export(ctx, src, 2, dir)
+	require.ErrorIs(t, err, ErrNotFinalized)
+	// No partial files are left behind.
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(entries))
+
+	f1 := openEra(t, p1)
+	require.Equal(t, uint64(1), f1.Era)
+	st, err := f1.State()
+	require.NoError(t, err)
+	require.Equal(t, c.era.Slot(), st.Slot())
+	blk, err := f1.Block(10)
+	require.NoError(t, err)
+	require.Equal(t, primitives.Slot(10), blk.Block().Slot())
+	blk, err = f1.Block(11)
+	require.NoError(t, err)
+	require.Equal(t, true, blk == nil)
+	// The genesis block is not part of the era.
+	blk, err = f1.Block(0)
+	require.NoError(t, err)
+	require.Equal(t, true, blk == nil)
+	srcGenesis, err := src.GenesisBlockRoot(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, src.Close())
+	dst := dbtest.SetupDB(t)
+	require.ErrorIs(t, Import(ctx, dst, f1), ErrMissingParent)
+	require.NoError(t, Import(ctx, dst, openEra(t, p0)))
+	require.NoError(t, Import(ctx, dst, f1))
+	for _, r := range c.roots {
+		require.Equal(t, true, dst.HasBlock(ctx, r))
+	}
+	dstGenesis, err := dst.GenesisBlockRoot(ctx)
+	require.NoError(t, err)
+	require.Equal(t, srcGenesis, dstGenesis)
+	cp, err := dst.FinalizedCheckpoint(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, c.lastRoot[:], cp.Root)
+	require.Equal(t, slots.ToEpoch(blockSlots[len(blockSlots)-1]), cp.Epoch)
+	require.Equal(t, true, dst.IsFinalizedBlock(ctx, c.roots[0]))
+	require.Equal(t, true, dst.HasState(ctx, c.lastRoot))
+
+	// Importing the same eras again is a no-op.
+	require.NoError(t, Import(ctx, dst, openEra(t, p0)))
+	require.NoError(t, Import(ctx, dst, f1))
+}
+
+func TestImport_GenesisMismatch(t *testing.T) {
+	ctx := context.Background()
+	src := sourceDB(t)
+	setupChain(t, src, []primitives.Slot{1})
+	p0, err := Export(ctx, src, 0, t.TempDir())
+	require.NoError(t, err)
+
+	require.NoError(t, src.Close())
+	dst := dbtest.SetupDB(t)
+	other, _ := util.DeterministicGenesisState(t, 64)
+	require.NoError(t, dst.SaveGenesisData(ctx, other))
+	require.ErrorIs(t, Import(ctx, dst, openEra(t, p0)), ErrGenesisMismatch)
+}
+
+func TestImport_BlockNotInState(t *testing.T) {
+	ctx := context.Background()
+	src := sourceDB(t)
+	c := setupChain(t, src, []primitives.Slot{1, 5})
+
+	// Replace the block at slot 5 with a different block, which the era state does not commit to.
+	b := util.NewBeaconBlock()
+	b.Block.Slot = 5
+	b.Block.ParentRoot = c.roots[0][:]
+	wsb, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	buf := &bytes.Buffer{}
+	w, err := NewWriter(buf, 1)
+	require.NoError(t, err)
+	require.NoError(t, w.WriteBlock(c.blocks[0]))
+	require.NoError(t, w.WriteBlock(wsb))
+	require.ErrorContains(t, "written after block", w.WriteBlock(c.blocks[0]))
+	require.NoError(t, w.Finish(c.era))
+	f, err := Open(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	require.NoError(t, src.Close())
+	dst := dbtest.SetupDB(t)
+	require.NoError(t, dst.SaveGenesisData(ctx, c.genesis))
+	require.ErrorIs(t, Import(ctx, dst, f), ErrInvalidEraFile)
+}
+
+func TestExport_RegeneratesState(t *testing.T) {
+	ctx := context.Background()
+	sphr := params.BeaconConfig().SlotsPerHistoricalRoot
+	src := sourceDB(t)
+	genesis, _ := util.DeterministicGenesisState(t, 32)
+	require.NoError(t, src.SaveGenesisData(ctx, genesis))
+	gRoot, err := src.GenesisBlockRoot(ctx)
+	require.NoError(t, err)
+	require.NoError(t, src.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: slots.ToEpoch(sphr), Root: gRoot[:]}))
+
+	// Only the genesis state is available, so the era state is regenerated by processing every slot of the era.
+	p1, err := Export(ctx, src, 1, t.TempDir())
+	require.NoError(t, err)
+	f1 := openEra(t, p1)
+	st, err := f1.State()
+	require.NoError(t, err)
+	require.Equal(t, sphr, st.Slot())
+	r, err := st.BlockRootAtIndex(uint64(sphr - 1))
+	require.NoError(t, err)
+	require.DeepEqual(t, gRoot[:], r)
+
+	require.NoError(t, src.Close())
+	dst := dbtest.SetupDB(t)
+	require.NoError(t, dst.SaveGenesisData(ctx, genesis))
+	require.NoError(t, Import(ctx, dst, f1))
+}
+
+func TestOpen_Invalid(t *testing.T) {
+	_, err := Open(bytes.NewReader([]byte{1, 2, 3}), 3)
+	require.ErrorIs(t, err, ErrInvalidEraFile)
+
+	buf := &bytes.Buffer{}
+	w, err := NewWriter(buf, 1)
+	require.NoError(t, err)
+	genesis, _ := util.DeterministicGenesisState(t, 4)
+	require.ErrorContains(t, "does not match the end of era", w.Finish(genesis))
+}
@@ -0,0 +1,269 @@
+package era
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/iface"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrNotFinalized = errors.New("era is not finalized")
+	ErrMissingBlock = errors.New("block is missing from the database")
+	ErrBlindedBlock = errors.New("block is stored without its execution payload")
+	errNoBaseState  = errors.New("no canonical state found to regenerate the era state from")
+)
+
+// Export writes the era file for the given era into dir, returning the path of the new file. The era must be
+// finalized, and the database must hold every canonical block of the era with full execution payloads.
+// The era state is read from the database when it holds the post-state of the last block of the era, and is
+// otherwise regenerated by replaying blocks from the closest earlier state.
+func Export(ctx context.Context, beaconDB iface.ReadOnlyDatabase, era uint64, dir string) (string, error) {
+	start, stateSlot := Slots(era)
+	cp, err := beaconDB.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "could not get finalized checkpoint")
+	}
+	fSlot, err := slots.EpochStart(cp.Epoch)
+	if err != nil {
+		return "", err
+	}
+	if stateSlot > fSlot {
+		return "", errors.Wrapf(ErrNotFinalized, "era %d ends at slot %d, finalized slot is %d", era, stateSlot, fSlot)
+	}
+	st, err := stateAtSlot(ctx, beaconDB, stateSlot)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not get state for era %d", era)
+	}
+	name, err := Filename(era, st)
+	if err != nil {
+		return "", err
+	}
+	p := filepath.Join(dir, name)
+	part := p + ".part"
+	f, err := os.Create(part) // #nosec G304 -- the output directory is chosen by the user.
+	if err != nil {
+		return "", errors.Wrapf(err, "could not create %s", part)
+	}
+	defer func() {
+		if err := f.Close(); err != nil && !errors.Is(err, os.ErrClosed) {
+			log.WithError(err).Error("Could not close era file")
+		}
+		if err := os.Remove(part); err != nil && !os.IsNotExist(err) {
+			log.WithError(err).WithField("file", part).Error("Could not remove partial era file")
+		}
+	}()
+	bw := bufio.NewWriter(f)
+	w, err := NewWriter(bw, era)
+	if err != nil {
+		return "", err
+	}
+	nBlocks := 0
+	if era > 0 {
+		if nBlocks, err = writeBlocks(ctx, beaconDB, w, st, start, stateSlot); err != nil {
+			return "", err
+		}
+	}
+	if err := w.Finish(st); err != nil {
+		return "", err
+	}
+	if err := bw.Flush(); err != nil {
+		return "", errors.Wrapf(err, "could not write %s", part)
+	}
+	if err := f.Sync(); err != nil {
+		return "", errors.Wrapf(err, "could not sync %s", part)
+	}
+	if err := f.Close(); err != nil {
+		return "", errors.Wrapf(err, "could not close %s", part)
+	}
+	if err := os.Rename(part, p); err != nil {
+		return "", errors.Wrapf(err, "could not rename %s", part)
+	}
+	log.WithFields(logrus.Fields{
+		"era":    era,
+		"blocks": nBlocks,
+		"file":   p,
+	}).Info("Exported era file")
+	return p, nil
+}
+
+// writeBlocks writes the canonical blocks of the era, found using the block roots of the era state.
+// The genesis block is not written, since it is derived from the genesis state.
+func writeBlocks(ctx context.Context, beaconDB iface.ReadOnlyDatabase, w *Writer, st state.ReadOnlyBeaconState, start, end primitives.Slot) (int, error) {
+	sphr := params.BeaconConfig().SlotsPerHistoricalRoot
+	var prev [32]byte
+	n := 0
+	for s := start; s < end; s++ {
+		if ctx.Err() != nil {
+			return n, ctx.Err()
+		}
+		if s == 0 {
+			continue
+		}
+		r, err := st.BlockRootAtIndex(uint64(s % sphr))
+		if err != nil {
+			return n, err
+		}
+		root := bytesutil.ToBytes32(r)
+		if root == prev {
+			continue
+		}
+		prev = root
+		blk, err := beaconDB.Block(ctx, root)
+		if err != nil {
+			return n, errors.Wrapf(err, "could not read block %#x", root)
+		}
+		if err := blocks.BeaconBlockIsNil(blk); err != nil {
+			return n, errors.Wrapf(ErrMissingBlock, "slot=%d, root=%#x", s, root)
+		}
+		if bs := blk.Block().Slot(); bs != s {
+			// The first root of the era can belong to a block from an earlier era when the first slot is empty.
+			if bs < start || (bs == 0 && n == 0) {
+				continue
+			}
+			return n, errors.Errorf("block %#x has slot %d, expected %d", root, bs, s)
+		}
+		if blk.IsBlinded() {
+			return n, errors.Wrapf(ErrBlindedBlock, "slot=%d, root=%#x", s, root)
+		}
+		if err := w.WriteBlock(blk); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+// stateAtSlot returns the canonical state at the given slot, before any block at that slot is applied.
+func stateAtSlot(ctx context.Context, beaconDB iface.ReadOnlyDatabase, slot primitives.Slot) (state.BeaconState, error) {
+	if slot == 0 {
+		return beaconDB.GenesisState(ctx)
+	}
+	genesisRoot, err := beaconDB.GenesisBlockRoot(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get genesis block root")
+	}
+	st, err := baseState(ctx, beaconDB, slot, genesisRoot)
+	if err != nil {
+		return nil, err
+	}
+	if st.Slot() < slot {
+		blks, err := canonicalBlocks(ctx, beaconDB, st.Slot()+1, slot-1)
+		if err != nil {
+			return nil, err
+		}
+		log.WithFields(logrus.Fields{
+			"from":   st.Slot(),
+			"to":     slot,
+			"blocks": len(blks),
+		}).Info("Replaying blocks to regenerate era state")
+		for _, b := range blks {
+			_, st, err = transition.ExecuteStateTransitionNoVerifyAnySig(ctx, st, b)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not replay block at slot %d", b.Block().Slot())
+			}
+		}
+		if st.Slot() < slot {
+			st, err = transition.ProcessSlots(ctx, st, slot)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not process slots up to %d", slot)
+			}
+		}
+	}
+	if st.Slot() != slot {
+		return nil, errors.Errorf("regenerated state has slot %d, expected %d", st.Slot(), slot)
+	}
+	return st, nil
+}
+
+// baseState finds the state with the highest slot at or below the given slot which does not include a block at
+// that slot, and descends from a finalized block.
+func baseState(ctx context.Context, beaconDB iface.ReadOnlyDatabase, slot primitives.Slot, genesisRoot [32]byte) (state.BeaconState, error) {
+	below := slot + 1
+	for {
+		sts, err := beaconDB.HighestSlotStatesBelow(ctx, below)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not find state below slot %d", below)
+		}
+		if len(sts) == 0 {
+			return nil, errNoBaseState
+		}
+		for _, s := range sts {
+			if h := s.LatestBlockHeader(); h == nil || h.Slot >= slot {
+				continue
+			}
+			st, ok := s.(state.BeaconState)
+			if !ok {
+				return nil, errors.Errorf("unexpected state type %T", s)
+			}
+			root, err := latestBlockRoot(ctx, st)
+			if err != nil {
+				return nil, err
+			}
+			if root == genesisRoot || beaconDB.IsFinalizedBlock(ctx, root) {
+				return st, nil
+			}
+		}
+		if sts[0].Slot() == 0 {
+			return nil, errNoBaseState
+		}
+		below = sts[0].Slot()
+	}
+}
+
+// canonicalBlocks returns the finalized blocks in the inclusive slot range, in slot order.
+func canonicalBlocks(ctx context.Context, beaconDB iface.ReadOnlyDatabase, start, end primitives.Slot) ([]interfaces.ReadOnlySignedBeaconBlock, error) {
+	if end < start {
+		return nil, nil
+	}
+	blks, roots, err := beaconDB.Blocks(ctx, filters.NewFilter().SetStartSlot(start).SetEndSlot(end))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read blocks from slot %d to %d", start, end)
+	}
+	canonical := make([]interfaces.ReadOnlySignedBeaconBlock, 0, len(blks))
+	for i := range blks {
+		if beaconDB.IsFinalizedBlock(ctx, roots[i]) {
+			canonical = append(canonical, blks[i])
+		}
+	}
+	sort.Slice(canonical, func(i, j int) bool {
+		return canonical[i].Block().Slot() < canonical[j].Block().Slot()
+	})
+	return canonical, nil
+}
+
+// latestBlockRoot computes the root of the latest block applied to the state. The state root of the latest block
+// header is only filled in at the next slot, so it is computed from the state when missing.
+func latestBlockRoot(ctx context.Context, st state.BeaconState) ([32]byte, error) {
+	h := st.LatestBlockHeader()
+	if h == nil {
+		return [32]byte{}, errors.New("state has no latest block header")
+	}
+	if bytes.Equal(h.StateRoot, params.BeaconConfig().ZeroHash[:]) {
+		sr, err := st.HashTreeRoot(ctx)
+		if err != nil {
+			return [32]byte{}, errors.Wrap(err, "could not compute state root")
+		}
+		h.StateRoot = sr[:]
+	}
+	root, err := h.HashTreeRoot()
+	if err != nil {
+		return [32]byte{}, errors.Wrapf(err, "could not compute root of latest block header at slot %d", h.Slot)
+	}
+	return root, nil
+}
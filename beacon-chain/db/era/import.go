@@ -0,0 +1,195 @@
+package era
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	coreblocks "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/iface"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// importBatchSize is the number of blocks saved to the database in a single transaction.
+const importBatchSize = 256
+
+var (
+	ErrMissingParent   = errors.New("parent of the era is not in the database")
+	ErrGenesisMismatch = errors.New("era genesis state does not match the database")
+)
+
+// Import verifies the blocks of an era file against the block roots of its state, then saves the blocks and the
+// state to the database. Era 0 initializes an empty database from the genesis state. Later eras must be imported
+// in order, as the parent of the first block of an era must already be in the database. When the era extends the
+// chain beyond the finalized checkpoint of the database, the last block of the era becomes the new finalized
+// checkpoint and head, in the same way as the origin block of a checkpoint sync.
+func Import(ctx context.Context, beaconDB iface.HeadAccessDatabase, f *File) error {
+	st, err := f.State()
+	if err != nil {
+		return err
+	}
+	if f.Era == 0 {
+		return importGenesis(ctx, beaconDB, st)
+	}
+	sphr := params.BeaconConfig().SlotsPerHistoricalRoot
+	start, end := Slots(f.Era)
+	if st.Slot() != end {
+		return errors.Wrapf(ErrInvalidEraFile, "state slot %d does not match the end of era %d", st.Slot(), f.Era)
+	}
+	lr, err := st.BlockRootAtIndex(uint64((end - 1) % sphr))
+	if err != nil {
+		return err
+	}
+	lastRoot := bytesutil.ToBytes32(lr)
+	stRoot, err := latestBlockRoot(ctx, st)
+	if err != nil {
+		return err
+	}
+	if stRoot != lastRoot {
+		return errors.Wrapf(ErrInvalidEraFile, "latest block header of the era state does not match block root %#x", lastRoot)
+	}
+
+	var prev [32]byte
+	var lastSlot primitives.Slot
+	batch := make([]interfaces.ReadOnlySignedBeaconBlock, 0, importBatchSize)
+	n := 0
+	for s := start; s < end; s++ {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		blk, err := f.Block(s)
+		if err != nil {
+			return err
+		}
+		if blk == nil {
+			continue
+		}
+		if blk.Block().Slot() != s {
+			return errors.Wrapf(ErrInvalidEraFile, "block indexed at slot %d has slot %d", s, blk.Block().Slot())
+		}
+		root, err := blk.Block().HashTreeRoot()
+		if err != nil {
+			return errors.Wrapf(err, "could not compute root of block at slot %d", s)
+		}
+		want, err := st.BlockRootAtIndex(uint64(s % sphr))
+		if err != nil {
+			return err
+		}
+		if root != bytesutil.ToBytes32(want) {
+			return errors.Wrapf(ErrInvalidEraFile, "root %#x of block at slot %d does not match the era state", root, s)
+		}
+		parent := blk.Block().ParentRoot()
+		if prev == [32]byte{} {
+			if !beaconDB.HasBlock(ctx, parent) {
+				return errors.Wrapf(ErrMissingParent, "parent %#x of block at slot %d", parent, s)
+			}
+		} else if parent != prev {
+			return errors.Wrapf(ErrInvalidEraFile, "block at slot %d does not descend from block %#x", s, prev)
+		}
+		prev, lastSlot = root, s
+		batch = append(batch, blk)
+		if len(batch) == importBatchSize {
+			if err := beaconDB.SaveBlocks(ctx, batch); err != nil {
+				return errors.Wrap(err, "could not save blocks")
+			}
+			n += len(batch)
+			batch = batch[:0]
+		}
+	}
+	if len(batch) > 0 {
+		if err := beaconDB.SaveBlocks(ctx, batch); err != nil {
+			return errors.Wrap(err, "could not save blocks")
+		}
+		n += len(batch)
+	}
+	if prev == [32]byte{} {
+		// Every slot of the era was empty, so the era state builds on the last block of an earlier era.
+		blk, err := beaconDB.Block(ctx, lastRoot)
+		if err != nil || blk == nil || blk.IsNil() {
+			return errors.Wrapf(ErrMissingParent, "block %#x", lastRoot)
+		}
+		lastSlot = blk.Block().Slot()
+	} else if prev != lastRoot {
+		return errors.Wrapf(ErrInvalidEraFile, "last block %#x does not match the era state", prev)
+	}
+
+	if !beaconDB.HasState(ctx, lastRoot) {
+		if err := beaconDB.SaveState(ctx, st, lastRoot); err != nil {
+			return errors.Wrap(err, "could not save era state")
+		}
+		if err := beaconDB.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: st.Slot(), Root: lastRoot[:]}); err != nil {
+			return errors.Wrap(err, "could not save era state summary")
+		}
+	}
+	if err := advanceFinalized(ctx, beaconDB, lastRoot, lastSlot); err != nil {
+		return err
+	}
+	log.WithFields(logrus.Fields{
+		"era":    f.Era,
+		"blocks": n,
+	}).Info("Imported era file")
+	return nil
+}
+
+// advanceFinalized marks the given block as finalized and as the head of the chain, if it is newer than the
+// current finalized checkpoint.
+func advanceFinalized(ctx context.Context, beaconDB iface.HeadAccessDatabase, root [32]byte, slot primitives.Slot) error {
+	cp, err := beaconDB.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get finalized checkpoint")
+	}
+	epoch := slots.ToEpoch(slot)
+	if epoch <= cp.Epoch {
+		return nil
+	}
+	if err := beaconDB.SaveHeadBlockRoot(ctx, root); err != nil {
+		return errors.Wrap(err, "could not save head block root")
+	}
+	chkpt := &ethpb.Checkpoint{Epoch: epoch, Root: root[:]}
+	if err := beaconDB.SaveJustifiedCheckpoint(ctx, chkpt); err != nil {
+		return errors.Wrap(err, "could not save justified checkpoint")
+	}
+	if err := beaconDB.SaveFinalizedCheckpoint(ctx, chkpt); err != nil {
+		return errors.Wrap(err, "could not save finalized checkpoint")
+	}
+	return nil
+}
+
+// importGenesis saves the genesis state and block to an empty database, or checks that they match the genesis
+// of a database that has already been initialized.
+func importGenesis(ctx context.Context, beaconDB iface.HeadAccessDatabase, st state.BeaconState) error {
+	if st.Slot() != 0 {
+		return errors.Wrapf(ErrInvalidEraFile, "era 0 state has slot %d", st.Slot())
+	}
+	gb, err := coreblocks.NewGenesisBlockForState(ctx, st)
+	if err != nil {
+		return errors.Wrap(err, "could not compute genesis block")
+	}
+	root, err := gb.Block().HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not compute genesis block root")
+	}
+	existing, err := beaconDB.GenesisBlockRoot(ctx)
+	if err == nil {
+		if existing != root {
+			return errors.Wrapf(ErrGenesisMismatch, "era genesis root %#x, database genesis root %#x", root, existing)
+		}
+		return nil
+	}
+	if !db.IsNotFound(err) {
+		return errors.Wrap(err, "could not get genesis block root")
+	}
+	if err := beaconDB.SaveGenesisData(ctx, st); err != nil {
+		return errors.Wrap(err, "could not save genesis data")
+	}
+	log.WithField("genesisRoot", fmt.Sprintf("%#x", root)).Info("Imported genesis from era file")
+	return nil
+}
@@ -19,6 +19,12 @@ var ErrNotFoundState = kv.ErrNotFoundState
 // ErrNotFoundOriginBlockRoot wraps ErrNotFound for an error specific to the origin block root.
 var ErrNotFoundOriginBlockRoot = kv.ErrNotFoundOriginBlockRoot
 
+// ErrNotFoundRewards wraps ErrNotFound for an error specific to the rewards of an epoch not being found in the database.
+var ErrNotFoundRewards = kv.ErrNotFoundRewards
+
+// ErrDeleteJustifiedAndFinalized is returned when deleting the state or block of the genesis, justified or finalized root.
+var ErrDeleteJustifiedAndFinalized = kv.ErrDeleteJustifiedAndFinalized
+
 // IsNotFound allows callers to treat errors from a flat-file database, where the file record is missing,
 // as equivalent to db.ErrNotFound.
 func IsNotFound(err error) bool {
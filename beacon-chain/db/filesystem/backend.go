@@ -0,0 +1,116 @@
+package filesystem
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+)
+
+var errUnsupportedBackendURL = errors.New("unsupported blob backend url")
+
+// BlobBackend is a store for encoded BlobSidecars that lives outside of the local BlobStorage directory.
+// BlobStorage writes every blob through to the backend, and reads from it when a blob has already been
+// pruned from local disk. Backends are never pruned, so they can hold blobs beyond the retention window
+// and be shared by several nodes.
+type BlobBackend interface {
+	// Put stores the ssz encoded BlobSidecar for the given root and index.
+	Put(ctx context.Context, root [32]byte, idx uint64, data []byte) error
+	// Get returns the ssz encoded BlobSidecar for the given root and index. An error wrapping os.ErrNotExist
+	// is returned if the blob is not present.
+	Get(ctx context.Context, root [32]byte, idx uint64) ([]byte, error)
+	// Indices returns the set of BlobSidecar indices stored for the given root.
+	Indices(ctx context.Context, root [32]byte) (blobIndexMask, error)
+	// Remove deletes all BlobSidecars stored for the given root.
+	Remove(ctx context.Context, root [32]byte) error
+}
+
+// backendKey is the location of a blob relative to the root of a BlobBackend. Backends do not suffer from the
+// large directory listings that the local layout is designed to avoid, and looking blobs up by root alone means
+// callers do not need to know the epoch of a block that has been evicted from the local cache.
+func backendKey(root [32]byte, idx uint64) string {
+	return path.Join(rootString(root), fmt.Sprintf("%d.%s", idx, sszExt))
+}
+
+// NewBlobBackend creates a BlobBackend from a url. Supported schemes are:
+//
+//	file:///path/to/dir
+//	s3://bucket/optional/prefix?endpoint=https://s3.example.com&region=us-east-1
+//
+// Credentials for the s3 scheme are read from the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY environment variables.
+// If endpoint is not given, the AWS endpoint for the region is used.
+func NewBlobBackend(raw string) (BlobBackend, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse blob backend url")
+	}
+	switch u.Scheme {
+	case "file":
+		if u.Path == "" {
+			return nil, errors.Wrap(errUnsupportedBackendURL, "file url must include a path")
+		}
+		return NewFsBackend(u.Path)
+	case "s3":
+		q := u.Query()
+		cfg := S3Config{
+			Endpoint:  q.Get("endpoint"),
+			Region:    q.Get("region"),
+			Bucket:    u.Host,
+			Prefix:    strings.Trim(u.Path, "/"),
+			AccessKey: os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		}
+		return NewS3Backend(cfg)
+	default:
+		return nil, errors.Wrapf(errUnsupportedBackendURL, "scheme=%s", u.Scheme)
+	}
+}
+
+// FsBackend is a BlobBackend that stores blobs in a local or network mounted directory.
+type FsBackend struct {
+	fs afero.Fs
+}
+
+var _ BlobBackend = &FsBackend{}
+
+// NewFsBackend creates a BlobBackend rooted at the given directory, creating the directory if needed.
+func NewFsBackend(base string) (*FsBackend, error) {
+	base = path.Clean(base)
+	if err := os.MkdirAll(base, directoryPermissions); err != nil {
+		return nil, errors.Wrapf(err, "failed to create blob backend directory at %s", base)
+	}
+	return &FsBackend{fs: afero.NewBasePathFs(afero.NewOsFs(), base)}, nil
+}
+
+// Put writes the blob to a temporary file and renames it into place, so that readers never see a partial blob.
+func (b *FsBackend) Put(_ context.Context, root [32]byte, idx uint64, data []byte) error {
+	key := backendKey(root, idx)
+	if err := b.fs.MkdirAll(path.Dir(key), directoryPermissions); err != nil {
+		return err
+	}
+	part := key + "." + partExt
+	if err := afero.WriteFile(b.fs, part, data, 0600); err != nil {
+		return errors.Wrapf(err, "failed to write %s", part)
+	}
+	return b.fs.Rename(part, key)
+}
+
+// Get reads the blob for the given root and index.
+func (b *FsBackend) Get(_ context.Context, root [32]byte, idx uint64) ([]byte, error) {
+	return afero.ReadFile(b.fs, backendKey(root, idx))
+}
+
+// Indices lists the blobs stored for the given root.
+func (b *FsBackend) Indices(_ context.Context, root [32]byte) (blobIndexMask, error) {
+	return indicesInDir(b.fs, rootString(root))
+}
+
+// Remove deletes the directory holding the blobs for the given root.
+func (b *FsBackend) Remove(_ context.Context, root [32]byte) error {
+	return b.fs.RemoveAll(rootString(root))
+}
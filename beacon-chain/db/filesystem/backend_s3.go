@@ -0,0 +1,314 @@
+package filesystem
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+)
+
+const (
+	s3Service         = "s3"
+	s3DefaultRegion   = "us-east-1"
+	s3RequestTimeout  = 30 * time.Second
+	s3SigningAlgo     = "AWS4-HMAC-SHA256"
+	s3TimeFormat      = "20060102T150405Z"
+	s3DateFormat      = "20060102"
+	s3EmptyBodySHA256 = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+var (
+	errS3NoBucket = errors.New("s3 blob backend requires a bucket")
+	errS3Request  = errors.New("unexpected response from s3 blob backend")
+)
+
+// S3Config holds the settings needed to reach an S3-compatible object store.
+type S3Config struct {
+	// Endpoint is the base url of the object store, eg http://localhost:9000. Buckets are addressed by path
+	// when an endpoint is given. If empty, the AWS endpoint for Region is used with virtual-hosted addressing.
+	Endpoint  string
+	Region    string
+	Bucket    string
+	Prefix    string
+	AccessKey string
+	SecretKey string
+}
+
+// S3Backend is a BlobBackend that stores blobs in an S3-compatible object store. Requests are signed
+// with AWS Signature Version 4, which is supported by AWS as well as self-hosted stores such as MinIO.
+type S3Backend struct {
+	cfg    S3Config
+	base   *url.URL
+	client *http.Client
+	now    func() time.Time
+}
+
+var _ BlobBackend = &S3Backend{}
+
+// NewS3Backend creates a BlobBackend for the given bucket.
+func NewS3Backend(cfg S3Config) (*S3Backend, error) {
+	if cfg.Bucket == "" {
+		return nil, errS3NoBucket
+	}
+	if cfg.Region == "" {
+		cfg.Region = s3DefaultRegion
+	}
+	cfg.Prefix = strings.Trim(cfg.Prefix, "/")
+	var base *url.URL
+	var err error
+	if cfg.Endpoint == "" {
+		base, err = url.Parse(fmt.Sprintf("https://%s.s3.%s.amazonaws.com/", cfg.Bucket, cfg.Region))
+	} else {
+		base, err = url.Parse(strings.TrimSuffix(cfg.Endpoint, "/") + "/" + cfg.Bucket + "/")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid s3 endpoint")
+	}
+	return &S3Backend{
+		cfg:    cfg,
+		base:   base,
+		client: &http.Client{Timeout: s3RequestTimeout},
+		now:    time.Now,
+	}, nil
+}
+
+func (b *S3Backend) key(k string) string {
+	if b.cfg.Prefix == "" {
+		return k
+	}
+	return path.Join(b.cfg.Prefix, k)
+}
+
+// Put uploads the blob with a single PUT request.
+func (b *S3Backend) Put(ctx context.Context, root [32]byte, idx uint64, data []byte) error {
+	resp, err := b.do(ctx, http.MethodPut, b.key(backendKey(root, idx)), nil, data)
+	if err != nil {
+		return err
+	}
+	return closeResponse(resp, http.StatusOK)
+}
+
+// Get downloads the blob, returning an error wrapping os.ErrNotExist if the object does not exist.
+func (b *S3Backend) Get(ctx context.Context, root [32]byte, idx uint64) ([]byte, error) {
+	key := b.key(backendKey(root, idx))
+	resp, err := b.do(ctx, http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.WithError(err).Debug("Could not close s3 response body")
+		}
+	}()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errors.Wrapf(os.ErrNotExist, "s3 object %s", key)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Wrapf(errS3Request, "GET %s: status %d", key, resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, fieldparams.BlobSidecarSize*2))
+}
+
+// Indices lists the objects stored under the prefix for the given root.
+func (b *S3Backend) Indices(ctx context.Context, root [32]byte) (blobIndexMask, error) {
+	var mask blobIndexMask
+	keys, err := b.list(ctx, b.key(rootString(root))+"/")
+	if err != nil {
+		return mask, err
+	}
+	for _, k := range keys {
+		idx, err := idxFromPath(k)
+		if err != nil {
+			continue
+		}
+		if idx >= fieldparams.MaxBlobsPerBlock {
+			return mask, errIndexOutOfBounds
+		}
+		mask[idx] = true
+	}
+	return mask, nil
+}
+
+// Remove deletes every object stored for the given root.
+func (b *S3Backend) Remove(ctx context.Context, root [32]byte) error {
+	keys, err := b.list(ctx, b.key(rootString(root))+"/")
+	if err != nil {
+		return err
+	}
+	for _, k := range keys {
+		resp, err := b.do(ctx, http.MethodDelete, k, nil, nil)
+		if err != nil {
+			return err
+		}
+		if err := closeResponse(resp, http.StatusNoContent, http.StatusOK); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type s3ListResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// list returns the keys of all objects with the given prefix using ListObjectsV2.
+func (b *S3Backend) list(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	token := ""
+	for {
+		q := url.Values{}
+		q.Set("list-type", "2")
+		q.Set("prefix", prefix)
+		if token != "" {
+			q.Set("continuation-token", token)
+		}
+		resp, err := b.do(ctx, http.MethodGet, "", q, nil)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, closeResponse(resp, http.StatusOK)
+		}
+		res := &s3ListResult{}
+		err = xml.NewDecoder(resp.Body).Decode(res)
+		if cerr := resp.Body.Close(); cerr != nil {
+			log.WithError(cerr).Debug("Could not close s3 response body")
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "could not decode s3 object listing")
+		}
+		for _, c := range res.Contents {
+			keys = append(keys, c.Key)
+		}
+		if !res.IsTruncated || res.NextContinuationToken == "" {
+			return keys, nil
+		}
+		token = res.NextContinuationToken
+	}
+}
+
+func (b *S3Backend) do(ctx context.Context, method, key string, query url.Values, body []byte) (*http.Response, error) {
+	u := *b.base
+	u.Path = path.Join(u.Path, key)
+	if key == "" {
+		// Bucket level requests such as listings keep the trailing slash.
+		u.Path = b.base.Path
+	}
+	u.RawQuery = canonicalQuery(query)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = int64(len(body))
+	signV4(req, body, b.cfg.AccessKey, b.cfg.SecretKey, b.cfg.Region, b.now())
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s %s", method, key)
+	}
+	return resp, nil
+}
+
+func closeResponse(resp *http.Response, expected ...int) error {
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.WithError(err).Debug("Could not close s3 response body")
+		}
+	}()
+	for _, s := range expected {
+		if resp.StatusCode == s {
+			return nil
+		}
+	}
+	msg, err := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		msg = nil
+	}
+	return errors.Wrapf(errS3Request, "%s %s: status %d %s", resp.Request.Method, resp.Request.URL.Path, resp.StatusCode, string(msg))
+}
+
+// signV4 adds AWS Signature Version 4 authentication headers to the request. Every header already present on the
+// request is included in the signature, along with host, x-amz-date and x-amz-content-sha256.
+func signV4(req *http.Request, body []byte, accessKey, secretKey, region string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format(s3TimeFormat)
+	date := now.Format(s3DateFormat)
+	payloadHash := s3EmptyBodySHA256
+	if len(body) > 0 {
+		h := sha256.Sum256(body)
+		payloadHash = hex.EncodeToString(h[:])
+	}
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for k, v := range req.Header {
+		headers[strings.ToLower(k)] = strings.TrimSpace(strings.Join(v, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, k := range names {
+		canonicalHeaders.WriteString(k + ":" + headers[k] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	uri := req.URL.EscapedPath()
+	if uri == "" {
+		uri = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		uri,
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := strings.Join([]string{date, region, s3Service, "aws4_request"}, "/")
+	crHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{s3SigningAlgo, amzDate, scope, hex.EncodeToString(crHash[:])}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, s3Service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3SigningAlgo, accessKey, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// canonicalQuery encodes query parameters sorted by key, with spaces encoded as %20 as required by SigV4.
+func canonicalQuery(q url.Values) string {
+	if len(q) == 0 {
+		return ""
+	}
+	return strings.ReplaceAll(q.Encode(), "+", "%20")
+}
@@ -0,0 +1,59 @@
+package filesystem
+
+import (
+	"context"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestFsBackend(t *testing.T) {
+	ctx := context.Background()
+	b, err := NewFsBackend(path.Join(t.TempDir(), "archive"))
+	require.NoError(t, err)
+
+	root := [32]byte{0xca, 0xfe}
+	_, err = b.Get(ctx, root, 2)
+	require.ErrorIs(t, err, os.ErrNotExist)
+	require.NoError(t, b.Put(ctx, root, 2, []byte("two")))
+	got, err := b.Get(ctx, root, 2)
+	require.NoError(t, err)
+	require.Equal(t, "two", string(got))
+	mask, err := b.Indices(ctx, root)
+	require.NoError(t, err)
+	require.Equal(t, blobIndexMask{false, false, true}, mask)
+	require.NoError(t, b.Remove(ctx, root))
+	mask, err = b.Indices(ctx, root)
+	require.NoError(t, err)
+	require.Equal(t, blobIndexMask{}, mask)
+}
+
+func TestNewBlobBackend(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "access")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	b, err := NewBlobBackend("s3://bucket/some/prefix/?endpoint=http://localhost:9000&region=eu-west-1")
+	require.NoError(t, err)
+	s3, ok := b.(*S3Backend)
+	require.Equal(t, true, ok)
+	require.DeepEqual(t, S3Config{
+		Endpoint:  "http://localhost:9000",
+		Region:    "eu-west-1",
+		Bucket:    "bucket",
+		Prefix:    "some/prefix",
+		AccessKey: "access",
+		SecretKey: "secret",
+	}, s3.cfg)
+	require.Equal(t, "http://localhost:9000/bucket/", s3.base.String())
+
+	b, err = NewBlobBackend("file://" + t.TempDir())
+	require.NoError(t, err)
+	_, ok = b.(*FsBackend)
+	require.Equal(t, true, ok)
+
+	_, err = NewBlobBackend("ftp://example.com")
+	require.ErrorIs(t, err, errUnsupportedBackendURL)
+	_, err = NewBlobBackend("file://")
+	require.ErrorIs(t, err, errUnsupportedBackendURL)
+}
@@ -6,8 +6,6 @@ import (
 	"math"
 	"os"
 	"path"
-	"strconv"
-	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -20,6 +18,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/io/file"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/runtime/logging"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/afero"
 )
@@ -66,6 +65,25 @@ func WithSaveFsync(fsync bool) BlobStorageOption {
 	}
 }
 
+// WithBlobArchiveMode is an option that disables blob pruning, so that blobs are kept indefinitely. Nodes in archive
+// mode backfill historical blobs and serve blobs for any slot since the Deneb fork.
+func WithBlobArchiveMode(archive bool) BlobStorageOption {
+	return func(b *BlobStorage) error {
+		b.archive = archive
+		return nil
+	}
+}
+
+// WithRemoteBackend is an option that writes every blob through to the given BlobBackend, and reads blobs that are
+// no longer on local disk from it. The local filesystem then acts as a cache for the retention window, and blobs are
+// only pruned from it once they have been uploaded.
+func WithRemoteBackend(r BlobBackend) BlobStorageOption {
+	return func(b *BlobStorage) error {
+		b.remote = r
+		return nil
+	}
+}
+
 // NewBlobStorage creates a new instance of the BlobStorage object. Note that the implementation of BlobStorage may
 // attempt to hold a file lock to guarantee exclusive control of the blob storage directory, so this should only be
 // initialized once per beacon node.
@@ -84,11 +102,19 @@ func NewBlobStorage(opts ...BlobStorageOption) (*BlobStorage, error) {
 		return nil, errors.Wrapf(err, "failed to create blob storage at %s", b.base)
 	}
 	b.fs = afero.NewBasePathFs(afero.NewOsFs(), b.base)
-	pruner, err := newBlobPruner(b.fs, b.retentionEpochs)
+	var popts []prunerOpt
+	if b.archive {
+		popts = append(popts, withPruningDisabled())
+	}
+	pruner, err := newBlobPruner(b.fs, b.retentionEpochs, popts...)
 	if err != nil {
 		return nil, err
 	}
 	b.pruner = pruner
+	b.cache = pruner.cache
+	if b.remote != nil {
+		b.uploader = newRemoteUploader(b.fs, b.remote)
+	}
 	return b, nil
 }
 
@@ -97,12 +123,25 @@ type BlobStorage struct {
 	base            string
 	retentionEpochs primitives.Epoch
 	fsync           bool
+	archive         bool
 	fs              afero.Fs
 	pruner          *blobPruner
+	cache           *blobStorageCache
+	remote          BlobBackend
+	uploader        *remoteUploader
+}
+
+// Close waits for the blobs queued for the remote backend to be uploaded, for a bounded time, and stops uploading.
+func (bs *BlobStorage) Close() {
+	if bs == nil || bs.uploader == nil {
+		return
+	}
+	bs.uploader.close()
 }
 
-// WarmCache runs the prune routine with an expiration of slot of 0, so nothing will be pruned, but the pruner's cache
-// will be populated at node startup, avoiding a costly cold prune (~4s in syscalls) during syncing.
+// WarmCache migrates any blobs still stored in the flat layout used by earlier versions and populates the pruner's
+// cache with the root->epoch mapping of every blob on disk. Warm-up only lists the epoch directories; the indices
+// of each root are read in the background once the cache is ready.
 func (bs *BlobStorage) WarmCache() {
 	if bs.pruner == nil {
 		return
@@ -116,6 +155,29 @@ func (bs *BlobStorage) WarmCache() {
 	}()
 }
 
+// StoredRoots returns the block root of every blob directory in local storage, including directories still in
+// the flat layout used by earlier versions.
+func (bs *BlobStorage) StoredRoots() ([][32]byte, error) {
+	var roots [][32]byte
+	if _, err := walkEpochRoots(bs.fs, func(root [32]byte, _ primitives.Epoch) {
+		roots = append(roots, root)
+	}); err != nil {
+		return nil, err
+	}
+	entries, err := listDir(bs.fs, ".")
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list root blobs directory")
+	}
+	for _, dir := range filter(entries, filterRoot) {
+		root, err := rootFromDir(dir)
+		if err != nil {
+			continue
+		}
+		roots = append(roots, root)
+	}
+	return roots, nil
+}
+
 // ErrBlobStorageSummarizerUnavailable is a sentinel error returned when there is no pruner/cache available.
 // This should be used by code that optionally uses the summarizer to optimize rpc requests. Being able to
 // fallback when there is no summarizer allows client code to avoid test complexity where the summarizer doesn't matter.
@@ -131,6 +193,15 @@ func (bs *BlobStorage) WaitForSummarizer(ctx context.Context) (BlobStorageSummar
 	return bs.pruner.waitForCache(ctx)
 }
 
+// UsageByEpoch returns the number and approximate size of the blobs stored under each epoch, in epoch order.
+// The usage is kept up to date by the cache as blobs are saved and pruned, so it does not read the filesystem.
+func (bs *BlobStorage) UsageByEpoch() ([]BlobEpochUsage, error) {
+	if bs == nil || bs.cache == nil {
+		return nil, ErrBlobStorageSummarizerUnavailable
+	}
+	return bs.cache.usageByEpoch(), nil
+}
+
 // Save saves blobs given a list of sidecars.
 func (bs *BlobStorage) Save(sidecar blocks.VerifiedROBlob) error {
 	startTime := time.Now()
@@ -148,6 +219,10 @@ func (bs *BlobStorage) Save(sidecar blocks.VerifiedROBlob) error {
 		if err := bs.pruner.notify(sidecar.BlockRoot(), sidecar.Slot(), sidecar.Index); err != nil {
 			return errors.Wrapf(err, "problem maintaining pruning cache/metrics for sidecar with root=%#x", sidecar.BlockRoot())
 		}
+	} else if bs.cache != nil {
+		if err := bs.cache.ensure(sidecar.BlockRoot(), fname.epoch, sidecar.Index); err != nil {
+			return errors.Wrapf(err, "problem maintaining cache for sidecar with root=%#x", sidecar.BlockRoot())
+		}
 	}
 
 	// Serialize the ethpb.BlobSidecar to binary data using SSZ.
@@ -158,6 +233,13 @@ func (bs *BlobStorage) Save(sidecar blocks.VerifiedROBlob) error {
 		return errSidecarEmptySSZData
 	}
 
+	if bs.uploader != nil {
+		// The journal entry is written first, so that a blob is never on local disk without being scheduled for
+		// upload, even if the node stops right after saving it.
+		if err := bs.uploader.journal(remoteUpload{root: sidecar.BlockRoot(), epoch: fname.epoch, idx: sidecar.Index}); err != nil {
+			return errors.Wrap(err, "failed to record blob sidecar upload to remote backend")
+		}
+	}
 	if err := bs.fs.MkdirAll(fname.dir(), directoryPermissions); err != nil {
 		return err
 	}
@@ -218,6 +300,13 @@ func (bs *BlobStorage) Save(sidecar blocks.VerifiedROBlob) error {
 	partialMoved = true
 	blobsWrittenCounter.Inc()
 	blobSaveLatency.Observe(float64(time.Since(startTime).Milliseconds()))
+	if bs.uploader != nil {
+		// The blob is written through to the remote backend in the background. If the queue is full, the upload
+		// stays in the journal and is picked up by a later scan, while the pruner keeps the local copy.
+		if !bs.uploader.enqueue(remoteUpload{root: sidecar.BlockRoot(), epoch: fname.epoch, idx: sidecar.Index}) {
+			log.WithFields(logging.BlobFields(sidecar.ROBlob)).Debug("Blob sidecar upload queue is full, deferring upload to remote backend")
+		}
+	}
 	return nil
 }
 
@@ -226,8 +315,17 @@ func (bs *BlobStorage) Save(sidecar blocks.VerifiedROBlob) error {
 // value is always a VerifiedROBlob.
 func (bs *BlobStorage) Get(root [32]byte, idx uint64) (blocks.VerifiedROBlob, error) {
 	startTime := time.Now()
-	expected := blobNamer{root: root, index: idx}
+	expected := bs.namerForRoot(root, idx)
 	encoded, err := afero.ReadFile(bs.fs, expected.path())
+	if err != nil && os.IsNotExist(err) && expected.flat {
+		// The blob may have been moved to the by-epoch layout by the migration since the root was looked up.
+		if n := bs.namerForRoot(root, idx); !n.flat {
+			encoded, err = afero.ReadFile(bs.fs, n.path())
+		}
+	}
+	if err != nil && os.IsNotExist(err) && bs.remote != nil {
+		encoded, err = bs.getRemote(root, idx)
+	}
 	var v blocks.VerifiedROBlob
 	if err != nil {
 		return v, err
@@ -246,47 +344,62 @@ func (bs *BlobStorage) Get(root [32]byte, idx uint64) (blocks.VerifiedROBlob, er
 	return verification.BlobSidecarNoop(ro)
 }
 
-// Remove removes all blobs for a given root.
+func (bs *BlobStorage) getRemote(root [32]byte, idx uint64) ([]byte, error) {
+	encoded, err := bs.remote.Get(context.Background(), root, idx)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			blobRemoteErrorCounter.Inc()
+		}
+		return nil, err
+	}
+	blobRemoteFetchCounter.Inc()
+	return encoded, nil
+}
+
+// Remove removes all blobs for a given root from local storage. Blobs already written to the remote backend are
+// left there, since the backend is an archive which may be shared with other nodes.
 func (bs *BlobStorage) Remove(root [32]byte) error {
-	rootDir := blobNamer{root: root}.dir()
-	return bs.fs.RemoveAll(rootDir)
+	namer := bs.namerForRoot(root, 0)
+	if err := bs.fs.RemoveAll(namer.dir()); err != nil {
+		return err
+	}
+	if bs.cache != nil {
+		bs.cache.evict(root)
+	}
+	if bs.uploader != nil && !namer.flat {
+		if err := bs.uploader.forget(root, namer.epoch); err != nil {
+			return errors.Wrap(err, "could not remove pending remote uploads")
+		}
+	}
+	return nil
 }
 
 // Indices generates a bitmap representing which BlobSidecar.Index values are present on disk for a given root.
 // This value can be compared to the commitments observed in a block to determine which indices need to be found
 // on the network to confirm data availability.
 func (bs *BlobStorage) Indices(root [32]byte) ([fieldparams.MaxBlobsPerBlock]bool, error) {
-	var mask [fieldparams.MaxBlobsPerBlock]bool
-	rootDir := blobNamer{root: root}.dir()
-	entries, err := afero.ReadDir(bs.fs, rootDir)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return mask, nil
-		}
+	return indicesInDir(bs.fs, bs.namerForRoot(root, 0).dir())
+}
+
+// IndicesWithRemote is like Indices, but when no blobs for the root are found on local disk it asks the remote
+// backend, if one is configured. Since this may make a network request, it should only be used to serve requests
+// for historical blobs, not on the block processing path.
+func (bs *BlobStorage) IndicesWithRemote(root [32]byte) ([fieldparams.MaxBlobsPerBlock]bool, error) {
+	mask, err := bs.Indices(root)
+	if err != nil || bs.remote == nil {
 		return mask, err
 	}
-	for i := range entries {
-		if entries[i].IsDir() {
-			continue
-		}
-		name := entries[i].Name()
-		if !strings.HasSuffix(name, sszExt) {
-			continue
-		}
-		parts := strings.Split(name, ".")
-		if len(parts) != 2 {
-			continue
-		}
-		u, err := strconv.ParseUint(parts[0], 10, 64)
-		if err != nil {
-			return mask, errors.Wrapf(err, "unexpected directory entry breaks listing, %s", parts[0])
-		}
-		if u >= fieldparams.MaxBlobsPerBlock {
-			return mask, errIndexOutOfBounds
+	for i := range mask {
+		if mask[i] {
+			return mask, nil
 		}
-		mask[u] = true
 	}
-	return mask, nil
+	rm, err := bs.remote.Indices(context.Background(), root)
+	if err != nil {
+		blobRemoteErrorCounter.Inc()
+		return mask, errors.Wrap(err, "could not list blobs in remote backend")
+	}
+	return rm, nil
 }
 
 // Clear deletes all files on the filesystem.
@@ -300,6 +413,9 @@ func (bs *BlobStorage) Clear() error {
 			return err
 		}
 	}
+	if bs.cache != nil {
+		bs.cache.clear()
+	}
 	return nil
 }
 
@@ -312,25 +428,46 @@ func (bs *BlobStorage) WithinRetentionPeriod(requested, current primitives.Epoch
 	return requested+bs.retentionEpochs >= current
 }
 
-type blobNamer struct {
-	root  [32]byte
-	index uint64
+// ServesEpoch checks if blobs for the requested epoch can be served. This is true for any epoch in archive mode or
+// when a remote backend is configured, and otherwise only within the retention period.
+func (bs *BlobStorage) ServesEpoch(requested, current primitives.Epoch) bool {
+	return bs.archive || bs.remote != nil || bs.WithinRetentionPeriod(requested, current)
 }
 
-func namerForSidecar(sc blocks.VerifiedROBlob) blobNamer {
-	return blobNamer{root: sc.BlockRoot(), index: sc.Index}
+// ArchiveMode returns true if the BlobStorage was configured to keep blobs indefinitely.
+func (bs *BlobStorage) ArchiveMode() bool {
+	return bs != nil && bs.archive
 }
 
-func (p blobNamer) dir() string {
-	return rootString(p.root)
-}
-
-func (p blobNamer) partPath(entropy string) string {
-	return path.Join(p.dir(), fmt.Sprintf("%s-%d.%s", entropy, p.index, partExt))
+// namerForRoot returns the blobNamer for the given root. The cache knows which epoch a root belongs to once it is
+// warmed up, and a miss from then on means the root is not in the by-epoch layout. Before that, both layouts are
+// probed on disk, so that blobs are readable while WarmCache and the migration of the flat layout are running.
+func (bs *BlobStorage) namerForRoot(root [32]byte, idx uint64) blobNamer {
+	if bs.cache != nil {
+		if e, ok := bs.cache.epoch(root); ok {
+			return blobNamer{root: root, epoch: e, index: idx}
+		}
+	}
+	flat := blobNamer{root: root, index: idx, flat: true}
+	if bs.pruner == nil || bs.pruner.cacheWarmed() {
+		return flat
+	}
+	if exists, err := afero.DirExists(bs.fs, flat.dir()); err == nil && exists {
+		return flat
+	}
+	e, ok, err := findRootEpoch(bs.fs, root)
+	if err != nil {
+		log.WithError(err).WithField("root", rootString(root)).Debug("Could not look up blob directory in by-epoch layout")
+		return flat
+	}
+	if ok {
+		return blobNamer{root: root, epoch: e, index: idx}
+	}
+	return flat
 }
 
-func (p blobNamer) path() string {
-	return path.Join(p.dir(), fmt.Sprintf("%d.%s", p.index, sszExt))
+func namerForSidecar(sc blocks.VerifiedROBlob) blobNamer {
+	return blobNamer{root: sc.BlockRoot(), epoch: slots.ToEpoch(sc.Slot()), index: sc.Index}
 }
 
 func rootString(root [32]byte) string {
@@ -5,17 +5,20 @@ import (
 	"math"
 	"os"
 	"path"
+	"strconv"
 	"sync"
 	"testing"
 
 	ssz "github.com/prysmaticlabs/fastssz"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
 	"github.com/spf13/afero"
 )
 
@@ -148,8 +151,9 @@ func TestBlobIndicesBounds(t *testing.T) {
 	require.ErrorIs(t, err, errIndexOutOfBounds)
 }
 
+// writeFakeSSZ writes a fake blob file in the flat layout, which is read via the fallback used for roots missing from the cache.
 func writeFakeSSZ(t *testing.T, fs afero.Fs, root [32]byte, idx uint64) {
-	namer := blobNamer{root: root, index: idx}
+	namer := blobNamer{root: root, index: idx, flat: true}
 	require.NoError(t, fs.MkdirAll(namer.dir(), 0700))
 	fh, err := fs.Create(namer.path())
 	require.NoError(t, err)
@@ -172,10 +176,7 @@ func TestBlobStoragePrune(t *testing.T) {
 		}
 
 		require.NoError(t, bs.pruner.prune(currentSlot-bs.pruner.windowSize))
-
-		remainingFolders, err := afero.ReadDir(fs, ".")
-		require.NoError(t, err)
-		require.Equal(t, 0, len(remainingFolders))
+		require.Equal(t, 0, len(listRootDirs(t, fs)))
 	})
 	t.Run("Prune dangling blob", func(t *testing.T) {
 		_, sidecars := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, 299, fieldparams.MaxBlobsPerBlock)
@@ -187,10 +188,7 @@ func TestBlobStoragePrune(t *testing.T) {
 		}
 
 		require.NoError(t, bs.pruner.prune(currentSlot-bs.pruner.windowSize))
-
-		remainingFolders, err := afero.ReadDir(fs, ".")
-		require.NoError(t, err)
-		require.Equal(t, 0, len(remainingFolders))
+		require.Equal(t, 0, len(listRootDirs(t, fs)))
 	})
 	t.Run("PruneMany", func(t *testing.T) {
 		blockQty := 10
@@ -207,11 +205,108 @@ func TestBlobStoragePrune(t *testing.T) {
 		}
 
 		require.NoError(t, bs.pruner.prune(currentSlot-bs.pruner.windowSize))
+		require.Equal(t, 4, len(listRootDirs(t, fs)))
+	})
+}
 
-		remainingFolders, err := afero.ReadDir(fs, ".")
+// listRootDirs returns the paths of all root directories in the by-epoch layout.
+func listRootDirs(t *testing.T, fs afero.Fs) []string {
+	var dirs []string
+	periods, err := listNumberedDirs(fs, byEpochLayoutDir)
+	require.NoError(t, err)
+	for _, period := range periods {
+		epochs, err := listNumberedDirs(fs, periodDir(period))
 		require.NoError(t, err)
-		require.Equal(t, 4, len(remainingFolders))
-	})
+		for _, epoch := range epochs {
+			roots, err := listDir(fs, epochDir(epoch))
+			require.NoError(t, err)
+			for _, r := range filter(roots, filterRoot) {
+				dirs = append(dirs, path.Join(epochDir(epoch), r))
+			}
+		}
+	}
+	return dirs
+}
+
+func TestBlobStorage_Layout(t *testing.T) {
+	fs, bs := NewEphemeralBlobStorageWithFs(t)
+	slot := primitives.Slot(periodEpochs) * params.BeaconConfig().SlotsPerEpoch
+	_, sidecars := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, slot+1, 2)
+	scs, err := verification.BlobSidecarSliceNoop(sidecars)
+	require.NoError(t, err)
+	require.NoError(t, bs.Save(scs[0]))
+	require.NoError(t, bs.Save(scs[1]))
+
+	root := scs[0].BlockRoot()
+	expected := path.Join(byEpochLayoutDir, "1", strconv.FormatUint(uint64(periodEpochs), 10), rootString(root))
+	require.DeepEqual(t, []string{expected}, listRootDirs(t, fs))
+	files, err := listDir(fs, expected)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(files))
+
+	// A restarted node only learns which epoch the root belongs to by warming up the cache.
+	restarted, err := newBlobPruner(fs, params.BeaconConfig().MinEpochsForBlobsSidecarsRequest, withWarmedCache())
+	require.NoError(t, err)
+	rbs := &BlobStorage{fs: fs, pruner: restarted, cache: restarted.cache}
+	e, ok := rbs.cache.epoch(root)
+	require.Equal(t, true, ok)
+	require.Equal(t, slots.ToEpoch(slot), e)
+	sum := rbs.cache.Summary(root)
+	require.Equal(t, true, sum.AllAvailable(2))
+	got, err := rbs.Get(root, 1)
+	require.NoError(t, err)
+	require.DeepSSZEqual(t, scs[1], got)
+
+	require.NoError(t, rbs.Remove(root))
+	_, ok = rbs.cache.epoch(root)
+	require.Equal(t, false, ok)
+	require.Equal(t, 0, len(listRootDirs(t, fs)))
+}
+
+func TestBlobStorage_ColdCache(t *testing.T) {
+	fs, bs := NewEphemeralBlobStorageWithFs(t)
+	_, sidecars := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, 100, 2)
+	scs, err := verification.BlobSidecarSliceNoop(sidecars)
+	require.NoError(t, err)
+	require.NoError(t, bs.Save(scs[0]))
+	require.NoError(t, bs.Save(scs[1]))
+	root := scs[0].BlockRoot()
+
+	// A restarted node which has not warmed up its cache yet still finds the blobs stored by epoch.
+	cold, err := newBlobPruner(fs, params.BeaconConfig().MinEpochsForBlobsSidecarsRequest)
+	require.NoError(t, err)
+	cbs := &BlobStorage{fs: fs, pruner: cold, cache: cold.cache}
+	_, ok := cbs.cache.epoch(root)
+	require.Equal(t, false, ok)
+	got, err := cbs.Get(root, 1)
+	require.NoError(t, err)
+	require.DeepSSZEqual(t, scs[1], got)
+	mask, err := cbs.Indices(root)
+	require.NoError(t, err)
+	require.Equal(t, true, mask[0] && mask[1])
+
+	// Blobs still in the flat layout are found as well.
+	flat := blobNamer{root: [32]byte{'f'}, index: 0, flat: true}
+	require.NoError(t, fs.MkdirAll(flat.dir(), directoryPermissions))
+	require.NoError(t, afero.WriteFile(fs, flat.path(), []byte{1}, 0600))
+	mask, err = cbs.Indices(flat.root)
+	require.NoError(t, err)
+	require.Equal(t, true, mask[0])
+}
+
+func TestBlobStorage_StoredRoots(t *testing.T) {
+	fs, bs := NewEphemeralBlobStorageWithFs(t)
+	_, sidecars := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, 1, 1)
+	scs, err := verification.BlobSidecarSliceNoop(sidecars)
+	require.NoError(t, err)
+	require.NoError(t, bs.Save(scs[0]))
+	// A root directory left in the flat layout is listed as well.
+	flat := [32]byte{'f'}
+	require.NoError(t, fs.MkdirAll(rootString(flat), directoryPermissions))
+
+	roots, err := bs.StoredRoots()
+	require.NoError(t, err)
+	require.DeepEqual(t, [][32]byte{scs[0].BlockRoot(), flat}, roots)
 }
 
 func BenchmarkPruning(b *testing.B) {
@@ -292,3 +387,30 @@ func TestConfig_WithinRetentionPeriod(t *testing.T) {
 		require.Equal(t, true, storage.WithinRetentionPeriod(1, 1))
 	})
 }
+
+func TestBlobStorage_ArchiveMode(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	pruner, err := newBlobPruner(fs, params.BeaconConfig().MinEpochsForBlobsSidecarsRequest, withWarmedCache(), withPruningDisabled())
+	require.NoError(t, err)
+	bs := &BlobStorage{fs: fs, pruner: pruner, cache: pruner.cache, archive: true, retentionEpochs: params.BeaconConfig().MinEpochsForBlobsSidecarsRequest}
+	require.Equal(t, true, bs.ArchiveMode())
+
+	_, sidecars := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, 1, 1)
+	old, err := verification.BlobSidecarNoop(sidecars[0])
+	require.NoError(t, err)
+	require.NoError(t, bs.Save(old))
+	_, sidecars = util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, 1_000_000, 1)
+	latest, err := verification.BlobSidecarNoop(sidecars[0])
+	require.NoError(t, err)
+	require.NoError(t, bs.Save(latest))
+
+	// Saving a blob far past the retention window does not prune anything.
+	require.Equal(t, primitives.Slot(0), primitives.Slot(bs.pruner.prunedBefore.Load()))
+	_, err = bs.Get(old.BlockRoot(), 0)
+	require.NoError(t, err)
+	require.Equal(t, false, bs.WithinRetentionPeriod(0, 1_000_000))
+	require.Equal(t, true, bs.ServesEpoch(0, 1_000_000))
+
+	var nilStorage *BlobStorage
+	require.Equal(t, false, nilStorage.ArchiveMode())
+}
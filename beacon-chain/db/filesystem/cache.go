@@ -1,7 +1,9 @@
 package filesystem
 
 import (
+	"sort"
 	"sync"
+	"time"
 
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
@@ -13,8 +15,8 @@ type blobIndexMask [fieldparams.MaxBlobsPerBlock]bool
 
 // BlobStorageSummary represents cached information about the BlobSidecars on disk for each root the cache knows about.
 type BlobStorageSummary struct {
-	slot primitives.Slot
-	mask blobIndexMask
+	epoch primitives.Epoch
+	mask  blobIndexMask
 }
 
 // HasIndex returns true if the BlobSidecar at the given index is available in the filesystem.
@@ -45,17 +47,37 @@ type BlobStorageSummarizer interface {
 	Summary(root [32]byte) BlobStorageSummary
 }
 
+// blobIndexer reads the set of blob indices stored on disk for the given root.
+type blobIndexer func(root [32]byte, epoch primitives.Epoch) (blobIndexMask, error)
+
 type blobStorageCache struct {
 	mu     sync.RWMutex
 	nBlobs float64
 	cache  map[[32]byte]BlobStorageSummary
+	// unindexed holds roots found by the cache warm-up whose blob indices have not been read from disk yet.
+	// Warm-up only lists epoch directories, so the indices are filled in lazily by indexer.
+	unindexed map[[32]byte]primitives.Epoch
+	indexer   blobIndexer
+	// epochs holds the number of roots and blobs stored under each epoch.
+	epochs map[primitives.Epoch]*BlobEpochUsage
+}
+
+// BlobEpochUsage is the number of blocks with blobs stored under an epoch, and the number and approximate size of
+// those blobs. Blobs of roots found by the cache warm-up are only counted once their indices have been read.
+type BlobEpochUsage struct {
+	Epoch primitives.Epoch
+	Roots int
+	Blobs int
+	Bytes uint64
 }
 
 var _ BlobStorageSummarizer = &blobStorageCache{}
 
 func newBlobStorageCache() *blobStorageCache {
 	return &blobStorageCache{
-		cache: make(map[[32]byte]BlobStorageSummary, params.BeaconConfig().MinEpochsForBlobsSidecarsRequest*fieldparams.SlotsPerEpoch),
+		cache:     make(map[[32]byte]BlobStorageSummary, params.BeaconConfig().MinEpochsForBlobsSidecarsRequest*fieldparams.SlotsPerEpoch),
+		unindexed: make(map[[32]byte]primitives.Epoch),
+		epochs:    make(map[primitives.Epoch]*BlobEpochUsage),
 	}
 }
 
@@ -63,19 +85,30 @@ func newBlobStorageCache() *blobStorageCache {
 // BlobSidecars based on Index.
 func (s *blobStorageCache) Summary(root [32]byte) BlobStorageSummary {
 	s.mu.RLock()
-	defer s.mu.RUnlock()
-	return s.cache[root]
+	sum := s.cache[root]
+	epoch, pending := s.unindexed[root]
+	s.mu.RUnlock()
+	if pending {
+		return s.index(root, epoch)
+	}
+	return sum
 }
 
-func (s *blobStorageCache) ensure(key [32]byte, slot primitives.Slot, idx uint64) error {
+func (s *blobStorageCache) ensure(key [32]byte, epoch primitives.Epoch, idx uint64) error {
 	if idx >= fieldparams.MaxBlobsPerBlock {
 		return errIndexOutOfBounds
 	}
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	v := s.cache[key]
-	v.slot = slot
+	v, ok := s.cache[key]
+	if !ok {
+		if _, pending := s.unindexed[key]; !pending {
+			s.epochUsage(epoch).Roots++
+		}
+	}
+	v.epoch = epoch
 	if !v.mask[idx] {
+		s.epochUsage(epoch).Blobs++
 		s.updateMetrics(1)
 	}
 	v.mask[idx] = true
@@ -83,17 +116,80 @@ func (s *blobStorageCache) ensure(key [32]byte, slot primitives.Slot, idx uint64
 	return nil
 }
 
-func (s *blobStorageCache) slot(key [32]byte) (primitives.Slot, bool) {
+// ensureUnindexed records that blobs for the given root are stored under the given epoch, without reading
+// which indices are present. The indices are read on the first call to Summary, or by indexPending.
+func (s *blobStorageCache) ensureUnindexed(key [32]byte, epoch primitives.Epoch) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.cache[key]; ok {
+		return
+	}
+	if _, ok := s.unindexed[key]; !ok {
+		s.epochUsage(epoch).Roots++
+	}
+	s.unindexed[key] = epoch
+}
+
+// index reads the indices for a root found by the warm-up and merges them into the cache.
+func (s *blobStorageCache) index(key [32]byte, epoch primitives.Epoch) BlobStorageSummary {
+	var mask blobIndexMask
+	if s.indexer != nil {
+		var err error
+		mask, err = s.indexer(key, epoch)
+		if err != nil {
+			log.WithError(err).WithField("root", rootString(key)).Error("Could not read blob indices for cache")
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.unindexed[key]; !ok {
+		// Either another caller indexed the root first, or it was evicted while we were reading from disk.
+		return s.cache[key]
+	}
+	delete(s.unindexed, key)
+	v := s.cache[key]
+	v.epoch = epoch
+	var added float64
+	for i := range mask {
+		if mask[i] && !v.mask[i] {
+			v.mask[i] = true
+			added += 1
+		}
+	}
+	s.cache[key] = v
+	s.epochUsage(epoch).Blobs += int(added)
+	s.updateMetrics(added)
+	return v
+}
+
+// indexPending reads the indices of every root left unindexed by the warm-up, so that metrics converge and
+// later Summary calls are served from memory.
+func (s *blobStorageCache) indexPending() {
+	start := time.Now()
+	s.mu.RLock()
+	pending := make(map[[32]byte]primitives.Epoch, len(s.unindexed))
+	for k, v := range s.unindexed {
+		pending[k] = v
+	}
+	s.mu.RUnlock()
+	for k, v := range pending {
+		s.index(k, v)
+	}
+	log.WithField("roots", len(pending)).WithField("elapsed", time.Since(start)).Debug("Indexed blobs found by cache warm-up")
+}
+
+func (s *blobStorageCache) epoch(key [32]byte) (primitives.Epoch, bool) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	v, ok := s.cache[key]
-	if !ok {
-		return 0, false
+	if v, ok := s.cache[key]; ok {
+		return v.epoch, true
 	}
-	return v.slot, ok
+	e, ok := s.unindexed[key]
+	return e, ok
 }
 
-func (s *blobStorageCache) evict(key [32]byte) {
+// evict removes the root from the cache and returns the number of blobs that were recorded for it.
+func (s *blobStorageCache) evict(key [32]byte) int {
 	var deleted float64
 	s.mu.Lock()
 	v, ok := s.cache[key]
@@ -103,12 +199,66 @@ func (s *blobStorageCache) evict(key [32]byte) {
 				deleted += 1
 			}
 		}
+		s.removeEpochUsage(v.epoch, int(deleted))
+	} else if epoch, pending := s.unindexed[key]; pending {
+		s.removeEpochUsage(epoch, 0)
 	}
 	delete(s.cache, key)
+	delete(s.unindexed, key)
 	s.mu.Unlock()
 	if deleted > 0 {
 		s.updateMetrics(-deleted)
 	}
+	return int(deleted)
+}
+
+// clear removes every root from the cache.
+func (s *blobStorageCache) clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cache = make(map[[32]byte]BlobStorageSummary)
+	s.unindexed = make(map[[32]byte]primitives.Epoch)
+	s.epochs = make(map[primitives.Epoch]*BlobEpochUsage)
+	s.updateMetrics(-s.nBlobs)
+}
+
+// usageByEpoch returns the storage usage of each epoch with blobs, in epoch order.
+func (s *blobStorageCache) usageByEpoch() []BlobEpochUsage {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	u := make([]BlobEpochUsage, 0, len(s.epochs))
+	for _, e := range s.epochs {
+		c := *e
+		c.Bytes = uint64(c.Blobs) * fieldparams.BlobSidecarSize
+		u = append(u, c)
+	}
+	sort.Slice(u, func(i, j int) bool {
+		return u[i].Epoch < u[j].Epoch
+	})
+	return u
+}
+
+// epochUsage returns the usage counters of the epoch, creating them if needed. The caller must hold the lock.
+func (s *blobStorageCache) epochUsage(epoch primitives.Epoch) *BlobEpochUsage {
+	e, ok := s.epochs[epoch]
+	if !ok {
+		e = &BlobEpochUsage{Epoch: epoch}
+		s.epochs[epoch] = e
+	}
+	return e
+}
+
+// removeEpochUsage removes a root and its blobs from the usage counters of the epoch. The caller must hold the lock.
+func (s *blobStorageCache) removeEpochUsage(epoch primitives.Epoch, blobs int) {
+	e, ok := s.epochs[epoch]
+	if !ok {
+		return
+	}
+	e.Roots--
+	e.Blobs -= blobs
+	if e.Roots <= 0 {
+		delete(s.epochs, epoch)
+	}
 }
 
 func (s *blobStorageCache) updateMetrics(delta float64) {
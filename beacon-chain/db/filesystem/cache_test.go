@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 )
@@ -49,7 +50,7 @@ func TestSlotByRoot_Summary(t *testing.T) {
 	for _, c := range cases {
 		if c.expected != nil {
 			key := bytesutil.ToBytes32([]byte(c.name))
-			sc.cache[key] = BlobStorageSummary{slot: 0, mask: *c.expected}
+			sc.cache[key] = BlobStorageSummary{epoch: 0, mask: *c.expected}
 		}
 	}
 	for _, c := range cases {
@@ -148,3 +149,34 @@ func TestAllAvailable(t *testing.T) {
 		})
 	}
 }
+
+func TestBlobStorageCache_UsageByEpoch(t *testing.T) {
+	sc := newBlobStorageCache()
+	sc.indexer = func(_ [32]byte, _ primitives.Epoch) (blobIndexMask, error) {
+		var m blobIndexMask
+		m[0], m[1], m[2] = true, true, true
+		return m, nil
+	}
+	require.NoError(t, sc.ensure([32]byte{1}, 5, 0))
+	require.NoError(t, sc.ensure([32]byte{1}, 5, 1))
+	require.NoError(t, sc.ensure([32]byte{2}, 5, 0))
+	require.NoError(t, sc.ensure([32]byte{3}, 7, 0))
+	sc.ensureUnindexed([32]byte{4}, 3)
+
+	u := sc.usageByEpoch()
+	require.Equal(t, 3, len(u))
+	require.DeepEqual(t, BlobEpochUsage{Epoch: 3, Roots: 1}, u[0])
+	require.DeepEqual(t, BlobEpochUsage{Epoch: 5, Roots: 2, Blobs: 3, Bytes: 3 * fieldparams.BlobSidecarSize}, u[1])
+	require.DeepEqual(t, BlobEpochUsage{Epoch: 7, Roots: 1, Blobs: 1, Bytes: fieldparams.BlobSidecarSize}, u[2])
+
+	sc.indexPending()
+	sc.evict([32]byte{1})
+	sc.evict([32]byte{3})
+	u = sc.usageByEpoch()
+	require.Equal(t, 2, len(u))
+	require.DeepEqual(t, BlobEpochUsage{Epoch: 3, Roots: 1, Blobs: 3, Bytes: 3 * fieldparams.BlobSidecarSize}, u[0])
+	require.DeepEqual(t, BlobEpochUsage{Epoch: 5, Roots: 1, Blobs: 1, Bytes: fieldparams.BlobSidecarSize}, u[1])
+
+	sc.clear()
+	require.Equal(t, 0, len(sc.usageByEpoch()))
+}
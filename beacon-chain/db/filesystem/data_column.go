@@ -0,0 +1,326 @@
+package filesystem
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/async/event"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/logging"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/spf13/afero"
+)
+
+var (
+	errColumnIndexOutOfBounds = errors.New("data column index in file name >= NumberOfColumns")
+	errNoColumnBasePath       = errors.New("DataColumnStorage base path not specified in init")
+)
+
+// DataColumnIdent identifies a data column sidecar that has been written to DataColumnStorage.
+type DataColumnIdent struct {
+	Root  [32]byte
+	Epoch primitives.Epoch
+	Index uint64
+}
+
+// DataColumnStorageOption is a functional option for configuring a DataColumnStorage.
+type DataColumnStorageOption func(*DataColumnStorage) error
+
+// WithDataColumnBasePath is a required option that sets the base path of data column storage.
+func WithDataColumnBasePath(base string) DataColumnStorageOption {
+	return func(s *DataColumnStorage) error {
+		s.base = base
+		return nil
+	}
+}
+
+// WithDataColumnRetentionEpochs is an option that changes the number of epochs data columns will be persisted.
+func WithDataColumnRetentionEpochs(e primitives.Epoch) DataColumnStorageOption {
+	return func(s *DataColumnStorage) error {
+		s.retentionEpochs = e
+		return nil
+	}
+}
+
+// DataColumnStorage is the filesystem backend for saving and retrieving DataColumnSidecars. Columns are stored
+// in the same by-epoch layout as blobs, under their own base path, with one file per column index:
+//
+//	by-epoch/<period>/<epoch>/<root>/<column index>.ssz
+//
+// The storage keeps an in-memory record of which columns are present for each root, so that availability checks
+// do not need to read the filesystem, and prunes whole epoch directories once they leave the retention period.
+type DataColumnStorage struct {
+	base            string
+	retentionEpochs primitives.Epoch
+	fs              afero.Fs
+	feed            event.Feed
+
+	sync.RWMutex
+	roots        map[[32]byte]*columnSummary
+	epochs       map[primitives.Epoch]map[[32]byte]struct{}
+	prunedBefore primitives.Epoch
+}
+
+type columnSummary struct {
+	epoch   primitives.Epoch
+	indices map[uint64]bool
+}
+
+// NewDataColumnStorage creates a new instance of the DataColumnStorage object.
+func NewDataColumnStorage(opts ...DataColumnStorageOption) (*DataColumnStorage, error) {
+	s := newDataColumnStorage(nil, params.BeaconConfig().MinEpochsForDataColumnSidecarsRequest)
+	for _, o := range opts {
+		if err := o(s); err != nil {
+			return nil, errors.Wrap(err, "failed to create data column storage")
+		}
+	}
+	if s.base == "" {
+		return nil, errNoColumnBasePath
+	}
+	s.base = path.Clean(s.base)
+	if err := file.MkdirAll(s.base); err != nil {
+		return nil, errors.Wrapf(err, "failed to create data column storage at %s", s.base)
+	}
+	s.fs = afero.NewBasePathFs(afero.NewOsFs(), s.base)
+	return s, nil
+}
+
+func newDataColumnStorage(fs afero.Fs, retention primitives.Epoch) *DataColumnStorage {
+	return &DataColumnStorage{
+		fs:              fs,
+		retentionEpochs: retention,
+		roots:           make(map[[32]byte]*columnSummary),
+		epochs:          make(map[primitives.Epoch]map[[32]byte]struct{}),
+	}
+}
+
+// WarmCache reads the columns present on disk into the in-memory record of stored columns.
+func (s *DataColumnStorage) WarmCache() {
+	go func() {
+		start := time.Now()
+		if err := s.warmCache(); err != nil {
+			log.WithError(err).Error("Error encountered while warming up data column storage cache")
+		}
+		log.WithField("elapsed", time.Since(start)).Info("Data column filesystem cache warm-up complete.")
+	}()
+}
+
+func (s *DataColumnStorage) warmCache() error {
+	var walkErr error
+	if _, err := walkEpochRoots(s.fs, func(root [32]byte, epoch primitives.Epoch) {
+		indices, err := columnIndicesInDir(s.fs, columnDir(root, epoch))
+		if err != nil {
+			walkErr = err
+			return
+		}
+		s.Lock()
+		for idx := range indices {
+			s.record(root, epoch, idx)
+		}
+		s.Unlock()
+	}); err != nil {
+		return err
+	}
+	return walkErr
+}
+
+// Save writes the data column sidecar to disk and notifies subscribers that it is available.
+func (s *DataColumnStorage) Save(dc blocks.VerifiedRODataColumn) error {
+	if dc.ColumnIndex >= fieldparams.NumberOfColumns {
+		return errColumnIndexOutOfBounds
+	}
+	startTime := time.Now()
+	epoch := slots.ToEpoch(dc.Slot())
+	sszPath := columnPath(dc.BlockRoot(), epoch, dc.ColumnIndex)
+	exists, err := afero.Exists(s.fs, sszPath)
+	if err != nil {
+		return err
+	}
+	if exists {
+		log.WithFields(logging.DataColumnFields(dc.RODataColumn)).Debug("Ignoring a duplicate data column sidecar save attempt")
+		return nil
+	}
+
+	encoded, err := dc.MarshalSSZ()
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize data column sidecar")
+	} else if len(encoded) == 0 {
+		return errSidecarEmptySSZData
+	}
+	dir := columnDir(dc.BlockRoot(), epoch)
+	if err := s.fs.MkdirAll(dir, directoryPermissions); err != nil {
+		return err
+	}
+	partPath := path.Join(dir, fmt.Sprintf("%p-%d.%s", encoded, dc.ColumnIndex, partExt))
+	if err := afero.WriteFile(s.fs, partPath, encoded, 0600); err != nil {
+		if rmErr := s.fs.Remove(partPath); rmErr != nil {
+			log.WithError(rmErr).WithField("partPath", partPath).Debug("Could not remove partial data column file")
+		}
+		return errors.Wrap(err, "failed to write partial file")
+	}
+	if err := s.fs.Rename(partPath, sszPath); err != nil {
+		return errors.Wrap(err, "failed to rename partial file to final name")
+	}
+
+	s.Lock()
+	s.record(dc.BlockRoot(), epoch, dc.ColumnIndex)
+	s.Unlock()
+	s.prune(epoch)
+	dataColumnsWrittenCounter.Inc()
+	dataColumnSaveLatency.Observe(float64(time.Since(startTime).Milliseconds()))
+	s.feed.Send(DataColumnIdent{Root: dc.BlockRoot(), Epoch: epoch, Index: dc.ColumnIndex})
+	return nil
+}
+
+// Get retrieves a single DataColumnSidecar by its root and index.
+// Since DataColumnStorage only writes columns that have undergone full verification, the return
+// value is always a VerifiedRODataColumn.
+func (s *DataColumnStorage) Get(root [32]byte, idx uint64) (blocks.VerifiedRODataColumn, error) {
+	s.RLock()
+	sum, ok := s.roots[root]
+	ok = ok && sum.indices[idx]
+	s.RUnlock()
+	if !ok {
+		return blocks.VerifiedRODataColumn{}, &os.PathError{Op: "get", Path: fmt.Sprintf("%s/%d", rootString(root), idx), Err: os.ErrNotExist}
+	}
+	encoded, err := afero.ReadFile(s.fs, columnPath(root, sum.epoch, idx))
+	if err != nil {
+		return blocks.VerifiedRODataColumn{}, err
+	}
+	pb := &ethpb.DataColumnSidecar{}
+	if err := pb.UnmarshalSSZ(encoded); err != nil {
+		return blocks.VerifiedRODataColumn{}, err
+	}
+	ro, err := blocks.NewRODataColumnWithRoot(pb, root)
+	if err != nil {
+		return blocks.VerifiedRODataColumn{}, err
+	}
+	return blocks.NewVerifiedRODataColumn(ro), nil
+}
+
+// Indices returns the set of column indices stored for the given root.
+func (s *DataColumnStorage) Indices(root [32]byte) map[uint64]bool {
+	s.RLock()
+	defer s.RUnlock()
+	sum, ok := s.roots[root]
+	if !ok {
+		return map[uint64]bool{}
+	}
+	indices := make(map[uint64]bool, len(sum.indices))
+	for idx := range sum.indices {
+		indices[idx] = true
+	}
+	return indices
+}
+
+// Remove removes all data columns for a given root.
+func (s *DataColumnStorage) Remove(root [32]byte) error {
+	s.Lock()
+	defer s.Unlock()
+	sum, ok := s.roots[root]
+	if !ok {
+		return nil
+	}
+	if err := s.fs.RemoveAll(columnDir(root, sum.epoch)); err != nil {
+		return err
+	}
+	delete(s.roots, root)
+	delete(s.epochs[sum.epoch], root)
+	return nil
+}
+
+// Subscribe sends a DataColumnIdent on the given channel every time a data column sidecar is saved.
+func (s *DataColumnStorage) Subscribe(ch chan<- DataColumnIdent) event.Subscription {
+	return s.feed.Subscribe(ch)
+}
+
+// WithinRetentionPeriod checks if the requested epoch is within the data column retention period.
+func (s *DataColumnStorage) WithinRetentionPeriod(requested, current primitives.Epoch) bool {
+	if requested > math.MaxUint64-s.retentionEpochs {
+		return true
+	}
+	return requested+s.retentionEpochs >= current
+}
+
+// record must be called with the lock held.
+func (s *DataColumnStorage) record(root [32]byte, epoch primitives.Epoch, idx uint64) {
+	sum, ok := s.roots[root]
+	if !ok {
+		sum = &columnSummary{epoch: epoch, indices: make(map[uint64]bool)}
+		s.roots[root] = sum
+	}
+	sum.indices[idx] = true
+	if _, ok := s.epochs[epoch]; !ok {
+		s.epochs[epoch] = make(map[[32]byte]struct{})
+	}
+	s.epochs[epoch][root] = struct{}{}
+}
+
+// prune removes every epoch directory that falls outside of the retention period, relative to the given epoch.
+func (s *DataColumnStorage) prune(latest primitives.Epoch) {
+	window := s.retentionEpochs + retentionBuffer
+	if latest <= window {
+		return
+	}
+	before := latest - window
+	s.Lock()
+	defer s.Unlock()
+	if before <= s.prunedBefore {
+		return
+	}
+	s.prunedBefore = before
+	for epoch, roots := range s.epochs {
+		if epoch >= before {
+			continue
+		}
+		if err := s.fs.RemoveAll(epochDir(epoch)); err != nil {
+			log.WithError(err).WithField("epoch", epoch).Error("Could not prune data column epoch directory")
+			continue
+		}
+		for root := range roots {
+			delete(s.roots, root)
+		}
+		delete(s.epochs, epoch)
+		dataColumnsPrunedCounter.Add(float64(len(roots)))
+	}
+}
+
+func columnDir(root [32]byte, epoch primitives.Epoch) string {
+	return path.Join(epochDir(epoch), rootString(root))
+}
+
+func columnPath(root [32]byte, epoch primitives.Epoch, idx uint64) string {
+	return path.Join(columnDir(root, epoch), fmt.Sprintf("%d.%s", idx, sszExt))
+}
+
+// columnIndicesInDir lists the column sidecar files in the given root directory. A missing directory is not an error.
+func columnIndicesInDir(fs afero.Fs, dir string) (map[uint64]bool, error) {
+	entries, err := listDir(fs, dir)
+	if err != nil {
+		return nil, err
+	}
+	indices := make(map[uint64]bool)
+	for _, name := range filter(entries, filterSsz) {
+		u, err := strconv.ParseUint(strings.TrimSuffix(name, dotSszExt), 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unexpected directory entry breaks listing, %s", name)
+		}
+		if u >= fieldparams.NumberOfColumns {
+			return nil, errColumnIndexOutOfBounds
+		}
+		indices[u] = true
+	}
+	return indices, nil
+}
@@ -0,0 +1,80 @@
+package filesystem
+
+import (
+	"os"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/spf13/afero"
+)
+
+func verifiedColumns(t *testing.T, slot primitives.Slot) []blocks.VerifiedRODataColumn {
+	_, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, slot, 2)
+	vcs := make([]blocks.VerifiedRODataColumn, len(columns))
+	for i := range columns {
+		vcs[i] = verification.FakeVerifyDataColumnForTest(t, columns[i])
+	}
+	return vcs
+}
+
+func TestDataColumnStorage_SaveGet(t *testing.T) {
+	fs, s := NewEphemeralDataColumnStorageWithFs(t)
+	vcs := verifiedColumns(t, 100)
+	root := vcs[0].BlockRoot()
+
+	ch := make(chan DataColumnIdent, 2)
+	sub := s.Subscribe(ch)
+	defer sub.Unsubscribe()
+
+	require.NoError(t, s.Save(vcs[3]))
+	require.NoError(t, s.Save(vcs[7]))
+	// Saving a duplicate is a no-op.
+	require.NoError(t, s.Save(vcs[7]))
+	require.DeepEqual(t, map[uint64]bool{3: true, 7: true}, s.Indices(root))
+	require.Equal(t, DataColumnIdent{Root: root, Epoch: slots.ToEpoch(100), Index: 3}, <-ch)
+	require.Equal(t, DataColumnIdent{Root: root, Epoch: slots.ToEpoch(100), Index: 7}, <-ch)
+
+	got, err := s.Get(root, 7)
+	require.NoError(t, err)
+	require.DeepEqual(t, vcs[7].DataColumnSidecar, got.DataColumnSidecar)
+	_, err = s.Get(root, 8)
+	require.ErrorIs(t, err, os.ErrNotExist)
+	require.Equal(t, true, os.IsNotExist(err))
+
+	// A fresh storage over the same filesystem recovers the stored indices.
+	warm := newDataColumnStorage(fs, params.BeaconConfig().MinEpochsForDataColumnSidecarsRequest)
+	require.NoError(t, warm.warmCache())
+	require.DeepEqual(t, map[uint64]bool{3: true, 7: true}, warm.Indices(root))
+
+	require.NoError(t, s.Remove(root))
+	require.Equal(t, 0, len(s.Indices(root)))
+	exists, err := afero.DirExists(fs, columnDir(root, slots.ToEpoch(100)))
+	require.NoError(t, err)
+	require.Equal(t, false, exists)
+}
+
+func TestDataColumnStorage_Prune(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	s := newDataColumnStorage(fs, 2)
+	old := verifiedColumns(t, 0)
+	require.NoError(t, s.Save(old[0]))
+
+	// Still within the retention period and buffer.
+	within := verifiedColumns(t, params.BeaconConfig().SlotsPerEpoch*4)
+	require.NoError(t, s.Save(within[0]))
+	require.Equal(t, 1, len(s.Indices(old[0].BlockRoot())))
+
+	recent := verifiedColumns(t, params.BeaconConfig().SlotsPerEpoch*5)
+	require.NoError(t, s.Save(recent[0]))
+	require.Equal(t, 0, len(s.Indices(old[0].BlockRoot())))
+	require.Equal(t, 1, len(s.Indices(within[0].BlockRoot())))
+	exists, err := afero.DirExists(fs, epochDir(0))
+	require.NoError(t, err)
+	require.Equal(t, false, exists)
+}
@@ -0,0 +1,177 @@
+package filesystem
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/spf13/afero"
+)
+
+// Blobs are stored in a tree of directories grouped by period and then by epoch:
+//
+//	by-epoch/<period>/<epoch>/<root>/<index>.ssz
+//
+// where period = epoch / periodEpochs. Grouping by epoch means that pruning an epoch is a single directory
+// removal, and the period level keeps the number of entries in any one directory small, even with a retention
+// window covering many epochs.
+const (
+	byEpochLayoutDir = "by-epoch"
+	// periodEpochs is the number of epoch directories grouped under a single period directory.
+	periodEpochs primitives.Epoch = 4096
+)
+
+func periodForEpoch(e primitives.Epoch) primitives.Epoch {
+	return e / periodEpochs
+}
+
+func periodDir(period primitives.Epoch) string {
+	return path.Join(byEpochLayoutDir, strconv.FormatUint(uint64(period), 10))
+}
+
+func epochDir(e primitives.Epoch) string {
+	return path.Join(periodDir(periodForEpoch(e)), strconv.FormatUint(uint64(e), 10))
+}
+
+// blobNamer computes the location of a blob within the BlobStorage filesystem.
+type blobNamer struct {
+	root  [32]byte
+	epoch primitives.Epoch
+	index uint64
+	// flat is set for blobs still stored in the layout used by earlier versions, where every root directory
+	// sits directly under the base path.
+	flat bool
+}
+
+func (p blobNamer) dir() string {
+	if p.flat {
+		return rootString(p.root)
+	}
+	return path.Join(epochDir(p.epoch), rootString(p.root))
+}
+
+func (p blobNamer) partPath(entropy string) string {
+	return path.Join(p.dir(), fmt.Sprintf("%s-%d.%s", entropy, p.index, partExt))
+}
+
+func (p blobNamer) path() string {
+	return path.Join(p.dir(), fmt.Sprintf("%d.%s", p.index, sszExt))
+}
+
+// indicesInDir lists the blob sidecar files in the given root directory, returning the set of indices
+// that are present. A missing directory is not an error and results in an empty mask.
+func indicesInDir(fs afero.Fs, dir string) (blobIndexMask, error) {
+	var mask blobIndexMask
+	entries, err := afero.ReadDir(fs, dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return mask, nil
+		}
+		return mask, err
+	}
+	for i := range entries {
+		if entries[i].IsDir() {
+			continue
+		}
+		name := entries[i].Name()
+		if !strings.HasSuffix(name, sszExt) {
+			continue
+		}
+		parts := strings.Split(name, ".")
+		if len(parts) != 2 {
+			continue
+		}
+		u, err := strconv.ParseUint(parts[0], 10, 64)
+		if err != nil {
+			return mask, errors.Wrapf(err, "unexpected directory entry breaks listing, %s", parts[0])
+		}
+		if u >= fieldparams.MaxBlobsPerBlock {
+			return mask, errIndexOutOfBounds
+		}
+		mask[u] = true
+	}
+	return mask, nil
+}
+
+// listNumberedDirs lists the numbered subdirectories of dir. A missing directory is not an error.
+func listNumberedDirs(fs afero.Fs, dir string) ([]primitives.Epoch, error) {
+	entries, err := listDir(fs, dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	epochs := make([]primitives.Epoch, 0, len(entries))
+	for _, e := range entries {
+		u, err := strconv.ParseUint(e, 10, 64)
+		if err != nil {
+			log.WithField("entry", path.Join(dir, e)).Warn("Ignoring unexpected entry in blob storage directory")
+			continue
+		}
+		epochs = append(epochs, primitives.Epoch(u))
+	}
+	return epochs, nil
+}
+
+// walkEpochRoots calls fn with the root and epoch of every root directory in the by-epoch layout, returning the
+// number of epoch directories visited.
+func walkEpochRoots(fs afero.Fs, fn func(root [32]byte, epoch primitives.Epoch)) (int, error) {
+	periods, err := listNumberedDirs(fs, byEpochLayoutDir)
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to list blob period directories")
+	}
+	nEpochs := 0
+	for _, period := range periods {
+		epochs, err := listNumberedDirs(fs, periodDir(period))
+		if err != nil {
+			return nEpochs, errors.Wrapf(err, "unable to list blob epoch directories for period %d", period)
+		}
+		for _, epoch := range epochs {
+			roots, err := listDir(fs, epochDir(epoch))
+			if err != nil {
+				return nEpochs, errors.Wrapf(err, "unable to list blob directories for epoch %d", epoch)
+			}
+			for _, dir := range filter(roots, filterRoot) {
+				root, err := rootFromDir(dir)
+				if err != nil {
+					log.WithError(err).WithField("epoch", epoch).Warn("Ignoring unexpected entry in blob epoch directory")
+					continue
+				}
+				fn(root, epoch)
+			}
+		}
+		nEpochs += len(epochs)
+	}
+	return nEpochs, nil
+}
+
+// findRootEpoch looks for the directory of the given root in the by-epoch layout, returning the epoch it is stored
+// under. This lists every epoch directory, so it should only be used when the cache can't answer, before warm-up.
+func findRootEpoch(fs afero.Fs, root [32]byte) (primitives.Epoch, bool, error) {
+	periods, err := listNumberedDirs(fs, byEpochLayoutDir)
+	if err != nil {
+		return 0, false, errors.Wrap(err, "unable to list blob period directories")
+	}
+	for _, period := range periods {
+		epochs, err := listNumberedDirs(fs, periodDir(period))
+		if err != nil {
+			return 0, false, errors.Wrapf(err, "unable to list blob epoch directories for period %d", period)
+		}
+		for _, epoch := range epochs {
+			exists, err := afero.DirExists(fs, blobNamer{root: root, epoch: epoch}.dir())
+			if err != nil {
+				return 0, false, err
+			}
+			if exists {
+				return epoch, true, nil
+			}
+		}
+	}
+	return 0, false, nil
+}
@@ -21,10 +21,39 @@ var (
 		Name: "blob_pruned",
 		Help: "Number of BlobSidecar files pruned.",
 	})
+	blobsMigratedCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "blob_migrated",
+		Help: "Number of BlobSidecar files moved from the flat layout to the by-epoch layout.",
+	})
 	blobsWrittenCounter = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "blob_written",
 		Help: "Number of BlobSidecar files written",
 	})
+	blobRemoteFetchCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "blob_remote_fetched",
+		Help: "Number of BlobSidecar files read from the remote backend after being pruned from local disk.",
+	})
+	blobRemoteErrorCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "blob_remote_errors",
+		Help: "Number of failed requests to the remote blob backend.",
+	})
+	blobRemoteUploadDroppedCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "blob_remote_upload_dropped",
+		Help: "Number of BlobSidecar uploads to the remote backend abandoned because the blob was removed from local disk.",
+	})
+	dataColumnSaveLatency = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "data_column_storage_save_latency",
+		Help:    "Latency of DataColumnSidecar storage save operations in milliseconds",
+		Buckets: blobBuckets,
+	})
+	dataColumnsWrittenCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "data_column_written",
+		Help: "Number of DataColumnSidecar files written",
+	})
+	dataColumnsPrunedCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "data_column_roots_pruned",
+		Help: "Number of block roots whose DataColumnSidecar files were pruned.",
+	})
 	blobDiskCount = promauto.NewGauge(prometheus.GaugeOpts{
 		Name: "blob_disk_count",
 		Help: "Approximate number of blob files in storage",
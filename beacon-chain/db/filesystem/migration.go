@@ -0,0 +1,93 @@
+package filesystem
+
+import (
+	"path"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+var errMigrationFailures = errors.New("blobs could not be migrated for some roots")
+
+// migrateFlatLayout moves blobs written by earlier versions of BlobStorage, where every root directory sits
+// directly under the base path, into the by-epoch layout. Each migrated blob is added to the cache. Blobs that
+// have not been migrated yet can still be read through the flat layout fallback in BlobStorage, so the node can
+// keep running while the migration is in progress.
+func migrateFlatLayout(fs afero.Fs, cache *blobStorageCache) error {
+	entries, err := listDir(fs, ".")
+	if err != nil {
+		return errors.Wrap(err, "unable to list root blobs directory")
+	}
+	dirs := filter(entries, filterRoot)
+	if len(dirs) == 0 {
+		return nil
+	}
+	start := time.Now()
+	log.WithField("directories", len(dirs)).Info("Migrating blob storage to by-epoch layout")
+	migrated, failed := 0, 0
+	for _, dir := range dirs {
+		n, err := migrateRootDir(fs, cache, dir)
+		if err != nil {
+			failed += 1
+			log.WithError(err).WithField("directory", dir).Error("Unable to migrate blob directory")
+			continue
+		}
+		migrated += n
+	}
+	blobsMigratedCounter.Add(float64(migrated))
+	log.WithFields(logrus.Fields{
+		"filesMigrated": migrated,
+		"failures":      failed,
+		"duration":      time.Since(start).String(),
+	}).Info("Blob storage layout migration complete")
+	if failed > 0 {
+		return errors.Wrapf(errMigrationFailures, "migration failed for %d root directories", failed)
+	}
+	return nil
+}
+
+// migrateRootDir moves the blob sidecar files in a flat layout root directory to the by-epoch layout,
+// then removes the old directory along with any abandoned .part files.
+func migrateRootDir(fs afero.Fs, cache *blobStorageCache, dir string) (int, error) {
+	root, err := rootFromDir(dir)
+	if err != nil {
+		return 0, err
+	}
+	entries, err := listDir(fs, dir)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to list blobs in directory %s", dir)
+	}
+	scFiles := filter(entries, filterSsz)
+	if len(scFiles) == 0 {
+		log.WithField("dir", dir).Warn("Removing blob directory with no blob files")
+		return 0, fs.RemoveAll(dir)
+	}
+	slot, err := slotFromFile(path.Join(dir, scFiles[0]), fs)
+	if err != nil {
+		return 0, errors.Wrapf(err, "slot could not be read from blob file %s", scFiles[0])
+	}
+	epoch := slots.ToEpoch(slot)
+	for i := range scFiles {
+		idx, err := idxFromPath(scFiles[i])
+		if err != nil {
+			return i, errors.Wrapf(err, "index could not be determined for blob file %s", scFiles[i])
+		}
+		n := blobNamer{root: root, epoch: epoch, index: idx}
+		if err := fs.MkdirAll(n.dir(), directoryPermissions); err != nil {
+			return i, err
+		}
+		if err := fs.Rename(path.Join(dir, scFiles[i]), n.path()); err != nil {
+			return i, errors.Wrapf(err, "unable to move blob file %s", scFiles[i])
+		}
+		if err := cache.ensure(root, epoch, idx); err != nil {
+			return i, errors.Wrapf(err, "could not update cache for blob file %s", scFiles[i])
+		}
+	}
+	if err := fs.RemoveAll(dir); err != nil {
+		return len(scFiles), errors.Wrapf(err, "unable to remove blob directory %s", dir)
+	}
+	return len(scFiles), nil
+}
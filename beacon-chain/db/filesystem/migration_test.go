@@ -0,0 +1,63 @@
+package filesystem
+
+import (
+	"path"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/spf13/afero"
+)
+
+func TestMigrateFlatLayout(t *testing.T) {
+	fs, bs := NewEphemeralBlobStorageWithFs(t)
+	slot := primitives.Slot(1000)
+	_, sidecars := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, slot, 3)
+	scs, err := verification.BlobSidecarSliceNoop(sidecars)
+	require.NoError(t, err)
+	root := scs[0].BlockRoot()
+
+	// Write the sidecars using the flat layout of earlier versions, along with an abandoned .part file.
+	for _, sc := range scs[:2] {
+		n := blobNamer{root: root, index: sc.Index, flat: true}
+		enc, err := sc.MarshalSSZ()
+		require.NoError(t, err)
+		require.NoError(t, fs.MkdirAll(n.dir(), directoryPermissions))
+		require.NoError(t, afero.WriteFile(fs, n.path(), enc, 0600))
+	}
+	flatDir := rootString(root)
+	require.NoError(t, afero.WriteFile(fs, path.Join(flatDir, "abc-2.part"), []byte("derp"), 0600))
+	// A directory with no blob files is removed.
+	require.NoError(t, fs.MkdirAll(rootString([32]byte{1}), directoryPermissions))
+
+	// Unmigrated blobs are readable through the flat layout fallback.
+	got, err := bs.Get(root, 1)
+	require.NoError(t, err)
+	require.DeepSSZEqual(t, scs[1], got)
+
+	require.NoError(t, migrateFlatLayout(fs, bs.cache))
+	entries, err := listDir(fs, ".")
+	require.NoError(t, err)
+	require.DeepEqual(t, []string{byEpochLayoutDir}, entries)
+
+	e, ok := bs.cache.epoch(root)
+	require.Equal(t, true, ok)
+	require.Equal(t, slots.ToEpoch(slot), e)
+	require.Equal(t, true, bs.cache.Summary(root).AllAvailable(2))
+	idx, err := bs.Indices(root)
+	require.NoError(t, err)
+	require.DeepEqual(t, [3]bool{true, true, false}, [3]bool{idx[0], idx[1], idx[2]})
+	got, err = bs.Get(root, 0)
+	require.NoError(t, err)
+	require.DeepSSZEqual(t, scs[0], got)
+
+	// Saving after the migration adds to the same directory.
+	require.NoError(t, bs.Save(scs[2]))
+	require.Equal(t, 1, len(listRootDirs(t, fs)))
+	files, err := listDir(fs, blobNamer{root: root, epoch: e}.dir())
+	require.NoError(t, err)
+	require.Equal(t, 3, len(files))
+}
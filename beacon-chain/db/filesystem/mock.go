@@ -16,7 +16,7 @@ func NewEphemeralBlobStorage(t testing.TB) *BlobStorage {
 	if err != nil {
 		t.Fatal("test setup issue", err)
 	}
-	return &BlobStorage{fs: fs, pruner: pruner}
+	return &BlobStorage{fs: fs, pruner: pruner, cache: pruner.cache}
 }
 
 // NewEphemeralBlobStorageWithFs can be used by tests that want access to the virtual filesystem
@@ -27,7 +27,19 @@ func NewEphemeralBlobStorageWithFs(t testing.TB) (afero.Fs, *BlobStorage) {
 	if err != nil {
 		t.Fatal("test setup issue", err)
 	}
-	return fs, &BlobStorage{fs: fs, pruner: pruner}
+	return fs, &BlobStorage{fs: fs, pruner: pruner, cache: pruner.cache}
+}
+
+// NewEphemeralDataColumnStorage should only be used for tests.
+// The instance of DataColumnStorage returned is backed by an in-memory virtual filesystem.
+func NewEphemeralDataColumnStorage(_ testing.TB) *DataColumnStorage {
+	return newDataColumnStorage(afero.NewMemMapFs(), params.BeaconConfig().MinEpochsForDataColumnSidecarsRequest)
+}
+
+// NewEphemeralDataColumnStorageWithFs is like NewEphemeralDataColumnStorage, and also returns the virtual filesystem.
+func NewEphemeralDataColumnStorageWithFs(_ testing.TB) (afero.Fs, *DataColumnStorage) {
+	fs := afero.NewMemMapFs()
+	return fs, newDataColumnStorage(fs, params.BeaconConfig().MinEpochsForDataColumnSidecarsRequest)
 }
 
 type BlobMocker struct {
@@ -36,9 +48,12 @@ type BlobMocker struct {
 }
 
 // CreateFakeIndices creates empty blob sidecar files at the expected path for the given
-// root and indices to influence the result of Indices().
+// root and indices to influence the result of Indices(). The files are placed under epoch 0.
 func (bm *BlobMocker) CreateFakeIndices(root [32]byte, indices ...uint64) error {
 	for i := range indices {
+		if err := bm.bs.cache.ensure(root, 0, indices[i]); err != nil {
+			return err
+		}
 		n := blobNamer{root: root, index: indices[i]}
 		if err := bm.fs.MkdirAll(n.dir(), directoryPermissions); err != nil {
 			return err
@@ -58,7 +73,7 @@ func (bm *BlobMocker) CreateFakeIndices(root [32]byte, indices ...uint64) error
 // BlockMocker encapsulates things blob path construction to avoid leaking implementation details.
 func NewEphemeralBlobStorageWithMocker(_ testing.TB) (*BlobMocker, *BlobStorage) {
 	fs := afero.NewMemMapFs()
-	bs := &BlobStorage{fs: fs}
+	bs := &BlobStorage{fs: fs, cache: newBlobStorageCache()}
 	return &BlobMocker{fs: fs, bs: bs}, bs
 }
 
@@ -34,6 +34,7 @@ type blobPruner struct {
 	cache        *blobStorageCache
 	cacheReady   chan struct{}
 	warmed       bool
+	disabled     bool
 	fs           afero.Fs
 }
 
@@ -45,6 +46,14 @@ func withWarmedCache() prunerOpt {
 	}
 }
 
+// withPruningDisabled keeps the pruner from deleting any blobs, while still maintaining the cache.
+func withPruningDisabled() prunerOpt {
+	return func(p *blobPruner) error {
+		p.disabled = true
+		return nil
+	}
+}
+
 func newBlobPruner(fs afero.Fs, retain primitives.Epoch, opts ...prunerOpt) (*blobPruner, error) {
 	r, err := slots.EpochStart(retain + retentionBuffer)
 	if err != nil {
@@ -52,6 +61,9 @@ func newBlobPruner(fs afero.Fs, retain primitives.Epoch, opts ...prunerOpt) (*bl
 	}
 	cw := make(chan struct{})
 	p := &blobPruner{fs: fs, windowSize: r, cache: newBlobStorageCache(), cacheReady: cw}
+	p.cache.indexer = func(root [32]byte, epoch primitives.Epoch) (blobIndexMask, error) {
+		return indicesInDir(fs, blobNamer{root: root, epoch: epoch}.dir())
+	}
 	for _, o := range opts {
 		if err := o(p); err != nil {
 			return nil, err
@@ -61,11 +73,14 @@ func newBlobPruner(fs afero.Fs, retain primitives.Epoch, opts ...prunerOpt) (*bl
 }
 
 // notify updates the pruner's view of root->blob mappings. This allows the pruner to build a cache
-// of root->slot mappings and decide when to evict old blobs based on the age of present blobs.
+// of root->epoch mappings and decide when to evict old blobs based on the age of present blobs.
 func (p *blobPruner) notify(root [32]byte, latest primitives.Slot, idx uint64) error {
-	if err := p.cache.ensure(root, latest, idx); err != nil {
+	if err := p.cache.ensure(root, slots.ToEpoch(latest), idx); err != nil {
 		return err
 	}
+	if p.disabled {
+		return nil
+	}
 	pruned := uint64(windowMin(latest, p.windowSize))
 	if p.prunedBefore.Swap(pruned) == pruned {
 		return nil
@@ -95,15 +110,35 @@ func (p *blobPruner) warmCache() error {
 		if !p.warmed {
 			p.warmed = true
 			close(p.cacheReady)
+			go p.cache.indexPending()
 		}
 		p.Unlock()
 	}()
-	if err := p.prune(0); err != nil {
+	start := time.Now()
+	if err := migrateFlatLayout(p.fs, p.cache); err != nil {
+		return err
+	}
+	nEpochs, err := walkEpochRoots(p.fs, p.cache.ensureUnindexed)
+	if err != nil {
 		return err
 	}
+	log.WithFields(logrus.Fields{
+		"epochs":   nEpochs,
+		"duration": time.Since(start).String(),
+	}).Debug("Warmed up pruner cache")
 	return nil
 }
 
+// cacheWarmed returns true once warmCache has completed, from which point the cache knows every stored root.
+func (p *blobPruner) cacheWarmed() bool {
+	select {
+	case <-p.cacheReady:
+		return true
+	default:
+		return false
+	}
+}
+
 func (p *blobPruner) waitForCache(ctx context.Context) (*blobStorageCache, error) {
 	select {
 	case <-p.cacheReady:
@@ -116,114 +151,93 @@ func (p *blobPruner) waitForCache(ctx context.Context) (*blobStorageCache, error
 // Prune prunes blobs in the base directory based on the retention epoch.
 // It deletes blobs older than currentEpoch - (retentionEpochs+bufferEpochs).
 // This is so that we keep a slight buffer and blobs are deleted after n+2 epochs.
+// Since blobs are grouped by epoch, each expired epoch is removed as a single directory.
 func (p *blobPruner) prune(pruneBefore primitives.Slot) error {
 	start := time.Now()
 	totalPruned, totalErr := 0, 0
-	// Customize logging/metrics behavior for the initial cache warmup when slot=0.
-	// We'll never see a prune request for slot 0, unless this is the initial call to warm up the cache.
-	if pruneBefore == 0 {
-		defer func() {
-			log.WithField("duration", time.Since(start).String()).Debug("Warmed up pruner cache")
-		}()
-	} else {
-		defer func() {
-			log.WithFields(logrus.Fields{
-				"upToEpoch":    slots.ToEpoch(pruneBefore),
-				"duration":     time.Since(start).String(),
-				"filesRemoved": totalPruned,
-			}).Debug("Pruned old blobs")
-			blobsPrunedCounter.Add(float64(totalPruned))
-		}()
-	}
+	defer func() {
+		log.WithFields(logrus.Fields{
+			"upToEpoch":    slots.ToEpoch(pruneBefore),
+			"duration":     time.Since(start).String(),
+			"filesRemoved": totalPruned,
+		}).Debug("Pruned old blobs")
+		blobsPrunedCounter.Add(float64(totalPruned))
+	}()
 
-	entries, err := listDir(p.fs, ".")
+	before := slots.ToEpoch(pruneBefore)
+	periods, err := listNumberedDirs(p.fs, byEpochLayoutDir)
 	if err != nil {
-		return errors.Wrap(err, "unable to list root blobs directory")
+		return errors.Wrap(err, "unable to list blob period directories")
 	}
-	dirs := filter(entries, filterRoot)
-	for _, dir := range dirs {
-		pruned, err := p.tryPruneDir(dir, pruneBefore)
+	for _, period := range periods {
+		// Every epoch in this period (and any later period) is retained.
+		if period > periodForEpoch(before) {
+			continue
+		}
+		epochs, err := listNumberedDirs(p.fs, periodDir(period))
 		if err != nil {
 			totalErr += 1
-			log.WithError(err).WithField("directory", dir).Error("Unable to prune directory")
+			log.WithError(err).WithField("period", period).Error("Unable to list blob epoch directories")
+			continue
+		}
+		remaining := len(epochs)
+		for _, epoch := range epochs {
+			if epoch >= before {
+				continue
+			}
+			pending, err := hasPendingUploads(p.fs, epoch)
+			if err != nil {
+				totalErr += 1
+				log.WithError(err).WithField("epoch", epoch).Error("Unable to check pending blob uploads")
+				continue
+			}
+			if pending {
+				// The local copy is the only one until the upload to the remote backend succeeds.
+				log.WithField("epoch", epoch).Debug("Not pruning blob epoch with pending remote uploads")
+				continue
+			}
+			pruned, err := p.pruneEpoch(epoch)
+			if err != nil {
+				totalErr += 1
+				log.WithError(err).WithField("epoch", epoch).Error("Unable to prune blob epoch directory")
+				continue
+			}
+			remaining -= 1
+			totalPruned += pruned
+		}
+		if remaining == 0 {
+			if err := p.fs.Remove(periodDir(period)); err != nil {
+				log.WithError(err).WithField("period", period).Error("Unable to remove empty blob period directory")
+			}
 		}
-		totalPruned += pruned
 	}
 
 	if totalErr > 0 {
-		return errors.Wrapf(errPruningFailures, "pruning failed for %d root directories", totalErr)
+		return errors.Wrapf(errPruningFailures, "pruning failed for %d epoch directories", totalErr)
 	}
 	return nil
 }
 
-func shouldRetain(slot, pruneBefore primitives.Slot) bool {
-	return slot >= pruneBefore
-}
-
-func (p *blobPruner) tryPruneDir(dir string, pruneBefore primitives.Slot) (int, error) {
-	root, err := rootFromDir(dir)
-	if err != nil {
-		return 0, errors.Wrapf(err, "invalid directory, could not parse subdir as root %s", dir)
-	}
-	slot, slotCached := p.cache.slot(root)
-	// Return early if the slot is cached and doesn't need pruning.
-	if slotCached && shouldRetain(slot, pruneBefore) {
-		return 0, nil
-	}
-
-	// entries will include things that aren't ssz files, like dangling .part files. We need these to
-	// completely clean up the directory.
+// pruneEpoch removes the directory holding all blobs for the given epoch, evicting each of its roots from the
+// cache. The number of blobs removed is based on the cache, so blobs whose indices were never read are not counted.
+func (p *blobPruner) pruneEpoch(epoch primitives.Epoch) (int, error) {
+	dir := epochDir(epoch)
 	entries, err := listDir(p.fs, dir)
 	if err != nil {
-		return 0, errors.Wrapf(err, "failed to list blobs in directory %s", dir)
+		return 0, errors.Wrapf(err, "failed to list blob directories in %s", dir)
 	}
-	// scFiles filters the dir listing down to the ssz encoded BlobSidecar files. This allows us to peek
-	// at the first one in the list to figure out the slot.
-	scFiles := filter(entries, filterSsz)
-	if len(scFiles) == 0 {
-		log.WithField("dir", dir).Warn("Pruner ignoring directory with no blob files")
-		return 0, nil
-	}
-	if !slotCached {
-		slot, err = slotFromFile(path.Join(dir, scFiles[0]), p.fs)
+	pruned := 0
+	for _, rd := range filter(entries, filterRoot) {
+		root, err := rootFromDir(rd)
 		if err != nil {
-			return 0, errors.Wrapf(err, "slot could not be read from blob file %s", scFiles[0])
-		}
-		for i := range scFiles {
-			idx, err := idxFromPath(scFiles[i])
-			if err != nil {
-				return 0, errors.Wrapf(err, "index could not be determined for blob file %s", scFiles[i])
-			}
-			if err := p.cache.ensure(root, slot, idx); err != nil {
-				return 0, errors.Wrapf(err, "could not update prune cache for blob file %s", scFiles[i])
-			}
-		}
-		if shouldRetain(slot, pruneBefore) {
-			return 0, nil
+			continue
 		}
+		pruned += p.cache.evict(root)
 	}
-
-	removed := 0
-	for _, fname := range entries {
-		fullName := path.Join(dir, fname)
-		if err := p.fs.Remove(fullName); err != nil {
-			return removed, errors.Wrapf(err, "unable to remove %s", fullName)
-		}
-		// Don't count other files that happen to be in the dir, like dangling .part files.
-		if filterSsz(fname) {
-			removed += 1
-		}
-		// Log a warning whenever we clean up a .part file
-		if filterPart(fullName) {
-			log.WithField("file", fullName).Warn("Deleting abandoned blob .part file")
-		}
-	}
-	if err := p.fs.Remove(dir); err != nil {
-		return removed, errors.Wrapf(err, "unable to remove blob directory %s", dir)
+	if err := p.fs.RemoveAll(dir); err != nil {
+		return pruned, errors.Wrapf(err, "unable to remove blob directory %s", dir)
 	}
-
-	p.cache.evict(root)
-	return len(scFiles), nil
+	return pruned, nil
 }
 
 func idxFromPath(fname string) (uint64, error) {
@@ -12,34 +12,18 @@ import (
 	"time"
 
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
-	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
 	"github.com/spf13/afero"
 )
 
-func TestTryPruneDir_CachedNotExpired(t *testing.T) {
-	fs := afero.NewMemMapFs()
-	pr, err := newBlobPruner(fs, 0)
-	require.NoError(t, err)
-	slot := pr.windowSize
-	_, sidecars := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, slot, fieldparams.MaxBlobsPerBlock)
-	sc, err := verification.BlobSidecarNoop(sidecars[0])
-	require.NoError(t, err)
-	rootStr := rootString(sc.BlockRoot())
-	// This slot is right on the edge of what would need to be pruned, so by adding it to the cache and
-	// skipping any other test setup, we can be certain the hot cache path never touches the filesystem.
-	require.NoError(t, pr.cache.ensure(sc.BlockRoot(), sc.Slot(), 0))
-	pruned, err := pr.tryPruneDir(rootStr, pr.windowSize)
-	require.NoError(t, err)
-	require.Equal(t, 0, pruned)
-}
-
 func TestCacheWarmFail(t *testing.T) {
 	fs := afero.NewMemMapFs()
-	n := blobNamer{root: bytesutil.ToBytes32([]byte("derp")), index: 0}
+	n := blobNamer{root: bytesutil.ToBytes32([]byte("derp")), index: 0, flat: true}
 	bp := n.path()
 	mkdir := path.Dir(bp)
 	require.NoError(t, fs.MkdirAll(mkdir, directoryPermissions))
@@ -49,10 +33,10 @@ func TestCacheWarmFail(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, fi.Close())
 
-	// Cache warm should fail due to the unexpected EOF.
+	// Cache warm should fail due to the unexpected EOF while migrating the flat layout.
 	pr, err := newBlobPruner(fs, 0)
 	require.NoError(t, err)
-	require.ErrorIs(t, pr.warmCache(), errPruningFailures)
+	require.ErrorIs(t, pr.warmCache(), errMigrationFailures)
 
 	// The cache warm has finished, so calling waitForCache with a super short deadline
 	// should not block or hit the context deadline.
@@ -65,121 +49,78 @@ func TestCacheWarmFail(t *testing.T) {
 	require.NotNil(t, c)
 }
 
-func TestTryPruneDir_CachedExpired(t *testing.T) {
-	t.Run("empty directory", func(t *testing.T) {
-		fs := afero.NewMemMapFs()
-		pr, err := newBlobPruner(fs, 0)
-		require.NoError(t, err)
-		var slot primitives.Slot = 0
-		_, sidecars := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, slot, 1)
-		sc, err := verification.BlobSidecarNoop(sidecars[0])
-		require.NoError(t, err)
-		rootStr := rootString(sc.BlockRoot())
-		require.NoError(t, fs.Mkdir(rootStr, directoryPermissions)) // make empty directory
-		require.NoError(t, pr.cache.ensure(sc.BlockRoot(), sc.Slot(), 0))
-		pruned, err := pr.tryPruneDir(rootStr, slot+1)
-		require.NoError(t, err)
-		require.Equal(t, 0, pruned)
-	})
-	t.Run("blobs to delete", func(t *testing.T) {
-		fs, bs := NewEphemeralBlobStorageWithFs(t)
-		var slot primitives.Slot = 0
-		_, sidecars := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, slot, 2)
-		scs, err := verification.BlobSidecarSliceNoop(sidecars)
-		require.NoError(t, err)
-
-		require.NoError(t, bs.Save(scs[0]))
-		require.NoError(t, bs.Save(scs[1]))
-
-		// check that the root->slot is cached
-		root := scs[0].BlockRoot()
-		rootStr := rootString(root)
-		cs, cok := bs.pruner.cache.slot(scs[0].BlockRoot())
-		require.Equal(t, true, cok)
-		require.Equal(t, slot, cs)
-
-		// ensure that we see the saved files in the filesystem
-		files, err := listDir(fs, rootStr)
-		require.NoError(t, err)
-		require.Equal(t, 2, len(files))
-
-		pruned, err := bs.pruner.tryPruneDir(rootStr, slot+1)
-		require.NoError(t, err)
-		require.Equal(t, 2, pruned)
-		files, err = listDir(fs, rootStr)
-		require.ErrorIs(t, err, os.ErrNotExist)
-		require.Equal(t, 0, len(files))
-	})
-}
-
-func TestTryPruneDir_SlotFromFile(t *testing.T) {
-	t.Run("expired blobs deleted", func(t *testing.T) {
-		fs, bs := NewEphemeralBlobStorageWithFs(t)
-		var slot primitives.Slot = 0
+func TestWarmCache_ByEpoch(t *testing.T) {
+	fs, bs := NewEphemeralBlobStorageWithFs(t)
+	roots := make(map[[32]byte]primitives.Epoch)
+	for _, slot := range []primitives.Slot{0, 33, 34, 2000} {
 		_, sidecars := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, slot, 2)
 		scs, err := verification.BlobSidecarSliceNoop(sidecars)
 		require.NoError(t, err)
-
-		require.NoError(t, bs.Save(scs[0]))
 		require.NoError(t, bs.Save(scs[1]))
+		roots[scs[1].BlockRoot()] = slots.ToEpoch(slot)
+	}
 
-		// check that the root->slot is cached
-		root := scs[0].BlockRoot()
-		rootStr := rootString(root)
-		cs, ok := bs.pruner.cache.slot(root)
+	pr, err := newBlobPruner(fs, 0)
+	require.NoError(t, err)
+	require.NoError(t, pr.warmCache())
+	for root, epoch := range roots {
+		e, ok := pr.cache.epoch(root)
 		require.Equal(t, true, ok)
-		require.Equal(t, slot, cs)
-		// evict it from the cache so that we trigger the file read path
-		bs.pruner.cache.evict(root)
-		_, ok = bs.pruner.cache.slot(root)
-		require.Equal(t, false, ok)
-
-		// ensure that we see the saved files in the filesystem
-		files, err := listDir(fs, rootStr)
-		require.NoError(t, err)
-		require.Equal(t, 2, len(files))
+		require.Equal(t, epoch, e)
+		sum := pr.cache.Summary(root)
+		require.Equal(t, false, sum.HasIndex(0))
+		require.Equal(t, true, sum.HasIndex(1))
+	}
+	// Every root was indexed by the calls to Summary.
+	require.Equal(t, 0, len(pr.cache.unindexed))
+	require.Equal(t, float64(len(roots)), pr.cache.nBlobs)
+}
 
-		pruned, err := bs.pruner.tryPruneDir(rootStr, slot+1)
-		require.NoError(t, err)
-		require.Equal(t, 2, pruned)
-		files, err = listDir(fs, rootStr)
-		require.ErrorIs(t, err, os.ErrNotExist)
-		require.Equal(t, 0, len(files))
-	})
-	t.Run("not expired, intact", func(t *testing.T) {
-		fs, bs := NewEphemeralBlobStorageWithFs(t)
-		// Set slot equal to the window size, so it should be retained.
-		slot := bs.pruner.windowSize
-		_, sidecars := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, slot, 2)
+func TestPrune_ByEpoch(t *testing.T) {
+	fs, bs := NewEphemeralBlobStorageWithFs(t)
+	spe := params.BeaconConfig().SlotsPerEpoch
+	pruneBefore := primitives.Slot(periodEpochs+2) * spe
+	cases := []struct {
+		slot     primitives.Slot
+		retained bool
+	}{
+		{slot: 0},
+		{slot: 5},
+		{slot: spe},
+		{slot: primitives.Slot(periodEpochs) * spe},
+		{slot: pruneBefore - 1},
+		{slot: pruneBefore, retained: true},
+		{slot: pruneBefore + spe, retained: true},
+		{slot: primitives.Slot(2*periodEpochs) * spe, retained: true},
+	}
+	roots := make([][32]byte, len(cases))
+	for i, c := range cases {
+		_, sidecars := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, c.slot, 2)
 		scs, err := verification.BlobSidecarSliceNoop(sidecars)
 		require.NoError(t, err)
-
 		require.NoError(t, bs.Save(scs[0]))
 		require.NoError(t, bs.Save(scs[1]))
+		roots[i] = scs[0].BlockRoot()
+	}
 
-		// Evict slot mapping from the cache so that we trigger the file read path.
-		root := scs[0].BlockRoot()
-		rootStr := rootString(root)
-		bs.pruner.cache.evict(root)
-		_, ok := bs.pruner.cache.slot(root)
-		require.Equal(t, false, ok)
-
-		// Ensure that we see the saved files in the filesystem.
-		files, err := listDir(fs, rootStr)
-		require.NoError(t, err)
-		require.Equal(t, 2, len(files))
-
-		// This should use the slotFromFile code (simulating restart).
-		// Setting pruneBefore == slot, so that the slot will be outside the window (at the boundary).
-		pruned, err := bs.pruner.tryPruneDir(rootStr, slot)
+	// Saving a blob can trigger a prune in the background, so hold the lock like notify does.
+	bs.pruner.Lock()
+	require.NoError(t, bs.pruner.prune(pruneBefore))
+	bs.pruner.Unlock()
+	for i, c := range cases {
+		idx, err := bs.Indices(roots[i])
 		require.NoError(t, err)
-		require.Equal(t, 0, pruned)
+		require.Equal(t, c.retained, idx[0], "unexpected blob presence at slot %d", c.slot)
+		_, cached := bs.cache.epoch(roots[i])
+		require.Equal(t, c.retained, cached)
+	}
+	require.Equal(t, 3, len(listRootDirs(t, fs)))
 
-		// Ensure files are still present.
-		files, err = listDir(fs, rootStr)
-		require.NoError(t, err)
-		require.Equal(t, 2, len(files))
-	})
+	// The period directory holding only expired epochs is removed entirely.
+	periods, err := listNumberedDirs(fs, byEpochLayoutDir)
+	require.NoError(t, err)
+	sort.Slice(periods, func(i, j int) bool { return periods[i] < periods[j] })
+	require.DeepEqual(t, []primitives.Epoch{1, 2}, periods)
 }
 
 func TestSlotFromBlob(t *testing.T) {
@@ -0,0 +1,321 @@
+package filesystem
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+const (
+	// remoteUploadQueueSize bounds the number of blobs waiting in memory to be written through to the remote backend.
+	remoteUploadQueueSize = 4096
+	// remoteUploadAttempts is the number of times an upload is tried before it is left to the next journal scan.
+	remoteUploadAttempts = 5
+	// remoteUploadBackoff is the wait before the first retry of an upload, doubled on each further retry.
+	remoteUploadBackoff = time.Second
+	// remoteUploadRetryInterval is how often the journal is scanned for uploads that are not queued, because they
+	// did not fit in the queue, failed every attempt, or were left over by a previous run of the node.
+	remoteUploadRetryInterval = time.Minute
+	// remoteUploadDrainTimeout is how long the uploads still queued on shutdown are given to complete.
+	remoteUploadDrainTimeout = 10 * time.Second
+	// uploadJournalDir holds an empty marker file for every blob which has not been written to the remote backend
+	// yet, grouped by epoch like the blobs themselves:
+	//
+	//	upload-journal/<epoch>/<root>-<index>
+	uploadJournalDir = "upload-journal"
+)
+
+var errInvalidJournalEntry = errors.New("invalid upload journal entry")
+
+type remoteUpload struct {
+	root  [32]byte
+	epoch primitives.Epoch
+	idx   uint64
+}
+
+func uploadJournalEpochDir(e primitives.Epoch) string {
+	return path.Join(uploadJournalDir, strconv.FormatUint(uint64(e), 10))
+}
+
+func (up remoteUpload) journalPath() string {
+	return path.Join(uploadJournalEpochDir(up.epoch), fmt.Sprintf("%s-%d", rootString(up.root), up.idx))
+}
+
+func (up remoteUpload) blobPath() string {
+	return blobNamer{root: up.root, epoch: up.epoch, index: up.idx}.path()
+}
+
+func uploadFromJournalEntry(epoch primitives.Epoch, name string) (remoteUpload, error) {
+	parts := strings.Split(name, "-")
+	if len(parts) != 2 {
+		return remoteUpload{}, errors.Wrapf(errInvalidJournalEntry, "name=%s", name)
+	}
+	root, err := stringToRoot(parts[0])
+	if err != nil {
+		return remoteUpload{}, err
+	}
+	idx, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return remoteUpload{}, errors.Wrapf(errInvalidJournalEntry, "name=%s", name)
+	}
+	return remoteUpload{root: root, epoch: epoch, idx: idx}, nil
+}
+
+// hasPendingUploads returns true if some blobs of the given epoch have not been written to the remote backend yet.
+// The pruner keeps such epochs on local disk, since the local copy is the only one until the upload succeeds.
+func hasPendingUploads(fs afero.Fs, e primitives.Epoch) (bool, error) {
+	entries, err := listDir(fs, uploadJournalEpochDir(e))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	return len(entries) > 0, nil
+}
+
+// remoteUploader writes blobs through to the remote backend in the background, so that a slow or unavailable
+// backend does not hold up saving blobs. Each blob is recorded in a journal on local disk before it is saved, and
+// only removed from it once the upload succeeded. Uploads which do not fit in the queue or fail every attempt are
+// picked up again from the journal, and the pruner keeps the blobs of any epoch with a journal entry.
+type remoteUploader struct {
+	backend BlobBackend
+	fs      afero.Fs
+	queue   chan remoteUpload
+	queued  map[remoteUpload]bool
+	ctx     context.Context
+	cancel  context.CancelFunc
+	backoff time.Duration
+	pending sync.WaitGroup
+	done    chan struct{}
+	sync.Mutex
+	closed bool
+}
+
+func newRemoteUploader(fs afero.Fs, backend BlobBackend) *remoteUploader {
+	ctx, cancel := context.WithCancel(context.Background())
+	u := &remoteUploader{
+		backend: backend,
+		fs:      fs,
+		queue:   make(chan remoteUpload, remoteUploadQueueSize),
+		queued:  make(map[remoteUpload]bool),
+		ctx:     ctx,
+		cancel:  cancel,
+		backoff: remoteUploadBackoff,
+		done:    make(chan struct{}),
+	}
+	// Uploads left over by a previous run are queued right away.
+	u.requeue()
+	go u.run()
+	return u
+}
+
+// journal records that the blob needs to be uploaded. It is called before the blob is written to local disk, so
+// that no blob can be saved without a journal entry.
+func (u *remoteUploader) journal(up remoteUpload) error {
+	// Held so that the epoch directory is not removed as empty before the entry is written.
+	u.Lock()
+	defer u.Unlock()
+	if err := u.fs.MkdirAll(uploadJournalEpochDir(up.epoch), directoryPermissions); err != nil {
+		return err
+	}
+	return afero.WriteFile(u.fs, up.journalPath(), nil, 0600)
+}
+
+// enqueue schedules a journaled upload without blocking, returning false if the upload is not queued. Such an
+// upload stays in the journal and is queued again by the next scan.
+func (u *remoteUploader) enqueue(up remoteUpload) bool {
+	u.Lock()
+	defer u.Unlock()
+	if u.closed {
+		return false
+	}
+	if u.queued[up] {
+		return true
+	}
+	u.pending.Add(1)
+	select {
+	case u.queue <- up:
+		u.queued[up] = true
+		return true
+	default:
+		u.pending.Done()
+		return false
+	}
+}
+
+// forget removes the journal entries of a root, for blobs which were removed from local disk and must not be
+// uploaded anymore.
+func (u *remoteUploader) forget(root [32]byte, e primitives.Epoch) error {
+	u.Lock()
+	defer u.Unlock()
+	entries, err := listDir(u.fs, uploadJournalEpochDir(e))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	prefix := rootString(root) + "-"
+	for _, name := range entries {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if err := u.fs.Remove(path.Join(uploadJournalEpochDir(e), name)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return u.removeEmptyJournalDir(e)
+}
+
+// requeue queues every journaled upload, until the queue is full.
+func (u *remoteUploader) requeue() {
+	epochs, err := listNumberedDirs(u.fs, uploadJournalDir)
+	if err != nil {
+		log.WithError(err).Error("Could not list blob upload journal")
+		return
+	}
+	for _, e := range epochs {
+		entries, err := listDir(u.fs, uploadJournalEpochDir(e))
+		if err != nil {
+			log.WithError(err).WithField("epoch", e).Error("Could not list blob upload journal")
+			continue
+		}
+		for _, name := range entries {
+			up, err := uploadFromJournalEntry(e, name)
+			if err != nil {
+				log.WithError(err).WithField("epoch", e).Warn("Ignoring unexpected entry in blob upload journal")
+				continue
+			}
+			if !u.enqueue(up) {
+				return
+			}
+		}
+	}
+}
+
+func (u *remoteUploader) run() {
+	defer close(u.done)
+	ticker := time.NewTicker(remoteUploadRetryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case up := <-u.queue:
+			u.upload(up)
+			u.Lock()
+			delete(u.queued, up)
+			u.Unlock()
+			u.pending.Done()
+		case <-ticker.C:
+			u.requeue()
+		case <-u.ctx.Done():
+			return
+		}
+	}
+}
+
+// upload writes a blob to the backend, retrying with exponential backoff, and removes its journal entry once it
+// succeeded. An upload which fails every attempt stays in the journal.
+func (u *remoteUploader) upload(up remoteUpload) {
+	data, err := afero.ReadFile(u.fs, up.blobPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			// The entry is written before the blob, so a recent entry may be for a blob which is still being saved.
+			// Past that, the blob could not be saved, and there is nothing to upload.
+			if info, err := u.fs.Stat(up.journalPath()); err == nil && time.Since(info.ModTime()) < remoteUploadRetryInterval {
+				return
+			}
+			blobRemoteUploadDroppedCounter.Inc()
+			u.complete(up)
+			return
+		}
+		log.WithError(err).WithFields(uploadFields(up)).Error("Could not read blob sidecar to write to remote backend")
+		return
+	}
+	backoff := u.backoff
+	for attempt := 1; attempt <= remoteUploadAttempts; attempt++ {
+		if err = u.backend.Put(u.ctx, up.root, up.idx, data); err == nil {
+			u.complete(up)
+			return
+		}
+		blobRemoteErrorCounter.Inc()
+		if attempt == remoteUploadAttempts {
+			break
+		}
+		select {
+		case <-u.ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+	log.WithError(err).WithFields(uploadFields(up)).Warn("Failed to write blob sidecar to remote backend, will retry")
+}
+
+// complete removes the journal entry of an upload.
+func (u *remoteUploader) complete(up remoteUpload) {
+	u.Lock()
+	defer u.Unlock()
+	if err := u.fs.Remove(up.journalPath()); err != nil && !os.IsNotExist(err) {
+		log.WithError(err).WithFields(uploadFields(up)).Error("Could not remove blob upload journal entry")
+		return
+	}
+	if err := u.removeEmptyJournalDir(up.epoch); err != nil {
+		log.WithError(err).WithField("epoch", up.epoch).Error("Could not remove blob upload journal directory")
+	}
+}
+
+// removeEmptyJournalDir must be called with the lock held.
+func (u *remoteUploader) removeEmptyJournalDir(e primitives.Epoch) error {
+	pending, err := hasPendingUploads(u.fs, e)
+	if err != nil || pending {
+		return err
+	}
+	if err := u.fs.Remove(uploadJournalEpochDir(e)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func uploadFields(up remoteUpload) logrus.Fields {
+	return logrus.Fields{
+		"root":  rootString(up.root),
+		"epoch": up.epoch,
+		"index": up.idx,
+	}
+}
+
+// close stops accepting uploads and gives the queued ones a chance to complete before cancelling them. Uploads
+// which did not complete stay in the journal for the next run.
+func (u *remoteUploader) close() {
+	u.Lock()
+	if u.closed {
+		u.Unlock()
+		return
+	}
+	u.closed = true
+	u.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		u.pending.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+	case <-time.After(remoteUploadDrainTimeout):
+		log.WithField("queued", len(u.queue)).Warn("Gave up waiting for blob sidecar uploads to the remote backend")
+	}
+	u.cancel()
+	<-u.done
+}
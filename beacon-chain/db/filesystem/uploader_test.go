@@ -0,0 +1,150 @@
+package filesystem
+
+import (
+	"context"
+	"path"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/spf13/afero"
+)
+
+// flakyBackend fails the first uploads of each blob, and can be made to block until released.
+type flakyBackend struct {
+	BlobBackend
+	sync.Mutex
+	failures int
+	attempts map[uint64]int
+	stored   map[uint64][]byte
+	release  chan struct{}
+}
+
+func (b *flakyBackend) Put(ctx context.Context, _ [32]byte, idx uint64, data []byte) error {
+	if b.release != nil {
+		select {
+		case <-b.release:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	b.Lock()
+	defer b.Unlock()
+	b.attempts[idx]++
+	if b.attempts[idx] <= b.failures {
+		return errors.New("unavailable")
+	}
+	b.stored[idx] = data
+	return nil
+}
+
+func newFlakyBackend(failures int) *flakyBackend {
+	return &flakyBackend{failures: failures, attempts: make(map[uint64]int), stored: make(map[uint64][]byte)}
+}
+
+// journaledUpload writes a blob to local disk along with its journal entry, as BlobStorage.Save does.
+func journaledUpload(t *testing.T, fs afero.Fs, u *remoteUploader, epoch primitives.Epoch, idx uint64) remoteUpload {
+	up := remoteUpload{root: [32]byte{'r'}, epoch: epoch, idx: idx}
+	require.NoError(t, u.journal(up))
+	require.NoError(t, fs.MkdirAll(path.Dir(up.blobPath()), directoryPermissions))
+	require.NoError(t, afero.WriteFile(fs, up.blobPath(), []byte{byte(idx)}, 0600))
+	return up
+}
+
+func TestRemoteUploader_Retries(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	backend := newFlakyBackend(2)
+	u := newRemoteUploader(fs, backend)
+	u.backoff = time.Millisecond
+	defer u.close()
+
+	up := journaledUpload(t, fs, u, 1, 1)
+	require.Equal(t, true, u.enqueue(up))
+	u.pending.Wait()
+	backend.Lock()
+	defer backend.Unlock()
+	require.Equal(t, 3, backend.attempts[1])
+	require.DeepEqual(t, []byte{1}, backend.stored[1])
+	// The journal entry is removed along with the then empty epoch directory.
+	pending, err := hasPendingUploads(fs, 1)
+	require.NoError(t, err)
+	require.Equal(t, false, pending)
+	exists, err := afero.DirExists(fs, uploadJournalEpochDir(1))
+	require.NoError(t, err)
+	require.Equal(t, false, exists)
+}
+
+func TestRemoteUploader_KeepsFailedUploads(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	backend := newFlakyBackend(remoteUploadAttempts)
+	u := newRemoteUploader(fs, backend)
+	u.backoff = time.Millisecond
+	defer u.close()
+
+	up := journaledUpload(t, fs, u, 1, 1)
+	require.Equal(t, true, u.enqueue(up))
+	u.pending.Wait()
+	backend.Lock()
+	require.Equal(t, remoteUploadAttempts, backend.attempts[1])
+	require.Equal(t, 0, len(backend.stored))
+	backend.Unlock()
+	pending, err := hasPendingUploads(fs, 1)
+	require.NoError(t, err)
+	require.Equal(t, true, pending)
+
+	// The next scan of the journal queues the upload again, which then succeeds.
+	u.requeue()
+	u.pending.Wait()
+	backend.Lock()
+	require.DeepEqual(t, []byte{1}, backend.stored[1])
+	backend.Unlock()
+	pending, err = hasPendingUploads(fs, 1)
+	require.NoError(t, err)
+	require.Equal(t, false, pending)
+}
+
+func TestRemoteUploader_DoesNotBlock(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	backend := newFlakyBackend(0)
+	backend.release = make(chan struct{})
+	u := newRemoteUploader(fs, backend)
+
+	// One upload is held by the stalled backend, the others fill the queue.
+	notQueued := 0
+	for i := 0; i < remoteUploadQueueSize+2; i++ {
+		if !u.enqueue(remoteUpload{idx: uint64(i)}) {
+			notQueued++
+		}
+	}
+	require.Equal(t, true, notQueued >= 1)
+
+	// Once the backend recovers, closing drains the queue and stops accepting uploads.
+	close(backend.release)
+	u.close()
+	require.Equal(t, false, u.enqueue(remoteUpload{idx: 1}))
+}
+
+func TestRemoteUploader_ResumesJournal(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	backend := newFlakyBackend(0)
+	backend.release = make(chan struct{})
+	u := newRemoteUploader(fs, backend)
+	journaledUpload(t, fs, u, 2, 3)
+	// The node stops before the upload completes.
+	u.close()
+	pending, err := hasPendingUploads(fs, 2)
+	require.NoError(t, err)
+	require.Equal(t, true, pending)
+
+	// The upload is resumed from the journal by the next run.
+	close(backend.release)
+	u = newRemoteUploader(fs, backend)
+	defer u.close()
+	u.pending.Wait()
+	backend.Lock()
+	defer backend.Unlock()
+	require.DeepEqual(t, []byte{3}, backend.stored[3])
+}
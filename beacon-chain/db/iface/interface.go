@@ -6,11 +6,15 @@ package iface
 import (
 	"context"
 	"io"
+	"time"
 
 	ethpbv2 "github.com/prysmaticlabs/prysm/v5/proto/eth/v2"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/dutyhistory"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/rewardsummary"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/peerdata"
 	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
@@ -35,6 +39,7 @@ type ReadOnlyDatabase interface {
 	IsFinalizedBlock(ctx context.Context, blockRoot [32]byte) bool
 	FinalizedChildBlock(ctx context.Context, blockRoot [32]byte) (interfaces.ReadOnlySignedBeaconBlock, error)
 	HighestRootsBelowSlot(ctx context.Context, slot primitives.Slot) (primitives.Slot, [][32]byte, error)
+	EarliestAvailableSlot(ctx context.Context) (primitives.Slot, error)
 	// State related methods.
 	State(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error)
 	StateOrError(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error)
@@ -43,6 +48,9 @@ type ReadOnlyDatabase interface {
 	StateSummary(ctx context.Context, blockRoot [32]byte) (*ethpb.StateSummary, error)
 	HasStateSummary(ctx context.Context, blockRoot [32]byte) bool
 	HighestSlotStatesBelow(ctx context.Context, slot primitives.Slot) ([]state.ReadOnlyBeaconState, error)
+	HasStateDiff(ctx context.Context, slot primitives.Slot) bool
+	StateDiffInterval() primitives.Slot
+	StateDiffBaseInterval() primitives.Slot
 	// Checkpoint operations.
 	JustifiedCheckpoint(ctx context.Context) (*ethpb.Checkpoint, error)
 	FinalizedCheckpoint(ctx context.Context) (*ethpb.Checkpoint, error)
@@ -61,6 +69,18 @@ type ReadOnlyDatabase interface {
 	// light client operations
 	LightClientUpdates(ctx context.Context, startPeriod, endPeriod uint64) (map[uint64]*ethpbv2.LightClientUpdateWithVersion, error)
 	LightClientUpdate(ctx context.Context, period uint64) (*ethpbv2.LightClientUpdateWithVersion, error)
+	// Validator history operations.
+	ValidatorHistory(ctx context.Context, idx primitives.ValidatorIndex, from, to primitives.Epoch) ([]*dutyhistory.EpochRecord, error)
+	// Rewards operations.
+	AttestationRewards(ctx context.Context, epoch primitives.Epoch) ([]rewardsummary.AttestationReward, []rewardsummary.IdealAttestationReward, error)
+	SyncCommitteeRewards(ctx context.Context, epoch primitives.Epoch) (*rewardsummary.SyncCommitteeRewards, error)
+	AttestationRewardsRange(ctx context.Context) (*rewardsummary.EpochRange, error)
+	SyncCommitteeRewardsRange(ctx context.Context) (*rewardsummary.EpochRange, error)
+	// Peer records operations.
+	PeerRecords(ctx context.Context) ([]*peerdata.Record, error)
+	// Blob archive gap tracking.
+	BlobArchiveScanCursor(ctx context.Context) (primitives.Slot, uint64, error)
+	BlobArchiveGaps(ctx context.Context) ([][32]byte, error)
 
 	// origin checkpoint sync support
 	OriginCheckpointBlockRoot(ctx context.Context) ([32]byte, error)
@@ -84,6 +104,8 @@ type NoHeadAccessDatabase interface {
 	DeleteStates(ctx context.Context, blockRoots [][32]byte) error
 	SaveStateSummary(ctx context.Context, summary *ethpb.StateSummary) error
 	SaveStateSummaries(ctx context.Context, summaries []*ethpb.StateSummary) error
+	SaveStateDiff(ctx context.Context, state state.ReadOnlyBeaconState, blockRoot [32]byte) error
+	MigrateArchivedStatesToDiffs(ctx context.Context) (int, error)
 	// Checkpoint operations.
 	SaveJustifiedCheckpoint(ctx context.Context, checkpoint *ethpb.Checkpoint) error
 	SaveFinalizedCheckpoint(ctx context.Context, checkpoint *ethpb.Checkpoint) error
@@ -99,8 +121,22 @@ type NoHeadAccessDatabase interface {
 	SaveRegistrationsByValidatorIDs(ctx context.Context, ids []primitives.ValidatorIndex, regs []*ethpb.ValidatorRegistrationV1) error
 	// light client operations
 	SaveLightClientUpdate(ctx context.Context, period uint64, update *ethpbv2.LightClientUpdateWithVersion) error
+	// Validator history operations.
+	SaveValidatorHistory(ctx context.Context, epoch primitives.Epoch, records []dutyhistory.Record) error
+	DeleteValidatorHistoryBefore(ctx context.Context, epoch primitives.Epoch) error
+	// Rewards operations.
+	SaveAttestationRewards(ctx context.Context, epoch primitives.Epoch, rewards []rewardsummary.AttestationReward, ideal []rewardsummary.IdealAttestationReward) error
+	SaveSyncCommitteeRewards(ctx context.Context, epoch primitives.Epoch, rewards *rewardsummary.SyncCommitteeRewards) error
+	DeleteRewardsBefore(ctx context.Context, epoch primitives.Epoch) error
+	// Peer records operations.
+	SavePeerRecords(ctx context.Context, records []*peerdata.Record) error
+	DeletePeerRecordsBefore(ctx context.Context, cutoff time.Time) error
+	// Blob archive gap tracking.
+	SaveBlobArchiveScan(ctx context.Context, cursor primitives.Slot, blocks uint64, gaps [][32]byte) error
+	DeleteBlobArchiveGaps(ctx context.Context, roots [][32]byte) error
 
 	CleanUpDirtyStates(ctx context.Context, slotsPerArchivedPoint primitives.Slot) error
+	DeleteHistoricalDataBeforeSlot(ctx context.Context, cutoff primitives.Slot, batchSize int) (int, error)
 }
 
 // HeadAccessDatabase defines a struct with access to reading chain head data.
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"path"
 
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/engine"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v5/io/file"
@@ -63,8 +64,8 @@ func (s *Store) Backup(ctx context.Context, outputDir string, permissionOverride
 	// bucket to use less memory usage when backing up.
 	var bucketKeys [][]byte
 	bucketMap := make(map[string][][]byte)
-	err = s.db.View(func(tx *bolt.Tx) error {
-		return tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+	err = s.db.View(func(tx engine.Tx) error {
+		return tx.ForEach(func(name []byte, b engine.Bucket) error {
 			newName := make([]byte, len(name))
 			copy(newName, name)
 			bucketKeys = append(bucketKeys, newName)
@@ -96,7 +97,7 @@ func (s *Store) Backup(ctx context.Context, outputDir string, permissionOverride
 		log.Debugf("Copying bucket %s\n", k)
 		innerKeys := bucketMap[string(k)]
 		for _, ik := range innerKeys {
-			err = s.db.View(func(tx *bolt.Tx) error {
+			err = s.db.View(func(tx engine.Tx) error {
 				bkt := tx.Bucket(k)
 				return copyDB.Update(func(tx2 *bolt.Tx) error {
 					b2, err := tx2.CreateBucketIfNotExists(k)
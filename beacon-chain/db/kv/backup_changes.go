@@ -0,0 +1,258 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/engine"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+)
+
+// Kinds of logged changes, stored as the value of a change log entry.
+const (
+	// backupChangeKey is a key which was put or deleted.
+	backupChangeKey byte = iota
+	// backupChangeBucket is a bucket which was deleted, so that all of its keys have to be written again.
+	backupChangeBucket
+)
+
+// backupChangesDeleteBatchSize is the number of change log entries deleted in a single read-write transaction.
+const backupChangesDeleteBatchSize = 10000
+
+var errInvalidBackupChange = errors.New("invalid backup change log entry")
+
+// backupChanges enables the change log once a streamed backup has been taken, so that the next incremental backup
+// reads the logged keys instead of the whole database.
+//
+// While the log is enabled, every put and delete also writes a change log entry, which roughly doubles the number of
+// keys written by each transaction, and the log grows until the next streamed backup has been written. Nodes which
+// no longer take streamed backups disable the log with ResetIncrementalBackups.
+//
+// Changes are logged by generation. A streamed backup starts a new generation before it opens its snapshot, writes
+// the keys logged in the earlier generations with their values in the snapshot, and deletes these generations once
+// the archive has been written. Keys written between the start of the generation and the snapshot are written
+// again by the next backup, which is harmless as the archive holds their values rather than the changes.
+type backupChanges struct {
+	// The lock is held for reading by read-write transactions, and for writing while the log is enabled, so that
+	// no transaction which started before the log was enabled commits after it.
+	sync.RWMutex
+	enabled bool
+}
+
+// resumeBackupChanges enables the change log of a database which has been backed up before.
+func (s *Store) resumeBackupChanges() error {
+	return s.db.View(func(tx engine.Tx) error {
+		s.backupChanges.enabled = tx.Bucket(backupMetadataBucket).Get(backupLastIDKey) != nil
+		return nil
+	})
+}
+
+// enableBackupChanges logs the changes of every later read-write transaction.
+func (s *Store) enableBackupChanges() {
+	s.backupChanges.Lock()
+	defer s.backupChanges.Unlock()
+	s.backupChanges.enabled = true
+}
+
+// ResetIncrementalBackups disables the change log and deletes its entries, and forgets the previous streamed backup,
+// so that writes are no longer logged. The next streamed backup is then a full backup, which enables the log again.
+func (s *Store) ResetIncrementalBackups(ctx context.Context) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.ResetIncrementalBackups")
+	defer span.End()
+
+	s.backupLock.Lock()
+	defer s.backupLock.Unlock()
+
+	s.backupChanges.Lock()
+	s.backupChanges.enabled = false
+	s.backupChanges.Unlock()
+	if err := s.db.Update(func(tx engine.Tx) error {
+		return tx.Bucket(backupMetadataBucket).Delete(backupLastIDKey)
+	}); err != nil {
+		return err
+	}
+	// The generation is kept, so that entries left by an interrupted reset are deleted by the next backup.
+	return s.deleteBackupChanges(nil)
+}
+
+// startBackupGeneration starts a new generation of the change log, and returns the previous generation along with
+// the ID of the last streamed backup.
+func (s *Store) startBackupGeneration() (uint64, string, error) {
+	var gen uint64
+	var lastID string
+	err := s.db.Update(func(tx engine.Tx) error {
+		meta := tx.Bucket(backupMetadataBucket)
+		if enc := meta.Get(backupGenerationKey); len(enc) == 8 {
+			gen = binary.BigEndian.Uint64(enc)
+		}
+		lastID = string(meta.Get(backupLastIDKey))
+		return meta.Put(backupGenerationKey, binary.BigEndian.AppendUint64(nil, gen+1))
+	})
+	return gen, lastID, err
+}
+
+// completeBackup records the ID of a streamed backup which has been written in full, and deletes the generations of
+// the change log up to the given one, whose changes the backup holds.
+func (s *Store) completeBackup(id string, gen uint64) error {
+	if err := s.db.Update(func(tx engine.Tx) error {
+		return tx.Bucket(backupMetadataBucket).Put(backupLastIDKey, []byte(id))
+	}); err != nil {
+		return err
+	}
+	return s.deleteBackupChanges(binary.BigEndian.AppendUint64(nil, gen+1))
+}
+
+// deleteBackupChanges deletes the change log entries before end, or all of them if end is nil.
+func (s *Store) deleteBackupChanges(end []byte) error {
+	for {
+		n := 0
+		if err := s.db.Update(func(tx engine.Tx) error {
+			changes := tx.Bucket(backupChangesBucket)
+			var keys [][]byte
+			c := changes.Cursor()
+			for k, _ := c.First(); k != nil && (end == nil || bytes.Compare(k, end) < 0) && len(keys) < backupChangesDeleteBatchSize; k, _ = c.Next() {
+				keys = append(keys, bytes.Clone(k))
+			}
+			// Keys are deleted once the cursor is done, as deletes move the cursors of some engines.
+			for _, k := range keys {
+				if err := changes.Delete(k); err != nil {
+					return err
+				}
+			}
+			n = len(keys)
+			return nil
+		}); err != nil {
+			return err
+		}
+		if n < backupChangesDeleteBatchSize {
+			return nil
+		}
+	}
+}
+
+// encodeBackupChange returns the change log key of a key of a bucket, or of the bucket itself if key is nil:
+// the generation, the length prefixed bucket name and the key.
+func encodeBackupChange(gen []byte, name, key []byte) []byte {
+	enc := make([]byte, 0, len(gen)+1+len(name)+len(key))
+	enc = append(enc, gen...)
+	enc = append(enc, byte(len(name)))
+	enc = append(enc, name...)
+	return append(enc, key...)
+}
+
+func decodeBackupChange(enc []byte) (gen uint64, name, key []byte, err error) {
+	if len(enc) < 9 || len(enc) < 9+int(enc[8]) {
+		return 0, nil, nil, errors.Wrapf(errInvalidBackupChange, "length %d", len(enc))
+	}
+	gen = binary.BigEndian.Uint64(enc[:8])
+	name = enc[9 : 9+int(enc[8])]
+	if len(enc) > 9+len(name) {
+		key = enc[9+len(name):]
+	}
+	return gen, name, key, nil
+}
+
+func isBackupBucket(name []byte) bool {
+	return bytes.Equal(name, backupChangesBucket) || bytes.Equal(name, backupMetadataBucket)
+}
+
+// changeLogDB logs the keys written by read-write transactions in the current generation of the change log,
+// in the same transaction as the writes, once the change log is enabled.
+type changeLogDB struct {
+	engine.DB
+	changes *backupChanges
+}
+
+func (db *changeLogDB) Update(fn func(engine.Tx) error) error {
+	db.changes.RLock()
+	defer db.changes.RUnlock()
+	if !db.changes.enabled {
+		return db.DB.Update(fn)
+	}
+	return db.DB.Update(func(tx engine.Tx) error {
+		return fn(&changeLogTx{Tx: tx})
+	})
+}
+
+type changeLogTx struct {
+	engine.Tx
+	gen []byte
+}
+
+func (tx *changeLogTx) log(name, key []byte) error {
+	if tx.gen == nil {
+		tx.gen = make([]byte, 8)
+		copy(tx.gen, tx.Tx.Bucket(backupMetadataBucket).Get(backupGenerationKey))
+	}
+	kind := backupChangeKey
+	if key == nil {
+		kind = backupChangeBucket
+	}
+	return tx.Tx.Bucket(backupChangesBucket).Put(encodeBackupChange(tx.gen, name, key), []byte{kind})
+}
+
+func (tx *changeLogTx) wrap(name []byte, b engine.Bucket) engine.Bucket {
+	if b == nil || isBackupBucket(name) {
+		return b
+	}
+	return &changeLogBucket{Bucket: b, name: name, tx: tx}
+}
+
+func (tx *changeLogTx) Bucket(name []byte) engine.Bucket {
+	return tx.wrap(name, tx.Tx.Bucket(name))
+}
+
+func (tx *changeLogTx) CreateBucket(name []byte) (engine.Bucket, error) {
+	b, err := tx.Tx.CreateBucket(name)
+	if err != nil {
+		return nil, err
+	}
+	return tx.wrap(name, b), nil
+}
+
+func (tx *changeLogTx) CreateBucketIfNotExists(name []byte) (engine.Bucket, error) {
+	b, err := tx.Tx.CreateBucketIfNotExists(name)
+	if err != nil {
+		return nil, err
+	}
+	return tx.wrap(name, b), nil
+}
+
+func (tx *changeLogTx) DeleteBucket(name []byte) error {
+	if err := tx.Tx.DeleteBucket(name); err != nil {
+		return err
+	}
+	if isBackupBucket(name) {
+		return nil
+	}
+	return tx.log(name, nil)
+}
+
+func (tx *changeLogTx) ForEach(fn func(name []byte, b engine.Bucket) error) error {
+	return tx.Tx.ForEach(func(name []byte, b engine.Bucket) error {
+		return fn(name, tx.wrap(name, b))
+	})
+}
+
+type changeLogBucket struct {
+	engine.Bucket
+	name []byte
+	tx   *changeLogTx
+}
+
+func (b *changeLogBucket) Put(key, value []byte) error {
+	if err := b.Bucket.Put(key, value); err != nil {
+		return err
+	}
+	return b.tx.log(b.name, key)
+}
+
+func (b *changeLogBucket) Delete(key []byte) error {
+	if err := b.Bucket.Delete(key); err != nil {
+		return err
+	}
+	return b.tx.log(b.name, key)
+}
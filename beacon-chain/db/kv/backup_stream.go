@@ -0,0 +1,475 @@
+package kv
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/engine"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/sirupsen/logrus"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	backupManifestName    = "manifest.json"
+	backupBucketsDir      = "buckets"
+	backupArchiveVersion  = 1
+	backupBatchSize       = 1000
+	backupArchiveChunkLen = 16 << 20
+)
+
+const (
+	backupOpPut byte = iota
+	backupOpDelete
+	// backupOpDeleteBucket removes every key of the bucket, before the keys it holds in the backup are put again.
+	backupOpDeleteBucket
+)
+
+var (
+	// ErrBackupChain is returned when a backup archive is not applied on top of the backup it was taken after.
+	ErrBackupChain = errors.New("backup archive does not follow the previously applied backup")
+	// ErrInvalidBackupArchive is returned when a backup archive cannot be decoded.
+	ErrInvalidBackupArchive = errors.New("invalid backup archive")
+)
+
+// BackupManifest describes a streamed backup archive. A full backup has no parent, while an incremental
+// backup only holds the changes made to the database since the backup with the parent ID was taken.
+type BackupManifest struct {
+	Version  int             `json:"version"`
+	ID       string          `json:"id"`
+	Parent   string          `json:"parent,omitempty"`
+	HeadSlot primitives.Slot `json:"head_slot"`
+	Time     time.Time       `json:"time"`
+}
+
+// Incremental returns true if the archive has to be applied on top of its parent.
+func (m *BackupManifest) Incremental() bool {
+	return m.Parent != ""
+}
+
+// BackupStream writes a gzip compressed tar archive of the database to w. When incremental is set, the
+// archive only holds the keys which were added, changed or deleted since the previous streamed backup, and
+// a full backup is written if there is no previous backup to build on. Once an archive has been written in
+// full, it becomes the parent of the next incremental backup.
+func (s *Store) BackupStream(ctx context.Context, w io.Writer, incremental bool) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.BackupStream")
+	defer span.End()
+
+	s.backupLock.Lock()
+	defer s.backupLock.Unlock()
+
+	// The changes made from now on are logged, so that the next incremental backup can be built on this one.
+	s.enableBackupChanges()
+	gen, parent, err := s.startBackupGeneration()
+	if err != nil {
+		return errors.Wrap(err, "could not start backup change log generation")
+	}
+	if !incremental {
+		parent = ""
+	} else if parent == "" {
+		log.Warn("No previous streamed backup found, writing a full backup")
+	}
+
+	id, err := newBackupID()
+	if err != nil {
+		return err
+	}
+	m := &BackupManifest{
+		Version: backupArchiveVersion,
+		ID:      id,
+		Parent:  parent,
+		Time:    time.Now().UTC(),
+	}
+	gz := gzip.NewWriter(w)
+	aw := &backupArchiveWriter{tw: tar.NewWriter(gz)}
+	// The whole archive is read from a single read transaction, so that it is a point-in-time snapshot of the
+	// database. Writers are not held up by it, but bolt cannot reuse the pages freed while it is open, so the
+	// database file may grow during the backup.
+	if err := s.db.View(func(tx engine.Tx) error {
+		head, err := backupHeadBlock(ctx, tx)
+		if err != nil {
+			return err
+		}
+		m.HeadSlot = head.Block().Slot()
+		if err := aw.writeManifest(m); err != nil {
+			return err
+		}
+		if m.Incremental() {
+			return backupChangedKeys(ctx, tx, gen, aw)
+		}
+		names, err := backupBucketNames(tx)
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			aw.startBucket(name)
+			if err := backupBucket(ctx, tx.Bucket(name), aw); err != nil {
+				return errors.Wrapf(err, "could not back up bucket %s", name)
+			}
+			if err := aw.flush(true); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if err := aw.tw.Close(); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+
+	// The archive has been written in full, so it becomes the parent of the next incremental backup.
+	if err := s.completeBackup(m.ID, gen); err != nil {
+		return errors.Wrap(err, "could not record completed backup")
+	}
+	log.WithFields(logrus.Fields{
+		"id":          m.ID,
+		"parent":      m.Parent,
+		"slot":        m.HeadSlot,
+		"putKeys":     aw.puts,
+		"deletedKeys": aw.deletes,
+	}).Info("Streamed database backup")
+	return nil
+}
+
+// backupHeadBlock returns the head block as of the backup transaction.
+func backupHeadBlock(ctx context.Context, tx engine.Tx) (interfaces.ReadOnlySignedBeaconBlock, error) {
+	bkt := tx.Bucket(blocksBucket)
+	headRoot := bkt.Get(headBlockRootKey)
+	if headRoot == nil {
+		return nil, blocks.ErrNilSignedBeaconBlock
+	}
+	enc := bkt.Get(headRoot)
+	if enc == nil {
+		return nil, blocks.ErrNilSignedBeaconBlock
+	}
+	head, err := unmarshalBlock(ctx, enc)
+	if err != nil {
+		return nil, err
+	}
+	return head, blocks.BeaconBlockIsNil(head)
+}
+
+// backupBucketNames returns the names of the buckets in the database which are backed up, in order.
+func backupBucketNames(tx engine.Tx) ([][]byte, error) {
+	var names [][]byte
+	if err := tx.ForEach(func(name []byte, _ engine.Bucket) error {
+		if !isBackupBucket(name) {
+			names = append(names, bytes.Clone(name))
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// backupBucket writes every key of a bucket to the archive.
+func backupBucket(ctx context.Context, b engine.Bucket, aw *backupArchiveWriter) error {
+	if b == nil {
+		return nil
+	}
+	n := 0
+	c := b.Cursor()
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		// Nested buckets are not used by the beacon database, and have a nil value.
+		if v == nil {
+			continue
+		}
+		aw.put(k, v)
+		n++
+		if n%backupBatchSize != 0 {
+			continue
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := aw.flush(false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// backupChangedKeys writes the keys logged in the generations of the change log up to gen, with their values in
+// the snapshot of tx, or as deleted if they are no longer in the snapshot. A key logged in several generations is
+// written more than once, which is harmless as it is written with the same value.
+func backupChangedKeys(ctx context.Context, tx engine.Tx, gen uint64, aw *backupArchiveWriter) error {
+	var current []byte
+	var b engine.Bucket
+	n := 0
+	c := tx.Bucket(backupChangesBucket).Cursor()
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		g, name, key, err := decodeBackupChange(k)
+		if err != nil {
+			return err
+		}
+		if g > gen {
+			break
+		}
+		if current == nil || !bytes.Equal(name, current) {
+			if err := aw.flush(true); err != nil {
+				return err
+			}
+			current = bytes.Clone(name)
+			aw.startBucket(current)
+			b = tx.Bucket(current)
+		}
+		if len(v) == 1 && v[0] == backupChangeBucket {
+			aw.deleteBucket()
+			if err := backupBucket(ctx, b, aw); err != nil {
+				return errors.Wrapf(err, "could not back up bucket %s", name)
+			}
+			continue
+		}
+		var value []byte
+		if b != nil {
+			value = b.Get(key)
+		}
+		if value != nil {
+			aw.put(key, value)
+		} else {
+			aw.delete(key)
+		}
+		n++
+		if n%backupBatchSize != 0 {
+			continue
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := aw.flush(false); err != nil {
+			return err
+		}
+	}
+	return aw.flush(true)
+}
+
+func newBackupID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "could not generate backup id")
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// backupArchiveWriter buffers the records of a bucket, and writes them to the tar archive in chunks.
+// Each record is an operation byte followed by the length prefixed key, and the length prefixed value of a put.
+type backupArchiveWriter struct {
+	tw      *tar.Writer
+	bucket  []byte
+	chunk   int
+	buf     bytes.Buffer
+	puts    uint64
+	deletes uint64
+}
+
+func (a *backupArchiveWriter) writeManifest(m *BackupManifest) error {
+	enc, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return a.writeEntry(backupManifestName, enc)
+}
+
+// startBucket starts writing the records of a bucket. The records of a bucket may be split across several runs,
+// so the chunks are numbered across the whole archive to keep the names of the entries unique.
+func (a *backupArchiveWriter) startBucket(name []byte) {
+	a.bucket = name
+	a.buf.Reset()
+}
+
+func (a *backupArchiveWriter) put(k, v []byte) {
+	a.buf.WriteByte(backupOpPut)
+	a.writeBytes(k)
+	a.writeBytes(v)
+	a.puts++
+}
+
+func (a *backupArchiveWriter) deleteBucket() {
+	a.buf.WriteByte(backupOpDeleteBucket)
+}
+
+func (a *backupArchiveWriter) delete(k []byte) {
+	a.buf.WriteByte(backupOpDelete)
+	a.writeBytes(k)
+	a.deletes++
+}
+
+func (a *backupArchiveWriter) writeBytes(b []byte) {
+	a.buf.Write(binary.AppendUvarint(nil, uint64(len(b))))
+	a.buf.Write(b)
+}
+
+// flush writes the buffered records of the bucket as a tar entry once the buffer is large enough, or always
+// when force is set and there are records to write.
+func (a *backupArchiveWriter) flush(force bool) error {
+	if a.buf.Len() == 0 || (!force && a.buf.Len() < backupArchiveChunkLen) {
+		return nil
+	}
+	name := path.Join(backupBucketsDir, hex.EncodeToString(a.bucket), fmt.Sprintf("%08d", a.chunk))
+	if err := a.writeEntry(name, a.buf.Bytes()); err != nil {
+		return err
+	}
+	a.chunk++
+	a.buf.Reset()
+	return nil
+}
+
+func (a *backupArchiveWriter) writeEntry(name string, data []byte) error {
+	hdr := &tar.Header{
+		Name:     name,
+		Mode:     int64(params.BeaconIoConfig().ReadWritePermissions),
+		Size:     int64(len(data)),
+		Typeflag: tar.TypeReg,
+	}
+	if err := a.tw.WriteHeader(hdr); err != nil {
+		return errors.Wrapf(err, "could not write archive entry %s", name)
+	}
+	if _, err := a.tw.Write(data); err != nil {
+		return errors.Wrapf(err, "could not write archive entry %s", name)
+	}
+	return nil
+}
+
+// ApplyBackupArchive applies a streamed backup archive read from r to the database file at dbFile, which is
+// created if it does not exist. The parent of the archive must be the given ID, so that a chain of incremental
+// backups can only be applied in order on top of a full backup, which has an empty parent.
+func ApplyBackupArchive(ctx context.Context, dbFile string, r io.Reader, parent string) (*BackupManifest, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidBackupArchive, err.Error())
+	}
+	tr := tar.NewReader(gz)
+	hdr, err := tr.Next()
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidBackupArchive, err.Error())
+	}
+	if hdr.Name != backupManifestName {
+		return nil, errors.Wrapf(ErrInvalidBackupArchive, "first entry is %s, expected %s", hdr.Name, backupManifestName)
+	}
+	m := &BackupManifest{}
+	if err := json.NewDecoder(tr).Decode(m); err != nil {
+		return nil, errors.Wrapf(ErrInvalidBackupArchive, "could not decode manifest: %v", err)
+	}
+	if m.Version != backupArchiveVersion {
+		return nil, errors.Wrapf(ErrInvalidBackupArchive, "unsupported version %d", m.Version)
+	}
+	if m.Parent != parent {
+		return nil, errors.Wrapf(ErrBackupChain, "backup %s has parent %q, expected %q", m.ID, m.Parent, parent)
+	}
+
+	d, err := bolt.Open(
+		dbFile,
+		params.BeaconIoConfig().ReadWritePermissions,
+		&bolt.Options{NoSync: true, Timeout: params.BeaconIoConfig().BoltTimeout, FreelistType: bolt.FreelistMapType},
+	)
+	if err != nil {
+		return nil, err
+	}
+	d.AllocSize = boltAllocSize
+	defer func() {
+		if err := d.Close(); err != nil {
+			log.WithError(err).Error("Failed to close restored database")
+		}
+	}()
+	for {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidBackupArchive, err.Error())
+		}
+		dir, _ := path.Split(hdr.Name)
+		bucket, err := hex.DecodeString(strings.TrimSuffix(strings.TrimPrefix(dir, backupBucketsDir+"/"), "/"))
+		if err != nil || !strings.HasPrefix(dir, backupBucketsDir+"/") || len(bucket) == 0 {
+			return nil, errors.Wrapf(ErrInvalidBackupArchive, "unexpected entry %s", hdr.Name)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidBackupArchive, err.Error())
+		}
+		if err := d.Update(func(tx *bolt.Tx) error {
+			return applyBackupRecords(tx, bucket, data)
+		}); err != nil {
+			return nil, errors.Wrapf(err, "could not apply archive entry %s", hdr.Name)
+		}
+	}
+	if err := d.Sync(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func applyBackupRecords(tx *bolt.Tx, bucket, data []byte) error {
+	b, err := tx.CreateBucketIfNotExists(bucket)
+	if err != nil {
+		return err
+	}
+	readBytes := func() ([]byte, error) {
+		n, l := binary.Uvarint(data)
+		if l <= 0 || uint64(len(data)-l) < n {
+			return nil, errors.Wrap(ErrInvalidBackupArchive, "truncated record")
+		}
+		v := data[l : l+int(n)]
+		data = data[l+int(n):]
+		return v, nil
+	}
+	for len(data) > 0 {
+		op := data[0]
+		data = data[1:]
+		if op == backupOpDeleteBucket {
+			if err := tx.DeleteBucket(bucket); err != nil {
+				return err
+			}
+			if b, err = tx.CreateBucket(bucket); err != nil {
+				return err
+			}
+			continue
+		}
+		k, err := readBytes()
+		if err != nil {
+			return err
+		}
+		switch op {
+		case backupOpPut:
+			v, err := readBytes()
+			if err != nil {
+				return err
+			}
+			if err := b.Put(k, v); err != nil {
+				return err
+			}
+		case backupOpDelete:
+			if err := b.Delete(k); err != nil {
+				return err
+			}
+		default:
+			return errors.Wrapf(ErrInvalidBackupArchive, "unknown record operation %d", op)
+		}
+	}
+	return nil
+}
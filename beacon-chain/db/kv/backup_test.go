@@ -1,13 +1,17 @@
 package kv
 
 import (
+	"bytes"
 	"context"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/engine"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 	"github.com/prysmaticlabs/prysm/v5/testing/util"
 )
@@ -108,3 +112,270 @@ func TestStore_BackupMultipleBuckets(t *testing.T) {
 		require.Equal(t, nState.Slot(), i)
 	}
 }
+
+func TestStore_BackupStream_Incremental(t *testing.T) {
+	db, err := NewKVStore(context.Background(), t.TempDir())
+	require.NoError(t, err, "Failed to instantiate DB")
+	ctx := context.Background()
+
+	saveBlock := func(slot primitives.Slot) [32]byte {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = slot
+		wsb, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		require.NoError(t, db.SaveBlock(ctx, wsb))
+		root, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: slot, Root: root[:]}))
+		return root
+	}
+	var roots [][32]byte
+	for i := primitives.Slot(1); i <= 10; i++ {
+		roots = append(roots, saveBlock(i))
+	}
+	require.NoError(t, db.SaveHeadBlockRoot(ctx, roots[9]))
+
+	// Without a previous backup, an incremental backup is a full backup.
+	full := &bytes.Buffer{}
+	require.NoError(t, db.BackupStream(ctx, full, true))
+
+	newRoot := saveBlock(11)
+	require.NoError(t, db.SaveHeadBlockRoot(ctx, newRoot))
+	require.NoError(t, db.db.Update(func(tx engine.Tx) error {
+		return tx.Bucket(blocksBucket).Delete(roots[0][:])
+	}))
+	incremental := &bytes.Buffer{}
+	require.NoError(t, db.BackupStream(ctx, incremental, true))
+	require.Equal(t, true, incremental.Len() < full.Len())
+	require.NoError(t, db.Close(), "Failed to close database")
+
+	dir := t.TempDir()
+	dbFile := filepath.Join(dir, DatabaseFileName)
+	_, err = ApplyBackupArchive(ctx, dbFile, bytes.NewReader(incremental.Bytes()), "")
+	require.ErrorIs(t, err, ErrBackupChain)
+	fm, err := ApplyBackupArchive(ctx, dbFile, bytes.NewReader(full.Bytes()), "")
+	require.NoError(t, err)
+	require.Equal(t, false, fm.Incremental())
+	require.Equal(t, primitives.Slot(10), fm.HeadSlot)
+	im, err := ApplyBackupArchive(ctx, dbFile, bytes.NewReader(incremental.Bytes()), fm.ID)
+	require.NoError(t, err)
+	require.Equal(t, fm.ID, im.Parent)
+	_, err = ApplyBackupArchive(ctx, dbFile, bytes.NewReader([]byte("not an archive")), im.ID)
+	require.ErrorIs(t, err, ErrInvalidBackupArchive)
+
+	restored, err := NewKVStore(ctx, dir)
+	require.NoError(t, err, "Failed to instantiate DB")
+	t.Cleanup(func() {
+		require.NoError(t, restored.Close(), "Failed to close database")
+	})
+	head, err := restored.HeadBlock(ctx)
+	require.NoError(t, err)
+	require.Equal(t, primitives.Slot(11), head.Block().Slot())
+	require.Equal(t, false, restored.HasBlock(ctx, roots[0]))
+	for _, r := range roots[1:] {
+		require.Equal(t, true, restored.HasBlock(ctx, r))
+	}
+}
+
+// writeHook calls fn before the first write to the underlying writer.
+type writeHook struct {
+	bytes.Buffer
+	fn func()
+}
+
+func (w *writeHook) Write(p []byte) (int, error) {
+	if w.fn != nil {
+		w.fn()
+		w.fn = nil
+	}
+	return w.Buffer.Write(p)
+}
+
+func TestStore_BackupStream_Snapshot(t *testing.T) {
+	db, err := NewKVStore(context.Background(), t.TempDir())
+	require.NoError(t, err, "Failed to instantiate DB")
+	ctx := context.Background()
+
+	saveBlock := func(slot primitives.Slot) [32]byte {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = slot
+		wsb, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		require.NoError(t, db.SaveBlock(ctx, wsb))
+		root, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: slot, Root: root[:]}))
+		return root
+	}
+	root := saveBlock(1)
+	require.NoError(t, db.SaveHeadBlockRoot(ctx, root))
+
+	// Blocks saved while the backup is being written are not part of it.
+	var laterRoot [32]byte
+	w := &writeHook{fn: func() {
+		laterRoot = saveBlock(2)
+		require.NoError(t, db.SaveHeadBlockRoot(ctx, laterRoot))
+	}}
+	require.NoError(t, db.BackupStream(ctx, w, false))
+	require.Equal(t, true, db.HasBlock(ctx, laterRoot))
+	require.NoError(t, db.Close(), "Failed to close database")
+
+	dir := t.TempDir()
+	m, err := ApplyBackupArchive(ctx, filepath.Join(dir, DatabaseFileName), bytes.NewReader(w.Bytes()), "")
+	require.NoError(t, err)
+	require.Equal(t, primitives.Slot(1), m.HeadSlot)
+	restored, err := NewKVStore(ctx, dir)
+	require.NoError(t, err, "Failed to instantiate DB")
+	t.Cleanup(func() {
+		require.NoError(t, restored.Close(), "Failed to close database")
+	})
+	head, err := restored.HeadBlock(ctx)
+	require.NoError(t, err)
+	require.Equal(t, primitives.Slot(1), head.Block().Slot())
+	require.Equal(t, false, restored.HasBlock(ctx, laterRoot))
+}
+
+// failingWriter fails every write.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestStore_BackupStream_ChangeLog(t *testing.T) {
+	db, err := NewKVStore(context.Background(), t.TempDir())
+	require.NoError(t, err, "Failed to instantiate DB")
+	ctx := context.Background()
+
+	b := util.NewBeaconBlock()
+	b.Block.Slot = 1
+	wsb, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	require.NoError(t, db.SaveBlock(ctx, wsb))
+	root, err := b.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: 1, Root: root[:]}))
+	require.NoError(t, db.SaveHeadBlockRoot(ctx, root))
+	put := func(bucket []byte, k, v string) {
+		require.NoError(t, db.db.Update(func(tx engine.Tx) error {
+			return tx.Bucket(bucket).Put([]byte(k), []byte(v))
+		}))
+	}
+	put(peerRecordsBucket, "a", "1")
+	put(peerRecordsBucket, "b", "2")
+	put(validatorHistoryBucket, "k", "aaaa")
+
+	full := &bytes.Buffer{}
+	require.NoError(t, db.BackupStream(ctx, full, true))
+	changes := func() int {
+		n := 0
+		require.NoError(t, db.db.View(func(tx engine.Tx) error {
+			return tx.Bucket(backupChangesBucket).ForEach(func(_, _ []byte) error {
+				n++
+				return nil
+			})
+		}))
+		return n
+	}
+	require.Equal(t, 0, changes())
+
+	// A value of the same length is written in full rather than compared by digest, and a deleted bucket is
+	// written again with the keys it holds at the time of the backup.
+	put(validatorHistoryBucket, "k", "bbbb")
+	require.NoError(t, db.db.Update(func(tx engine.Tx) error {
+		if err := tx.DeleteBucket(peerRecordsBucket); err != nil {
+			return err
+		}
+		b, err := tx.CreateBucket(peerRecordsBucket)
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte("c"), []byte("3"))
+	}))
+	// The changes of a failed backup are kept for the next one.
+	require.NotNil(t, db.BackupStream(ctx, failingWriter{}, true))
+	require.NotEqual(t, 0, changes())
+	incremental := &bytes.Buffer{}
+	require.NoError(t, db.BackupStream(ctx, incremental, true))
+	require.Equal(t, 0, changes())
+	require.NoError(t, db.Close(), "Failed to close database")
+
+	dir := t.TempDir()
+	dbFile := filepath.Join(dir, DatabaseFileName)
+	fm, err := ApplyBackupArchive(ctx, dbFile, bytes.NewReader(full.Bytes()), "")
+	require.NoError(t, err)
+	_, err = ApplyBackupArchive(ctx, dbFile, bytes.NewReader(incremental.Bytes()), fm.ID)
+	require.NoError(t, err)
+	restored, err := NewKVStore(ctx, dir)
+	require.NoError(t, err, "Failed to instantiate DB")
+	t.Cleanup(func() {
+		require.NoError(t, restored.Close(), "Failed to close database")
+	})
+	require.NoError(t, restored.db.View(func(tx engine.Tx) error {
+		require.DeepEqual(t, []byte("bbbb"), tx.Bucket(validatorHistoryBucket).Get([]byte("k")))
+		peers := tx.Bucket(peerRecordsBucket)
+		require.Equal(t, true, peers.Get([]byte("a")) == nil)
+		require.Equal(t, true, peers.Get([]byte("b")) == nil)
+		require.DeepEqual(t, []byte("3"), peers.Get([]byte("c")))
+		return nil
+	}))
+}
+
+func TestStore_ResetIncrementalBackups(t *testing.T) {
+	dir := t.TempDir()
+	db, err := NewKVStore(context.Background(), dir)
+	require.NoError(t, err, "Failed to instantiate DB")
+	ctx := context.Background()
+
+	b := util.NewBeaconBlock()
+	b.Block.Slot = 1
+	wsb, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	require.NoError(t, db.SaveBlock(ctx, wsb))
+	root, err := b.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: 1, Root: root[:]}))
+	require.NoError(t, db.SaveHeadBlockRoot(ctx, root))
+	put := func(db *Store, k string) {
+		require.NoError(t, db.db.Update(func(tx engine.Tx) error {
+			return tx.Bucket(peerRecordsBucket).Put([]byte(k), []byte("v"))
+		}))
+	}
+	changes := func(db *Store) int {
+		n := 0
+		require.NoError(t, db.db.View(func(tx engine.Tx) error {
+			return tx.Bucket(backupChangesBucket).ForEach(func(_, _ []byte) error {
+				n++
+				return nil
+			})
+		}))
+		return n
+	}
+
+	require.NoError(t, db.BackupStream(ctx, &bytes.Buffer{}, true))
+	put(db, "a")
+	require.Equal(t, 1, changes(db))
+
+	// Writes are no longer logged after a reset, including after a restart.
+	require.NoError(t, db.ResetIncrementalBackups(ctx))
+	require.Equal(t, 0, changes(db))
+	put(db, "b")
+	require.Equal(t, 0, changes(db))
+	require.NoError(t, db.Close(), "Failed to close database")
+	db, err = NewKVStore(ctx, dir)
+	require.NoError(t, err, "Failed to instantiate DB")
+	t.Cleanup(func() {
+		require.NoError(t, db.Close(), "Failed to close database")
+	})
+	put(db, "c")
+	require.Equal(t, 0, changes(db))
+
+	// The next backup is a full backup, and logs the later writes again.
+	full := &bytes.Buffer{}
+	require.NoError(t, db.BackupStream(ctx, full, true))
+	fm, err := ApplyBackupArchive(ctx, filepath.Join(t.TempDir(), DatabaseFileName), bytes.NewReader(full.Bytes()), "")
+	require.NoError(t, err)
+	require.Equal(t, false, fm.Incremental())
+	put(db, "d")
+	require.Equal(t, 1, changes(db))
+}
@@ -0,0 +1,83 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/engine"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+)
+
+// BlobArchiveScanCursor returns the slot below which every finalized block has been checked for missing blobs by the
+// blob archive gap scan, along with the number of those blocks which have blob commitments. Zero values are returned
+// if no scan has been saved yet.
+func (s *Store) BlobArchiveScanCursor(ctx context.Context) (primitives.Slot, uint64, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.BlobArchiveScanCursor")
+	defer span.End()
+	var (
+		cursor primitives.Slot
+		blocks uint64
+	)
+	err := s.db.View(func(tx engine.Tx) error {
+		enc := tx.Bucket(chainMetadataBucket).Get(blobArchiveScanCursorKey)
+		if len(enc) == 0 {
+			return nil
+		}
+		if len(enc) != 16 {
+			return errors.Errorf("invalid blob archive scan cursor length %d", len(enc))
+		}
+		cursor = bytesutil.BytesToSlotBigEndian(enc[:8])
+		blocks = bytesutil.BytesToUint64BigEndian(enc[8:])
+		return nil
+	})
+	return cursor, blocks, err
+}
+
+// BlobArchiveGaps returns the roots of the blocks below the blob archive scan cursor which are still missing some
+// of their blobs.
+func (s *Store) BlobArchiveGaps(ctx context.Context) ([][32]byte, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.BlobArchiveGaps")
+	defer span.End()
+	var roots [][32]byte
+	err := s.db.View(func(tx engine.Tx) error {
+		return tx.Bucket(blobArchiveGapsBucket).ForEach(func(k, _ []byte) error {
+			roots = append(roots, bytesutil.ToBytes32(k))
+			return nil
+		})
+	})
+	return roots, err
+}
+
+// SaveBlobArchiveScan moves the blob archive scan cursor to the given slot, along with the number of blocks with
+// blob commitments below it, and adds the blocks found to be missing blobs to the gap index.
+func (s *Store) SaveBlobArchiveScan(ctx context.Context, cursor primitives.Slot, blocks uint64, gaps [][32]byte) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveBlobArchiveScan")
+	defer span.End()
+	return s.db.Update(func(tx engine.Tx) error {
+		bkt := tx.Bucket(blobArchiveGapsBucket)
+		for _, r := range gaps {
+			if err := bkt.Put(r[:], []byte{}); err != nil {
+				return err
+			}
+		}
+		enc := append(bytesutil.SlotToBytesBigEndian(cursor), bytesutil.Uint64ToBytesBigEndian(blocks)...)
+		return tx.Bucket(chainMetadataBucket).Put(blobArchiveScanCursorKey, enc)
+	})
+}
+
+// DeleteBlobArchiveGaps removes blocks which are no longer missing blobs from the gap index.
+func (s *Store) DeleteBlobArchiveGaps(ctx context.Context, roots [][32]byte) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.DeleteBlobArchiveGaps")
+	defer span.End()
+	return s.db.Update(func(tx engine.Tx) error {
+		bkt := tx.Bucket(blobArchiveGapsBucket)
+		for _, r := range roots {
+			if err := bkt.Delete(r[:]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,35 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestStore_BlobArchiveGaps(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	cursor, blocks, err := db.BlobArchiveScanCursor(ctx)
+	require.NoError(t, err)
+	require.Equal(t, primitives.Slot(0), cursor)
+	require.Equal(t, uint64(0), blocks)
+
+	a, b, c := [32]byte{'a'}, [32]byte{'b'}, [32]byte{'c'}
+	require.NoError(t, db.SaveBlobArchiveScan(ctx, 64, 10, [][32]byte{a, b}))
+	require.NoError(t, db.SaveBlobArchiveScan(ctx, 128, 20, [][32]byte{c}))
+	cursor, blocks, err = db.BlobArchiveScanCursor(ctx)
+	require.NoError(t, err)
+	require.Equal(t, primitives.Slot(128), cursor)
+	require.Equal(t, uint64(20), blocks)
+	gaps, err := db.BlobArchiveGaps(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, [][32]byte{a, b, c}, gaps)
+
+	require.NoError(t, db.DeleteBlobArchiveGaps(ctx, [][32]byte{a, c}))
+	gaps, err = db.BlobArchiveGaps(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, [][32]byte{b}, gaps)
+}
@@ -0,0 +1,60 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/engine"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+)
+
+// convertBatchSize is the number of keys copied in a single transaction while converting a database.
+const convertBatchSize = 10000
+
+// ConvertEngine copies the database in srcDir into a new database in dstDir, stored in the named engine, and returns
+// the number of keys copied. The source database is not modified, and must not be in use. Converting a bolt database
+// into a new bolt database also compacts it, as the copy does not carry over the free pages of the source.
+func ConvertEngine(ctx context.Context, srcDir, dstDir, name string) (uint64, error) {
+	if err := engine.Validate(name); err != nil {
+		return 0, err
+	}
+	srcEngine, err := DatabaseEngine(srcDir)
+	if err != nil {
+		return 0, err
+	}
+	if srcEngine == "" {
+		return 0, fmt.Errorf("no database found in %s", srcDir)
+	}
+	dstEngine, err := DatabaseEngine(dstDir)
+	if err != nil {
+		return 0, err
+	}
+	if dstEngine != "" {
+		return 0, fmt.Errorf("%s already holds a %s database", dstDir, dstEngine)
+	}
+	if err := file.MkdirAll(dstDir); err != nil {
+		return 0, err
+	}
+
+	src, err := openEngine(srcDir, srcEngine)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not open source database")
+	}
+	defer func() {
+		if err := src.Close(); err != nil {
+			log.WithError(err).Error("Could not close source database")
+		}
+	}()
+	dst, err := openEngine(dstDir, name)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not open destination database")
+	}
+	defer func() {
+		if err := dst.Close(); err != nil {
+			log.WithError(err).Error("Could not close destination database")
+		}
+	}()
+	log.WithField("from", srcEngine).WithField("to", name).Info("Converting database")
+	return engine.Copy(ctx, src, dst, convertBatchSize)
+}
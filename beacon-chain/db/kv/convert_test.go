@@ -0,0 +1,61 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/engine"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestConvertEngine(t *testing.T) {
+	ctx := context.Background()
+	for _, tt := range []struct{ from, to string }{
+		{from: engine.Bolt, to: engine.Pebble},
+		{from: engine.Pebble, to: engine.Bolt},
+		{from: engine.Bolt, to: engine.Bolt},
+	} {
+		t.Run(tt.from+" to "+tt.to, func(t *testing.T) {
+			srcDir, dstDir := t.TempDir(), t.TempDir()
+			src, err := NewKVStore(ctx, srcDir, WithEngine(tt.from))
+			require.NoError(t, err)
+			b := util.NewBeaconBlock()
+			b.Block.Slot = 10
+			wsb, err := blocks.NewSignedBeaconBlock(b)
+			require.NoError(t, err)
+			require.NoError(t, src.SaveBlock(ctx, wsb))
+			root, err := wsb.Block().HashTreeRoot()
+			require.NoError(t, err)
+			require.NoError(t, src.Close())
+
+			n, err := ConvertEngine(ctx, srcDir, dstDir, tt.to)
+			require.NoError(t, err)
+			assert.NotEqual(t, uint64(0), n)
+			_, err = ConvertEngine(ctx, srcDir, dstDir, tt.to)
+			require.ErrorContains(t, "already holds", err)
+
+			e, err := DatabaseEngine(dstDir)
+			require.NoError(t, err)
+			assert.Equal(t, tt.to, e)
+			dst, err := NewKVStore(ctx, dstDir)
+			require.NoError(t, err)
+			defer func() {
+				require.NoError(t, dst.Close())
+			}()
+			assert.Equal(t, tt.to, dst.Engine())
+			got, err := dst.Block(ctx, root)
+			require.NoError(t, err)
+			assert.Equal(t, wsb.Block().Slot(), got.Block().Slot())
+		})
+	}
+}
+
+func TestConvertEngine_NoDatabase(t *testing.T) {
+	_, err := ConvertEngine(context.Background(), t.TempDir(), t.TempDir(), engine.Pebble)
+	require.ErrorContains(t, "no database found", err)
+	_, err = ConvertEngine(context.Background(), t.TempDir(), t.TempDir(), "leveldb")
+	require.ErrorIs(t, err, engine.ErrUnknownEngine)
+}
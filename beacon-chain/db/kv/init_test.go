@@ -1,10 +1,12 @@
 package kv
 
 import (
+	"flag"
 	"io"
 	"os"
 	"testing"
 
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/engine"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/sirupsen/logrus"
 )
@@ -16,7 +18,16 @@ func init() {
 	}
 }
 
+// dbEngine runs the suite against another engine, so that the package behaves the same whatever engine the
+// database is stored in. For example: go test ./beacon-chain/db/kv -args -db-engine=pebble
+var dbEngine = flag.String("db-engine", engine.Bolt, "The engine of the databases created by the tests")
+
 func TestMain(m *testing.M) {
+	flag.Parse()
+	if err := engine.Validate(*dbEngine); err != nil {
+		panic(err)
+	}
+	defaultEngine = *dbEngine
 	logrus.SetLevel(logrus.DebugLevel)
 	logrus.SetOutput(io.Discard)
 	os.Exit(m.Run())
@@ -0,0 +1,613 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/engine"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/sirupsen/logrus"
+)
+
+// Names of the integrity checks performed by VerifyIntegrity.
+const (
+	CheckBlockDecode          = "block-decode"
+	CheckBlockSlotIndex       = "block-slot-index"
+	CheckBlockParentIndex     = "block-parent-index"
+	CheckSlotIndexBlock       = "slot-index-block"
+	CheckParentIndexBlock     = "parent-index-block"
+	CheckStateSummaryBlock    = "state-summary-block"
+	CheckStateDecode          = "state-decode"
+	CheckStateSlot            = "state-slot"
+	CheckFinalizedIndexBlock  = "finalized-index-block"
+	CheckFinalizedIndexLink   = "finalized-index-link"
+	CheckFinalizedIndexTip    = "finalized-index-checkpoint"
+	CheckFinalizedIndexDecode = "finalized-index-decode"
+)
+
+// maxReportedIssues limits the number of issues kept in an integrity report, so that a badly damaged database
+// does not exhaust memory. Issues past the limit are only counted.
+const maxReportedIssues = 1000
+
+// integrityBatchSize is the number of keys read in each read transaction while checking a bucket.
+const integrityBatchSize = 1000
+
+// IntegrityIssue is a single violation of an invariant between the buckets of the database.
+type IntegrityIssue struct {
+	Check  string
+	Key    []byte
+	Detail string
+}
+
+func (i IntegrityIssue) String() string {
+	return fmt.Sprintf("%s: key=%#x %s", i.Check, i.Key, i.Detail)
+}
+
+// IntegrityReport holds the result of VerifyIntegrity.
+type IntegrityReport struct {
+	// Counts holds the number of issues found by each check.
+	Counts map[string]int
+	// Issues holds the first issues found, up to a limit.
+	Issues    []IntegrityIssue
+	Blocks    int
+	States    int
+	Summaries int
+}
+
+// OK returns true if no issues were found.
+func (r *IntegrityReport) OK() bool {
+	return r.Total() == 0
+}
+
+// Total returns the number of issues found.
+func (r *IntegrityReport) Total() int {
+	n := 0
+	for _, c := range r.Counts {
+		n += c
+	}
+	return n
+}
+
+// Checks returns the names of the checks which found issues, in order.
+func (r *IntegrityReport) Checks() []string {
+	names := make([]string, 0, len(r.Counts))
+	for k := range r.Counts {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Add records an issue found by the named check.
+func (r *IntegrityReport) Add(check string, key []byte, format string, args ...interface{}) {
+	r.Counts[check]++
+	if len(r.Issues) < maxReportedIssues {
+		r.Issues = append(r.Issues, IntegrityIssue{Check: check, Key: bytes.Clone(key), Detail: fmt.Sprintf(format, args...)})
+	}
+}
+
+// VerifyIntegrity walks the blocks, state summary, state and index buckets and checks the invariants between
+// them: every block is present in the slot and parent root indices, every root in those indices and every
+// state summary refers to a stored block, every state decodes at the slot of its block, and the finalized block
+// roots index forms a chain linking each parent to its child up to the finalized checkpoint.
+// Problems with the data are returned in the report, while the error is only set if the walk itself failed.
+func (s *Store) VerifyIntegrity(ctx context.Context) (*IntegrityReport, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.VerifyIntegrity")
+	defer span.End()
+
+	// State summaries are cached before they are written, so the cache is flushed to check them all.
+	if err := s.saveCachedStateSummariesDB(ctx); err != nil {
+		return nil, err
+	}
+	r := &IntegrityReport{Counts: make(map[string]int)}
+	steps := []struct {
+		name string
+		fn   func(context.Context, *IntegrityReport) error
+	}{
+		{"blocks", s.verifyBlocks},
+		{"block indices", s.verifyBlockIndices},
+		{"state summaries", s.verifyStateSummaries},
+		{"states", s.verifyStates},
+		{"finalized block roots index", s.verifyFinalizedIndex},
+	}
+	for _, step := range steps {
+		log.WithField("step", step.name).Info("Verifying database")
+		if err := step.fn(ctx, r); err != nil {
+			return nil, errors.Wrapf(err, "could not verify %s", step.name)
+		}
+	}
+	return r, nil
+}
+
+// isBlockRootKey returns false for the keys of the blocks bucket which hold metadata rather than a block.
+func isBlockRootKey(k []byte) bool {
+	return len(k) == 32
+}
+
+func (s *Store) verifyBlocks(ctx context.Context, r *IntegrityReport) error {
+	return s.forEachBatched(ctx, blocksBucket, func(tx engine.Tx, k, v []byte) error {
+		if !isBlockRootKey(k) {
+			return nil
+		}
+		r.Blocks++
+		blk, err := unmarshalBlock(ctx, v)
+		if err != nil {
+			r.Add(CheckBlockDecode, k, "could not decode block: %v", err)
+			return nil
+		}
+		slot := blk.Block().Slot()
+		if !rootListContains(tx.Bucket(blockSlotIndicesBucket).Get(bytesutil.SlotToBytesBigEndian(slot)), k) {
+			r.Add(CheckBlockSlotIndex, k, "block is missing from the slot index at slot %d", slot)
+		}
+		parent := blk.Block().ParentRoot()
+		if !rootListContains(tx.Bucket(blockParentRootIndicesBucket).Get(parent[:]), k) {
+			r.Add(CheckBlockParentIndex, k, "block is missing from the parent root index of %#x", parent)
+		}
+		return nil
+	}, nil)
+}
+
+func (s *Store) verifyBlockIndices(ctx context.Context, r *IntegrityReport) error {
+	check := func(bucket []byte, name string) error {
+		return s.forEachBatched(ctx, bucket, func(tx engine.Tx, k, v []byte) error {
+			if len(v)%32 != 0 {
+				r.Add(name, k, "value of length %d is not a list of roots", len(v))
+				return nil
+			}
+			blks := tx.Bucket(blocksBucket)
+			for i := 0; i < len(v); i += 32 {
+				if blks.Get(v[i:i+32]) == nil {
+					r.Add(name, k, "indexed block %#x is not in the database", v[i:i+32])
+				}
+			}
+			return nil
+		}, nil)
+	}
+	if err := check(blockSlotIndicesBucket, CheckSlotIndexBlock); err != nil {
+		return err
+	}
+	return check(blockParentRootIndicesBucket, CheckParentIndexBlock)
+}
+
+func (s *Store) verifyStateSummaries(ctx context.Context, r *IntegrityReport) error {
+	return s.forEachBatched(ctx, stateSummaryBucket, func(tx engine.Tx, k, _ []byte) error {
+		r.Summaries++
+		if tx.Bucket(blocksBucket).Get(k) == nil {
+			r.Add(CheckStateSummaryBlock, k, "state summary refers to a block which is not in the database")
+		}
+		return nil
+	}, nil)
+}
+
+func (s *Store) verifyStates(ctx context.Context, r *IntegrityReport) error {
+	// States are decoded outside of the walk, since decoding reads from several buckets in its own transaction.
+	var roots [][32]byte
+	if err := s.forEachBatched(ctx, stateBucket, func(_ engine.Tx, k, _ []byte) error {
+		if len(k) == 32 {
+			roots = append(roots, bytesutil.ToBytes32(k))
+		}
+		return nil
+	}, nil); err != nil {
+		return err
+	}
+	for _, root := range roots {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		r.States++
+		// The state summary holds the slot of the state, while the block only bounds it from below, since a state
+		// can be saved after the empty slots following its block have been processed.
+		var want uint64
+		var fromSummary, found bool
+		err := s.db.View(func(tx engine.Tx) error {
+			if enc := tx.Bucket(stateSummaryBucket).Get(root[:]); enc != nil {
+				summary := &ethpb.StateSummary{}
+				if err := decode(ctx, enc, summary); err != nil {
+					return err
+				}
+				want, fromSummary, found = uint64(summary.Slot), true, true
+				return nil
+			}
+			if enc := tx.Bucket(blocksBucket).Get(root[:]); enc != nil {
+				blk, err := unmarshalBlock(ctx, enc)
+				if err != nil {
+					return err
+				}
+				want, found = uint64(blk.Block().Slot()), true
+			}
+			return nil
+		})
+		if err != nil {
+			// An undecodable summary or block is reported by the other checks.
+			continue
+		}
+		st, err := s.State(ctx, root)
+		if err != nil || st == nil || st.IsNil() {
+			r.Add(CheckStateDecode, root[:], "could not decode state: %v", err)
+			continue
+		}
+		switch {
+		case !found:
+			r.Add(CheckStateSlot, root[:], "state at slot %d has no block or state summary", st.Slot())
+		case fromSummary && uint64(st.Slot()) != want:
+			r.Add(CheckStateSlot, root[:], "state has slot %d, its state summary has slot %d", st.Slot(), want)
+		case !fromSummary && uint64(st.Slot()) < want:
+			r.Add(CheckStateSlot, root[:], "state has slot %d, below the slot %d of its block", st.Slot(), want)
+		}
+	}
+	return nil
+}
+
+func (s *Store) verifyFinalizedIndex(ctx context.Context, r *IntegrityReport) error {
+	var genesisRoot, originRoot []byte
+	if err := s.db.View(func(tx engine.Tx) error {
+		genesisRoot = bytes.Clone(tx.Bucket(blocksBucket).Get(genesisBlockRootKey))
+		originRoot = bytes.Clone(tx.Bucket(blocksBucket).Get(originCheckpointBlockRootKey))
+		return nil
+	}); err != nil {
+		return err
+	}
+	err := s.forEachBatched(ctx, finalizedBlockRootsIndexBucket, func(tx engine.Tx, k, v []byte) error {
+		if bytes.Equal(k, previousFinalizedCheckpointKey) {
+			return nil
+		}
+		blks := tx.Bucket(blocksBucket)
+		if blks.Get(k) == nil {
+			r.Add(CheckFinalizedIndexBlock, k, "finalized block is not in the database")
+		}
+		if bytes.Equal(v, containerFinalizedButNotCanonical) {
+			return nil
+		}
+		idx := tx.Bucket(finalizedBlockRootsIndexBucket)
+		c := &ethpb.FinalizedBlockRootContainer{}
+		if err := decode(ctx, v, c); err != nil {
+			r.Add(CheckFinalizedIndexDecode, k, "could not decode index entry: %v", err)
+			return nil
+		}
+		if len(c.ChildRoot) > 0 {
+			child, ok := finalizedContainer(ctx, idx, c.ChildRoot)
+			if !ok {
+				r.Add(CheckFinalizedIndexLink, k, "child %#x is not in the finalized index", c.ChildRoot)
+			} else if child != nil && !bytes.Equal(child.ParentRoot, k) {
+				r.Add(CheckFinalizedIndexLink, k, "child %#x has parent %#x", c.ChildRoot, child.ParentRoot)
+			}
+		}
+		// The chain ends at genesis, and at the origin checkpoint or any pruned or not yet backfilled block.
+		if bytes.Equal(c.ParentRoot, genesisRoot) || bytes.Equal(k, originRoot) || blks.Get(c.ParentRoot) == nil {
+			return nil
+		}
+		parent, ok := finalizedContainer(ctx, idx, c.ParentRoot)
+		if !ok {
+			r.Add(CheckFinalizedIndexLink, k, "parent %#x is in the database but not in the finalized index", c.ParentRoot)
+		} else if parent != nil && !bytes.Equal(parent.ChildRoot, k) {
+			r.Add(CheckFinalizedIndexLink, k, "parent %#x has child %#x", c.ParentRoot, parent.ChildRoot)
+		}
+		return nil
+	}, nil)
+	if err != nil {
+		return err
+	}
+	cp, err := s.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(cp.Root, params.BeaconConfig().ZeroHash[:]) || bytes.Equal(cp.Root, genesisRoot) {
+		return nil
+	}
+	return s.db.View(func(tx engine.Tx) error {
+		if tx.Bucket(finalizedBlockRootsIndexBucket).Get(cp.Root) == nil {
+			r.Add(CheckFinalizedIndexTip, cp.Root, "finalized checkpoint at epoch %d is not in the finalized index", cp.Epoch)
+		}
+		return nil
+	})
+}
+
+// finalizedContainer reads an entry of the finalized block roots index. A nil container is returned for blocks of
+// the latest finalized epoch which are not linked into the chain yet, and false if the entry does not exist or
+// cannot be decoded.
+func finalizedContainer(ctx context.Context, idx engine.Bucket, root []byte) (*ethpb.FinalizedBlockRootContainer, bool) {
+	enc := idx.Get(root)
+	if enc == nil {
+		return nil, false
+	}
+	if bytes.Equal(enc, containerFinalizedButNotCanonical) {
+		return nil, true
+	}
+	c := &ethpb.FinalizedBlockRootContainer{}
+	if err := decode(ctx, enc, c); err != nil {
+		return nil, false
+	}
+	return c, true
+}
+
+func rootListContains(list, root []byte) bool {
+	for i := 0; i+32 <= len(list); i += 32 {
+		if bytes.Equal(list[i:i+32], root) {
+			return true
+		}
+	}
+	return false
+}
+
+// forEachBatched calls fn for every key of the bucket, reading the keys in batches so that no read transaction
+// is held open for the whole walk. The key and value are only valid during the call. If afterBatch is set, it is
+// called once the read transaction of each batch has been closed, so that it can write to the database.
+func (s *Store) forEachBatched(ctx context.Context, bucket []byte, fn func(tx engine.Tx, k, v []byte) error, afterBatch func() error) error {
+	var last []byte
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		n := 0
+		err := s.db.View(func(tx engine.Tx) error {
+			c := tx.Bucket(bucket).Cursor()
+			var k, v []byte
+			if last == nil {
+				k, v = c.First()
+			} else {
+				k, v = c.Seek(last)
+				if bytes.Equal(k, last) {
+					k, v = c.Next()
+				}
+			}
+			for ; k != nil && n < integrityBatchSize; k, v = c.Next() {
+				n++
+				last = bytes.Clone(k)
+				if v == nil {
+					continue
+				}
+				if err := fn(tx, k, v); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if afterBatch != nil {
+			if err := afterBatch(); err != nil {
+				return err
+			}
+		}
+		if n < integrityBatchSize {
+			return nil
+		}
+	}
+}
+
+// repairedIndices are the buckets rebuilt by RepairIndices.
+var repairedIndices = [][]byte{blockSlotIndicesBucket, blockParentRootIndicesBucket, finalizedBlockRootsIndexBucket}
+
+// repairBucket returns the name of the bucket an index is rebuilt into, before it is swapped in.
+func repairBucket(name []byte) []byte {
+	return append([]byte("repair-"), name...)
+}
+
+// RepairIndices rebuilds the block slot index, the block parent root index and the finalized block roots index
+// from the blocks bucket and the finalized checkpoint. Blocks which cannot be decoded are left out of the indices.
+// The indices are rebuilt into separate buckets, which replace them in a single transaction once complete, so that
+// an interrupted repair leaves the indices as they were.
+func (s *Store) RepairIndices(ctx context.Context) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.RepairIndices")
+	defer span.End()
+
+	// The buckets of an earlier repair which was interrupted are started over.
+	if err := s.resetRepairBuckets(true); err != nil {
+		return errors.Wrap(err, "could not create repair buckets")
+	}
+	n, f, err := s.rebuildIndices(ctx)
+	if err != nil {
+		if cleanupErr := s.resetRepairBuckets(false); cleanupErr != nil {
+			log.WithError(cleanupErr).Error("Could not delete repair buckets")
+		}
+		return err
+	}
+	if err := s.swapRepairedIndices(); err != nil {
+		return errors.Wrap(err, "could not replace indices")
+	}
+	log.WithFields(logrus.Fields{
+		"indexedBlocks":   n,
+		"finalizedBlocks": f,
+	}).Info("Rebuilt database indices")
+	return nil
+}
+
+func (s *Store) rebuildIndices(ctx context.Context) (int, int, error) {
+	n, err := s.rebuildBlockIndices(ctx)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "could not rebuild block indices")
+	}
+	f, err := s.rebuildFinalizedIndex(ctx)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "could not rebuild finalized block roots index")
+	}
+	return n, f, nil
+}
+
+// resetRepairBuckets deletes the buckets the indices are rebuilt into, and creates them empty if create is set.
+func (s *Store) resetRepairBuckets(create bool) error {
+	return s.db.Update(func(tx engine.Tx) error {
+		for _, name := range repairedIndices {
+			tmp := repairBucket(name)
+			if tx.Bucket(tmp) != nil {
+				if err := tx.DeleteBucket(tmp); err != nil {
+					return err
+				}
+			}
+			if !create {
+				continue
+			}
+			if _, err := tx.CreateBucket(tmp); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// swapRepairedIndices replaces the indices with the rebuilt ones, in a single transaction.
+func (s *Store) swapRepairedIndices() error {
+	return s.db.Update(func(tx engine.Tx) error {
+		for _, name := range repairedIndices {
+			if err := tx.DeleteBucket(name); err != nil {
+				return err
+			}
+			bkt, err := tx.CreateBucket(name)
+			if err != nil {
+				return err
+			}
+			tmp := repairBucket(name)
+			if err := tx.Bucket(tmp).ForEach(func(k, v []byte) error {
+				return bkt.Put(bytes.Clone(k), bytes.Clone(v))
+			}); err != nil {
+				return err
+			}
+			if err := tx.DeleteBucket(tmp); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+type blockIndexEntry struct {
+	root    []byte
+	indices map[string][]byte
+}
+
+func (s *Store) rebuildBlockIndices(ctx context.Context) (int, error) {
+	total := 0
+	batch := make([]blockIndexEntry, 0, integrityBatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		err := s.db.Update(func(tx engine.Tx) error {
+			for _, e := range batch {
+				if err := updateValueForIndices(ctx, e.indices, e.root, tx); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		total += len(batch)
+		batch = batch[:0]
+		return err
+	}
+	err := s.forEachBatched(ctx, blocksBucket, func(_ engine.Tx, k, v []byte) error {
+		if !isBlockRootKey(k) {
+			return nil
+		}
+		blk, err := unmarshalBlock(ctx, v)
+		if err != nil {
+			log.WithError(err).WithField("root", fmt.Sprintf("%#x", k)).Warn("Skipping block which cannot be decoded")
+			return nil
+		}
+		indices := make(map[string][]byte, 2)
+		for name, idx := range blockIndices(blk.Block().Slot(), blk.Block().ParentRoot()) {
+			indices[string(repairBucket([]byte(name)))] = idx
+		}
+		batch = append(batch, blockIndexEntry{root: bytes.Clone(k), indices: indices})
+		return nil
+	}, flush)
+	return total, err
+}
+
+func (s *Store) rebuildFinalizedIndex(ctx context.Context) (int, error) {
+	cp, err := s.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var genesisRoot []byte
+	if err := s.db.View(func(tx engine.Tx) error {
+		genesisRoot = bytes.Clone(tx.Bucket(blocksBucket).Get(genesisBlockRootKey))
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	finalizedIndex := repairBucket(finalizedBlockRootsIndexBucket)
+	if bytes.Equal(cp.Root, params.BeaconConfig().ZeroHash[:]) {
+		return 0, nil
+	}
+
+	// Walk from the finalized checkpoint down the chain of parents, until genesis or the first block which is
+	// not in the database, which is where history was pruned or has not been backfilled yet.
+	total := 0
+	root, child := bytes.Clone(cp.Root), []byte(nil)
+	for root != nil && !bytes.Equal(root, genesisRoot) {
+		if ctx.Err() != nil {
+			return 0, ctx.Err()
+		}
+		encs := make([][]byte, 0, integrityBatchSize)
+		roots := make([][]byte, 0, integrityBatchSize)
+		err := s.db.View(func(tx engine.Tx) error {
+			for len(roots) < integrityBatchSize && root != nil && !bytes.Equal(root, genesisRoot) {
+				enc := tx.Bucket(blocksBucket).Get(root)
+				if enc == nil {
+					root = nil
+					return nil
+				}
+				blk, err := unmarshalBlock(ctx, enc)
+				if err != nil {
+					return errors.Wrapf(err, "could not decode finalized block %#x", root)
+				}
+				parent := blk.Block().ParentRoot()
+				c, err := encode(ctx, &ethpb.FinalizedBlockRootContainer{ParentRoot: parent[:], ChildRoot: child})
+				if err != nil {
+					return err
+				}
+				roots, encs = append(roots, root), append(encs, c)
+				child, root = root, parent[:]
+			}
+			return nil
+		})
+		if err != nil {
+			return 0, err
+		}
+		if err := s.db.Update(func(tx engine.Tx) error {
+			bkt := tx.Bucket(finalizedIndex)
+			for i := range roots {
+				if err := bkt.Put(roots[i], encs[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return 0, err
+		}
+		total += len(roots)
+	}
+
+	enc, err := encode(ctx, cp)
+	if err != nil {
+		return 0, err
+	}
+	err = s.db.Update(func(tx engine.Tx) error {
+		// The other blocks of the finalized epoch are marked as finalized, as updateFinalizedBlockRoots does. They
+		// are looked up in the rebuilt slot index, as the slot index is only replaced once the repair is complete.
+		slotIndex := tx.Bucket(repairBucket(blockSlotIndicesBucket))
+		epochRoots, err := blockRootsBySlotRange(ctx, slotIndex, nil, nil, cp.Epoch, cp.Epoch+1, nil)
+		if err != nil {
+			return err
+		}
+		bkt := tx.Bucket(finalizedIndex)
+		for _, r := range epochRoots {
+			if bkt.Get(r) != nil {
+				continue
+			}
+			if err := bkt.Put(r, containerFinalizedButNotCanonical); err != nil {
+				return err
+			}
+			total++
+		}
+		return bkt.Put(previousFinalizedCheckpointKey, enc)
+	})
+	return total, err
+}
@@ -0,0 +1,105 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/engine"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestStore_VerifyIntegrity_Repair(t *testing.T) {
+	slotsPerEpoch := uint64(params.BeaconConfig().SlotsPerEpoch)
+	db := setupDB(t)
+	ctx := context.Background()
+
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, genesisBlockRoot))
+	blks := makeBlocks(t, 0, slotsPerEpoch*4, genesisBlockRoot)
+	require.NoError(t, db.SaveBlocks(ctx, blks))
+	roots := make([][32]byte, len(blks))
+	for i := range blks {
+		r, err := blks[i].Block().HashTreeRoot()
+		require.NoError(t, err)
+		roots[i] = r
+		require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: blks[i].Block().Slot(), Root: r[:]}))
+	}
+	for _, i := range []int{0, 10} {
+		st, err := util.NewBeaconState()
+		require.NoError(t, err)
+		require.NoError(t, st.SetSlot(blks[i].Block().Slot()))
+		require.NoError(t, db.SaveState(ctx, st, roots[i]))
+	}
+	finalizedRoot := roots[3*slotsPerEpoch-1]
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 3, Root: finalizedRoot[:]}))
+
+	r, err := db.VerifyIntegrity(ctx)
+	require.NoError(t, err)
+	require.Equal(t, true, r.OK(), r.Issues)
+	assert.Equal(t, len(blks), r.Blocks)
+	assert.Equal(t, 2, r.States)
+	assert.Equal(t, len(blks), r.Summaries)
+
+	// Damage the indices, and add a summary and a state which do not match the blocks.
+	missing := bytesutil.ToBytes32([]byte("missing"))
+	require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: 1000, Root: missing[:]}))
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(1000))
+	require.NoError(t, db.SaveState(ctx, st, roots[5]))
+	require.NoError(t, db.db.Update(func(tx engine.Tx) error {
+		if err := tx.Bucket(blockSlotIndicesBucket).Delete(bytesutil.SlotToBytesBigEndian(blks[3].Block().Slot())); err != nil {
+			return err
+		}
+		if err := tx.Bucket(blockParentRootIndicesBucket).Delete(roots[7][:]); err != nil {
+			return err
+		}
+		return tx.Bucket(finalizedBlockRootsIndexBucket).Delete(roots[20][:])
+	}))
+
+	r, err = db.VerifyIntegrity(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, r.Counts[CheckBlockSlotIndex])
+	assert.Equal(t, 1, r.Counts[CheckBlockParentIndex])
+	assert.Equal(t, 1, r.Counts[CheckStateSummaryBlock])
+	assert.Equal(t, 1, r.Counts[CheckStateSlot])
+	// Both the parent and the child of the removed entry no longer link to an entry.
+	assert.Equal(t, 2, r.Counts[CheckFinalizedIndexLink])
+	assert.Equal(t, r.Total(), len(r.Issues))
+
+	// An interrupted repair leaves the indices as they were.
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	require.ErrorIs(t, db.RepairIndices(cancelled), context.Canceled)
+	interrupted, err := db.VerifyIntegrity(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, r.Counts, interrupted.Counts)
+	found, slotRoots, err := db.BlockRootsBySlot(ctx, blks[4].Block().Slot())
+	require.NoError(t, err)
+	assert.Equal(t, true, found)
+	assert.Equal(t, true, db.IsFinalizedBlock(ctx, roots[21]))
+	require.NoError(t, db.db.View(func(tx engine.Tx) error {
+		for _, name := range repairedIndices {
+			assert.Equal(t, true, tx.Bucket(repairBucket(name)) == nil, "Repair bucket %s was not deleted", name)
+		}
+		return nil
+	}))
+
+	require.NoError(t, db.RepairIndices(ctx))
+	r, err = db.VerifyIntegrity(ctx)
+	require.NoError(t, err)
+	// Only the primary data issues are left after the indices are rebuilt.
+	assert.DeepEqual(t, []string{CheckStateSlot, CheckStateSummaryBlock}, r.Checks())
+	assert.Equal(t, true, db.IsFinalizedBlock(ctx, roots[20]))
+	child, err := db.FinalizedChildBlock(ctx, roots[19])
+	require.NoError(t, err)
+	assert.Equal(t, blks[20].Block().Slot(), child.Block().Slot())
+	found, slotRoots, err = db.BlockRootsBySlot(ctx, blks[3].Block().Slot())
+	require.NoError(t, err)
+	assert.Equal(t, true, found)
+	assert.DeepEqual(t, [][32]byte{roots[3]}, slotRoots)
+}
@@ -1,5 +1,5 @@
-// Package kv defines a bolt-db, key-value store implementation
-// of the Database interface defined by a Prysm beacon node.
+// Package kv defines a key-value store implementation of the Database interface defined by a Prysm beacon node,
+// which is kept in one of the embedded engines of the engine package.
 package kv
 
 import (
@@ -7,13 +7,14 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"sync"
 	"time"
 
 	"github.com/dgraph-io/ristretto"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
-	prombolt "github.com/prysmaticlabs/prombbolt"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/engine"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/iface"
 	"github.com/prysmaticlabs/prysm/v5/config/features"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
@@ -34,6 +35,8 @@ const (
 	BeaconNodeDbDirName = "beaconchaindata"
 	// DatabaseFileName is the name of the beacon node database.
 	DatabaseFileName = "beaconchain.db"
+	// PebbleDirName is the name of the directory holding the beacon node database when it uses the pebble engine.
+	PebbleDirName = "beaconchain.pebble"
 
 	boltAllocSize = 8 * 1024 * 1024
 	// The size of hash length in bytes
@@ -81,15 +84,24 @@ var blockedBuckets = [][]byte{
 	finalizedBlockRootsIndexBucket,
 }
 
+// defaultEngine is the engine of new databases which are not opened with WithEngine.
+var defaultEngine = engine.Bolt
+
 // Store defines an implementation of the Prysm Database interface
-// using BoltDB as the underlying persistent kv-store for Ethereum Beacon Nodes.
+// using an embedded key-value engine, BoltDB by default, as the persistent kv-store for Ethereum Beacon Nodes.
 type Store struct {
-	db                  *bolt.DB
+	db                  engine.DB
+	engine              string
 	databasePath        string
 	blockCache          *ristretto.Cache
 	validatorEntryCache *ristretto.Cache
 	stateSummaryCache   *stateSummaryCache
 	ctx                 context.Context
+	backupLock          sync.Mutex
+	backupChanges       backupChanges
+	usage               storageUsage
+	stateDiffExponents  []uint64
+	stateDiffs          *stateDiffs
 }
 
 // StoreDatafilePath is the canonical construction of a full
@@ -114,6 +126,17 @@ var Buckets = [][]byte{
 	blockParentRootIndicesBucket,
 	finalizedBlockRootsIndexBucket,
 	blockRootValidatorHashesBucket,
+	stateDiffBucket,
+	stateDiffDataBucket,
+	stateDiffRootsBucket,
+	validatorHistoryBucket,
+	attestationRewardsBucket,
+	idealAttestationRewardsBucket,
+	syncCommitteeRewardsBucket,
+	peerRecordsBucket,
+	blobArchiveGapsBucket,
+	backupChangesBucket,
+	backupMetadataBucket,
 	// Migrations
 	migrationsBucket,
 
@@ -124,6 +147,37 @@ var Buckets = [][]byte{
 // KVStoreOption is a functional option that modifies a kv.Store.
 type KVStoreOption func(*Store)
 
+// WithEngine sets the engine of a new database. An existing database is always opened with the engine it was created
+// with, so opening it with another engine is an error.
+func WithEngine(name string) KVStoreOption {
+	return func(s *Store) {
+		s.engine = name
+	}
+}
+
+// DatabaseEngine returns the engine of the database in the directory dirPath, or an empty string if the directory
+// holds no database.
+func DatabaseEngine(dirPath string) (string, error) {
+	hasBolt, err := file.Exists(StoreDatafilePath(dirPath), file.Regular)
+	if err != nil {
+		return "", err
+	}
+	hasPebble, err := file.Exists(path.Join(dirPath, PebbleDirName), file.Directory)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case hasBolt && hasPebble:
+		return "", fmt.Errorf("%s holds both a %s and a %s database, remove the one which is not in use", dirPath, engine.Bolt, engine.Pebble)
+	case hasBolt:
+		return engine.Bolt, nil
+	case hasPebble:
+		return engine.Pebble, nil
+	default:
+		return "", nil
+	}
+}
+
 // NewKVStore initializes a new boltDB key-value store at the directory
 // path specified, creates the kv-buckets based on the schema, and stores
 // an open connection db object as a property of the Store struct.
@@ -137,23 +191,25 @@ func NewKVStore(ctx context.Context, dirPath string, opts ...KVStoreOption) (*St
 			return nil, err
 		}
 	}
-	datafile := StoreDatafilePath(dirPath)
-	log.WithField("path", datafile).Info("Opening Bolt DB")
-	boltDB, err := bolt.Open(
-		datafile,
-		params.BeaconIoConfig().ReadWritePermissions,
-		&bolt.Options{
-			Timeout:         1 * time.Second,
-			InitialMmapSize: mmapSize,
-		},
-	)
-	if err != nil {
-		if errors.Is(err, bolt.ErrTimeout) {
-			return nil, errors.New("cannot obtain database lock, database may be in use by another process")
+	kv := &Store{
+		databasePath: dirPath,
+		ctx:          ctx,
+	}
+	for _, o := range opts {
+		o(kv)
+	}
+	if len(kv.stateDiffExponents) > 0 {
+		if kv.stateDiffs, err = newStateDiffs(kv.stateDiffExponents); err != nil {
+			return nil, err
 		}
+	}
+	db, err := openEngine(dirPath, kv.engine)
+	if err != nil {
 		return nil, err
 	}
-	boltDB.AllocSize = boltAllocSize
+	kv.db = &changeLogDB{DB: &usageTrackingDB{DB: db, usage: &kv.usage}, changes: &kv.backupChanges}
+	kv.engine = kv.db.Engine()
+
 	blockCache, err := ristretto.NewCache(&ristretto.Config{
 		NumCounters: 1000,           // number of keys to track frequency of (1000).
 		MaxCost:     BlockCacheSize, // maximum cost of cache (1000 Blocks).
@@ -172,25 +228,22 @@ func NewKVStore(ctx context.Context, dirPath string, opts ...KVStoreOption) (*St
 		return nil, err
 	}
 
-	kv := &Store{
-		db:                  boltDB,
-		databasePath:        dirPath,
-		blockCache:          blockCache,
-		validatorEntryCache: validatorCache,
-		stateSummaryCache:   newStateSummaryCache(),
-		ctx:                 ctx,
-	}
-	for _, o := range opts {
-		o(kv)
-	}
-	if err := kv.db.Update(func(tx *bolt.Tx) error {
+	kv.blockCache = blockCache
+	kv.validatorEntryCache = validatorCache
+	kv.stateSummaryCache = newStateSummaryCache()
+	if err := kv.db.Update(func(tx engine.Tx) error {
 		return createBuckets(tx, Buckets...)
 	}); err != nil {
 		return nil, err
 	}
-	if err = prometheus.Register(createBoltCollector(kv.db)); err != nil {
+	if err := kv.resumeBackupChanges(); err != nil {
 		return nil, err
 	}
+	if c := kv.db.Collector(); c != nil {
+		if err = prometheus.Register(c); err != nil {
+			return nil, err
+		}
+	}
 	// Setup the type of block storage used depending on whether or not this is a fresh database.
 	if err := kv.setupBlockStorageType(ctx); err != nil {
 		return nil, err
@@ -207,16 +260,23 @@ func (s *Store) ClearDB() error {
 	if _, err := os.Stat(s.databasePath); os.IsNotExist(err) {
 		return nil
 	}
-	prometheus.Unregister(createBoltCollector(s.db))
+	if s.engine == engine.Pebble {
+		if err := os.RemoveAll(path.Join(s.databasePath, PebbleDirName)); err != nil {
+			return errors.Wrap(err, "could not remove database directory")
+		}
+		return nil
+	}
 	if err := os.Remove(path.Join(s.databasePath, DatabaseFileName)); err != nil {
 		return errors.Wrap(err, "could not remove database file")
 	}
 	return nil
 }
 
-// Close closes the underlying BoltDB database.
+// Close closes the underlying database.
 func (s *Store) Close() error {
-	prometheus.Unregister(createBoltCollector(s.db))
+	if c := s.db.Collector(); c != nil {
+		prometheus.Unregister(c)
+	}
 
 	// Before DB closes, we should dump the cached state summary objects to DB.
 	if err := s.saveCachedStateSummariesDB(s.ctx); err != nil {
@@ -231,6 +291,59 @@ func (s *Store) DatabasePath() string {
 	return s.databasePath
 }
 
+// Engine returns the name of the engine the database is stored in.
+func (s *Store) Engine() string {
+	return s.engine
+}
+
+// openEngine opens the database in dirPath with the engine it was created with, or creates it with the requested
+// engine, or the default engine if none was requested.
+func openEngine(dirPath, requested string) (engine.DB, error) {
+	if requested != "" {
+		if err := engine.Validate(requested); err != nil {
+			return nil, err
+		}
+	}
+	existing, err := DatabaseEngine(dirPath)
+	if err != nil {
+		return nil, err
+	}
+	name := existing
+	switch {
+	case existing == "" && requested != "":
+		name = requested
+	case existing == "":
+		name = defaultEngine
+	case requested != "" && requested != existing:
+		return nil, fmt.Errorf("the database in %s uses the %s engine and cannot be opened with the %s engine, "+
+			"use prysmctl db convert to move it to another engine", dirPath, existing, requested)
+	}
+
+	if name == engine.Pebble {
+		dir := path.Join(dirPath, PebbleDirName)
+		log.WithField("path", dir).Info("Opening Pebble DB")
+		return engine.OpenPebble(dir)
+	}
+	datafile := StoreDatafilePath(dirPath)
+	log.WithField("path", datafile).Info("Opening Bolt DB")
+	boltDB, err := bolt.Open(
+		datafile,
+		params.BeaconIoConfig().ReadWritePermissions,
+		&bolt.Options{
+			Timeout:         1 * time.Second,
+			InitialMmapSize: mmapSize,
+		},
+	)
+	if err != nil {
+		if errors.Is(err, bolt.ErrTimeout) {
+			return nil, errors.New("cannot obtain database lock, database may be in use by another process")
+		}
+		return nil, err
+	}
+	boltDB.AllocSize = boltAllocSize
+	return engine.NewBolt(boltDB, blockedBuckets...), nil
+}
+
 func (s *Store) setupBlockStorageType(ctx context.Context) error {
 	// We check if we want to save blinded beacon blocks by checking a key in the db
 	// otherwise, we check the last stored block and set that key in the DB if it is blinded.
@@ -243,7 +356,7 @@ func (s *Store) setupBlockStorageType(ctx context.Context) error {
 	saveFull := features.Get().SaveFullExecutionPayloads
 
 	var saveBlinded bool
-	if err := s.db.Update(func(tx *bolt.Tx) error {
+	if err := s.db.Update(func(tx engine.Tx) error {
 		// If we have a key stating we wish to save blinded beacon blocks, then we set saveBlinded to true.
 		metadataBkt := tx.Bucket(chainMetadataBucket)
 		keyExists := len(metadataBkt.Get(saveBlindedBeaconBlocksKey)) > 0
@@ -287,7 +400,7 @@ func (s *Store) setupBlockStorageType(ctx context.Context) error {
 	return nil
 }
 
-func createBuckets(tx *bolt.Tx, buckets ...[]byte) error {
+func createBuckets(tx engine.Tx, buckets ...[]byte) error {
 	for _, bucket := range buckets {
 		if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
 			return err
@@ -295,8 +408,3 @@ func createBuckets(tx *bolt.Tx, buckets ...[]byte) error {
 	}
 	return nil
 }
-
-// createBoltCollector returns a prometheus collector specifically configured for boltdb.
-func createBoltCollector(db *bolt.DB) prometheus.Collector {
-	return prombolt.New("boltDB", db, blockedBuckets...)
-}
@@ -5,17 +5,17 @@ import (
 	"encoding/binary"
 	"fmt"
 
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/engine"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
 	ethpbv2 "github.com/prysmaticlabs/prysm/v5/proto/eth/v2"
-	bolt "go.etcd.io/bbolt"
 )
 
 func (s *Store) SaveLightClientUpdate(ctx context.Context, period uint64, update *ethpbv2.LightClientUpdateWithVersion) error {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.saveLightClientUpdate")
 	defer span.End()
 
-	return s.db.Update(func(tx *bolt.Tx) error {
+	return s.db.Update(func(tx engine.Tx) error {
 		bkt := tx.Bucket(lightClientUpdatesBucket)
 		updateMarshalled, err := encode(ctx, update)
 		if err != nil {
@@ -34,7 +34,7 @@ func (s *Store) LightClientUpdates(ctx context.Context, startPeriod, endPeriod u
 	}
 
 	updates := make(map[uint64]*ethpbv2.LightClientUpdateWithVersion)
-	err := s.db.View(func(tx *bolt.Tx) error {
+	err := s.db.View(func(tx engine.Tx) error {
 		bkt := tx.Bucket(lightClientUpdatesBucket)
 		c := bkt.Cursor()
 
@@ -67,7 +67,7 @@ func (s *Store) LightClientUpdate(ctx context.Context, period uint64) (*ethpbv2.
 	defer span.End()
 
 	var update ethpbv2.LightClientUpdateWithVersion
-	err := s.db.View(func(tx *bolt.Tx) error {
+	err := s.db.View(func(tx engine.Tx) error {
 		bkt := tx.Bucket(lightClientUpdatesBucket)
 		updateBytes := bkt.Get(bytesutil.Uint64ToBytesBigEndian(period))
 		if updateBytes == nil {
@@ -0,0 +1,81 @@
+package kv
+
+import (
+	"context"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/engine"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/peerdata"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+)
+
+// SavePeerRecords saves the given peer records, replacing the saved records of the same peers.
+func (s *Store) SavePeerRecords(ctx context.Context, records []*peerdata.Record) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SavePeerRecords")
+	defer span.End()
+	return s.db.Update(func(tx engine.Tx) error {
+		bkt := tx.Bucket(peerRecordsBucket)
+		for _, r := range records {
+			enc, err := peerdata.EncodeRecord(r)
+			if err != nil {
+				return errors.Wrapf(err, "could not encode record of peer %s", r.PeerID)
+			}
+			if err := bkt.Put([]byte(r.PeerID), enc); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// PeerRecords returns all the saved peer records. Records which cannot be decoded are skipped.
+func (s *Store) PeerRecords(ctx context.Context) ([]*peerdata.Record, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.PeerRecords")
+	defer span.End()
+	var records []*peerdata.Record
+	err := s.db.View(func(tx engine.Tx) error {
+		return tx.Bucket(peerRecordsBucket).ForEach(func(k, v []byte) error {
+			r, err := peerdata.DecodeRecord(peer.ID(k), v)
+			if err != nil {
+				log.WithError(err).WithField("peer", peer.ID(k)).Debug("Could not decode peer record")
+				return nil
+			}
+			records = append(records, r)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// DeletePeerRecordsBefore deletes the records of the peers last seen before the cutoff, unless they are still
+// banned. Records which cannot be decoded are deleted as well.
+func (s *Store) DeletePeerRecordsBefore(ctx context.Context, cutoff time.Time) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.DeletePeerRecordsBefore")
+	defer span.End()
+	return s.db.Update(func(tx engine.Tx) error {
+		bkt := tx.Bucket(peerRecordsBucket)
+		var keys [][]byte
+		err := bkt.ForEach(func(k, v []byte) error {
+			r, err := peerdata.DecodeRecord(peer.ID(k), v)
+			if err != nil || (r.LastSeen.Before(cutoff) && !r.Banned(cutoff)) {
+				keys = append(keys, bytesutil.SafeCopyBytes(k))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, k := range keys {
+			if err := bkt.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,50 @@
+package kv
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/peerdata"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestStore_PeerRecords(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	addr, err := ma.NewMultiaddr("/ip4/127.0.0.1/tcp/13000")
+	require.NoError(t, err)
+	now := time.Unix(1700000000, 0)
+
+	records := []*peerdata.Record{
+		{PeerID: "a", Address: addr, LastSeen: now, ProcessedBlocks: 64},
+		{PeerID: "b", Address: addr, LastSeen: now.Add(-48 * time.Hour)},
+		{PeerID: "c", Address: addr, LastSeen: now.Add(-48 * time.Hour), BanExpiry: now.Add(time.Hour), BadResponses: 7},
+	}
+	require.NoError(t, db.SavePeerRecords(ctx, records))
+	// Saving a record again replaces it.
+	records[0].ProcessedBlocks = 128
+	require.NoError(t, db.SavePeerRecords(ctx, records[:1]))
+
+	saved, err := db.PeerRecords(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(saved))
+	sort.Slice(saved, func(i, j int) bool { return saved[i].PeerID < saved[j].PeerID })
+	assert.Equal(t, peer.ID("a"), saved[0].PeerID)
+	assert.Equal(t, uint64(128), saved[0].ProcessedBlocks)
+	assert.Equal(t, true, addr.Equal(saved[1].Address))
+	assert.Equal(t, 7, saved[2].BadResponses)
+
+	// Peers not seen since the cutoff are deleted, unless they are still banned.
+	require.NoError(t, db.DeletePeerRecordsBefore(ctx, now.Add(-24*time.Hour)))
+	saved, err = db.PeerRecords(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(saved))
+	sort.Slice(saved, func(i, j int) bool { return saved[i].PeerID < saved[j].PeerID })
+	assert.Equal(t, peer.ID("a"), saved[0].PeerID)
+	assert.Equal(t, peer.ID("c"), saved[1].PeerID)
+}
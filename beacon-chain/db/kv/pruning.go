@@ -0,0 +1,263 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/engine"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// ErrPruneAboveFinalized is returned when a caller asks to prune history that has not yet been finalized.
+var ErrPruneAboveFinalized = errors.New("cannot prune history above the finalized checkpoint")
+
+// EarliestAvailableSlot returns the lowest slot (other than genesis) for which blocks and states may still
+// be present in the database. A value of zero means history has never been pruned.
+func (s *Store) EarliestAvailableSlot(ctx context.Context) (primitives.Slot, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.EarliestAvailableSlot")
+	defer span.End()
+
+	var slot primitives.Slot
+	err := s.db.View(func(tx engine.Tx) error {
+		enc := tx.Bucket(chainMetadataBucket).Get(earliestAvailableSlotKey)
+		if len(enc) == 0 {
+			return nil
+		}
+		slot = bytesutil.BytesToSlotBigEndian(enc)
+		return nil
+	})
+	return slot, err
+}
+
+// DeleteHistoricalDataBeforeSlot removes blocks, states, state summaries and all of their index entries
+// for slots strictly below the cutoff slot. At most batchSize slots are processed in a single call, so that
+// callers can prune incrementally without holding the bolt write lock for long periods of time. The genesis,
+// origin checkpoint and finalized checkpoint blocks and states are always retained. The number of slots pruned is returned; a value
+// of zero means there is nothing left to prune below the cutoff.
+//
+// The cutoff is lowered to the slot of the highest state saved at or below it, such as an archived point, so that
+// the states from the earliest available slot on can still be regenerated by replaying blocks from a saved state.
+func (s *Store) DeleteHistoricalDataBeforeSlot(ctx context.Context, cutoff primitives.Slot, batchSize int) (int, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.DeleteHistoricalDataBeforeSlot")
+	defer span.End()
+
+	if batchSize <= 0 {
+		return 0, errors.Errorf("invalid batch size %d", batchSize)
+	}
+	finalized, err := s.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return 0, err
+	}
+	finalizedSlot, err := slots.EpochStart(finalized.Epoch)
+	if err != nil {
+		return 0, err
+	}
+	if cutoff > finalizedSlot {
+		return 0, errors.Wrapf(ErrPruneAboveFinalized, "cutoff=%d, finalized=%d", cutoff, finalizedSlot)
+	}
+
+	var (
+		prunedSlots   int
+		deletedBlocks [][]byte
+		deletedRoots  [][32]byte
+		prunedDiffs   []primitives.Slot
+	)
+	err = s.db.Update(func(tx engine.Tx) error {
+		cutoff = retainedStateSlot(tx, cutoff)
+		blocksBkt := tx.Bucket(blocksBucket)
+		protected := [][]byte{blocksBkt.Get(genesisBlockRootKey), blocksBkt.Get(originCheckpointBlockRootKey), finalized.Root}
+		isProtected := func(root []byte) bool {
+			for _, p := range protected {
+				if p != nil && bytes.Equal(p, root) {
+					return true
+				}
+			}
+			return false
+		}
+
+		earliest := cutoff
+		slotIdx := tx.Bucket(blockSlotIndicesBucket)
+		c := slotIdx.Cursor()
+		// Slot 0 is the genesis slot and is never pruned, so we start scanning from slot 1.
+		for k, v := c.Seek(bytesutil.SlotToBytesBigEndian(1)); k != nil; k, v = c.Seek(k) {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			slot := bytesutil.BytesToSlotBigEndian(k)
+			if slot >= cutoff {
+				break
+			}
+			if prunedSlots >= batchSize {
+				earliest = slot
+				break
+			}
+			roots, err := splitRoots(v)
+			if err != nil {
+				return errors.Wrapf(err, "could not decode roots at slot %d", slot)
+			}
+			// Copy the key, as bolt memory is only valid until the next modification of the bucket.
+			k = bytesutil.SafeCopyBytes(k)
+			retained := make([]byte, 0)
+			for _, r := range roots {
+				if isProtected(r[:]) {
+					retained = append(retained, r[:]...)
+					continue
+				}
+				if err := s.deleteBlockAndIndices(ctx, tx, r[:]); err != nil {
+					return err
+				}
+				deletedBlocks = append(deletedBlocks, r[:])
+				deletedRoots = append(deletedRoots, r)
+			}
+			// Slots holding only protected roots are left untouched and not counted, otherwise callers looping
+			// until nothing is pruned would never stop once such a slot falls below the cutoff.
+			if len(retained) < len(roots)*32 {
+				if len(retained) > 0 {
+					if err := slotIdx.Put(k, retained); err != nil {
+						return err
+					}
+				} else if err := slotIdx.Delete(k); err != nil {
+					return err
+				}
+				prunedSlots++
+			}
+			// Seek to the slot following the one we just processed, since the cursor position
+			// is invalidated by the modifications above.
+			k = bytesutil.SlotToBytesBigEndian(slot + 1)
+		}
+
+		// States are indexed separately from blocks, and may be present without a corresponding block
+		// (e.g. the origin checkpoint state). Remove any remaining state entries below the new earliest slot.
+		stateIdx := tx.Bucket(stateSlotIndicesBucket)
+		sc := stateIdx.Cursor()
+		for k, v := sc.Seek(bytesutil.SlotToBytesBigEndian(1)); k != nil; k, v = sc.Seek(k) {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			slot := bytesutil.BytesToSlotBigEndian(k)
+			if slot >= earliest {
+				break
+			}
+			roots, err := splitRoots(v)
+			if err != nil {
+				return errors.Wrapf(err, "could not decode state roots at slot %d", slot)
+			}
+			k = bytesutil.SafeCopyBytes(k)
+			retained := make([]byte, 0)
+			for _, r := range roots {
+				if isProtected(r[:]) {
+					retained = append(retained, r[:]...)
+					continue
+				}
+				if err := s.deleteStateAndSummary(tx, r[:]); err != nil {
+					return err
+				}
+				deletedRoots = append(deletedRoots, r)
+			}
+			if len(retained) > 0 {
+				if err := stateIdx.Put(k, retained); err != nil {
+					return err
+				}
+			} else if err := stateIdx.Delete(k); err != nil {
+				return err
+			}
+			k = bytesutil.SlotToBytesBigEndian(slot + 1)
+		}
+
+		var err error
+		if prunedDiffs, err = s.pruneStateDiffs(ctx, tx, earliest); err != nil {
+			return errors.Wrap(err, "could not prune state diffs")
+		}
+
+		current := bytesutil.BytesToSlotBigEndian(tx.Bucket(chainMetadataBucket).Get(earliestAvailableSlotKey))
+		if earliest <= current {
+			return nil
+		}
+		return tx.Bucket(chainMetadataBucket).Put(earliestAvailableSlotKey, bytesutil.SlotToBytesBigEndian(earliest))
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, r := range deletedBlocks {
+		s.blockCache.Del(string(r))
+	}
+	for _, r := range deletedRoots {
+		s.stateSummaryCache.delete(r)
+	}
+	if s.stateDiffs != nil {
+		for _, slot := range prunedDiffs {
+			s.stateDiffs.cache.Remove(slot)
+			s.stateDiffs.indexes.Remove(slot)
+		}
+	}
+	return prunedSlots, nil
+}
+
+// retainedStateSlot returns the slot of the highest state saved at or below the cutoff, which the states above it are
+// regenerated from, or the cutoff itself when no state other than the genesis state is saved below it.
+func retainedStateSlot(tx engine.Tx, cutoff primitives.Slot) primitives.Slot {
+	c := tx.Bucket(stateSlotIndicesBucket).Cursor()
+	k, _ := c.Seek(bytesutil.SlotToBytesBigEndian(cutoff + 1))
+	if k == nil {
+		k, _ = c.Last()
+	} else {
+		k, _ = c.Prev()
+	}
+	if k == nil {
+		return cutoff
+	}
+	slot := bytesutil.BytesToSlotBigEndian(k)
+	if slot == 0 || slot > cutoff {
+		return cutoff
+	}
+	return slot
+}
+
+// deleteBlockAndIndices removes a block along with its parent root and finalized index entries, as well as
+// the state and state summary saved under the same root. The caller is responsible for the slot index.
+func (s *Store) deleteBlockAndIndices(ctx context.Context, tx engine.Tx, root []byte) error {
+	bkt := tx.Bucket(blocksBucket)
+	if enc := bkt.Get(root); enc != nil {
+		blk, err := unmarshalBlock(ctx, enc)
+		if err != nil {
+			return errors.Wrapf(err, "could not unmarshal block with root %#x", root)
+		}
+		parentRoot := blk.Block().ParentRoot()
+		indices := map[string][]byte{string(blockParentRootIndicesBucket): parentRoot[:]}
+		if err := deleteValueForIndices(ctx, indices, root, tx); err != nil {
+			return errors.Wrapf(err, "could not delete parent root index for root %#x", root)
+		}
+		if err := bkt.Delete(root); err != nil {
+			return err
+		}
+	}
+	// Remove the list of children indexed under this root, since the root itself no longer exists.
+	if err := tx.Bucket(blockParentRootIndicesBucket).Delete(root); err != nil {
+		return err
+	}
+	if err := tx.Bucket(finalizedBlockRootsIndexBucket).Delete(root); err != nil {
+		return err
+	}
+	return s.deleteStateAndSummary(tx, root)
+}
+
+// deleteStateAndSummary removes the state, state summary and validator hash index entries for a block root.
+// Unlike DeleteState, it does not consult the checkpoint safeguards and does not touch the state slot index.
+func (s *Store) deleteStateAndSummary(tx engine.Tx, root []byte) error {
+	if err := tx.Bucket(stateBucket).Delete(root); err != nil {
+		return err
+	}
+	if err := tx.Bucket(stateSummaryBucket).Delete(root); err != nil {
+		return err
+	}
+	idxBkt := tx.Bucket(blockRootValidatorHashesBucket)
+	if idxBkt.Get(root) == nil {
+		return nil
+	}
+	return idxBkt.Delete(root)
+}
@@ -0,0 +1,172 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/engine"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestStore_DeleteHistoricalDataBeforeSlot(t *testing.T) {
+	slotsPerEpoch := uint64(params.BeaconConfig().SlotsPerEpoch)
+	db := setupDB(t)
+	ctx := context.Background()
+
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, genesisBlockRoot))
+	blks := makeBlocks(t, 0, slotsPerEpoch*4, genesisBlockRoot)
+	require.NoError(t, db.SaveBlocks(ctx, blks))
+
+	roots := make([][32]byte, len(blks))
+	for i := range blks {
+		r, err := blks[i].Block().HashTreeRoot()
+		require.NoError(t, err)
+		roots[i] = r
+		st, err := util.NewBeaconState()
+		require.NoError(t, err)
+		require.NoError(t, st.SetSlot(blks[i].Block().Slot()))
+		require.NoError(t, db.SaveState(ctx, st, r))
+		require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: blks[i].Block().Slot(), Root: r[:]}))
+	}
+	// blks[i] is at slot i+1, so the block at index 3*slotsPerEpoch-1 is the first block of epoch 3.
+	finalizedRoot := roots[3*slotsPerEpoch-1]
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 3, Root: finalizedRoot[:]}))
+
+	earliest, err := db.EarliestAvailableSlot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Slot(0), earliest)
+
+	cutoff := primitives.Slot(2 * slotsPerEpoch)
+	// Prune in batches of 5 slots until there is nothing left to prune.
+	total := 0
+	for {
+		n, err := db.DeleteHistoricalDataBeforeSlot(ctx, cutoff, 5)
+		require.NoError(t, err)
+		if n == 0 {
+			break
+		}
+		assert.Equal(t, true, n <= 5)
+		total += n
+	}
+	// Slots 1..cutoff-1 are pruned.
+	assert.Equal(t, int(cutoff)-1, total)
+
+	earliest, err = db.EarliestAvailableSlot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, cutoff, earliest)
+
+	for i := range blks {
+		slot := blks[i].Block().Slot()
+		r := roots[i]
+		pruned := slot < cutoff
+		assert.Equal(t, !pruned, db.HasBlock(ctx, r), "unexpected block presence at slot %d", slot)
+		assert.Equal(t, !pruned, db.HasState(ctx, r), "unexpected state presence at slot %d", slot)
+		assert.Equal(t, !pruned, db.HasStateSummary(ctx, r), "unexpected summary presence at slot %d", slot)
+		has, _, err := db.BlockRootsBySlot(ctx, slot)
+		require.NoError(t, err)
+		assert.Equal(t, !pruned, has, "unexpected slot index entry at slot %d", slot)
+	}
+
+	require.NoError(t, db.db.View(func(tx engine.Tx) error {
+		for i := range blks {
+			if blks[i].Block().Slot() >= cutoff {
+				continue
+			}
+			assert.Equal(t, true, tx.Bucket(finalizedBlockRootsIndexBucket).Get(roots[i][:]) == nil)
+			assert.Equal(t, true, tx.Bucket(blockParentRootIndicesBucket).Get(roots[i][:]) == nil)
+		}
+		// The genesis root no longer lists the pruned block at slot 1 as a child.
+		assert.Equal(t, true, tx.Bucket(blockParentRootIndicesBucket).Get(genesisBlockRoot[:]) == nil)
+		return nil
+	}))
+	assert.Equal(t, true, db.IsFinalizedBlock(ctx, roots[cutoff-1]))
+}
+
+func TestStore_DeleteHistoricalDataBeforeSlot_ProtectedRootBelowCutoff(t *testing.T) {
+	slotsPerEpoch := uint64(params.BeaconConfig().SlotsPerEpoch)
+	db := setupDB(t)
+	ctx := context.Background()
+
+	blks := makeBlocks(t, 0, slotsPerEpoch*3, genesisBlockRoot)
+	require.NoError(t, db.SaveBlocks(ctx, blks))
+	roots := make([][32]byte, len(blks))
+	for i := range blks {
+		r, err := blks[i].Block().HashTreeRoot()
+		require.NoError(t, err)
+		roots[i] = r
+	}
+	// The origin checkpoint block at slot 3 is below the cutoff, and must be kept.
+	originRoot := roots[2]
+	require.NoError(t, db.SaveOriginCheckpointBlockRoot(ctx, originRoot))
+	finalizedRoot := roots[2*slotsPerEpoch-1]
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 2, Root: finalizedRoot[:]}))
+
+	cutoff := primitives.Slot(slotsPerEpoch)
+	n, err := db.DeleteHistoricalDataBeforeSlot(ctx, cutoff, int(slotsPerEpoch))
+	require.NoError(t, err)
+	assert.Equal(t, int(cutoff)-2, n)
+	// Only the protected slot is left below the cutoff, so further calls report nothing left to prune.
+	for i := 0; i < 3; i++ {
+		n, err = db.DeleteHistoricalDataBeforeSlot(ctx, cutoff, int(slotsPerEpoch))
+		require.NoError(t, err)
+		assert.Equal(t, 0, n)
+	}
+	assert.Equal(t, true, db.HasBlock(ctx, originRoot))
+	has, indexed, err := db.BlockRootsBySlot(ctx, 3)
+	require.NoError(t, err)
+	assert.Equal(t, true, has)
+	assert.DeepEqual(t, [][32]byte{originRoot}, indexed)
+}
+
+func TestStore_DeleteHistoricalDataBeforeSlot_KeepsHighestStateBelowCutoff(t *testing.T) {
+	slotsPerEpoch := uint64(params.BeaconConfig().SlotsPerEpoch)
+	db := setupDB(t)
+	ctx := context.Background()
+
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, genesisBlockRoot))
+	blks := makeBlocks(t, 0, slotsPerEpoch*3, genesisBlockRoot)
+	require.NoError(t, db.SaveBlocks(ctx, blks))
+	roots := make([][32]byte, len(blks))
+	for i := range blks {
+		r, err := blks[i].Block().HashTreeRoot()
+		require.NoError(t, err)
+		roots[i] = r
+	}
+	// The archived state at slot 20 is the highest saved state below the cutoff.
+	for _, slot := range []primitives.Slot{10, 20} {
+		st, err := util.NewBeaconState()
+		require.NoError(t, err)
+		require.NoError(t, st.SetSlot(slot))
+		require.NoError(t, db.SaveState(ctx, st, roots[slot-1]))
+	}
+	finalizedRoot := roots[2*slotsPerEpoch-1]
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 2, Root: finalizedRoot[:]}))
+
+	cutoff := primitives.Slot(slotsPerEpoch)
+	n, err := db.DeleteHistoricalDataBeforeSlot(ctx, cutoff, int(slotsPerEpoch))
+	require.NoError(t, err)
+	assert.Equal(t, 19, n)
+	earliest, err := db.EarliestAvailableSlot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Slot(20), earliest)
+	assert.Equal(t, false, db.HasState(ctx, roots[9]))
+	assert.Equal(t, true, db.HasState(ctx, roots[19]))
+	for i := range blks {
+		slot := blks[i].Block().Slot()
+		assert.Equal(t, slot >= 20, db.HasBlock(ctx, roots[i]), "unexpected block presence at slot %d", slot)
+	}
+}
+
+func TestStore_DeleteHistoricalDataBeforeSlot_AboveFinalized(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	_, err := db.DeleteHistoricalDataBeforeSlot(ctx, 1, 10)
+	require.ErrorIs(t, err, ErrPruneAboveFinalized)
+	_, err = db.DeleteHistoricalDataBeforeSlot(ctx, 0, 0)
+	require.ErrorContains(t, "invalid batch size", err)
+}
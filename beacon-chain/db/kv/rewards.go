@@ -0,0 +1,178 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/rewardsummary"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/engine"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+)
+
+// ErrNotFoundRewards is a not found error for the rewards of an epoch.
+var ErrNotFoundRewards = errors.Wrap(ErrNotFound, "rewards")
+
+// attestationRewardsKey is the epoch followed by the chunk index, so that the rewards of an epoch are contiguous and
+// in validator order.
+func attestationRewardsKey(epoch primitives.Epoch, chunk uint64) []byte {
+	return append(bytesutil.Uint64ToBytesBigEndian(uint64(epoch)), bytesutil.Uint64ToBytesBigEndian(chunk)...)
+}
+
+// SaveAttestationRewards saves the attestation rewards of every validator for an epoch, indexed by validator index,
+// and the ideal attestation rewards of the epoch.
+func (s *Store) SaveAttestationRewards(
+	ctx context.Context,
+	epoch primitives.Epoch,
+	rewards []rewardsummary.AttestationReward,
+	ideal []rewardsummary.IdealAttestationReward,
+) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveAttestationRewards")
+	defer span.End()
+	return s.db.Update(func(tx engine.Tx) error {
+		bkt := tx.Bucket(attestationRewardsBucket)
+		for start := 0; start < len(rewards); start += rewardsummary.ChunkSize {
+			end := start + rewardsummary.ChunkSize
+			if end > len(rewards) {
+				end = len(rewards)
+			}
+			key := attestationRewardsKey(epoch, uint64(start/rewardsummary.ChunkSize))
+			if err := bkt.Put(key, rewardsummary.EncodeAttestationRewards(rewards[start:end])); err != nil {
+				return err
+			}
+		}
+		return tx.Bucket(idealAttestationRewardsBucket).Put(
+			bytesutil.Uint64ToBytesBigEndian(uint64(epoch)),
+			rewardsummary.EncodeIdealAttestationRewards(ideal),
+		)
+	})
+}
+
+// AttestationRewards returns the attestation rewards of every validator for an epoch, indexed by validator index,
+// and the ideal attestation rewards of the epoch. ErrNotFoundRewards is returned if the rewards of the epoch were
+// not saved.
+func (s *Store) AttestationRewards(ctx context.Context, epoch primitives.Epoch) ([]rewardsummary.AttestationReward, []rewardsummary.IdealAttestationReward, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.AttestationRewards")
+	defer span.End()
+	var rewards []rewardsummary.AttestationReward
+	var ideal []rewardsummary.IdealAttestationReward
+	err := s.db.View(func(tx engine.Tx) error {
+		enc := tx.Bucket(idealAttestationRewardsBucket).Get(bytesutil.Uint64ToBytesBigEndian(uint64(epoch)))
+		if enc == nil {
+			return ErrNotFoundRewards
+		}
+		var err error
+		ideal, err = rewardsummary.DecodeIdealAttestationRewards(enc)
+		if err != nil {
+			return errors.Wrap(err, "could not decode ideal attestation rewards")
+		}
+		prefix := bytesutil.Uint64ToBytesBigEndian(uint64(epoch))
+		c := tx.Bucket(attestationRewardsBucket).Cursor()
+		chunk := uint64(0)
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			if bytesutil.BytesToUint64BigEndian(k[8:]) != chunk {
+				return errors.Errorf("attestation rewards chunk %d of epoch %d is missing", chunk, epoch)
+			}
+			decoded, err := rewardsummary.DecodeAttestationRewards(v)
+			if err != nil {
+				return errors.Wrapf(err, "could not decode attestation rewards chunk %d", chunk)
+			}
+			rewards = append(rewards, decoded...)
+			chunk++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return rewards, ideal, nil
+}
+
+// SaveSyncCommitteeRewards saves the sync committee rewards of an epoch.
+func (s *Store) SaveSyncCommitteeRewards(ctx context.Context, epoch primitives.Epoch, rewards *rewardsummary.SyncCommitteeRewards) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveSyncCommitteeRewards")
+	defer span.End()
+	return s.db.Update(func(tx engine.Tx) error {
+		return tx.Bucket(syncCommitteeRewardsBucket).Put(
+			bytesutil.Uint64ToBytesBigEndian(uint64(epoch)),
+			rewardsummary.EncodeSyncCommitteeRewards(rewards),
+		)
+	})
+}
+
+// SyncCommitteeRewards returns the sync committee rewards of an epoch. ErrNotFoundRewards is returned if the rewards
+// of the epoch were not saved.
+func (s *Store) SyncCommitteeRewards(ctx context.Context, epoch primitives.Epoch) (*rewardsummary.SyncCommitteeRewards, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SyncCommitteeRewards")
+	defer span.End()
+	var rewards *rewardsummary.SyncCommitteeRewards
+	err := s.db.View(func(tx engine.Tx) error {
+		enc := tx.Bucket(syncCommitteeRewardsBucket).Get(bytesutil.Uint64ToBytesBigEndian(uint64(epoch)))
+		if enc == nil {
+			return ErrNotFoundRewards
+		}
+		var err error
+		rewards, err = rewardsummary.DecodeSyncCommitteeRewards(enc)
+		return errors.Wrap(err, "could not decode sync committee rewards")
+	})
+	return rewards, err
+}
+
+// AttestationRewardsRange returns the range of epochs whose attestation rewards are saved, or ErrNotFoundRewards if
+// there are none.
+func (s *Store) AttestationRewardsRange(ctx context.Context) (*rewardsummary.EpochRange, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.AttestationRewardsRange")
+	defer span.End()
+	return s.rewardsRange(idealAttestationRewardsBucket)
+}
+
+// SyncCommitteeRewardsRange returns the range of epochs whose sync committee rewards are saved, or
+// ErrNotFoundRewards if there are none.
+func (s *Store) SyncCommitteeRewardsRange(ctx context.Context) (*rewardsummary.EpochRange, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SyncCommitteeRewardsRange")
+	defer span.End()
+	return s.rewardsRange(syncCommitteeRewardsBucket)
+}
+
+// rewardsRange returns the range of epochs of a bucket keyed by epoch.
+func (s *Store) rewardsRange(bucket []byte) (*rewardsummary.EpochRange, error) {
+	var r *rewardsummary.EpochRange
+	err := s.db.View(func(tx engine.Tx) error {
+		c := tx.Bucket(bucket).Cursor()
+		first, _ := c.First()
+		last, _ := c.Last()
+		if first == nil || last == nil {
+			return ErrNotFoundRewards
+		}
+		r = &rewardsummary.EpochRange{
+			Earliest: primitives.Epoch(bytesutil.BytesToUint64BigEndian(first)),
+			Latest:   primitives.Epoch(bytesutil.BytesToUint64BigEndian(last)),
+		}
+		return nil
+	})
+	return r, err
+}
+
+// DeleteRewardsBefore deletes the attestation and sync committee rewards of the epochs before the given epoch.
+func (s *Store) DeleteRewardsBefore(ctx context.Context, epoch primitives.Epoch) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.DeleteRewardsBefore")
+	defer span.End()
+	return s.db.Update(func(tx engine.Tx) error {
+		for _, bucket := range [][]byte{attestationRewardsBucket, idealAttestationRewardsBucket, syncCommitteeRewardsBucket} {
+			bkt := tx.Bucket(bucket)
+			var keys [][]byte
+			c := bkt.Cursor()
+			for k, _ := c.First(); k != nil && bytesutil.BytesToUint64BigEndian(k[:8]) < uint64(epoch); k, _ = c.Next() {
+				keys = append(keys, bytesutil.SafeCopyBytes(k))
+			}
+			for _, k := range keys {
+				if err := bkt.Delete(k); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,93 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/rewardsummary"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func testAttestationRewards(n int, epoch primitives.Epoch) []rewardsummary.AttestationReward {
+	rewards := make([]rewardsummary.AttestationReward, n)
+	for i := range rewards {
+		rewards[i] = rewardsummary.AttestationReward{
+			EffectiveBalance: 32_000_000_000,
+			Head:             int64(epoch),
+			Source:           int64(i % 7),
+			Target:           -int64(i % 3),
+		}
+	}
+	return rewards
+}
+
+func TestStore_AttestationRewards(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	n := rewardsummary.ChunkSize + 10
+	ideal := []rewardsummary.IdealAttestationReward{{EffectiveBalance: 32_000_000_000, Head: 1, Source: 2, Target: 3}}
+	for _, epoch := range []primitives.Epoch{3, 4} {
+		require.NoError(t, db.SaveAttestationRewards(ctx, epoch, testAttestationRewards(n, epoch), ideal))
+	}
+
+	rewards, gotIdeal, err := db.AttestationRewards(ctx, 4)
+	require.NoError(t, err)
+	assert.DeepEqual(t, testAttestationRewards(n, 4), rewards)
+	assert.DeepEqual(t, ideal, gotIdeal)
+
+	_, _, err = db.AttestationRewards(ctx, 5)
+	require.ErrorIs(t, err, ErrNotFoundRewards)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	r, err := db.AttestationRewardsRange(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, &rewardsummary.EpochRange{Earliest: 3, Latest: 4}, r)
+}
+
+func TestStore_SyncCommitteeRewards(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	_, err := db.SyncCommitteeRewardsRange(ctx)
+	require.ErrorIs(t, err, ErrNotFoundRewards)
+
+	saved := &rewardsummary.SyncCommitteeRewards{ParticipantReward: 100, Members: []primitives.ValidatorIndex{5, 2, 5}}
+	require.NoError(t, db.SaveSyncCommitteeRewards(ctx, 7, saved))
+	rewards, err := db.SyncCommitteeRewards(ctx, 7)
+	require.NoError(t, err)
+	assert.DeepEqual(t, saved, rewards)
+	_, err = db.SyncCommitteeRewards(ctx, 8)
+	require.ErrorIs(t, err, ErrNotFoundRewards)
+
+	r, err := db.SyncCommitteeRewardsRange(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, &rewardsummary.EpochRange{Earliest: 7, Latest: 7}, r)
+}
+
+func TestStore_DeleteRewardsBefore(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	n := rewardsummary.ChunkSize + 10
+	for epoch := primitives.Epoch(1); epoch <= 5; epoch++ {
+		require.NoError(t, db.SaveAttestationRewards(ctx, epoch, testAttestationRewards(n, epoch), nil))
+		require.NoError(t, db.SaveSyncCommitteeRewards(ctx, epoch, &rewardsummary.SyncCommitteeRewards{}))
+	}
+	require.NoError(t, db.DeleteRewardsBefore(ctx, 4))
+
+	for epoch := primitives.Epoch(1); epoch < 4; epoch++ {
+		_, _, err := db.AttestationRewards(ctx, epoch)
+		require.ErrorIs(t, err, ErrNotFoundRewards)
+		_, err = db.SyncCommitteeRewards(ctx, epoch)
+		require.ErrorIs(t, err, ErrNotFoundRewards)
+	}
+	rewards, _, err := db.AttestationRewards(ctx, 4)
+	require.NoError(t, err)
+	assert.Equal(t, n, len(rewards))
+	r, err := db.AttestationRewardsRange(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, &rewardsummary.EpochRange{Earliest: 4, Latest: 5}, r)
+	r, err = db.SyncCommitteeRewardsRange(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, &rewardsummary.EpochRange{Earliest: 4, Latest: 5}, r)
+}
@@ -32,6 +32,31 @@ var (
 	finalizedBlockRootsIndexBucket = []byte("finalized-block-roots-index")
 	blockRootValidatorHashesBucket = []byte("block-root-validator-hashes")
 
+	// State diff buckets. The headers and data of the state diff hierarchy are keyed by slot, and the
+	// roots bucket indexes their slots by block root.
+	stateDiffBucket      = []byte("state-diffs")
+	stateDiffDataBucket  = []byte("state-diff-data")
+	stateDiffRootsBucket = []byte("state-diff-roots")
+
+	// Validator duty records, keyed by epoch and chunk of validator indices.
+	validatorHistoryBucket = []byte("validator-history")
+
+	// Reward summaries, keyed by epoch, and by epoch and chunk of validator indices for attestation rewards.
+	attestationRewardsBucket      = []byte("attestation-rewards")
+	idealAttestationRewardsBucket = []byte("ideal-attestation-rewards")
+	syncCommitteeRewardsBucket    = []byte("sync-committee-rewards")
+
+	// Peer records, keyed by peer ID.
+	peerRecordsBucket = []byte("peer-records")
+
+	// Blocks since the Deneb fork which are missing blobs in the blob archive, keyed by block root.
+	blobArchiveGapsBucket = []byte("blob-archive-gaps")
+
+	// Streamed backup buckets. The changes bucket logs the keys written since the last streamed backup, keyed by
+	// generation, bucket and key, and the metadata bucket holds the current generation and the last backup ID.
+	backupChangesBucket  = []byte("backup-changes")
+	backupMetadataBucket = []byte("backup-metadata")
+
 	// Specific item keys.
 	headBlockRootKey           = []byte("head-root")
 	genesisBlockRootKey        = []byte("genesis-root")
@@ -40,6 +65,8 @@ var (
 	finalizedCheckpointKey     = []byte("finalized-checkpoint")
 	powchainDataKey            = []byte("powchain-data")
 	lastValidatedCheckpointKey = []byte("last-validated-checkpoint")
+	backupGenerationKey        = []byte("generation")
+	backupLastIDKey            = []byte("last-backup-id")
 
 	// Below keys are used to identify objects are to be fork compatible.
 	// Objects that are only compatible with specific forks should be prefixed with such keys.
@@ -58,6 +85,10 @@ var (
 	originCheckpointBlockRootKey = []byte("origin-checkpoint-block-root")
 	// tracking data about an ongoing backfill
 	backfillStatusKey = []byte("backfill-status")
+	// lowest slot that has not been removed by historical pruning
+	earliestAvailableSlotKey = []byte("earliest-available-slot")
+	// progress of the scan of the blob archive for blocks missing blobs
+	blobArchiveScanCursorKey = []byte("blob-archive-scan-cursor")
 
 	// Deprecated: This index key was migrated in PR 6461. Do not use, except for migrations.
 	lastArchivedIndexKey = []byte("last-archived")
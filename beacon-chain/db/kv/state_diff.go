@@ -0,0 +1,519 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"time"
+
+	"github.com/golang/snappy"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/engine"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultStateDiffExponents are the levels of the state diff hierarchy as powers of two slots: a full snapshot
+// every 2^21 slots, diffs down to every 2^5 slots, which is every epoch on mainnet, and a diff at every slot
+// against the state of its epoch, so that no state is rebuilt by replaying blocks.
+var DefaultStateDiffExponents = []uint64{21, 18, 16, 13, 11, 9, 5, 0}
+
+// ErrStateDiffsDisabled is returned when saving a state diff in a database which was not opened with WithStateDiffs.
+var ErrStateDiffsDisabled = errors.New("state diffs are not enabled")
+
+// Kinds of state diff entries.
+const (
+	stateDiffSnapshot byte = iota
+	stateDiffDelta
+)
+
+// stateDiffHeaderSize is the size of a state diff header: its kind, the slot of its base and its block root.
+const stateDiffHeaderSize = 1 + 8 + 32
+
+// stateDiffs describes the state diff hierarchy. The state at a slot which is a multiple of 2^exponents[0] is saved
+// as a full snapshot, and the state at a slot which is a multiple of 2^exponents[i] is saved as a diff against the
+// state at the closest lower multiple of 2^exponents[i-1]. Any state of the hierarchy is then rebuilt from a
+// snapshot and at most one diff per level. With a finest level of 2^0 every state is in the hierarchy, otherwise
+// states between the slots of the finest level are rebuilt by replaying the blocks after the closest lower state
+// of the hierarchy.
+type stateDiffs struct {
+	exponents []uint64
+	// cache holds the encodings of recently used bases, as the same bases are used by consecutive diffs.
+	cache *lru.Cache
+	// indexes holds the block indexes of the recently used bases, by slot. The base of the diffs of the finest level
+	// only changes once per period of the next coarser level, while indexing it takes a pass over the whole state.
+	indexes *lru.Cache
+}
+
+// stateDiffBase is the encoding of a base along with the index of its blocks, which diffs against it are computed with.
+type stateDiffBase struct {
+	enc    []byte
+	blocks []deltaBlock
+}
+
+type stateDiffHeader struct {
+	kind byte
+	base primitives.Slot
+	root [32]byte
+}
+
+// WithStateDiffs saves the finalized states of the cold section as a hierarchy of snapshots and diffs, whose levels
+// are the given powers of two slots, from the coarsest to the finest.
+func WithStateDiffs(exponents []uint64) KVStoreOption {
+	return func(s *Store) {
+		s.stateDiffExponents = exponents
+	}
+}
+
+func newStateDiffs(exponents []uint64) (*stateDiffs, error) {
+	if len(exponents) == 0 {
+		return nil, errors.New("state diffs need at least one level")
+	}
+	for i, e := range exponents {
+		if e >= 64 {
+			return nil, errors.Errorf("state diff exponent %d is too large", e)
+		}
+		if i > 0 && e >= exponents[i-1] {
+			return nil, errors.New("state diff exponents must be in strictly decreasing order")
+		}
+	}
+	cache, err := lru.New(len(exponents))
+	if err != nil {
+		return nil, err
+	}
+	indexes, err := lru.New(len(exponents))
+	if err != nil {
+		return nil, err
+	}
+	return &stateDiffs{exponents: exponents, cache: cache, indexes: indexes}, nil
+}
+
+// level returns the coarsest level of the hierarchy which the slot belongs to, or -1 if it is not in the hierarchy.
+func (d *stateDiffs) level(slot primitives.Slot) int {
+	for i, e := range d.exponents {
+		if uint64(slot)%(1<<e) == 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// base returns the slot of the state which the diff at a slot of the given level is computed against.
+func (d *stateDiffs) base(slot primitives.Slot, level int) primitives.Slot {
+	interval := primitives.Slot(1) << d.exponents[level-1]
+	return slot - slot%interval
+}
+
+// StateDiffInterval returns the number of slots between the states of the finest level of the state diff
+// hierarchy, or zero if state diffs are disabled.
+func (s *Store) StateDiffInterval() primitives.Slot {
+	if s.stateDiffs == nil {
+		return 0
+	}
+	return primitives.Slot(1) << s.stateDiffs.exponents[len(s.stateDiffs.exponents)-1]
+}
+
+// StateDiffBaseInterval returns the number of slots between the states which the states of the finest level of the
+// state diff hierarchy are computed against, or zero if state diffs are disabled or the hierarchy has a single level.
+// The states of the finest level which are not at these slots are never the base of another state.
+func (s *Store) StateDiffBaseInterval() primitives.Slot {
+	if s.stateDiffs == nil || len(s.stateDiffs.exponents) < 2 {
+		return 0
+	}
+	return primitives.Slot(1) << s.stateDiffs.exponents[len(s.stateDiffs.exponents)-2]
+}
+
+// HasStateDiff returns true if the state at the slot is saved in the state diff hierarchy.
+func (s *Store) HasStateDiff(ctx context.Context, slot primitives.Slot) bool {
+	_, span := trace.StartSpan(ctx, "BeaconDB.HasStateDiff")
+	defer span.End()
+	has := false
+	if err := s.db.View(func(tx engine.Tx) error {
+		has = tx.Bucket(stateDiffBucket).Get(bytesutil.SlotToBytesBigEndian(slot)) != nil
+		return nil
+	}); err != nil {
+		log.WithError(err).Error("Could not check for state diff")
+	}
+	return has
+}
+
+// SaveStateDiff saves a finalized state in the state diff hierarchy, under the root of the latest block applied to
+// the state. The slot of the state must be a slot of the hierarchy. A diff is computed against the state of the
+// next coarser level, or against the lowest saved state above it when that state is missing, for instance before
+// the origin of a checkpoint synced node. The state is saved as a snapshot when there is no state to diff against.
+func (s *Store) SaveStateDiff(ctx context.Context, st state.ReadOnlyBeaconState, blockRoot [32]byte) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveStateDiff")
+	defer span.End()
+	if s.stateDiffs == nil {
+		return ErrStateDiffsDisabled
+	}
+	startTime := time.Now()
+	slot := st.Slot()
+	level := s.stateDiffs.level(slot)
+	if level < 0 {
+		return errors.Errorf("slot %d is not a slot of the state diff hierarchy", slot)
+	}
+	target, err := versionedStateSSZ(st)
+	if err != nil {
+		return err
+	}
+
+	h := stateDiffHeader{kind: stateDiffSnapshot, root: blockRoot}
+	if level > 0 {
+		base, ok, err := s.stateDiffBaseSlot(slot, s.stateDiffs.base(slot, level))
+		if err != nil {
+			return err
+		}
+		if ok {
+			h.kind = stateDiffDelta
+			h.base = base
+		}
+	}
+	var payload []byte
+	if h.kind == stateDiffDelta {
+		base, err := s.stateDiffBase(ctx, h.base)
+		if err != nil {
+			return errors.Wrapf(err, "could not load base state at slot %d", h.base)
+		}
+		payload = snappy.Encode(nil, encodeIndexedDelta(base.enc, base.blocks, target))
+	} else {
+		payload = snappy.Encode(nil, target)
+	}
+
+	key := bytesutil.SlotToBytesBigEndian(slot)
+	if err := s.db.Update(func(tx engine.Tx) error {
+		if err := tx.Bucket(stateDiffBucket).Put(key, encodeStateDiffHeader(h)); err != nil {
+			return err
+		}
+		if err := tx.Bucket(stateDiffDataBucket).Put(key, payload); err != nil {
+			return err
+		}
+		// The state at a skipped slot is saved under the root of the last block before it. The root keeps
+		// referring to the closest state to its block.
+		roots := tx.Bucket(stateDiffRootsBucket)
+		if roots.Get(blockRoot[:]) != nil {
+			return nil
+		}
+		return roots.Put(blockRoot[:], key)
+	}); err != nil {
+		return err
+	}
+	// States of the finest level are never a base.
+	if level < len(s.stateDiffs.exponents)-1 {
+		s.stateDiffs.cache.Add(slot, target)
+	}
+	log.WithFields(logrus.Fields{
+		"slot":     slot,
+		"snapshot": h.kind == stateDiffSnapshot,
+		"size":     len(payload),
+	}).Debug("Saved state diff")
+	stateSavingTime.Observe(float64(time.Since(startTime).Milliseconds()))
+	return nil
+}
+
+// stateDiffBase returns the encoding of the state at slot along with the index of its blocks, indexing it once for
+// all the diffs computed against it.
+func (s *Store) stateDiffBase(ctx context.Context, slot primitives.Slot) (*stateDiffBase, error) {
+	if b, ok := s.stateDiffs.indexes.Get(slot); ok {
+		return b.(*stateDiffBase), nil
+	}
+	enc, err := s.stateDiffBytes(ctx, slot)
+	if err != nil {
+		return nil, err
+	}
+	b := &stateDiffBase{enc: enc, blocks: indexDeltaBlocks(enc)}
+	s.stateDiffs.indexes.Add(slot, b)
+	return b, nil
+}
+
+// stateDiffBaseSlot returns the slot of the base of a diff at slot, given the slot of its base in the hierarchy.
+// It returns false if there is no saved state to use as a base.
+func (s *Store) stateDiffBaseSlot(slot, base primitives.Slot) (primitives.Slot, bool, error) {
+	var found primitives.Slot
+	ok := false
+	err := s.db.View(func(tx engine.Tx) error {
+		k, _ := tx.Bucket(stateDiffBucket).Cursor().Seek(bytesutil.SlotToBytesBigEndian(base))
+		if k == nil {
+			return nil
+		}
+		found = bytesutil.BytesToSlotBigEndian(k)
+		ok = found < slot
+		return nil
+	})
+	return found, ok, err
+}
+
+// stateFromDiffs returns the state saved in the state diff hierarchy under a block root, or nil if there is none.
+func (s *Store) stateFromDiffs(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error) {
+	var key []byte
+	if err := s.db.View(func(tx engine.Tx) error {
+		key = bytes.Clone(tx.Bucket(stateDiffRootsBucket).Get(blockRoot[:]))
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return nil, nil
+	}
+	enc, err := s.stateDiffBytes(ctx, bytesutil.BytesToSlotBigEndian(key))
+	if err != nil {
+		return nil, err
+	}
+	return decodeVersionedState(enc, nil)
+}
+
+// stateDiffBytes rebuilds the versioned ssz encoding of the state at slot from its snapshot and diffs.
+func (s *Store) stateDiffBytes(ctx context.Context, slot primitives.Slot) ([]byte, error) {
+	if s.stateDiffs != nil {
+		if enc, ok := s.stateDiffs.cache.Get(slot); ok {
+			return enc.([]byte), nil
+		}
+	}
+	// Walk down the hierarchy to a snapshot or a cached base, then apply the diffs on the way back up.
+	var chain []primitives.Slot
+	var payloads [][]byte
+	var enc []byte
+	for enc == nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		var h stateDiffHeader
+		var payload []byte
+		err := s.db.View(func(tx engine.Tx) error {
+			key := bytesutil.SlotToBytesBigEndian(slot)
+			hb := tx.Bucket(stateDiffBucket).Get(key)
+			if hb == nil {
+				return errors.Wrapf(ErrNotFoundState, "no state diff at slot %d", slot)
+			}
+			var err error
+			if h, err = decodeStateDiffHeader(hb); err != nil {
+				return err
+			}
+			payload, err = snappy.Decode(nil, tx.Bucket(stateDiffDataBucket).Get(key))
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		if h.kind == stateDiffSnapshot {
+			enc = payload
+			break
+		}
+		chain = append(chain, slot)
+		payloads = append(payloads, payload)
+		slot = h.base
+		if s.stateDiffs != nil {
+			if cached, ok := s.stateDiffs.cache.Get(slot); ok {
+				enc = cached.([]byte)
+			}
+		}
+	}
+	if s.stateDiffs != nil && s.stateDiffs.level(slot) < len(s.stateDiffs.exponents)-1 {
+		s.stateDiffs.cache.Add(slot, enc)
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		var err error
+		if enc, err = applyDelta(enc, payloads[i]); err != nil {
+			return nil, errors.Wrapf(err, "could not apply state diff at slot %d", chain[i])
+		}
+		if s.stateDiffs != nil && s.stateDiffs.level(chain[i]) < len(s.stateDiffs.exponents)-1 {
+			s.stateDiffs.cache.Add(chain[i], enc)
+		}
+	}
+	return enc, nil
+}
+
+// MigrateArchivedStatesToDiffs moves the finalized states saved in full at archived points into the state diff
+// hierarchy, and returns the number of states moved. Only the states at slots of the hierarchy are moved. The
+// genesis, justified and finalized states are added to the hierarchy, but also kept in full.
+func (s *Store) MigrateArchivedStatesToDiffs(ctx context.Context) (int, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.MigrateArchivedStatesToDiffs")
+	defer span.End()
+	if s.stateDiffs == nil {
+		return 0, ErrStateDiffsDisabled
+	}
+	finalized, err := s.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return 0, err
+	}
+	finalizedSlot, err := slots.EpochStart(finalized.Epoch)
+	if err != nil {
+		return 0, err
+	}
+
+	type archived struct {
+		slot  primitives.Slot
+		roots [][32]byte
+	}
+	var states []archived
+	if err := s.db.View(func(tx engine.Tx) error {
+		diffs := tx.Bucket(stateDiffBucket)
+		return tx.Bucket(stateSlotIndicesBucket).ForEach(func(k, v []byte) error {
+			slot := bytesutil.BytesToSlotBigEndian(k)
+			if slot > finalizedSlot || s.stateDiffs.level(slot) < 0 || diffs.Get(k) != nil {
+				return nil
+			}
+			roots, err := splitRoots(v)
+			if err != nil {
+				return errors.Wrapf(err, "could not decode state roots at slot %d", slot)
+			}
+			states = append(states, archived{slot: slot, roots: roots})
+			return nil
+		})
+	}); err != nil {
+		return 0, err
+	}
+
+	moved := 0
+	for _, a := range states {
+		for _, root := range a.roots {
+			if ctx.Err() != nil {
+				return moved, ctx.Err()
+			}
+			st, err := s.State(ctx, root)
+			if err != nil {
+				return moved, errors.Wrapf(err, "could not load state at slot %d", a.slot)
+			}
+			// A state at a skipped slot is indexed at the slot of its block, and is left in full.
+			if st == nil || st.IsNil() || st.Slot() != a.slot {
+				continue
+			}
+			if err := s.SaveStateDiff(ctx, st, root); err != nil {
+				return moved, err
+			}
+			if err := s.DeleteState(ctx, root); err != nil && !errors.Is(err, ErrDeleteJustifiedAndFinalized) {
+				return moved, err
+			}
+			moved++
+			// Only one state is finalized at a slot.
+			break
+		}
+	}
+	return moved, nil
+}
+
+// pruneStateDiffs deletes the states of the state diff hierarchy below the cutoff, except for the genesis state and the
+// states which the states above the cutoff are computed against.
+func (s *Store) pruneStateDiffs(ctx context.Context, tx engine.Tx, cutoff primitives.Slot) ([]primitives.Slot, error) {
+	headers := tx.Bucket(stateDiffBucket)
+	cutoffKey := bytesutil.SlotToBytesBigEndian(cutoff)
+	first := bytesutil.SlotToBytesBigEndian(1)
+	if k, _ := headers.Cursor().Seek(first); k == nil || bytes.Compare(k, cutoffKey) >= 0 {
+		return nil, nil
+	}
+
+	// The bases of the states above the cutoff are within one period of the coarsest level above the cutoff,
+	// as no diff is computed against a state below the closest snapshot.
+	keep := make(map[primitives.Slot]bool)
+	var period primitives.Slot
+	if s.stateDiffs != nil {
+		period = primitives.Slot(1) << s.stateDiffs.exponents[0]
+	}
+	c := headers.Cursor()
+	for k, v := c.Seek(cutoffKey); k != nil; k, v = c.Next() {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		slot := bytesutil.BytesToSlotBigEndian(k)
+		if period > 0 && slot >= cutoff+period {
+			break
+		}
+		h, err := decodeStateDiffHeader(v)
+		if err != nil {
+			return nil, err
+		}
+		for h.kind == stateDiffDelta && h.base < cutoff && !keep[h.base] {
+			keep[h.base] = true
+			hb := headers.Get(bytesutil.SlotToBytesBigEndian(h.base))
+			if hb == nil {
+				break
+			}
+			if h, err = decodeStateDiffHeader(hb); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var pruned []primitives.Slot
+	var keys [][]byte
+	c = headers.Cursor()
+	for k, v := c.Seek(first); k != nil && bytes.Compare(k, cutoffKey) < 0; k, v = c.Next() {
+		slot := bytesutil.BytesToSlotBigEndian(k)
+		if keep[slot] {
+			continue
+		}
+		h, err := decodeStateDiffHeader(v)
+		if err != nil {
+			return nil, err
+		}
+		roots := tx.Bucket(stateDiffRootsBucket)
+		if bytes.Equal(roots.Get(h.root[:]), k) {
+			if err := roots.Delete(h.root[:]); err != nil {
+				return nil, err
+			}
+		}
+		keys = append(keys, bytes.Clone(k))
+		pruned = append(pruned, slot)
+	}
+	// Keys are deleted once the cursor is done, as deletes move the cursors of some engines.
+	for _, k := range keys {
+		if err := headers.Delete(k); err != nil {
+			return nil, err
+		}
+		if err := tx.Bucket(stateDiffDataBucket).Delete(k); err != nil {
+			return nil, err
+		}
+	}
+	return pruned, nil
+}
+
+func encodeStateDiffHeader(h stateDiffHeader) []byte {
+	enc := make([]byte, stateDiffHeaderSize)
+	enc[0] = h.kind
+	binary.BigEndian.PutUint64(enc[1:9], uint64(h.base))
+	copy(enc[9:], h.root[:])
+	return enc
+}
+
+func decodeStateDiffHeader(enc []byte) (stateDiffHeader, error) {
+	if len(enc) != stateDiffHeaderSize {
+		return stateDiffHeader{}, errors.Errorf("invalid state diff header length %d", len(enc))
+	}
+	h := stateDiffHeader{kind: enc[0], base: primitives.Slot(binary.BigEndian.Uint64(enc[1:9]))}
+	copy(h.root[:], enc[9:])
+	if h.kind != stateDiffSnapshot && h.kind != stateDiffDelta {
+		return h, errors.Errorf("unknown state diff kind %d", h.kind)
+	}
+	return h, nil
+}
+
+// versionedStateSSZ returns the ssz encoding of a state, including its validators, prefixed with the key of its fork.
+func versionedStateSSZ(st state.ReadOnlyBeaconState) ([]byte, error) {
+	enc, err := st.MarshalSSZ()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal state")
+	}
+	var key []byte
+	switch st.Version() {
+	case version.Phase0:
+	case version.Altair:
+		key = altairKey
+	case version.Bellatrix:
+		key = bellatrixKey
+	case version.Capella:
+		key = capellaKey
+	case version.Deneb:
+		key = denebKey
+	case version.Electra:
+		key = electraKey
+	default:
+		return nil, errors.Errorf("unsupported state version %s", version.String(st.Version()))
+	}
+	return append(bytes.Clone(key), enc...), nil
+}
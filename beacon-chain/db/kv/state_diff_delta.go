@@ -0,0 +1,216 @@
+package kv
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// A delta turns a base encoding into a target encoding with three operations:
+//   - copy, which appends a range of the base,
+//   - xor, which appends bytes of the base at the current offset xored with the delta bytes,
+//   - insert, which appends the delta bytes.
+//
+// The current offset in the base follows the last copied byte, so that an xor against bytes which changed in place,
+// such as balances, leaves mostly zero bytes which compress well. Copies are found through an index of the blocks
+// of the base, so that data which moved, such as the fields after a list which grew, is still copied.
+const (
+	deltaOpCopy byte = iota
+	deltaOpXor
+	deltaOpInsert
+)
+
+// deltaBlockSize is the length of the blocks of the base which are indexed to find copies, and the shortest copy.
+const deltaBlockSize = 32
+
+// deltaHashBase is the multiplier of the rolling hash of a block.
+const deltaHashBase = 1099511628211
+
+type deltaBlock struct {
+	hash   uint64
+	offset uint32
+}
+
+// encodeDelta returns a delta which turns base into target.
+func encodeDelta(base, target []byte) []byte {
+	return encodeIndexedDelta(base, indexDeltaBlocks(base), target)
+}
+
+// encodeIndexedDelta returns a delta which turns base into target, given the index of the blocks of base.
+func encodeIndexedDelta(base []byte, idx []deltaBlock, target []byte) []byte {
+	// pow is deltaHashBase to the power of deltaBlockSize - 1, to remove the first byte from a rolling hash.
+	pow := uint64(1)
+	for i := 0; i < deltaBlockSize-1; i++ {
+		pow *= deltaHashBase
+	}
+
+	out := binary.AppendUvarint(nil, uint64(len(target)))
+	var (
+		pending   []byte
+		pendingOp byte
+	)
+	flush := func() {
+		if len(pending) == 0 {
+			return
+		}
+		out = append(out, pendingOp)
+		out = binary.AppendUvarint(out, uint64(len(pending)))
+		out = append(out, pending...)
+		pending = pending[:0]
+	}
+
+	i, at := 0, 0
+	var hash uint64
+	hashed := -1
+	for i < len(target) {
+		// Data which did not move is copied from the current offset.
+		if n := commonPrefix(target[i:], base[min(at, len(base)):]); n >= deltaBlockSize {
+			flush()
+			out = append(out, deltaOpCopy)
+			out = binary.AppendUvarint(out, uint64(at))
+			out = binary.AppendUvarint(out, uint64(n))
+			i += n
+			at += n
+			continue
+		}
+		if i+deltaBlockSize <= len(target) {
+			if i > 0 && hashed == i-1 {
+				hash = (hash-uint64(target[i-1])*pow)*deltaHashBase + uint64(target[i+deltaBlockSize-1])
+			} else {
+				hash = deltaHash(target[i : i+deltaBlockSize])
+			}
+			hashed = i
+			if off, n := findDeltaBlock(idx, hash, base, target[i:]); n >= deltaBlockSize {
+				flush()
+				out = append(out, deltaOpCopy)
+				out = binary.AppendUvarint(out, uint64(off))
+				out = binary.AppendUvarint(out, uint64(n))
+				i += n
+				at = off + n
+				continue
+			}
+		}
+		op, b := deltaOpInsert, target[i]
+		if at < len(base) {
+			op, b = deltaOpXor, target[i]^base[at]
+		}
+		if op != pendingOp {
+			flush()
+			pendingOp = op
+		}
+		pending = append(pending, b)
+		i++
+		at++
+	}
+	flush()
+	return out
+}
+
+// applyDelta returns the target encoding of a delta computed by encodeDelta against base.
+func applyDelta(base, delta []byte) ([]byte, error) {
+	size, n := binary.Uvarint(delta)
+	if n <= 0 {
+		return nil, errors.New("could not read target length of delta")
+	}
+	delta = delta[n:]
+	target := make([]byte, 0, size)
+	at := uint64(0)
+	for len(delta) > 0 {
+		op := delta[0]
+		delta = delta[1:]
+		switch op {
+		case deltaOpCopy:
+			off, n := binary.Uvarint(delta)
+			if n <= 0 {
+				return nil, errors.New("could not read copy offset of delta")
+			}
+			delta = delta[n:]
+			l, n := binary.Uvarint(delta)
+			if n <= 0 {
+				return nil, errors.New("could not read copy length of delta")
+			}
+			delta = delta[n:]
+			if off > uint64(len(base)) || l > uint64(len(base))-off {
+				return nil, errors.Errorf("delta copies %d bytes at offset %d of a base of %d bytes", l, off, len(base))
+			}
+			target = append(target, base[off:off+l]...)
+			at = off + l
+		case deltaOpXor, deltaOpInsert:
+			l, n := binary.Uvarint(delta)
+			if n <= 0 || l > uint64(len(delta)-n) {
+				return nil, errors.New("could not read bytes of delta")
+			}
+			b := delta[n : n+int(l)]
+			delta = delta[n+int(l):]
+			if op == deltaOpXor {
+				if at > uint64(len(base)) || l > uint64(len(base))-at {
+					return nil, errors.Errorf("delta xors %d bytes at offset %d of a base of %d bytes", l, at, len(base))
+				}
+				for j := range b {
+					target = append(target, b[j]^base[at+uint64(j)])
+				}
+			} else {
+				target = append(target, b...)
+			}
+			at += l
+		default:
+			return nil, errors.Errorf("unknown delta operation %d", op)
+		}
+	}
+	if uint64(len(target)) != size {
+		return nil, errors.Errorf("delta produced %d bytes, expected %d", len(target), size)
+	}
+	return target, nil
+}
+
+// indexDeltaBlocks returns the hashes of the blocks of base, sorted by hash.
+func indexDeltaBlocks(base []byte) []deltaBlock {
+	idx := make([]deltaBlock, 0, len(base)/deltaBlockSize)
+	for off := 0; off+deltaBlockSize <= len(base); off += deltaBlockSize {
+		idx = append(idx, deltaBlock{hash: deltaHash(base[off : off+deltaBlockSize]), offset: uint32(off)})
+	}
+	sort.Slice(idx, func(i, j int) bool {
+		if idx[i].hash != idx[j].hash {
+			return idx[i].hash < idx[j].hash
+		}
+		return idx[i].offset < idx[j].offset
+	})
+	return idx
+}
+
+// findDeltaBlock returns the offset of the longest match of target among the blocks of base with the given hash.
+func findDeltaBlock(idx []deltaBlock, hash uint64, base, target []byte) (int, int) {
+	bestOff, bestLen := 0, 0
+	for j := sort.Search(len(idx), func(j int) bool { return idx[j].hash >= hash }); j < len(idx) && idx[j].hash == hash; j++ {
+		off := int(idx[j].offset)
+		if n := commonPrefix(target, base[off:]); n > bestLen {
+			bestOff, bestLen = off, n
+		}
+	}
+	return bestOff, bestLen
+}
+
+func deltaHash(b []byte) uint64 {
+	var h uint64
+	for _, c := range b {
+		h = h*deltaHashBase + uint64(c)
+	}
+	return h
+}
+
+// commonPrefix returns the length of the common prefix of a and b.
+func commonPrefix(a, b []byte) int {
+	n := min(len(a), len(b))
+	// Compare large chunks first, as most of an encoding is usually unchanged.
+	const chunk = 256
+	i := 0
+	for i+chunk <= n && bytes.Equal(a[i:i+chunk], b[i:i+chunk]) {
+		i += chunk
+	}
+	for i < n && a[i] == b[i] {
+		i++
+	}
+	return i
+}
@@ -0,0 +1,74 @@
+package kv
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestDelta_Roundtrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	random := func(n int) []byte {
+		b := make([]byte, n)
+		r.Read(b)
+		return b
+	}
+	base := random(4096)
+
+	changed := bytes.Clone(base)
+	for i := 100; i < 4000; i += 97 {
+		changed[i]++
+	}
+	inserted := append(append(bytes.Clone(base[:1000]), random(100)...), base[1000:]...)
+	moved := append(bytes.Clone(base[2048:]), base[:2048]...)
+
+	tests := []struct {
+		name   string
+		base   []byte
+		target []byte
+	}{
+		{name: "identical", base: base, target: bytes.Clone(base)},
+		{name: "empty base", base: nil, target: base},
+		{name: "empty target", base: base, target: nil},
+		{name: "changed in place", base: base, target: changed},
+		{name: "inserted", base: base, target: inserted},
+		{name: "truncated", base: base, target: base[:3000]},
+		{name: "extended", base: base, target: append(bytes.Clone(base), random(500)...)},
+		{name: "moved", base: base, target: moved},
+		{name: "unrelated", base: base, target: random(2000)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delta := encodeDelta(tt.base, tt.target)
+			got, err := applyDelta(tt.base, delta)
+			require.NoError(t, err)
+			assert.DeepEqual(t, len(tt.target), len(got))
+			assert.Equal(t, true, bytes.Equal(tt.target, got))
+		})
+	}
+
+	// Data which is unchanged or moved is copied rather than repeated in the delta.
+	assert.Equal(t, true, len(encodeDelta(base, bytes.Clone(base))) < 16)
+	assert.Equal(t, true, len(encodeDelta(base, inserted)) < 200)
+	assert.Equal(t, true, len(encodeDelta(base, moved)) < 32)
+}
+
+func TestDelta_Invalid(t *testing.T) {
+	base := bytes.Repeat([]byte{1, 2, 3, 4}, 64)
+	target := append(bytes.Clone(base), 5)
+	delta := encodeDelta(base, target)
+
+	_, err := applyDelta(base, nil)
+	require.ErrorContains(t, "could not read target length", err)
+	_, err = applyDelta(base[:32], delta)
+	require.ErrorContains(t, "of a base of 32 bytes", err)
+	_, err = applyDelta(base, delta[:len(delta)-1])
+	require.ErrorContains(t, "could not read bytes of delta", err)
+	_, err = applyDelta(base, append(bytes.Clone(delta), 9))
+	require.ErrorContains(t, "unknown delta operation 9", err)
+	_, err = applyDelta(base, append(bytes.Clone(delta), deltaOpInsert, 1, 0))
+	require.ErrorContains(t, "delta produced 258 bytes, expected 257", err)
+}
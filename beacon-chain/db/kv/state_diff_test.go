@@ -0,0 +1,314 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/engine"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// testStateDiffExponents is a hierarchy with a snapshot every 64 slots, and diffs every 16 and 4 slots.
+var testStateDiffExponents = []uint64{6, 4, 2}
+
+func setupStateDiffDB(t *testing.T) *Store {
+	db, err := NewKVStore(context.Background(), t.TempDir(), WithStateDiffs(testStateDiffExponents))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+	return db
+}
+
+func stateDiffTestRoot(slot primitives.Slot) [32]byte {
+	return bytesutil.ToBytes32(append([]byte{'D'}, bytesutil.SlotToBytesBigEndian(slot)...))
+}
+
+// stateDiffTestState returns a copy of st at the given slot, with balances which differ at every slot.
+func stateDiffTestState(t *testing.T, st state.BeaconState, slot primitives.Slot) state.BeaconState {
+	cp := st.Copy()
+	require.NoError(t, cp.SetSlot(slot))
+	for i := 0; i < cp.NumValidators(); i += 3 {
+		require.NoError(t, cp.UpdateBalancesAtIndex(primitives.ValidatorIndex(i), uint64(slot)*1000+uint64(i)))
+	}
+	return cp
+}
+
+// saveStateDiffs saves the states from one slot to another in steps of 4 slots, and returns their hash tree roots.
+func saveStateDiffs(t *testing.T, db *Store, from, to primitives.Slot) map[primitives.Slot][32]byte {
+	ctx := context.Background()
+	genesis, _ := util.DeterministicGenesisStateAltair(t, 64)
+	roots := make(map[primitives.Slot][32]byte)
+	for slot := from; slot <= to; slot += 4 {
+		st := stateDiffTestState(t, genesis, slot)
+		require.NoError(t, db.SaveStateDiff(ctx, st, stateDiffTestRoot(slot)))
+		r, err := st.HashTreeRoot(ctx)
+		require.NoError(t, err)
+		roots[slot] = r
+	}
+	return roots
+}
+
+func stateDiffTestHeader(t *testing.T, db *Store, slot primitives.Slot) stateDiffHeader {
+	var h stateDiffHeader
+	require.NoError(t, db.db.View(func(tx engine.Tx) error {
+		var err error
+		h, err = decodeStateDiffHeader(tx.Bucket(stateDiffBucket).Get(bytesutil.SlotToBytesBigEndian(slot)))
+		return err
+	}))
+	return h
+}
+
+func TestStore_SaveStateDiff(t *testing.T) {
+	ctx := context.Background()
+	db := setupStateDiffDB(t)
+	assert.Equal(t, primitives.Slot(4), db.StateDiffInterval())
+	assert.Equal(t, primitives.Slot(16), db.StateDiffBaseInterval())
+
+	roots := saveStateDiffs(t, db, 0, 128)
+	// The block index of a base is built once for all the diffs against it.
+	assert.Equal(t, true, db.stateDiffs.indexes.Contains(primitives.Slot(112)))
+	b, ok := db.stateDiffs.indexes.Get(primitives.Slot(112))
+	require.Equal(t, true, ok)
+	base, err := db.stateDiffBytes(ctx, 112)
+	require.NoError(t, err)
+	assert.DeepEqual(t, indexDeltaBlocks(base), b.(*stateDiffBase).blocks)
+	// Rebuild every state from the database rather than from the cached bases.
+	db.stateDiffs.cache.Purge()
+	for slot, want := range roots {
+		blockRoot := stateDiffTestRoot(slot)
+		assert.Equal(t, true, db.HasStateDiff(ctx, slot))
+		assert.Equal(t, true, db.HasState(ctx, blockRoot))
+		st, err := db.State(ctx, blockRoot)
+		require.NoError(t, err)
+		require.NotNil(t, st)
+		assert.Equal(t, slot, st.Slot())
+		got, err := st.HashTreeRoot(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, "state at slot %d", slot)
+	}
+	assert.Equal(t, false, db.HasStateDiff(ctx, 5))
+
+	tests := []struct {
+		slot primitives.Slot
+		kind byte
+		base primitives.Slot
+	}{
+		{slot: 0, kind: stateDiffSnapshot},
+		{slot: 16, kind: stateDiffDelta, base: 0},
+		{slot: 20, kind: stateDiffDelta, base: 16},
+		{slot: 64, kind: stateDiffSnapshot},
+		{slot: 80, kind: stateDiffDelta, base: 64},
+		{slot: 92, kind: stateDiffDelta, base: 80},
+	}
+	for _, tt := range tests {
+		h := stateDiffTestHeader(t, db, tt.slot)
+		assert.Equal(t, tt.kind, h.kind, "kind of slot %d", tt.slot)
+		assert.Equal(t, tt.base, h.base, "base of slot %d", tt.slot)
+		assert.Equal(t, stateDiffTestRoot(tt.slot), h.root)
+	}
+
+	st, err := util.NewBeaconStateAltair()
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(5))
+	require.ErrorContains(t, "not a slot of the state diff hierarchy", db.SaveStateDiff(ctx, st, [32]byte{'a'}))
+}
+
+func TestStore_SaveStateDiff_MissingBase(t *testing.T) {
+	ctx := context.Background()
+	db := setupStateDiffDB(t)
+	// A node which starts after the closest snapshot diffs against the lowest state it has.
+	saveStateDiffs(t, db, 20, 28)
+	assert.Equal(t, stateDiffSnapshot, stateDiffTestHeader(t, db, 20).kind)
+	h := stateDiffTestHeader(t, db, 24)
+	assert.Equal(t, stateDiffDelta, h.kind)
+	assert.Equal(t, primitives.Slot(20), h.base)
+
+	db.stateDiffs.cache.Purge()
+	st, err := db.State(ctx, stateDiffTestRoot(28))
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Slot(28), st.Slot())
+}
+
+func TestStore_StateDiffsDisabled(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+	assert.Equal(t, primitives.Slot(0), db.StateDiffInterval())
+	assert.Equal(t, primitives.Slot(0), db.StateDiffBaseInterval())
+	st, err := util.NewBeaconStateAltair()
+	require.NoError(t, err)
+	require.ErrorIs(t, db.SaveStateDiff(ctx, st, [32]byte{'a'}), ErrStateDiffsDisabled)
+	_, err = db.MigrateArchivedStatesToDiffs(ctx)
+	require.ErrorIs(t, err, ErrStateDiffsDisabled)
+}
+
+func TestNewStateDiffs(t *testing.T) {
+	_, err := newStateDiffs(nil)
+	require.ErrorContains(t, "at least one level", err)
+	_, err = newStateDiffs([]uint64{4, 6})
+	require.ErrorContains(t, "strictly decreasing", err)
+	_, err = newStateDiffs([]uint64{64, 6})
+	require.ErrorContains(t, "too large", err)
+	d, err := newStateDiffs(testStateDiffExponents)
+	require.NoError(t, err)
+	assert.Equal(t, 0, d.level(128))
+	assert.Equal(t, 1, d.level(80))
+	assert.Equal(t, 2, d.level(84))
+	assert.Equal(t, -1, d.level(85))
+	assert.Equal(t, primitives.Slot(64), d.base(80, 1))
+	assert.Equal(t, primitives.Slot(80), d.base(84, 2))
+}
+
+func TestStore_MigrateArchivedStatesToDiffs(t *testing.T) {
+	ctx := context.Background()
+	db := setupStateDiffDB(t)
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, genesisBlockRoot))
+	blk := makeBlocks(t, 7, 1, genesisBlockRoot)[0]
+	require.NoError(t, db.SaveBlock(ctx, blk))
+	finalized, err := blk.Block().HashTreeRoot()
+	require.NoError(t, err)
+
+	genesis, _ := util.DeterministicGenesisStateAltair(t, 64)
+	roots := map[primitives.Slot][32]byte{0: stateDiffTestRoot(0), 4: stateDiffTestRoot(4), 6: stateDiffTestRoot(6), 8: finalized}
+	want := make(map[primitives.Slot][32]byte)
+	for slot, root := range roots {
+		st := stateDiffTestState(t, genesis, slot)
+		require.NoError(t, db.SaveState(ctx, st, root))
+		require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: slot, Root: root[:]}))
+		r, err := st.HashTreeRoot(ctx)
+		require.NoError(t, err)
+		want[slot] = r
+	}
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: slots.ToEpoch(8) + 1, Root: finalized[:]}))
+
+	n, err := db.MigrateArchivedStatesToDiffs(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	// Migrated states are not migrated again.
+	n, err = db.MigrateArchivedStatesToDiffs(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	hasFull := func(root [32]byte) bool {
+		var has bool
+		require.NoError(t, db.db.View(func(tx engine.Tx) error {
+			has = tx.Bucket(stateBucket).Get(root[:]) != nil
+			return nil
+		}))
+		return has
+	}
+	assert.Equal(t, false, hasFull(roots[4]))
+	assert.Equal(t, true, hasFull(roots[6]))
+	assert.Equal(t, true, hasFull(finalized))
+	assert.Equal(t, false, db.HasStateDiff(ctx, 6))
+
+	db.stateDiffs.cache.Purge()
+	for slot, r := range want {
+		st, err := db.State(ctx, roots[slot])
+		require.NoError(t, err)
+		got, err := st.HashTreeRoot(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, r, got, "state at slot %d", slot)
+	}
+}
+
+func TestStore_PruneStateDiffs(t *testing.T) {
+	ctx := context.Background()
+	db := setupStateDiffDB(t)
+	roots := saveStateDiffs(t, db, 0, 128)
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, genesisBlockRoot))
+	blk := makeBlocks(t, 127, 1, genesisBlockRoot)[0]
+	require.NoError(t, db.SaveBlock(ctx, blk))
+	finalized, err := blk.Block().HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: 128, Root: finalized[:]}))
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: slots.ToEpoch(128), Root: finalized[:]}))
+
+	cutoff := primitives.Slot(90)
+	_, err = db.DeleteHistoricalDataBeforeSlot(ctx, cutoff, 100)
+	require.NoError(t, err)
+
+	// The genesis state and the bases of the diffs above the cutoff are kept.
+	kept := map[primitives.Slot]bool{0: true, 64: true, 80: true}
+	for slot := range roots {
+		want := slot >= cutoff || kept[slot]
+		assert.Equal(t, want, db.HasStateDiff(ctx, slot), "state diff at slot %d", slot)
+		assert.Equal(t, want, db.HasState(ctx, stateDiffTestRoot(slot)), "state at slot %d", slot)
+	}
+	db.stateDiffs.cache.Purge()
+	for slot := cutoff; slot <= 128; slot++ {
+		want, ok := roots[slot]
+		if !ok {
+			continue
+		}
+		st, err := db.State(ctx, stateDiffTestRoot(slot))
+		require.NoError(t, err)
+		got, err := st.HashTreeRoot(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, "state at slot %d", slot)
+	}
+}
+
+// BenchmarkStore_SaveStateDiff_FinestLevel saves the diffs of the finest level of the default hierarchy, at every
+// slot, for a state with a validator set about the size of mainnet.
+func BenchmarkStore_SaveStateDiff_FinestLevel(b *testing.B) {
+	const numValidators = 1 << 20
+	ctx := context.Background()
+	db, err := NewKVStore(ctx, b.TempDir(), WithStateDiffs(DefaultStateDiffExponents))
+	require.NoError(b, err)
+	b.Cleanup(func() {
+		require.NoError(b, db.Close())
+	})
+
+	validators := make([]*ethpb.Validator, numValidators)
+	balances := make([]uint64, numValidators)
+	for i := range validators {
+		pubkey := make([]byte, 48)
+		copy(pubkey, bytesutil.Uint64ToBytesBigEndian(uint64(i)))
+		validators[i] = &ethpb.Validator{
+			PublicKey:                  pubkey,
+			WithdrawalCredentials:      make([]byte, 32),
+			EffectiveBalance:           32_000_000_000,
+			ActivationEligibilityEpoch: 0,
+			ActivationEpoch:            0,
+			ExitEpoch:                  ^primitives.Epoch(0),
+			WithdrawableEpoch:          ^primitives.Epoch(0),
+		}
+		balances[i] = 32_000_000_000 + uint64(i)
+	}
+	st, err := util.NewBeaconState()
+	require.NoError(b, err)
+	require.NoError(b, st.SetValidators(validators))
+	require.NoError(b, st.SetBalances(balances))
+	require.NoError(b, db.SaveStateDiff(ctx, st, stateDiffTestRoot(0)))
+
+	// Every slot of the first epoch is a diff of the finest level against the snapshot at slot 0.
+	save := func(b *testing.B, i int) {
+		slot := primitives.Slot(i%31 + 1)
+		require.NoError(b, st.SetSlot(slot))
+		for j := 0; j < numValidators; j += 1024 {
+			require.NoError(b, st.UpdateBalancesAtIndex(primitives.ValidatorIndex(j), uint64(i+j)))
+		}
+		require.NoError(b, db.SaveStateDiff(ctx, st, stateDiffTestRoot(slot)))
+	}
+	b.Run("cached base index", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			save(b, i)
+		}
+	})
+	b.Run("uncached base index", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			db.stateDiffs.indexes.Purge()
+			save(b, i)
+		}
+	})
+}
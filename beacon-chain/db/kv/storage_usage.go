@@ -0,0 +1,533 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/engine"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+)
+
+const (
+	// storageUsageCheckInterval is the number of keys read between checks for cancellation while scanning the database.
+	storageUsageCheckInterval = 10000
+)
+
+var (
+	// ErrStorageUsageNotReady is returned when storage usage is requested before the initial scan of the database completed.
+	ErrStorageUsageNotReady = errors.New("storage usage has not been computed yet")
+	// ErrStorageUsageNotTracked is returned when storage usage is requested from a database which does not track it.
+	ErrStorageUsageNotTracked = errors.New("storage usage tracking is not enabled")
+)
+
+var (
+	bucketKeysGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "db_beacon_bucket_keys",
+		Help: "The number of keys in each bucket of the beacon database.",
+	}, []string{"bucket"})
+	bucketBytesGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "db_beacon_bucket_bytes",
+		Help: "The size of the keys and values in each bucket of the beacon database.",
+	}, []string{"bucket"})
+	blocksByForkGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "db_beacon_blocks",
+		Help: "The number of blocks in the beacon database, by fork.",
+	}, []string{"fork"})
+	blockBytesByForkGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "db_beacon_block_bytes",
+		Help: "The size of the encoded blocks in the beacon database, by fork.",
+	}, []string{"fork"})
+	statesByForkGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "db_beacon_states",
+		Help: "The number of states in the beacon database, by fork.",
+	}, []string{"fork"})
+	stateBytesByForkGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "db_beacon_state_bytes",
+		Help: "The size of the encoded states in the beacon database, by fork. Validators stored separately from " +
+			"their states are counted in the state-validators bucket.",
+	}, []string{"fork"})
+)
+
+// BucketUsage is the number of keys in a bucket, and the size of those keys and their values.
+// Bytes does not include the page overhead and free pages of the database file.
+type BucketUsage struct {
+	Name  string
+	Keys  uint64
+	Bytes uint64
+}
+
+// ForkUsage is the number of blocks or states of a fork, and the size of their encoding.
+type ForkUsage struct {
+	Version int
+	Count   uint64
+	Bytes   uint64
+}
+
+// StorageUsage breaks down the space used by the database by bucket, and the space used by blocks and states by fork.
+type StorageUsage struct {
+	Buckets []BucketUsage
+	Blocks  []ForkUsage
+	States  []ForkUsage
+	// Updated is the time at which the totals last changed.
+	Updated time.Time
+}
+
+// storageUsage holds the totals of the database. They are computed by a single scan of the database, and then kept up
+// to date with the changes of every committed read-write transaction.
+type storageUsage struct {
+	sync.RWMutex
+	buckets map[string]BucketUsage
+	blocks  map[int]ForkUsage
+	states  map[int]ForkUsage
+	updated time.Time
+	tracked bool
+	// While the initial scan runs, the changes committed after its snapshot of the database are held in pending, and
+	// applied to the totals of the scan once it completes.
+	scanning bool
+	pending  []*usageDelta
+	// commits is held for reading by read-write transactions until their changes are recorded, and for writing by
+	// the initial scan until it has opened its snapshot, so that every change is either seen by the scan or pending.
+	commits sync.RWMutex
+}
+
+// StorageUsage returns the storage usage of the database. Requests never scan the database.
+func (s *Store) StorageUsage() (*StorageUsage, error) {
+	s.usage.RLock()
+	defer s.usage.RUnlock()
+	if !s.usage.tracked {
+		return nil, ErrStorageUsageNotTracked
+	}
+	if s.usage.updated.IsZero() {
+		return nil, ErrStorageUsageNotReady
+	}
+	u := &StorageUsage{
+		Buckets: make([]BucketUsage, 0, len(s.usage.buckets)),
+		Blocks:  sortedForkUsage(s.usage.blocks),
+		States:  sortedForkUsage(s.usage.states),
+		Updated: s.usage.updated,
+	}
+	for _, b := range s.usage.buckets {
+		u.Buckets = append(u.Buckets, b)
+	}
+	sort.Slice(u.Buckets, func(i, j int) bool {
+		return u.Buckets[i].Name < u.Buckets[j].Name
+	})
+	return u, nil
+}
+
+// TrackStorageUsage starts tracking the storage usage of the database and its metrics. The totals are computed by a
+// single scan of the database, which reads every key in one read transaction, and are then updated with the changes
+// of every read-write transaction. The scan is abandoned if the context is cancelled.
+func (s *Store) TrackStorageUsage(ctx context.Context) {
+	if err := s.scanStorageUsage(ctx); err != nil {
+		if ctx.Err() != nil || errors.Is(err, engine.ErrDatabaseNotOpen) {
+			return
+		}
+		log.WithError(err).Error("Could not compute database storage usage")
+	}
+}
+
+func (s *Store) scanStorageUsage(ctx context.Context) error {
+	s.usage.commits.Lock()
+	var once sync.Once
+	release := func() { once.Do(s.usage.commits.Unlock) }
+	defer release()
+	s.usage.Lock()
+	s.usage.tracked = true
+	s.usage.scanning = true
+	s.usage.pending = nil
+	s.usage.Unlock()
+
+	var buckets map[string]BucketUsage
+	var blocks, states map[int]ForkUsage
+	err := s.db.View(func(tx engine.Tx) error {
+		// The snapshot of the transaction is open, later commits are recorded as pending.
+		release()
+		var err error
+		buckets, blocks, states, err = storageUsageOf(ctx, tx)
+		return err
+	})
+
+	s.usage.Lock()
+	defer s.usage.Unlock()
+	if err != nil {
+		s.usage.tracked = false
+		s.usage.scanning = false
+		s.usage.pending = nil
+		return err
+	}
+	s.usage.buckets, s.usage.blocks, s.usage.states = buckets, blocks, states
+	for _, d := range s.usage.pending {
+		s.usage.apply(d)
+	}
+	s.usage.pending = nil
+	s.usage.scanning = false
+	s.usage.updated = time.Now()
+	for _, b := range s.usage.buckets {
+		bucketKeysGauge.WithLabelValues(b.Name).Set(float64(b.Keys))
+		bucketBytesGauge.WithLabelValues(b.Name).Set(float64(b.Bytes))
+	}
+	setForkUsageGauges(blocksByForkGauge, blockBytesByForkGauge, s.usage.blocks)
+	setForkUsageGauges(statesByForkGauge, stateBytesByForkGauge, s.usage.states)
+	return nil
+}
+
+// storageUsageOf counts the keys of every bucket and their size. Blocks and states are also broken down by fork.
+func storageUsageOf(ctx context.Context, tx engine.Tx) (map[string]BucketUsage, map[int]ForkUsage, map[int]ForkUsage, error) {
+	buckets := make(map[string]BucketUsage)
+	blocks := make(map[int]ForkUsage)
+	states := make(map[int]ForkUsage)
+	n := 0
+	err := tx.ForEach(func(name []byte, b engine.Bucket) error {
+		u := BucketUsage{Name: string(name)}
+		forks := forkUsageOf(name, blocks, states)
+		err := b.ForEach(func(k, v []byte) error {
+			n++
+			if n%storageUsageCheckInterval == 0 && ctx.Err() != nil {
+				return ctx.Err()
+			}
+			// Nested buckets have a nil value.
+			if v == nil {
+				return nil
+			}
+			u.Keys++
+			u.Bytes += uint64(len(k) + len(v))
+			if forks != nil {
+				addForkUsage(forks, name, k, v, 1)
+			}
+			return nil
+		})
+		if err != nil {
+			return errors.Wrapf(err, "could not compute usage of bucket %s", name)
+		}
+		buckets[u.Name] = u
+		return nil
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return buckets, blocks, states, nil
+}
+
+// forkUsageOf returns the fork usage map which the values of a bucket are counted in, or nil if the bucket holds
+// neither blocks nor states.
+func forkUsageOf(name []byte, blocks, states map[int]ForkUsage) map[int]ForkUsage {
+	switch {
+	case bytes.Equal(name, blocksBucket):
+		return blocks
+	case bytes.Equal(name, stateBucket):
+		return states
+	default:
+		return nil
+	}
+}
+
+// addForkUsage adds (sign 1) or removes (sign -1) an encoded block or state from the usage of its fork.
+func addForkUsage(forks map[int]ForkUsage, bucket, k, v []byte, sign int64) {
+	// Blocks and states are keyed by root, other keys in their buckets hold metadata.
+	if len(k) != hashLength {
+		return
+	}
+	ver, err := encodingVersion(v)
+	if err != nil {
+		log.WithError(err).WithField("bucket", string(bucket)).Debug("Could not read version of encoded value")
+		return
+	}
+	f := forks[ver]
+	f.Version = ver
+	f.Count = uint64(int64(f.Count) + sign)
+	f.Bytes = uint64(int64(f.Bytes) + sign*int64(len(v)))
+	forks[ver] = f
+}
+
+// bucketDelta is the change of the usage of a bucket made by a transaction. A bucket which is deleted by the
+// transaction has its earlier changes dropped, and its totals reset before the later changes are added. Removed
+// blocks and states make the unsigned fork totals of the delta wrap around, which cancels out once they are added to
+// the totals of the database.
+type bucketDelta struct {
+	reset   bool
+	deleted bool
+	keys    int64
+	bytes   int64
+	forks   map[int]ForkUsage
+}
+
+// usageDelta is the change of the storage usage made by a read-write transaction.
+type usageDelta struct {
+	buckets map[string]*bucketDelta
+}
+
+func (d *usageDelta) bucket(name []byte) *bucketDelta {
+	bd, ok := d.buckets[string(name)]
+	if !ok {
+		bd = &bucketDelta{}
+		if bytes.Equal(name, blocksBucket) || bytes.Equal(name, stateBucket) {
+			bd.forks = make(map[int]ForkUsage)
+		}
+		d.buckets[string(name)] = bd
+	}
+	return bd
+}
+
+// apply adds the changes of a committed transaction to the totals and their metrics. It must be called with the
+// lock held.
+func (u *storageUsage) apply(d *usageDelta) {
+	for name, bd := range d.buckets {
+		forks := forkUsageOf([]byte(name), u.blocks, u.states)
+		if bd.reset {
+			delete(u.buckets, name)
+			for v := range forks {
+				delete(forks, v)
+			}
+		}
+		if bd.deleted {
+			bucketKeysGauge.DeleteLabelValues(name)
+			bucketBytesGauge.DeleteLabelValues(name)
+		} else {
+			b := u.buckets[name]
+			b.Name = name
+			b.Keys = uint64(int64(b.Keys) + bd.keys)
+			b.Bytes = uint64(int64(b.Bytes) + bd.bytes)
+			u.buckets[name] = b
+			bucketKeysGauge.WithLabelValues(name).Set(float64(b.Keys))
+			bucketBytesGauge.WithLabelValues(name).Set(float64(b.Bytes))
+		}
+		if forks == nil {
+			continue
+		}
+		for v, fd := range bd.forks {
+			f := forks[v]
+			f.Version = v
+			f.Count += fd.Count
+			f.Bytes += fd.Bytes
+			if f.Count == 0 {
+				delete(forks, v)
+				continue
+			}
+			forks[v] = f
+		}
+		if name == string(blocksBucket) {
+			setForkUsageGauges(blocksByForkGauge, blockBytesByForkGauge, forks)
+		} else {
+			setForkUsageGauges(statesByForkGauge, stateBytesByForkGauge, forks)
+		}
+	}
+	u.updated = time.Now()
+}
+
+// usageTrackingDB records the changes of the read-write transactions of the database in its storage usage, once
+// storage usage is tracked.
+type usageTrackingDB struct {
+	engine.DB
+	usage *storageUsage
+}
+
+func (db *usageTrackingDB) Update(fn func(engine.Tx) error) error {
+	db.usage.commits.RLock()
+	defer db.usage.commits.RUnlock()
+	db.usage.RLock()
+	tracked := db.usage.tracked
+	db.usage.RUnlock()
+	if !tracked {
+		return db.DB.Update(fn)
+	}
+	d := &usageDelta{buckets: make(map[string]*bucketDelta)}
+	if err := db.DB.Update(func(tx engine.Tx) error {
+		return fn(&usageTrackingTx{Tx: tx, delta: d})
+	}); err != nil {
+		return err
+	}
+	db.usage.Lock()
+	defer db.usage.Unlock()
+	if db.usage.scanning {
+		db.usage.pending = append(db.usage.pending, d)
+		return nil
+	}
+	if db.usage.tracked {
+		db.usage.apply(d)
+	}
+	return nil
+}
+
+type usageTrackingTx struct {
+	engine.Tx
+	delta *usageDelta
+}
+
+func (tx *usageTrackingTx) Bucket(name []byte) engine.Bucket {
+	b := tx.Tx.Bucket(name)
+	if b == nil {
+		return nil
+	}
+	return &usageTrackingBucket{Bucket: b, name: name, delta: tx.delta}
+}
+
+func (tx *usageTrackingTx) CreateBucket(name []byte) (engine.Bucket, error) {
+	b, err := tx.Tx.CreateBucket(name)
+	if err != nil {
+		return nil, err
+	}
+	tx.delta.bucket(name).deleted = false
+	return &usageTrackingBucket{Bucket: b, name: name, delta: tx.delta}, nil
+}
+
+func (tx *usageTrackingTx) CreateBucketIfNotExists(name []byte) (engine.Bucket, error) {
+	b, err := tx.Tx.CreateBucketIfNotExists(name)
+	if err != nil {
+		return nil, err
+	}
+	tx.delta.bucket(name).deleted = false
+	return &usageTrackingBucket{Bucket: b, name: name, delta: tx.delta}, nil
+}
+
+func (tx *usageTrackingTx) DeleteBucket(name []byte) error {
+	if err := tx.Tx.DeleteBucket(name); err != nil {
+		return err
+	}
+	bd := tx.delta.bucket(name)
+	*bd = bucketDelta{reset: true, deleted: true, forks: bd.forks}
+	for v := range bd.forks {
+		delete(bd.forks, v)
+	}
+	return nil
+}
+
+func (tx *usageTrackingTx) ForEach(fn func(name []byte, b engine.Bucket) error) error {
+	return tx.Tx.ForEach(func(name []byte, b engine.Bucket) error {
+		return fn(name, &usageTrackingBucket{Bucket: b, name: name, delta: tx.delta})
+	})
+}
+
+type usageTrackingBucket struct {
+	engine.Bucket
+	name  []byte
+	delta *usageDelta
+}
+
+func (b *usageTrackingBucket) Put(key, value []byte) error {
+	old := b.Bucket.Get(key)
+	oldLen := len(old)
+	bd := b.delta.bucket(b.name)
+	if old != nil && bd.forks != nil {
+		addForkUsage(bd.forks, b.name, key, old, -1)
+	}
+	if err := b.Bucket.Put(key, value); err != nil {
+		return err
+	}
+	if old == nil {
+		bd.keys++
+		bd.bytes += int64(len(key))
+	}
+	bd.bytes += int64(len(value) - oldLen)
+	if bd.forks != nil {
+		addForkUsage(bd.forks, b.name, key, value, 1)
+	}
+	return nil
+}
+
+func (b *usageTrackingBucket) Delete(key []byte) error {
+	old := b.Bucket.Get(key)
+	if old == nil {
+		return b.Bucket.Delete(key)
+	}
+	oldLen := len(old)
+	bd := b.delta.bucket(b.name)
+	if bd.forks != nil {
+		addForkUsage(bd.forks, b.name, key, old, -1)
+	}
+	if err := b.Bucket.Delete(key); err != nil {
+		return err
+	}
+	bd.keys--
+	bd.bytes -= int64(len(key) + oldLen)
+	return nil
+}
+
+// encodingVersion returns the fork of an encoded block or state, which is given by the key prefixed to its ssz
+// encoding. The prefix is read from the first literal of the snappy block where possible, so that the value does
+// not have to be decompressed.
+func encodingVersion(enc []byte) (int, error) {
+	prefix := snappyLiteralPrefix(enc)
+	// The blind bellatrix key is the longest version key.
+	if len(prefix) <= len(bellatrixBlindKey) {
+		dec, err := snappy.Decode(nil, enc)
+		if err != nil {
+			return 0, errors.Wrap(err, "could not snappy decode value")
+		}
+		prefix = dec
+	}
+	switch {
+	case hasElectraKey(prefix), hasElectraBlindKey(prefix):
+		return version.Electra, nil
+	case hasDenebKey(prefix), hasDenebBlindKey(prefix):
+		return version.Deneb, nil
+	case hasCapellaKey(prefix), hasCapellaBlindKey(prefix):
+		return version.Capella, nil
+	case hasBellatrixKey(prefix), hasBellatrixBlindKey(prefix):
+		return version.Bellatrix, nil
+	case hasAltairKey(prefix):
+		return version.Altair, nil
+	default:
+		return version.Phase0, nil
+	}
+}
+
+// snappyLiteralPrefix returns the bytes of the literal which starts the snappy block enc, or nil if the block does not
+// start with a literal. Encoders always start a block with a literal, as there is no earlier data to copy from.
+func snappyLiteralPrefix(enc []byte) []byte {
+	_, n := binary.Uvarint(enc)
+	if n <= 0 || n >= len(enc) {
+		return nil
+	}
+	enc = enc[n:]
+	tag := enc[0]
+	enc = enc[1:]
+	if tag&0x03 != 0 {
+		return nil
+	}
+	l := uint64(tag >> 2)
+	// Lengths of 60 and above are stored in the following 1 to 4 bytes, in little endian order.
+	if l >= 60 {
+		w := int(l - 59)
+		if len(enc) < w {
+			return nil
+		}
+		l = 0
+		for i := 0; i < w; i++ {
+			l |= uint64(enc[i]) << (8 * i)
+		}
+		enc = enc[w:]
+	}
+	l++
+	if uint64(len(enc)) < l {
+		return nil
+	}
+	return enc[:l]
+}
+
+func sortedForkUsage(forks map[int]ForkUsage) []ForkUsage {
+	u := make([]ForkUsage, 0, len(forks))
+	for _, f := range forks {
+		u = append(u, f)
+	}
+	sort.Slice(u, func(i, j int) bool {
+		return u[i].Version < u[j].Version
+	})
+	return u
+}
+
+func setForkUsageGauges(count, size *prometheus.GaugeVec, forks map[int]ForkUsage) {
+	for _, v := range version.All() {
+		f := forks[v]
+		count.WithLabelValues(version.String(v)).Set(float64(f.Count))
+		size.WithLabelValues(version.String(v)).Set(float64(f.Bytes))
+	}
+}
@@ -0,0 +1,125 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/engine"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestStore_StorageUsage(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	_, err := db.StorageUsage()
+	require.ErrorIs(t, err, ErrStorageUsageNotTracked)
+
+	blks := makeBlocks(t, 0, 3, genesisBlockRoot)
+	deneb := util.NewBeaconBlockDeneb()
+	deneb.Block.Slot = 4
+	denebBlk, err := blocks.NewSignedBeaconBlock(deneb)
+	require.NoError(t, err)
+	require.NoError(t, db.SaveBlocks(ctx, append(blks, interfaces.ReadOnlySignedBeaconBlock(denebBlk))))
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, genesisBlockRoot))
+
+	st, err := util.NewBeaconStateAltair()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveState(ctx, st, [32]byte{'a'}))
+	st0, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveState(ctx, st0, [32]byte{'b'}))
+
+	db.TrackStorageUsage(ctx)
+	u, err := db.StorageUsage()
+	require.NoError(t, err)
+	assert.Equal(t, false, u.Updated.IsZero())
+
+	buckets := make(map[string]BucketUsage)
+	for _, b := range u.Buckets {
+		buckets[b.Name] = b
+	}
+	// The genesis root is stored in the blocks bucket as well.
+	assert.Equal(t, uint64(5), buckets[string(blocksBucket)].Keys)
+	assert.Equal(t, uint64(2), buckets[string(stateBucket)].Keys)
+	assert.Equal(t, uint64(4), buckets[string(blockSlotIndicesBucket)].Keys)
+	assert.NotEqual(t, uint64(0), buckets[string(blocksBucket)].Bytes)
+
+	require.Equal(t, 2, len(u.Blocks))
+	assert.Equal(t, version.Phase0, u.Blocks[0].Version)
+	assert.Equal(t, uint64(3), u.Blocks[0].Count)
+	assert.Equal(t, version.Deneb, u.Blocks[1].Version)
+	assert.Equal(t, uint64(1), u.Blocks[1].Count)
+	require.Equal(t, 2, len(u.States))
+	assert.Equal(t, version.Phase0, u.States[0].Version)
+	assert.Equal(t, version.Altair, u.States[1].Version)
+	assert.Equal(t, buckets[string(stateBucket)].Bytes-2*hashLength, u.States[0].Bytes+u.States[1].Bytes)
+
+	// Later writes update the totals without scanning the database again.
+	require.NoError(t, db.DeleteBlock(ctx, blks[2].Block().ParentRoot()))
+	require.NoError(t, db.SaveState(ctx, st, [32]byte{'b'}))
+	require.NoError(t, db.DeleteState(ctx, [32]byte{'a'}))
+	u, err = db.StorageUsage()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), u.Blocks[0].Count)
+	require.Equal(t, 1, len(u.States))
+	assert.Equal(t, version.Altair, u.States[0].Version)
+	assert.Equal(t, uint64(1), u.States[0].Count)
+
+	var scanned map[string]BucketUsage
+	var scannedBlocks, scannedStates map[int]ForkUsage
+	require.NoError(t, db.db.View(func(tx engine.Tx) error {
+		scanned, scannedBlocks, scannedStates, err = storageUsageOf(ctx, tx)
+		return err
+	}))
+	require.Equal(t, len(scanned), len(u.Buckets))
+	for _, b := range u.Buckets {
+		assert.DeepEqual(t, scanned[b.Name], b)
+	}
+	assert.DeepEqual(t, sortedForkUsage(scannedBlocks), u.Blocks)
+	assert.DeepEqual(t, sortedForkUsage(scannedStates), u.States)
+}
+
+func TestStore_StorageUsagePendingDuringScan(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	// Writes committed while the scan is running are applied to the totals of the scan once it completes.
+	db.usage.commits.Lock()
+	db.usage.tracked, db.usage.scanning = true, true
+	db.usage.commits.Unlock()
+	require.NoError(t, db.SaveBlock(ctx, makeBlocks(t, 0, 1, genesisBlockRoot)[0]))
+	_, err := db.StorageUsage()
+	require.ErrorIs(t, err, ErrStorageUsageNotReady)
+	require.Equal(t, 1, len(db.usage.pending))
+}
+
+func TestEncodingVersion(t *testing.T) {
+	long := make([]byte, 1000)
+	tests := []struct {
+		name string
+		enc  []byte
+		want int
+	}{
+		{name: "phase0", enc: snappy.Encode(nil, long), want: version.Phase0},
+		{name: "altair", enc: snappy.Encode(nil, append(altairKey, long...)), want: version.Altair},
+		{name: "blind bellatrix", enc: snappy.Encode(nil, append(bellatrixBlindKey, long...)), want: version.Bellatrix},
+		{name: "electra", enc: snappy.Encode(nil, append(electraKey, long...)), want: version.Electra},
+		{name: "short value", enc: snappy.Encode(nil, append(denebKey, 1)), want: version.Deneb},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := encodingVersion(tt.enc)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, v)
+		})
+	}
+	_, err := encodingVersion([]byte{0xff})
+	require.ErrorContains(t, "could not snappy decode value", err)
+}
@@ -0,0 +1,101 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/dutyhistory"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/engine"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+)
+
+// validatorHistoryKey is the epoch followed by the chunk index, so that the records of an epoch are contiguous and
+// old epochs can be pruned in key order.
+func validatorHistoryKey(epoch primitives.Epoch, chunk uint64) []byte {
+	return append(bytesutil.Uint64ToBytesBigEndian(uint64(epoch)), bytesutil.Uint64ToBytesBigEndian(chunk)...)
+}
+
+// SaveValidatorHistory saves the duty records of every validator for an epoch, indexed by validator index.
+func (s *Store) SaveValidatorHistory(ctx context.Context, epoch primitives.Epoch, records []dutyhistory.Record) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveValidatorHistory")
+	defer span.End()
+	return s.db.Update(func(tx engine.Tx) error {
+		bkt := tx.Bucket(validatorHistoryBucket)
+		for start := 0; start < len(records); start += dutyhistory.ChunkSize {
+			end := start + dutyhistory.ChunkSize
+			if end > len(records) {
+				end = len(records)
+			}
+			key := validatorHistoryKey(epoch, uint64(start/dutyhistory.ChunkSize))
+			if err := bkt.Put(key, dutyhistory.EncodeChunk(records[start:end])); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ValidatorHistory returns the duty records of a validator from one epoch to another, both included, in epoch order.
+// Epochs without a record of the validator are skipped.
+func (s *Store) ValidatorHistory(ctx context.Context, idx primitives.ValidatorIndex, from, to primitives.Epoch) ([]*dutyhistory.EpochRecord, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.ValidatorHistory")
+	defer span.End()
+	if from > to {
+		return nil, errors.Errorf("from epoch %d is after to epoch %d", from, to)
+	}
+	chunk := dutyhistory.Chunk(idx)
+	offset := int(uint64(idx) % dutyhistory.ChunkSize)
+	var history []*dutyhistory.EpochRecord
+	err := s.db.View(func(tx engine.Tx) error {
+		bkt := tx.Bucket(validatorHistoryBucket)
+		c := bkt.Cursor()
+		// Only the saved epochs are visited, by seeking the first key of the next epoch after each one.
+		k, _ := c.Seek(validatorHistoryKey(from, 0))
+		for k != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			epoch := primitives.Epoch(bytesutil.BytesToUint64BigEndian(k[:8]))
+			if epoch > to {
+				return nil
+			}
+			if enc := bkt.Get(validatorHistoryKey(epoch, chunk)); enc != nil {
+				records, err := dutyhistory.DecodeChunk(enc)
+				if err != nil {
+					return errors.Wrapf(err, "could not decode records of epoch %d", epoch)
+				}
+				if offset < len(records) {
+					history = append(history, &dutyhistory.EpochRecord{Epoch: epoch, Record: records[offset]})
+				}
+			}
+			if epoch == to {
+				return nil
+			}
+			k, _ = c.Seek(validatorHistoryKey(epoch+1, 0))
+		}
+		return nil
+	})
+	return history, err
+}
+
+// DeleteValidatorHistoryBefore deletes the duty records of the epochs before the given epoch.
+func (s *Store) DeleteValidatorHistoryBefore(ctx context.Context, epoch primitives.Epoch) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.DeleteValidatorHistoryBefore")
+	defer span.End()
+	return s.db.Update(func(tx engine.Tx) error {
+		bkt := tx.Bucket(validatorHistoryBucket)
+		var keys [][]byte
+		c := bkt.Cursor()
+		for k, _ := c.First(); k != nil && bytesutil.BytesToUint64BigEndian(k[:8]) < uint64(epoch); k, _ = c.Next() {
+			keys = append(keys, bytesutil.SafeCopyBytes(k))
+		}
+		for _, k := range keys {
+			if err := bkt.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,83 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/dutyhistory"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func testValidatorHistoryRecords(n int, epoch primitives.Epoch) []dutyhistory.Record {
+	records := make([]dutyhistory.Record, n)
+	for i := range records {
+		records[i] = dutyhistory.Record{
+			Active:         true,
+			CorrectSource:  true,
+			CorrectTarget:  i%2 == 0,
+			Included:       true,
+			InclusionDelay: primitives.Slot(uint64(epoch)%4 + 1),
+			Proposed:       uint8(i % 3),
+		}
+	}
+	return records
+}
+
+func TestStore_ValidatorHistory(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	n := dutyhistory.ChunkSize + 10
+	for _, epoch := range []primitives.Epoch{3, 4, 6, 9} {
+		require.NoError(t, db.SaveValidatorHistory(ctx, epoch, testValidatorHistoryRecords(n, epoch)))
+	}
+
+	t.Run("range", func(t *testing.T) {
+		history, err := db.ValidatorHistory(ctx, 5, 4, 9)
+		require.NoError(t, err)
+		require.Equal(t, 3, len(history))
+		for i, epoch := range []primitives.Epoch{4, 6, 9} {
+			assert.Equal(t, epoch, history[i].Epoch)
+			assert.DeepEqual(t, testValidatorHistoryRecords(n, epoch)[5], history[i].Record)
+		}
+	})
+	t.Run("second chunk", func(t *testing.T) {
+		idx := primitives.ValidatorIndex(dutyhistory.ChunkSize + 4)
+		history, err := db.ValidatorHistory(ctx, idx, 0, 100)
+		require.NoError(t, err)
+		require.Equal(t, 4, len(history))
+		assert.DeepEqual(t, testValidatorHistoryRecords(n, 3)[idx], history[0].Record)
+	})
+	t.Run("unknown validator", func(t *testing.T) {
+		history, err := db.ValidatorHistory(ctx, primitives.ValidatorIndex(n), 0, 100)
+		require.NoError(t, err)
+		assert.Equal(t, 0, len(history))
+	})
+	t.Run("single epoch", func(t *testing.T) {
+		history, err := db.ValidatorHistory(ctx, 0, 6, 6)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(history))
+		assert.Equal(t, primitives.Epoch(6), history[0].Epoch)
+	})
+	t.Run("invalid range", func(t *testing.T) {
+		_, err := db.ValidatorHistory(ctx, 0, 6, 5)
+		require.ErrorContains(t, "is after", err)
+	})
+}
+
+func TestStore_DeleteValidatorHistoryBefore(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	n := 2*dutyhistory.ChunkSize + 1
+	for epoch := primitives.Epoch(0); epoch < 5; epoch++ {
+		require.NoError(t, db.SaveValidatorHistory(ctx, epoch, testValidatorHistoryRecords(n, epoch)))
+	}
+	require.NoError(t, db.DeleteValidatorHistoryBefore(ctx, 3))
+
+	history, err := db.ValidatorHistory(ctx, primitives.ValidatorIndex(n-1), 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(history))
+	assert.Equal(t, primitives.Epoch(3), history[0].Epoch)
+	assert.Equal(t, primitives.Epoch(4), history[1].Epoch)
+}
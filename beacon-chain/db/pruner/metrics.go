@@ -0,0 +1,21 @@
+package pruner
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	prunedSlotsCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "beacon_db_pruned_slots_total",
+		Help: "Number of slots whose blocks and states have been removed by the historical data pruner.",
+	})
+	earliestAvailableSlot = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "beacon_db_earliest_available_slot",
+		Help: "The earliest slot for which blocks and states are retained in the beacon database.",
+	})
+	pruningDuration = promauto.NewSummary(prometheus.SummaryOpts{
+		Name: "beacon_db_pruning_duration_milliseconds",
+		Help: "Milliseconds spent pruning a single batch of historical blocks and states.",
+	})
+)
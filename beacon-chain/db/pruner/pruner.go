@@ -0,0 +1,192 @@
+// Package pruner implements a background service which removes finalized blocks, states and their
+// indices from the beacon database once they fall outside of a configured retention window.
+package pruner
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/iface"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/runtime"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultBatchSize is the number of slots removed from the database in a single write transaction.
+const DefaultBatchSize = 64
+
+var _ runtime.Service = (*Service)(nil)
+
+var errRetentionTooShort = errors.New("retention period is smaller than MIN_EPOCHS_FOR_BLOCK_REQUESTS")
+
+// Service periodically deletes historical blocks and states that are older than the retention period,
+// measured in epochs behind the finalized checkpoint.
+type Service struct {
+	ctx             context.Context
+	cancel          context.CancelFunc
+	db              iface.NoHeadAccessDatabase
+	cw              startup.ClockWaiter
+	retentionEpochs primitives.Epoch
+	batchSize       int
+	prunedBefore    primitives.Slot
+	done            chan struct{}
+}
+
+// ServiceOption is a functional option for the pruner Service constructor.
+type ServiceOption func(*Service) error
+
+// WithRetentionEpochs sets the number of epochs of history to retain behind the finalized checkpoint.
+// The value may not be less than MIN_EPOCHS_FOR_BLOCK_REQUESTS, as the node would otherwise be unable
+// to serve the block history that peers expect from it.
+func WithRetentionEpochs(e primitives.Epoch) ServiceOption {
+	return func(s *Service) error {
+		if e < helpers.MinEpochsForBlockRequests() {
+			return errors.Wrapf(errRetentionTooShort, "retention=%d, minimum=%d", e, helpers.MinEpochsForBlockRequests())
+		}
+		s.retentionEpochs = e
+		return nil
+	}
+}
+
+// WithBatchSize sets the number of slots to delete in a single database transaction.
+func WithBatchSize(n int) ServiceOption {
+	return func(s *Service) error {
+		if n <= 0 {
+			return errors.Errorf("invalid pruner batch size %d", n)
+		}
+		s.batchSize = n
+		return nil
+	}
+}
+
+// New initializes the pruner Service. Pruning does not begin until Start is called.
+func New(ctx context.Context, db iface.NoHeadAccessDatabase, cw startup.ClockWaiter, opts ...ServiceOption) (*Service, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	s := &Service{
+		ctx:             ctx,
+		cancel:          cancel,
+		db:              db,
+		cw:              cw,
+		retentionEpochs: helpers.MinEpochsForBlockRequests(),
+		batchSize:       DefaultBatchSize,
+		done:            make(chan struct{}),
+	}
+	for _, o := range opts {
+		if err := o(s); err != nil {
+			cancel()
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// Start the pruning loop in the background.
+func (s *Service) Start() {
+	go s.run()
+}
+
+// Stop the pruning loop, blocking until any in-progress batch has completed.
+func (s *Service) Stop() error {
+	s.cancel()
+	<-s.done
+	return nil
+}
+
+// Status always returns nil, as pruning failures are retried on the next slot.
+func (*Service) Status() error {
+	return nil
+}
+
+func (s *Service) run() {
+	defer close(s.done)
+	clock, err := s.cw.WaitForClock(s.ctx)
+	if err != nil {
+		log.WithError(err).Error("Pruner failed to start while waiting for genesis data")
+		return
+	}
+	earliest, err := s.db.EarliestAvailableSlot(s.ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not read earliest available slot from the database")
+		return
+	}
+	s.prunedBefore = earliest
+	earliestAvailableSlot.Set(float64(earliest))
+	log.WithFields(logrus.Fields{
+		"retentionEpochs":       s.retentionEpochs,
+		"earliestAvailableSlot": earliest,
+	}).Info("Historical block and state pruning enabled")
+
+	ticker := slots.NewSlotTicker(clock.GenesisTime(), params.BeaconConfig().SecondsPerSlot)
+	defer ticker.Done()
+	for {
+		select {
+		case <-ticker.C():
+			if err := s.prune(s.ctx); err != nil {
+				log.WithError(err).Error("Failed to prune historical blocks and states")
+			}
+		case <-s.ctx.Done():
+			log.Debug("Context closed, exiting pruner routine")
+			return
+		}
+	}
+}
+
+// prune deletes history below the current prune target in batches, until either the target is reached or
+// the context is canceled. Each batch is committed in its own transaction so that other writers are not
+// blocked for the entire duration.
+func (s *Service) prune(ctx context.Context) error {
+	f, err := s.db.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get finalized checkpoint")
+	}
+	target := s.pruneTarget(f.Epoch)
+	if target <= s.prunedBefore {
+		return nil
+	}
+	total := 0
+	start := time.Now()
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		batchStart := time.Now()
+		n, err := s.db.DeleteHistoricalDataBeforeSlot(ctx, target, s.batchSize)
+		if err != nil {
+			return errors.Wrapf(err, "could not prune history before slot %d", target)
+		}
+		pruningDuration.Observe(float64(time.Since(batchStart).Milliseconds()))
+		prunedSlotsCount.Add(float64(n))
+		total += n
+		if n == 0 {
+			break
+		}
+	}
+	s.prunedBefore = target
+	// History is kept down to the highest saved state below the target, which the states above it are
+	// regenerated from.
+	earliest, err := s.db.EarliestAvailableSlot(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get earliest available slot")
+	}
+	earliestAvailableSlot.Set(float64(earliest))
+	log.WithFields(logrus.Fields{
+		"prunedBefore":          target,
+		"earliestAvailableSlot": earliest,
+		"slotsPruned":           total,
+		"duration":              time.Since(start).String(),
+	}).Debug("Pruned historical blocks and states")
+	return nil
+}
+
+// pruneTarget returns the slot below which history can be deleted, given the finalized epoch.
+func (s *Service) pruneTarget(finalized primitives.Epoch) primitives.Slot {
+	if finalized <= s.retentionEpochs {
+		return 0
+	}
+	return slots.UnsafeEpochStart(finalized - s.retentionEpochs)
+}
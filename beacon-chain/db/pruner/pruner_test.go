@@ -0,0 +1,91 @@
+package pruner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	dbtest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestNew_Options(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.SetupDB(t)
+	cw := startup.NewClockSynchronizer()
+
+	s, err := New(ctx, db, cw)
+	require.NoError(t, err)
+	assert.Equal(t, helpers.MinEpochsForBlockRequests(), s.retentionEpochs)
+	assert.Equal(t, DefaultBatchSize, s.batchSize)
+
+	_, err = New(ctx, db, cw, WithRetentionEpochs(helpers.MinEpochsForBlockRequests()-1))
+	require.ErrorIs(t, err, errRetentionTooShort)
+	_, err = New(ctx, db, cw, WithBatchSize(0))
+	require.ErrorContains(t, "invalid pruner batch size", err)
+
+	s, err = New(ctx, db, cw, WithRetentionEpochs(helpers.MinEpochsForBlockRequests()+10), WithBatchSize(8))
+	require.NoError(t, err)
+	assert.Equal(t, helpers.MinEpochsForBlockRequests()+10, s.retentionEpochs)
+	assert.Equal(t, 8, s.batchSize)
+}
+
+func TestService_pruneTarget(t *testing.T) {
+	s := &Service{retentionEpochs: 10}
+	assert.Equal(t, primitives.Slot(0), s.pruneTarget(0))
+	assert.Equal(t, primitives.Slot(0), s.pruneTarget(10))
+	assert.Equal(t, params.BeaconConfig().SlotsPerEpoch*5, s.pruneTarget(15))
+}
+
+func TestService_prune(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.SetupDB(t)
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+
+	genesis := util.NewBeaconBlock()
+	genesisRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	util.SaveBlock(t, ctx, db, genesis)
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, genesisRoot))
+
+	parent := genesisRoot
+	roots := make(map[primitives.Slot][32]byte)
+	for i := primitives.Slot(1); i <= 4*slotsPerEpoch; i++ {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = i
+		b.Block.ParentRoot = parent[:]
+		wsb, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		require.NoError(t, db.SaveBlock(ctx, wsb))
+		parent, err = b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: i, Root: parent[:]}))
+		roots[i] = parent
+	}
+	finalized := roots[3*slotsPerEpoch]
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveState(ctx, st, finalized))
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 3, Root: finalized[:]}))
+
+	s := &Service{db: db, retentionEpochs: 1, batchSize: 3}
+	require.NoError(t, s.prune(ctx))
+	target := 2 * slotsPerEpoch
+	assert.Equal(t, target, s.prunedBefore)
+
+	earliest, err := db.EarliestAvailableSlot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, target, earliest)
+	assert.Equal(t, true, db.HasBlock(ctx, genesisRoot))
+	for slot, r := range roots {
+		assert.Equal(t, slot >= target, db.HasBlock(ctx, r), "unexpected block presence at slot %d", slot)
+		assert.Equal(t, slot >= target, db.HasStateSummary(ctx, r), "unexpected summary presence at slot %d", slot)
+	}
+}
@@ -1,33 +1,61 @@
 package db
 
 import (
+	"bufio"
+	"bytes"
+	"context"
 	"os"
 	"path"
 	"strings"
 
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/engine"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
 	"github.com/prysmaticlabs/prysm/v5/cmd"
 	"github.com/prysmaticlabs/prysm/v5/io/file"
 	"github.com/prysmaticlabs/prysm/v5/io/prompt"
+	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v2"
 )
 
 const dbExistsYesNoPrompt = "A database file already exists in the target directory. " +
 	"Are you sure that you want to overwrite it? [y/n]"
 
+// gzipMagic is the header of the gzip compressed archives written by streamed backups.
+var gzipMagic = []byte{0x1f, 0x8b}
+
 // Restore a beacon chain database.
+// The source file is either a database file written by a backup, or a streamed backup archive. Incremental
+// backup archives are applied on top of a streamed source archive, in the order they are given.
 func Restore(cliCtx *cli.Context) error {
 	sourceFile := cliCtx.String(cmd.RestoreSourceFileFlag.Name)
+	incrementalFiles := cliCtx.StringSlice(cmd.RestoreIncrementalFileFlag.Name)
 	targetDir := cliCtx.String(cmd.RestoreTargetDirFlag.Name)
 
 	restoreDir := path.Join(targetDir, kv.BeaconNodeDbDirName)
 	restoreFile := path.Join(restoreDir, kv.DatabaseFileName)
 
+	isArchive, err := isBackupArchive(sourceFile)
+	if err != nil {
+		return err
+	}
+	if len(incrementalFiles) > 0 && !isArchive {
+		return errors.New("incremental backups can only be applied on top of a streamed backup archive")
+	}
+
 	dbExists, err := file.Exists(restoreFile, file.Regular)
 	if err != nil {
 		return errors.Wrapf(err, "could not check if database exists in %s", restoreFile)
 	}
+	// Backups are always restored into a bolt database, which cannot sit next to a database in another engine.
+	pebbleExists, err := file.Exists(path.Join(restoreDir, kv.PebbleDirName), file.Directory)
+	if err != nil {
+		return errors.Wrapf(err, "could not check if database exists in %s", restoreDir)
+	}
+	if pebbleExists {
+		return errors.Errorf("%s holds a %s database, remove it before restoring a backup, which is restored into a "+
+			"bolt database", restoreDir, engine.Pebble)
+	}
 
 	if dbExists {
 		resp, err := prompt.ValidatePrompt(
@@ -44,10 +72,74 @@ func Restore(cliCtx *cli.Context) error {
 	if err := file.MkdirAll(restoreDir); err != nil {
 		return err
 	}
-	if err := file.CopyFile(sourceFile, path.Join(restoreDir, kv.DatabaseFileName)); err != nil {
+	if isArchive {
+		if err := restoreArchives(cliCtx.Context, restoreFile, append([]string{sourceFile}, incrementalFiles...)); err != nil {
+			return err
+		}
+	} else if err := file.CopyFile(sourceFile, path.Join(restoreDir, kv.DatabaseFileName)); err != nil {
 		return err
 	}
 
 	log.Info("Restore completed successfully")
 	return nil
 }
+
+// restoreArchives applies a full backup archive followed by a chain of incremental backup archives to a new
+// database, which replaces the database file once every archive has been applied.
+func restoreArchives(ctx context.Context, restoreFile string, archives []string) error {
+	tmpFile := restoreFile + ".restore"
+	if err := os.Remove(tmpFile); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	defer func() {
+		if err := os.Remove(tmpFile); err != nil && !os.IsNotExist(err) {
+			log.WithError(err).Error("Could not remove partially restored database")
+		}
+	}()
+	parent := ""
+	for _, p := range archives {
+		m, err := applyArchive(ctx, tmpFile, p, parent)
+		if err != nil {
+			return errors.Wrapf(err, "could not apply backup archive %s", p)
+		}
+		log.WithFields(logrus.Fields{
+			"file":        p,
+			"id":          m.ID,
+			"slot":        m.HeadSlot,
+			"incremental": m.Incremental(),
+		}).Info("Applied backup archive")
+		parent = m.ID
+	}
+	return os.Rename(tmpFile, restoreFile)
+}
+
+func applyArchive(ctx context.Context, dbFile, p, parent string) (*kv.BackupManifest, error) {
+	f, err := os.Open(p) // #nosec G304 -- the backup files are chosen by the user.
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.WithError(err).WithField("file", p).Error("Could not close backup archive")
+		}
+	}()
+	return kv.ApplyBackupArchive(ctx, dbFile, bufio.NewReader(f), parent)
+}
+
+func isBackupArchive(p string) (bool, error) {
+	f, err := os.Open(p) // #nosec G304 -- the backup file is chosen by the user.
+	if err != nil {
+		return false, errors.Wrapf(err, "could not open %s", p)
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.WithError(err).WithField("file", p).Error("Could not close backup file")
+		}
+	}()
+	header := make([]byte, len(gzipMagic))
+	n, err := f.Read(header)
+	if err != nil && n == 0 {
+		return false, nil
+	}
+	return bytes.Equal(header[:n], gzipMagic), nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/cmd"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/testing/assert"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 	"github.com/prysmaticlabs/prysm/v5/testing/util"
@@ -69,3 +70,71 @@ func TestRestore(t *testing.T) {
 	assert.LogsContain(t, logHook, "Restore completed successfully")
 
 }
+
+func TestRestore_IncrementalArchives(t *testing.T) {
+	logHook := logTest.NewGlobal()
+	ctx := context.Background()
+
+	backupDb, err := kv.NewKVStore(context.Background(), t.TempDir())
+	require.NoError(t, err)
+	saveHead := func(slot primitives.Slot) {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = slot
+		wsb, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		require.NoError(t, backupDb.SaveBlock(ctx, wsb))
+		root, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		require.NoError(t, backupDb.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: slot, Root: root[:]}))
+		require.NoError(t, backupDb.SaveHeadBlockRoot(ctx, root))
+	}
+	backupDir := t.TempDir()
+	writeBackup := func(name string) string {
+		p := path.Join(backupDir, name)
+		f, err := os.Create(p)
+		require.NoError(t, err)
+		require.NoError(t, backupDb.BackupStream(ctx, f, true))
+		require.NoError(t, f.Close())
+		return p
+	}
+	saveHead(5000)
+	full := writeBackup("full.tar.gz")
+	saveHead(5001)
+	first := writeBackup("first.tar.gz")
+	saveHead(5002)
+	second := writeBackup("second.tar.gz")
+	require.NoError(t, backupDb.Close())
+
+	restore := func(incremental ...string) error {
+		app := cli.App{}
+		set := flag.NewFlagSet("test", 0)
+		set.String(cmd.RestoreSourceFileFlag.Name, full, "")
+		set.Var(cli.NewStringSlice(incremental...), cmd.RestoreIncrementalFileFlag.Name, "")
+		set.String(cmd.RestoreTargetDirFlag.Name, t.TempDir(), "")
+		cliCtx := cli.NewContext(&app, set, nil)
+		return Restore(cliCtx)
+	}
+	// The incremental backups have to be applied in the order they were taken.
+	require.ErrorIs(t, restore(second, first), kv.ErrBackupChain)
+
+	restoreDir := t.TempDir()
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.String(cmd.RestoreSourceFileFlag.Name, full, "")
+	set.Var(cli.NewStringSlice(first, second), cmd.RestoreIncrementalFileFlag.Name, "")
+	set.String(cmd.RestoreTargetDirFlag.Name, restoreDir, "")
+	require.NoError(t, Restore(cli.NewContext(&app, set, nil)))
+
+	files, err := os.ReadDir(path.Join(restoreDir, kv.BeaconNodeDbDirName))
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(files))
+	restoredDb, err := kv.NewKVStore(context.Background(), path.Join(restoreDir, kv.BeaconNodeDbDirName))
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, restoredDb.Close())
+	}()
+	headBlock, err := restoredDb.HeadBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Slot(5002), headBlock.Block().Slot(), "Restored database has incorrect data")
+	assert.LogsContain(t, logHook, "Restore completed successfully")
+}
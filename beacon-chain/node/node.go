@@ -19,6 +19,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/server/auth"
 	"github.com/prysmaticlabs/prysm/v5/api/server/httprest"
 	"github.com/prysmaticlabs/prysm/v5/api/server/middleware"
 	"github.com/prysmaticlabs/prysm/v5/async/event"
@@ -26,9 +27,11 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/builder"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache/depositsnapshot"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/peerdas"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/pruner"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/slasherkv"
 	interopcoldstart "github.com/prysmaticlabs/prysm/v5/beacon-chain/deterministic-genesis"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/execution"
@@ -57,11 +60,13 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
 	"github.com/prysmaticlabs/prysm/v5/cmd"
 	"github.com/prysmaticlabs/prysm/v5/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/v5/cmd/beacon-chain/jwt"
 	"github.com/prysmaticlabs/prysm/v5/config/features"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/container/slice"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/backup"
 	"github.com/prysmaticlabs/prysm/v5/monitoring/prometheus"
 	"github.com/prysmaticlabs/prysm/v5/runtime"
 	"github.com/prysmaticlabs/prysm/v5/runtime/debug"
@@ -80,47 +85,54 @@ type serviceFlagOpts struct {
 	blockchainFlagOpts     []blockchain.Option
 	executionChainFlagOpts []execution.Option
 	builderOpts            []builder.Option
+	pruningEnabled         bool
+	prunerOpts             []pruner.ServiceOption
+	stateDiffExponents     []uint64
+	storageUsage           bool
 }
 
 // BeaconNode defines a struct that handles the services running a random beacon chain
 // full PoS node. It handles the lifecycle of the entire system and registers
 // services to a service registry.
 type BeaconNode struct {
-	cliCtx                  *cli.Context
-	ctx                     context.Context
-	cancel                  context.CancelFunc
-	services                *runtime.ServiceRegistry
-	lock                    sync.RWMutex
-	stop                    chan struct{} // Channel to wait for termination notifications.
-	db                      db.Database
-	slasherDB               db.SlasherDatabase
-	attestationPool         attestations.Pool
-	exitPool                voluntaryexits.PoolManager
-	slashingsPool           slashings.PoolManager
-	syncCommitteePool       synccommittee.Pool
-	blsToExecPool           blstoexec.PoolManager
-	depositCache            cache.DepositCache
-	trackedValidatorsCache  *cache.TrackedValidatorsCache
-	payloadIDCache          *cache.PayloadIDCache
-	stateFeed               *event.Feed
-	blockFeed               *event.Feed
-	opFeed                  *event.Feed
-	stateGen                *stategen.State
-	collector               *bcnodeCollector
-	slasherBlockHeadersFeed *event.Feed
-	slasherAttestationsFeed *event.Feed
-	finalizedStateAtStartUp state.BeaconState
-	serviceFlagOpts         *serviceFlagOpts
-	GenesisInitializer      genesis.Initializer
-	CheckpointInitializer   checkpoint.Initializer
-	forkChoicer             forkchoice.ForkChoicer
-	clockWaiter             startup.ClockWaiter
-	BackfillOpts            []backfill.ServiceOption
-	initialSyncComplete     chan struct{}
-	BlobStorage             *filesystem.BlobStorage
-	BlobStorageOptions      []filesystem.BlobStorageOption
-	verifyInitWaiter        *verification.InitializerWaiter
-	syncChecker             *initialsync.SyncChecker
+	cliCtx                   *cli.Context
+	ctx                      context.Context
+	cancel                   context.CancelFunc
+	services                 *runtime.ServiceRegistry
+	lock                     sync.RWMutex
+	stop                     chan struct{} // Channel to wait for termination notifications.
+	db                       db.Database
+	slasherDB                db.SlasherDatabase
+	attestationPool          attestations.Pool
+	exitPool                 voluntaryexits.PoolManager
+	slashingsPool            slashings.PoolManager
+	syncCommitteePool        synccommittee.Pool
+	blsToExecPool            blstoexec.PoolManager
+	depositCache             cache.DepositCache
+	trackedValidatorsCache   *cache.TrackedValidatorsCache
+	validatorIndexCache      *cache.ValidatorIndexCache
+	payloadIDCache           *cache.PayloadIDCache
+	stateFeed                *event.Feed
+	blockFeed                *event.Feed
+	opFeed                   *event.Feed
+	stateGen                 *stategen.State
+	collector                *bcnodeCollector
+	slasherBlockHeadersFeed  *event.Feed
+	slasherAttestationsFeed  *event.Feed
+	finalizedStateAtStartUp  state.BeaconState
+	serviceFlagOpts          *serviceFlagOpts
+	GenesisInitializer       genesis.Initializer
+	CheckpointInitializer    checkpoint.Initializer
+	forkChoicer              forkchoice.ForkChoicer
+	clockWaiter              startup.ClockWaiter
+	BackfillOpts             []backfill.ServiceOption
+	initialSyncComplete      chan struct{}
+	BlobStorage              *filesystem.BlobStorage
+	BlobStorageOptions       []filesystem.BlobStorageOption
+	DataColumnStorage        *filesystem.DataColumnStorage
+	DataColumnStorageOptions []filesystem.DataColumnStorageOption
+	verifyInitWaiter         *verification.InitializerWaiter
+	syncChecker              *initialsync.SyncChecker
 }
 
 // New creates a new node instance, sets up configuration options, and registers
@@ -165,6 +177,10 @@ func New(cliCtx *cli.Context, cancel context.CancelFunc, opts ...Option) (*Beaco
 		}
 	}
 
+	if cliCtx.Bool(flags.ValidatorQueryIndex.Name) {
+		beacon.validatorIndexCache = cache.NewValidatorIndexCache()
+	}
+
 	synchronizer := startup.NewClockSynchronizer()
 	beacon.clockWaiter = synchronizer
 	beacon.forkChoicer = doublylinkedtree.New()
@@ -183,6 +199,14 @@ func New(cliCtx *cli.Context, cancel context.CancelFunc, opts ...Option) (*Beaco
 		}
 		beacon.BlobStorage = blobs
 	}
+	// Data column storage is only needed on networks that schedule the PeerDAS fork.
+	if beacon.DataColumnStorage == nil && params.PeerDASEnabled() {
+		columns, err := filesystem.NewDataColumnStorage(beacon.DataColumnStorageOptions...)
+		if err != nil {
+			return nil, err
+		}
+		beacon.DataColumnStorage = columns
+	}
 
 	bfs, err := startBaseServices(cliCtx, beacon, depositAddress)
 	if err != nil {
@@ -198,6 +222,7 @@ func New(cliCtx *cli.Context, cancel context.CancelFunc, opts ...Option) (*Beaco
 		beacon.BackfillOpts,
 		backfill.WithVerifierWaiter(beacon.verifyInitWaiter),
 		backfill.WithInitSyncWaiter(initSyncWaiter(ctx, beacon.initialSyncComplete)),
+		backfill.WithDataColumnStorage(beacon.DataColumnStorage),
 	)
 
 	bf, err := backfill.NewService(ctx, bfs, beacon.BlobStorage, beacon.clockWaiter, beacon.fetchP2P(), pa, beacon.BackfillOpts...)
@@ -286,6 +311,9 @@ func startBaseServices(cliCtx *cli.Context, beacon *BeaconNode, depositAddress s
 		return nil, errors.Wrap(err, "could not start DB")
 	}
 	beacon.BlobStorage.WarmCache()
+	if beacon.DataColumnStorage != nil {
+		beacon.DataColumnStorage.WarmCache()
+	}
 
 	log.Debugln("Starting Slashing DB")
 	if err := beacon.startSlasherDB(cliCtx); err != nil {
@@ -350,6 +378,11 @@ func registerServices(cliCtx *cli.Context, beacon *BeaconNode, synchronizer *sta
 		return errors.Wrap(err, "could not register sync service")
 	}
 
+	log.Debugln("Registering Pruner Service")
+	if err := beacon.registerPrunerService(); err != nil {
+		return errors.Wrap(err, "could not register pruner service")
+	}
+
 	log.Debugln("Registering Slasher Service")
 	if err := beacon.registerSlasherService(); err != nil {
 		return errors.Wrap(err, "could not register slasher service")
@@ -453,6 +486,7 @@ func (b *BeaconNode) Close() {
 
 	log.Info("Stopping beacon node")
 	b.services.StopAll()
+	b.BlobStorage.Close()
 	if err := b.db.Close(); err != nil {
 		log.WithError(err).Error("Failed to close database")
 	}
@@ -461,7 +495,7 @@ func (b *BeaconNode) Close() {
 	close(b.stop)
 }
 
-func (b *BeaconNode) clearDB(clearDB, forceClearDB bool, d *kv.Store, dbPath string) (*kv.Store, error) {
+func (b *BeaconNode) clearDB(clearDB, forceClearDB bool, d *kv.Store, dbPath string, opts ...kv.KVStoreOption) (*kv.Store, error) {
 	var err error
 	clearDBConfirmed := false
 
@@ -489,7 +523,7 @@ func (b *BeaconNode) clearDB(clearDB, forceClearDB bool, d *kv.Store, dbPath str
 			return nil, errors.Wrap(err, "could not clear blob storage")
 		}
 
-		d, err = kv.NewKVStore(b.ctx, dbPath)
+		d, err = kv.NewKVStore(b.ctx, dbPath, opts...)
 		if err != nil {
 			return nil, errors.Wrap(err, "could not create new database")
 		}
@@ -529,15 +563,29 @@ func (b *BeaconNode) startDB(cliCtx *cli.Context, depositAddress string) error {
 	clearDBRequired := cliCtx.Bool(cmd.ClearDB.Name)
 	forceClearDBRequired := cliCtx.Bool(cmd.ForceClearDB.Name)
 
+	var dbOpts []kv.KVStoreOption
+	if cliCtx.IsSet(cmd.DBEngineFlag.Name) {
+		dbOpts = append(dbOpts, kv.WithEngine(cliCtx.String(cmd.DBEngineFlag.Name)))
+	}
+	if len(b.serviceFlagOpts.stateDiffExponents) > 0 {
+		dbOpts = append(dbOpts, kv.WithStateDiffs(b.serviceFlagOpts.stateDiffExponents))
+	}
+
 	log.WithField("databasePath", dbPath).Info("Checking DB")
 
-	d, err := kv.NewKVStore(b.ctx, dbPath)
+	// A database which is about to be cleared is opened with the engine it is stored in, so that it can be
+	// recreated with another engine.
+	openOpts := dbOpts
+	if clearDBRequired || forceClearDBRequired {
+		openOpts = nil
+	}
+	d, err := kv.NewKVStore(b.ctx, dbPath, openOpts...)
 	if err != nil {
 		return errors.Wrapf(err, "could not create database at %s", dbPath)
 	}
 
 	if clearDBRequired || forceClearDBRequired {
-		d, err = b.clearDB(clearDBRequired, forceClearDBRequired, d, dbPath)
+		d, err = b.clearDB(clearDBRequired, forceClearDBRequired, d, dbPath, dbOpts...)
 		if err != nil {
 			return errors.Wrap(err, "could not clear database")
 		}
@@ -546,6 +594,15 @@ func (b *BeaconNode) startDB(cliCtx *cli.Context, depositAddress string) error {
 	if err := d.RunMigrations(b.ctx); err != nil {
 		return err
 	}
+	if len(b.serviceFlagOpts.stateDiffExponents) > 0 {
+		n, err := d.MigrateArchivedStatesToDiffs(b.ctx)
+		if err != nil {
+			return errors.Wrap(err, "could not migrate archived states to state diffs")
+		}
+		if n > 0 {
+			log.WithField("states", n).Info("Migrated archived states to state diffs")
+		}
+	}
 
 	b.db = d
 
@@ -583,6 +640,9 @@ func (b *BeaconNode) startDB(cliCtx *cli.Context, depositAddress string) error {
 	}
 
 	log.WithField("address", depositAddress).Info("Deposit contract")
+	if b.serviceFlagOpts.storageUsage {
+		go d.TrackStorageUsage(b.ctx)
+	}
 	return nil
 }
 
@@ -693,6 +753,7 @@ func (b *BeaconNode) registerP2P(cliCtx *cli.Context) error {
 		EnableUPnP:           cliCtx.Bool(cmd.EnableUPnPFlag.Name),
 		StateNotifier:        b,
 		DB:                   b.db,
+		PeerStore:            b.db,
 		ClockWaiter:          b.clockWaiter,
 	})
 	if err != nil {
@@ -761,9 +822,22 @@ func (b *BeaconNode) registerBlockchainService(fc forkchoice.ForkChoicer, gs *st
 		blockchain.WithSyncComplete(syncComplete),
 		blockchain.WithBlobStorage(b.BlobStorage),
 		blockchain.WithTrackedValidatorsCache(b.trackedValidatorsCache),
+		blockchain.WithValidatorIndexCache(b.validatorIndexCache),
 		blockchain.WithPayloadIDCache(b.payloadIDCache),
 		blockchain.WithSyncChecker(b.syncChecker),
+		blockchain.WithDataColumnStorage(b.DataColumnStorage),
 	)
+	if b.DataColumnStorage != nil {
+		nodeID, err := peerdas.ConvertPeerIDToNodeID(b.fetchP2P().PeerID())
+		if err != nil {
+			return errors.Wrap(err, "could not convert peer ID to node ID")
+		}
+		custody, err := peerdas.CustodyColumns(nodeID, peerdas.CustodySubnetCount())
+		if err != nil {
+			return errors.Wrap(err, "could not compute custody columns")
+		}
+		opts = append(opts, blockchain.WithCustodyColumns(custody))
+	}
 
 	blockchainService, err := blockchain.NewService(b.ctx, opts...)
 	if err != nil {
@@ -844,6 +918,7 @@ func (b *BeaconNode) registerSyncService(initialSyncComplete chan struct{}, bFil
 		regularsync.WithInitialSyncComplete(initialSyncComplete),
 		regularsync.WithStateNotifier(b),
 		regularsync.WithBlobStorage(b.BlobStorage),
+		regularsync.WithDataColumnStorage(b.DataColumnStorage),
 		regularsync.WithVerifierWaiter(b.verifyInitWaiter),
 		regularsync.WithAvailableBlocker(bFillStore),
 	)
@@ -869,10 +944,22 @@ func (b *BeaconNode) registerInitialSyncService(complete chan struct{}) error {
 		ClockWaiter:         b.clockWaiter,
 		InitialSyncComplete: complete,
 		BlobStorage:         b.BlobStorage,
+		DataColumnStorage:   b.DataColumnStorage,
 	}, opts...)
 	return b.services.RegisterService(is)
 }
 
+func (b *BeaconNode) registerPrunerService() error {
+	if !b.serviceFlagOpts.pruningEnabled {
+		return nil
+	}
+	svc, err := pruner.New(b.ctx, b.db, b.clockWaiter, b.serviceFlagOpts.prunerOpts...)
+	if err != nil {
+		return err
+	}
+	return b.services.RegisterService(svc)
+}
+
 func (b *BeaconNode) registerSlasherService() error {
 	if !features.Get().EnableSlasher {
 		return nil
@@ -975,6 +1062,7 @@ func (b *BeaconNode) registerRPCService(router *http.ServeMux) error {
 		FinalizationFetcher:       chainService,
 		BlockReceiver:             chainService,
 		BlobReceiver:              chainService,
+		DataColumnReceiver:        chainService,
 		AttestationReceiver:       chainService,
 		GenesisTimeFetcher:        chainService,
 		GenesisFetcher:            chainService,
@@ -996,12 +1084,16 @@ func (b *BeaconNode) registerRPCService(router *http.ServeMux) error {
 		OperationNotifier:         b,
 		StateGen:                  b.stateGen,
 		EnableDebugRPCEndpoints:   enableDebugRPCEndpoints,
+		EnableGraphQL:             b.cliCtx.Bool(flags.EnableGraphQL.Name),
+		GraphQLMaxDepth:           b.cliCtx.Int(flags.GraphQLMaxDepth.Name),
+		GraphQLMaxCost:            b.cliCtx.Uint64(flags.GraphQLMaxCost.Name),
 		MaxMsgSize:                maxMsgSize,
 		BlockBuilder:              b.fetchBuilderService(),
 		Router:                    router,
 		ClockWaiter:               b.clockWaiter,
 		BlobStorage:               b.BlobStorage,
 		TrackedValidatorsCache:    b.trackedValidatorsCache,
+		ValidatorIndexCache:       b.validatorIndexCache,
 		PayloadIDCache:            b.payloadIDCache,
 	})
 
@@ -1015,6 +1107,15 @@ func (b *BeaconNode) registerPrometheusService(_ *cli.Context) error {
 		panic(err)
 	}
 	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/p2p", Handler: p.InfoHandler})
+	if b.cliCtx.IsSet(cmd.EnableBackupWebhookFlag.Name) {
+		additionalHandlers = append(
+			additionalHandlers,
+			prometheus.Handler{
+				Path:    "/db/backup",
+				Handler: backup.Handler(b.db, b.cliCtx.String(cmd.BackupWebhookOutputDir.Name)),
+			},
+		)
+	}
 
 	var c *blockchain.Service
 	if err := b.services.FetchService(&c); err != nil {
@@ -1046,6 +1147,11 @@ func (b *BeaconNode) registerHTTPService(router *http.ServeMux) error {
 		middleware.NormalizeQueryValuesHandler,
 		middleware.CorsHandler(allowedOrigins),
 	}
+	authMiddlewares, err := b.httpAuthMiddlewares()
+	if err != nil {
+		return err
+	}
+	middlewares = append(middlewares, authMiddlewares...)
 
 	opts := []httprest.Option{
 		httprest.WithRouter(router),
@@ -1062,6 +1168,39 @@ func (b *BeaconNode) registerHTTPService(router *http.ServeMux) error {
 	return b.services.RegisterService(g)
 }
 
+// httpAuthMiddlewares returns the middlewares authenticating the clients of the HTTP server and limiting the rate of
+// their requests, as configured by flags.
+func (b *BeaconNode) httpAuthMiddlewares() ([]middleware.Middleware, error) {
+	var middlewares []middleware.Middleware
+	var opts []auth.Option
+	if path := b.cliCtx.String(flags.HTTPAuthTokensFile.Name); path != "" {
+		opts = append(opts, auth.WithTokensFile(path))
+	}
+	if path := b.cliCtx.String(flags.HTTPAuthJWTSecret.Name); path != "" {
+		secret, err := jwt.ReadSecret(path)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read HTTP API JWT secret")
+		}
+		opts = append(opts, auth.WithJWTSecret(secret))
+	}
+	if len(opts) > 0 {
+		authenticator, err := auth.NewAuthenticator(opts...)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not configure HTTP API authentication")
+		}
+		middlewares = append(middlewares, authenticator.Middleware)
+		log.Info("HTTP API authentication enabled")
+	}
+	rates := map[auth.CostClass]float64{
+		auth.CostLight: b.cliCtx.Float64(flags.HTTPRateLimit.Name),
+		auth.CostHeavy: b.cliCtx.Float64(flags.HTTPRateLimitHeavy.Name),
+	}
+	if rates[auth.CostLight] > 0 || rates[auth.CostHeavy] > 0 {
+		middlewares = append(middlewares, auth.NewRateLimiter(rates).Middleware)
+	}
+	return middlewares, nil
+}
+
 func (b *BeaconNode) registerDeterministicGenesisService() error {
 	genesisTime := b.cliCtx.Uint64(flags.InteropGenesisTimeFlag.Name)
 	genesisValidators := b.cliCtx.Uint64(flags.InteropNumValidatorsFlag.Name)
@@ -1,9 +1,11 @@
 package node
 
 import (
+
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/builder"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/pruner"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/execution"
 )
 
@@ -50,3 +52,47 @@ func WithBlobStorageOptions(opt ...filesystem.BlobStorageOption) Option {
 		return nil
 	}
 }
+
+// WithDataColumnStorage sets the DataColumnStorage backend for the BeaconNode.
+func WithDataColumnStorage(cs *filesystem.DataColumnStorage) Option {
+	return func(bn *BeaconNode) error {
+		bn.DataColumnStorage = cs
+		return nil
+	}
+}
+
+// WithDataColumnStorageOptions appends 1 or more filesystem.DataColumnStorageOption on the beacon node,
+// to be used when initializing data column storage.
+func WithDataColumnStorageOptions(opt ...filesystem.DataColumnStorageOption) Option {
+	return func(bn *BeaconNode) error {
+		bn.DataColumnStorageOptions = append(bn.DataColumnStorageOptions, opt...)
+		return nil
+	}
+}
+
+// WithPrunerOptions enables historical block and state pruning, configuring the pruner service with the
+// given options. The pruner service is only registered when this option is provided.
+func WithPrunerOptions(opts ...pruner.ServiceOption) Option {
+	return func(bn *BeaconNode) error {
+		bn.serviceFlagOpts.pruningEnabled = true
+		bn.serviceFlagOpts.prunerOpts = append(bn.serviceFlagOpts.prunerOpts, opts...)
+		return nil
+	}
+}
+
+// WithStorageUsage enables tracking the storage usage of the beacon database.
+func WithStorageUsage() Option {
+	return func(bn *BeaconNode) error {
+		bn.serviceFlagOpts.storageUsage = true
+		return nil
+	}
+}
+
+// WithStateDiffExponents saves finalized states as a hierarchy of snapshots and diffs with the given levels,
+// and moves the existing archived states into the hierarchy at startup.
+func WithStateDiffExponents(exponents []uint64) Option {
+	return func(bn *BeaconNode) error {
+		bn.serviceFlagOpts.stateDiffExponents = exponents
+		return nil
+	}
+}
@@ -33,6 +33,7 @@ type Config struct {
 	DenyListCIDR         []string
 	StateNotifier        statefeed.Notifier
 	DB                   db.ReadOnlyDatabase
+	PeerStore            PeerStore
 	ClockWaiter          startup.ClockWaiter
 }
 
@@ -209,7 +209,8 @@ func filterConnections(f *multiaddr.Filters, a multiaddr.Multiaddr) bool {
 				break
 			}
 		}
-		return found
+		// Subnets denied within the allow list, such as banned ones, are still rejected.
+		return found && !f.AddrBlocked(a)
 	}
 	return !f.AddrBlocked(a)
 }
@@ -0,0 +1,85 @@
+package p2p
+
+import (
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/peerdas"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+)
+
+var custodySubnetCountEnrKey = params.BeaconNetworkConfig().CustodySubnetCountKey
+
+// CustodySubnetCount is the ENR entry holding the number of data column subnets a node custodies.
+type CustodySubnetCount uint64
+
+// ENRKey implements enr.Entry.
+func (CustodySubnetCount) ENRKey() string { return custodySubnetCountEnrKey }
+
+// CustodyCountFromRemotePeer returns the number of data column subnets the given peer custodies. The value
+// advertised in the peer's metadata is preferred, since it can be refreshed by a ping, with the ENR as a
+// fallback. Peers which advertise neither are assumed to custody the minimum required by the spec.
+func (s *Service) CustodyCountFromRemotePeer(pid peer.ID) uint64 {
+	count := params.BeaconConfig().CustodyRequirement
+	md, err := s.peers.Metadata(pid)
+	if err == nil && md != nil && md.CustodySubnetCount() > 0 {
+		return md.CustodySubnetCount()
+	}
+	record, err := s.peers.ENR(pid)
+	if err != nil || record == nil {
+		return count
+	}
+	csc, err := custodySubnetCount(record)
+	if err != nil {
+		return count
+	}
+	return csc
+}
+
+// CustodyColumnsFromRemotePeer returns the set of data columns the given peer custodies.
+func (s *Service) CustodyColumnsFromRemotePeer(pid peer.ID) (map[uint64]bool, error) {
+	nodeID, err := peerdas.ConvertPeerIDToNodeID(pid)
+	if err != nil {
+		return nil, err
+	}
+	return peerdas.CustodyColumns(nodeID, s.CustodyCountFromRemotePeer(pid))
+}
+
+// Initializes the custody subnet count entry of the ENR, on networks that schedule the PeerDAS fork.
+func initializeCustodySubnetCount(node *enode.LocalNode) *enode.LocalNode {
+	if !params.PeerDASEnabled() {
+		return node
+	}
+	node.Set(CustodySubnetCount(peerdas.CustodySubnetCount()))
+	return node
+}
+
+// Reads the custody subnet count entry from a node's ENR.
+func custodySubnetCount(record *enr.Record) (uint64, error) {
+	var csc CustodySubnetCount
+	if err := record.Load(&csc); err != nil {
+		return 0, err
+	}
+	if uint64(csc) > params.BeaconConfig().DataColumnSidecarSubnetCount {
+		return 0, peerdas.ErrCustodySubnetCountTooLarge
+	}
+	return uint64(csc), nil
+}
+
+// returns a method with filters peers specifically for a particular data column subnet.
+func (s *Service) filterPeerForDataColumnsSubnet(index uint64) func(node *enode.Node) bool {
+	return func(node *enode.Node) bool {
+		if !s.filterPeer(node) {
+			return false
+		}
+		count, err := custodySubnetCount(node.Record())
+		if err != nil {
+			return false
+		}
+		subnets, err := peerdas.CustodyColumnSubnets(node.ID(), count)
+		if err != nil {
+			return false
+		}
+		return subnets[index]
+	}
+}
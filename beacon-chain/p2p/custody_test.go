@@ -0,0 +1,52 @@
+package p2p
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/peerdas"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	ecdsaprysm "github.com/prysmaticlabs/prysm/v5/crypto/ecdsa"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func newTestLocalNode(t *testing.T) *enode.LocalNode {
+	db, err := enode.OpenDB("")
+	require.NoError(t, err)
+	t.Cleanup(db.Close)
+	priv, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+	require.NoError(t, err)
+	convertedKey, err := ecdsaprysm.ConvertFromInterfacePrivKey(priv)
+	require.NoError(t, err)
+	return enode.NewLocalNode(db, convertedKey)
+}
+
+func TestInitializeCustodySubnetCount(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+
+	t.Run("peerdas not scheduled", func(t *testing.T) {
+		localNode := initializeCustodySubnetCount(newTestLocalNode(t))
+		_, err := custodySubnetCount(localNode.Node().Record())
+		require.NotNil(t, err)
+	})
+
+	t.Run("peerdas scheduled", func(t *testing.T) {
+		cfg := params.BeaconConfig().Copy()
+		cfg.Eip7594ForkEpoch = 10
+		params.OverrideBeaconConfig(cfg)
+
+		localNode := initializeCustodySubnetCount(newTestLocalNode(t))
+		csc, err := custodySubnetCount(localNode.Node().Record())
+		require.NoError(t, err)
+		require.Equal(t, peerdas.CustodySubnetCount(), csc)
+	})
+}
+
+func TestCustodySubnetCount_TooLarge(t *testing.T) {
+	localNode := newTestLocalNode(t)
+	localNode.Set(CustodySubnetCount(params.BeaconConfig().DataColumnSidecarSubnetCount + 1))
+	_, err := custodySubnetCount(localNode.Node().Record())
+	require.ErrorIs(t, err, peerdas.ErrCustodySubnetCountTooLarge)
+}
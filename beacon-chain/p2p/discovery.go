@@ -16,6 +16,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/peerdas"
 	"github.com/prysmaticlabs/prysm/v5/cmd/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/v5/config/features"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
@@ -181,6 +182,14 @@ func (s *Service) RefreshENR() {
 			log.WithError(err).Error("Could not retrieve sync bitfield")
 			return
 		}
+		if params.PeerDASActive(currEpoch) {
+			if bytes.Equal(bitV, currentBitV) && bytes.Equal(bitS, currentBitS) &&
+				s.Metadata().Version() == version.Deneb {
+				return
+			}
+			s.updateSubnetRecordWithMetadataV3(bitV, bitS, peerdas.CustodySubnetCount())
+			break
+		}
 		if bytes.Equal(bitV, currentBitV) && bytes.Equal(bitS, currentBitS) &&
 			s.Metadata().Version() == version.Altair {
 			// return early if bitfields haven't changed
@@ -377,6 +386,7 @@ func (s *Service) createLocalNode(
 
 	localNode = initializeAttSubnets(localNode)
 	localNode = initializeSyncCommSubnets(localNode)
+	localNode = initializeCustodySubnetCount(localNode)
 
 	if s.cfg != nil && s.cfg.HostAddress != "" {
 		hostIP := net.ParseIP(s.cfg.HostAddress)
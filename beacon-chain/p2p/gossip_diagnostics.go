@@ -0,0 +1,264 @@
+package p2p
+
+import (
+	"sync"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	prysmTime "github.com/prysmaticlabs/prysm/v5/time"
+)
+
+const (
+	// gossipDiagnosticsWindow is the sliding window over which the message delivery events of each topic are counted.
+	gossipDiagnosticsWindow = 5 * time.Minute
+	// gossipDiagnosticsBucket is the granularity at which events leave the sliding window.
+	gossipDiagnosticsBucket = 10 * time.Second
+	// firstSeenRetention is how long the first delivery time of a message is remembered, to tell late duplicates
+	// of the message apart.
+	firstSeenRetention = time.Minute
+)
+
+// GossipDiagnostics is a snapshot of the state of the gossipsub router, used to debug message propagation.
+type GossipDiagnostics struct {
+	// Time is the time of the snapshot.
+	Time time.Time
+	// Window is the duration over which the message delivery events were counted. It is shorter than the sliding
+	// window while the node has not been running for that long.
+	Window time.Duration
+	// Topics holds the mesh and the message delivery events of each topic.
+	Topics map[string]*TopicDiagnostics
+	// Scores holds the last peer score snapshots of the router, taken at ScoresTime.
+	Scores     map[peer.ID]*pubsub.PeerScoreSnapshot
+	ScoresTime time.Time
+	// IWantReceived and IWantSent are the numbers of message IDs requested by and from peers within the window.
+	// IWANT messages do not carry a topic.
+	IWantReceived uint64
+	IWantSent     uint64
+}
+
+// TopicDiagnostics holds the mesh of a topic and the counts of its message delivery events within the window.
+type TopicDiagnostics struct {
+	Mesh []peer.ID
+	// Delivered is the number of messages which passed validation.
+	Delivered uint64
+	// Duplicates is the number of copies received of messages already seen, and Late the number of those
+	// received after the mesh message deliveries window, which do not count toward the score of the peer.
+	Duplicates uint64
+	Late       uint64
+	Rejected   uint64
+	// IHaveReceived and IHaveSent are the numbers of message IDs advertised by and to peers.
+	IHaveReceived uint64
+	IHaveSent     uint64
+}
+
+func (t *TopicDiagnostics) add(o *TopicDiagnostics) {
+	t.Delivered += o.Delivered
+	t.Duplicates += o.Duplicates
+	t.Late += o.Late
+	t.Rejected += o.Rejected
+	t.IHaveReceived += o.IHaveReceived
+	t.IHaveSent += o.IHaveSent
+}
+
+var _ GossipDiagnosticsProvider = (*Service)(nil)
+
+// GossipDiagnostics returns the mesh of each topic along with the message delivery events of the topics within the
+// sliding window, and the last peer score snapshots of the router.
+func (s *Service) GossipDiagnostics() *GossipDiagnostics {
+	snap := s.gossipDiag.snapshot()
+	for topic, mesh := range s.mesh.meshes() {
+		t, ok := snap.Topics[topic]
+		if !ok {
+			t = &TopicDiagnostics{}
+			snap.Topics[topic] = t
+		}
+		t.Mesh = mesh
+	}
+	return snap
+}
+
+type diagnosticsBucket struct {
+	start     time.Time
+	topics    map[string]*TopicDiagnostics
+	iwantRecv uint64
+	iwantSent uint64
+}
+
+// gossipDiagnostics collects the message delivery events of the router over a sliding window, along with its peer
+// score snapshots. A nil collector ignores the events.
+type gossipDiagnostics struct {
+	sync.Mutex
+	now        func() time.Time
+	start      time.Time
+	buckets    []*diagnosticsBucket
+	firstSeen  map[string]time.Time
+	scores     map[peer.ID]*pubsub.PeerScoreSnapshot
+	scoresTime time.Time
+}
+
+func newGossipDiagnostics() *gossipDiagnostics {
+	return &gossipDiagnostics{
+		now:       prysmTime.Now,
+		start:     prysmTime.Now(),
+		firstSeen: make(map[string]time.Time),
+		scores:    make(map[peer.ID]*pubsub.PeerScoreSnapshot),
+	}
+}
+
+// bucket returns the bucket of the given time, rotating out the buckets which left the window. The lock must be held.
+func (d *gossipDiagnostics) bucket(now time.Time) *diagnosticsBucket {
+	if n := len(d.buckets); n > 0 && now.Sub(d.buckets[n-1].start) < gossipDiagnosticsBucket {
+		return d.buckets[n-1]
+	}
+	i := 0
+	for i < len(d.buckets) && now.Sub(d.buckets[i].start) >= gossipDiagnosticsWindow {
+		i++
+	}
+	d.buckets = append(d.buckets[i:], &diagnosticsBucket{
+		start:  now.Truncate(gossipDiagnosticsBucket),
+		topics: make(map[string]*TopicDiagnostics),
+	})
+	// Message IDs are forgotten at the same pace.
+	for id, seen := range d.firstSeen {
+		if now.Sub(seen) > firstSeenRetention {
+			delete(d.firstSeen, id)
+		}
+	}
+	return d.buckets[len(d.buckets)-1]
+}
+
+// topic returns the counters of the topic in the current bucket. The lock must be held.
+func (d *gossipDiagnostics) topic(now time.Time, topic string) *TopicDiagnostics {
+	b := d.bucket(now)
+	t, ok := b.topics[topic]
+	if !ok {
+		t = &TopicDiagnostics{}
+		b.topics[topic] = t
+	}
+	return t
+}
+
+func (d *gossipDiagnostics) validate(msg *pubsub.Message) {
+	if d == nil {
+		return
+	}
+	d.Lock()
+	defer d.Unlock()
+	if _, ok := d.firstSeen[msg.ID]; !ok {
+		d.firstSeen[msg.ID] = d.now()
+	}
+}
+
+func (d *gossipDiagnostics) deliver(msg *pubsub.Message) {
+	if d == nil {
+		return
+	}
+	d.Lock()
+	defer d.Unlock()
+	d.topic(d.now(), msg.GetTopic()).Delivered++
+}
+
+func (d *gossipDiagnostics) reject(msg *pubsub.Message) {
+	if d == nil {
+		return
+	}
+	d.Lock()
+	defer d.Unlock()
+	d.topic(d.now(), msg.GetTopic()).Rejected++
+}
+
+func (d *gossipDiagnostics) duplicate(msg *pubsub.Message) {
+	if d == nil {
+		return
+	}
+	d.Lock()
+	defer d.Unlock()
+	now := d.now()
+	t := d.topic(now, msg.GetTopic())
+	t.Duplicates++
+	if seen, ok := d.firstSeen[msg.ID]; ok && now.Sub(seen) > meshMessageDeliveriesWindow {
+		t.Late++
+	}
+}
+
+func (d *gossipDiagnostics) rpc(act action, rpc *pubsub.RPC) {
+	if d == nil || rpc.Control == nil || act == drop {
+		return
+	}
+	d.Lock()
+	defer d.Unlock()
+	now := d.now()
+	for _, ihave := range rpc.Control.Ihave {
+		t := d.topic(now, ihave.GetTopicID())
+		if act == recv {
+			t.IHaveReceived += uint64(len(ihave.MessageIDs))
+		} else {
+			t.IHaveSent += uint64(len(ihave.MessageIDs))
+		}
+	}
+	var iwant uint64
+	for _, w := range rpc.Control.Iwant {
+		iwant += uint64(len(w.MessageIDs))
+	}
+	if iwant == 0 {
+		return
+	}
+	b := d.bucket(now)
+	if act == recv {
+		b.iwantRecv += iwant
+	} else {
+		b.iwantSent += iwant
+	}
+}
+
+func (d *gossipDiagnostics) setScores(scores map[peer.ID]*pubsub.PeerScoreSnapshot) {
+	if d == nil {
+		return
+	}
+	d.Lock()
+	defer d.Unlock()
+	d.scores = scores
+	d.scoresTime = d.now()
+}
+
+// snapshot returns the counts of the message delivery events within the window and the last peer scores. The mesh
+// of the topics is left for the caller to fill.
+func (d *gossipDiagnostics) snapshot() *GossipDiagnostics {
+	snap := &GossipDiagnostics{
+		Topics: make(map[string]*TopicDiagnostics),
+		Scores: make(map[peer.ID]*pubsub.PeerScoreSnapshot),
+	}
+	if d == nil {
+		snap.Time = prysmTime.Now()
+		return snap
+	}
+	d.Lock()
+	defer d.Unlock()
+	now := d.now()
+	snap.Time = now
+	snap.Window = gossipDiagnosticsWindow
+	if uptime := now.Sub(d.start); uptime < snap.Window {
+		snap.Window = uptime
+	}
+	for _, b := range d.buckets {
+		if now.Sub(b.start) >= gossipDiagnosticsWindow {
+			continue
+		}
+		for topic, counts := range b.topics {
+			t, ok := snap.Topics[topic]
+			if !ok {
+				t = &TopicDiagnostics{}
+				snap.Topics[topic] = t
+			}
+			t.add(counts)
+		}
+		snap.IWantReceived += b.iwantRecv
+		snap.IWantSent += b.iwantSent
+	}
+	for pid, score := range d.scores {
+		snap.Scores[pid] = score
+	}
+	snap.ScoresTime = d.scoresTime
+	return snap
+}
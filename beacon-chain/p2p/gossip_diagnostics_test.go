@@ -0,0 +1,98 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func testGossipMessage(id, topic string) *pubsub.Message {
+	return &pubsub.Message{Message: &pubsubpb.Message{Topic: &topic}, ID: id}
+}
+
+func TestGossipDiagnostics_Messages(t *testing.T) {
+	now := time.Now()
+	d := newGossipDiagnostics()
+	d.start = now
+	d.now = func() time.Time { return now }
+
+	d.validate(testGossipMessage("a", "topic1"))
+	d.deliver(testGossipMessage("a", "topic1"))
+	d.duplicate(testGossipMessage("a", "topic1"))
+	d.reject(testGossipMessage("b", "topic2"))
+	now = now.Add(meshMessageDeliveriesWindow + time.Second)
+	d.duplicate(testGossipMessage("a", "topic1"))
+	// The first delivery of this message was not seen, so it cannot be told late.
+	d.duplicate(testGossipMessage("c", "topic1"))
+
+	snap := d.snapshot()
+	assert.Equal(t, now.Sub(d.start), snap.Window)
+	require.Equal(t, 2, len(snap.Topics))
+	assert.DeepEqual(t, &TopicDiagnostics{Delivered: 1, Duplicates: 3, Late: 1}, snap.Topics["topic1"])
+	assert.DeepEqual(t, &TopicDiagnostics{Rejected: 1}, snap.Topics["topic2"])
+}
+
+func TestGossipDiagnostics_SlidingWindow(t *testing.T) {
+	now := time.Now()
+	d := newGossipDiagnostics()
+	d.start = now.Add(-time.Hour)
+	d.now = func() time.Time { return now }
+
+	d.validate(testGossipMessage("a", "topic1"))
+	d.deliver(testGossipMessage("a", "topic1"))
+	now = now.Add(gossipDiagnosticsWindow / 2)
+	d.deliver(testGossipMessage("b", "topic1"))
+	snap := d.snapshot()
+	assert.Equal(t, gossipDiagnosticsWindow, snap.Window)
+	assert.Equal(t, uint64(2), snap.Topics["topic1"].Delivered)
+
+	now = now.Add(gossipDiagnosticsWindow/2 + gossipDiagnosticsBucket)
+	snap = d.snapshot()
+	assert.Equal(t, uint64(1), snap.Topics["topic1"].Delivered)
+
+	// Rotating the buckets forgets the old messages and drops the buckets out of the window.
+	d.deliver(testGossipMessage("c", "topic1"))
+	assert.Equal(t, 2, len(d.buckets))
+	assert.Equal(t, 0, len(d.firstSeen))
+}
+
+func TestGossipDiagnostics_ControlMessages(t *testing.T) {
+	d := newGossipDiagnostics()
+	topic := "topic1"
+	rpc := &pubsub.RPC{RPC: pubsubpb.RPC{Control: &pubsubpb.ControlMessage{
+		Ihave: []*pubsubpb.ControlIHave{{TopicID: &topic, MessageIDs: []string{"a", "b"}}},
+		Iwant: []*pubsubpb.ControlIWant{{MessageIDs: []string{"c"}}},
+	}}}
+	d.rpc(recv, rpc)
+	d.rpc(send, rpc)
+	d.rpc(send, rpc)
+	d.rpc(drop, rpc)
+
+	snap := d.snapshot()
+	assert.DeepEqual(t, &TopicDiagnostics{IHaveReceived: 2, IHaveSent: 4}, snap.Topics[topic])
+	assert.Equal(t, uint64(1), snap.IWantReceived)
+	assert.Equal(t, uint64(2), snap.IWantSent)
+}
+
+func TestService_GossipDiagnostics(t *testing.T) {
+	s := &Service{mesh: newMeshTracker(), gossipDiag: newGossipDiagnostics()}
+	s.mesh.graft("a", "topic1")
+	s.gossipDiag.deliver(testGossipMessage("a", "topic2"))
+	s.gossipDiag.setScores(map[peer.ID]*pubsub.PeerScoreSnapshot{"a": {Score: 1}})
+
+	snap := s.GossipDiagnostics()
+	require.Equal(t, 2, len(snap.Topics))
+	assert.DeepEqual(t, []peer.ID{"a"}, snap.Topics["topic1"].Mesh)
+	assert.Equal(t, uint64(1), snap.Topics["topic2"].Delivered)
+	assert.Equal(t, float64(1), snap.Scores["a"].Score)
+	assert.Equal(t, false, snap.ScoresTime.IsZero())
+
+	var nilDiag *gossipDiagnostics
+	nilDiag.deliver(testGossipMessage("a", "topic1"))
+	assert.Equal(t, 0, len(nilDiag.snapshot().Topics))
+}
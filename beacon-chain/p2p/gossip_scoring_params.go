@@ -56,6 +56,10 @@ const (
 
 	// dampeningFactor reduces the amount by which the various thresholds and caps are created.
 	dampeningFactor = 90
+
+	// meshMessageDeliveriesWindow is the time after the first delivery of a message during which duplicates from
+	// mesh peers still count as deliveries.
+	meshMessageDeliveriesWindow = 2 * time.Second
 )
 
 var (
@@ -124,6 +128,9 @@ func (s *Service) topicScoreParams(topic string) (*pubsub.TopicScoreParams, erro
 	case strings.Contains(topic, GossipBlobSidecarMessage):
 		// TODO(Deneb): Using the default block scoring. But this should be updated.
 		return defaultBlockTopicParams(), nil
+	case strings.Contains(topic, GossipDataColumnSidecarMessage):
+		// Each data column subnet carries a single sidecar per block, so it is scored like the block topic.
+		return defaultBlockTopicParams(), nil
 	default:
 		return nil, errors.Errorf("unrecognized topic provided for parameter registration: %s", topic)
 	}
@@ -190,7 +197,7 @@ func defaultBlockTopicParams() *pubsub.TopicScoreParams {
 		MeshMessageDeliveriesDecay:      scoreDecay(decayEpoch * oneEpochDuration()),
 		MeshMessageDeliveriesCap:        float64(blocksPerEpoch * uint64(decayEpoch)),
 		MeshMessageDeliveriesThreshold:  float64(blocksPerEpoch*uint64(decayEpoch)) / 10,
-		MeshMessageDeliveriesWindow:     2 * time.Second,
+		MeshMessageDeliveriesWindow:     meshMessageDeliveriesWindow,
 		MeshMessageDeliveriesActivation: 4 * oneEpochDuration(),
 		MeshFailurePenaltyWeight:        meshWeight,
 		MeshFailurePenaltyDecay:         scoreDecay(decayEpoch * oneEpochDuration()),
@@ -232,7 +239,7 @@ func defaultAggregateTopicParams(activeValidators uint64) *pubsub.TopicScorePara
 		MeshMessageDeliveriesDecay:      scoreDecay(1 * oneEpochDuration()),
 		MeshMessageDeliveriesCap:        meshCap,
 		MeshMessageDeliveriesThreshold:  meshThreshold,
-		MeshMessageDeliveriesWindow:     2 * time.Second,
+		MeshMessageDeliveriesWindow:     meshMessageDeliveriesWindow,
 		MeshMessageDeliveriesActivation: 1 * oneEpochDuration(),
 		MeshFailurePenaltyWeight:        meshWeight,
 		MeshFailurePenaltyDecay:         scoreDecay(1 * oneEpochDuration()),
@@ -274,7 +281,7 @@ func defaultSyncContributionTopicParams() *pubsub.TopicScoreParams {
 		MeshMessageDeliveriesDecay:      scoreDecay(1 * oneEpochDuration()),
 		MeshMessageDeliveriesCap:        meshCap,
 		MeshMessageDeliveriesThreshold:  meshThreshold,
-		MeshMessageDeliveriesWindow:     2 * time.Second,
+		MeshMessageDeliveriesWindow:     meshMessageDeliveriesWindow,
 		MeshMessageDeliveriesActivation: 1 * oneEpochDuration(),
 		MeshFailurePenaltyWeight:        meshWeight,
 		MeshFailurePenaltyDecay:         scoreDecay(1 * oneEpochDuration()),
@@ -343,7 +350,7 @@ func defaultAggregateSubnetTopicParams(activeValidators uint64) *pubsub.TopicSco
 		MeshMessageDeliveriesDecay:      scoreDecay(meshDecay * oneEpochDuration()),
 		MeshMessageDeliveriesCap:        meshCap,
 		MeshMessageDeliveriesThreshold:  meshThreshold,
-		MeshMessageDeliveriesWindow:     2 * time.Second,
+		MeshMessageDeliveriesWindow:     meshMessageDeliveriesWindow,
 		MeshMessageDeliveriesActivation: 1 * oneEpochDuration(),
 		MeshFailurePenaltyWeight:        meshWeight,
 		MeshFailurePenaltyDecay:         scoreDecay(meshDecay * oneEpochDuration()),
@@ -406,7 +413,7 @@ func defaultSyncSubnetTopicParams(activeValidators uint64) *pubsub.TopicScorePar
 		MeshMessageDeliveriesDecay:      scoreDecay(meshDecay * oneEpochDuration()),
 		MeshMessageDeliveriesCap:        meshCap,
 		MeshMessageDeliveriesThreshold:  meshThreshold,
-		MeshMessageDeliveriesWindow:     2 * time.Second,
+		MeshMessageDeliveriesWindow:     meshMessageDeliveriesWindow,
 		MeshMessageDeliveriesActivation: 1 * oneEpochDuration(),
 		MeshFailurePenaltyWeight:        meshWeight,
 		MeshFailurePenaltyDecay:         scoreDecay(meshDecay * oneEpochDuration()),
@@ -2,6 +2,8 @@ package p2p
 
 import (
 	"context"
+	"net"
+	"time"
 
 	"github.com/ethereum/go-ethereum/p2p/enr"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
@@ -12,6 +14,7 @@ import (
 	"github.com/multiformats/go-multiaddr"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/encoder"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/types"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/metadata"
 	"google.golang.org/protobuf/proto"
@@ -28,6 +31,7 @@ type P2P interface {
 	ConnectionHandler
 	PeersProvider
 	MetadataProvider
+	CustodyHandler
 }
 
 // Broadcaster broadcasts messages to peers over the p2p pubsub protocol.
@@ -36,6 +40,7 @@ type Broadcaster interface {
 	BroadcastAttestation(ctx context.Context, subnet uint64, att ethpb.Att) error
 	BroadcastSyncCommitteeMessage(ctx context.Context, subnet uint64, sMsg *ethpb.SyncCommitteeMessage) error
 	BroadcastBlob(ctx context.Context, subnet uint64, blob *ethpb.BlobSidecar) error
+	BroadcastDataColumn(ctx context.Context, subnet uint64, column *ethpb.DataColumnSidecar) error
 }
 
 // SetStreamHandler configures p2p to handle streams of a certain topic ID.
@@ -87,6 +92,23 @@ type PeerManager interface {
 	AddPingMethod(reqFunc func(ctx context.Context, id peer.ID) error)
 }
 
+// PeerAdmin manages peers at runtime on behalf of the node operator.
+type PeerAdmin interface {
+	BanPeer(pid peer.ID, d time.Duration)
+	UnbanPeer(pid peer.ID)
+	BanSubnet(subnet *net.IPNet, d time.Duration)
+	UnbanSubnet(subnet *net.IPNet) bool
+	BannedSubnets() map[string]time.Time
+	AddStaticPeer(info peer.AddrInfo, d time.Duration) error
+	DisconnectWithGoodbye(ctx context.Context, pid peer.ID, code types.RPCGoodbyeCode) error
+	Meshes() map[string][]peer.ID
+}
+
+// GossipDiagnosticsProvider provides a snapshot of the state of the gossipsub router.
+type GossipDiagnosticsProvider interface {
+	GossipDiagnostics() *GossipDiagnostics
+}
+
 // Sender abstracts the sending functionality from libp2p.
 type Sender interface {
 	Send(context.Context, interface{}, string, peer.ID) (network.Stream, error)
@@ -97,6 +119,12 @@ type PeersProvider interface {
 	Peers() *peers.Status
 }
 
+// CustodyHandler provides the data column custody of remote peers.
+type CustodyHandler interface {
+	CustodyCountFromRemotePeer(peer.ID) uint64
+	CustodyColumnsFromRemotePeer(peer.ID) (map[uint64]bool, error)
+}
+
 // MetadataProvider returns the metadata related information for the local peer.
 type MetadataProvider interface {
 	Metadata() metadata.Metadata
@@ -64,6 +64,14 @@ var (
 		Name: "p2p_blob_sidecar_committee_broadcasts",
 		Help: "The number of blob sidecar committee messages that were broadcast with no peer on.",
 	})
+	dataColumnSidecarBroadcasts = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "p2p_data_column_sidecar_subnet_broadcasts",
+		Help: "The number of data column sidecar messages that were broadcast with no peer on.",
+	})
+	dataColumnSidecarBroadcastAttempts = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "p2p_data_column_sidecar_subnet_attempted_broadcasts",
+		Help: "The number of data column sidecar messages that were attempted to be broadcast.",
+	})
 	syncCommitteeBroadcastAttempts = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "p2p_sync_committee_subnet_attempted_broadcasts",
 		Help: "The number of sync committee that were attempted to be broadcast.",
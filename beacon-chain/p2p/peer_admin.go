@@ -0,0 +1,195 @@
+package p2p
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	prysmTime "github.com/prysmaticlabs/prysm/v5/time"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+var _ PeerAdmin = (*Service)(nil)
+
+// adminExpiryInterval is how often expired subnet bans and temporary static peers are lifted.
+const adminExpiryInterval = time.Minute
+
+// subnetBan is a ban of an IP subnet added at runtime. The action the subnet had in the address filter before
+// the ban, if any, is restored when the ban is lifted.
+type subnetBan struct {
+	subnet     net.IPNet
+	expiry     time.Time
+	prevAction multiaddr.Action
+	hadAction  bool
+}
+
+// BanPeer bans the peer for the given duration, and disconnects from it.
+func (s *Service) BanPeer(pid peer.ID, d time.Duration) {
+	s.peers.Ban(pid, prysmTime.Now().Add(d))
+	if s.host.Network().Connectedness(pid) == network.Connected {
+		go func() {
+			if err := s.DisconnectWithGoodbye(s.ctx, pid, types.GoodbyeCodeBanned); err != nil {
+				log.WithError(err).WithField("peer", pid).Debug("Could not disconnect from banned peer")
+			}
+		}()
+	}
+}
+
+// UnbanPeer lifts the ban of the peer.
+func (s *Service) UnbanPeer(pid peer.ID) {
+	s.peers.Unban(pid)
+}
+
+// BanSubnet denies connections from and to the IP subnet for the given duration, and disconnects from the peers in
+// it.
+func (s *Service) BanSubnet(subnet *net.IPNet, d time.Duration) {
+	s.adminLock.Lock()
+	key := subnet.String()
+	ban, ok := s.subnetBans[key]
+	if !ok {
+		ban = &subnetBan{subnet: *subnet}
+		ban.prevAction, ban.hadAction = s.addrFilter.ActionForFilter(*subnet)
+		s.subnetBans[key] = ban
+	}
+	ban.expiry = prysmTime.Now().Add(d)
+	s.addrFilter.AddFilter(*subnet, multiaddr.ActionDeny)
+	s.adminLock.Unlock()
+
+	for _, pid := range s.peers.Connected() {
+		addr, err := s.peers.Address(pid)
+		if err != nil || addr == nil {
+			continue
+		}
+		ip, err := manet.ToIP(addr)
+		if err != nil || !subnet.Contains(ip) {
+			continue
+		}
+		go func(pid peer.ID) {
+			if err := s.DisconnectWithGoodbye(s.ctx, pid, types.GoodbyeCodeBanned); err != nil {
+				log.WithError(err).WithField("peer", pid).Debug("Could not disconnect from banned peer")
+			}
+		}(pid)
+	}
+}
+
+// UnbanSubnet lifts the ban of the IP subnet. It returns false if the subnet was not banned at runtime, in which
+// case bans from the deny list of the configuration are left untouched.
+func (s *Service) UnbanSubnet(subnet *net.IPNet) bool {
+	s.adminLock.Lock()
+	defer s.adminLock.Unlock()
+	ban, ok := s.subnetBans[subnet.String()]
+	if !ok {
+		return false
+	}
+	s.liftSubnetBan(ban)
+	return true
+}
+
+// BannedSubnets returns the IP subnets banned at runtime, along with the expiry of their ban.
+func (s *Service) BannedSubnets() map[string]time.Time {
+	s.adminLock.Lock()
+	defer s.adminLock.Unlock()
+	banned := make(map[string]time.Time, len(s.subnetBans))
+	for key, ban := range s.subnetBans {
+		banned[key] = ban.expiry
+	}
+	return banned
+}
+
+// AddStaticPeer adds the peer as a trusted peer that we keep a connection to, for the given duration or until the
+// node restarts if the duration is zero, and connects to it.
+func (s *Service) AddStaticPeer(info peer.AddrInfo, d time.Duration) error {
+	if len(info.Addrs) == 0 {
+		return errors.New("no address for peer")
+	}
+	s.adminLock.Lock()
+	_, temporary := s.staticPeerExpiries[info.ID]
+	switch {
+	case d == 0:
+		delete(s.staticPeerExpiries, info.ID)
+	case temporary || !s.peers.IsTrustedPeers(info.ID):
+		// Peers which are already trusted for good are not made temporary.
+		s.staticPeerExpiries[info.ID] = prysmTime.Now().Add(d)
+	}
+	s.adminLock.Unlock()
+	s.peers.Add(nil, info.ID, info.Addrs[0], network.DirUnknown)
+	s.peers.SetTrustedPeers([]peer.ID{info.ID})
+	go func() {
+		if err := s.connectWithPeer(s.ctx, info); err != nil {
+			log.WithError(err).WithField("peer", info.ID).Debug("Could not connect with static peer")
+		}
+	}()
+	return nil
+}
+
+// DisconnectWithGoodbye sends a goodbye message with the given code to the peer, and disconnects from it.
+func (s *Service) DisconnectWithGoodbye(ctx context.Context, pid peer.ID, code types.RPCGoodbyeCode) error {
+	if err := s.sendGoodbye(ctx, pid, code); err != nil {
+		log.WithError(err).WithField("peer", pid).Debug("Could not send goodbye message")
+	}
+	return s.Disconnect(pid)
+}
+
+// Meshes returns the peers in our gossipsub mesh of each topic.
+func (s *Service) Meshes() map[string][]peer.ID {
+	return s.mesh.meshes()
+}
+
+func (s *Service) sendGoodbye(ctx context.Context, pid peer.ID, code types.RPCGoodbyeCode) error {
+	ctx, cancel := context.WithTimeout(ctx, params.BeaconConfig().RespTimeoutDuration())
+	defer cancel()
+
+	var epoch = params.BeaconConfig().GenesisEpoch
+	if !s.genesisTime.IsZero() {
+		epoch = slots.ToEpoch(slots.CurrentSlot(uint64(s.genesisTime.Unix())))
+	}
+	topic, err := TopicFromMessage(GoodbyeMessageName, epoch)
+	if err != nil {
+		return err
+	}
+	stream, err := s.Send(ctx, &code, topic, pid)
+	if err != nil {
+		return err
+	}
+	return stream.Close()
+}
+
+// expireAdminState lifts the subnet bans and removes the temporary static peers which expired.
+func (s *Service) expireAdminState() {
+	s.adminLock.Lock()
+	defer s.adminLock.Unlock()
+	now := prysmTime.Now()
+	for _, ban := range s.subnetBans {
+		if !ban.expiry.After(now) {
+			s.liftSubnetBan(ban)
+		}
+	}
+	var expired []peer.ID
+	for pid, expiry := range s.staticPeerExpiries {
+		if !expiry.After(now) {
+			expired = append(expired, pid)
+			delete(s.staticPeerExpiries, pid)
+		}
+	}
+	if len(expired) > 0 {
+		s.peers.DeleteTrustedPeers(expired)
+	}
+}
+
+// liftSubnetBan removes the subnet ban from the address filter, restoring the previous action of the subnet.
+// The admin lock must be held.
+func (s *Service) liftSubnetBan(ban *subnetBan) {
+	if ban.hadAction {
+		s.addrFilter.AddFilter(ban.subnet, ban.prevAction)
+	} else {
+		s.addrFilter.RemoveLiteral(ban.subnet)
+	}
+	delete(s.subnetBans, ban.subnet.String())
+}
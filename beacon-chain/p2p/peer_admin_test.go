@@ -0,0 +1,139 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/scorers"
+	leakybucket "github.com/prysmaticlabs/prysm/v5/container/leaky-bucket"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func newAdminTestService(t *testing.T, cfg *Config) *Service {
+	filter, err := configureFilter(cfg)
+	require.NoError(t, err)
+	return &Service{
+		ctx:        context.Background(),
+		cfg:        cfg,
+		addrFilter: filter,
+		ipLimiter:  leakybucket.NewCollector(ipLimit, ipBurst, 1*time.Second, false),
+		peers: peers.NewStatus(context.Background(), &peers.StatusConfig{
+			ScorerParams: &scorers.Config{},
+		}),
+		subnetBans:         make(map[string]*subnetBan),
+		staticPeerExpiries: make(map[peer.ID]time.Time),
+	}
+}
+
+func canDial(t *testing.T, s *Service, ip string) bool {
+	addr, err := ma.NewMultiaddr(fmt.Sprintf("/ip4/%s/tcp/%d", ip, 3000))
+	require.NoError(t, err)
+	return s.InterceptAddrDial("", addr)
+}
+
+func TestService_BanSubnet(t *testing.T) {
+	s := newAdminTestService(t, &Config{})
+	_, subnet, err := net.ParseCIDR("212.67.0.0/16")
+	require.NoError(t, err)
+
+	s.BanSubnet(subnet, time.Hour)
+	assert.Equal(t, false, canDial(t, s, "212.67.10.122"))
+	assert.Equal(t, true, canDial(t, s, "91.65.69.69"))
+	banned := s.BannedSubnets()
+	require.Equal(t, 1, len(banned))
+	_, ok := banned["212.67.0.0/16"]
+	assert.Equal(t, true, ok)
+
+	require.Equal(t, true, s.UnbanSubnet(subnet))
+	assert.Equal(t, true, canDial(t, s, "212.67.10.122"))
+	assert.Equal(t, 0, len(s.BannedSubnets()))
+	assert.Equal(t, false, s.UnbanSubnet(subnet))
+}
+
+func TestService_BanSubnet_AllowList(t *testing.T) {
+	s := newAdminTestService(t, &Config{AllowListCIDR: "212.67.0.0/16"})
+	_, subnet, err := net.ParseCIDR("212.67.10.0/24")
+	require.NoError(t, err)
+
+	s.BanSubnet(subnet, time.Hour)
+	assert.Equal(t, false, canDial(t, s, "212.67.10.122"))
+	assert.Equal(t, true, canDial(t, s, "212.67.11.122"))
+
+	require.Equal(t, true, s.UnbanSubnet(subnet))
+	assert.Equal(t, true, canDial(t, s, "212.67.10.122"))
+}
+
+func TestService_BanSubnet_RestoresConfiguredAction(t *testing.T) {
+	s := newAdminTestService(t, &Config{DenyListCIDR: []string{"212.67.0.0/16"}})
+	_, subnet, err := net.ParseCIDR("212.67.0.0/16")
+	require.NoError(t, err)
+
+	s.BanSubnet(subnet, time.Hour)
+	require.Equal(t, true, s.UnbanSubnet(subnet))
+	// The subnet is still denied by the configuration.
+	assert.Equal(t, false, canDial(t, s, "212.67.10.122"))
+}
+
+func TestService_ExpireAdminState(t *testing.T) {
+	s := newAdminTestService(t, &Config{})
+	_, expiring, err := net.ParseCIDR("212.67.0.0/16")
+	require.NoError(t, err)
+	_, lasting, err := net.ParseCIDR("91.65.0.0/16")
+	require.NoError(t, err)
+	s.BanSubnet(expiring, -time.Second)
+	s.BanSubnet(lasting, time.Hour)
+
+	s.peers.SetTrustedPeers([]peer.ID{"temporary", "permanent"})
+	s.staticPeerExpiries["temporary"] = time.Now().Add(-time.Second)
+
+	s.expireAdminState()
+	assert.Equal(t, true, canDial(t, s, "212.67.10.122"))
+	assert.Equal(t, false, canDial(t, s, "91.65.69.69"))
+	assert.Equal(t, false, s.peers.IsTrustedPeers("temporary"))
+	assert.Equal(t, true, s.peers.IsTrustedPeers("permanent"))
+	assert.Equal(t, 0, len(s.staticPeerExpiries))
+}
+
+func TestService_BanPeer(t *testing.T) {
+	s := newAdminTestService(t, &Config{})
+	h, _, _ := createHost(t, 2000)
+	s.host = h
+	pid := peer.ID("banned")
+
+	s.BanPeer(pid, time.Hour)
+	assert.Equal(t, true, s.peers.IsBad(pid))
+	_, ok := s.peers.Banned()[pid]
+	assert.Equal(t, true, ok)
+
+	s.UnbanPeer(pid)
+	assert.Equal(t, false, s.peers.IsBad(pid))
+}
+
+func TestMeshTracker(t *testing.T) {
+	m := newMeshTracker()
+	m.graft("b", "topic1")
+	m.graft("a", "topic1")
+	m.graft("a", "topic2")
+	m.graft("c", "topic3")
+	assert.DeepEqual(t, map[string][]peer.ID{
+		"topic1": {"a", "b"},
+		"topic2": {"a"},
+		"topic3": {"c"},
+	}, m.meshes())
+
+	m.prune("b", "topic1")
+	m.removePeer("a")
+	m.leave("topic3")
+	assert.Equal(t, 0, len(m.meshes()))
+
+	var nilTracker *meshTracker
+	nilTracker.graft("a", "topic1")
+	assert.Equal(t, 0, len(nilTracker.meshes()))
+}
@@ -0,0 +1,96 @@
+package p2p
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/peerdata"
+	prysmTime "github.com/prysmaticlabs/prysm/v5/time"
+)
+
+const (
+	// peerRecordsSaveInterval is how often the records of known peers are persisted.
+	peerRecordsSaveInterval = 10 * time.Minute
+	// peerRecordsRetention is how long the record of a peer which is not seen anymore is kept.
+	peerRecordsRetention = 7 * 24 * time.Hour
+)
+
+// PeerStore persists the records of known peers, so that their addresses and reputation survive restarts.
+type PeerStore interface {
+	PeerRecords(ctx context.Context) ([]*peerdata.Record, error)
+	SavePeerRecords(ctx context.Context, records []*peerdata.Record) error
+	DeletePeerRecordsBefore(ctx context.Context, cutoff time.Time) error
+}
+
+// restorePeerRecords seeds the peer status with the persisted peer records, after aging out the records of peers not
+// seen for too long. It returns the known-good peers to dial.
+func (s *Service) restorePeerRecords() []peer.AddrInfo {
+	if s.cfg.PeerStore == nil {
+		return nil
+	}
+	now := prysmTime.Now()
+	if err := s.cfg.PeerStore.DeletePeerRecordsBefore(s.ctx, now.Add(-peerRecordsRetention)); err != nil {
+		log.WithError(err).Error("Could not prune peer records")
+	}
+	records, err := s.cfg.PeerStore.PeerRecords(s.ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not load peer records")
+		return nil
+	}
+	s.peers.Restore(records)
+	known := knownGoodPeers(records, now, int(s.cfg.MaxPeers))
+	log.WithField("records", len(records)).WithField("knownGood", len(known)).Info("Restored peer records")
+	return known
+}
+
+// savePeerRecords persists the records of the peers currently known, and ages out the records of peers not seen for
+// too long.
+func (s *Service) savePeerRecords() {
+	if s.cfg.PeerStore == nil {
+		return
+	}
+	if err := s.cfg.PeerStore.SavePeerRecords(s.ctx, s.peers.Records()); err != nil {
+		log.WithError(err).Error("Could not save peer records")
+		return
+	}
+	if err := s.cfg.PeerStore.DeletePeerRecordsBefore(s.ctx, prysmTime.Now().Add(-peerRecordsRetention)); err != nil {
+		log.WithError(err).Error("Could not prune peer records")
+	}
+}
+
+// connectWithKnownPeers dials the given peers, without blocking.
+func (s *Service) connectWithKnownPeers(infos []peer.AddrInfo) {
+	for _, info := range infos {
+		go func(info peer.AddrInfo) {
+			if err := s.connectWithPeer(s.ctx, info); err != nil {
+				log.WithError(err).Tracef("Could not connect with peer %s", info.String())
+			}
+		}(info)
+	}
+}
+
+// knownGoodPeers returns the address info of, at most, limit peers which are not banned, had no bad responses and
+// no negative gossip score, most recently seen first.
+func knownGoodPeers(records []*peerdata.Record, now time.Time, limit int) []peer.AddrInfo {
+	good := make([]*peerdata.Record, 0, len(records))
+	for _, r := range records {
+		if r.Address == nil || r.LastSeen.IsZero() || r.Banned(now) || r.BadResponses > 0 || r.GossipScore < 0 {
+			continue
+		}
+		good = append(good, r)
+	}
+	sort.Slice(good, func(i, j int) bool {
+		return good[i].LastSeen.After(good[j].LastSeen)
+	})
+	if len(good) > limit {
+		good = good[:limit]
+	}
+	infos := make([]peer.AddrInfo, 0, len(good))
+	for _, r := range good {
+		infos = append(infos, peer.AddrInfo{ID: r.PeerID, Addrs: []multiaddr.Multiaddr{r.Address}})
+	}
+	return infos
+}
@@ -0,0 +1,85 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	dbutil "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/peerdata"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/scorers"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestKnownGoodPeers(t *testing.T) {
+	addr, err := ma.NewMultiaddr("/ip4/213.202.254.180/tcp/13000")
+	require.NoError(t, err)
+	now := time.Now()
+	records := []*peerdata.Record{
+		{PeerID: "old", Address: addr, LastSeen: now.Add(-time.Hour)},
+		{PeerID: "recent", Address: addr, LastSeen: now.Add(-time.Minute)},
+		{PeerID: "older", Address: addr, LastSeen: now.Add(-2 * time.Hour)},
+		{PeerID: "unseen", Address: addr},
+		{PeerID: "noaddr", LastSeen: now},
+		{PeerID: "banned", Address: addr, LastSeen: now, BanExpiry: now.Add(time.Hour)},
+		{PeerID: "badresponses", Address: addr, LastSeen: now, BadResponses: 1},
+		{PeerID: "badgossip", Address: addr, LastSeen: now, GossipScore: -1},
+	}
+	infos := knownGoodPeers(records, now, 2)
+	require.Equal(t, 2, len(infos))
+	assert.Equal(t, peer.ID("recent"), infos[0].ID)
+	assert.Equal(t, peer.ID("old"), infos[1].ID)
+	assert.Equal(t, true, addr.Equal(infos[0].Addrs[0]))
+}
+
+func TestService_PeerRecords(t *testing.T) {
+	db := dbutil.SetupDB(t)
+	ctx := context.Background()
+	addr, err := ma.NewMultiaddr("/ip4/213.202.254.180/tcp/13000")
+	require.NoError(t, err)
+	newService := func() *Service {
+		return &Service{
+			ctx: ctx,
+			cfg: &Config{PeerStore: db, MaxPeers: 30},
+			peers: peers.NewStatus(ctx, &peers.StatusConfig{
+				PeerLimit: 30,
+				ScorerParams: &scorers.Config{
+					BadResponsesScorerConfig: &scorers.BadResponsesScorerConfig{
+						Threshold: maxBadResponses,
+					},
+				},
+			}),
+		}
+	}
+
+	s := newService()
+	s.peers.Add(nil, "good", addr, network.DirOutbound)
+	s.peers.SetConnectionState("good", peers.PeerConnected)
+	s.peers.Add(nil, "bad", addr, network.DirInbound)
+	s.peers.SetConnectionState("bad", peers.PeerConnected)
+	for i := 0; i < maxBadResponses; i++ {
+		s.peers.Scorers().BadResponsesScorer().Increment("bad")
+	}
+	s.savePeerRecords()
+	// Records of peers not seen for too long are aged out.
+	require.NoError(t, db.SavePeerRecords(ctx, []*peerdata.Record{
+		{PeerID: "stale", Address: addr, LastSeen: time.Now().Add(-peerRecordsRetention - time.Hour)},
+	}))
+
+	// After a restart, the bad peer is still bad and the good one is dialed.
+	s = newService()
+	known := s.restorePeerRecords()
+	require.Equal(t, 1, len(known))
+	assert.Equal(t, peer.ID("good"), known[0].ID)
+	assert.Equal(t, true, s.peers.IsBad("bad"))
+	assert.Equal(t, false, s.peers.IsBad("good"))
+	assert.Equal(t, 2, len(s.peers.Disconnected()))
+	records, err := db.PeerRecords(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(records))
+}
@@ -0,0 +1,182 @@
+package peerdata
+
+import (
+	"encoding/binary"
+	"math"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/pkg/errors"
+)
+
+// recordVersion is the version of the record encoding, written as its first byte.
+const recordVersion = 1
+
+var errRecordTruncated = errors.New("encoded peer record is truncated")
+
+// Record is the part of the data of a peer that is persisted across restarts: how to reach the peer, when it was
+// last seen and its reputation.
+type Record struct {
+	PeerID  peer.ID
+	Enr     *enr.Record
+	Address ma.Multiaddr
+	// LastSeen is the last time the peer was connected.
+	LastSeen         time.Time
+	BadResponses     int
+	ProcessedBlocks  uint64
+	GossipScore      float64
+	BehaviourPenalty float64
+	// BanExpiry is the time until which the peer is considered bad, regardless of its scores.
+	BanExpiry time.Time
+}
+
+// Banned returns true if the peer is banned at the given time.
+func (r *Record) Banned(now time.Time) bool {
+	return r.BanExpiry.After(now)
+}
+
+// EncodeRecord encodes a peer record. The peer ID is not part of the encoding, as records are keyed by it.
+func EncodeRecord(r *Record) ([]byte, error) {
+	buf := []byte{recordVersion}
+	buf = binary.AppendVarint(buf, unixNano(r.LastSeen))
+	buf = binary.AppendVarint(buf, unixNano(r.BanExpiry))
+	buf = binary.AppendUvarint(buf, uint64(r.BadResponses))
+	buf = binary.AppendUvarint(buf, r.ProcessedBlocks)
+	buf = binary.BigEndian.AppendUint64(buf, math.Float64bits(r.GossipScore))
+	buf = binary.BigEndian.AppendUint64(buf, math.Float64bits(r.BehaviourPenalty))
+	var addr []byte
+	if r.Address != nil {
+		addr = r.Address.Bytes()
+	}
+	buf = appendBytes(buf, addr)
+	var record []byte
+	// Unsigned records, such as the empty records of peers added without one, cannot be encoded.
+	if r.Enr != nil && r.Enr.Signature() != nil {
+		var err error
+		record, err = rlp.EncodeToBytes(r.Enr)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not encode ENR")
+		}
+	}
+	return appendBytes(buf, record), nil
+}
+
+// DecodeRecord decodes the record of the given peer.
+func DecodeRecord(pid peer.ID, buf []byte) (*Record, error) {
+	if len(buf) == 0 {
+		return nil, errRecordTruncated
+	}
+	if buf[0] != recordVersion {
+		return nil, errors.Errorf("unknown peer record version %d", buf[0])
+	}
+	d := &recordDecoder{buf: buf[1:]}
+	r := &Record{PeerID: pid}
+	r.LastSeen = fromUnixNano(d.varint())
+	r.BanExpiry = fromUnixNano(d.varint())
+	r.BadResponses = int(d.uvarint())
+	r.ProcessedBlocks = d.uvarint()
+	r.GossipScore = math.Float64frombits(d.uint64())
+	r.BehaviourPenalty = math.Float64frombits(d.uint64())
+	addr := d.bytes()
+	record := d.bytes()
+	if d.err != nil {
+		return nil, d.err
+	}
+	if len(d.buf) != 0 {
+		return nil, errors.Errorf("%d trailing bytes in encoded peer record", len(d.buf))
+	}
+	if len(addr) > 0 {
+		var err error
+		r.Address, err = ma.NewMultiaddrBytes(addr)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not decode address")
+		}
+	}
+	if len(record) > 0 {
+		r.Enr = &enr.Record{}
+		if err := rlp.DecodeBytes(record, r.Enr); err != nil {
+			return nil, errors.Wrap(err, "could not decode ENR")
+		}
+	}
+	return r, nil
+}
+
+func unixNano(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+func fromUnixNano(n int64) time.Time {
+	if n == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, n)
+}
+
+func appendBytes(buf, b []byte) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(b)))
+	return append(buf, b...)
+}
+
+type recordDecoder struct {
+	buf []byte
+	err error
+}
+
+func (d *recordDecoder) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(d.buf)
+	if n <= 0 {
+		d.err = errRecordTruncated
+		return 0
+	}
+	d.buf = d.buf[n:]
+	return v
+}
+
+func (d *recordDecoder) varint() int64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(d.buf)
+	if n <= 0 {
+		d.err = errRecordTruncated
+		return 0
+	}
+	d.buf = d.buf[n:]
+	return v
+}
+
+func (d *recordDecoder) uint64() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	if len(d.buf) < 8 {
+		d.err = errRecordTruncated
+		return 0
+	}
+	v := binary.BigEndian.Uint64(d.buf)
+	d.buf = d.buf[8:]
+	return v
+}
+
+func (d *recordDecoder) bytes() []byte {
+	l := d.uvarint()
+	if d.err != nil {
+		return nil
+	}
+	if uint64(len(d.buf)) < l {
+		d.err = errRecordTruncated
+		return nil
+	}
+	b := d.buf[:l]
+	d.buf = d.buf[l:]
+	return b
+}
@@ -0,0 +1,73 @@
+package peerdata_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/peerdata"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestRecord_EncodeDecode(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	record := &enr.Record{}
+	record.Set(enr.IPv4{127, 0, 0, 1})
+	require.NoError(t, enode.SignV4(record, key))
+	addr, err := ma.NewMultiaddr("/ip4/127.0.0.1/tcp/13000")
+	require.NoError(t, err)
+	pid := peer.ID("peer")
+
+	t.Run("full", func(t *testing.T) {
+		r := &peerdata.Record{
+			PeerID:           pid,
+			Enr:              record,
+			Address:          addr,
+			LastSeen:         time.Unix(1700000000, 12),
+			BadResponses:     3,
+			ProcessedBlocks:  640,
+			GossipScore:      -1.5,
+			BehaviourPenalty: 0.25,
+			BanExpiry:        time.Unix(1700003600, 0),
+		}
+		enc, err := peerdata.EncodeRecord(r)
+		require.NoError(t, err)
+		decoded, err := peerdata.DecodeRecord(pid, enc)
+		require.NoError(t, err)
+		assert.Equal(t, pid, decoded.PeerID)
+		assert.Equal(t, true, addr.Equal(decoded.Address))
+		assert.Equal(t, record.Seq(), decoded.Enr.Seq())
+		assert.DeepEqual(t, record.Signature(), decoded.Enr.Signature())
+		assert.Equal(t, true, r.LastSeen.Equal(decoded.LastSeen))
+		assert.Equal(t, true, r.BanExpiry.Equal(decoded.BanExpiry))
+		assert.Equal(t, 3, decoded.BadResponses)
+		assert.Equal(t, uint64(640), decoded.ProcessedBlocks)
+		assert.Equal(t, -1.5, decoded.GossipScore)
+		assert.Equal(t, 0.25, decoded.BehaviourPenalty)
+	})
+	t.Run("empty", func(t *testing.T) {
+		// Unsigned records are not persisted.
+		enc, err := peerdata.EncodeRecord(&peerdata.Record{PeerID: pid, Enr: new(enr.Record)})
+		require.NoError(t, err)
+		decoded, err := peerdata.DecodeRecord(pid, enc)
+		require.NoError(t, err)
+		assert.Equal(t, (*enr.Record)(nil), decoded.Enr)
+		assert.Equal(t, nil, decoded.Address)
+		assert.Equal(t, true, decoded.LastSeen.IsZero())
+		assert.Equal(t, false, decoded.Banned(time.Now()))
+	})
+	t.Run("truncated", func(t *testing.T) {
+		enc, err := peerdata.EncodeRecord(&peerdata.Record{PeerID: pid, Address: addr})
+		require.NoError(t, err)
+		_, err = peerdata.DecodeRecord(pid, enc[:len(enc)-2])
+		require.ErrorContains(t, "truncated", err)
+		_, err = peerdata.DecodeRecord(pid, append([]byte{0}, enc[1:]...))
+		require.ErrorContains(t, "unknown peer record version", err)
+	})
+}
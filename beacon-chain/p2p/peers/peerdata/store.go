@@ -52,6 +52,10 @@ type PeerData struct {
 	ConnState     PeerConnectionState
 	Enr           *enr.Record
 	NextValidTime time.Time
+	// LastSeen is the last time the peer was connected.
+	LastSeen time.Time
+	// BanExpiry is the time until which the peer is considered bad, regardless of its scores.
+	BanExpiry time.Time
 	// Chain related data.
 	MetaData                  metadata.Metadata
 	ChainState                *ethpb.Status
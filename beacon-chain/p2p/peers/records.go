@@ -0,0 +1,86 @@
+package peers
+
+import (
+	"sort"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/peerdata"
+	prysmTime "github.com/prysmaticlabs/prysm/v5/time"
+)
+
+// Records returns the records to persist of the peers with a known address which were seen at some point, or are
+// banned. Peers currently deemed bad by the scorers are banned for BanDuration.
+func (p *Status) Records() []*peerdata.Record {
+	p.store.RLock()
+	defer p.store.RUnlock()
+
+	now := prysmTime.Now()
+	records := make([]*peerdata.Record, 0, len(p.store.Peers()))
+	for pid, peerData := range p.store.Peers() {
+		if peerData.Address == nil {
+			continue
+		}
+		r := &peerdata.Record{
+			PeerID:           pid,
+			Enr:              peerData.Enr,
+			Address:          peerData.Address,
+			LastSeen:         peerData.LastSeen,
+			BadResponses:     peerData.BadResponses,
+			ProcessedBlocks:  peerData.ProcessedBlocks,
+			GossipScore:      peerData.GossipScore,
+			BehaviourPenalty: peerData.BehaviourPenalty,
+			BanExpiry:        peerData.BanExpiry,
+		}
+		if peerData.ConnState == PeerConnected {
+			r.LastSeen = now
+		}
+		if !p.store.IsTrustedPeer(pid) && p.scorers.IsBadPeerNoLock(pid) && !r.Banned(now.Add(BanDuration)) {
+			r.BanExpiry = now.Add(BanDuration)
+		}
+		if r.LastSeen.IsZero() && !r.Banned(now) {
+			continue
+		}
+		records = append(records, r)
+	}
+	return records
+}
+
+// Restore adds the peers of persisted records, as disconnected peers, along with their reputation. Peers which are
+// already known are left untouched. Banned peers are restored first, then the most recently seen ones, up to the
+// capacity of the peer store.
+func (p *Status) Restore(records []*peerdata.Record) {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	now := prysmTime.Now()
+	sorted := make([]*peerdata.Record, len(records))
+	copy(sorted, records)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if bi, bj := sorted[i].Banned(now), sorted[j].Banned(now); bi != bj {
+			return bi
+		}
+		return sorted[i].LastSeen.After(sorted[j].LastSeen)
+	})
+	for _, r := range sorted {
+		if len(p.store.Peers()) >= p.store.Config().MaxPeers {
+			break
+		}
+		if _, ok := p.store.PeerData(r.PeerID); ok {
+			continue
+		}
+		p.store.SetPeerData(r.PeerID, &peerdata.PeerData{
+			Address:              r.Address,
+			Direction:            network.DirUnknown,
+			ConnState:            PeerDisconnected,
+			Enr:                  r.Enr,
+			LastSeen:             r.LastSeen,
+			BanExpiry:            r.BanExpiry,
+			BadResponses:         r.BadResponses,
+			ProcessedBlocks:      r.ProcessedBlocks,
+			BlockProviderUpdated: r.LastSeen,
+			GossipScore:          r.GossipScore,
+			BehaviourPenalty:     r.BehaviourPenalty,
+		})
+		p.addIpToTracker(r.PeerID)
+	}
+}
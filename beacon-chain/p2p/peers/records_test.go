@@ -0,0 +1,125 @@
+package peers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/peerdata"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/scorers"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestStatus_Records(t *testing.T) {
+	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit: 30,
+		ScorerParams: &scorers.Config{
+			BadResponsesScorerConfig: &scorers.BadResponsesScorerConfig{
+				Threshold: 2,
+			},
+		},
+	})
+	addr, err := ma.NewMultiaddr("/ip4/213.202.254.180/tcp/13000")
+	require.NoError(t, err)
+
+	// Never connected.
+	p.Add(nil, "unseen", addr, network.DirOutbound)
+	// Connected, then disconnected.
+	p.Add(nil, "seen", addr, network.DirOutbound)
+	p.SetConnectionState("seen", peers.PeerConnected)
+	p.SetConnectionState("seen", peers.PeerDisconnected)
+	// Connected, with blocks served.
+	p.Add(nil, "good", addr, network.DirInbound)
+	p.SetConnectionState("good", peers.PeerConnected)
+	p.Scorers().BlockProviderScorer().IncrementProcessedBlocks("good", 64)
+	// Never connected, but bad.
+	p.Add(nil, "bad", addr, network.DirInbound)
+	p.Scorers().BadResponsesScorer().Increment("bad")
+	p.Scorers().BadResponsesScorer().Increment("bad")
+
+	records := make(map[peer.ID]*peerdata.Record)
+	for _, r := range p.Records() {
+		records[r.PeerID] = r
+	}
+	require.Equal(t, 3, len(records))
+	_, ok := records["unseen"]
+	assert.Equal(t, false, ok)
+	assert.Equal(t, false, records["seen"].LastSeen.IsZero())
+	assert.Equal(t, false, records["seen"].Banned(time.Now()))
+	assert.Equal(t, uint64(64), records["good"].ProcessedBlocks)
+	assert.Equal(t, true, records["bad"].Banned(time.Now().Add(peers.BanDuration-time.Minute)))
+	assert.Equal(t, false, records["bad"].Banned(time.Now().Add(peers.BanDuration+time.Minute)))
+	assert.Equal(t, 2, records["bad"].BadResponses)
+}
+
+func TestStatus_Restore(t *testing.T) {
+	addr, err := ma.NewMultiaddr("/ip4/213.202.254.180/tcp/13000")
+	require.NoError(t, err)
+	now := time.Now()
+	records := []*peerdata.Record{
+		{PeerID: "good", Address: addr, LastSeen: now.Add(-time.Minute), ProcessedBlocks: 64},
+		// Banned, although its bad responses have decayed.
+		{PeerID: "banned", Address: addr, LastSeen: now.Add(-time.Hour), BanExpiry: now.Add(time.Hour)},
+		{PeerID: "expired", Address: addr, LastSeen: now.Add(-time.Hour), BanExpiry: now.Add(-time.Minute)},
+		{PeerID: "known", Address: addr, LastSeen: now.Add(-time.Hour), BadResponses: 1},
+	}
+
+	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit:    30,
+		ScorerParams: &scorers.Config{},
+	})
+	p.Add(nil, "known", addr, network.DirInbound)
+	p.SetConnectionState("known", peers.PeerConnected)
+	p.Restore(records)
+
+	require.Equal(t, 4, len(p.All()))
+	assert.Equal(t, 4, len(p.Disconnected())+len(p.Connected()))
+	assert.Equal(t, uint64(64), p.Scorers().BlockProviderScorer().ProcessedBlocks("good"))
+	assert.Equal(t, true, p.IsBad("banned"))
+	assert.Equal(t, false, p.IsBad("expired"))
+	assert.Equal(t, false, p.IsBad("good"))
+	// Known peers are left untouched.
+	state, err := p.ConnectionState("known")
+	require.NoError(t, err)
+	assert.Equal(t, peers.PeerConnected, state)
+	badResponses, err := p.Scorers().BadResponsesScorer().Count("known")
+	require.NoError(t, err)
+	assert.Equal(t, 0, badResponses)
+
+	// Restored records are persisted again.
+	for _, r := range p.Records() {
+		if r.PeerID == "banned" {
+			assert.Equal(t, true, r.BanExpiry.Equal(records[1].BanExpiry))
+		}
+	}
+}
+
+func TestStatus_Restore_Capacity(t *testing.T) {
+	addr, err := ma.NewMultiaddr("/ip4/213.202.254.180/tcp/13000")
+	require.NoError(t, err)
+	now := time.Now()
+	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit:    0,
+		ScorerParams: &scorers.Config{},
+	})
+	limit := p.MaxPeerLimit()
+	records := make([]*peerdata.Record, 0, limit+2)
+	for i := 0; i < limit+1; i++ {
+		records = append(records, &peerdata.Record{PeerID: peer.ID(rune('a' + i)), Address: addr, LastSeen: now.Add(-time.Duration(i) * time.Minute)})
+	}
+	records = append(records, &peerdata.Record{PeerID: "banned", Address: addr, LastSeen: now.Add(-time.Hour), BanExpiry: now.Add(time.Hour)})
+	p.Restore(records)
+
+	require.Equal(t, limit, len(p.All()))
+	// The banned peer is kept, the least recently seen peers are not.
+	assert.Equal(t, true, p.IsBad("banned"))
+	_, err = p.Address(records[limit].PeerID)
+	assert.ErrorContains(t, peerdata.ErrPeerUnknown.Error(), err)
+	_, err = p.Address(records[limit-1].PeerID)
+	assert.ErrorContains(t, peerdata.ErrPeerUnknown.Error(), err)
+}
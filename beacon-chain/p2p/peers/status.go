@@ -75,6 +75,9 @@ const (
 	MinBackOffDuration = 100
 	// MaxBackOffDuration maximum amount (in milliseconds) to wait before peer is re-dialed.
 	MaxBackOffDuration = 5000
+
+	// BanDuration is how long a peer found bad stays banned in its persisted record, even after a restart.
+	BanDuration = 6 * time.Hour
 )
 
 type InternetProtocol string
@@ -306,6 +309,9 @@ func (p *Status) SetConnectionState(pid peer.ID, state peerdata.PeerConnectionSt
 	defer p.store.Unlock()
 
 	peerData := p.store.PeerDataGetOrCreate(pid)
+	if state == PeerConnected || peerData.ConnState == PeerConnected {
+		peerData.LastSeen = prysmTime.Now()
+	}
 	peerData.ConnState = state
 }
 
@@ -347,7 +353,49 @@ func (p *Status) isBad(pid peer.ID) bool {
 	if p.store.IsTrustedPeer(pid) {
 		return false
 	}
-	return p.isfromBadIP(pid) || p.scorers.IsBadPeerNoLock(pid)
+	return p.isBanned(pid) || p.isfromBadIP(pid) || p.scorers.IsBadPeerNoLock(pid)
+}
+
+// Ban marks the peer as bad until the given time, regardless of its scores.
+func (p *Status) Ban(pid peer.ID, until time.Time) {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	p.store.PeerDataGetOrCreate(pid).BanExpiry = until
+}
+
+// Unban lifts the ban of the peer. A peer deemed bad by its scores stays bad until they recover.
+func (p *Status) Unban(pid peer.ID) {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	if peerData, ok := p.store.PeerData(pid); ok {
+		peerData.BanExpiry = time.Time{}
+	}
+}
+
+// Banned returns the peers with a ban which has not expired yet, along with the expiry of their ban.
+func (p *Status) Banned() map[peer.ID]time.Time {
+	p.store.RLock()
+	defer p.store.RUnlock()
+
+	now := prysmTime.Now()
+	banned := make(map[peer.ID]time.Time)
+	for pid, peerData := range p.store.Peers() {
+		if peerData.BanExpiry.After(now) {
+			banned[pid] = peerData.BanExpiry
+		}
+	}
+	return banned
+}
+
+// isBanned returns true if the peer has a ban which has not expired yet.
+func (p *Status) isBanned(pid peer.ID) bool {
+	peerData, ok := p.store.PeerData(pid)
+	if !ok {
+		return false
+	}
+	return peerData.BanExpiry.After(prysmTime.Now())
 }
 
 // NextValidTime gets the earliest possible time it is to contact/dial
@@ -1324,3 +1324,26 @@ func createPeer(t *testing.T, p *peers.Status, addr ma.Multiaddr,
 	p.SetConnectionState(id, state)
 	return id
 }
+
+func TestStatus_BanAndUnban(t *testing.T) {
+	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit:    30,
+		ScorerParams: &scorers.Config{},
+	})
+	pid := addPeer(t, p, peers.PeerConnected)
+	expired := addPeer(t, p, peers.PeerConnected)
+	assert.Equal(t, false, p.IsBad(pid))
+
+	until := time.Now().Add(time.Hour)
+	p.Ban(pid, until)
+	p.Ban(expired, time.Now().Add(-time.Second))
+	assert.Equal(t, true, p.IsBad(pid))
+	assert.Equal(t, false, p.IsBad(expired))
+	banned := p.Banned()
+	require.Equal(t, 1, len(banned))
+	assert.Equal(t, true, banned[pid].Equal(until))
+
+	p.Unban(pid)
+	assert.Equal(t, false, p.IsBad(pid))
+	assert.Equal(t, 0, len(p.Banned()))
+}
@@ -1,6 +1,9 @@
 package p2p
 
 import (
+	"sort"
+	"sync"
+
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -20,9 +23,12 @@ const (
 )
 
 // This tracer is used to implement metrics collection for messages received
-// and broadcasted through gossipsub.
+// and broadcasted through gossipsub, to track the mesh of each topic and to feed
+// the gossip diagnostics.
 type gossipTracer struct {
 	host host.Host
+	mesh *meshTracker
+	diag *gossipDiagnostics
 }
 
 // AddPeer .
@@ -32,7 +38,7 @@ func (g gossipTracer) AddPeer(p peer.ID, proto protocol.ID) {
 
 // RemovePeer .
 func (g gossipTracer) RemovePeer(p peer.ID) {
-	// no-op
+	g.mesh.removePeer(p)
 }
 
 // Join .
@@ -43,36 +49,43 @@ func (g gossipTracer) Join(topic string) {
 // Leave .
 func (g gossipTracer) Leave(topic string) {
 	pubsubTopicsActive.WithLabelValues(topic).Set(0)
+	g.mesh.leave(topic)
 }
 
 // Graft .
 func (g gossipTracer) Graft(p peer.ID, topic string) {
 	pubsubTopicsGraft.WithLabelValues(topic).Inc()
+	g.mesh.graft(p, topic)
 }
 
 // Prune .
 func (g gossipTracer) Prune(p peer.ID, topic string) {
 	pubsubTopicsPrune.WithLabelValues(topic).Inc()
+	g.mesh.prune(p, topic)
 }
 
 // ValidateMessage .
 func (g gossipTracer) ValidateMessage(msg *pubsub.Message) {
 	pubsubMessageValidate.WithLabelValues(*msg.Topic).Inc()
+	g.diag.validate(msg)
 }
 
 // DeliverMessage .
 func (g gossipTracer) DeliverMessage(msg *pubsub.Message) {
 	pubsubMessageDeliver.WithLabelValues(*msg.Topic).Inc()
+	g.diag.deliver(msg)
 }
 
 // RejectMessage .
 func (g gossipTracer) RejectMessage(msg *pubsub.Message, reason string) {
 	pubsubMessageReject.WithLabelValues(*msg.Topic, reason).Inc()
+	g.diag.reject(msg)
 }
 
 // DuplicateMessage .
 func (g gossipTracer) DuplicateMessage(msg *pubsub.Message) {
 	pubsubMessageDuplicate.WithLabelValues(*msg.Topic).Inc()
+	g.diag.duplicate(msg)
 }
 
 // UndeliverableMessage .
@@ -102,6 +115,7 @@ func (g gossipTracer) DropRPC(rpc *pubsub.RPC, p peer.ID) {
 }
 
 func (g gossipTracer) setMetricFromRPC(act action, subCtr prometheus.Counter, pubCtr, ctrlCtr *prometheus.CounterVec, rpc *pubsub.RPC) {
+	g.diag.rpc(act, rpc)
 	subCtr.Add(float64(len(rpc.Subscriptions)))
 	if rpc.Control != nil {
 		ctrlCtr.WithLabelValues("graft").Add(float64(len(rpc.Control.Graft)))
@@ -118,3 +132,82 @@ func (g gossipTracer) setMetricFromRPC(act action, subCtr prometheus.Counter, pu
 		pubCtr.WithLabelValues(*msg.Topic).Inc()
 	}
 }
+
+// meshTracker keeps track of the peers in the gossipsub mesh of each topic, from the graft and prune events of the
+// router. A nil tracker ignores the events.
+type meshTracker struct {
+	sync.RWMutex
+	topics map[string]map[peer.ID]bool
+}
+
+func newMeshTracker() *meshTracker {
+	return &meshTracker{topics: make(map[string]map[peer.ID]bool)}
+}
+
+func (m *meshTracker) graft(p peer.ID, topic string) {
+	if m == nil {
+		return
+	}
+	m.Lock()
+	defer m.Unlock()
+	if m.topics[topic] == nil {
+		m.topics[topic] = make(map[peer.ID]bool)
+	}
+	m.topics[topic][p] = true
+}
+
+func (m *meshTracker) prune(p peer.ID, topic string) {
+	if m == nil {
+		return
+	}
+	m.Lock()
+	defer m.Unlock()
+	delete(m.topics[topic], p)
+	if len(m.topics[topic]) == 0 {
+		delete(m.topics, topic)
+	}
+}
+
+func (m *meshTracker) removePeer(p peer.ID) {
+	if m == nil {
+		return
+	}
+	m.Lock()
+	defer m.Unlock()
+	for topic, peers := range m.topics {
+		delete(peers, p)
+		if len(peers) == 0 {
+			delete(m.topics, topic)
+		}
+	}
+}
+
+func (m *meshTracker) leave(topic string) {
+	if m == nil {
+		return
+	}
+	m.Lock()
+	defer m.Unlock()
+	delete(m.topics, topic)
+}
+
+// meshes returns the peers in the mesh of each topic, sorted.
+func (m *meshTracker) meshes() map[string][]peer.ID {
+	meshes := make(map[string][]peer.ID)
+	if m == nil {
+		return meshes
+	}
+	m.RLock()
+	defer m.RUnlock()
+	for topic, peers := range m.topics {
+		pids := make([]peer.ID, 0, len(peers))
+		for p := range peers {
+			pids = append(pids, p)
+		}
+		sort.Slice(pids, func(i, j int) bool {
+			return pids[i] < pids[j]
+		})
+		meshes[topic] = pids
+	}
+	return meshes
+}
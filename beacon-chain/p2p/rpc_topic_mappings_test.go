@@ -122,3 +122,26 @@ func TestTopicFromMessage_CorrectType(t *testing.T) {
 		}
 	}
 }
+
+func TestTopicFromMessage_PeerDAS(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	bCfg := params.BeaconConfig().Copy()
+	bCfg.AltairForkEpoch = 0
+	bCfg.Eip7594ForkEpoch = 10
+	params.OverrideBeaconConfig(bCfg)
+
+	topic, err := TopicFromMessage(MetadataMessageName, 9)
+	require.NoError(t, err)
+	assert.Equal(t, RPCMetaDataTopicV2, topic)
+	topic, err = TopicFromMessage(MetadataMessageName, 10)
+	require.NoError(t, err)
+	assert.Equal(t, RPCMetaDataTopicV3, topic)
+
+	topic, err = TopicFromMessage(DataColumnSidecarsByRootName, 10)
+	require.NoError(t, err)
+	assert.Equal(t, RPCDataColumnSidecarsByRootTopicV1, topic)
+	_, message, version, err := TopicDeconstructor(RPCDataColumnSidecarsByRangeTopicV1)
+	require.NoError(t, err)
+	assert.Equal(t, DataColumnSidecarsByRangeName, message)
+	assert.Equal(t, SchemaVersionV1, version)
+}
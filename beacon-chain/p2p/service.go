@@ -78,6 +78,11 @@ type Service struct {
 	genesisTime           time.Time
 	genesisValidatorsRoot []byte
 	activeValidatorCount  uint64
+	mesh                  *meshTracker
+	gossipDiag            *gossipDiagnostics
+	adminLock             sync.Mutex
+	subnetBans            map[string]*subnetBan
+	staticPeerExpiries    map[peer.ID]time.Time
 }
 
 // NewService initializes a new p2p service compatible with shared.Service interface. No
@@ -107,16 +112,20 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 	ipLimiter := leakybucket.NewCollector(ipLimit, ipBurst, 30*time.Second, true /* deleteEmptyBuckets */)
 
 	s := &Service{
-		ctx:          ctx,
-		cancel:       cancel,
-		cfg:          cfg,
-		addrFilter:   addrFilter,
-		ipLimiter:    ipLimiter,
-		privKey:      privKey,
-		metaData:     metaData,
-		isPreGenesis: true,
-		joinedTopics: make(map[string]*pubsub.Topic, len(gossipTopicMappings)),
-		subnetsLock:  make(map[uint64]*sync.RWMutex),
+		ctx:                ctx,
+		cancel:             cancel,
+		cfg:                cfg,
+		addrFilter:         addrFilter,
+		ipLimiter:          ipLimiter,
+		privKey:            privKey,
+		metaData:           metaData,
+		isPreGenesis:       true,
+		joinedTopics:       make(map[string]*pubsub.Topic, len(gossipTopicMappings)),
+		subnetsLock:        make(map[uint64]*sync.RWMutex),
+		mesh:               newMeshTracker(),
+		gossipDiag:         newGossipDiagnostics(),
+		subnetBans:         make(map[string]*subnetBan),
+		staticPeerExpiries: make(map[peer.ID]time.Time),
 	}
 
 	ipAddr := prysmnetwork.IPAddr()
@@ -183,6 +192,9 @@ func (s *Service) Start() {
 	s.awaitStateInitialized()
 	s.isPreGenesis = false
 
+	// Restore the known peers before discovery starts, so that banned peers are not connected to.
+	knownPeers := s.restorePeerRecords()
+
 	var relayNodes []string
 	if s.cfg.RelayNodeAddr != "" {
 		relayNodes = append(relayNodes, s.cfg.RelayNodeAddr)
@@ -224,6 +236,7 @@ func (s *Service) Start() {
 		s.peers.SetTrustedPeers(pids)
 		s.connectWithAllTrustedPeers(addrs)
 	}
+	s.connectWithKnownPeers(knownPeers)
 	// Initialize metadata according to the
 	// current epoch.
 	s.RefreshENR()
@@ -233,6 +246,8 @@ func (s *Service) Start() {
 		ensurePeerConnections(s.ctx, s.host, s.peers, relayNodes...)
 	})
 	async.RunEvery(s.ctx, 30*time.Minute, s.Peers().Prune)
+	async.RunEvery(s.ctx, peerRecordsSaveInterval, s.savePeerRecords)
+	async.RunEvery(s.ctx, adminExpiryInterval, s.expireAdminState)
 	async.RunEvery(s.ctx, time.Duration(params.BeaconConfig().RespTimeout)*time.Second, s.updateMetrics)
 	async.RunEvery(s.ctx, refreshRate, s.RefreshENR)
 	async.RunEvery(s.ctx, 1*time.Minute, func() {
@@ -278,6 +293,9 @@ func (s *Service) Start() {
 // Stop the p2p service and terminate all peer connections.
 func (s *Service) Stop() error {
 	defer s.cancel()
+	if s.started {
+		s.savePeerRecords()
+	}
 	s.started = false
 	if s.dv5Listener != nil {
 		s.dv5Listener.Close()
@@ -44,6 +44,11 @@ const syncLockerVal = 100
 // chosen more than sync and attestation subnet combined.
 const blobSubnetLockerVal = 110
 
+// The value used with the data column sidecar subnet, in order
+// to create an appropriate key to retrieve the relevant lock.
+// This is deliberately chosen above the range used by blob subnets.
+const dataColumnSubnetLockerVal = 130
+
 // FindPeersWithSubnet performs a network search for peers
 // subscribed to a particular subnet. Then it tries to connect
 // with those peers. This method will block until either:
@@ -72,6 +77,8 @@ func (s *Service) FindPeersWithSubnet(ctx context.Context, topic string,
 		iterator = filterNodes(ctx, iterator, s.filterPeerForAttSubnet(index))
 	case strings.Contains(topic, GossipSyncCommitteeMessage):
 		iterator = filterNodes(ctx, iterator, s.filterPeerForSyncSubnet(index))
+	case strings.Contains(topic, GossipDataColumnSidecarMessage):
+		iterator = filterNodes(ctx, iterator, s.filterPeerForDataColumnsSubnet(index))
 	default:
 		return false, errors.New("no subnet exists for provided topic")
 	}
@@ -192,6 +199,22 @@ func (s *Service) updateSubnetRecordWithMetadataV2(bitVAtt bitfield.Bitvector64,
 	})
 }
 
+// Updates the service's discv5 listener record like updateSubnetRecordWithMetadataV2, and upgrades
+// the node's metadata to carry the data column custody subnet count once PeerDAS is active.
+func (s *Service) updateSubnetRecordWithMetadataV3(bitVAtt bitfield.Bitvector64, bitVSync bitfield.Bitvector4, custodySubnetCount uint64) {
+	entry := enr.WithEntry(attSubnetEnrKey, &bitVAtt)
+	subEntry := enr.WithEntry(syncCommsSubnetEnrKey, &bitVSync)
+	s.dv5Listener.LocalNode().Set(entry)
+	s.dv5Listener.LocalNode().Set(subEntry)
+	s.dv5Listener.LocalNode().Set(CustodySubnetCount(custodySubnetCount))
+	s.metaData = wrapper.WrappedMetadataV2(&pb.MetaDataV2{
+		SeqNumber:          s.metaData.SequenceNumber() + 1,
+		Attnets:            bitVAtt,
+		Syncnets:           bitVSync,
+		CustodySubnetCount: custodySubnetCount,
+	})
+}
+
 func initializePersistentSubnets(id enode.ID, epoch primitives.Epoch) error {
 	_, ok, expTime := cache.SubnetIDs.GetPersistentSubnets()
 	if ok && expTime.After(time.Now()) {
@@ -148,6 +148,21 @@ func (_ *FakeP2P) BroadcastBlob(_ context.Context, _ uint64, _ *ethpb.BlobSideca
 	return nil
 }
 
+// BroadcastDataColumn -- fake.
+func (_ *FakeP2P) BroadcastDataColumn(_ context.Context, _ uint64, _ *ethpb.DataColumnSidecar) error {
+	return nil
+}
+
+// CustodyCountFromRemotePeer -- fake.
+func (_ *FakeP2P) CustodyCountFromRemotePeer(_ peer.ID) uint64 {
+	return 0
+}
+
+// CustodyColumnsFromRemotePeer -- fake.
+func (_ *FakeP2P) CustodyColumnsFromRemotePeer(_ peer.ID) (map[uint64]bool, error) {
+	return map[uint64]bool{}, nil
+}
+
 // InterceptPeerDial -- fake.
 func (_ *FakeP2P) InterceptPeerDial(peer.ID) (allow bool) {
 	return true
@@ -22,9 +22,11 @@ import (
 	"github.com/libp2p/go-libp2p/p2p/transport/tcp"
 	"github.com/multiformats/go-multiaddr"
 	ssz "github.com/prysmaticlabs/fastssz"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/peerdas"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/encoder"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/scorers"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/metadata"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
@@ -36,6 +38,7 @@ import (
 // with the main p2p package.
 const metatadataV1Topic = "/eth2/beacon_chain/req/metadata/1"
 const metatadataV2Topic = "/eth2/beacon_chain/req/metadata/2"
+const metatadataV3Topic = "/eth2/beacon_chain/req/metadata/3"
 
 // TestP2P represents a p2p implementation that can be used for testing.
 type TestP2P struct {
@@ -190,6 +193,26 @@ func (p *TestP2P) BroadcastBlob(context.Context, uint64, *ethpb.BlobSidecar) err
 	return nil
 }
 
+// BroadcastDataColumn broadcasts a data column sidecar for mock.
+func (p *TestP2P) BroadcastDataColumn(context.Context, uint64, *ethpb.DataColumnSidecar) error {
+	p.BroadcastCalled.Store(true)
+	return nil
+}
+
+// CustodyCountFromRemotePeer -- mock. Every peer custodies the minimum number of subnets.
+func (*TestP2P) CustodyCountFromRemotePeer(peer.ID) uint64 {
+	return params.BeaconConfig().CustodyRequirement
+}
+
+// CustodyColumnsFromRemotePeer -- mock. Every peer custodies the minimum number of subnets.
+func (p *TestP2P) CustodyColumnsFromRemotePeer(pid peer.ID) (map[uint64]bool, error) {
+	nodeID, err := peerdas.ConvertPeerIDToNodeID(pid)
+	if err != nil {
+		return nil, err
+	}
+	return peerdas.CustodyColumns(nodeID, p.CustodyCountFromRemotePeer(pid))
+}
+
 // SetStreamHandler for RPC.
 func (p *TestP2P) SetStreamHandler(topic string, handler network.StreamHandler) {
 	p.BHost.SetStreamHandler(protocol.ID(topic), handler)
@@ -326,7 +349,7 @@ func (p *TestP2P) Send(ctx context.Context, msg interface{}, topic string, pid p
 		return nil, err
 	}
 
-	if topic != metatadataV1Topic && topic != metatadataV2Topic {
+	if topic != metatadataV1Topic && topic != metatadataV2Topic && topic != metatadataV3Topic {
 		castedMsg, ok := msg.(ssz.Marshaler)
 		if !ok {
 			p.t.Fatalf("%T doesn't support ssz marshaler", msg)
@@ -80,6 +80,30 @@ func TestBlobSidecarsByRootReq_MarshalSSZ(t *testing.T) {
 	}
 }
 
+func TestDataColumnSidecarsByRootReq_MarshalSSZ(t *testing.T) {
+	ids := make([]*eth.DataColumnIdentifier, 10)
+	for i := range ids {
+		ids[i] = &eth.DataColumnIdentifier{
+			BlockRoot:   bytesutil.PadTo([]byte{byte(i)}, 32),
+			ColumnIndex: uint64(i),
+		}
+	}
+	r := DataColumnSidecarsByRootReq(ids)
+	by, err := r.MarshalSSZ()
+	require.NoError(t, err)
+	require.Equal(t, r.SizeSSZ(), len(by))
+
+	got := &DataColumnSidecarsByRootReq{}
+	require.NoError(t, got.UnmarshalSSZ(by))
+	for i, gid := range *got {
+		require.DeepEqual(t, ids[i], gid)
+	}
+
+	require.ErrorIs(t, got.UnmarshalSSZ(append(by, 0)), ssz.ErrIncorrectByteSize)
+	tooMany := make([]byte, (params.BeaconConfig().MaxRequestDataColumnSidecars+1)*uint64(dataColumnIdSize))
+	require.NotNil(t, got.UnmarshalSSZ(tooMany))
+}
+
 func TestBeaconBlockByRootsReq_Limit(t *testing.T) {
 	fixedRoots := make([][32]byte, 0)
 	for i := uint64(0); i < params.BeaconConfig().MaxRequestBlocks+100; i++ {
@@ -13,6 +13,7 @@ const (
 	Unavailable
 	BadRequest
 	NotFound
+	Pruned
 	// Add more errors as needed
 )
 
@@ -31,6 +32,8 @@ func ErrorReasonToGRPC(reason ErrorReason) codes.Code {
 		return codes.InvalidArgument
 	case NotFound:
 		return codes.NotFound
+	case Pruned:
+		return codes.OutOfRange
 	// Add more cases for other error reasons as needed
 	default:
 		return codes.Internal
@@ -47,6 +50,8 @@ func ErrorReasonToHTTP(reason ErrorReason) int {
 		return http.StatusBadRequest
 	case NotFound:
 		return http.StatusNotFound
+	case Pruned:
+		return http.StatusGone
 	// Add more cases for other error reasons as needed
 	default:
 		return http.StatusInternalServerError
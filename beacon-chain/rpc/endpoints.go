@@ -7,6 +7,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/prysmaticlabs/prysm/v5/api"
 	"github.com/prysmaticlabs/prysm/v5/api/server/middleware"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/core"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/beacon"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/blob"
@@ -18,6 +19,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/node"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/rewards"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/validator"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/graphql"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/lookup"
 	beaconprysm "github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/prysm/beacon"
 	nodeprysm "github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/prysm/node"
@@ -73,6 +75,9 @@ func (s *Service) endpoints(
 	if enableDebug {
 		endpoints = append(endpoints, s.debugEndpoints(stater)...)
 	}
+	if s.cfg.EnableGraphQL {
+		endpoints = append(endpoints, s.graphqlEndpoints(blocker, stater)...)
+	}
 	return endpoints
 }
 
@@ -85,6 +90,7 @@ func (s *Service) rewardsEndpoints(blocker lookup.Blocker, stater lookup.Stater,
 		Stater:                stater,
 		HeadFetcher:           s.cfg.HeadFetcher,
 		BlockRewardFetcher:    rewardFetcher,
+		BeaconDB:              s.cfg.BeaconDB,
 	}
 
 	const namespace = "rewards"
@@ -282,7 +288,7 @@ func (s *Service) validatorEndpoints(
 			name:     namespace + ".GetAttesterDuties",
 			middleware: []middleware.Middleware{
 				middleware.ContentTypeHandler([]string{api.JsonMediaType}),
-				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType, api.OctetStreamMediaType}),
 			},
 			handler: server.GetAttesterDuties,
 			methods: []string{http.MethodPost},
@@ -291,7 +297,7 @@ func (s *Service) validatorEndpoints(
 			template: "/eth/v1/validator/duties/proposer/{epoch}",
 			name:     namespace + ".GetProposerDuties",
 			middleware: []middleware.Middleware{
-				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType, api.OctetStreamMediaType}),
 			},
 			handler: server.GetProposerDuties,
 			methods: []string{http.MethodGet},
@@ -301,7 +307,7 @@ func (s *Service) validatorEndpoints(
 			name:     namespace + ".GetSyncCommitteeDuties",
 			middleware: []middleware.Middleware{
 				middleware.ContentTypeHandler([]string{api.JsonMediaType}),
-				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType, api.OctetStreamMediaType}),
 			},
 			handler: server.GetSyncCommitteeDuties,
 			methods: []string{http.MethodPost},
@@ -321,7 +327,7 @@ func (s *Service) validatorEndpoints(
 			name:     namespace + ".GetLiveness",
 			middleware: []middleware.Middleware{
 				middleware.ContentTypeHandler([]string{api.JsonMediaType}),
-				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType, api.OctetStreamMediaType}),
 			},
 			handler: server.GetLiveness,
 			methods: []string{http.MethodPost},
@@ -496,7 +502,7 @@ func (s *Service) beaconEndpoints(
 			template: "/eth/v1/beacon/states/{state_id}/committees",
 			name:     namespace + ".GetCommittees",
 			middleware: []middleware.Middleware{
-				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType, api.OctetStreamMediaType}),
 			},
 			handler: server.GetCommittees,
 			methods: []string{http.MethodGet},
@@ -523,7 +529,7 @@ func (s *Service) beaconEndpoints(
 			template: "/eth/v1/beacon/states/{state_id}/sync_committees",
 			name:     namespace + ".GetSyncCommittees",
 			middleware: []middleware.Middleware{
-				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType, api.OctetStreamMediaType}),
 			},
 			handler: server.GetSyncCommittees,
 			methods: []string{http.MethodGet},
@@ -537,6 +543,33 @@ func (s *Service) beaconEndpoints(
 			handler: server.GetRandao,
 			methods: []string{http.MethodGet},
 		},
+		{
+			template: "/eth/v1/beacon/states/{state_id}/pending_deposits",
+			name:     namespace + ".GetPendingDeposits",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType, api.OctetStreamMediaType}),
+			},
+			handler: server.GetPendingDeposits,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/eth/v1/beacon/states/{state_id}/pending_partial_withdrawals",
+			name:     namespace + ".GetPendingPartialWithdrawals",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType, api.OctetStreamMediaType}),
+			},
+			handler: server.GetPendingPartialWithdrawals,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/eth/v1/beacon/states/{state_id}/pending_consolidations",
+			name:     namespace + ".GetPendingConsolidations",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType, api.OctetStreamMediaType}),
+			},
+			handler: server.GetPendingConsolidations,
+			methods: []string{http.MethodGet},
+		},
 		{
 			template: "/eth/v1/beacon/blocks",
 			name:     namespace + ".PublishBlock",
@@ -626,7 +659,7 @@ func (s *Service) beaconEndpoints(
 			template: "/eth/v1/beacon/pool/attestations",
 			name:     namespace + ".ListAttestations",
 			middleware: []middleware.Middleware{
-				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType, api.OctetStreamMediaType}),
 			},
 			handler: server.ListAttestations,
 			methods: []string{http.MethodGet},
@@ -635,7 +668,7 @@ func (s *Service) beaconEndpoints(
 			template: "/eth/v2/beacon/pool/attestations",
 			name:     namespace + ".ListAttestationsV2",
 			middleware: []middleware.Middleware{
-				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType, api.OctetStreamMediaType}),
 			},
 			handler: server.ListAttestationsV2,
 			methods: []string{http.MethodGet},
@@ -702,7 +735,7 @@ func (s *Service) beaconEndpoints(
 			template: "/eth/v1/beacon/pool/attester_slashings",
 			name:     namespace + ".GetAttesterSlashings",
 			middleware: []middleware.Middleware{
-				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType, api.OctetStreamMediaType}),
 			},
 			handler: server.GetAttesterSlashings,
 			methods: []string{http.MethodGet},
@@ -711,7 +744,7 @@ func (s *Service) beaconEndpoints(
 			template: "/eth/v2/beacon/pool/attester_slashings",
 			name:     namespace + ".GetAttesterSlashingsV2",
 			middleware: []middleware.Middleware{
-				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType, api.OctetStreamMediaType}),
 			},
 			handler: server.GetAttesterSlashingsV2,
 			methods: []string{http.MethodGet},
@@ -759,7 +792,7 @@ func (s *Service) beaconEndpoints(
 			template: "/eth/v1/beacon/headers",
 			name:     namespace + ".GetBlockHeaders",
 			middleware: []middleware.Middleware{
-				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType, api.OctetStreamMediaType}),
 			},
 			handler: server.GetBlockHeaders,
 			methods: []string{http.MethodGet},
@@ -796,7 +829,7 @@ func (s *Service) beaconEndpoints(
 			name:     namespace + ".GetValidators",
 			middleware: []middleware.Middleware{
 				middleware.ContentTypeHandler([]string{api.JsonMediaType}),
-				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType, api.OctetStreamMediaType}),
 			},
 			handler: server.GetValidators,
 			methods: []string{http.MethodGet, http.MethodPost},
@@ -815,7 +848,7 @@ func (s *Service) beaconEndpoints(
 			name:     namespace + ".GetValidatorBalances",
 			middleware: []middleware.Middleware{
 				middleware.ContentTypeHandler([]string{api.JsonMediaType}),
-				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType, api.OctetStreamMediaType}),
 			},
 			handler: server.GetValidatorBalances,
 			methods: []string{http.MethodGet, http.MethodPost},
@@ -965,6 +998,7 @@ func (s *Service) eventsEndpoints() []endpoint {
 		HeadFetcher:            s.cfg.HeadFetcher,
 		ChainInfoFetcher:       s.cfg.ChainInfoFetcher,
 		TrackedValidatorsCache: s.cfg.TrackedValidatorsCache,
+		History:                s.eventHistory,
 	}
 
 	const namespace = "events"
@@ -1000,6 +1034,7 @@ func (s *Service) prysmBeaconEndpoints(
 		CoreService:           coreService,
 		Broadcaster:           s.cfg.Broadcaster,
 		BlobReceiver:          s.cfg.BlobReceiver,
+		ValidatorIndexCache:   s.cfg.ValidatorIndexCache,
 	}
 
 	const namespace = "prysm.beacon"
@@ -1060,10 +1095,22 @@ func (s *Service) prysmBeaconEndpoints(
 			handler: server.PublishBlobs,
 			methods: []string{http.MethodPost},
 		},
+		{
+			template: "/prysm/v1/beacon/validators/query",
+			name:     namespace + ".QueryValidators",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.QueryValidators,
+			methods: []string{http.MethodGet},
+		},
 	}
 }
 
 func (s *Service) prysmNodeEndpoints() []endpoint {
+	usageFetcher, _ := s.cfg.BeaconDB.(nodeprysm.StorageUsageFetcher)
+	peerAdmin, _ := s.cfg.PeerManager.(p2p.PeerAdmin)
+	gossipDiagnostics, _ := s.cfg.PeerManager.(p2p.GossipDiagnosticsProvider)
 	server := &nodeprysm.Server{
 		BeaconDB:                  s.cfg.BeaconDB,
 		SyncChecker:               s.cfg.SyncService,
@@ -1071,9 +1118,13 @@ func (s *Service) prysmNodeEndpoints() []endpoint {
 		GenesisTimeFetcher:        s.cfg.GenesisTimeFetcher,
 		PeersFetcher:              s.cfg.PeersFetcher,
 		PeerManager:               s.cfg.PeerManager,
+		PeerAdmin:                 peerAdmin,
+		GossipDiagnosticsProvider: gossipDiagnostics,
 		MetadataProvider:          s.cfg.MetadataProvider,
 		HeadFetcher:               s.cfg.HeadFetcher,
 		ExecutionChainInfoFetcher: s.cfg.ExecutionChainInfoFetcher,
+		StorageUsageFetcher:       usageFetcher,
+		BlobStorage:               s.cfg.BlobStorage,
 	}
 
 	const namespace = "prysm.node"
@@ -1134,11 +1185,87 @@ func (s *Service) prysmNodeEndpoints() []endpoint {
 			handler: server.RemoveTrustedPeer,
 			methods: []string{http.MethodDelete},
 		},
+		{
+			template: "/prysm/v1/node/storage",
+			name:     namespace + ".GetStorageUsage",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetStorageUsage,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/prysm/v1/node/gossip",
+			name:     namespace + ".GetGossipDiagnostics",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetGossipDiagnostics,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/prysm/v1/admin/peers",
+			name:     namespace + ".ListPeerScores",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.ListPeerScores,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/prysm/v1/admin/peers/bans",
+			name:     namespace + ".ListPeerBans",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.ListPeerBans,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/prysm/v1/admin/peers/bans",
+			name:     namespace + ".BanPeer",
+			middleware: []middleware.Middleware{
+				middleware.ContentTypeHandler([]string{api.JsonMediaType}),
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.BanPeer,
+			methods: []string{http.MethodPost},
+		},
+		{
+			template: "/prysm/v1/admin/peers/bans",
+			name:     namespace + ".UnbanPeer",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.UnbanPeer,
+			methods: []string{http.MethodDelete},
+		},
+		{
+			template: "/prysm/v1/admin/peers/disconnect",
+			name:     namespace + ".DisconnectPeer",
+			middleware: []middleware.Middleware{
+				middleware.ContentTypeHandler([]string{api.JsonMediaType}),
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.DisconnectPeer,
+			methods: []string{http.MethodPost},
+		},
+		{
+			template: "/prysm/v1/admin/peers/static",
+			name:     namespace + ".AddStaticPeer",
+			middleware: []middleware.Middleware{
+				middleware.ContentTypeHandler([]string{api.JsonMediaType}),
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.AddStaticPeer,
+			methods: []string{http.MethodPost},
+		},
 	}
 }
 
 func (s *Service) prysmValidatorEndpoints(stater lookup.Stater, coreService *core.Service) []endpoint {
 	server := &validatorprysm.Server{
+		BeaconDB:         s.cfg.BeaconDB,
 		ChainInfoFetcher: s.cfg.ChainInfoFetcher,
 		Stater:           stater,
 		CoreService:      coreService,
@@ -1184,5 +1311,42 @@ func (s *Service) prysmValidatorEndpoints(stater lookup.Stater, coreService *cor
 			handler: server.GetActiveSetChanges,
 			methods: []string{http.MethodGet},
 		},
+		{
+			template: "/prysm/v1/validators/{validator_id}/history",
+			name:     namespace + ".GetValidatorHistory",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetValidatorHistory,
+			methods: []string{http.MethodGet},
+		},
+	}
+}
+
+func (s *Service) graphqlEndpoints(blocker lookup.Blocker, stater lookup.Stater) []endpoint {
+	server := graphql.NewServer(&graphql.Config{
+		Blocker:          blocker,
+		Stater:           stater,
+		HeadFetcher:      s.cfg.HeadFetcher,
+		AttestationsPool: s.cfg.AttestationsPool,
+		ExitPool:         s.cfg.ExitPool,
+		SlashingsPool:    s.cfg.SlashingsPool,
+		BLSChangesPool:   s.cfg.BLSChangesPool,
+		MaxDepth:         s.cfg.GraphQLMaxDepth,
+		MaxCost:          s.cfg.GraphQLMaxCost,
+	})
+
+	const namespace = "graphql"
+	return []endpoint{
+		{
+			template: "/prysm/v1/graphql",
+			name:     namespace + ".Query",
+			middleware: []middleware.Middleware{
+				middleware.ContentTypeHandler([]string{api.JsonMediaType}),
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.Query,
+			methods: []string{http.MethodPost},
+		},
 	}
 }
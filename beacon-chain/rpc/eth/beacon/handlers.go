@@ -1292,7 +1292,24 @@ func (s *Server) GetCommittees(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	committeesPerSlot := corehelpers.SlotCommitteeCount(activeCount)
+
+	isOptimistic, err := helpers.IsOptimistic(ctx, []byte(stateId), s.OptimisticModeFetcher, s.Stater, s.ChainInfoFetcher, s.BeaconDB)
+	if err != nil {
+		httputil.HandleError(w, "Could not check optimistic status: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	blockRoot, err := st.LatestBlockHeader().HashTreeRoot()
+	if err != nil {
+		httputil.HandleError(w, "Could not calculate root of latest block header: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	isFinalized := s.FinalizationFetcher.IsFinalized(ctx, blockRoot)
+
+	respondWithSsz := httputil.RespondWithSsz(r)
 	committees := make([]*structs.Committee, 0)
+	// The JSON committees are streamed rather than collected, as together they hold every active validator.
+	lw := httputil.NewJsonListWriter(w, "data", &stateListMetadata{ExecutionOptimistic: isOptimistic, Finalized: isFinalized})
 	for slot := startSlot; slot <= endSlot; slot++ {
 		if rawSlot != "" && slot != primitives.Slot(sl) {
 			continue
@@ -1303,35 +1320,32 @@ func (s *Server) GetCommittees(w http.ResponseWriter, r *http.Request) {
 			}
 			committee, err := corehelpers.BeaconCommitteeFromState(ctx, st, slot, index)
 			if err != nil {
-				httputil.HandleError(w, "Could not get committee: "+err.Error(), http.StatusInternalServerError)
+				lw.HandleError("Could not get committee: "+err.Error(), http.StatusInternalServerError)
 				return
 			}
-			var validators []string
-			for _, v := range committee {
-				validators = append(validators, strconv.FormatUint(uint64(v), 10))
+			validators := make([]string, len(committee))
+			for j, v := range committee {
+				validators[j] = strconv.FormatUint(uint64(v), 10)
 			}
 			committeeContainer := &structs.Committee{
 				Index:      strconv.FormatUint(uint64(index), 10),
 				Slot:       strconv.FormatUint(uint64(slot), 10),
 				Validators: validators,
 			}
-			committees = append(committees, committeeContainer)
+			if respondWithSsz {
+				committees = append(committees, committeeContainer)
+			} else if !lw.Write(committeeContainer) {
+				return
+			}
 		}
 	}
 
-	isOptimistic, err := helpers.IsOptimistic(ctx, []byte(stateId), s.OptimisticModeFetcher, s.Stater, s.ChainInfoFetcher, s.BeaconDB)
-	if err != nil {
-		httputil.HandleError(w, "Could not check optimistic status: "+err.Error(), http.StatusInternalServerError)
+	if respondWithSsz {
+		md := shared.ListMetadata{Version: st.Version(), ExecutionOptimistic: isOptimistic, Finalized: &isFinalized}
+		shared.WriteSszList(w, committees, (*structs.Committee).ToSSZ, md, "committees.ssz")
 		return
 	}
-
-	blockRoot, err := st.LatestBlockHeader().HashTreeRoot()
-	if err != nil {
-		httputil.HandleError(w, "Could not calculate root of latest block header: "+err.Error(), http.StatusInternalServerError)
-		return
-	}
-	isFinalized := s.FinalizationFetcher.IsFinalized(ctx, blockRoot)
-	httputil.WriteJson(w, &structs.GetCommitteesResponse{Data: committees, ExecutionOptimistic: isOptimistic, Finalized: isFinalized})
+	lw.Close()
 }
 
 // GetBlockHeaders retrieves block headers matching given query. By default it will fetch current head slot blocks.
@@ -1418,6 +1432,12 @@ func (s *Server) GetBlockHeaders(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if httputil.RespondWithSsz(r) {
+		md := shared.ListMetadata{Version: blks[0].Version(), ExecutionOptimistic: isOptimistic, Finalized: &isFinalized}
+		shared.WriteSszList(w, blkHdrs, (*structs.SignedBeaconBlockHeaderContainer).ToSSZ, md, "headers.ssz")
+		return
+	}
+
 	response := &structs.GetBlockHeadersResponse{
 		Data:                blkHdrs,
 		ExecutionOptimistic: isOptimistic,
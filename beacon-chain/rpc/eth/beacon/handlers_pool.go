@@ -72,6 +72,12 @@ func (s *Server) ListAttestations(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if httputil.RespondWithSsz(r) {
+		md := shared.ListMetadata{Version: version.Phase0}
+		shared.WriteSszList(w, filteredAtts, (*structs.Attestation).ToSSZ, md, "attestations.ssz")
+		return
+	}
+
 	attsData, err := json.Marshal(filteredAtts)
 	if err != nil {
 		httputil.HandleError(w, "Could not marshal attestations: "+err.Error(), http.StatusInternalServerError)
@@ -112,7 +118,8 @@ func (s *Server) ListAttestationsV2(w http.ResponseWriter, r *http.Request) {
 	}
 	attestations = append(attestations, unaggAtts...)
 
-	filteredAtts := make([]interface{}, 0, len(attestations))
+	filteredAtts := make([]*structs.Attestation, 0)
+	filteredAttsElectra := make([]*structs.AttestationElectra, 0)
 	for _, att := range attestations {
 		var includeAttestation bool
 		if headState.Version() >= version.Electra {
@@ -125,7 +132,7 @@ func (s *Server) ListAttestationsV2(w http.ResponseWriter, r *http.Request) {
 			includeAttestation = shouldIncludeAttestation(attElectra.GetData(), rawSlot, slot, rawCommitteeIndex, committeeIndex)
 			if includeAttestation {
 				attStruct := structs.AttElectraFromConsensus(attElectra)
-				filteredAtts = append(filteredAtts, attStruct)
+				filteredAttsElectra = append(filteredAttsElectra, attStruct)
 			}
 		} else {
 			attOld, ok := att.(*eth.Attestation)
@@ -142,7 +149,22 @@ func (s *Server) ListAttestationsV2(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	attsData, err := json.Marshal(filteredAtts)
+	if httputil.RespondWithSsz(r) {
+		md := shared.ListMetadata{Version: headState.Version()}
+		if headState.Version() >= version.Electra {
+			shared.WriteSszList(w, filteredAttsElectra, (*structs.AttestationElectra).ToSSZ, md, "attestations.ssz")
+		} else {
+			shared.WriteSszList(w, filteredAtts, (*structs.Attestation).ToSSZ, md, "attestations.ssz")
+		}
+		return
+	}
+
+	var attsData []byte
+	if headState.Version() >= version.Electra {
+		attsData, err = json.Marshal(filteredAttsElectra)
+	} else {
+		attsData, err = json.Marshal(filteredAtts)
+	}
 	if err != nil {
 		httputil.HandleError(w, "Could not marshal attestations: "+err.Error(), http.StatusInternalServerError)
 		return
@@ -560,6 +582,11 @@ func (s *Server) GetAttesterSlashings(w http.ResponseWriter, r *http.Request) {
 		}
 		slashings[i] = structs.AttesterSlashingFromConsensus(as)
 	}
+	if httputil.RespondWithSsz(r) {
+		md := shared.ListMetadata{Version: version.Phase0}
+		shared.WriteSszList(w, slashings, (*structs.AttesterSlashing).ToSSZ, md, "attester_slashings.ssz")
+		return
+	}
 	attBytes, err := json.Marshal(slashings)
 	if err != nil {
 		httputil.HandleError(w, fmt.Sprintf("Failed to marshal slashings: %v", err), http.StatusInternalServerError)
@@ -580,30 +607,43 @@ func (s *Server) GetAttesterSlashingsV2(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	var attStructs []interface{}
 	sourceSlashings := s.SlashingsPool.PendingAttesterSlashings(ctx, headState, true /* return unlimited slashings */)
-
+	slashings := make([]*structs.AttesterSlashing, 0, len(sourceSlashings))
+	slashingsElectra := make([]*structs.AttesterSlashingElectra, 0, len(sourceSlashings))
 	for _, slashing := range sourceSlashings {
-		var attStruct interface{}
 		if headState.Version() >= version.Electra {
 			a, ok := slashing.(*eth.AttesterSlashingElectra)
 			if !ok {
 				httputil.HandleError(w, fmt.Sprintf("Unable to convert slashing of type %T to an Electra slashing", slashing), http.StatusInternalServerError)
 				return
 			}
-			attStruct = structs.AttesterSlashingElectraFromConsensus(a)
+			slashingsElectra = append(slashingsElectra, structs.AttesterSlashingElectraFromConsensus(a))
 		} else {
 			a, ok := slashing.(*eth.AttesterSlashing)
 			if !ok {
 				httputil.HandleError(w, fmt.Sprintf("Unable to convert slashing of type %T to a Phase0 slashing", slashing), http.StatusInternalServerError)
 				return
 			}
-			attStruct = structs.AttesterSlashingFromConsensus(a)
+			slashings = append(slashings, structs.AttesterSlashingFromConsensus(a))
+		}
+	}
+
+	if httputil.RespondWithSsz(r) {
+		md := shared.ListMetadata{Version: headState.Version()}
+		if headState.Version() >= version.Electra {
+			shared.WriteSszList(w, slashingsElectra, (*structs.AttesterSlashingElectra).ToSSZ, md, "attester_slashings.ssz")
+		} else {
+			shared.WriteSszList(w, slashings, (*structs.AttesterSlashing).ToSSZ, md, "attester_slashings.ssz")
 		}
-		attStructs = append(attStructs, attStruct)
+		return
 	}
 
-	attBytes, err := json.Marshal(attStructs)
+	var attBytes []byte
+	if headState.Version() >= version.Electra {
+		attBytes, err = json.Marshal(slashingsElectra)
+	} else {
+		attBytes, err = json.Marshal(slashings)
+	}
 	if err != nil {
 		httputil.HandleError(w, fmt.Sprintf("Failed to marshal slashing: %v", err), http.StatusInternalServerError)
 		return
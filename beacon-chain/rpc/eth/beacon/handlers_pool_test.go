@@ -138,6 +138,20 @@ func TestListAttestations(t *testing.T) {
 			require.NoError(t, json.Unmarshal(resp.Data, &atts))
 			assert.Equal(t, 4, len(atts))
 		})
+		t.Run("ssz request", func(t *testing.T) {
+			url := "http://example.com"
+			request := httptest.NewRequest(http.MethodGet, url, nil)
+			request.Header.Set("Accept", api.OctetStreamMediaType)
+			writer := httptest.NewRecorder()
+			writer.Body = &bytes.Buffer{}
+
+			s.ListAttestations(writer, request)
+			assert.Equal(t, http.StatusOK, writer.Code)
+			assert.Equal(t, "phase0", writer.Header().Get(api.VersionHeader))
+			atts, err := structs.UnmarshalSSZList(writer.Body.Bytes(), structs.AttestationFromSSZ)
+			require.NoError(t, err)
+			assert.Equal(t, 4, len(atts))
+		})
 		t.Run("slot request", func(t *testing.T) {
 			url := "http://example.com?slot=2"
 			request := httptest.NewRequest(http.MethodGet, url, nil)
@@ -395,6 +409,23 @@ func TestListAttestations(t *testing.T) {
 				assert.Equal(t, 4, len(atts))
 				assert.Equal(t, "electra", resp.Version)
 			})
+			t.Run("ssz request", func(t *testing.T) {
+				url := "http://example.com?slot=2"
+				request := httptest.NewRequest(http.MethodGet, url, nil)
+				request.Header.Set("Accept", api.OctetStreamMediaType)
+				writer := httptest.NewRecorder()
+				writer.Body = &bytes.Buffer{}
+
+				s.ListAttestationsV2(writer, request)
+				assert.Equal(t, http.StatusOK, writer.Code)
+				assert.Equal(t, "electra", writer.Header().Get(api.VersionHeader))
+				atts, err := structs.UnmarshalSSZList(writer.Body.Bytes(), structs.AttestationElectraFromSSZ)
+				require.NoError(t, err)
+				assert.Equal(t, 2, len(atts))
+				for _, a := range atts {
+					assert.Equal(t, "2", a.Data.Slot)
+				}
+			})
 			t.Run("slot request", func(t *testing.T) {
 				url := "http://example.com?slot=2"
 				request := httptest.NewRequest(http.MethodGet, url, nil)
@@ -1435,6 +1466,32 @@ func TestGetAttesterSlashings(t *testing.T) {
 			require.DeepEqual(t, slashing1PreElectra, ss[0])
 			require.DeepEqual(t, slashing2PreElectra, ss[1])
 		})
+		t.Run("ssz", func(t *testing.T) {
+			bs, err := util.NewBeaconState()
+			require.NoError(t, err)
+
+			s := &Server{
+				ChainInfoFetcher: &blockchainmock.ChainService{State: bs},
+				SlashingsPool:    &slashingsmock.PoolMock{PendingAttSlashings: []ethpbv1alpha1.AttSlashing{slashing1PreElectra, slashing2PreElectra}},
+			}
+
+			request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/beacon/pool/attester_slashings", nil)
+			request.Header.Set("Accept", api.OctetStreamMediaType)
+			writer := httptest.NewRecorder()
+			writer.Body = &bytes.Buffer{}
+
+			s.GetAttesterSlashings(writer, request)
+			require.Equal(t, http.StatusOK, writer.Code)
+			assert.Equal(t, "phase0", writer.Header().Get(api.VersionHeader))
+			slashings, err := structs.UnmarshalSSZList(writer.Body.Bytes(), structs.AttesterSlashingFromSSZ)
+			require.NoError(t, err)
+
+			ss, err := structs.AttesterSlashingsToConsensus(slashings)
+			require.NoError(t, err)
+
+			require.DeepEqual(t, slashing1PreElectra, ss[0])
+			require.DeepEqual(t, slashing2PreElectra, ss[1])
+		})
 		t.Run("no slashings", func(t *testing.T) {
 			bs, err := util.NewBeaconState()
 			require.NoError(t, err)
@@ -1492,6 +1549,32 @@ func TestGetAttesterSlashings(t *testing.T) {
 			require.DeepEqual(t, slashing1PostElectra, ss[0])
 			require.DeepEqual(t, slashing2PostElectra, ss[1])
 		})
+		t.Run("post-electra-ssz", func(t *testing.T) {
+			bs, err := util.NewBeaconStateElectra()
+			require.NoError(t, err)
+
+			s := &Server{
+				ChainInfoFetcher: &blockchainmock.ChainService{State: bs},
+				SlashingsPool:    &slashingsmock.PoolMock{PendingAttSlashings: []ethpbv1alpha1.AttSlashing{slashing1PostElectra, slashing2PostElectra}},
+			}
+
+			request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v2/beacon/pool/attester_slashings", nil)
+			request.Header.Set("Accept", api.OctetStreamMediaType)
+			writer := httptest.NewRecorder()
+			writer.Body = &bytes.Buffer{}
+
+			s.GetAttesterSlashingsV2(writer, request)
+			require.Equal(t, http.StatusOK, writer.Code)
+			assert.Equal(t, "electra", writer.Header().Get(api.VersionHeader))
+			slashings, err := structs.UnmarshalSSZList(writer.Body.Bytes(), structs.AttesterSlashingElectraFromSSZ)
+			require.NoError(t, err)
+
+			ss, err := structs.AttesterSlashingsElectraToConsensus(slashings)
+			require.NoError(t, err)
+
+			require.DeepEqual(t, slashing1PostElectra, ss[0])
+			require.DeepEqual(t, slashing2PostElectra, ss[1])
+		})
 		t.Run("pre-electra-ok", func(t *testing.T) {
 			bs, err := util.NewBeaconState()
 			require.NoError(t, err)
@@ -8,6 +8,7 @@ import (
 	"strconv"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/api"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/altair"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/helpers"
@@ -20,6 +21,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
 	"github.com/prysmaticlabs/prysm/v5/network/httputil"
 	ethpbalpha "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 	"github.com/prysmaticlabs/prysm/v5/time/slots"
 )
 
@@ -238,11 +240,28 @@ func (s *Server) GetSyncCommittees(w http.ResponseWriter, r *http.Request) {
 	}
 	isFinalized := s.FinalizationFetcher.IsFinalized(ctx, blockRoot)
 
+	data := &structs.SyncCommitteeValidators{
+		Validators:          committeeIndices,
+		ValidatorAggregates: subcommittees,
+	}
+	if httputil.RespondWithSsz(r) {
+		committeeSsz, err := data.ToSSZ()
+		if err != nil {
+			httputil.HandleError(w, "Could not convert sync committee to SSZ: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		b, err := committeeSsz.MarshalSSZ()
+		if err != nil {
+			httputil.HandleError(w, "Could not marshal sync committee into SSZ: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		shared.SetListMetadataHeaders(w, shared.ListMetadata{Version: st.Version(), ExecutionOptimistic: isOptimistic, Finalized: &isFinalized})
+		httputil.WriteSsz(w, b, "sync_committees.ssz")
+		return
+	}
+
 	resp := structs.GetSyncCommitteeResponse{
-		Data: &structs.SyncCommitteeValidators{
-			Validators:          committeeIndices,
-			ValidatorAggregates: subcommittees,
-		},
+		Data:                data,
 		ExecutionOptimistic: isOptimistic,
 		Finalized:           isFinalized,
 	}
@@ -333,3 +352,138 @@ func (s *Server) stateForSyncCommittee(ctx context.Context, w http.ResponseWrite
 	}
 	return st, true
 }
+
+// GetPendingDeposits returns the pending deposits of the state identified by state_id, in the order in which they are processed.
+func (s *Server) GetPendingDeposits(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "beacon.GetPendingDeposits")
+	defer span.End()
+
+	st, md, ok := s.electraStateForQueue(ctx, w, r, "pending deposits")
+	if !ok {
+		return
+	}
+	deposits, err := st.PendingDeposits()
+	if err != nil {
+		httputil.HandleError(w, "Could not get pending deposits: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if httputil.RespondWithSsz(r) {
+		writeSszQueue(w, md, deposits, "pending_deposits.ssz")
+		return
+	}
+	w.Header().Set(api.VersionHeader, version.String(md.Version))
+	httputil.WriteJson(w, &structs.GetPendingDepositsResponse{
+		Version:             version.String(md.Version),
+		ExecutionOptimistic: md.ExecutionOptimistic,
+		Finalized:           *md.Finalized,
+		Data:                structs.PendingDepositsFromConsensus(deposits),
+	})
+}
+
+// GetPendingPartialWithdrawals returns the pending partial withdrawals of the state identified by state_id,
+// in the order in which they are processed.
+func (s *Server) GetPendingPartialWithdrawals(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "beacon.GetPendingPartialWithdrawals")
+	defer span.End()
+
+	st, md, ok := s.electraStateForQueue(ctx, w, r, "pending partial withdrawals")
+	if !ok {
+		return
+	}
+	withdrawals, err := st.PendingPartialWithdrawals()
+	if err != nil {
+		httputil.HandleError(w, "Could not get pending partial withdrawals: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if httputil.RespondWithSsz(r) {
+		writeSszQueue(w, md, withdrawals, "pending_partial_withdrawals.ssz")
+		return
+	}
+	w.Header().Set(api.VersionHeader, version.String(md.Version))
+	httputil.WriteJson(w, &structs.GetPendingPartialWithdrawalsResponse{
+		Version:             version.String(md.Version),
+		ExecutionOptimistic: md.ExecutionOptimistic,
+		Finalized:           *md.Finalized,
+		Data:                structs.PendingPartialWithdrawalsFromConsensus(withdrawals),
+	})
+}
+
+// GetPendingConsolidations returns the pending consolidations of the state identified by state_id,
+// in the order in which they are processed.
+func (s *Server) GetPendingConsolidations(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "beacon.GetPendingConsolidations")
+	defer span.End()
+
+	st, md, ok := s.electraStateForQueue(ctx, w, r, "pending consolidations")
+	if !ok {
+		return
+	}
+	consolidations, err := st.PendingConsolidations()
+	if err != nil {
+		httputil.HandleError(w, "Could not get pending consolidations: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if httputil.RespondWithSsz(r) {
+		writeSszQueue(w, md, consolidations, "pending_consolidations.ssz")
+		return
+	}
+	w.Header().Set(api.VersionHeader, version.String(md.Version))
+	httputil.WriteJson(w, &structs.GetPendingConsolidationsResponse{
+		Version:             version.String(md.Version),
+		ExecutionOptimistic: md.ExecutionOptimistic,
+		Finalized:           *md.Finalized,
+		Data:                structs.PendingConsolidationsFromConsensus(consolidations),
+	})
+}
+
+// electraStateForQueue returns the state identified by the state_id of the request, which must be at least
+// an electra state as the queue did not exist before, along with the metadata of the response.
+func (s *Server) electraStateForQueue(ctx context.Context, w http.ResponseWriter, r *http.Request, queue string) (state.BeaconState, shared.ListMetadata, bool) {
+	stateId := r.PathValue("state_id")
+	if stateId == "" {
+		httputil.HandleError(w, "state_id is required in URL params", http.StatusBadRequest)
+		return nil, shared.ListMetadata{}, false
+	}
+	st, err := s.Stater.State(ctx, []byte(stateId))
+	if err != nil {
+		shared.WriteStateFetchError(w, err)
+		return nil, shared.ListMetadata{}, false
+	}
+	if st.Version() < version.Electra {
+		httputil.HandleError(w, "State is before the electra fork, which introduced "+queue, http.StatusBadRequest)
+		return nil, shared.ListMetadata{}, false
+	}
+	isOptimistic, err := helpers.IsOptimistic(ctx, []byte(stateId), s.OptimisticModeFetcher, s.Stater, s.ChainInfoFetcher, s.BeaconDB)
+	if err != nil {
+		httputil.HandleError(w, "Could not check optimistic status: "+err.Error(), http.StatusInternalServerError)
+		return nil, shared.ListMetadata{}, false
+	}
+	blockRoot, err := st.LatestBlockHeader().HashTreeRoot()
+	if err != nil {
+		httputil.HandleError(w, "Could not calculate root of latest block header: "+err.Error(), http.StatusInternalServerError)
+		return nil, shared.ListMetadata{}, false
+	}
+	isFinalized := s.FinalizationFetcher.IsFinalized(ctx, blockRoot)
+	return st, shared.ListMetadata{Version: st.Version(), ExecutionOptimistic: isOptimistic, Finalized: &isFinalized}, true
+}
+
+// writeSszQueue writes the SSZ encoding of a list of fixed size elements of the state.
+func writeSszQueue[T interface {
+	SizeSSZ() int
+	MarshalSSZTo([]byte) ([]byte, error)
+}](w http.ResponseWriter, md shared.ListMetadata, items []T, fileName string) {
+	size := 0
+	if len(items) > 0 {
+		size = len(items) * items[0].SizeSSZ()
+	}
+	b := make([]byte, 0, size)
+	var err error
+	for _, item := range items {
+		if b, err = item.MarshalSSZTo(b); err != nil {
+			httputil.HandleError(w, "Could not marshal response into SSZ: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	shared.SetListMetadataHeaders(w, md)
+	httputil.WriteSsz(w, b, fileName)
+}
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/api"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	chainMock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
 	dbTest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
@@ -476,6 +477,22 @@ func TestGetSyncCommittees(t *testing.T) {
 		}
 	}
 
+	t.Run("ssz", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com//eth/v1/beacon/states/{state_id}/sync_committees", nil)
+		request.SetPathValue("state_id", hexutil.Encode(stRoot[:]))
+		request.Header.Set("Accept", api.OctetStreamMediaType)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetSyncCommittees(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, "altair", writer.Header().Get(api.VersionHeader))
+		assert.Equal(t, "false", writer.Header().Get(api.FinalizedHeader))
+		committee := &structs.SyncCommitteeValidatorsSSZ{}
+		require.NoError(t, committee.UnmarshalSSZ(writer.Body.Bytes()))
+		require.DeepEqual(t, resp.Data, structs.SyncCommitteeValidatorsFromSSZ(committee))
+	})
+
 	t.Run("execution optimistic", func(t *testing.T) {
 		parentRoot := [32]byte{'a'}
 		blk := util.NewBeaconBlock()
@@ -647,3 +664,166 @@ func TestGetSyncCommittees_Future(t *testing.T) {
 		}
 	}
 }
+
+func pendingQueuesTestServer(t *testing.T, st state.BeaconState) *Server {
+	chainService := &chainMock.ChainService{}
+	return &Server{
+		Stater: &testutil.MockStater{
+			BeaconState: st,
+		},
+		HeadFetcher:           chainService,
+		OptimisticModeFetcher: chainService,
+		FinalizationFetcher:   chainService,
+		BeaconDB:              dbTest.SetupDB(t),
+	}
+}
+
+func TestGetPendingDeposits(t *testing.T) {
+	deposits := []*ethpbalpha.PendingDeposit{
+		{
+			PublicKey:             bytesutil.PadTo([]byte{1}, 48),
+			WithdrawalCredentials: bytesutil.PadTo([]byte{2}, 32),
+			Amount:                32,
+			Signature:             bytesutil.PadTo([]byte{3}, 96),
+			Slot:                  5,
+		},
+		{
+			PublicKey:             bytesutil.PadTo([]byte{4}, 48),
+			WithdrawalCredentials: bytesutil.PadTo([]byte{5}, 32),
+			Amount:                1,
+			Signature:             bytesutil.PadTo([]byte{6}, 96),
+			Slot:                  6,
+		},
+	}
+	st, err := util.NewBeaconStateElectra(func(st *ethpbalpha.BeaconStateElectra) error {
+		st.PendingDeposits = deposits
+		return nil
+	})
+	require.NoError(t, err)
+	s := pendingQueuesTestServer(t, st)
+
+	t.Run("json", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/beacon/states/{state_id}/pending_deposits", nil)
+		request.SetPathValue("state_id", "head")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetPendingDeposits(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, "electra", writer.Header().Get(api.VersionHeader))
+		resp := &structs.GetPendingDepositsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, "electra", resp.Version)
+		assert.Equal(t, false, resp.Finalized)
+		assert.DeepEqual(t, structs.PendingDepositsFromConsensus(deposits), resp.Data)
+	})
+	t.Run("ssz", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/beacon/states/{state_id}/pending_deposits", nil)
+		request.SetPathValue("state_id", "head")
+		request.Header.Set("Accept", api.OctetStreamMediaType)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetPendingDeposits(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, "electra", writer.Header().Get(api.VersionHeader))
+		assert.Equal(t, "false", writer.Header().Get(api.FinalizedHeader))
+		size := deposits[0].SizeSSZ()
+		require.Equal(t, 2*size, writer.Body.Len())
+		for i, d := range deposits {
+			got := &ethpbalpha.PendingDeposit{}
+			require.NoError(t, got.UnmarshalSSZ(writer.Body.Bytes()[i*size:(i+1)*size]))
+			assert.DeepEqual(t, d, got)
+		}
+	})
+	t.Run("before electra", func(t *testing.T) {
+		st, err := util.NewBeaconStateDeneb()
+		require.NoError(t, err)
+		s := &Server{Stater: &testutil.MockStater{BeaconState: st}}
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/beacon/states/{state_id}/pending_deposits", nil)
+		request.SetPathValue("state_id", "head")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetPendingDeposits(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &httputil.DefaultJsonError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "before the electra fork, which introduced pending deposits", e.Message)
+	})
+}
+
+func TestGetPendingPartialWithdrawals(t *testing.T) {
+	withdrawals := []*ethpbalpha.PendingPartialWithdrawal{
+		{Index: 1, Amount: 100, WithdrawableEpoch: 10},
+		{Index: 7, Amount: 200, WithdrawableEpoch: 11},
+	}
+	st, err := util.NewBeaconStateElectra(func(st *ethpbalpha.BeaconStateElectra) error {
+		st.PendingPartialWithdrawals = withdrawals
+		return nil
+	})
+	require.NoError(t, err)
+	s := pendingQueuesTestServer(t, st)
+
+	t.Run("json", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/beacon/states/{state_id}/pending_partial_withdrawals", nil)
+		request.SetPathValue("state_id", "head")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetPendingPartialWithdrawals(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetPendingPartialWithdrawalsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+		assert.DeepEqual(t, &structs.PendingPartialWithdrawal{Index: "7", Amount: "200", WithdrawableEpoch: "11"}, resp.Data[1])
+	})
+	t.Run("ssz", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/beacon/states/{state_id}/pending_partial_withdrawals", nil)
+		request.SetPathValue("state_id", "head")
+		request.Header.Set("Accept", api.OctetStreamMediaType)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetPendingPartialWithdrawals(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		want, err := withdrawals[0].MarshalSSZ()
+		require.NoError(t, err)
+		want, err = withdrawals[1].MarshalSSZTo(want)
+		require.NoError(t, err)
+		assert.DeepEqual(t, want, writer.Body.Bytes())
+	})
+}
+
+func TestGetPendingConsolidations(t *testing.T) {
+	st, err := util.NewBeaconStateElectra(func(st *ethpbalpha.BeaconStateElectra) error {
+		st.PendingConsolidations = []*ethpbalpha.PendingConsolidation{{SourceIndex: 3, TargetIndex: 4}}
+		return nil
+	})
+	require.NoError(t, err)
+	s := pendingQueuesTestServer(t, st)
+
+	t.Run("json", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/beacon/states/{state_id}/pending_consolidations", nil)
+		request.SetPathValue("state_id", "head")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetPendingConsolidations(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetPendingConsolidationsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.DeepEqual(t, []*structs.PendingConsolidation{{SourceIndex: "3", TargetIndex: "4"}}, resp.Data)
+	})
+	t.Run("ssz", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/beacon/states/{state_id}/pending_consolidations", nil)
+		request.SetPathValue("state_id", "head")
+		request.Header.Set("Accept", api.OctetStreamMediaType)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetPendingConsolidations(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.DeepEqual(t, []byte{3, 0, 0, 0, 0, 0, 0, 0, 4, 0, 0, 0, 0, 0, 0, 0}, writer.Body.Bytes())
+	})
+}
@@ -3334,6 +3334,26 @@ func TestGetCommittees(t *testing.T) {
 			assert.Equal(t, epoch, slots.ToEpoch(primitives.Slot(slot)))
 		}
 	})
+	t.Run("Head all committees ssz", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, url, nil)
+		request.SetPathValue("state_id", "head")
+		request.Header.Set("Accept", api.OctetStreamMediaType)
+		writer := httptest.NewRecorder()
+
+		writer.Body = &bytes.Buffer{}
+		s.GetCommittees(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, "phase0", writer.Header().Get(api.VersionHeader))
+		assert.Equal(t, "false", writer.Header().Get(api.FinalizedHeader))
+		data, err := structs.UnmarshalSSZList(writer.Body.Bytes(), structs.CommitteeFromSSZ)
+		require.NoError(t, err)
+		assert.Equal(t, int(params.BeaconConfig().SlotsPerEpoch)*2, len(data))
+		total := 0
+		for _, datum := range data {
+			total += len(datum.Validators)
+		}
+		assert.Equal(t, 8192, total)
+	})
 	t.Run("Head all committees of epoch 10", func(t *testing.T) {
 		query := url + "?epoch=10"
 		request := httptest.NewRequest(http.MethodGet, query, nil)
@@ -3612,6 +3632,27 @@ func TestGetBlockHeaders(t *testing.T) {
 				}
 			})
 		}
+		t.Run("ssz", func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, url+"?slot=30", nil)
+			writer := httptest.NewRecorder()
+			writer.Body = &bytes.Buffer{}
+			bs.GetBlockHeaders(writer, request)
+			require.Equal(t, http.StatusOK, writer.Code)
+			resp := &structs.GetBlockHeadersResponse{}
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+
+			request = httptest.NewRequest(http.MethodGet, url+"?slot=30", nil)
+			request.Header.Set("Accept", api.OctetStreamMediaType)
+			writer = httptest.NewRecorder()
+			writer.Body = &bytes.Buffer{}
+			bs.GetBlockHeaders(writer, request)
+			require.Equal(t, http.StatusOK, writer.Code)
+			assert.Equal(t, "phase0", writer.Header().Get(api.VersionHeader))
+			assert.Equal(t, strconv.FormatBool(resp.Finalized), writer.Header().Get(api.FinalizedHeader))
+			headers, err := structs.UnmarshalSSZList(writer.Body.Bytes(), structs.BlockHeaderContainerFromSSZ)
+			require.NoError(t, err)
+			require.DeepEqual(t, resp.Data, headers)
+		})
 	})
 
 	t.Run("execution optimistic", func(t *testing.T) {
@@ -82,95 +82,100 @@ func (s *Server) GetValidators(w http.ResponseWriter, r *http.Request) {
 	if !ok {
 		return
 	}
+	var readOnlyVals []state.ReadOnlyValidator
 	// return no data if all IDs are ignored
-	if len(rawIds) > 0 && len(ids) == 0 {
-		resp := &structs.GetValidatorsResponse{
-			Data:                []*structs.ValidatorContainer{},
-			ExecutionOptimistic: isOptimistic,
-			Finalized:           isFinalized,
+	if len(rawIds) == 0 || len(ids) > 0 {
+		readOnlyVals, ok = valsFromIds(w, st, ids)
+		if !ok {
+			return
 		}
-		httputil.WriteJson(w, resp)
-		return
-	}
-
-	readOnlyVals, ok := valsFromIds(w, st, ids)
-	if !ok {
-		return
 	}
 	epoch := slots.ToEpoch(st.Slot())
 
-	// Exit early if no matching validators were found or we don't want to further filter validators by status.
-	if len(readOnlyVals) == 0 || len(statuses) == 0 {
-		containers := make([]*structs.ValidatorContainer, len(readOnlyVals))
-		for i, val := range readOnlyVals {
-			valStatus, err := helpers.ValidatorSubStatus(val, epoch)
-			if err != nil {
-				httputil.HandleError(w, "Could not get validator status: "+err.Error(), http.StatusInternalServerError)
+	var filteredStatuses map[validator.Status]bool
+	if len(readOnlyVals) > 0 && len(statuses) > 0 {
+		filteredStatuses = make(map[validator.Status]bool, len(statuses))
+		for _, ss := range statuses {
+			ok, vs := validator.StatusFromString(ss)
+			if !ok {
+				httputil.HandleError(w, "Invalid status "+ss, http.StatusBadRequest)
 				return
 			}
-			id := primitives.ValidatorIndex(i)
-			if len(ids) > 0 {
-				id = ids[i]
-			}
-			balance, err := st.BalanceAtIndex(id)
+			filteredStatuses[vs] = true
+		}
+	}
+	// entry returns the index, balance and status of the i-th validator, or nil if the validator does not match the
+	// statuses.
+	entry := func(i int, val state.ReadOnlyValidator) (*validatorEntry, *httputil.DefaultJsonError) {
+		valSubStatus, err := helpers.ValidatorSubStatus(val, epoch)
+		if err != nil {
+			return nil, &httputil.DefaultJsonError{Message: "Could not get validator status: " + err.Error(), Code: http.StatusInternalServerError}
+		}
+		if filteredStatuses != nil {
+			valStatus, err := helpers.ValidatorStatus(val, epoch)
 			if err != nil {
-				httputil.HandleError(w, "Could not get validator balance: "+err.Error(), http.StatusInternalServerError)
-				return
+				return nil, &httputil.DefaultJsonError{Message: "Could not get validator status: " + err.Error(), Code: http.StatusInternalServerError}
+			}
+			if !filteredStatuses[valStatus] && !filteredStatuses[valSubStatus] {
+				return nil, nil
 			}
-			containers[i] = valContainerFromReadOnlyVal(val, id, balance, valStatus)
 		}
-		resp := &structs.GetValidatorsResponse{
-			Data:                containers,
-			ExecutionOptimistic: isOptimistic,
-			Finalized:           isFinalized,
+		id := primitives.ValidatorIndex(i)
+		if len(ids) > 0 {
+			id = ids[i]
 		}
-		httputil.WriteJson(w, resp)
-		return
+		balance, err := st.BalanceAtIndex(id)
+		if err != nil {
+			return nil, &httputil.DefaultJsonError{Message: "Could not get validator balance: " + err.Error(), Code: http.StatusInternalServerError}
+		}
+		return &validatorEntry{index: id, balance: balance, status: valSubStatus}, nil
 	}
 
-	filteredStatuses := make(map[validator.Status]bool, len(statuses))
-	for _, ss := range statuses {
-		ok, vs := validator.StatusFromString(ss)
-		if !ok {
-			httputil.HandleError(w, "Invalid status "+ss, http.StatusBadRequest)
-			return
+	// The containers are streamed rather than collected, as there can be millions of them.
+	if httputil.RespondWithSsz(r) {
+		md := shared.ListMetadata{Version: st.Version(), ExecutionOptimistic: isOptimistic, Finalized: &isFinalized}
+		lw := shared.NewSszListWriter(w, md, "validators.ssz")
+		var enc []byte
+		for i, val := range readOnlyVals {
+			e, errJson := entry(i, val)
+			if errJson != nil {
+				lw.HandleError(errJson.Message, errJson.Code)
+				return
+			}
+			if e == nil {
+				continue
+			}
+			c := &structs.ValidatorContainerSSZ{Index: uint64(e.index), Balance: e.balance, Status: uint8(e.status), Validator: val.Copy()}
+			if enc, err = c.MarshalSSZTo(enc[:0]); err != nil {
+				lw.HandleError("Could not marshal response into SSZ: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+			if !lw.Write(enc) {
+				return
+			}
 		}
-		filteredStatuses[vs] = true
+		lw.Close()
+		return
 	}
-	valContainers := make([]*structs.ValidatorContainer, 0, len(readOnlyVals))
+	lw := httputil.NewJsonListWriter(w, "data", &stateListMetadata{ExecutionOptimistic: isOptimistic, Finalized: isFinalized})
 	for i, val := range readOnlyVals {
-		valStatus, err := helpers.ValidatorStatus(val, epoch)
-		if err != nil {
-			httputil.HandleError(w, "Could not get validator status: "+err.Error(), http.StatusInternalServerError)
+		e, errJson := entry(i, val)
+		if errJson != nil {
+			lw.HandleError(errJson.Message, errJson.Code)
 			return
 		}
-		valSubStatus, err := helpers.ValidatorSubStatus(val, epoch)
-		if err != nil {
-			httputil.HandleError(w, "Could not get validator status: "+err.Error(), http.StatusInternalServerError)
+		if e != nil && !lw.Write(valContainerFromReadOnlyVal(val, e.index, e.balance, e.status)) {
 			return
 		}
-		if filteredStatuses[valStatus] || filteredStatuses[valSubStatus] {
-			var container *structs.ValidatorContainer
-			id := primitives.ValidatorIndex(i)
-			if len(ids) > 0 {
-				id = ids[i]
-			}
-			balance, err := st.BalanceAtIndex(id)
-			if err != nil {
-				httputil.HandleError(w, "Could not get validator balance: "+err.Error(), http.StatusInternalServerError)
-				return
-			}
-			container = valContainerFromReadOnlyVal(val, id, balance, valSubStatus)
-			valContainers = append(valContainers, container)
-		}
 	}
+	lw.Close()
+}
 
-	resp := &structs.GetValidatorsResponse{
-		Data:                valContainers,
-		ExecutionOptimistic: isOptimistic,
-		Finalized:           isFinalized,
-	}
-	httputil.WriteJson(w, resp)
+// validatorEntry holds the fields of an element of the validators response which are not read from the validator.
+type validatorEntry struct {
+	index   primitives.ValidatorIndex
+	balance uint64
+	status  validator.Status
 }
 
 // GetValidator returns a validator specified by state and id or public key along with status and balance.
@@ -285,43 +290,53 @@ func (s *Server) GetValidatorBalances(w http.ResponseWriter, r *http.Request) {
 	if !ok {
 		return
 	}
-	// return no data if all IDs are ignored
-	if len(rawIds) > 0 && len(ids) == 0 {
-		resp := &structs.GetValidatorBalancesResponse{
-			Data:                []*structs.ValidatorBalance{},
-			ExecutionOptimistic: isOptimistic,
-			Finalized:           isFinalized,
+	bals := st.Balances()
+	n := len(ids)
+	if len(rawIds) == 0 {
+		n = len(bals)
+	}
+	index := func(i int) primitives.ValidatorIndex {
+		if len(rawIds) > 0 {
+			return ids[i]
 		}
-		httputil.WriteJson(w, resp)
-		return
+		return primitives.ValidatorIndex(i)
 	}
 
-	bals := st.Balances()
-	var valBalances []*structs.ValidatorBalance
-	if len(ids) == 0 {
-		valBalances = make([]*structs.ValidatorBalance, len(bals))
-		for i, b := range bals {
-			valBalances[i] = &structs.ValidatorBalance{
-				Index:   strconv.FormatUint(uint64(i), 10),
-				Balance: strconv.FormatUint(b, 10),
+	if httputil.RespondWithSsz(r) {
+		md := shared.ListMetadata{Version: st.Version(), ExecutionOptimistic: isOptimistic, Finalized: &isFinalized}
+		lw := shared.NewSszListWriter(w, md, "validator_balances.ssz")
+		var enc []byte
+		for i := 0; i < n; i++ {
+			id := index(i)
+			b := &structs.ValidatorBalanceSSZ{Index: uint64(id), Balance: bals[id]}
+			if enc, err = b.MarshalSSZTo(enc[:0]); err != nil {
+				lw.HandleError("Could not marshal response into SSZ: "+err.Error(), http.StatusInternalServerError)
+				return
 			}
-		}
-	} else {
-		valBalances = make([]*structs.ValidatorBalance, len(ids))
-		for i, id := range ids {
-			valBalances[i] = &structs.ValidatorBalance{
-				Index:   strconv.FormatUint(uint64(id), 10),
-				Balance: strconv.FormatUint(bals[id], 10),
+			if !lw.Write(enc) {
+				return
 			}
 		}
+		lw.Close()
+		return
+	}
+	lw := httputil.NewJsonListWriter(w, "data", &stateListMetadata{ExecutionOptimistic: isOptimistic, Finalized: isFinalized})
+	for i := 0; i < n; i++ {
+		id := index(i)
+		if !lw.Write(&structs.ValidatorBalance{
+			Index:   strconv.FormatUint(uint64(id), 10),
+			Balance: strconv.FormatUint(bals[id], 10),
+		}) {
+			return
+		}
 	}
+	lw.Close()
+}
 
-	resp := &structs.GetValidatorBalancesResponse{
-		Data:                valBalances,
-		ExecutionOptimistic: isOptimistic,
-		Finalized:           isFinalized,
-	}
-	httputil.WriteJson(w, resp)
+// stateListMetadata holds the fields of a list response read from a state, which are written before the streamed list.
+type stateListMetadata struct {
+	ExecutionOptimistic bool `json:"execution_optimistic"`
+	Finalized           bool `json:"finalized"`
 }
 
 // decodeIds takes in a list of validator ID strings (as either a pubkey or a validator index)
@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/api"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	chainMock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/lookup"
@@ -316,6 +317,37 @@ func TestGetValidators(t *testing.T) {
 		require.Equal(t, 1, len(resp.Data))
 		assert.Equal(t, "3", resp.Data[0].Index)
 	})
+	t.Run("ssz", func(t *testing.T) {
+		chainService := &chainMock.ChainService{}
+		s := Server{
+			Stater: &testutil.MockStater{
+				BeaconState: st,
+			},
+			HeadFetcher:           chainService,
+			OptimisticModeFetcher: chainService,
+			FinalizationFetcher:   chainService,
+		}
+
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/beacon/states/{state_id}/validators?status=exited", nil)
+		request.SetPathValue("state_id", "head")
+		request.Header.Set("Accept", api.OctetStreamMediaType)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetValidators(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, api.OctetStreamMediaType, writer.Header().Get("Content-Type"))
+		assert.Equal(t, "phase0", writer.Header().Get(api.VersionHeader))
+		assert.Equal(t, "false", writer.Header().Get(api.ExecutionOptimisticHeader))
+		assert.Equal(t, "false", writer.Header().Get(api.FinalizedHeader))
+		data, err := structs.UnmarshalSSZList(writer.Body.Bytes(), structs.ValidatorContainerFromSSZ)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(data))
+		assert.Equal(t, strconv.Itoa(exitedValIndex), data[0].Index)
+		assert.Equal(t, "exited_unslashed", data[0].Status)
+		assert.Equal(t, "32000000000", data[0].Balance)
+		assert.Equal(t, "0", data[0].Validator.ExitEpoch)
+	})
 	t.Run("POST nil values", func(t *testing.T) {
 		chainService := &chainMock.ChainService{}
 		s := Server{
@@ -871,6 +903,31 @@ func TestGetValidatorBalances(t *testing.T) {
 		assert.Equal(t, "3", val.Index)
 		assert.Equal(t, "3", val.Balance)
 	})
+	t.Run("ssz", func(t *testing.T) {
+		chainService := &chainMock.ChainService{}
+		s := Server{
+			Stater: &testutil.MockStater{
+				BeaconState: st,
+			},
+			HeadFetcher:           chainService,
+			OptimisticModeFetcher: chainService,
+			FinalizationFetcher:   chainService,
+		}
+
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/beacon/states/{state_id}/validator_balances?id=1&id=3", nil)
+		request.SetPathValue("state_id", "head")
+		request.Header.Set("Accept", api.OctetStreamMediaType)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetValidatorBalances(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, "phase0", writer.Header().Get(api.VersionHeader))
+		data, err := structs.UnmarshalSSZList(writer.Body.Bytes(), structs.ValidatorBalanceFromSSZ)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(data))
+		assert.DeepEqual(t, &structs.ValidatorBalance{Index: "3", Balance: "3"}, data[1])
+	})
 	t.Run("get by index", func(t *testing.T) {
 		chainService := &chainMock.ChainService{}
 		s := Server{
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -62,8 +64,16 @@ const (
 	LightClientFinalityUpdateTopic = "light_client_finality_update"
 	// LightClientOptimisticUpdateTopic represents a new light client optimistic update event topic.
 	LightClientOptimisticUpdateTopic = "light_client_optimistic_update"
+	// ValidatorLifecycleTopic represents a validator lifecycle transition event topic.
+	ValidatorLifecycleTopic = "validator_lifecycle"
+	// BlockGossipTopic represents a new block received from gossip event topic, sent before the block is imported.
+	BlockGossipTopic = "block_gossip"
 )
 
+// validatorFilterParam is the query parameter restricting the validator lifecycle and block gossip events to those
+// of the given validator indices. It can be repeated, and each value can be a comma separated list of indices.
+const validatorFilterParam = "validator_indices"
+
 var (
 	errInvalidTopicName   = errors.New("invalid topic name")
 	errNoValidTopics      = errors.New("no valid topics specified")
@@ -71,6 +81,9 @@ var (
 	errNotRequested       = errors.New("event not requested by client")
 	errUnhandledEventData = errors.New("unable to represent event data in the event stream")
 	errWriterUnusable     = errors.New("http response writer is unusable")
+	errInvalidLastEventID = errors.New("invalid Last-Event-ID header")
+	errStaleLastEventID   = errors.New("stale Last-Event-ID header: the events sent before the beacon node restarted are not kept")
+	errInvalidValidator   = errors.New("invalid validator index")
 )
 
 // The eventStreamer uses lazyReaders to defer serialization until the moment the value is ready to be written to the client.
@@ -85,6 +98,7 @@ var opsFeedEventTopics = map[feed.EventType]string{
 	operation.BlobSidecarReceived:               BlobSidecarTopic,
 	operation.AttesterSlashingReceived:          AttesterSlashingTopic,
 	operation.ProposerSlashingReceived:          ProposerSlashingTopic,
+	operation.BlockGossipReceived:               BlockGossipTopic,
 }
 
 var stateFeedEventTopics = map[feed.EventType]string{
@@ -95,6 +109,7 @@ var stateFeedEventTopics = map[feed.EventType]string{
 	statefeed.LightClientOptimisticUpdate: LightClientOptimisticUpdateTopic,
 	statefeed.Reorg:                       ChainReorgTopic,
 	statefeed.BlockProcessed:              BlockTopic,
+	statefeed.ValidatorLifecycle:          ValidatorLifecycleTopic,
 }
 
 var topicsForStateFeed = topicsForFeed(stateFeedEventTopics)
@@ -112,12 +127,35 @@ type topicRequest struct {
 	topics        map[string]bool
 	needStateFeed bool
 	needOpsFeed   bool
+	// validators restricts the events about validators to these indices. All validators are included when nil.
+	validators map[primitives.ValidatorIndex]bool
 }
 
 func (req *topicRequest) requested(topic string) bool {
 	return req.topics[topic]
 }
 
+func (req *topicRequest) validatorRequested(idx primitives.ValidatorIndex) bool {
+	return req.validators == nil || req.validators[idx]
+}
+
+// setValidatorFilter restricts the request to the validator indices given in the query parameter values.
+func (req *topicRequest) setValidatorFilter(values []string) error {
+	for _, value := range values {
+		for _, v := range strings.Split(value, ",") {
+			idx, err := strconv.ParseUint(strings.TrimSpace(v), 10, 64)
+			if err != nil {
+				return errors.Wrap(errInvalidValidator, v)
+			}
+			if req.validators == nil {
+				req.validators = make(map[primitives.ValidatorIndex]bool)
+			}
+			req.validators[primitives.ValidatorIndex(idx)] = true
+		}
+	}
+	return nil
+}
+
 func newTopicRequest(topics []string) (*topicRequest, error) {
 	req := &topicRequest{topics: make(map[string]bool)}
 	for _, name := range topics {
@@ -137,10 +175,43 @@ func newTopicRequest(topics []string) (*topicRequest, error) {
 	return req, nil
 }
 
+// replayRequest returns the topics of req which can be replayed. Payload attributes are computed when they are sent,
+// so they are left out of replayed events.
+func (req *topicRequest) replayRequest() *topicRequest {
+	replay := &topicRequest{topics: make(map[string]bool, len(req.topics)), validators: req.validators}
+	for topic := range req.topics {
+		if topic != PayloadAttributesTopic {
+			replay.topics[topic] = true
+		}
+	}
+	return replay
+}
+
+// streamPosition is the ID of the last event received by a reconnecting client, from the Last-Event-ID header.
+type streamPosition struct {
+	lastID uint64
+	resume bool
+}
+
+func newStreamPosition(r *http.Request, history *EventHistory) (streamPosition, error) {
+	h := r.Header.Get(api.LastEventIDHeader)
+	if h == "" || history == nil {
+		return streamPosition{}, nil
+	}
+	id, err := history.parseEventID(h)
+	if err != nil {
+		return streamPosition{}, err
+	}
+	return streamPosition{lastID: id, resume: true}, nil
+}
+
 // StreamEvents provides an endpoint to subscribe to the beacon node Server-Sent-Events stream.
 // Consumers should use the eventsource implementation to listen for those events.
 // Servers may send SSE comments beginning with ':' for any purpose,
 // including to keep the event stream connection alive in the presence of proxy servers.
+// When the server keeps an event history, every event is sent with an ID, and a client reconnecting with
+// the Last-Event-ID header is first sent the kept events of the requested topics which came after that ID.
+// IDs sent before the beacon node restarted are rejected with a 409 status, as the events after them are lost.
 func (s *Server) StreamEvents(w http.ResponseWriter, r *http.Request) {
 	log.Debug("Starting StreamEvents handler")
 	ctx, span := trace.StartSpan(r.Context(), "events.StreamEvents")
@@ -151,6 +222,19 @@ func (s *Server) StreamEvents(w http.ResponseWriter, r *http.Request) {
 		httputil.HandleError(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	if err := topics.setValidatorFilter(r.URL.Query()[validatorFilterParam]); err != nil {
+		httputil.HandleError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	pos, err := newStreamPosition(r, s.History)
+	if errors.Is(err, errStaleLastEventID) {
+		httputil.HandleError(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		httputil.HandleError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
 	timeout := s.EventWriteTimeout
 	if timeout == 0 {
@@ -172,7 +256,7 @@ func (s *Server) StreamEvents(w http.ResponseWriter, r *http.Request) {
 	es := newEventStreamer(buffSize, ka)
 
 	go es.outboxWriteLoop(ctx, cancel, sw)
-	if err := es.recvEventLoop(ctx, cancel, topics, s); err != nil {
+	if err := es.recvEventLoop(ctx, cancel, topics, pos, s); err != nil {
 		log.WithError(err).Debug("Shutting down StreamEvents handler.")
 	}
 	cleanupStart := time.Now()
@@ -194,49 +278,129 @@ type eventStreamer struct {
 	openUntilExit chan struct{}
 }
 
-func (es *eventStreamer) recvEventLoop(ctx context.Context, cancel context.CancelFunc, req *topicRequest, s *Server) error {
+func (es *eventStreamer) recvEventLoop(ctx context.Context, cancel context.CancelFunc, req *topicRequest, pos streamPosition, s *Server) error {
 	defer close(es.outbox)
 	defer cancel()
 	eventsChan := make(chan *feed.Event, len(es.outbox))
-	if req.needOpsFeed {
-		opsSub := s.OperationNotifier.OperationFeed().Subscribe(eventsChan)
-		defer opsSub.Unsubscribe()
-	}
-	if req.needStateFeed {
-		stateSub := s.StateNotifier.StateFeed().Subscribe(eventsChan)
-		defer stateSub.Unsubscribe()
+	// Both stay nil without an event history, so that they are never selected.
+	var recordsChan <-chan *eventRecord
+	var dropped <-chan struct{}
+	var replayedID uint64
+	if s.History != nil {
+		sub, missed := s.History.subscribe(cap(es.outbox), req, pos.lastID, pos.resume)
+		defer sub.unsubscribe()
+		recordsChan, dropped = sub.records, sub.dropped
+		if len(missed) > 0 {
+			log.WithField("events", len(missed)).Debug("Replaying events missed by reconnecting client")
+		}
+		replayReq := req.replayRequest()
+		for _, rec := range missed {
+			lr, err := s.lazyReaderForEvent(ctx, rec.event, replayReq)
+			if err != nil {
+				logEventError(rec.event, err)
+				continue
+			}
+			// The replayed events may not fit in the outbox, so wait for the client to read them.
+			if err := es.write(ctx, withEventID(s.History.eventID(rec.id), lr)); err != nil {
+				return err
+			}
+			replayedID = rec.id
+		}
+		// Neither may the new events buffered by the subscription while the replay was sent, so they are sent the
+		// same way until the subscription has caught up.
+		for len(sub.records) > 0 {
+			rec := <-sub.records
+			if rec.id <= replayedID {
+				continue
+			}
+			lr, err := s.lazyReaderForEvent(ctx, rec.event, req)
+			if err != nil {
+				logEventError(rec.event, err)
+				continue
+			}
+			if err := es.write(ctx, withEventID(s.History.eventID(rec.id), lr)); err != nil {
+				return err
+			}
+		}
+	} else {
+		if req.needOpsFeed {
+			opsSub := s.OperationNotifier.OperationFeed().Subscribe(eventsChan)
+			defer opsSub.Unsubscribe()
+		}
+		if req.needStateFeed {
+			stateSub := s.StateNotifier.StateFeed().Subscribe(eventsChan)
+			defer stateSub.Unsubscribe()
+		}
 	}
 	for {
+		var lr lazyReader
+		var err error
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		case event := <-eventsChan:
-			lr, err := s.lazyReaderForEvent(ctx, event, req)
+			lr, err = s.lazyReaderForEvent(ctx, event, req)
 			if err != nil {
-				if !errors.Is(err, errNotRequested) {
-					log.WithField("event_type", fmt.Sprintf("%v", event.Data)).WithError(err).Error("StreamEvents API endpoint received an event it was unable to handle.")
-				}
+				logEventError(event, err)
 				continue
 			}
-			// If the client can't keep up, the outbox will eventually completely fill, at which
-			// safeWrite will error, and we'll hit the below return statement, at which point the deferred
-			// Unsuscribe calls will be made and the event feed will stop writing to this channel.
-			// Since the outbox and event stream channels are separately buffered, the event subscription
-			// channel should stay relatively empty, which gives this loop time to unsubscribe
-			// and cleanup before the event stream channel fills and disrupts other readers.
-			if err := es.safeWrite(ctx, lr); err != nil {
-				// note: we could hijack the connection and close it here. Does that cause issues? What are the benefits?
-				// A benefit of hijack and close is that it may force an error on the remote end, however just closing the context of the
-				// http handler may be sufficient to cause the remote http response reader to close.
-				if errors.Is(err, errSlowReader) {
-					log.WithError(err).Warn("Client is unable to keep up with event stream, shutting down.")
-				}
-				return err
+		case rec := <-recordsChan:
+			// Events kept before the client subscribed may be sent both as a replay and live.
+			if rec.id <= replayedID {
+				continue
+			}
+			lr, err = s.lazyReaderForEvent(ctx, rec.event, req)
+			if err != nil {
+				logEventError(rec.event, err)
+				continue
+			}
+			lr = withEventID(s.History.eventID(rec.id), lr)
+		case <-dropped:
+			log.WithError(errSlowReader).Warn("Client is unable to keep up with event stream, shutting down.")
+			return errSlowReader
+		}
+		// If the client can't keep up, the outbox will eventually completely fill, at which
+		// safeWrite will error, and we'll hit the below return statement, at which point the deferred
+		// Unsuscribe calls will be made and the event feed will stop writing to this channel.
+		// Since the outbox and event stream channels are separately buffered, the event subscription
+		// channel should stay relatively empty, which gives this loop time to unsubscribe
+		// and cleanup before the event stream channel fills and disrupts other readers.
+		if err := es.safeWrite(ctx, lr); err != nil {
+			// note: we could hijack the connection and close it here. Does that cause issues? What are the benefits?
+			// A benefit of hijack and close is that it may force an error on the remote end, however just closing the context of the
+			// http handler may be sufficient to cause the remote http response reader to close.
+			if errors.Is(err, errSlowReader) {
+				log.WithError(err).Warn("Client is unable to keep up with event stream, shutting down.")
 			}
+			return err
 		}
 	}
 }
 
+func logEventError(event *feed.Event, err error) {
+	if errors.Is(err, errNotRequested) {
+		return
+	}
+	var data any
+	if event != nil {
+		data = event.Data
+	}
+	log.WithField("event_type", fmt.Sprintf("%v", data)).WithError(err).Error("StreamEvents API endpoint received an event it was unable to handle.")
+}
+
+// write waits for room in the outbox rather than failing when it is full.
+func (es *eventStreamer) write(ctx context.Context, rf lazyReader) error {
+	if rf == nil {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case es.outbox <- rf:
+		return nil
+	}
+}
+
 func (es *eventStreamer) safeWrite(ctx context.Context, rf func() io.Reader) error {
 	if rf == nil {
 		return nil
@@ -388,6 +552,20 @@ func jsonMarshalReader(name string, v any) io.Reader {
 	return bytes.NewBufferString("event: " + name + "\ndata: " + string(d) + "\n\n")
 }
 
+// withEventID prefixes the first event message of lr with the id field.
+func withEventID(id string, lr lazyReader) lazyReader {
+	if lr == nil {
+		return nil
+	}
+	return func() io.Reader {
+		r := lr()
+		if r == nil {
+			return nil
+		}
+		return io.MultiReader(bytes.NewBufferString("id: "+id+"\n"), r)
+	}
+}
+
 func topicForEvent(event *feed.Event) string {
 	switch event.Data.(type) {
 	case *operation.AggregatedAttReceivedData:
@@ -418,6 +596,10 @@ func topicForEvent(event *feed.Event) string {
 		return ChainReorgTopic
 	case *statefeed.BlockProcessedData:
 		return BlockTopic
+	case *statefeed.ValidatorLifecycleData:
+		return ValidatorLifecycleTopic
+	case *operation.BlockGossipReceivedData:
+		return BlockGossipTopic
 	default:
 		if event.Type == statefeed.MissedSlot {
 			return PayloadAttributesTopic
@@ -551,6 +733,44 @@ func (s *Server) lazyReaderForEvent(ctx context.Context, event *feed.Event, topi
 			}
 			return jsonMarshalReader(eventName, blk)
 		}, nil
+	case *statefeed.ValidatorLifecycleData:
+		changes := make([]*structs.ValidatorLifecycleEvent, 0, len(v.Transitions))
+		for _, c := range v.Transitions {
+			if !topics.validatorRequested(c.Index) {
+				continue
+			}
+			changes = append(changes, &structs.ValidatorLifecycleEvent{
+				Epoch:          fmt.Sprintf("%d", v.Epoch),
+				ValidatorIndex: fmt.Sprintf("%d", c.Index),
+				Pubkey:         hexutil.Encode(c.Pubkey[:]),
+				Transition:     string(c.Transition),
+			})
+		}
+		if len(changes) == 0 {
+			return nil, errNotRequested
+		}
+		// Each transition is sent as a separate event message.
+		return func() io.Reader {
+			readers := make([]io.Reader, 0, len(changes))
+			for _, c := range changes {
+				if r := jsonMarshalReader(eventName, c); r != nil {
+					readers = append(readers, r)
+				}
+			}
+			return io.MultiReader(readers...)
+		}, nil
+	case *operation.BlockGossipReceivedData:
+		if !topics.validatorRequested(v.ProposerIndex) {
+			return nil, errNotRequested
+		}
+		return func() io.Reader {
+			return jsonMarshalReader(eventName, &structs.BlockGossipEvent{
+				Slot:          fmt.Sprintf("%d", v.Slot),
+				Block:         hexutil.Encode(v.BlockRoot[:]),
+				ProposerIndex: fmt.Sprintf("%d", v.ProposerIndex),
+				ArrivalTime:   fmt.Sprintf("%d", v.ArrivalTime.UnixMilli()),
+			})
+		}, nil
 	default:
 		return nil, errors.Wrapf(errUnhandledEventData, "event data type %T unsupported", v)
 	}
@@ -12,6 +12,8 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/api"
 	mockChain "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
@@ -22,6 +24,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/eth/v1"
 	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
@@ -587,3 +590,211 @@ func wedgedWriterTestCase(t *testing.T, queueDepth func([]*feed.Event) int) {
 		t.Fatalf("context canceled / timed out waiting to write all events, err=%v", ctx.Err())
 	}
 }
+
+func TestStreamEvents_Replay(t *testing.T) {
+	testSync := newStreamTestSync(t)
+	defer testSync.cleanup()
+
+	h := NewEventHistory(2)
+	s := &Server{History: h, EventWriteTimeout: testEventWriteTimeout}
+	head := func(slot primitives.Slot) *feed.Event {
+		return &feed.Event{Type: statefeed.NewHead, Data: &ethpb.EventHead{Slot: slot}}
+	}
+	finalized := func(epoch primitives.Epoch) *feed.Event {
+		return &feed.Event{Type: statefeed.FinalizedCheckpoint, Data: &ethpb.EventFinalizedCheckpoint{Epoch: epoch}}
+	}
+	recorded := []*feed.Event{head(1), finalized(1), head(2), head(3), finalized(2)}
+	for _, ev := range recorded {
+		h.record(ev)
+	}
+
+	topics, err := newTopicRequest([]string{HeadTopic, FinalizedCheckpointTopic})
+	require.NoError(t, err)
+	expected := func(id int, ev *feed.Event) string {
+		lr, err := s.lazyReaderForEvent(context.Background(), ev, topics)
+		require.NoError(t, err)
+		b, err := io.ReadAll(withEventID(h.eventID(uint64(id)), lr)())
+		require.NoError(t, err)
+		return string(b[:len(b)-2])
+	}
+	request := topics.testHttpRequest(testSync.ctx, t)
+	request.Header.Set(api.LastEventIDHeader, h.eventID(2))
+	w := NewStreamingResponseWriterRecorder(testSync.ctx)
+	go func() {
+		s.StreamEvents(w, request)
+		testSync.markDone()
+	}()
+
+	sseR := sse.NewEventStreamReader(w.Body(), 1<<24)
+	next := func() string {
+		for {
+			ev, err := sseR.ReadEvent()
+			require.NoError(t, err)
+			if !strings.HasPrefix(string(ev), ":") {
+				return string(ev)
+			}
+		}
+	}
+	// The first head event is no longer kept, and the first finalized checkpoint was received by the client.
+	require.Equal(t, expected(3, recorded[2]), next())
+	require.Equal(t, expected(4, recorded[3]), next())
+	require.Equal(t, expected(5, recorded[4]), next())
+
+	live := head(4)
+	h.record(live)
+	require.Equal(t, expected(6, live), next())
+}
+
+func TestStreamEvents_ReplayWithLiveEvents(t *testing.T) {
+	testSync := newStreamTestSync(t)
+	defer testSync.cleanup()
+
+	const depth = 64
+	h := NewEventHistory(depth)
+	// The outbox is much smaller than the replay, so the replay waits for the client to read it.
+	s := &Server{History: h, EventWriteTimeout: testEventWriteTimeout, EventFeedDepth: 4}
+	head := func(slot primitives.Slot) *feed.Event {
+		return &feed.Event{Type: statefeed.NewHead, Data: &ethpb.EventHead{Slot: slot}}
+	}
+	recorded := make([]*feed.Event, 0, 2*depth)
+	for slot := primitives.Slot(1); slot <= depth; slot++ {
+		ev := head(slot)
+		recorded = append(recorded, ev)
+		h.record(ev)
+	}
+
+	topics, err := newTopicRequest([]string{HeadTopic})
+	require.NoError(t, err)
+	expected := func(id int, ev *feed.Event) string {
+		lr, err := s.lazyReaderForEvent(context.Background(), ev, topics)
+		require.NoError(t, err)
+		b, err := io.ReadAll(withEventID(h.eventID(uint64(id)), lr)())
+		require.NoError(t, err)
+		return string(b[:len(b)-2])
+	}
+	request := topics.testHttpRequest(testSync.ctx, t)
+	request.Header.Set(api.LastEventIDHeader, h.eventID(0))
+	w := NewStreamingResponseWriterRecorder(testSync.ctx)
+	go func() {
+		s.StreamEvents(w, request)
+		testSync.markDone()
+	}()
+
+	sseR := sse.NewEventStreamReader(w.Body(), 1<<24)
+	next := func() string {
+		for {
+			ev, err := sseR.ReadEvent()
+			require.NoError(t, err)
+			if !strings.HasPrefix(string(ev), ":") {
+				return string(ev)
+			}
+		}
+	}
+	require.Equal(t, expected(1, recorded[0]), next())
+	// Live events arrive while the rest of the replay is still being sent, and are sent after it.
+	for slot := primitives.Slot(depth + 1); slot <= 2*depth; slot++ {
+		ev := head(slot)
+		recorded = append(recorded, ev)
+		h.record(ev)
+	}
+	for i := 1; i < len(recorded); i++ {
+		require.Equal(t, expected(i+1, recorded[i]), next())
+	}
+}
+
+func TestStreamEvents_InvalidLastEventID(t *testing.T) {
+	s := &Server{History: NewEventHistory(0)}
+	topics, err := newTopicRequest([]string{HeadTopic})
+	require.NoError(t, err)
+	request := topics.testHttpRequest(context.Background(), t)
+	request.Header.Set(api.LastEventIDHeader, "abc")
+	w := httptest.NewRecorder()
+	s.StreamEvents(w, request)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.StringContains(t, "invalid Last-Event-ID header", w.Body.String())
+
+	// IDs from before a restart of the node cannot be resumed from.
+	request.Header.Set(api.LastEventIDHeader, NewEventHistory(0).eventID(2))
+	w = httptest.NewRecorder()
+	s.StreamEvents(w, request)
+	require.Equal(t, http.StatusConflict, w.Code)
+	require.StringContains(t, "stale Last-Event-ID header", w.Body.String())
+}
+
+func TestStreamEvents_ValidatorFilter(t *testing.T) {
+	testSync := newStreamTestSync(t)
+	defer testSync.cleanup()
+
+	stn := mockChain.NewEventFeedWrapper()
+	opn := mockChain.NewEventFeedWrapper()
+	s := &Server{
+		StateNotifier:     &mockChain.SimpleNotifier{Feed: stn},
+		OperationNotifier: &mockChain.SimpleNotifier{Feed: opn},
+		EventWriteTimeout: testEventWriteTimeout,
+	}
+	request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/events?topics=validator_lifecycle&topics=block_gossip&validator_indices=2,5", nil)
+	request = request.WithContext(testSync.ctx)
+	w := NewStreamingResponseWriterRecorder(testSync.ctx)
+	go func() {
+		s.StreamEvents(w, request)
+		testSync.markDone()
+	}()
+
+	arrival := time.UnixMilli(1700000000123)
+	require.NoError(t, stn.WaitForSubscription(testSync.ctx))
+	require.NoError(t, opn.WaitForSubscription(testSync.ctx))
+	s.StateNotifier.StateFeed().Send(&feed.Event{
+		Type: statefeed.ValidatorLifecycle,
+		Data: &statefeed.ValidatorLifecycleData{
+			Epoch: 3,
+			Transitions: []*statefeed.ValidatorLifecycleChange{
+				{Index: 1, Transition: statefeed.Activated},
+				{Index: 2, Transition: statefeed.Exited},
+				{Index: 5, Transition: statefeed.Slashed},
+			},
+		},
+	})
+	// Neither the lifecycle event nor the block of an unrequested validator is sent.
+	s.StateNotifier.StateFeed().Send(&feed.Event{
+		Type: statefeed.ValidatorLifecycle,
+		Data: &statefeed.ValidatorLifecycleData{
+			Epoch:       4,
+			Transitions: []*statefeed.ValidatorLifecycleChange{{Index: 1, Transition: statefeed.Withdrawable}},
+		},
+	})
+	s.OperationNotifier.OperationFeed().Send(&feed.Event{
+		Type: operation.BlockGossipReceived,
+		Data: &operation.BlockGossipReceivedData{Slot: 9, ProposerIndex: 3, ArrivalTime: arrival},
+	})
+	s.OperationNotifier.OperationFeed().Send(&feed.Event{
+		Type: operation.BlockGossipReceived,
+		Data: &operation.BlockGossipReceivedData{Slot: 10, ProposerIndex: 5, BlockRoot: [32]byte{'a'}, ArrivalTime: arrival},
+	})
+
+	pubkey := hexutil.Encode(make([]byte, 48))
+	want := []string{
+		`event: validator_lifecycle` + "\n" + `data: {"epoch":"3","validator_index":"2","pubkey":"` + pubkey + `","transition":"exited"}`,
+		`event: validator_lifecycle` + "\n" + `data: {"epoch":"3","validator_index":"5","pubkey":"` + pubkey + `","transition":"slashed"}`,
+		`event: block_gossip` + "\n" + `data: {"slot":"10","block":"` + hexutil.Encode(bytesutil.PadTo([]byte{'a'}, 32)) + `","proposer_index":"5","arrival_time":"1700000000123"}`,
+	}
+	sseR := sse.NewEventStreamReader(w.Body(), 1<<24)
+	for _, expected := range want {
+		for {
+			ev, err := sseR.ReadEvent()
+			require.NoError(t, err)
+			if !strings.HasPrefix(string(ev), ":") {
+				require.Equal(t, expected, string(ev))
+				break
+			}
+		}
+	}
+}
+
+func TestStreamEvents_InvalidValidatorFilter(t *testing.T) {
+	s := &Server{}
+	request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/events?topics=block_gossip&validator_indices=1,x", nil)
+	w := httptest.NewRecorder()
+	s.StreamEvents(w, request)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.StringContains(t, "invalid validator index", w.Body.String())
+}
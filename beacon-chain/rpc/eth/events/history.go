@@ -0,0 +1,200 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
+	opfeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/operation"
+	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/v5/crypto/rand"
+)
+
+// DefaultEventHistoryDepth is the number of recent events of each topic kept for replay.
+const DefaultEventHistoryDepth = 256
+
+// eventRecord is a feed event with the ID it is sent to clients with.
+type eventRecord struct {
+	id    uint64
+	topic string
+	event *feed.Event
+}
+
+// eventRing holds the most recent records of a topic, oldest first from start.
+type eventRing struct {
+	records []*eventRecord
+	start   int
+}
+
+func (r *eventRing) add(rec *eventRecord) {
+	if len(r.records) < cap(r.records) {
+		r.records = append(r.records, rec)
+		return
+	}
+	r.records[r.start] = rec
+	r.start = (r.start + 1) % len(r.records)
+}
+
+func (r *eventRing) after(id uint64, out []*eventRecord) []*eventRecord {
+	for i := range r.records {
+		rec := r.records[(r.start+i)%len(r.records)]
+		if rec.id > id {
+			out = append(out, rec)
+		}
+	}
+	return out
+}
+
+// historySubscription receives the records of new events. Subscribers which do not keep up are dropped, rather
+// than holding up the state and operation feeds.
+type historySubscription struct {
+	h       *EventHistory
+	req     *topicRequest
+	records chan *eventRecord
+	dropped chan struct{}
+}
+
+func (s *historySubscription) unsubscribe() {
+	s.h.lock.Lock()
+	defer s.h.lock.Unlock()
+	delete(s.h.subs, s)
+}
+
+// EventHistory numbers the events of the state and operation feeds and keeps the most recent events of each topic,
+// so that clients reconnecting to the event stream with a Last-Event-ID header can be sent the events they missed.
+// Payload attributes are computed from the head at the time they are sent, so they are never replayed.
+// Event IDs are prefixed with a nonce picked at startup, as the numbering starts over when the node restarts.
+type EventHistory struct {
+	depth  int
+	nonce  string
+	lock   sync.Mutex
+	lastID uint64
+	rings  map[string]*eventRing
+	subs   map[*historySubscription]struct{}
+}
+
+// NewEventHistory returns an event history keeping up to depth events of each topic.
+func NewEventHistory(depth int) *EventHistory {
+	if depth <= 0 {
+		depth = DefaultEventHistoryDepth
+	}
+	return &EventHistory{
+		depth: depth,
+		nonce: strconv.FormatUint(rand.NewGenerator().Uint64(), 36),
+		rings: make(map[string]*eventRing),
+		subs:  make(map[*historySubscription]struct{}),
+	}
+}
+
+// eventID returns the ID an event record is sent to clients with.
+func (h *EventHistory) eventID(id uint64) string {
+	return fmt.Sprintf("%s-%d", h.nonce, id)
+}
+
+// parseEventID returns the number of the event with the given ID. IDs given out before the node restarted are
+// rejected with errStaleLastEventID, as their numbers refer to events which are no longer kept.
+func (h *EventHistory) parseEventID(id string) (uint64, error) {
+	nonce, n, ok := strings.Cut(id, "-")
+	if !ok {
+		return 0, errors.Wrap(errInvalidLastEventID, "missing nonce")
+	}
+	num, err := strconv.ParseUint(n, 10, 64)
+	if err != nil {
+		return 0, errors.Wrap(errInvalidLastEventID, err.Error())
+	}
+	if nonce != h.nonce {
+		return 0, errStaleLastEventID
+	}
+	return num, nil
+}
+
+// Run records the events of the state and operation feeds until the context is canceled.
+func (h *EventHistory) Run(ctx context.Context, stateNotifier statefeed.Notifier, opsNotifier opfeed.Notifier) {
+	eventsChan := make(chan *feed.Event, DefaultEventFeedDepth)
+	if stateNotifier != nil {
+		stateSub := stateNotifier.StateFeed().Subscribe(eventsChan)
+		defer stateSub.Unsubscribe()
+	}
+	if opsNotifier != nil {
+		opsSub := opsNotifier.OperationFeed().Subscribe(eventsChan)
+		defer opsSub.Unsubscribe()
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ev := <-eventsChan:
+			h.record(ev)
+		}
+	}
+}
+
+// record assigns the next ID to the event, keeps it in the history of its topic and sends it to the streams subscribed
+// to the topic. It never blocks: a stream whose buffer is full is dropped, and has to reconnect to be sent the events
+// it missed.
+func (h *EventHistory) record(ev *feed.Event) {
+	if ev == nil {
+		return
+	}
+	topic := topicForEvent(ev)
+	if topic == InvalidTopic {
+		return
+	}
+	h.lock.Lock()
+	h.lastID++
+	rec := &eventRecord{id: h.lastID, topic: topic, event: ev}
+	if topic != PayloadAttributesTopic {
+		ring, ok := h.rings[topic]
+		if !ok {
+			ring = &eventRing{records: make([]*eventRecord, 0, h.depth)}
+			h.rings[topic] = ring
+		}
+		ring.add(rec)
+	}
+	for sub := range h.subs {
+		if !sub.req.requested(topic) {
+			continue
+		}
+		select {
+		case sub.records <- rec:
+		default:
+			delete(h.subs, sub)
+			close(sub.dropped)
+		}
+	}
+	h.lock.Unlock()
+}
+
+// subscribe returns a subscription to the records of new events of the requested topics, buffering up to size
+// records. If replay is set, it also returns the kept records of the requested topics with an ID greater than lastID,
+// in ID order, and the subscription buffers as many more records: new events keep arriving while the returned records
+// are sent, and the subscription must not be dropped before the client has been sent its replay. Records which are
+// both returned and sent to the subscription have an ID no greater than the last returned one.
+func (h *EventHistory) subscribe(size int, req *topicRequest, lastID uint64, replay bool) (*historySubscription, []*eventRecord) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	var missed []*eventRecord
+	if replay {
+		for topic, ring := range h.rings {
+			if req.requested(topic) {
+				missed = ring.after(lastID, missed)
+			}
+		}
+		sort.Slice(missed, func(i, j int) bool {
+			return missed[i].id < missed[j].id
+		})
+	}
+	sub := &historySubscription{
+		h:       h,
+		req:     req,
+		records: make(chan *eventRecord, size+len(missed)),
+		dropped: make(chan struct{}),
+	}
+	h.subs[sub] = struct{}{}
+	return sub, missed
+}
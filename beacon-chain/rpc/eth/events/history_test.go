@@ -0,0 +1,90 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/eth/v1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestEventHistory(t *testing.T) {
+	h := NewEventHistory(3)
+	for slot := primitives.Slot(1); slot <= 5; slot++ {
+		h.record(&feed.Event{Type: statefeed.NewHead, Data: &ethpb.EventHead{Slot: slot}})
+		h.record(&feed.Event{Type: statefeed.MissedSlot})
+	}
+	h.record(&feed.Event{Type: statefeed.FinalizedCheckpoint, Data: &ethpb.EventFinalizedCheckpoint{Epoch: 1}})
+	h.record(&feed.Event{Type: statefeed.Reorg, Data: "not an event"})
+	require.Equal(t, uint64(11), h.lastID)
+
+	ids := func(records []*eventRecord) []uint64 {
+		out := make([]uint64, len(records))
+		for i, rec := range records {
+			out[i] = rec.id
+		}
+		return out
+	}
+	req, err := newTopicRequest([]string{HeadTopic, FinalizedCheckpointTopic, PayloadAttributesTopic})
+	require.NoError(t, err)
+	sub, missed := h.subscribe(1, req, 0, true)
+	defer sub.unsubscribe()
+	// Only the last 3 head events are kept, and payload attributes are never kept.
+	require.DeepEqual(t, []uint64{5, 7, 9, 11}, ids(missed))
+	// The subscription buffers the new events arriving while the missed ones are sent.
+	require.Equal(t, 5, cap(sub.records))
+	sub2, missed := h.subscribe(1, req, 7, true)
+	defer sub2.unsubscribe()
+	require.DeepEqual(t, []uint64{9, 11}, ids(missed))
+	sub3, missed := h.subscribe(1, req, 7, false)
+	sub3.unsubscribe()
+	require.Equal(t, 0, len(missed))
+	require.Equal(t, 1, cap(sub3.records))
+	require.Equal(t, 2, len(h.subs))
+}
+
+func TestEventHistory_DropsSlowSubscriber(t *testing.T) {
+	h := NewEventHistory(0)
+	req, err := newTopicRequest([]string{HeadTopic})
+	require.NoError(t, err)
+	slow, _ := h.subscribe(1, req, 0, false)
+	fast, _ := h.subscribe(2, req, 0, false)
+	defer fast.unsubscribe()
+	// Subscriptions are only sent the events of their topics.
+	finalizedReq, err := newTopicRequest([]string{FinalizedCheckpointTopic})
+	require.NoError(t, err)
+	other, _ := h.subscribe(1, finalizedReq, 0, false)
+	defer other.unsubscribe()
+
+	// Recording never blocks on a full subscription, which is dropped instead.
+	h.record(&feed.Event{Type: statefeed.NewHead, Data: &ethpb.EventHead{Slot: 1}})
+	h.record(&feed.Event{Type: statefeed.NewHead, Data: &ethpb.EventHead{Slot: 2}})
+	select {
+	case <-slow.dropped:
+	default:
+		t.Fatal("Slow subscription was not dropped")
+	}
+	require.Equal(t, 1, len(slow.records))
+	require.Equal(t, 2, len(fast.records))
+	require.Equal(t, 0, len(other.records))
+	require.Equal(t, 2, len(h.subs))
+	// Unsubscribing a dropped subscription is harmless.
+	slow.unsubscribe()
+}
+
+func TestEventHistory_EventID(t *testing.T) {
+	h := NewEventHistory(0)
+	id, err := h.parseEventID(h.eventID(42))
+	require.NoError(t, err)
+	require.Equal(t, uint64(42), id)
+
+	// IDs given out before a restart are rejected.
+	_, err = NewEventHistory(0).parseEventID(h.eventID(42))
+	require.ErrorIs(t, err, errStaleLastEventID)
+	for _, invalid := range []string{"42", h.nonce + "-abc", ""} {
+		_, err = h.parseEventID(invalid)
+		require.ErrorIs(t, err, errInvalidLastEventID)
+	}
+}
@@ -23,4 +23,6 @@ type Server struct {
 	KeepAliveInterval      time.Duration
 	EventFeedDepth         int
 	EventWriteTimeout      time.Duration
+	// History, if set, numbers the streamed events and replays missed events to reconnecting clients.
+	History *EventHistory
 }
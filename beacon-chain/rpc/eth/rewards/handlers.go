@@ -7,9 +7,12 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/altair"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/epoch/precompute"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/rewardsummary"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/shared"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
@@ -70,25 +73,32 @@ func (s *Server) BlockRewards(w http.ResponseWriter, r *http.Request) {
 
 // AttestationRewards retrieves attestation reward info for validators specified by array of public keys or validator index.
 // If no array is provided, return reward info for every validator.
+// Rewards saved during epoch processing are served when available, otherwise they are computed from the state.
 func (s *Server) AttestationRewards(w http.ResponseWriter, r *http.Request) {
-	st, ok := s.attRewardsState(w, r)
+	ctx, span := trace.StartSpan(r.Context(), "beacon.AttestationRewards")
+	defer span.End()
+	epoch, ok := s.attRewardsEpoch(w, r)
 	if !ok {
 		return
 	}
-	bal, vals, valIndices, ok := attRewardsBalancesAndVals(w, r, st)
-	if !ok {
+	if s.savedAttestationRewards(ctx, w, r, epoch) {
 		return
 	}
-	totalRewards, ok := totalAttRewards(w, st, bal, vals, valIndices)
+	st, ok := s.attRewardsState(w, r, epoch)
 	if !ok {
 		return
 	}
-	idealRewards, ok := idealAttRewards(w, st, bal, vals)
+	rewards, ideal, err := rewardsummary.AttestationRewards(ctx, st)
+	if err != nil {
+		httputil.HandleError(w, "Could not compute attestation rewards: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	valIndices, ok := requestedValIndices(w, r, st, len(rewards))
 	if !ok {
 		return
 	}
 
-	optimistic, err := s.OptimisticModeFetcher.IsOptimistic(r.Context())
+	optimistic, err := s.OptimisticModeFetcher.IsOptimistic(ctx)
 	if err != nil {
 		httputil.HandleError(w, "Could not get optimistic mode info: "+err.Error(), http.StatusInternalServerError)
 		return
@@ -99,19 +109,15 @@ func (s *Server) AttestationRewards(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp := &structs.AttestationRewardsResponse{
-		Data: structs.AttestationRewards{
-			IdealRewards: idealRewards,
-			TotalRewards: totalRewards,
-		},
+	writeAttRewards(w, rewards, ideal, valIndices, &attRewardsMetadata{
 		ExecutionOptimistic: optimistic,
-		Finalized:           s.FinalizationFetcher.IsFinalized(r.Context(), blkRoot),
-	}
-	httputil.WriteJson(w, resp)
+		Finalized:           s.FinalizationFetcher.IsFinalized(ctx, blkRoot),
+	})
 }
 
 // SyncCommitteeRewards retrieves rewards info for sync committee members specified by array of public keys or validator index.
 // If no array is provided, return reward info for every committee member.
+// Rewards saved during epoch processing are served when available, otherwise they are computed from the state.
 func (s *Server) SyncCommitteeRewards(w http.ResponseWriter, r *http.Request) {
 	ctx, span := trace.StartSpan(r.Context(), "beacon.SyncCommitteeRewards")
 	defer span.End()
@@ -126,9 +132,15 @@ func (s *Server) SyncCommitteeRewards(w http.ResponseWriter, r *http.Request) {
 		httputil.HandleError(w, "Sync committee rewards are not supported for Phase 0", http.StatusBadRequest)
 		return
 	}
+	if s.savedSyncCommitteeRewards(ctx, w, r, blk) {
+		return
+	}
 
 	st, httpErr := s.BlockRewardFetcher.GetStateForRewards(ctx, blk.Block())
 	if httpErr != nil {
+		if hint, ok := s.savedRewardsHint(ctx, syncCommitteeRewardsKind); ok {
+			httpErr.Message += ". " + hint
+		}
 		httputil.WriteError(w, httpErr)
 		return
 	}
@@ -138,39 +150,21 @@ func (s *Server) SyncCommitteeRewards(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	vals, valIndices, ok := syncRewardsVals(w, r, st)
+	_, valIndices, ok := syncRewardsVals(w, r, st)
 	if !ok {
 		return
 	}
-	preProcessBals := make([]uint64, len(vals))
-	for i, valIdx := range valIndices {
-		preProcessBals[i], err = st.BalanceAtIndex(valIdx)
-		if err != nil {
-			httputil.HandleError(w, "Could not get validator's balance: "+err.Error(), http.StatusInternalServerError)
-			return
-		}
+	committeeRewards, err := rewardsummary.CurrentSyncCommitteeRewards(st)
+	if err != nil {
+		httputil.HandleError(w, "Could not get sync committee rewards: "+err.Error(), http.StatusInternalServerError)
+		return
 	}
-
-	_, proposerReward, err := altair.ProcessSyncAggregate(r.Context(), st, sa)
+	memberRewards, _, err := syncAggregateRewards(committeeRewards, sa.SyncCommitteeBits, st.BalanceAtIndex)
 	if err != nil {
 		httputil.HandleError(w, "Could not get sync aggregate rewards: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	rewards := make([]int, len(preProcessBals))
-	proposerIndex := blk.Block().ProposerIndex()
-	for i, valIdx := range valIndices {
-		bal, err := st.BalanceAtIndex(valIdx)
-		if err != nil {
-			httputil.HandleError(w, "Could not get validator's balance: "+err.Error(), http.StatusInternalServerError)
-			return
-		}
-		rewards[i] = int(bal - preProcessBals[i]) // lint:ignore uintcast
-		if valIdx == proposerIndex {
-			rewards[i] = rewards[i] - int(proposerReward) // lint:ignore uintcast
-		}
-	}
-
 	optimistic, err := s.OptimisticModeFetcher.IsOptimistic(r.Context())
 	if err != nil {
 		httputil.HandleError(w, "Could not get optimistic mode info: "+err.Error(), http.StatusInternalServerError)
@@ -186,7 +180,7 @@ func (s *Server) SyncCommitteeRewards(w http.ResponseWriter, r *http.Request) {
 	for i, valIdx := range valIndices {
 		scRewards[i] = structs.SyncCommitteeReward{
 			ValidatorIndex: strconv.FormatUint(uint64(valIdx), 10),
-			Reward:         strconv.Itoa(rewards[i]),
+			Reward:         strconv.FormatInt(memberRewards[valIdx], 10),
 		}
 	}
 	response := &structs.SyncCommitteeRewardsResponse{
@@ -197,162 +191,88 @@ func (s *Server) SyncCommitteeRewards(w http.ResponseWriter, r *http.Request) {
 	httputil.WriteJson(w, response)
 }
 
-func (s *Server) attRewardsState(w http.ResponseWriter, r *http.Request) (state.BeaconState, bool) {
+func (s *Server) attRewardsEpoch(w http.ResponseWriter, r *http.Request) (primitives.Epoch, bool) {
 	segments := strings.Split(r.URL.Path, "/")
 	requestedEpoch, err := strconv.ParseUint(segments[len(segments)-1], 10, 64)
 	if err != nil {
 		httputil.HandleError(w, "Could not decode epoch: "+err.Error(), http.StatusBadRequest)
-		return nil, false
+		return 0, false
 	}
 	if primitives.Epoch(requestedEpoch) < params.BeaconConfig().AltairForkEpoch {
 		httputil.HandleError(w, "Attestation rewards are not supported for Phase 0", http.StatusNotFound)
-		return nil, false
+		return 0, false
 	}
 	currentEpoch := uint64(slots.ToEpoch(s.TimeFetcher.CurrentSlot()))
 	if requestedEpoch+1 >= currentEpoch {
 		httputil.HandleError(w,
 			"Attestation rewards are available after two epoch transitions to ensure all attestations have a chance of inclusion",
 			http.StatusNotFound)
-		return nil, false
+		return 0, false
 	}
-	nextEpochEnd, err := slots.EpochEnd(primitives.Epoch(requestedEpoch + 1))
+	return primitives.Epoch(requestedEpoch), true
+}
+
+func (s *Server) attRewardsState(w http.ResponseWriter, r *http.Request, epoch primitives.Epoch) (state.BeaconState, bool) {
+	nextEpochEnd, err := slots.EpochEnd(epoch + 1)
 	if err != nil {
 		httputil.HandleError(w, "Could not get next epoch's ending slot: "+err.Error(), http.StatusInternalServerError)
 		return nil, false
 	}
 	st, err := s.Stater.StateBySlot(r.Context(), nextEpochEnd)
 	if err != nil {
-		httputil.HandleError(w, "Could not get state for epoch's starting slot: "+err.Error(), http.StatusInternalServerError)
+		msg := "Could not get state for epoch's starting slot: " + err.Error()
+		if hint, ok := s.savedRewardsHint(r.Context(), attestationRewardsKind); ok {
+			httputil.HandleError(w, msg+". "+hint, http.StatusNotFound)
+			return nil, false
+		}
+		httputil.HandleError(w, msg, http.StatusInternalServerError)
 		return nil, false
 	}
 	return st, true
 }
 
-func attRewardsBalancesAndVals(
-	w http.ResponseWriter,
-	r *http.Request,
-	st state.BeaconState,
-) (*precompute.Balance, []*precompute.Validator, []primitives.ValidatorIndex, bool) {
-	allVals, bal, err := altair.InitializePrecomputeValidators(r.Context(), st)
-	if err != nil {
-		httputil.HandleError(w, "Could not initialize precompute validators: "+err.Error(), http.StatusBadRequest)
-		return nil, nil, nil, false
-	}
-	allVals, bal, err = altair.ProcessEpochParticipation(r.Context(), st, bal, allVals)
-	if err != nil {
-		httputil.HandleError(w, "Could not process epoch participation: "+err.Error(), http.StatusBadRequest)
-		return nil, nil, nil, false
-	}
-	valIndices, ok := requestedValIndices(w, r, st, allVals)
-	if !ok {
-		return nil, nil, nil, false
-	}
-	if len(valIndices) == len(allVals) {
-		return bal, allVals, valIndices, true
-	} else {
-		filteredVals := make([]*precompute.Validator, len(valIndices))
-		for i, valIx := range valIndices {
-			filteredVals[i] = allVals[valIx]
-		}
-		return bal, filteredVals, valIndices, true
-	}
-}
-
-// idealAttRewards returns rewards for hypothetical, perfectly voting validators
-// whose effective balances are over EJECTION_BALANCE and match balances in passed in validators.
-func idealAttRewards(
-	w http.ResponseWriter,
-	st state.BeaconState,
-	bal *precompute.Balance,
-	vals []*precompute.Validator,
-) ([]structs.IdealAttestationReward, bool) {
-	idealValsCount := uint64(16)
-	minIdealBalance := uint64(17)
-	maxIdealBalance := minIdealBalance + idealValsCount - 1
-	idealRewards := make([]structs.IdealAttestationReward, 0, idealValsCount)
-	idealVals := make([]*precompute.Validator, 0, idealValsCount)
-	increment := params.BeaconConfig().EffectiveBalanceIncrement
-	for i := minIdealBalance; i <= maxIdealBalance; i++ {
-		for _, v := range vals {
-			if v.CurrentEpochEffectiveBalance/1e9 == i {
-				effectiveBalance := i * increment
-				idealVals = append(idealVals, &precompute.Validator{
-					IsActivePrevEpoch:            true,
-					IsSlashed:                    false,
-					CurrentEpochEffectiveBalance: effectiveBalance,
-					IsPrevEpochSourceAttester:    true,
-					IsPrevEpochTargetAttester:    true,
-					IsPrevEpochHeadAttester:      true,
-				})
-				idealRewards = append(idealRewards, structs.IdealAttestationReward{
-					EffectiveBalance: strconv.FormatUint(effectiveBalance, 10),
-					Inactivity:       strconv.FormatUint(0, 10),
-				})
-				break
+// syncAggregateRewards returns the rewards of the sync committee members for the sync aggregate of a block, from the
+// sync committee rewards of its epoch and the balances of the members before the block. The rewards and penalties of
+// each position in the committee are applied in order as in process_sync_aggregate, so that penalties do not take a
+// balance below zero. The proposer's reward for including the aggregate is not a sync committee reward and is left
+// out. It also returns whether a penalty was reduced by a balance reaching zero.
+func syncAggregateRewards(
+	committeeRewards *rewardsummary.SyncCommitteeRewards,
+	bits bitfield.Bitfield,
+	balanceAt func(primitives.ValidatorIndex) (uint64, error),
+) (map[primitives.ValidatorIndex]int64, bool, error) {
+	if bits.Len() > uint64(len(committeeRewards.Members)) {
+		return nil, false, errors.New("sync aggregate bits length exceeds committee length")
+	}
+	reward := committeeRewards.ParticipantReward
+	initial := make(map[primitives.ValidatorIndex]uint64)
+	balances := make(map[primitives.ValidatorIndex]uint64)
+	floored := false
+	for i := uint64(0); i < bits.Len(); i++ {
+		idx := committeeRewards.Members[i]
+		if _, ok := initial[idx]; !ok {
+			bal, err := balanceAt(idx)
+			if err != nil {
+				return nil, false, errors.Wrapf(err, "could not get balance of validator %d", idx)
 			}
+			initial[idx] = bal
+			balances[idx] = bal
 		}
-	}
-	deltas, err := altair.AttestationsDelta(st, bal, idealVals)
-	if err != nil {
-		httputil.HandleError(w, "Could not get attestations delta: "+err.Error(), http.StatusInternalServerError)
-		return nil, false
-	}
-	for i, d := range deltas {
-		idealRewards[i].Head = strconv.FormatUint(d.HeadReward, 10)
-		if d.SourcePenalty > 0 {
-			idealRewards[i].Source = fmt.Sprintf("-%s", strconv.FormatUint(d.SourcePenalty, 10))
-		} else {
-			idealRewards[i].Source = strconv.FormatUint(d.SourceReward, 10)
-		}
-		if d.TargetPenalty > 0 {
-			idealRewards[i].Target = fmt.Sprintf("-%s", strconv.FormatUint(d.TargetPenalty, 10))
-		} else {
-			idealRewards[i].Target = strconv.FormatUint(d.TargetReward, 10)
-		}
-		if d.InactivityPenalty > 0 {
-			idealRewards[i].Inactivity = fmt.Sprintf("-%s", strconv.FormatUint(d.InactivityPenalty, 10))
-		} else {
-			idealRewards[i].Inactivity = strconv.FormatUint(d.InactivityPenalty, 10)
+		switch {
+		case bits.BitAt(i):
+			balances[idx] += reward
+		case balances[idx] < reward:
+			balances[idx] = 0
+			floored = true
+		default:
+			balances[idx] -= reward
 		}
 	}
-	return idealRewards, true
-}
-
-func totalAttRewards(
-	w http.ResponseWriter,
-	st state.BeaconState,
-	bal *precompute.Balance,
-	vals []*precompute.Validator,
-	valIndices []primitives.ValidatorIndex,
-) ([]structs.TotalAttestationReward, bool) {
-	totalRewards := make([]structs.TotalAttestationReward, len(valIndices))
-	for i, v := range valIndices {
-		totalRewards[i] = structs.TotalAttestationReward{ValidatorIndex: strconv.FormatUint(uint64(v), 10)}
-	}
-	deltas, err := altair.AttestationsDelta(st, bal, vals)
-	if err != nil {
-		httputil.HandleError(w, "Could not get attestations delta: "+err.Error(), http.StatusInternalServerError)
-		return nil, false
-	}
-	for i, d := range deltas {
-		totalRewards[i].Head = strconv.FormatUint(d.HeadReward, 10)
-		if d.SourcePenalty > 0 {
-			totalRewards[i].Source = fmt.Sprintf("-%s", strconv.FormatUint(d.SourcePenalty, 10))
-		} else {
-			totalRewards[i].Source = strconv.FormatUint(d.SourceReward, 10)
-		}
-		if d.TargetPenalty > 0 {
-			totalRewards[i].Target = fmt.Sprintf("-%s", strconv.FormatUint(d.TargetPenalty, 10))
-		} else {
-			totalRewards[i].Target = strconv.FormatUint(d.TargetReward, 10)
-		}
-		if d.InactivityPenalty > 0 {
-			totalRewards[i].Inactivity = fmt.Sprintf("-%s", strconv.FormatUint(d.InactivityPenalty, 10))
-		} else {
-			totalRewards[i].Inactivity = strconv.FormatUint(d.InactivityPenalty, 10)
-		}
+	rewards := make(map[primitives.ValidatorIndex]int64, len(balances))
+	for idx, bal := range balances {
+		rewards[idx] = int64(bal) - int64(initial[idx]) // lint:ignore uintcast -- Balances are far below the max int64.
 	}
-	return totalRewards, true
+	return rewards, floored, nil
 }
 
 func syncRewardsVals(
@@ -365,7 +285,7 @@ func syncRewardsVals(
 		httputil.HandleError(w, "Could not initialize precompute validators: "+err.Error(), http.StatusBadRequest)
 		return nil, nil, false
 	}
-	valIndices, ok := requestedValIndices(w, r, st, allVals)
+	valIndices, ok := requestedValIndices(w, r, st, len(allVals))
 	if !ok {
 		return nil, nil, false
 	}
@@ -400,7 +320,9 @@ func syncRewardsVals(
 	return scVals, scIndices, true
 }
 
-func requestedValIndices(w http.ResponseWriter, r *http.Request, st state.BeaconState, allVals []*precompute.Validator) ([]primitives.ValidatorIndex, bool) {
+// requestedValIndices returns the indices of the validators requested by index or public key, or of every validator
+// if none were requested. Public keys are looked up in st, and indices must be lower than numVals.
+func requestedValIndices(w http.ResponseWriter, r *http.Request, st state.ReadOnlyBeaconState, numVals int) ([]primitives.ValidatorIndex, bool) {
 	var rawValIds []string
 	if r.Body != http.NoBody {
 		if err := json.NewDecoder(r.Body).Decode(&rawValIds); err != nil {
@@ -419,21 +341,21 @@ func requestedValIndices(w http.ResponseWriter, r *http.Request, st state.Beacon
 			}
 			var ok bool
 			valIndices[i], ok = st.ValidatorIndexByPubkey(bytesutil.ToBytes48(pubkey))
-			if !ok {
+			if !ok || uint64(valIndices[i]) >= uint64(numVals) {
 				httputil.HandleError(w, fmt.Sprintf("No validator index found for pubkey %#x", pubkey), http.StatusBadRequest)
 				return nil, false
 			}
 		} else {
-			if index >= uint64(st.NumValidators()) {
-				httputil.HandleError(w, fmt.Sprintf("Validator index %d is too large. Maximum allowed index is %d", index, st.NumValidators()-1), http.StatusBadRequest)
+			if index >= uint64(numVals) {
+				httputil.HandleError(w, fmt.Sprintf("Validator index %d is too large. Maximum allowed index is %d", index, numVals-1), http.StatusBadRequest)
 				return nil, false
 			}
 			valIndices[i] = primitives.ValidatorIndex(index)
 		}
 	}
 	if len(valIndices) == 0 {
-		valIndices = make([]primitives.ValidatorIndex, len(allVals))
-		for i := 0; i < len(allVals); i++ {
+		valIndices = make([]primitives.ValidatorIndex, numVals)
+		for i := 0; i < numVals; i++ {
 			valIndices[i] = primitives.ValidatorIndex(i)
 		}
 	}
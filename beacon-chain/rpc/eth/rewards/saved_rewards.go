@@ -0,0 +1,204 @@
+package rewards
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/rewardsummary"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+type rewardsKind int
+
+const (
+	attestationRewardsKind rewardsKind = iota
+	syncCommitteeRewardsKind
+)
+
+// savedAttestationRewards writes the attestation rewards of an epoch saved during epoch processing, if there are
+// any. It returns false if nothing was written, in which case the rewards must be computed from the state.
+func (s *Server) savedAttestationRewards(ctx context.Context, w http.ResponseWriter, r *http.Request, epoch primitives.Epoch) bool {
+	if s.BeaconDB == nil {
+		return false
+	}
+	rewards, ideal, err := s.BeaconDB.AttestationRewards(ctx, epoch)
+	if errors.Is(err, db.ErrNotFoundRewards) {
+		return false
+	}
+	if err != nil {
+		httputil.HandleError(w, "Could not get saved attestation rewards: "+err.Error(), http.StatusInternalServerError)
+		return true
+	}
+	// Validator indices never change, so public keys can be looked up in the head state.
+	st, err := s.HeadFetcher.HeadStateReadOnly(ctx)
+	if err != nil {
+		httputil.HandleError(w, "Could not get head state: "+err.Error(), http.StatusInternalServerError)
+		return true
+	}
+	valIndices, ok := requestedValIndices(w, r, st, len(rewards))
+	if !ok {
+		return true
+	}
+	optimistic, err := s.OptimisticModeFetcher.IsOptimistic(ctx)
+	if err != nil {
+		httputil.HandleError(w, "Could not get optimistic mode info: "+err.Error(), http.StatusInternalServerError)
+		return true
+	}
+	writeAttRewards(w, rewards, ideal, valIndices, &attRewardsMetadata{
+		ExecutionOptimistic: optimistic,
+		// The rewards are computed from the last state of the next epoch.
+		Finalized: s.FinalizationFetcher.FinalizedCheckpt().Epoch > epoch+1,
+	})
+	return true
+}
+
+// savedSyncCommitteeRewards writes the sync committee rewards of a block, computed from the sync committee rewards
+// of its epoch saved during epoch processing, if there are any. The balances of the members are read from the head
+// state, so the rewards are only written if no penalty is reduced by a balance reaching zero, which depends on the
+// balances before the block. It returns false if nothing was written, in which case the rewards must be computed
+// from the state.
+func (s *Server) savedSyncCommitteeRewards(ctx context.Context, w http.ResponseWriter, r *http.Request, blk interfaces.ReadOnlySignedBeaconBlock) bool {
+	if s.BeaconDB == nil {
+		return false
+	}
+	saved, err := s.BeaconDB.SyncCommitteeRewards(ctx, slots.ToEpoch(blk.Block().Slot()))
+	if errors.Is(err, db.ErrNotFoundRewards) {
+		return false
+	}
+	if err != nil {
+		httputil.HandleError(w, "Could not get saved sync committee rewards: "+err.Error(), http.StatusInternalServerError)
+		return true
+	}
+	st, err := s.HeadFetcher.HeadStateReadOnly(ctx)
+	if err != nil {
+		httputil.HandleError(w, "Could not get head state: "+err.Error(), http.StatusInternalServerError)
+		return true
+	}
+	valIndices, ok := requestedValIndices(w, r, st, st.NumValidators())
+	if !ok {
+		return true
+	}
+	sa, err := blk.Block().Body().SyncAggregate()
+	if err != nil {
+		httputil.HandleError(w, "Could not get sync aggregate: "+err.Error(), http.StatusInternalServerError)
+		return true
+	}
+	memberRewards, floored, err := syncAggregateRewards(saved, sa.SyncCommitteeBits, st.BalanceAtIndex)
+	if err != nil {
+		httputil.HandleError(w, "Could not get sync aggregate rewards: "+err.Error(), http.StatusInternalServerError)
+		return true
+	}
+	if floored {
+		return false
+	}
+	scRewards := make([]structs.SyncCommitteeReward, 0, len(memberRewards))
+	for _, valIdx := range valIndices {
+		if reward, ok := memberRewards[valIdx]; ok {
+			scRewards = append(scRewards, structs.SyncCommitteeReward{
+				ValidatorIndex: strconv.FormatUint(uint64(valIdx), 10),
+				Reward:         strconv.FormatInt(reward, 10),
+			})
+		}
+	}
+
+	optimistic, err := s.OptimisticModeFetcher.IsOptimistic(ctx)
+	if err != nil {
+		httputil.HandleError(w, "Could not get optimistic mode info: "+err.Error(), http.StatusInternalServerError)
+		return true
+	}
+	blkRoot, err := blk.Block().HashTreeRoot()
+	if err != nil {
+		httputil.HandleError(w, "Could not get block root: "+err.Error(), http.StatusInternalServerError)
+		return true
+	}
+	httputil.WriteJson(w, &structs.SyncCommitteeRewardsResponse{
+		Data:                scRewards,
+		ExecutionOptimistic: optimistic,
+		Finalized:           s.FinalizationFetcher.IsFinalized(ctx, blkRoot),
+	})
+	return true
+}
+
+// savedRewardsHint returns a message giving the earliest epoch whose rewards are saved, to explain that rewards of
+// older epochs are missing. It returns false if no rewards are saved.
+func (s *Server) savedRewardsHint(ctx context.Context, kind rewardsKind) (string, bool) {
+	if s.BeaconDB == nil {
+		return "", false
+	}
+	var (
+		r   *rewardsummary.EpochRange
+		err error
+	)
+	name := "Attestation"
+	if kind == syncCommitteeRewardsKind {
+		name = "Sync committee"
+		r, err = s.BeaconDB.SyncCommitteeRewardsRange(ctx)
+	} else {
+		r, err = s.BeaconDB.AttestationRewardsRange(ctx)
+	}
+	if err != nil {
+		return "", false
+	}
+	return fmt.Sprintf("%s rewards are available from epoch %d", name, r.Earliest), true
+}
+
+// attRewardsMetadata holds the fields of the attestation rewards response written before its data.
+type attRewardsMetadata struct {
+	ExecutionOptimistic bool `json:"execution_optimistic"`
+	Finalized           bool `json:"finalized"`
+}
+
+// attRewardsDataMetadata holds the fields of the attestation rewards data written before the total rewards.
+type attRewardsDataMetadata struct {
+	IdealRewards []structs.IdealAttestationReward `json:"ideal_rewards"`
+}
+
+// writeAttRewards writes the total rewards of the requested validators, and the ideal rewards of their effective
+// balances. The total rewards are streamed rather than collected, as there can be millions of them.
+func writeAttRewards(
+	w http.ResponseWriter,
+	rewards []rewardsummary.AttestationReward,
+	ideal []rewardsummary.IdealAttestationReward,
+	valIndices []primitives.ValidatorIndex,
+	md *attRewardsMetadata,
+) {
+	balances := make(map[uint64]bool)
+	for _, idx := range valIndices {
+		balances[rewards[idx].EffectiveBalance/1e9] = true
+	}
+	idealRewards := make([]structs.IdealAttestationReward, 0, len(ideal))
+	for _, r := range ideal {
+		if !balances[r.EffectiveBalance/1e9] {
+			continue
+		}
+		idealRewards = append(idealRewards, structs.IdealAttestationReward{
+			EffectiveBalance: strconv.FormatUint(r.EffectiveBalance, 10),
+			Head:             strconv.FormatInt(r.Head, 10),
+			Target:           strconv.FormatInt(r.Target, 10),
+			Source:           strconv.FormatInt(r.Source, 10),
+			Inactivity:       strconv.FormatInt(r.Inactivity, 10),
+		})
+	}
+	lw := httputil.NewNestedJsonListWriter(w, "data", md, "total_rewards", &attRewardsDataMetadata{IdealRewards: idealRewards})
+	for _, idx := range valIndices {
+		r := rewards[idx]
+		if !lw.Write(&structs.TotalAttestationReward{
+			ValidatorIndex: strconv.FormatUint(uint64(idx), 10),
+			Head:           strconv.FormatInt(r.Head, 10),
+			Target:         strconv.FormatInt(r.Target, 10),
+			Source:         strconv.FormatInt(r.Source, 10),
+			Inactivity:     strconv.FormatInt(r.Inactivity, 10),
+		}) {
+			return
+		}
+	}
+	lw.Close()
+}
@@ -0,0 +1,203 @@
+package rewards
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/rewardsummary"
+	dbutil "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/testutil"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+type failingStater struct {
+	*testutil.MockStater
+}
+
+func (failingStater) StateBySlot(context.Context, primitives.Slot) (state.BeaconState, error) {
+	return nil, errors.New("state not found")
+}
+
+func TestAttestationRewards_Saved(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig()
+	cfg.AltairForkEpoch = 1
+	params.OverrideBeaconConfig(cfg)
+
+	ctx := context.Background()
+	st, _ := util.DeterministicGenesisStateAltair(t, 4)
+	beaconDB := dbutil.SetupDB(t)
+	rewards := []rewardsummary.AttestationReward{
+		{EffectiveBalance: 32_000_000_000, Head: 10, Source: 20, Target: 30},
+		{EffectiveBalance: 32_000_000_000, Source: -20, Target: -30, Inactivity: -5},
+		{EffectiveBalance: 20_000_000_000, Head: 5, Source: 10, Target: 15},
+		{EffectiveBalance: 32_000_000_000, Head: 10, Source: 20, Target: 30},
+	}
+	ideal := []rewardsummary.IdealAttestationReward{
+		{EffectiveBalance: 20_000_000_000, Head: 5, Source: 10, Target: 15},
+		{EffectiveBalance: 32_000_000_000, Head: 10, Source: 20, Target: 30},
+	}
+	require.NoError(t, beaconDB.SaveAttestationRewards(ctx, 5, rewards, ideal))
+
+	currentSlot := params.BeaconConfig().SlotsPerEpoch * 100
+	chainService := &mock.ChainService{
+		State:               st,
+		Slot:                &currentSlot,
+		FinalizedCheckPoint: &eth.Checkpoint{Epoch: 10},
+	}
+	s := &Server{
+		Stater:                failingStater{},
+		TimeFetcher:           chainService,
+		OptimisticModeFetcher: chainService,
+		FinalizationFetcher:   chainService,
+		HeadFetcher:           chainService,
+		BeaconDB:              beaconDB,
+	}
+
+	t.Run("filtered vals", func(t *testing.T) {
+		val, err := st.ValidatorAtIndexReadOnly(1)
+		require.NoError(t, err)
+		pk := val.PublicKey()
+		body, err := json.Marshal([]string{fmt.Sprintf("%#x", pk)})
+		require.NoError(t, err)
+		request := httptest.NewRequest("POST", "http://example.com/eth/v1/beacon/rewards/attestations/5", bytes.NewReader(body))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.AttestationRewards(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.AttestationRewardsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, true, resp.Finalized)
+		require.Equal(t, 1, len(resp.Data.TotalRewards))
+		assert.DeepEqual(t, structs.TotalAttestationReward{
+			ValidatorIndex: "1",
+			Head:           "0",
+			Target:         "-30",
+			Source:         "-20",
+			Inactivity:     "-5",
+		}, resp.Data.TotalRewards[0])
+		// Only the ideal rewards of the effective balances of the requested validators are returned.
+		require.Equal(t, 1, len(resp.Data.IdealRewards))
+		assert.Equal(t, "32000000000", resp.Data.IdealRewards[0].EffectiveBalance)
+	})
+	t.Run("all vals", func(t *testing.T) {
+		request := httptest.NewRequest("POST", "http://example.com/eth/v1/beacon/rewards/attestations/5", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.AttestationRewards(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.AttestationRewardsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 4, len(resp.Data.TotalRewards))
+		assert.Equal(t, "5", resp.Data.TotalRewards[2].Head)
+		assert.Equal(t, 2, len(resp.Data.IdealRewards))
+	})
+	t.Run("earliest available epoch", func(t *testing.T) {
+		request := httptest.NewRequest("POST", "http://example.com/eth/v1/beacon/rewards/attestations/3", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.AttestationRewards(writer, request)
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+		e := &httputil.DefaultJsonError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "Attestation rewards are available from epoch 5", e.Message)
+	})
+}
+
+func TestSyncCommitteeRewards_Saved(t *testing.T) {
+	ctx := context.Background()
+	st, _ := util.DeterministicGenesisStateAltair(t, 4)
+	beaconDB := dbutil.SetupDB(t)
+	saved := &rewardsummary.SyncCommitteeRewards{
+		ParticipantReward: 100,
+		Members:           make([]primitives.ValidatorIndex, params.BeaconConfig().SyncCommitteeSize),
+	}
+	// Validator 1 has two positions and validator 2 has one, the other positions belong to validator 0.
+	saved.Members[1] = 1
+	saved.Members[2] = 1
+	saved.Members[3] = 2
+	require.NoError(t, beaconDB.SaveSyncCommitteeRewards(ctx, 2, saved))
+
+	b := util.NewBeaconBlockAltair()
+	b.Block.Slot = params.BeaconConfig().SlotsPerEpoch * 2
+	b.Block.Body.SyncAggregate.SyncCommitteeBits = bitfield.NewBitvector512()
+	b.Block.Body.SyncAggregate.SyncCommitteeBits.SetBitAt(1, true)
+	b.Block.Body.SyncAggregate.SyncCommitteeBits.SetBitAt(3, true)
+	sbb, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+
+	chainService := &mock.ChainService{State: st}
+	s := &Server{
+		Blocker: &testutil.MockBlocker{SlotBlockMap: map[primitives.Slot]interfaces.ReadOnlySignedBeaconBlock{
+			b.Block.Slot: sbb,
+		}},
+		OptimisticModeFetcher: chainService,
+		FinalizationFetcher:   chainService,
+		HeadFetcher:           chainService,
+		BeaconDB:              beaconDB,
+	}
+
+	body, err := json.Marshal([]string{"1", "2", "3"})
+	require.NoError(t, err)
+	request := httptest.NewRequest("POST", "http://example.com/eth/v1/beacon/rewards/sync_committee/64", bytes.NewReader(body))
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+
+	s.SyncCommitteeRewards(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &structs.SyncCommitteeRewardsResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	// Validator 3 is not a member of the sync committee.
+	require.Equal(t, 2, len(resp.Data))
+	assert.DeepEqual(t, structs.SyncCommitteeReward{ValidatorIndex: "1", Reward: "0"}, resp.Data[0])
+	assert.DeepEqual(t, structs.SyncCommitteeReward{ValidatorIndex: "2", Reward: "100"}, resp.Data[1])
+}
+
+func TestSyncAggregateRewards(t *testing.T) {
+	committeeRewards := &rewardsummary.SyncCommitteeRewards{
+		ParticipantReward: 100,
+		Members:           []primitives.ValidatorIndex{0, 1, 1, 2, 0, 0, 2, 1},
+	}
+	bits := bitfield.NewBitvector8()
+	bits.SetBitAt(1, true)
+	bits.SetBitAt(3, true)
+	balances := map[primitives.ValidatorIndex]uint64{0: 1000, 1: 1000, 2: 0}
+	balanceAt := func(idx primitives.ValidatorIndex) (uint64, error) { return balances[idx], nil }
+
+	_, _, err := syncAggregateRewards(committeeRewards, bitfield.NewBitvector32(), balanceAt)
+	require.ErrorContains(t, "exceeds committee length", err)
+
+	rewards, floored, err := syncAggregateRewards(committeeRewards, bits, balanceAt)
+	require.NoError(t, err)
+	assert.Equal(t, false, floored)
+	// Validator 2 has no balance, but its penalty follows its reward in the committee.
+	assert.DeepEqual(t, map[primitives.ValidatorIndex]int64{0: -300, 1: -100, 2: 0}, rewards)
+
+	// Penalties do not take a balance below zero.
+	balances[0] = 150
+	rewards, floored, err = syncAggregateRewards(committeeRewards, bits, balanceAt)
+	require.NoError(t, err)
+	assert.Equal(t, true, floored)
+	assert.Equal(t, int64(-150), rewards[0])
+}
@@ -2,6 +2,7 @@ package rewards
 
 import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/lookup"
 )
 
@@ -13,4 +14,5 @@ type Server struct {
 	Stater                lookup.Stater
 	HeadFetcher           blockchain.HeadFetcher
 	BlockRewardFetcher    BlockRewardsFetcher
+	BeaconDB              db.ReadOnlyDatabase
 }
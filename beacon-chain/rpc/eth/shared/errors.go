@@ -18,6 +18,11 @@ func WriteStateFetchError(w http.ResponseWriter, err error) {
 		httputil.HandleError(w, "State not found", http.StatusNotFound)
 		return
 	}
+	var prunedErr *lookup.StatePrunedError
+	if errors.As(err, &prunedErr) {
+		httputil.HandleError(w, "State pruned: "+prunedErr.Error(), http.StatusGone)
+		return
+	}
 	var parseErr *lookup.StateIdParseError
 	if errors.As(err, &parseErr) {
 		httputil.HandleError(w, "Invalid state ID: "+parseErr.Error(), http.StatusBadRequest)
@@ -34,6 +39,11 @@ func WriteBlockFetchError(w http.ResponseWriter, blk interfaces.ReadOnlySignedBe
 		httputil.HandleError(w, "Invalid block ID: "+invalidBlockIdErr.Error(), http.StatusBadRequest)
 		return false
 	}
+	var prunedErr *lookup.BlockPrunedError
+	if errors.As(err, &prunedErr) {
+		httputil.HandleError(w, "Block pruned: "+prunedErr.Error(), http.StatusGone)
+		return false
+	}
 	if err != nil {
 		httputil.HandleError(w, "Could not get block from block ID: "+err.Error(), http.StatusInternalServerError)
 		return false
@@ -0,0 +1,55 @@
+package shared
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/prysmaticlabs/prysm/v5/api"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+)
+
+// ListMetadata holds the fields of a JSON list response other than its data. They are returned in the headers
+// of the response when the list is requested as SSZ.
+type ListMetadata struct {
+	Version             int
+	ExecutionOptimistic bool
+	Finalized           *bool
+	DependentRoot       string
+}
+
+// WriteSszList writes the SSZ encoding of the data of a list response, with its metadata in the headers.
+func WriteSszList[T any, S structs.SSZElement](w http.ResponseWriter, data []T, toSSZ func(T) (S, error), md ListMetadata, fileName string) {
+	b, err := structs.MarshalSSZList(data, toSSZ)
+	if err != nil {
+		httputil.HandleError(w, "Could not marshal response into SSZ: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	SetListMetadataHeaders(w, md)
+	httputil.WriteSsz(w, b, fileName)
+}
+
+// NewSszListWriter returns a writer streaming the SSZ encoding of a list response with fixed size elements, with
+// its metadata in the headers.
+func NewSszListWriter(w http.ResponseWriter, md ListMetadata, fileName string) *httputil.SszListWriter {
+	h := http.Header{}
+	setListMetadataHeaders(h, md)
+	return httputil.NewSszListWriter(w, h, fileName)
+}
+
+// SetListMetadataHeaders sets the headers holding the metadata of a list response returned as SSZ.
+func SetListMetadataHeaders(w http.ResponseWriter, md ListMetadata) {
+	setListMetadataHeaders(w.Header(), md)
+}
+
+func setListMetadataHeaders(h http.Header, md ListMetadata) {
+	h.Set(api.VersionHeader, version.String(md.Version))
+	h.Set(api.ExecutionOptimisticHeader, strconv.FormatBool(md.ExecutionOptimistic))
+	if md.Finalized != nil {
+		h.Set(api.FinalizedHeader, strconv.FormatBool(*md.Finalized))
+	}
+	if md.DependentRoot != "" {
+		h.Set(api.DependentRootHeader, md.DependentRoot)
+	}
+}
@@ -0,0 +1,41 @@
+package shared
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/api"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestWriteSszList(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		data := []*structs.ValidatorBalance{{Index: "1", Balance: "2"}}
+		finalized := true
+		writer := httptest.NewRecorder()
+		WriteSszList(writer, data, (*structs.ValidatorBalance).ToSSZ, ListMetadata{
+			Version:       version.Deneb,
+			Finalized:     &finalized,
+			DependentRoot: "0xab",
+		}, "balances.ssz")
+		assert.Equal(t, 200, writer.Code)
+		assert.Equal(t, api.OctetStreamMediaType, writer.Header().Get("Content-Type"))
+		assert.Equal(t, "deneb", writer.Header().Get(api.VersionHeader))
+		assert.Equal(t, "false", writer.Header().Get(api.ExecutionOptimisticHeader))
+		assert.Equal(t, "true", writer.Header().Get(api.FinalizedHeader))
+		assert.Equal(t, "0xab", writer.Header().Get(api.DependentRootHeader))
+		got, err := structs.UnmarshalSSZList(writer.Body.Bytes(), structs.ValidatorBalanceFromSSZ)
+		require.NoError(t, err)
+		assert.DeepEqual(t, data, got)
+	})
+	t.Run("invalid data", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		WriteSszList(writer, []*structs.ValidatorBalance{{Index: "x", Balance: "2"}}, (*structs.ValidatorBalance).ToSSZ, ListMetadata{}, "balances.ssz")
+		assert.Equal(t, 500, writer.Code)
+		assert.StringContains(t, "Could not marshal response into SSZ", writer.Body.String())
+		assert.Equal(t, "", writer.Header().Get(api.FinalizedHeader))
+	})
+}
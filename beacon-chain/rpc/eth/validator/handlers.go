@@ -819,6 +819,11 @@ func (s *Server) GetAttesterDuties(w http.ResponseWriter, r *http.Request) {
 		Data:                duties,
 		ExecutionOptimistic: isOptimistic,
 	}
+	if httputil.RespondWithSsz(r) {
+		md := shared.ListMetadata{Version: st.Version(), ExecutionOptimistic: isOptimistic, DependentRoot: response.DependentRoot}
+		shared.WriteSszList(w, duties, (*structs.AttesterDuty).ToSSZ, md, "attester_duties.ssz")
+		return
+	}
 	httputil.WriteJson(w, response)
 }
 
@@ -946,6 +951,11 @@ func (s *Server) GetProposerDuties(w http.ResponseWriter, r *http.Request) {
 		Data:                duties,
 		ExecutionOptimistic: isOptimistic,
 	}
+	if httputil.RespondWithSsz(r) {
+		md := shared.ListMetadata{Version: st.Version(), ExecutionOptimistic: isOptimistic, DependentRoot: resp.DependentRoot}
+		shared.WriteSszList(w, duties, (*structs.ProposerDuty).ToSSZ, md, "proposer_duties.ssz")
+		return
+	}
 	httputil.WriteJson(w, resp)
 }
 
@@ -1083,6 +1093,11 @@ func (s *Server) GetSyncCommitteeDuties(w http.ResponseWriter, r *http.Request)
 		Data:                duties,
 		ExecutionOptimistic: isOptimistic,
 	}
+	if httputil.RespondWithSsz(r) {
+		md := shared.ListMetadata{Version: st.Version(), ExecutionOptimistic: isOptimistic}
+		shared.WriteSszList(w, duties, (*structs.SyncCommitteeDuty).ToSSZ, md, "sync_committee_duties.ssz")
+		return
+	}
 	httputil.WriteJson(w, resp)
 }
 
@@ -1188,6 +1203,10 @@ func (s *Server) GetLiveness(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if httputil.RespondWithSsz(r) {
+		shared.WriteSszList(w, resp.Data, (*structs.Liveness).ToSSZ, shared.ListMetadata{Version: st.Version()}, "liveness.ssz")
+		return
+	}
 	httputil.WriteJson(w, resp)
 }
 
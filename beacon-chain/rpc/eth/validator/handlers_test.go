@@ -1637,6 +1637,34 @@ func TestGetAttesterDuties(t *testing.T) {
 		assert.Equal(t, "3", duty.CommitteesAtSlot)
 		assert.Equal(t, "80", duty.ValidatorCommitteeIndex)
 	})
+	t.Run("ssz", func(t *testing.T) {
+		var body bytes.Buffer
+		_, err = body.WriteString("[\"0\"]")
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodGet, "http://www.example.com/eth/v1/validator/duties/attester/{epoch}", &body)
+		request.SetPathValue("epoch", "0")
+		request.Header.Set("Accept", api.OctetStreamMediaType)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetAttesterDuties(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, "phase0", writer.Header().Get(api.VersionHeader))
+		assert.Equal(t, hexutil.Encode(genesisRoot[:]), writer.Header().Get(api.DependentRootHeader))
+		assert.Equal(t, "false", writer.Header().Get(api.ExecutionOptimisticHeader))
+		data, err := structs.UnmarshalSSZList(writer.Body.Bytes(), structs.AttesterDutyFromSSZ)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(data))
+		assert.DeepEqual(t, &structs.AttesterDuty{
+			Pubkey:                  hexutil.Encode(pubKeys[0]),
+			ValidatorIndex:          "0",
+			CommitteeIndex:          "1",
+			CommitteeLength:         "171",
+			CommitteesAtSlot:        "3",
+			ValidatorCommitteeIndex: "80",
+			Slot:                    "0",
+		}, data[0])
+	})
 	t.Run("multiple validators", func(t *testing.T) {
 		var body bytes.Buffer
 		_, err = body.WriteString("[\"0\",\"1\"]")
@@ -1896,6 +1924,40 @@ func TestGetProposerDuties(t *testing.T) {
 		assert.Equal(t, "12289", expectedDuty.ValidatorIndex)
 		assert.Equal(t, hexutil.Encode(pubKeys[12289]), expectedDuty.Pubkey)
 	})
+	t.Run("ssz", func(t *testing.T) {
+		bs, err := transition.GenesisBeaconState(context.Background(), deposits, 0, eth1Data)
+		require.NoError(t, err, "Could not set up genesis state")
+		require.NoError(t, bs.SetSlot(params.BeaconConfig().SlotsPerEpoch))
+		require.NoError(t, bs.SetBlockRoots(roots))
+		chainSlot := primitives.Slot(0)
+		chain := &mockChain.ChainService{
+			State: bs, Root: genesisRoot[:], Slot: &chainSlot,
+		}
+		s := &Server{
+			Stater:                 &testutil.MockStater{StatesBySlot: map[primitives.Slot]state.BeaconState{0: bs}},
+			HeadFetcher:            chain,
+			TimeFetcher:            chain,
+			OptimisticModeFetcher:  chain,
+			SyncChecker:            &mockSync.Sync{IsSyncing: false},
+			PayloadIDCache:         cache.NewPayloadIDCache(),
+			TrackedValidatorsCache: cache.NewTrackedValidatorsCache(),
+			BeaconDB:               db,
+		}
+
+		request := httptest.NewRequest(http.MethodGet, "http://www.example.com/eth/v1/validator/duties/proposer/{epoch}", nil)
+		request.SetPathValue("epoch", "0")
+		request.Header.Set("Accept", api.OctetStreamMediaType)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetProposerDuties(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, hexutil.Encode(genesisRoot[:]), writer.Header().Get(api.DependentRootHeader))
+		data, err := structs.UnmarshalSSZList(writer.Body.Bytes(), structs.ProposerDutyFromSSZ)
+		require.NoError(t, err)
+		assert.Equal(t, 31, len(data))
+		assert.DeepEqual(t, &structs.ProposerDuty{Pubkey: hexutil.Encode(pubKeys[12289]), ValidatorIndex: "12289", Slot: "11"}, data[10])
+	})
 	t.Run("next epoch", func(t *testing.T) {
 		bs, err := transition.GenesisBeaconState(context.Background(), deposits, 0, eth1Data)
 		require.NoError(t, err, "Could not set up genesis state")
@@ -2110,6 +2172,25 @@ func TestGetSyncCommitteeDuties(t *testing.T) {
 		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
 		require.Equal(t, 2, len(resp.Data))
 	})
+	t.Run("ssz", func(t *testing.T) {
+		var body bytes.Buffer
+		_, err := body.WriteString("[\"0\",\"1\"]")
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodGet, "http://www.example.com/eth/v1/validator/duties/sync/{epoch}", &body)
+		request.SetPathValue("epoch", "0")
+		request.Header.Set("Accept", api.OctetStreamMediaType)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetSyncCommitteeDuties(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, "altair", writer.Header().Get(api.VersionHeader))
+		data, err := structs.UnmarshalSSZList(writer.Body.Bytes(), structs.SyncCommitteeDutyFromSSZ)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(data))
+		assert.DeepEqual(t, []string{"0", "5"}, data[0].ValidatorSyncCommitteeIndices)
+		assert.Equal(t, hexutil.Encode(vals[1].PublicKey), data[1].Pubkey)
+	})
 	t.Run("no body", func(t *testing.T) {
 		request := httptest.NewRequest(http.MethodPost, "http://www.example.com/eth/v1/validator/duties/sync/{epoch}", nil)
 		request.SetPathValue("epoch", "0")
@@ -2659,6 +2740,23 @@ func TestGetLiveness(t *testing.T) {
 		assert.Equal(t, true, (data0.Index == "0" && data0.IsLive) || (data0.Index == "1" && !data0.IsLive))
 		assert.Equal(t, true, (data1.Index == "0" && data1.IsLive) || (data1.Index == "1" && !data1.IsLive))
 	})
+	t.Run("current epoch ssz", func(t *testing.T) {
+		var body bytes.Buffer
+		_, err := body.WriteString("[\"0\",\"1\"]")
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/eth/v1/validator/liveness/{epoch}", &body)
+		request.SetPathValue("epoch", "2")
+		request.Header.Set("Accept", api.OctetStreamMediaType)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetLiveness(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, "bellatrix", writer.Header().Get(api.VersionHeader))
+		data, err := structs.UnmarshalSSZList(writer.Body.Bytes(), structs.LivenessFromSSZ)
+		require.NoError(t, err)
+		require.DeepEqual(t, []*structs.Liveness{{Index: "0", IsLive: true}, {Index: "1", IsLive: false}}, data)
+	})
 	t.Run("future epoch", func(t *testing.T) {
 		var body bytes.Buffer
 		_, err := body.WriteString("[\"0\",\"1\"]")
@@ -0,0 +1,545 @@
+package graphql
+
+import (
+	"math/bits"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+)
+
+type blockResolver struct {
+	blk  interfaces.ReadOnlySignedBeaconBlock
+	root [32]byte
+}
+
+func newBlockResolver(blk interfaces.ReadOnlySignedBeaconBlock) (*blockResolver, error) {
+	root, err := blk.Block().HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute block root")
+	}
+	return &blockResolver{blk: blk, root: root}, nil
+}
+
+func (r *blockResolver) Root() Bytes {
+	return r.root[:]
+}
+
+func (r *blockResolver) Slot() Uint64 {
+	return Uint64(r.blk.Block().Slot())
+}
+
+func (r *blockResolver) ProposerIndex() Uint64 {
+	return Uint64(r.blk.Block().ProposerIndex())
+}
+
+func (r *blockResolver) ParentRoot() Bytes {
+	root := r.blk.Block().ParentRoot()
+	return root[:]
+}
+
+func (r *blockResolver) StateRoot() Bytes {
+	root := r.blk.Block().StateRoot()
+	return root[:]
+}
+
+func (r *blockResolver) BodyRoot() (Bytes, error) {
+	root, err := r.blk.Block().Body().HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute block body root")
+	}
+	return root[:], nil
+}
+
+func (r *blockResolver) Version() string {
+	return version.String(r.blk.Version())
+}
+
+func (r *blockResolver) Signature() Bytes {
+	sig := r.blk.Signature()
+	return sig[:]
+}
+
+func (r *blockResolver) RandaoReveal() Bytes {
+	reveal := r.blk.Block().Body().RandaoReveal()
+	return reveal[:]
+}
+
+func (r *blockResolver) Graffiti() Bytes {
+	graffiti := r.blk.Block().Body().Graffiti()
+	return graffiti[:]
+}
+
+func (r *blockResolver) Header() (*headerResolver, error) {
+	h, err := r.blk.Header()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get block header")
+	}
+	return newHeaderResolver(h)
+}
+
+func (r *blockResolver) Eth1Data() *eth1DataResolver {
+	return &eth1DataResolver{data: r.blk.Block().Body().Eth1Data()}
+}
+
+func (r *blockResolver) Attestations() []*attestationResolver {
+	atts := r.blk.Block().Body().Attestations()
+	resolvers := make([]*attestationResolver, len(atts))
+	for i, att := range atts {
+		resolvers[i] = &attestationResolver{att: att}
+	}
+	return resolvers
+}
+
+func (r *blockResolver) AttesterSlashings() []*attesterSlashingResolver {
+	return attesterSlashingResolvers(r.blk.Block().Body().AttesterSlashings())
+}
+
+func (r *blockResolver) ProposerSlashings() ([]*proposerSlashingResolver, error) {
+	return proposerSlashingResolvers(r.blk.Block().Body().ProposerSlashings())
+}
+
+func (r *blockResolver) DepositCount() int32 {
+	return int32(len(r.blk.Block().Body().Deposits()))
+}
+
+func (r *blockResolver) VoluntaryExits() []*voluntaryExitResolver {
+	return voluntaryExitResolvers(r.blk.Block().Body().VoluntaryExits())
+}
+
+func (r *blockResolver) SyncAggregate() (*syncAggregateResolver, error) {
+	if r.blk.Version() < version.Altair {
+		return nil, nil
+	}
+	sa, err := r.blk.Block().Body().SyncAggregate()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get sync aggregate")
+	}
+	return &syncAggregateResolver{sa: sa}, nil
+}
+
+func (r *blockResolver) ExecutionPayload() (*executionPayloadResolver, error) {
+	if r.blk.Version() < version.Bellatrix {
+		return nil, nil
+	}
+	payload, err := r.blk.Block().Body().Execution()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get execution payload")
+	}
+	return &executionPayloadResolver{payload: payload, version: r.blk.Version()}, nil
+}
+
+func (r *blockResolver) BlsToExecutionChanges() ([]*blsToExecutionChangeResolver, error) {
+	if r.blk.Version() < version.Capella {
+		return []*blsToExecutionChangeResolver{}, nil
+	}
+	changes, err := r.blk.Block().Body().BLSToExecutionChanges()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get BLS to execution changes")
+	}
+	return blsToExecutionChangeResolvers(changes), nil
+}
+
+func (r *blockResolver) BlobKzgCommitments() ([]Bytes, error) {
+	if r.blk.Version() < version.Deneb {
+		return []Bytes{}, nil
+	}
+	commitments, err := r.blk.Block().Body().BlobKzgCommitments()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get blob KZG commitments")
+	}
+	resolved := make([]Bytes, len(commitments))
+	for i, c := range commitments {
+		resolved[i] = c
+	}
+	return resolved, nil
+}
+
+type headerResolver struct {
+	h    *ethpb.SignedBeaconBlockHeader
+	root [32]byte
+}
+
+func newHeaderResolver(h *ethpb.SignedBeaconBlockHeader) (*headerResolver, error) {
+	if h == nil || h.Header == nil {
+		return nil, errors.New("nil block header")
+	}
+	root, err := h.Header.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute block header root")
+	}
+	return &headerResolver{h: h, root: root}, nil
+}
+
+func (r *headerResolver) Root() Bytes {
+	return r.root[:]
+}
+
+func (r *headerResolver) Slot() Uint64 {
+	return Uint64(r.h.Header.Slot)
+}
+
+func (r *headerResolver) ProposerIndex() Uint64 {
+	return Uint64(r.h.Header.ProposerIndex)
+}
+
+func (r *headerResolver) ParentRoot() Bytes {
+	return r.h.Header.ParentRoot
+}
+
+func (r *headerResolver) StateRoot() Bytes {
+	return r.h.Header.StateRoot
+}
+
+func (r *headerResolver) BodyRoot() Bytes {
+	return r.h.Header.BodyRoot
+}
+
+func (r *headerResolver) Signature() Bytes {
+	return r.h.Signature
+}
+
+type eth1DataResolver struct {
+	data *ethpb.Eth1Data
+}
+
+func (r *eth1DataResolver) DepositRoot() Bytes {
+	return r.data.GetDepositRoot()
+}
+
+func (r *eth1DataResolver) DepositCount() Uint64 {
+	return Uint64(r.data.GetDepositCount())
+}
+
+func (r *eth1DataResolver) BlockHash() Bytes {
+	return r.data.GetBlockHash()
+}
+
+type checkpointResolver struct {
+	cp *ethpb.Checkpoint
+}
+
+func (r *checkpointResolver) Epoch() Uint64 {
+	return Uint64(r.cp.GetEpoch())
+}
+
+func (r *checkpointResolver) Root() Bytes {
+	return r.cp.GetRoot()
+}
+
+type attestationDataResolver struct {
+	data *ethpb.AttestationData
+}
+
+func (r *attestationDataResolver) Slot() Uint64 {
+	return Uint64(r.data.GetSlot())
+}
+
+func (r *attestationDataResolver) Index() Uint64 {
+	return Uint64(r.data.GetCommitteeIndex())
+}
+
+func (r *attestationDataResolver) BeaconBlockRoot() Bytes {
+	return r.data.GetBeaconBlockRoot()
+}
+
+func (r *attestationDataResolver) Source() *checkpointResolver {
+	return &checkpointResolver{cp: r.data.GetSource()}
+}
+
+func (r *attestationDataResolver) Target() *checkpointResolver {
+	return &checkpointResolver{cp: r.data.GetTarget()}
+}
+
+type attestationResolver struct {
+	att ethpb.Att
+}
+
+func (r *attestationResolver) AggregationBits() Bytes {
+	return Bytes(r.att.GetAggregationBits())
+}
+
+func (r *attestationResolver) CommitteeBits() *Bytes {
+	if r.att.Version() < version.Electra {
+		return nil
+	}
+	b := Bytes(r.att.CommitteeBitsVal().Bytes())
+	return &b
+}
+
+func (r *attestationResolver) Data() *attestationDataResolver {
+	return &attestationDataResolver{data: r.att.GetData()}
+}
+
+func (r *attestationResolver) Signature() Bytes {
+	return r.att.GetSignature()
+}
+
+type indexedAttestationResolver struct {
+	att ethpb.IndexedAtt
+}
+
+func (r *indexedAttestationResolver) AttestingIndices() []Uint64 {
+	indices := r.att.GetAttestingIndices()
+	resolved := make([]Uint64, len(indices))
+	for i, idx := range indices {
+		resolved[i] = Uint64(idx)
+	}
+	return resolved
+}
+
+func (r *indexedAttestationResolver) Data() *attestationDataResolver {
+	return &attestationDataResolver{data: r.att.GetData()}
+}
+
+func (r *indexedAttestationResolver) Signature() Bytes {
+	return r.att.GetSignature()
+}
+
+type attesterSlashingResolver struct {
+	slashing ethpb.AttSlashing
+}
+
+func attesterSlashingResolvers(slashings []ethpb.AttSlashing) []*attesterSlashingResolver {
+	resolvers := make([]*attesterSlashingResolver, len(slashings))
+	for i, s := range slashings {
+		resolvers[i] = &attesterSlashingResolver{slashing: s}
+	}
+	return resolvers
+}
+
+func (r *attesterSlashingResolver) Attestation1() *indexedAttestationResolver {
+	return &indexedAttestationResolver{att: r.slashing.FirstAttestation()}
+}
+
+func (r *attesterSlashingResolver) Attestation2() *indexedAttestationResolver {
+	return &indexedAttestationResolver{att: r.slashing.SecondAttestation()}
+}
+
+type proposerSlashingResolver struct {
+	header1 *headerResolver
+	header2 *headerResolver
+}
+
+func proposerSlashingResolvers(slashings []*ethpb.ProposerSlashing) ([]*proposerSlashingResolver, error) {
+	resolvers := make([]*proposerSlashingResolver, len(slashings))
+	for i, s := range slashings {
+		h1, err := newHeaderResolver(s.Header_1)
+		if err != nil {
+			return nil, err
+		}
+		h2, err := newHeaderResolver(s.Header_2)
+		if err != nil {
+			return nil, err
+		}
+		resolvers[i] = &proposerSlashingResolver{header1: h1, header2: h2}
+	}
+	return resolvers, nil
+}
+
+func (r *proposerSlashingResolver) Header1() *headerResolver {
+	return r.header1
+}
+
+func (r *proposerSlashingResolver) Header2() *headerResolver {
+	return r.header2
+}
+
+type voluntaryExitResolver struct {
+	exit *ethpb.SignedVoluntaryExit
+}
+
+func voluntaryExitResolvers(exits []*ethpb.SignedVoluntaryExit) []*voluntaryExitResolver {
+	resolvers := make([]*voluntaryExitResolver, len(exits))
+	for i, e := range exits {
+		resolvers[i] = &voluntaryExitResolver{exit: e}
+	}
+	return resolvers
+}
+
+func (r *voluntaryExitResolver) Epoch() Uint64 {
+	return Uint64(r.exit.GetExit().GetEpoch())
+}
+
+func (r *voluntaryExitResolver) ValidatorIndex() Uint64 {
+	return Uint64(r.exit.GetExit().GetValidatorIndex())
+}
+
+func (r *voluntaryExitResolver) Signature() Bytes {
+	return r.exit.GetSignature()
+}
+
+type blsToExecutionChangeResolver struct {
+	change *ethpb.SignedBLSToExecutionChange
+}
+
+func blsToExecutionChangeResolvers(changes []*ethpb.SignedBLSToExecutionChange) []*blsToExecutionChangeResolver {
+	resolvers := make([]*blsToExecutionChangeResolver, len(changes))
+	for i, c := range changes {
+		resolvers[i] = &blsToExecutionChangeResolver{change: c}
+	}
+	return resolvers
+}
+
+func (r *blsToExecutionChangeResolver) ValidatorIndex() Uint64 {
+	return Uint64(r.change.GetMessage().GetValidatorIndex())
+}
+
+func (r *blsToExecutionChangeResolver) FromBlsPubkey() Bytes {
+	return r.change.GetMessage().GetFromBlsPubkey()
+}
+
+func (r *blsToExecutionChangeResolver) ToExecutionAddress() Bytes {
+	return r.change.GetMessage().GetToExecutionAddress()
+}
+
+func (r *blsToExecutionChangeResolver) Signature() Bytes {
+	return r.change.GetSignature()
+}
+
+type syncAggregateResolver struct {
+	sa *ethpb.SyncAggregate
+}
+
+func (r *syncAggregateResolver) SyncCommitteeBits() Bytes {
+	return Bytes(r.sa.SyncCommitteeBits)
+}
+
+func (r *syncAggregateResolver) SyncCommitteeSignature() Bytes {
+	return r.sa.SyncCommitteeSignature
+}
+
+func (r *syncAggregateResolver) ParticipantCount() int32 {
+	count := 0
+	for _, b := range r.sa.SyncCommitteeBits {
+		count += bits.OnesCount8(b)
+	}
+	return int32(count)
+}
+
+type executionPayloadResolver struct {
+	payload interfaces.ExecutionData
+	version int
+}
+
+func (r *executionPayloadResolver) BlockHash() Bytes {
+	return r.payload.BlockHash()
+}
+
+func (r *executionPayloadResolver) ParentHash() Bytes {
+	return r.payload.ParentHash()
+}
+
+func (r *executionPayloadResolver) BlockNumber() Uint64 {
+	return Uint64(r.payload.BlockNumber())
+}
+
+func (r *executionPayloadResolver) Timestamp() Uint64 {
+	return Uint64(r.payload.Timestamp())
+}
+
+func (r *executionPayloadResolver) FeeRecipient() Bytes {
+	return r.payload.FeeRecipient()
+}
+
+func (r *executionPayloadResolver) StateRoot() Bytes {
+	return r.payload.StateRoot()
+}
+
+func (r *executionPayloadResolver) ReceiptsRoot() Bytes {
+	return r.payload.ReceiptsRoot()
+}
+
+func (r *executionPayloadResolver) PrevRandao() Bytes {
+	return r.payload.PrevRandao()
+}
+
+func (r *executionPayloadResolver) ExtraData() Bytes {
+	return r.payload.ExtraData()
+}
+
+func (r *executionPayloadResolver) GasLimit() Uint64 {
+	return Uint64(r.payload.GasLimit())
+}
+
+func (r *executionPayloadResolver) GasUsed() Uint64 {
+	return Uint64(r.payload.GasUsed())
+}
+
+func (r *executionPayloadResolver) BaseFeePerGas() string {
+	return bytesutil.LittleEndianBytesToBigInt(r.payload.BaseFeePerGas()).String()
+}
+
+func (r *executionPayloadResolver) TransactionCount() (*int32, error) {
+	if r.payload.IsBlinded() {
+		return nil, nil
+	}
+	txs, err := r.payload.Transactions()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get transactions")
+	}
+	count := int32(len(txs))
+	return &count, nil
+}
+
+func (r *executionPayloadResolver) Withdrawals() (*[]*withdrawalResolver, error) {
+	if r.version < version.Capella || r.payload.IsBlinded() {
+		return nil, nil
+	}
+	withdrawals, err := r.payload.Withdrawals()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get withdrawals")
+	}
+	resolvers := make([]*withdrawalResolver, len(withdrawals))
+	for i, w := range withdrawals {
+		resolvers[i] = &withdrawalResolver{w: w}
+	}
+	return &resolvers, nil
+}
+
+func (r *executionPayloadResolver) BlobGasUsed() (*Uint64, error) {
+	if r.version < version.Deneb {
+		return nil, nil
+	}
+	used, err := r.payload.BlobGasUsed()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get blob gas used")
+	}
+	u := Uint64(used)
+	return &u, nil
+}
+
+func (r *executionPayloadResolver) ExcessBlobGas() (*Uint64, error) {
+	if r.version < version.Deneb {
+		return nil, nil
+	}
+	excess, err := r.payload.ExcessBlobGas()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get excess blob gas")
+	}
+	u := Uint64(excess)
+	return &u, nil
+}
+
+type withdrawalResolver struct {
+	w *enginev1.Withdrawal
+}
+
+func (r *withdrawalResolver) Index() Uint64 {
+	return Uint64(r.w.Index)
+}
+
+func (r *withdrawalResolver) ValidatorIndex() Uint64 {
+	return Uint64(r.w.ValidatorIndex)
+}
+
+func (r *withdrawalResolver) Address() Bytes {
+	return r.w.Address
+}
+
+func (r *withdrawalResolver) Amount() Uint64 {
+	return Uint64(r.w.Amount)
+}
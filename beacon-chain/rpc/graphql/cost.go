@@ -0,0 +1,74 @@
+package graphql
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+)
+
+// The cost of a query is the sum of the costs of the objects it loads. It approximates the work of the node rather
+// than the size of the response, so that a query fetching many states is rejected before it replays many blocks.
+const (
+	stateCost = 10000
+	blockCost = 10
+	blobCost  = 100
+	itemCost  = 1
+	// pageCost is the cost of a page of up to pageSize validators, balances or committee members. Lists read from a
+	// state are charged by the pages they return rather than by the validators they scan, so that listing or
+	// filtering the validators of a mainnet state stays well within the default limit.
+	pageCost = 100
+	pageSize = 1000
+	// shufflingCost is the cost of the committee shuffling of an epoch. It is charged once per list of committees, as
+	// the shuffling is computed once per epoch and then read from the committee cache.
+	shufflingCost = 1000
+)
+
+var errCostLimitExceeded = errors.New("query cost limit exceeded")
+
+type costBudgetKey struct{}
+
+// costBudget is the remaining cost of a query. Fields of a query are resolved concurrently, hence the atomic counter.
+type costBudget struct {
+	remaining atomic.Int64
+	limit     uint64
+}
+
+// withCostBudget returns a context limiting the cost of the query it resolves. A limit of zero disables the limit.
+func withCostBudget(ctx context.Context, limit uint64) context.Context {
+	if limit == 0 {
+		return ctx
+	}
+	b := &costBudget{limit: limit}
+	b.remaining.Store(int64(min(limit, uint64(1<<62))))
+	return context.WithValue(ctx, costBudgetKey{}, b)
+}
+
+// charge spends the cost of loading objects from the budget of the query, and fails once the budget is exhausted.
+func charge(ctx context.Context, cost int64) error {
+	b, ok := ctx.Value(costBudgetKey{}).(*costBudget)
+	if !ok {
+		return nil
+	}
+	if b.remaining.Add(-cost) < 0 {
+		return errors.Wrapf(errCostLimitExceeded, "limit is %d", b.limit)
+	}
+	return nil
+}
+
+// chargePages spends the cost of the pages needed to go from returning `returned` items to returning `returned+n`
+// items. The first page is charged up front, by chargeFirstPage.
+func chargePages(ctx context.Context, returned, n int) error {
+	pages := func(items int) int64 {
+		return int64(max(1, (items+pageSize-1)/pageSize))
+	}
+	if added := pages(returned+n) - pages(returned); added > 0 {
+		return charge(ctx, added*pageCost)
+	}
+	return nil
+}
+
+// chargeFirstPage spends the cost of the first page of a list, which is charged even if the list is empty.
+func chargeFirstPage(ctx context.Context) error {
+	return charge(ctx, pageCost)
+}
@@ -0,0 +1,236 @@
+package graphql
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/lookup"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+// maxBlocksRange is the maximum number of slots of a blocks query.
+const maxBlocksRange = 1024
+
+// queryResolver resolves the fields of the Query type, which load blocks, states and blobs through the same lookups
+// as the Beacon API.
+type queryResolver struct {
+	cfg *Config
+}
+
+func (r *queryResolver) Block(ctx context.Context, args struct{ ID string }) (*blockResolver, error) {
+	if err := charge(ctx, blockCost); err != nil {
+		return nil, err
+	}
+	blk, err := r.cfg.Blocker.Block(ctx, []byte(args.ID))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get block")
+	}
+	if blocks.BeaconBlockIsNil(blk) != nil {
+		return nil, nil
+	}
+	return newBlockResolver(blk)
+}
+
+func (r *queryResolver) Blocks(ctx context.Context, args struct {
+	FromSlot Uint64
+	ToSlot   Uint64
+}) ([]*blockResolver, error) {
+	if args.ToSlot < args.FromSlot {
+		return nil, errors.New("toSlot must not be lower than fromSlot")
+	}
+	if args.ToSlot-args.FromSlot >= maxBlocksRange {
+		return nil, errors.Errorf("at most %d slots can be queried", maxBlocksRange)
+	}
+	resolvers := make([]*blockResolver, 0)
+	for slot := args.FromSlot; slot <= args.ToSlot; slot++ {
+		if err := charge(ctx, blockCost); err != nil {
+			return nil, err
+		}
+		blk, err := r.cfg.Blocker.Block(ctx, []byte(strconv.FormatUint(uint64(slot), 10)))
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get block at slot %d", slot)
+		}
+		if blocks.BeaconBlockIsNil(blk) != nil {
+			continue
+		}
+		b, err := newBlockResolver(blk)
+		if err != nil {
+			return nil, err
+		}
+		resolvers = append(resolvers, b)
+	}
+	return resolvers, nil
+}
+
+func (r *queryResolver) Header(ctx context.Context, args struct{ ID string }) (*headerResolver, error) {
+	b, err := r.Block(ctx, args)
+	if err != nil || b == nil {
+		return nil, err
+	}
+	return b.Header()
+}
+
+func (r *queryResolver) State(ctx context.Context, args struct{ ID string }) (*stateResolver, error) {
+	if err := charge(ctx, stateCost); err != nil {
+		return nil, err
+	}
+	st, err := r.cfg.Stater.State(ctx, []byte(args.ID))
+	if err != nil {
+		var notFoundErr *lookup.StateNotFoundError
+		if errors.As(err, &notFoundErr) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "could not get state")
+	}
+	return &stateResolver{st: st}, nil
+}
+
+func (r *queryResolver) Pool() *poolResolver {
+	return &poolResolver{cfg: r.cfg}
+}
+
+func (r *queryResolver) BlobSidecars(ctx context.Context, args struct {
+	BlockID string
+	Indices *[]Uint64
+}) ([]*blobSidecarResolver, error) {
+	var indices []uint64
+	if args.Indices != nil {
+		indices = make([]uint64, len(*args.Indices))
+		for i, idx := range *args.Indices {
+			indices[i] = uint64(idx)
+		}
+	}
+	if err := charge(ctx, blockCost); err != nil {
+		return nil, err
+	}
+	blobs, rpcErr := r.cfg.Blocker.Blobs(ctx, args.BlockID, indices)
+	if rpcErr != nil {
+		return nil, errors.Wrap(rpcErr.Err, "could not get blobs")
+	}
+	if err := charge(ctx, int64(len(blobs))*blobCost); err != nil {
+		return nil, err
+	}
+	resolvers := make([]*blobSidecarResolver, len(blobs))
+	for i, b := range blobs {
+		resolvers[i] = &blobSidecarResolver{blob: b.ROBlob}
+	}
+	return resolvers, nil
+}
+
+// poolResolver resolves the fields of the Pool type. Slashings are read against the head state, as when they are
+// packed into a block.
+type poolResolver struct {
+	cfg *Config
+}
+
+func (r *poolResolver) Attestations(ctx context.Context, args struct {
+	Slot           *Uint64
+	CommitteeIndex *Uint64
+}) ([]*attestationResolver, error) {
+	atts := r.cfg.AttestationsPool.AggregatedAttestations()
+	unaggregated, err := r.cfg.AttestationsPool.UnaggregatedAttestations()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get unaggregated attestations")
+	}
+	atts = append(atts, unaggregated...)
+	resolvers := make([]*attestationResolver, 0)
+	for _, att := range atts {
+		if args.Slot != nil && att.GetData().Slot != primitives.Slot(*args.Slot) {
+			continue
+		}
+		if args.CommitteeIndex != nil {
+			index, err := att.GetCommitteeIndex()
+			if err != nil {
+				return nil, errors.Wrap(err, "could not get committee index of attestation")
+			}
+			if index != primitives.CommitteeIndex(*args.CommitteeIndex) {
+				continue
+			}
+		}
+		if err := charge(ctx, itemCost); err != nil {
+			return nil, err
+		}
+		resolvers = append(resolvers, &attestationResolver{att: att})
+	}
+	return resolvers, nil
+}
+
+func (r *poolResolver) VoluntaryExits(ctx context.Context) ([]*voluntaryExitResolver, error) {
+	exits, err := r.cfg.ExitPool.PendingExits()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get voluntary exits")
+	}
+	if err := charge(ctx, int64(len(exits))*itemCost); err != nil {
+		return nil, err
+	}
+	return voluntaryExitResolvers(exits), nil
+}
+
+func (r *poolResolver) BlsToExecutionChanges(ctx context.Context) ([]*blsToExecutionChangeResolver, error) {
+	changes, err := r.cfg.BLSChangesPool.PendingBLSToExecChanges()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get BLS to execution changes")
+	}
+	if err := charge(ctx, int64(len(changes))*itemCost); err != nil {
+		return nil, err
+	}
+	return blsToExecutionChangeResolvers(changes), nil
+}
+
+func (r *poolResolver) ProposerSlashings(ctx context.Context) ([]*proposerSlashingResolver, error) {
+	headState, err := r.cfg.HeadFetcher.HeadStateReadOnly(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get head state")
+	}
+	slashings := r.cfg.SlashingsPool.PendingProposerSlashings(ctx, headState, true)
+	if err := charge(ctx, int64(len(slashings))*itemCost); err != nil {
+		return nil, err
+	}
+	return proposerSlashingResolvers(slashings)
+}
+
+func (r *poolResolver) AttesterSlashings(ctx context.Context) ([]*attesterSlashingResolver, error) {
+	headState, err := r.cfg.HeadFetcher.HeadStateReadOnly(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get head state")
+	}
+	slashings := r.cfg.SlashingsPool.PendingAttesterSlashings(ctx, headState, true)
+	if err := charge(ctx, int64(len(slashings))*itemCost); err != nil {
+		return nil, err
+	}
+	return attesterSlashingResolvers(slashings), nil
+}
+
+type blobSidecarResolver struct {
+	blob blocks.ROBlob
+}
+
+func (r *blobSidecarResolver) Index() Uint64 {
+	return Uint64(r.blob.Index)
+}
+
+func (r *blobSidecarResolver) BlockRoot() Bytes {
+	return r.blob.BlockRootSlice()
+}
+
+func (r *blobSidecarResolver) Slot() Uint64 {
+	return Uint64(r.blob.Slot())
+}
+
+func (r *blobSidecarResolver) KzgCommitment() Bytes {
+	return r.blob.KzgCommitment
+}
+
+func (r *blobSidecarResolver) KzgProof() Bytes {
+	return r.blob.KzgProof
+}
+
+func (r *blobSidecarResolver) Blob() Bytes {
+	return r.blob.Blob
+}
+
+func (r *blobSidecarResolver) SignedBlockHeader() (*headerResolver, error) {
+	return newHeaderResolver(r.blob.SignedBlockHeader)
+}
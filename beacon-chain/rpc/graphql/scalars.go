@@ -0,0 +1,77 @@
+package graphql
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+)
+
+// Uint64 is the GraphQL scalar of unsigned 64 bit integers, which do not fit in the 32 bit Int of GraphQL. As in the
+// Beacon API, it is written as a decimal string. It is read from a decimal string or from an integer.
+type Uint64 uint64
+
+// ImplementsGraphQLType maps the type to the Uint64 scalar of the schema.
+func (Uint64) ImplementsGraphQLType(name string) bool {
+	return name == "Uint64"
+}
+
+// UnmarshalGraphQL reads the value of an argument.
+func (u *Uint64) UnmarshalGraphQL(input interface{}) error {
+	switch v := input.(type) {
+	case string:
+		n, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "could not parse Uint64 %q", v)
+		}
+		*u = Uint64(n)
+	case int32:
+		if v < 0 {
+			return errors.Errorf("Uint64 must not be negative, got %d", v)
+		}
+		*u = Uint64(v)
+	case float64:
+		// Integers of JSON variables are decoded as floats, which are exact up to 2^53.
+		if v < 0 || v != math.Trunc(v) || v > 1<<53 {
+			return errors.Errorf("Uint64 must be a non-negative integer of at most 2^53, got %v", v)
+		}
+		*u = Uint64(v)
+	default:
+		return errors.Errorf("unexpected type %T for Uint64", input)
+	}
+	return nil
+}
+
+// MarshalJSON writes the value as a decimal string.
+func (u Uint64) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strconv.FormatUint(uint64(u), 10))
+}
+
+// Bytes is the GraphQL scalar of byte strings, which are written as 0x prefixed hex strings.
+type Bytes []byte
+
+// ImplementsGraphQLType maps the type to the Bytes scalar of the schema.
+func (Bytes) ImplementsGraphQLType(name string) bool {
+	return name == "Bytes"
+}
+
+// UnmarshalGraphQL reads the value of an argument.
+func (b *Bytes) UnmarshalGraphQL(input interface{}) error {
+	s, ok := input.(string)
+	if !ok {
+		return errors.Errorf("unexpected type %T for Bytes", input)
+	}
+	decoded, err := hexutil.Decode(s)
+	if err != nil {
+		return errors.Wrapf(err, "could not decode Bytes %q", s)
+	}
+	*b = decoded
+	return nil
+}
+
+// MarshalJSON writes the value as a hex string.
+func (b Bytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(hexutil.Encode(b))
+}
@@ -0,0 +1,53 @@
+package graphql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestUint64_UnmarshalGraphQL(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   interface{}
+		want    Uint64
+		wantErr bool
+	}{
+		{name: "string", input: "18446744073709551615", want: Uint64(18446744073709551615)},
+		{name: "int", input: int32(12), want: 12},
+		{name: "variable", input: float64(1 << 40), want: 1 << 40},
+		{name: "negative string", input: "-1", wantErr: true},
+		{name: "negative int", input: int32(-1), wantErr: true},
+		{name: "fraction", input: 1.5, wantErr: true},
+		{name: "too large variable", input: float64(1 << 60), wantErr: true},
+		{name: "bool", input: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var u Uint64
+			err := u.UnmarshalGraphQL(tt.input)
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, u)
+		})
+	}
+}
+
+func TestScalars_MarshalJSON(t *testing.T) {
+	b, err := json.Marshal(Uint64(18446744073709551615))
+	require.NoError(t, err)
+	assert.Equal(t, `"18446744073709551615"`, string(b))
+	b, err = json.Marshal(Bytes{0x01, 0xab})
+	require.NoError(t, err)
+	assert.Equal(t, `"0x01ab"`, string(b))
+
+	var decoded Bytes
+	require.NoError(t, decoded.UnmarshalGraphQL("0x01ab"))
+	assert.DeepEqual(t, Bytes{0x01, 0xab}, decoded)
+	assert.NotNil(t, decoded.UnmarshalGraphQL("01ab"))
+}
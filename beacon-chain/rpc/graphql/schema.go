@@ -0,0 +1,247 @@
+package graphql
+
+// schema is the GraphQL schema served by the beacon node. Block, header and state IDs take the same values as in the
+// Beacon API: head, genesis, finalized, justified, a slot or a hex encoded root.
+const schema = `
+schema {
+	query: Query
+}
+
+"An unsigned 64 bit integer, such as a slot, an epoch or an amount of Gwei, written as a decimal string."
+scalar Uint64
+
+"A byte string, written as a 0x prefixed hex string."
+scalar Bytes
+
+type Query {
+	"The block with the given block ID."
+	block(id: String!): Block
+	"The canonical blocks of a slot range, both slots included. Empty slots are skipped."
+	blocks(fromSlot: Uint64!, toSlot: Uint64!): [Block!]!
+	"The header of the block with the given block ID."
+	header(id: String!): BlockHeader
+	"The state with the given state ID."
+	state(id: String!): State
+	"The operation pools of the node."
+	pool: Pool!
+	"The blob sidecars of the block with the given block ID, optionally restricted to some blob indices."
+	blobSidecars(blockId: String!, indices: [Uint64!]): [BlobSidecar!]!
+}
+
+type Block {
+	root: Bytes!
+	slot: Uint64!
+	proposerIndex: Uint64!
+	parentRoot: Bytes!
+	stateRoot: Bytes!
+	bodyRoot: Bytes!
+	version: String!
+	signature: Bytes!
+	randaoReveal: Bytes!
+	graffiti: Bytes!
+	header: BlockHeader!
+	eth1Data: Eth1Data!
+	attestations: [Attestation!]!
+	attesterSlashings: [AttesterSlashing!]!
+	proposerSlashings: [ProposerSlashing!]!
+	depositCount: Int!
+	voluntaryExits: [SignedVoluntaryExit!]!
+	"Null before Altair."
+	syncAggregate: SyncAggregate
+	"Null before Bellatrix."
+	executionPayload: ExecutionPayload
+	"Empty before Capella."
+	blsToExecutionChanges: [SignedBLSToExecutionChange!]!
+	"Empty before Deneb."
+	blobKzgCommitments: [Bytes!]!
+}
+
+type BlockHeader {
+	root: Bytes!
+	slot: Uint64!
+	proposerIndex: Uint64!
+	parentRoot: Bytes!
+	stateRoot: Bytes!
+	bodyRoot: Bytes!
+	signature: Bytes!
+}
+
+type Eth1Data {
+	depositRoot: Bytes!
+	depositCount: Uint64!
+	blockHash: Bytes!
+}
+
+type Checkpoint {
+	epoch: Uint64!
+	root: Bytes!
+}
+
+type AttestationData {
+	slot: Uint64!
+	index: Uint64!
+	beaconBlockRoot: Bytes!
+	source: Checkpoint!
+	target: Checkpoint!
+}
+
+type Attestation {
+	aggregationBits: Bytes!
+	"Null before Electra."
+	committeeBits: Bytes
+	data: AttestationData!
+	signature: Bytes!
+}
+
+type IndexedAttestation {
+	attestingIndices: [Uint64!]!
+	data: AttestationData!
+	signature: Bytes!
+}
+
+type AttesterSlashing {
+	attestation1: IndexedAttestation!
+	attestation2: IndexedAttestation!
+}
+
+type ProposerSlashing {
+	header1: BlockHeader!
+	header2: BlockHeader!
+}
+
+type SignedVoluntaryExit {
+	epoch: Uint64!
+	validatorIndex: Uint64!
+	signature: Bytes!
+}
+
+type SignedBLSToExecutionChange {
+	validatorIndex: Uint64!
+	fromBlsPubkey: Bytes!
+	toExecutionAddress: Bytes!
+	signature: Bytes!
+}
+
+type SyncAggregate {
+	syncCommitteeBits: Bytes!
+	syncCommitteeSignature: Bytes!
+	participantCount: Int!
+}
+
+type ExecutionPayload {
+	blockHash: Bytes!
+	parentHash: Bytes!
+	blockNumber: Uint64!
+	timestamp: Uint64!
+	feeRecipient: Bytes!
+	stateRoot: Bytes!
+	receiptsRoot: Bytes!
+	prevRandao: Bytes!
+	extraData: Bytes!
+	gasLimit: Uint64!
+	gasUsed: Uint64!
+	"The base fee per gas in Wei, as a decimal string."
+	baseFeePerGas: String!
+	"Null for blinded blocks."
+	transactionCount: Int
+	"Null before Capella and for blinded blocks."
+	withdrawals: [Withdrawal!]
+	"Null before Deneb."
+	blobGasUsed: Uint64
+	"Null before Deneb."
+	excessBlobGas: Uint64
+}
+
+type Withdrawal {
+	index: Uint64!
+	validatorIndex: Uint64!
+	address: Bytes!
+	amount: Uint64!
+}
+
+type Fork {
+	previousVersion: Bytes!
+	currentVersion: Bytes!
+	epoch: Uint64!
+}
+
+type State {
+	slot: Uint64!
+	root: Bytes!
+	version: String!
+	fork: Fork!
+	genesisValidatorsRoot: Bytes!
+	finalizedCheckpoint: Checkpoint!
+	currentJustifiedCheckpoint: Checkpoint!
+	previousJustifiedCheckpoint: Checkpoint!
+	validatorCount: Int!
+	"The validator with the given index or hex encoded public key."
+	validator(id: String!): Validator
+	"""
+	The validators with the given indices or hex encoded public keys, or every validator if no ID is given, optionally
+	restricted to some statuses or sub-statuses. At most first validators with an index greater than after are returned.
+	"""
+	validators(ids: [String!], statuses: [String!], first: Int, after: Uint64): [Validator!]!
+	"The balances of the validators with the given indices or hex encoded public keys, or of every validator."
+	balances(ids: [String!]): [Balance!]!
+	"The beacon committees of an epoch, the epoch of the state by default, optionally restricted to a slot or an index. The epoch must be between the previous epoch of the state and its seed lookahead."
+	committees(epoch: Uint64, slot: Uint64, index: Uint64): [Committee!]!
+	"Null before Altair."
+	currentSyncCommittee: SyncCommittee
+	"Null before Altair."
+	nextSyncCommittee: SyncCommittee
+}
+
+type Validator {
+	index: Uint64!
+	pubkey: Bytes!
+	withdrawalCredentials: Bytes!
+	effectiveBalance: Uint64!
+	balance: Uint64!
+	slashed: Boolean!
+	activationEligibilityEpoch: Uint64!
+	activationEpoch: Uint64!
+	exitEpoch: Uint64!
+	withdrawableEpoch: Uint64!
+	"The sub-status of the validator at the epoch of the state, as in the Beacon API."
+	status: String!
+}
+
+type Balance {
+	index: Uint64!
+	balance: Uint64!
+}
+
+type Committee {
+	index: Uint64!
+	slot: Uint64!
+	validators: [Uint64!]!
+}
+
+type SyncCommittee {
+	"The indices of the validators of the committee. Unknown public keys are skipped."
+	validators: [Uint64!]!
+	aggregatePubkey: Bytes!
+}
+
+type Pool {
+	"The aggregated and unaggregated attestations, optionally restricted to a slot and a committee index."
+	attestations(slot: Uint64, committeeIndex: Uint64): [Attestation!]!
+	voluntaryExits: [SignedVoluntaryExit!]!
+	blsToExecutionChanges: [SignedBLSToExecutionChange!]!
+	"The proposer slashings which are valid against the head state."
+	proposerSlashings: [ProposerSlashing!]!
+	"The attester slashings which are valid against the head state."
+	attesterSlashings: [AttesterSlashing!]!
+}
+
+type BlobSidecar {
+	index: Uint64!
+	blockRoot: Bytes!
+	slot: Uint64!
+	kzgCommitment: Bytes!
+	kzgProof: Bytes!
+	blob: Bytes!
+	signedBlockHeader: BlockHeader!
+}
+`
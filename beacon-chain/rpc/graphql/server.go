@@ -0,0 +1,87 @@
+// Package graphql defines a GraphQL endpoint serving blocks, states, operation pools and blob sidecars, so that
+// queries joining them can be answered by a single request.
+package graphql
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/attestations"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/blstoexec"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/slashings"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/voluntaryexits"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/lookup"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+)
+
+const (
+	// maxRequestSize is the maximum size of the body of a query request.
+	maxRequestSize = 1 << 20
+	// maxParallelism is the maximum number of fields of a query resolved concurrently.
+	maxParallelism = 10
+)
+
+// Config holds the data sources of the GraphQL server and the limits of its queries.
+type Config struct {
+	Blocker          lookup.Blocker
+	Stater           lookup.Stater
+	HeadFetcher      blockchain.HeadFetcher
+	AttestationsPool attestations.Pool
+	ExitPool         voluntaryexits.PoolManager
+	SlashingsPool    slashings.PoolManager
+	BLSChangesPool   blstoexec.PoolManager
+	// MaxDepth is the maximum depth of the selections of a query. Zero disables the limit.
+	MaxDepth int
+	// MaxCost is the maximum cost of the objects loaded by a query. Zero disables the limit.
+	MaxCost uint64
+}
+
+// Server serves GraphQL queries.
+type Server struct {
+	cfg    *Config
+	schema *graphql.Schema
+}
+
+// queryRequest is the body of a query request.
+type queryRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// NewServer returns a server resolving queries from the data sources of the config.
+func NewServer(cfg *Config) *Server {
+	return &Server{
+		cfg: cfg,
+		schema: graphql.MustParseSchema(
+			schema,
+			&queryResolver{cfg: cfg},
+			graphql.UseStringDescriptions(),
+			graphql.MaxDepth(cfg.MaxDepth),
+			graphql.MaxParallelism(maxParallelism),
+		),
+	}
+}
+
+// Query executes the GraphQL query of the request body, which holds the query, and optionally the operation name
+// and the variables. As is usual for GraphQL, errors of the query are written in the errors field of the response,
+// along with the data which could be resolved.
+func (s *Server) Query(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "graphql.Query")
+	defer span.End()
+
+	req := &queryRequest{}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(req); err != nil {
+		httputil.HandleError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.Query == "" {
+		httputil.HandleError(w, "Query is required in request body", http.StatusBadRequest)
+		return
+	}
+	resp := s.schema.Exec(withCostBudget(ctx, s.cfg.MaxCost), req.Query, req.OperationName, req.Variables)
+	httputil.WriteJson(w, resp)
+}
@@ -0,0 +1,397 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/attestations"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/blstoexec"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/slashings"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/voluntaryexits"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/core"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/testutil"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+type queryResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func runQuery(t *testing.T, s *Server, query string, variables map[string]interface{}) *queryResponse {
+	body, err := json.Marshal(&queryRequest{Query: query, Variables: variables})
+	require.NoError(t, err)
+	request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/graphql", bytes.NewReader(body))
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.Query(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &queryResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	return resp
+}
+
+// blobBlocker serves the blobs of a single block.
+type blobBlocker struct {
+	*testutil.MockBlocker
+	blobs []*blocks.VerifiedROBlob
+}
+
+func (b *blobBlocker) Blobs(_ context.Context, _ string, indices []uint64) ([]*blocks.VerifiedROBlob, *core.RpcError) {
+	if len(indices) == 0 {
+		return b.blobs, nil
+	}
+	blobs := make([]*blocks.VerifiedROBlob, 0, len(indices))
+	for _, idx := range indices {
+		blobs = append(blobs, b.blobs[idx])
+	}
+	return blobs, nil
+}
+
+func TestServer_Query_Blocks(t *testing.T) {
+	blk1 := util.NewBeaconBlock()
+	blk1.Block.Slot = 1
+	blk1.Block.ProposerIndex = 7
+	signed1, err := blocks.NewSignedBeaconBlock(blk1)
+	require.NoError(t, err)
+	root1, err := signed1.Block().HashTreeRoot()
+	require.NoError(t, err)
+	denebBlk, sidecars := util.GenerateTestDenebBlockWithSidecar(t, root1, 3, 2)
+	verified := make([]*blocks.VerifiedROBlob, len(sidecars))
+	for i := range sidecars {
+		v := blocks.NewVerifiedROBlob(sidecars[i])
+		verified[i] = &v
+	}
+	blocker := &blobBlocker{
+		MockBlocker: &testutil.MockBlocker{SlotBlockMap: map[primitives.Slot]interfaces.ReadOnlySignedBeaconBlock{
+			1: signed1,
+			3: denebBlk,
+		}},
+		blobs: verified,
+	}
+	s := NewServer(&Config{Blocker: blocker, MaxDepth: 10})
+
+	resp := runQuery(t, s, `query($from: Uint64!) {
+		block(id: "1") { slot proposerIndex root version attestations { signature } syncAggregate { participantCount } }
+		blocks(fromSlot: $from, toSlot: "3") { slot version blobKzgCommitments }
+		header(id: "3") { slot root }
+		missing: block(id: "2") { slot }
+		blobSidecars(blockId: "3", indices: ["1"]) { index slot blockRoot }
+	}`, map[string]interface{}{"from": 0})
+	require.Equal(t, 0, len(resp.Errors))
+	data := &struct {
+		Block struct {
+			Slot          string
+			ProposerIndex string
+			Root          string
+			Version       string
+			Attestations  []interface{}
+			SyncAggregate *struct{}
+		}
+		Blocks []struct {
+			Slot               string
+			Version            string
+			BlobKzgCommitments []string
+		}
+		Header struct {
+			Slot string
+			Root string
+		}
+		Missing      *struct{}
+		BlobSidecars []struct {
+			Index     string
+			Slot      string
+			BlockRoot string
+		}
+	}{}
+	require.NoError(t, json.Unmarshal(resp.Data, data))
+	assert.Equal(t, "1", data.Block.Slot)
+	assert.Equal(t, "7", data.Block.ProposerIndex)
+	assert.Equal(t, hexutil.Encode(root1[:]), data.Block.Root)
+	assert.Equal(t, "phase0", data.Block.Version)
+	assert.Equal(t, 0, len(data.Block.Attestations))
+	assert.Equal(t, true, data.Block.SyncAggregate == nil)
+	require.Equal(t, 2, len(data.Blocks))
+	assert.Equal(t, "1", data.Blocks[0].Slot)
+	assert.Equal(t, "3", data.Blocks[1].Slot)
+	assert.Equal(t, "deneb", data.Blocks[1].Version)
+	assert.Equal(t, 2, len(data.Blocks[1].BlobKzgCommitments))
+	denebRoot := denebBlk.Root()
+	assert.Equal(t, "3", data.Header.Slot)
+	assert.Equal(t, hexutil.Encode(denebRoot[:]), data.Header.Root)
+	assert.Equal(t, true, data.Missing == nil)
+	require.Equal(t, 1, len(data.BlobSidecars))
+	assert.Equal(t, "1", data.BlobSidecars[0].Index)
+	assert.Equal(t, "3", data.BlobSidecars[0].Slot)
+	assert.Equal(t, hexutil.Encode(denebRoot[:]), data.BlobSidecars[0].BlockRoot)
+}
+
+func TestServer_Query_State(t *testing.T) {
+	st, _ := util.DeterministicGenesisStateAltair(t, 64)
+	require.NoError(t, st.SetSlot(5))
+	val, err := st.ValidatorAtIndex(3)
+	require.NoError(t, err)
+	val.ExitEpoch = 10
+	require.NoError(t, st.UpdateValidatorAtIndex(3, val))
+	pubkey := st.PubkeyAtIndex(4)
+	otherPubkey := st.PubkeyAtIndex(7)
+	require.NoError(t, st.SetCurrentSyncCommittee(&ethpb.SyncCommittee{
+		Pubkeys:         [][]byte{otherPubkey[:], pubkey[:]},
+		AggregatePubkey: make([]byte, 48),
+	}))
+	s := NewServer(&Config{Stater: &testutil.MockStater{BeaconState: st}, MaxDepth: 10})
+
+	resp := runQuery(t, s, `query($pubkey: String!) {
+		state(id: "head") {
+			slot
+			version
+			validatorCount
+			validator(id: $pubkey) { index status }
+			page: validators(first: 2, after: "9") { index }
+			exiting: validators(statuses: ["active_exiting"]) { index exitEpoch }
+			balances(ids: ["0", $pubkey, "1000"]) { index balance }
+			committees(slot: "5") { slot index validators }
+			currentSyncCommittee { validators }
+		}
+	}`, map[string]interface{}{"pubkey": hexutil.Encode(pubkey[:])})
+	require.Equal(t, 0, len(resp.Errors))
+	data := &struct {
+		State struct {
+			Slot           string
+			Version        string
+			ValidatorCount int
+			Validator      struct {
+				Index  string
+				Status string
+			}
+			Page []struct {
+				Index string
+			}
+			Exiting []struct {
+				Index     string
+				ExitEpoch string
+			}
+			Balances []struct {
+				Index   string
+				Balance string
+			}
+			Committees []struct {
+				Slot       string
+				Index      string
+				Validators []string
+			}
+			CurrentSyncCommittee struct {
+				Validators []string
+			}
+		}
+	}{}
+	require.NoError(t, json.Unmarshal(resp.Data, data))
+	assert.Equal(t, "5", data.State.Slot)
+	assert.Equal(t, "altair", data.State.Version)
+	assert.Equal(t, 64, data.State.ValidatorCount)
+	assert.Equal(t, "4", data.State.Validator.Index)
+	assert.Equal(t, "active_ongoing", data.State.Validator.Status)
+	require.Equal(t, 2, len(data.State.Page))
+	assert.Equal(t, "10", data.State.Page[0].Index)
+	assert.Equal(t, "11", data.State.Page[1].Index)
+	require.Equal(t, 1, len(data.State.Exiting))
+	assert.Equal(t, "3", data.State.Exiting[0].Index)
+	assert.Equal(t, "10", data.State.Exiting[0].ExitEpoch)
+	require.Equal(t, 2, len(data.State.Balances))
+	assert.Equal(t, "0", data.State.Balances[0].Index)
+	assert.Equal(t, "4", data.State.Balances[1].Index)
+	assert.Equal(t, "32000000000", data.State.Balances[1].Balance)
+	require.NotEqual(t, 0, len(data.State.Committees))
+	validators := 0
+	for _, c := range data.State.Committees {
+		assert.Equal(t, "5", c.Slot)
+		validators += len(c.Validators)
+	}
+	assert.Equal(t, 2, validators)
+	assert.DeepEqual(t, []string{"7", "4"}, data.State.CurrentSyncCommittee.Validators)
+}
+
+func TestServer_Query_Pool(t *testing.T) {
+	st, _ := util.DeterministicGenesisState(t, 64)
+	exitPool := voluntaryexits.NewPool()
+	exitPool.InsertVoluntaryExit(&ethpb.SignedVoluntaryExit{
+		Exit:      &ethpb.VoluntaryExit{Epoch: 2, ValidatorIndex: 5},
+		Signature: make([]byte, 96),
+	})
+	attPool := attestations.NewPool()
+	att := util.HydrateAttestation(&ethpb.Attestation{Data: &ethpb.AttestationData{Slot: 4}})
+	att.AggregationBits = []byte{0b1101}
+	require.NoError(t, attPool.SaveAggregatedAttestation(att))
+	s := NewServer(&Config{
+		HeadFetcher:      &mock.ChainService{State: st},
+		AttestationsPool: attPool,
+		ExitPool:         exitPool,
+		SlashingsPool:    slashings.NewPool(),
+		BLSChangesPool:   blstoexec.NewPool(),
+		MaxDepth:         10,
+	})
+
+	resp := runQuery(t, s, `{
+		pool {
+			attestations(slot: "4") { aggregationBits committeeBits data { slot } }
+			other: attestations(slot: "5") { aggregationBits }
+			voluntaryExits { epoch validatorIndex }
+			blsToExecutionChanges { validatorIndex }
+			proposerSlashings { header1 { slot } }
+			attesterSlashings { attestation1 { attestingIndices } }
+		}
+	}`, nil)
+	require.Equal(t, 0, len(resp.Errors))
+	data := &struct {
+		Pool struct {
+			Attestations []struct {
+				AggregationBits string
+				CommitteeBits   *string
+			}
+			Other          []interface{}
+			VoluntaryExits []struct {
+				Epoch          string
+				ValidatorIndex string
+			}
+			BlsToExecutionChanges []interface{}
+			ProposerSlashings     []interface{}
+			AttesterSlashings     []interface{}
+		}
+	}{}
+	require.NoError(t, json.Unmarshal(resp.Data, data))
+	require.Equal(t, 1, len(data.Pool.Attestations))
+	assert.Equal(t, "0x0d", data.Pool.Attestations[0].AggregationBits)
+	assert.Equal(t, true, data.Pool.Attestations[0].CommitteeBits == nil)
+	assert.Equal(t, 0, len(data.Pool.Other))
+	require.Equal(t, 1, len(data.Pool.VoluntaryExits))
+	assert.Equal(t, "2", data.Pool.VoluntaryExits[0].Epoch)
+	assert.Equal(t, "5", data.Pool.VoluntaryExits[0].ValidatorIndex)
+	assert.Equal(t, 0, len(data.Pool.BlsToExecutionChanges))
+	assert.Equal(t, 0, len(data.Pool.ProposerSlashings))
+	assert.Equal(t, 0, len(data.Pool.AttesterSlashings))
+}
+
+func TestServer_Query_Limits(t *testing.T) {
+	st, _ := util.DeterministicGenesisState(t, 64)
+	blk := util.NewBeaconBlock()
+	signed, err := blocks.NewSignedBeaconBlock(blk)
+	require.NoError(t, err)
+	cfg := &Config{
+		Blocker:  &testutil.MockBlocker{BlockToReturn: signed},
+		Stater:   &testutil.MockStater{BeaconState: st},
+		MaxDepth: 3,
+		MaxCost:  stateCost + 2*blockCost,
+	}
+	s := NewServer(cfg)
+
+	t.Run("within limits", func(t *testing.T) {
+		resp := runQuery(t, s, `{ state(id: "head") { slot } a: block(id: "head") { slot } b: block(id: "head") { slot } }`, nil)
+		assert.Equal(t, 0, len(resp.Errors))
+	})
+	t.Run("depth", func(t *testing.T) {
+		resp := runQuery(t, s, `{ block(id: "head") { attestations { data { slot } } } }`, nil)
+		require.Equal(t, 1, len(resp.Errors))
+		assert.StringContains(t, "exceeds max depth", resp.Errors[0].Message)
+	})
+	t.Run("cost", func(t *testing.T) {
+		resp := runQuery(t, s, `{ state(id: "head") { slot } a: block(id: "head") { slot } b: block(id: "head") { slot } c: block(id: "head") { slot } }`, nil)
+		require.Equal(t, 1, len(resp.Errors))
+		assert.Equal(t, true, strings.Contains(resp.Errors[0].Message, errCostLimitExceeded.Error()))
+	})
+	t.Run("cost of validators", func(t *testing.T) {
+		resp := runQuery(t, s, `{ state(id: "head") { validators { index } } }`, nil)
+		require.Equal(t, 1, len(resp.Errors))
+		assert.Equal(t, true, strings.Contains(resp.Errors[0].Message, errCostLimitExceeded.Error()))
+	})
+	t.Run("cost of filtered validators", func(t *testing.T) {
+		resp := runQuery(t, s, `{ state(id: "head") { validators(statuses: ["exited_slashed"]) { index } } }`, nil)
+		require.Equal(t, 1, len(resp.Errors))
+		assert.Equal(t, true, strings.Contains(resp.Errors[0].Message, errCostLimitExceeded.Error()))
+	})
+	t.Run("cost of committees", func(t *testing.T) {
+		resp := runQuery(t, s, `{ state(id: "head") { committees(slot: "0", index: "1") { index } } }`, nil)
+		require.Equal(t, 1, len(resp.Errors))
+		assert.Equal(t, true, strings.Contains(resp.Errors[0].Message, errCostLimitExceeded.Error()))
+	})
+	t.Run("committees epoch beyond lookahead", func(t *testing.T) {
+		resp := runQuery(t, s, `{ state(id: "head") { committees(epoch: "2") { index } } }`, nil)
+		require.Equal(t, 1, len(resp.Errors))
+		assert.StringContains(t, "not within the committee lookahead", resp.Errors[0].Message)
+	})
+	t.Run("invalid argument", func(t *testing.T) {
+		resp := runQuery(t, s, `{ blocks(fromSlot: "-1", toSlot: "2") { slot } }`, nil)
+		assert.Equal(t, 1, len(resp.Errors))
+	})
+	t.Run("no query", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/graphql", strings.NewReader("{}"))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.Query(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+	t.Run("invalid body", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/graphql", strings.NewReader("query"))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.Query(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
+
+func TestStateResolver_Cost(t *testing.T) {
+	st, _ := util.DeterministicGenesisState(t, 64)
+	r := &stateResolver{st: st}
+	const limit = 1_000_000
+	spent := func(ctx context.Context) int64 {
+		return limit - ctx.Value(costBudgetKey{}).(*costBudget).remaining.Load()
+	}
+
+	t.Run("filtered validators are charged by pages returned", func(t *testing.T) {
+		ctx := withCostBudget(context.Background(), limit)
+		statuses := []string{"exited_slashed"}
+		vals, err := r.Validators(ctx, struct {
+			IDs      *[]string
+			Statuses *[]string
+			First    *int32
+			After    *Uint64
+		}{Statuses: &statuses})
+		require.NoError(t, err)
+		assert.Equal(t, 0, len(vals))
+		assert.Equal(t, int64(pageCost), spent(ctx))
+	})
+	t.Run("committees charge the shuffling once", func(t *testing.T) {
+		ctx := withCostBudget(context.Background(), limit)
+		committees, err := r.Committees(ctx, struct {
+			Epoch *Uint64
+			Slot  *Uint64
+			Index *Uint64
+		}{})
+		require.NoError(t, err)
+		assert.NotEqual(t, 0, len(committees))
+		assert.Equal(t, int64(shufflingCost+pageCost), spent(ctx))
+	})
+}
+
+func TestChargePages(t *testing.T) {
+	ctx := withCostBudget(context.Background(), 2*pageCost)
+	require.NoError(t, chargeFirstPage(ctx))
+	require.NoError(t, chargePages(ctx, 0, pageSize))
+	require.NoError(t, chargePages(ctx, pageSize, 1))
+	require.NoError(t, chargePages(ctx, pageSize+1, pageSize-1))
+	require.ErrorIs(t, chargePages(ctx, 2*pageSize, 1), errCostLimitExceeded)
+}
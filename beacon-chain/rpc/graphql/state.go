@@ -0,0 +1,467 @@
+package graphql
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	corehelpers "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/validator"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+type stateResolver struct {
+	st state.BeaconState
+}
+
+func (r *stateResolver) Slot() Uint64 {
+	return Uint64(r.st.Slot())
+}
+
+func (r *stateResolver) Root(ctx context.Context) (Bytes, error) {
+	root, err := r.st.HashTreeRoot(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute state root")
+	}
+	return root[:], nil
+}
+
+func (r *stateResolver) Version() string {
+	return version.String(r.st.Version())
+}
+
+func (r *stateResolver) Fork() *forkResolver {
+	return &forkResolver{fork: r.st.Fork()}
+}
+
+func (r *stateResolver) GenesisValidatorsRoot() Bytes {
+	return r.st.GenesisValidatorsRoot()
+}
+
+func (r *stateResolver) FinalizedCheckpoint() *checkpointResolver {
+	return &checkpointResolver{cp: r.st.FinalizedCheckpoint()}
+}
+
+func (r *stateResolver) CurrentJustifiedCheckpoint() *checkpointResolver {
+	return &checkpointResolver{cp: r.st.CurrentJustifiedCheckpoint()}
+}
+
+func (r *stateResolver) PreviousJustifiedCheckpoint() *checkpointResolver {
+	return &checkpointResolver{cp: r.st.PreviousJustifiedCheckpoint()}
+}
+
+func (r *stateResolver) ValidatorCount() int32 {
+	return int32(r.st.NumValidators())
+}
+
+func (r *stateResolver) Validator(ctx context.Context, args struct{ ID string }) (*validatorResolver, error) {
+	indices, err := validatorIndices(r.st, []string{args.ID})
+	if err != nil || len(indices) == 0 {
+		return nil, err
+	}
+	if err := charge(ctx, itemCost); err != nil {
+		return nil, err
+	}
+	return r.validator(indices[0])
+}
+
+func (r *stateResolver) Validators(ctx context.Context, args struct {
+	IDs      *[]string
+	Statuses *[]string
+	First    *int32
+	After    *Uint64
+}) ([]*validatorResolver, error) {
+	var statuses []validator.Status
+	if args.Statuses != nil {
+		for _, raw := range *args.Statuses {
+			ok, status := validator.StatusFromString(strings.ToLower(raw))
+			if !ok {
+				return nil, errors.Errorf("invalid validator status %s", raw)
+			}
+			statuses = append(statuses, status)
+		}
+	}
+	first := r.st.NumValidators()
+	if args.First != nil {
+		if *args.First < 0 {
+			return nil, errors.New("first must not be negative")
+		}
+		first = min(first, int(*args.First))
+	}
+	indices, err := r.selectedIndices(args.IDs, args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := chargeFirstPage(ctx); err != nil {
+		return nil, err
+	}
+	epoch := slots.ToEpoch(r.st.Slot())
+	resolvers := make([]*validatorResolver, 0)
+	for _, idx := range indices {
+		if len(resolvers) == first {
+			break
+		}
+		v, err := r.validator(idx)
+		if err != nil {
+			return nil, err
+		}
+		ok, err := hasStatus(v.val, epoch, statuses)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		if err := chargePages(ctx, len(resolvers), 1); err != nil {
+			return nil, err
+		}
+		resolvers = append(resolvers, v)
+	}
+	return resolvers, nil
+}
+
+func (r *stateResolver) Balances(ctx context.Context, args struct{ IDs *[]string }) ([]*balanceResolver, error) {
+	indices, err := r.selectedIndices(args.IDs, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := chargeFirstPage(ctx); err != nil {
+		return nil, err
+	}
+	resolvers := make([]*balanceResolver, len(indices))
+	for i, idx := range indices {
+		if err := chargePages(ctx, i, 1); err != nil {
+			return nil, err
+		}
+		balance, err := r.st.BalanceAtIndex(idx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get balance of validator %d", idx)
+		}
+		resolvers[i] = &balanceResolver{index: idx, balance: balance}
+	}
+	return resolvers, nil
+}
+
+func (r *stateResolver) Committees(ctx context.Context, args struct {
+	Epoch *Uint64
+	Slot  *Uint64
+	Index *Uint64
+}) ([]*committeeResolver, error) {
+	current := slots.ToEpoch(r.st.Slot())
+	epoch := current
+	if args.Epoch != nil {
+		epoch = primitives.Epoch(*args.Epoch)
+	}
+	// The seed of an epoch is only known from the randao mixes of the state within its lookahead, and the committees
+	// of the other epochs are read from the states of these epochs.
+	if epoch+1 < current || epoch > current+params.BeaconConfig().MinSeedLookahead {
+		return nil, errors.Errorf("epoch %d is not within the committee lookahead of the state at epoch %d", epoch, current)
+	}
+	if err := charge(ctx, shufflingCost); err != nil {
+		return nil, err
+	}
+	if err := chargeFirstPage(ctx); err != nil {
+		return nil, err
+	}
+	activeCount, err := corehelpers.ActiveValidatorCount(ctx, r.st, epoch)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get active validator count")
+	}
+	startSlot, err := slots.EpochStart(epoch)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get epoch start slot")
+	}
+	endSlot, err := slots.EpochEnd(epoch)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get epoch end slot")
+	}
+	committeesPerSlot := corehelpers.SlotCommitteeCount(activeCount)
+
+	resolvers := make([]*committeeResolver, 0)
+	members := 0
+	for slot := startSlot; slot <= endSlot; slot++ {
+		if args.Slot != nil && slot != primitives.Slot(*args.Slot) {
+			continue
+		}
+		for index := primitives.CommitteeIndex(0); index < primitives.CommitteeIndex(committeesPerSlot); index++ {
+			if args.Index != nil && index != primitives.CommitteeIndex(*args.Index) {
+				continue
+			}
+			committee, err := corehelpers.BeaconCommitteeFromState(ctx, r.st, slot, index)
+			if err != nil {
+				return nil, errors.Wrap(err, "could not get committee")
+			}
+			if err := chargePages(ctx, members, len(committee)); err != nil {
+				return nil, err
+			}
+			members += len(committee)
+			resolvers = append(resolvers, &committeeResolver{slot: slot, index: index, validators: committee})
+		}
+	}
+	return resolvers, nil
+}
+
+func (r *stateResolver) CurrentSyncCommittee(ctx context.Context) (*syncCommitteeResolver, error) {
+	if r.st.Version() < version.Altair {
+		return nil, nil
+	}
+	committee, err := r.st.CurrentSyncCommittee()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get current sync committee")
+	}
+	return r.syncCommittee(ctx, committee)
+}
+
+func (r *stateResolver) NextSyncCommittee(ctx context.Context) (*syncCommitteeResolver, error) {
+	if r.st.Version() < version.Altair {
+		return nil, nil
+	}
+	committee, err := r.st.NextSyncCommittee()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get next sync committee")
+	}
+	return r.syncCommittee(ctx, committee)
+}
+
+func (r *stateResolver) syncCommittee(ctx context.Context, committee *ethpb.SyncCommittee) (*syncCommitteeResolver, error) {
+	if err := chargeFirstPage(ctx); err != nil {
+		return nil, err
+	}
+	if err := chargePages(ctx, 0, len(committee.Pubkeys)); err != nil {
+		return nil, err
+	}
+	indices := make([]Uint64, 0, len(committee.Pubkeys))
+	for _, pubkey := range committee.Pubkeys {
+		idx, ok := r.st.ValidatorIndexByPubkey(bytesutil.ToBytes48(pubkey))
+		if ok {
+			indices = append(indices, Uint64(idx))
+		}
+	}
+	return &syncCommitteeResolver{validators: indices, aggregatePubkey: committee.AggregatePubkey}, nil
+}
+
+// selectedIndices returns the indices of the given validator IDs, or the indices of every validator greater than
+// after if no ID is given.
+func (r *stateResolver) selectedIndices(ids *[]string, after *Uint64) ([]primitives.ValidatorIndex, error) {
+	if ids != nil && len(*ids) > 0 {
+		indices, err := validatorIndices(r.st, *ids)
+		if err != nil {
+			return nil, err
+		}
+		if after == nil {
+			return indices, nil
+		}
+		selected := make([]primitives.ValidatorIndex, 0, len(indices))
+		for _, idx := range indices {
+			if uint64(idx) > uint64(*after) {
+				selected = append(selected, idx)
+			}
+		}
+		return selected, nil
+	}
+	start := uint64(0)
+	if after != nil {
+		start = uint64(*after) + 1
+	}
+	numVals := uint64(r.st.NumValidators())
+	if start >= numVals {
+		return []primitives.ValidatorIndex{}, nil
+	}
+	indices := make([]primitives.ValidatorIndex, 0, numVals-start)
+	for idx := start; idx < numVals; idx++ {
+		indices = append(indices, primitives.ValidatorIndex(idx))
+	}
+	return indices, nil
+}
+
+func (r *stateResolver) validator(idx primitives.ValidatorIndex) (*validatorResolver, error) {
+	val, err := r.st.ValidatorAtIndexReadOnly(idx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get validator %d", idx)
+	}
+	balance, err := r.st.BalanceAtIndex(idx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get balance of validator %d", idx)
+	}
+	return &validatorResolver{index: idx, val: val, balance: balance, epoch: slots.ToEpoch(r.st.Slot())}, nil
+}
+
+// validatorIndices returns the indices of validators given by index or by hex encoded public key. Unknown validators
+// are skipped.
+func validatorIndices(st state.ReadOnlyBeaconState, ids []string) ([]primitives.ValidatorIndex, error) {
+	indices := make([]primitives.ValidatorIndex, 0, len(ids))
+	numVals := uint64(st.NumValidators())
+	for _, id := range ids {
+		if strings.HasPrefix(id, "0x") {
+			pubkey, err := hexutil.Decode(id)
+			if err != nil || len(pubkey) != fieldparams.BLSPubkeyLength {
+				return nil, errors.Errorf("invalid validator public key %s", id)
+			}
+			if idx, ok := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(pubkey)); ok {
+				indices = append(indices, idx)
+			}
+			continue
+		}
+		idx, err := strconv.ParseUint(id, 10, 64)
+		if err != nil {
+			return nil, errors.Errorf("invalid validator index %s", id)
+		}
+		if idx < numVals {
+			indices = append(indices, primitives.ValidatorIndex(idx))
+		}
+	}
+	return indices, nil
+}
+
+// hasStatus returns true if the status or the sub-status of a validator at an epoch is one of the given statuses, or
+// if no status is given.
+func hasStatus(val state.ReadOnlyValidator, epoch primitives.Epoch, statuses []validator.Status) (bool, error) {
+	if len(statuses) == 0 {
+		return true, nil
+	}
+	status, err := helpers.ValidatorStatus(val, epoch)
+	if err != nil {
+		return false, errors.Wrap(err, "could not get validator status")
+	}
+	subStatus, err := helpers.ValidatorSubStatus(val, epoch)
+	if err != nil {
+		return false, errors.Wrap(err, "could not get validator sub-status")
+	}
+	for _, s := range statuses {
+		if s == status || s == subStatus {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type forkResolver struct {
+	fork *ethpb.Fork
+}
+
+func (r *forkResolver) PreviousVersion() Bytes {
+	return r.fork.GetPreviousVersion()
+}
+
+func (r *forkResolver) CurrentVersion() Bytes {
+	return r.fork.GetCurrentVersion()
+}
+
+func (r *forkResolver) Epoch() Uint64 {
+	return Uint64(r.fork.GetEpoch())
+}
+
+type validatorResolver struct {
+	index   primitives.ValidatorIndex
+	val     state.ReadOnlyValidator
+	balance uint64
+	epoch   primitives.Epoch
+}
+
+func (r *validatorResolver) Index() Uint64 {
+	return Uint64(r.index)
+}
+
+func (r *validatorResolver) Pubkey() Bytes {
+	pubkey := r.val.PublicKey()
+	return pubkey[:]
+}
+
+func (r *validatorResolver) WithdrawalCredentials() Bytes {
+	return r.val.GetWithdrawalCredentials()
+}
+
+func (r *validatorResolver) EffectiveBalance() Uint64 {
+	return Uint64(r.val.EffectiveBalance())
+}
+
+func (r *validatorResolver) Balance() Uint64 {
+	return Uint64(r.balance)
+}
+
+func (r *validatorResolver) Slashed() bool {
+	return r.val.Slashed()
+}
+
+func (r *validatorResolver) ActivationEligibilityEpoch() Uint64 {
+	return Uint64(r.val.ActivationEligibilityEpoch())
+}
+
+func (r *validatorResolver) ActivationEpoch() Uint64 {
+	return Uint64(r.val.ActivationEpoch())
+}
+
+func (r *validatorResolver) ExitEpoch() Uint64 {
+	return Uint64(r.val.ExitEpoch())
+}
+
+func (r *validatorResolver) WithdrawableEpoch() Uint64 {
+	return Uint64(r.val.WithdrawableEpoch())
+}
+
+func (r *validatorResolver) Status() (string, error) {
+	status, err := helpers.ValidatorSubStatus(r.val, r.epoch)
+	if err != nil {
+		return "", errors.Wrap(err, "could not get validator status")
+	}
+	return status.String(), nil
+}
+
+type balanceResolver struct {
+	index   primitives.ValidatorIndex
+	balance uint64
+}
+
+func (r *balanceResolver) Index() Uint64 {
+	return Uint64(r.index)
+}
+
+func (r *balanceResolver) Balance() Uint64 {
+	return Uint64(r.balance)
+}
+
+type committeeResolver struct {
+	slot       primitives.Slot
+	index      primitives.CommitteeIndex
+	validators []primitives.ValidatorIndex
+}
+
+func (r *committeeResolver) Index() Uint64 {
+	return Uint64(r.index)
+}
+
+func (r *committeeResolver) Slot() Uint64 {
+	return Uint64(r.slot)
+}
+
+func (r *committeeResolver) Validators() []Uint64 {
+	validators := make([]Uint64, len(r.validators))
+	for i, v := range r.validators {
+		validators[i] = Uint64(v)
+	}
+	return validators
+}
+
+type syncCommitteeResolver struct {
+	validators      []Uint64
+	aggregatePubkey []byte
+}
+
+func (r *syncCommitteeResolver) Validators() []Uint64 {
+	return r.validators
+}
+
+func (r *syncCommitteeResolver) AggregatePubkey() Bytes {
+	return r.aggregatePubkey
+}
@@ -39,6 +39,24 @@ func (e BlockIdParseError) Error() string {
 	return e.message
 }
 
+// BlockPrunedError represents an error scenario where the requested block is older than the
+// earliest slot retained by the database, and has been removed by historical pruning.
+type BlockPrunedError struct {
+	message string
+}
+
+// NewBlockPrunedError creates a new error instance.
+func NewBlockPrunedError(slot, earliest primitives.Slot) BlockPrunedError {
+	return BlockPrunedError{
+		message: fmt.Sprintf("block at slot %d has been pruned, earliest available slot is %d", slot, earliest),
+	}
+}
+
+// Error returns the underlying error message.
+func (e *BlockPrunedError) Error() string {
+	return e.message
+}
+
 // Blocker is responsible for retrieving blocks.
 type Blocker interface {
 	Block(ctx context.Context, id []byte) (interfaces.ReadOnlySignedBeaconBlock, error)
@@ -115,6 +133,14 @@ func (p *BeaconDbBlocker) Block(ctx context.Context, id []byte) (interfaces.Read
 			}
 			numBlks := len(blks)
 			if numBlks == 0 {
+				pruned, earliest, err := isPruned(ctx, p.BeaconDB, primitives.Slot(slot))
+				if err != nil {
+					return nil, err
+				}
+				if pruned {
+					e := NewBlockPrunedError(primitives.Slot(slot), earliest)
+					return nil, &e
+				}
 				return nil, nil
 			}
 			for i, b := range blks {
@@ -194,6 +220,14 @@ func (p *BeaconDbBlocker) Blobs(ctx context.Context, id string, indices []uint64
 			}
 			ok, roots, err := p.BeaconDB.BlockRootsBySlot(ctx, primitives.Slot(slot))
 			if !ok {
+				pruned, earliest, pErr := isPruned(ctx, p.BeaconDB, primitives.Slot(slot))
+				if pErr != nil {
+					return nil, &core.RpcError{Err: errors.Wrap(pErr, "could not determine earliest available slot"), Reason: core.Internal}
+				}
+				if pruned {
+					e := NewBlockPrunedError(primitives.Slot(slot), earliest)
+					return nil, &core.RpcError{Err: &e, Reason: core.Pruned}
+				}
 				return nil, &core.RpcError{Err: fmt.Errorf("block not found: no block roots at slot %d", slot), Reason: core.NotFound}
 			}
 			if err != nil {
@@ -222,8 +256,8 @@ func (p *BeaconDbBlocker) Blobs(ctx context.Context, id string, indices []uint64
 	if err != nil {
 		return nil, &core.RpcError{Err: errors.Wrap(err, "failed to retrieve block from db"), Reason: core.Internal}
 	}
-	// if block is not in the retention window  return 200 w/ empty list
-	if !p.BlobStorage.WithinRetentionPeriod(slots.ToEpoch(b.Block().Slot()), slots.ToEpoch(p.GenesisTimeFetcher.CurrentSlot())) {
+	// if blobs for the block can't be served from storage return 200 w/ empty list
+	if !p.BlobStorage.ServesEpoch(slots.ToEpoch(b.Block().Slot()), slots.ToEpoch(p.GenesisTimeFetcher.CurrentSlot())) {
 		return make([]*blocks.VerifiedROBlob, 0), nil
 	}
 	commitments, err := b.Block().Body().BlobKzgCommitments()
@@ -235,7 +269,7 @@ func (p *BeaconDbBlocker) Blobs(ctx context.Context, id string, indices []uint64
 		return make([]*blocks.VerifiedROBlob, 0), nil
 	}
 	if len(indices) == 0 {
-		m, err := p.BlobStorage.Indices(bytesutil.ToBytes32(root))
+		m, err := p.BlobStorage.IndicesWithRemote(bytesutil.ToBytes32(root))
 		if err != nil {
 			log.WithFields(log.Fields{
 				"blockRoot": hexutil.Encode(root),
@@ -263,3 +297,16 @@ func (p *BeaconDbBlocker) Blobs(ctx context.Context, id string, indices []uint64
 	}
 	return blobs, nil
 }
+
+// isPruned reports whether history at the given slot has been removed from the database by historical
+// pruning, along with the earliest slot that is still available. The genesis slot is never pruned.
+func isPruned(ctx context.Context, d db.ReadOnlyDatabase, slot primitives.Slot) (bool, primitives.Slot, error) {
+	if d == nil {
+		return false, 0, nil
+	}
+	earliest, err := d.EarliestAvailableSlot(ctx)
+	if err != nil {
+		return false, 0, errors.Wrap(err, "could not get earliest available slot")
+	}
+	return slot != params.BeaconConfig().GenesisSlot && slot < earliest, earliest, nil
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
 	mockChain "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
 	testDB "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
@@ -301,3 +302,52 @@ func TestGetBlob(t *testing.T) {
 		require.Equal(t, 0, len(verifiedBlobs))
 	})
 }
+
+func TestGetBlock_Pruned(t *testing.T) {
+	beaconDB := testDB.SetupDB(t)
+	ctx := context.Background()
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+
+	genBlk, blkContainers := testutil.FillDBWithBlocks(ctx, t, beaconDB)
+	// The blocks in the test db do not form a chain, so we use the genesis root as the finalized root
+	// to avoid walking the ancestry while building the finalized index.
+	genRoot, err := genBlk.Block.HashTreeRoot()
+	require.NoError(t, err)
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveState(ctx, st, genRoot))
+	require.NoError(t, beaconDB.SaveFinalizedCheckpoint(ctx, &ethpbalpha.Checkpoint{Epoch: 2, Root: genRoot[:]}))
+	_, err = beaconDB.DeleteHistoricalDataBeforeSlot(ctx, slotsPerEpoch, int(slotsPerEpoch))
+	require.NoError(t, err)
+
+	canonicalRoots := make(map[[32]byte]bool)
+	for _, bContr := range blkContainers {
+		canonicalRoots[bytesutil.ToBytes32(bContr.BlockRoot)] = true
+	}
+	fetcher := &BeaconDbBlocker{
+		BeaconDB: beaconDB,
+		ChainInfoFetcher: &mockChain.ChainService{
+			DB:             beaconDB,
+			CanonicalRoots: canonicalRoots,
+		},
+	}
+
+	_, err = fetcher.Block(ctx, []byte("1"))
+	var prunedErr *BlockPrunedError
+	require.Equal(t, true, errors.As(err, &prunedErr))
+	assert.ErrorContains(t, fmt.Sprintf("earliest available slot is %d", slotsPerEpoch), err)
+
+	// Slots at or above the earliest available slot are unaffected.
+	blk, err := fetcher.Block(ctx, []byte(fmt.Sprintf("%d", slotsPerEpoch)))
+	require.NoError(t, err)
+	pb, err := blk.Proto()
+	require.NoError(t, err)
+	assert.DeepEqual(t, blkContainers[slotsPerEpoch].Block.(*ethpbalpha.BeaconBlockContainer_Phase0Block).Phase0Block, pb)
+
+	// Genesis is never pruned.
+	blk, err = fetcher.Block(ctx, []byte("genesis"))
+	require.NoError(t, err)
+	pb, err = blk.Proto()
+	require.NoError(t, err)
+	assert.DeepEqual(t, genBlk, pb)
+}
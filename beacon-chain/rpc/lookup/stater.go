@@ -72,6 +72,24 @@ func (e *StateRootNotFoundError) Error() string {
 	return e.message
 }
 
+// StatePrunedError represents an error scenario where the requested state is older than the
+// earliest slot retained by the database, and can no longer be regenerated.
+type StatePrunedError struct {
+	message string
+}
+
+// NewStatePrunedError creates a new error instance.
+func NewStatePrunedError(slot, earliest primitives.Slot) StatePrunedError {
+	return StatePrunedError{
+		message: fmt.Sprintf("state at slot %d has been pruned, earliest available slot is %d", slot, earliest),
+	}
+}
+
+// Error returns the underlying error message.
+func (e *StatePrunedError) Error() string {
+	return e.message
+}
+
 // Stater is responsible for retrieving states.
 type Stater interface {
 	State(ctx context.Context, id []byte) (state.BeaconState, error)
@@ -230,6 +248,14 @@ func (p *BeaconDbStater) StateBySlot(ctx context.Context, target primitives.Slot
 	if target > p.GenesisTimeFetcher.CurrentSlot() {
 		return nil, errors.New("requested slot is in the future")
 	}
+	pruned, earliest, err := isPruned(ctx, p.BeaconDB, target)
+	if err != nil {
+		return nil, err
+	}
+	if pruned {
+		e := NewStatePrunedError(target, earliest)
+		return nil, &e
+	}
 
 	st, err := p.ReplayerBuilder.ReplayerForSlot(target).ReplayBlocks(ctx)
 	if err != nil {
@@ -130,9 +130,10 @@ func (s *Server) GetIndividualVotes(w http.ResponseWriter, r *http.Request) {
 		httputil.HandleError(w, rpcError.Err.Error(), core.ErrorReasonToHTTP(rpcError.Reason))
 		return
 	}
-	v := make([]*structs.IndividualVote, 0, len(votes.IndividualVotes))
+	// The votes are streamed rather than collected, as all the validators can be requested.
+	lw := httputil.NewJsonListWriter(w, "individual_votes", &struct{}{})
 	for _, vote := range votes.IndividualVotes {
-		v = append(v, &structs.IndividualVote{
+		if !lw.Write(&structs.IndividualVote{
 			Epoch:                            fmt.Sprintf("%d", vote.Epoch),
 			PublicKey:                        hexutil.Encode(vote.PublicKey),
 			ValidatorIndex:                   fmt.Sprintf("%d", vote.ValidatorIndex),
@@ -149,12 +150,11 @@ func (s *Server) GetIndividualVotes(w http.ResponseWriter, r *http.Request) {
 			InclusionSlot:                    fmt.Sprintf("%d", vote.InclusionSlot),
 			InclusionDistance:                fmt.Sprintf("%d", vote.InclusionDistance),
 			InactivityScore:                  fmt.Sprintf("%d", vote.InactivityScore),
-		})
-	}
-	response := &structs.GetIndividualVotesResponse{
-		IndividualVotes: v,
+		}) {
+			return
+		}
 	}
-	httputil.WriteJson(w, response)
+	lw.Close()
 }
 
 // GetChainHead retrieves information about the head of the beacon chain from
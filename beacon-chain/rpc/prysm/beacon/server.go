@@ -2,6 +2,7 @@ package beacon
 
 import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
 	beacondb "github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/core"
@@ -23,4 +24,5 @@ type Server struct {
 	CoreService           *core.Service
 	Broadcaster           p2p.Broadcaster
 	BlobReceiver          blockchain.BlobReceiver
+	ValidatorIndexCache   *cache.ValidatorIndexCache
 }
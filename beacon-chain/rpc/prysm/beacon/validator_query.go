@@ -0,0 +1,244 @@
+package beacon
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/shared"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/validator"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+)
+
+// validatorQuery holds the filters of a validator query. Nil and empty filters match every validator.
+type validatorQuery struct {
+	credentialsPrefix []byte
+	address           *[20]byte
+	credentialTypes   map[byte]bool
+	statuses          []validator.Status
+	previousStatuses  []validator.Status
+	fromIndex         primitives.ValidatorIndex
+	toIndex           primitives.ValidatorIndex
+}
+
+// validatorQueryMetadata holds the fields of a validator query response, which are written before the streamed list.
+type validatorQueryMetadata struct {
+	Epoch         string `json:"epoch"`
+	PreviousEpoch string `json:"previous_epoch"`
+}
+
+// QueryValidators returns the validators matching every given filter, from the validator index built at the start
+// of the current epoch. The filters are:
+//   - withdrawal_credentials_prefix: a hex prefix of the withdrawal credentials.
+//   - withdrawal_address: the execution address of eth1 and compounding withdrawal credentials.
+//   - credential_type: bls, eth1 or compounding. It can be repeated.
+//   - status: a status or sub-status at the epoch of the index. It can be repeated.
+//   - previous_status: a status or sub-status at the epoch of the previous index, one epoch earlier. It can be
+//     repeated. Combined with status, it finds the validators which moved between status categories.
+//   - from_index and to_index: the range of validator indices, both included.
+//
+// The index is only kept by nodes running with the --validator-query-index flag.
+func (s *Server) QueryValidators(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "beacon.QueryValidators")
+	defer span.End()
+
+	if s.ValidatorIndexCache == nil {
+		httputil.HandleError(w, "Validator index is disabled, run the node with the --validator-query-index flag", http.StatusNotFound)
+		return
+	}
+	index := s.ValidatorIndexCache.Index()
+	if index == nil {
+		httputil.HandleError(w, "Validator index is built at the next epoch transition", http.StatusServiceUnavailable)
+		return
+	}
+	q, ok := parseValidatorQuery(w, r, index)
+	if !ok {
+		return
+	}
+
+	lw := httputil.NewJsonListWriter(w, "data", &validatorQueryMetadata{
+		Epoch:         strconv.FormatUint(uint64(index.Epoch), 10),
+		PreviousEpoch: strconv.FormatUint(uint64(index.PreviousEpoch), 10),
+	})
+	write := func(idx primitives.ValidatorIndex) bool {
+		v, err := q.match(index, idx)
+		if err != nil {
+			lw.HandleError(fmt.Sprintf("Could not get status of validator %d: %v", idx, err), http.StatusInternalServerError)
+			return false
+		}
+		return v == nil || lw.Write(v)
+	}
+	if q.address != nil {
+		// Validators sharing a withdrawal address are indexed, so they are found without scanning every validator.
+		for _, idx := range index.ByWithdrawalAddress(*q.address) {
+			if idx >= q.fromIndex && idx <= q.toIndex && !write(idx) {
+				return
+			}
+		}
+	} else {
+		for idx := q.fromIndex; idx <= q.toIndex; idx++ {
+			if !write(idx) {
+				return
+			}
+		}
+	}
+	lw.Close()
+}
+
+func parseValidatorQuery(w http.ResponseWriter, r *http.Request, index *cache.ValidatorIndex) (*validatorQuery, bool) {
+	query := r.URL.Query()
+	q := &validatorQuery{}
+
+	if raw := query.Get("withdrawal_credentials_prefix"); raw != "" {
+		prefix, err := hexutil.Decode(raw)
+		if err != nil || len(prefix) > 32 {
+			httputil.HandleError(w, "withdrawal_credentials_prefix must be a hex string of at most 32 bytes", http.StatusBadRequest)
+			return nil, false
+		}
+		q.credentialsPrefix = prefix
+	}
+	if raw := query.Get("withdrawal_address"); raw != "" {
+		addr, err := hexutil.Decode(raw)
+		if err != nil || len(addr) != 20 {
+			httputil.HandleError(w, "withdrawal_address must be a hex string of 20 bytes", http.StatusBadRequest)
+			return nil, false
+		}
+		a := bytesutil.ToBytes20(addr)
+		q.address = &a
+	}
+	for _, raw := range query["credential_type"] {
+		if q.credentialTypes == nil {
+			q.credentialTypes = make(map[byte]bool)
+		}
+		switch strings.ToLower(raw) {
+		case "bls":
+			q.credentialTypes[params.BeaconConfig().BLSWithdrawalPrefixByte] = true
+		case "eth1":
+			q.credentialTypes[params.BeaconConfig().ETH1AddressWithdrawalPrefixByte] = true
+		case "compounding":
+			q.credentialTypes[params.BeaconConfig().CompoundingWithdrawalPrefixByte] = true
+		default:
+			httputil.HandleError(w, fmt.Sprintf("Invalid credential_type %s, expected bls, eth1 or compounding", raw), http.StatusBadRequest)
+			return nil, false
+		}
+	}
+	var ok bool
+	if q.statuses, ok = statusesFromQuery(w, query["status"], "status"); !ok {
+		return nil, false
+	}
+	if q.previousStatuses, ok = statusesFromQuery(w, query["previous_status"], "previous_status"); !ok {
+		return nil, false
+	}
+
+	if index.NumValidators() == 0 {
+		httputil.HandleError(w, "Validator index is empty", http.StatusNotFound)
+		return nil, false
+	}
+	last := uint64(index.NumValidators() - 1)
+	_, from, ok := shared.UintFromQuery(w, r, "from_index", false)
+	if !ok {
+		return nil, false
+	}
+	rawTo, to, ok := shared.UintFromQuery(w, r, "to_index", false)
+	if !ok {
+		return nil, false
+	}
+	if rawTo == "" || to > last {
+		to = last
+	}
+	if from > to {
+		httputil.HandleError(w, "from_index must not be greater than to_index or the last validator index", http.StatusBadRequest)
+		return nil, false
+	}
+	q.fromIndex = primitives.ValidatorIndex(from)
+	q.toIndex = primitives.ValidatorIndex(to)
+	return q, true
+}
+
+func statusesFromQuery(w http.ResponseWriter, raw []string, name string) ([]validator.Status, bool) {
+	statuses := make([]validator.Status, 0, len(raw))
+	for _, rawStatus := range raw {
+		ok, status := validator.StatusFromString(strings.ToLower(rawStatus))
+		if !ok {
+			httputil.HandleError(w, fmt.Sprintf("Invalid %s %s", name, rawStatus), http.StatusBadRequest)
+			return nil, false
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, true
+}
+
+// match returns the validator at the given index if it matches the query, or nil otherwise.
+func (q *validatorQuery) match(index *cache.ValidatorIndex, idx primitives.ValidatorIndex) (*structs.QueriedValidator, error) {
+	e := index.Entry(idx)
+	creds := e.GetWithdrawalCredentials()
+	if q.credentialTypes != nil && !q.credentialTypes[creds[0]] {
+		return nil, nil
+	}
+	if !bytes.HasPrefix(creds, q.credentialsPrefix) {
+		return nil, nil
+	}
+	if q.address != nil {
+		if addr, ok := e.WithdrawalAddress(); !ok || addr != *q.address {
+			return nil, nil
+		}
+	}
+	status, ok, err := matchStatus(e, index.Epoch, q.statuses)
+	if err != nil || !ok {
+		return nil, err
+	}
+	pubkey := e.PublicKey()
+	v := &structs.QueriedValidator{
+		Index:                 strconv.FormatUint(uint64(idx), 10),
+		Pubkey:                hexutil.Encode(pubkey[:]),
+		WithdrawalCredentials: hexutil.Encode(creds),
+		EffectiveBalance:      strconv.FormatUint(e.EffectiveBalance(), 10),
+		Status:                status.String(),
+	}
+	prev, existed := index.PreviousEntry(idx)
+	if !existed {
+		// Validators added since the previous index have no previous status.
+		if len(q.previousStatuses) > 0 {
+			return nil, nil
+		}
+		return v, nil
+	}
+	prevStatus, ok, err := matchStatus(prev, index.PreviousEpoch, q.previousStatuses)
+	if err != nil || !ok {
+		return nil, err
+	}
+	v.PreviousStatus = prevStatus.String()
+	return v, nil
+}
+
+// matchStatus returns the sub-status of a validator at an epoch, and true if the sub-status or the status is one of
+// the given statuses or if none are given.
+func matchStatus(e *cache.ValidatorIndexEntry, epoch primitives.Epoch, statuses []validator.Status) (validator.Status, bool, error) {
+	subStatus, err := helpers.ValidatorSubStatus(e, epoch)
+	if err != nil {
+		return 0, false, err
+	}
+	if len(statuses) == 0 {
+		return subStatus, true, nil
+	}
+	status, err := helpers.ValidatorStatus(e, epoch)
+	if err != nil {
+		return 0, false, err
+	}
+	for _, s := range statuses {
+		if s == status || s == subStatus {
+			return subStatus, true, nil
+		}
+	}
+	return 0, false, nil
+}
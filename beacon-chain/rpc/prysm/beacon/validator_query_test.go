@@ -0,0 +1,140 @@
+package beacon
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+func TestQueryValidators(t *testing.T) {
+	cfg := params.BeaconConfig()
+	far := cfg.FarFutureEpoch
+	addrA := bytes.Repeat([]byte{0xaa}, 20)
+	addrB := bytes.Repeat([]byte{0xbb}, 20)
+	val := func(prefix byte, addr []byte, eligible, activation, exit, withdrawable primitives.Epoch) *eth.Validator {
+		creds := make([]byte, 32)
+		creds[0] = prefix
+		copy(creds[12:], addr)
+		return &eth.Validator{
+			PublicKey:                  make([]byte, 48),
+			WithdrawalCredentials:      creds,
+			EffectiveBalance:           cfg.MaxEffectiveBalance,
+			ActivationEligibilityEpoch: eligible,
+			ActivationEpoch:            activation,
+			ExitEpoch:                  exit,
+			WithdrawableEpoch:          withdrawable,
+		}
+	}
+	vals := []*eth.Validator{
+		val(cfg.BLSWithdrawalPrefixByte, nil, 0, 0, far, far),
+		val(cfg.ETH1AddressWithdrawalPrefixByte, addrA, 0, 0, far, far),
+		val(cfg.CompoundingWithdrawalPrefixByte, addrA, 0, 0, far, far),
+		val(cfg.ETH1AddressWithdrawalPrefixByte, addrB, 0, 0, 5, 300),
+	}
+	c := cache.NewValidatorIndexCache()
+	update := func(epoch primitives.Epoch, vals []*eth.Validator) {
+		st, err := util.NewBeaconState()
+		require.NoError(t, err)
+		require.NoError(t, st.SetValidators(vals))
+		start, err := slots.EpochStart(epoch)
+		require.NoError(t, err)
+		require.NoError(t, st.SetSlot(start))
+		require.NoError(t, c.Update(st))
+	}
+	s := &Server{}
+
+	query := func(params string) (int, []byte) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/beacon/validators/query?"+params, nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.QueryValidators(writer, request)
+		return writer.Code, writer.Body.Bytes()
+	}
+	t.Run("disabled", func(t *testing.T) {
+		code, _ := query("")
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+	s.ValidatorIndexCache = c
+	t.Run("not built", func(t *testing.T) {
+		code, _ := query("")
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+	})
+
+	update(9, vals)
+	// Validator 2 initiates its exit and validator 4 is added.
+	exiting := val(cfg.CompoundingWithdrawalPrefixByte, addrA, 0, 0, 12, 268)
+	update(10, []*eth.Validator{vals[0], vals[1], exiting, vals[3], val(cfg.ETH1AddressWithdrawalPrefixByte, addrA, 9, far, far, far)})
+
+	tests := []struct {
+		name    string
+		params  string
+		indices []string
+	}{
+		{name: "all", params: "", indices: []string{"0", "1", "2", "3", "4"}},
+		{name: "address", params: "withdrawal_address=0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", indices: []string{"1", "2", "4"}},
+		{name: "address and status", params: "withdrawal_address=0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa&status=active", indices: []string{"1", "2"}},
+		{name: "bls", params: "credential_type=bls", indices: []string{"0"}},
+		{name: "compounding", params: "credential_type=compounding", indices: []string{"2"}},
+		{name: "credentials prefix", params: "withdrawal_credentials_prefix=0x01", indices: []string{"1", "3", "4"}},
+		{name: "status change", params: "status=active_exiting&previous_status=active_ongoing", indices: []string{"2"}},
+		{name: "previous status", params: "previous_status=active", indices: []string{"0", "1", "2"}},
+		{name: "index range", params: "from_index=1&to_index=2", indices: []string{"1", "2"}},
+		{name: "address and index range", params: "withdrawal_address=0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa&from_index=3", indices: []string{"4"}},
+		{name: "no match", params: "status=withdrawal_done", indices: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, body := query(tt.params)
+			require.Equal(t, http.StatusOK, code)
+			resp := &structs.QueryValidatorsResponse{}
+			require.NoError(t, json.Unmarshal(body, resp))
+			assert.Equal(t, "10", resp.Epoch)
+			assert.Equal(t, "9", resp.PreviousEpoch)
+			indices := make([]string, len(resp.Data))
+			for i, v := range resp.Data {
+				indices[i] = v.Index
+			}
+			assert.DeepEqual(t, tt.indices, indices)
+		})
+	}
+
+	t.Run("statuses", func(t *testing.T) {
+		code, body := query("from_index=2&to_index=4")
+		require.Equal(t, http.StatusOK, code)
+		resp := &structs.QueryValidatorsResponse{}
+		require.NoError(t, json.Unmarshal(body, resp))
+		require.Equal(t, 3, len(resp.Data))
+		assert.Equal(t, "active_exiting", resp.Data[0].Status)
+		assert.Equal(t, "active_ongoing", resp.Data[0].PreviousStatus)
+		assert.Equal(t, "0x020000000000000000000000aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", resp.Data[0].WithdrawalCredentials)
+		assert.Equal(t, "exited_unslashed", resp.Data[1].Status)
+		assert.Equal(t, "pending_queued", resp.Data[2].Status)
+		assert.Equal(t, "", resp.Data[2].PreviousStatus)
+	})
+	t.Run("invalid filters", func(t *testing.T) {
+		for _, params := range []string{
+			"status=foo",
+			"previous_status=foo",
+			"credential_type=foo",
+			"withdrawal_address=0x01",
+			"withdrawal_credentials_prefix=foo",
+			"from_index=4&to_index=3",
+			"from_index=foo",
+		} {
+			code, _ := query(params)
+			assert.Equal(t, http.StatusBadRequest, code, params)
+		}
+	})
+}
@@ -0,0 +1,108 @@
+package node
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+)
+
+// GetGossipDiagnostics returns the mesh of each gossip topic with the message delivery events of the topic over a
+// sliding window, along with the gossipsub score components of the peers. The topics can be narrowed down with the
+// topic query parameter, which matches any topic containing it.
+func (s *Server) GetGossipDiagnostics(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.GetGossipDiagnostics")
+	defer span.End()
+
+	if s.GossipDiagnosticsProvider == nil {
+		httputil.HandleError(w, "Gossip diagnostics are not supported", http.StatusNotImplemented)
+		return
+	}
+	snap := s.GossipDiagnosticsProvider.GossipDiagnostics()
+	httputil.WriteJson(w, &structs.GossipDiagnosticsResponse{Data: gossipDiagnostics(snap, r.URL.Query().Get("topic"))})
+}
+
+// gossipDiagnostics converts a snapshot of the gossipsub router, keeping the topics containing the filter.
+func gossipDiagnostics(snap *p2p.GossipDiagnostics, filter string) *structs.GossipDiagnostics {
+	resp := &structs.GossipDiagnostics{
+		Time:          snap.Time.UTC().Format(time.RFC3339),
+		WindowSeconds: formatScore(snap.Window.Seconds()),
+		IwantReceived: strconv.FormatUint(snap.IWantReceived, 10),
+		IwantSent:     strconv.FormatUint(snap.IWantSent, 10),
+		Topics:        make([]*structs.GossipTopicDiagnostics, 0, len(snap.Topics)),
+		PeerScores:    make([]*structs.GossipPeerScore, 0, len(snap.Scores)),
+	}
+	if !snap.ScoresTime.IsZero() {
+		resp.ScoresTime = snap.ScoresTime.UTC().Format(time.RFC3339)
+	}
+	perSecond := func(n uint64) string {
+		if snap.Window <= 0 {
+			return "0"
+		}
+		return formatScore(float64(n) / snap.Window.Seconds())
+	}
+	for topic, t := range snap.Topics {
+		if !strings.Contains(topic, filter) {
+			continue
+		}
+		mesh := make([]string, len(t.Mesh))
+		for i, pid := range t.Mesh {
+			mesh[i] = pid.String()
+		}
+		resp.Topics = append(resp.Topics, &structs.GossipTopicDiagnostics{
+			Topic:               topic,
+			Mesh:                mesh,
+			Delivered:           strconv.FormatUint(t.Delivered, 10),
+			Duplicates:          strconv.FormatUint(t.Duplicates, 10),
+			Late:                strconv.FormatUint(t.Late, 10),
+			Rejected:            strconv.FormatUint(t.Rejected, 10),
+			DuplicatesPerSecond: perSecond(t.Duplicates),
+			LatePerSecond:       perSecond(t.Late),
+			IhaveReceived:       strconv.FormatUint(t.IHaveReceived, 10),
+			IhaveSent:           strconv.FormatUint(t.IHaveSent, 10),
+		})
+	}
+	sort.Slice(resp.Topics, func(i, j int) bool {
+		return resp.Topics[i].Topic < resp.Topics[j].Topic
+	})
+	for pid, score := range snap.Scores {
+		topics := make([]*structs.GossipTopicScore, 0, len(score.Topics))
+		for topic, t := range score.Topics {
+			if !strings.Contains(topic, filter) {
+				continue
+			}
+			topics = append(topics, &structs.GossipTopicScore{
+				Topic:                    topic,
+				TimeInMeshSeconds:        formatScore(t.TimeInMesh.Seconds()),
+				FirstMessageDeliveries:   formatScore(t.FirstMessageDeliveries),
+				MeshMessageDeliveries:    formatScore(t.MeshMessageDeliveries),
+				InvalidMessageDeliveries: formatScore(t.InvalidMessageDeliveries),
+			})
+		}
+		// When filtering, only the peers scored on a matching topic are of interest.
+		if filter != "" && len(topics) == 0 {
+			continue
+		}
+		sort.Slice(topics, func(i, j int) bool {
+			return topics[i].Topic < topics[j].Topic
+		})
+		resp.PeerScores = append(resp.PeerScores, &structs.GossipPeerScore{
+			PeerId:             pid.String(),
+			Score:              formatScore(score.Score),
+			AppSpecificScore:   formatScore(score.AppSpecificScore),
+			IpColocationFactor: formatScore(score.IPColocationFactor),
+			BehaviourPenalty:   formatScore(score.BehaviourPenalty),
+			Topics:             topics,
+		})
+	}
+	sort.Slice(resp.PeerScores, func(i, j int) bool {
+		return resp.PeerScores[i].PeerId < resp.PeerScores[j].PeerId
+	})
+	return resp
+}
@@ -0,0 +1,122 @@
+package node
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	libp2ptest "github.com/libp2p/go-libp2p/p2p/host/peerstore/test"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+type fakeGossipDiagnostics struct {
+	snap *p2p.GossipDiagnostics
+}
+
+func (f *fakeGossipDiagnostics) GossipDiagnostics() *p2p.GossipDiagnostics {
+	return f.snap
+}
+
+func TestGetGossipDiagnostics(t *testing.T) {
+	ids := libp2ptest.GeneratePeerIDs(2)
+	now := time.Now()
+	snap := &p2p.GossipDiagnostics{
+		Time:          now,
+		Window:        10 * time.Second,
+		IWantReceived: 3,
+		IWantSent:     4,
+		Topics: map[string]*p2p.TopicDiagnostics{
+			"/eth2/00000000/beacon_block/ssz_snappy": {
+				Mesh:      []peer.ID{ids[0], ids[1]},
+				Delivered: 10,
+			},
+			"/eth2/00000000/beacon_attestation_5/ssz_snappy": {
+				Mesh:          []peer.ID{ids[0]},
+				Delivered:     100,
+				Duplicates:    50,
+				Late:          5,
+				Rejected:      1,
+				IHaveReceived: 7,
+				IHaveSent:     8,
+			},
+		},
+		Scores: map[peer.ID]*pubsub.PeerScoreSnapshot{
+			ids[0]: {
+				Score:            12.5,
+				BehaviourPenalty: 1,
+				Topics: map[string]*pubsub.TopicScoreSnapshot{
+					"/eth2/00000000/beacon_attestation_5/ssz_snappy": {
+						TimeInMesh:               time.Minute,
+						FirstMessageDeliveries:   20,
+						MeshMessageDeliveries:    30,
+						InvalidMessageDeliveries: 2,
+					},
+				},
+			},
+			ids[1]: {Score: -3},
+		},
+		ScoresTime: now,
+	}
+	s := &Server{GossipDiagnosticsProvider: &fakeGossipDiagnostics{snap: snap}}
+
+	get := func(query string) *structs.GossipDiagnostics {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/node/gossip"+query, nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetGossipDiagnostics(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GossipDiagnosticsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.NotNil(t, resp.Data)
+		return resp.Data
+	}
+
+	t.Run("all topics", func(t *testing.T) {
+		data := get("")
+		assert.Equal(t, "10", data.WindowSeconds)
+		assert.Equal(t, "3", data.IwantReceived)
+		assert.Equal(t, "4", data.IwantSent)
+		require.Equal(t, 2, len(data.Topics))
+		att := data.Topics[0]
+		assert.Equal(t, "/eth2/00000000/beacon_attestation_5/ssz_snappy", att.Topic)
+		assert.DeepEqual(t, []string{ids[0].String()}, att.Mesh)
+		assert.Equal(t, "100", att.Delivered)
+		assert.Equal(t, "50", att.Duplicates)
+		assert.Equal(t, "5", att.Late)
+		assert.Equal(t, "1", att.Rejected)
+		assert.Equal(t, "5", att.DuplicatesPerSecond)
+		assert.Equal(t, "0.5", att.LatePerSecond)
+		assert.Equal(t, "7", att.IhaveReceived)
+		assert.Equal(t, "8", att.IhaveSent)
+		assert.Equal(t, 2, len(data.PeerScores))
+	})
+	t.Run("filtered by topic", func(t *testing.T) {
+		data := get("?topic=beacon_attestation_5")
+		require.Equal(t, 1, len(data.Topics))
+		require.Equal(t, 1, len(data.PeerScores))
+		score := data.PeerScores[0]
+		assert.Equal(t, ids[0].String(), score.PeerId)
+		assert.Equal(t, "12.5", score.Score)
+		assert.Equal(t, "1", score.BehaviourPenalty)
+		require.Equal(t, 1, len(score.Topics))
+		assert.Equal(t, "60", score.Topics[0].TimeInMeshSeconds)
+		assert.Equal(t, "20", score.Topics[0].FirstMessageDeliveries)
+		assert.Equal(t, "30", score.Topics[0].MeshMessageDeliveries)
+		assert.Equal(t, "2", score.Topics[0].InvalidMessageDeliveries)
+	})
+	t.Run("not supported", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/node/gossip", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		(&Server{}).GetGossipDiagnostics(writer, request)
+		assert.Equal(t, http.StatusNotImplemented, writer.Code)
+	})
+}
@@ -0,0 +1,317 @@
+package node
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
+	p2ptypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/types"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/shared"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+)
+
+// ListPeerScores lists the known peers with the components of their score and the gossip topics whose mesh they
+// are in. The peers can be filtered by connection state with the state query parameter.
+func (s *Server) ListPeerScores(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.ListPeerScores")
+	defer span.End()
+
+	if s.PeerAdmin == nil {
+		httputil.HandleError(w, "Peer administration is not supported", http.StatusNotImplemented)
+		return
+	}
+	states := make(map[string]bool)
+	for _, st := range r.URL.Query()["state"] {
+		states[strings.ToUpper(st)] = true
+	}
+
+	meshTopics := make(map[peer.ID][]string)
+	for topic, pids := range s.PeerAdmin.Meshes() {
+		for _, pid := range pids {
+			meshTopics[pid] = append(meshTopics[pid], topic)
+		}
+	}
+	status := s.PeersFetcher.Peers()
+	banned := status.Banned()
+	pids := status.All()
+	sort.Slice(pids, func(i, j int) bool {
+		return pids[i] < pids[j]
+	})
+	resp := &structs.AdminPeersResponse{Data: make([]*structs.AdminPeer, 0, len(pids))}
+	for _, pid := range pids {
+		p, err := adminPeerInfo(status, pid)
+		if err != nil {
+			httputil.HandleError(w, "Could not get peer info: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if p == nil || (len(states) > 0 && !states[p.State]) {
+			continue
+		}
+		if expiry, ok := banned[pid]; ok {
+			p.BanExpiry = expiry.UTC().Format(time.RFC3339)
+		}
+		p.MeshTopics = meshTopics[pid]
+		sort.Strings(p.MeshTopics)
+		if p.MeshTopics == nil {
+			p.MeshTopics = make([]string, 0)
+		}
+		resp.Data = append(resp.Data, p)
+	}
+	httputil.WriteJson(w, resp)
+}
+
+// ListPeerBans lists the banned peers and IP subnets, along with the expiry of their ban.
+func (s *Server) ListPeerBans(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.ListPeerBans")
+	defer span.End()
+
+	if s.PeerAdmin == nil {
+		httputil.HandleError(w, "Peer administration is not supported", http.StatusNotImplemented)
+		return
+	}
+	bans := &structs.PeerBans{
+		Peers:   make([]*structs.PeerBan, 0),
+		Subnets: make([]*structs.SubnetBan, 0),
+	}
+	for pid, expiry := range s.PeersFetcher.Peers().Banned() {
+		bans.Peers = append(bans.Peers, &structs.PeerBan{PeerId: pid.String(), Expiry: expiry.UTC().Format(time.RFC3339)})
+	}
+	for cidr, expiry := range s.PeerAdmin.BannedSubnets() {
+		bans.Subnets = append(bans.Subnets, &structs.SubnetBan{Cidr: cidr, Expiry: expiry.UTC().Format(time.RFC3339)})
+	}
+	sort.Slice(bans.Peers, func(i, j int) bool {
+		return bans.Peers[i].PeerId < bans.Peers[j].PeerId
+	})
+	sort.Slice(bans.Subnets, func(i, j int) bool {
+		return bans.Subnets[i].Cidr < bans.Subnets[j].Cidr
+	})
+	httputil.WriteJson(w, &structs.PeerBansResponse{Data: bans})
+}
+
+// BanPeer bans a peer ID or an IP subnet, given in CIDR notation, for a duration in seconds. Connected peers matching
+// the ban are disconnected.
+func (s *Server) BanPeer(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.BanPeer")
+	defer span.End()
+
+	if s.PeerAdmin == nil {
+		httputil.HandleError(w, "Peer administration is not supported", http.StatusNotImplemented)
+		return
+	}
+	var req structs.BanPeerRequest
+	if !decodeAdminRequest(w, r, &req) {
+		return
+	}
+	seconds, ok := shared.ValidateUint(w, "duration", req.Duration)
+	if !ok {
+		return
+	}
+	if seconds == 0 {
+		httputil.HandleError(w, "Duration must be greater than zero", http.StatusBadRequest)
+		return
+	}
+	d := time.Duration(seconds) * time.Second
+	pid, subnet, ok := peerOrSubnet(w, req.PeerId, req.Cidr)
+	if !ok {
+		return
+	}
+	if subnet != nil {
+		s.PeerAdmin.BanSubnet(subnet, d)
+	} else {
+		s.PeerAdmin.BanPeer(pid, d)
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// UnbanPeer lifts the ban of the peer ID or IP subnet given by the peer_id or cidr query parameter.
+func (s *Server) UnbanPeer(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.UnbanPeer")
+	defer span.End()
+
+	if s.PeerAdmin == nil {
+		httputil.HandleError(w, "Peer administration is not supported", http.StatusNotImplemented)
+		return
+	}
+	pid, subnet, ok := peerOrSubnet(w, r.URL.Query().Get("peer_id"), r.URL.Query().Get("cidr"))
+	if !ok {
+		return
+	}
+	if subnet != nil {
+		if !s.PeerAdmin.UnbanSubnet(subnet) {
+			httputil.HandleError(w, "Subnet "+subnet.String()+" is not banned", http.StatusNotFound)
+			return
+		}
+	} else {
+		s.PeerAdmin.UnbanPeer(pid)
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// DisconnectPeer sends a goodbye message with the given code to a connected peer and disconnects from it. The code
+// defaults to the generic error code.
+func (s *Server) DisconnectPeer(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "node.DisconnectPeer")
+	defer span.End()
+
+	if s.PeerAdmin == nil {
+		httputil.HandleError(w, "Peer administration is not supported", http.StatusNotImplemented)
+		return
+	}
+	var req structs.DisconnectPeerRequest
+	if !decodeAdminRequest(w, r, &req) {
+		return
+	}
+	pid, err := peer.Decode(req.PeerId)
+	if err != nil {
+		httputil.HandleError(w, "Could not decode peer id: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	code := p2ptypes.GoodbyeCodeGenericError
+	if req.Code != "" {
+		c, ok := shared.ValidateUint(w, "code", req.Code)
+		if !ok {
+			return
+		}
+		code = p2ptypes.RPCGoodbyeCode(c)
+	}
+	state, err := s.PeersFetcher.Peers().ConnectionState(pid)
+	if err != nil || state != peers.PeerConnected {
+		httputil.HandleError(w, "Peer is not connected", http.StatusNotFound)
+		return
+	}
+	if err := s.PeerAdmin.DisconnectWithGoodbye(ctx, pid, code); err != nil {
+		httputil.HandleError(w, "Could not disconnect from peer: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// AddStaticPeer adds a peer, given by its multiaddress, to the peers we keep a connection to for a duration in
+// seconds, or until the node restarts if no duration is given.
+func (s *Server) AddStaticPeer(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.AddStaticPeer")
+	defer span.End()
+
+	if s.PeerAdmin == nil {
+		httputil.HandleError(w, "Peer administration is not supported", http.StatusNotImplemented)
+		return
+	}
+	var req structs.AddStaticPeerRequest
+	if !decodeAdminRequest(w, r, &req) {
+		return
+	}
+	info, err := peer.AddrInfoFromString(req.Addr)
+	if err != nil {
+		httputil.HandleError(w, "Could not derive peer info from multiaddress: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	var d time.Duration
+	if req.Duration != "" {
+		seconds, ok := shared.ValidateUint(w, "duration", req.Duration)
+		if !ok {
+			return
+		}
+		d = time.Duration(seconds) * time.Second
+	}
+	if err := s.PeerAdmin.AddStaticPeer(*info, d); err != nil {
+		httputil.HandleError(w, "Could not add static peer: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func decodeAdminRequest(w http.ResponseWriter, r *http.Request, req interface{}) bool {
+	err := json.NewDecoder(r.Body).Decode(req)
+	switch {
+	case errors.Is(err, io.EOF):
+		httputil.HandleError(w, "No data submitted", http.StatusBadRequest)
+		return false
+	case err != nil:
+		httputil.HandleError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+// peerOrSubnet parses exactly one of a peer ID and an IP subnet in CIDR notation.
+func peerOrSubnet(w http.ResponseWriter, peerID, cidr string) (peer.ID, *net.IPNet, bool) {
+	if (peerID == "") == (cidr == "") {
+		httputil.HandleError(w, "Exactly one of peer_id and cidr must be provided", http.StatusBadRequest)
+		return "", nil, false
+	}
+	if cidr != "" {
+		_, subnet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			httputil.HandleError(w, "Could not parse CIDR: "+err.Error(), http.StatusBadRequest)
+			return "", nil, false
+		}
+		return "", subnet, true
+	}
+	pid, err := peer.Decode(peerID)
+	if err != nil {
+		httputil.HandleError(w, "Could not decode peer id: "+err.Error(), http.StatusBadRequest)
+		return "", nil, false
+	}
+	return pid, nil, true
+}
+
+// adminPeerInfo returns the information of a known peer, including the components of its score.
+func adminPeerInfo(status *peers.Status, pid peer.ID) (*structs.AdminPeer, error) {
+	p, err := httpPeerInfo(status, pid)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		// Peers with an unknown direction, such as the ones restored from the peer store, are not described by
+		// httpPeerInfo.
+		state, err := status.ConnectionState(pid)
+		if err != nil {
+			return nil, nil
+		}
+		p = &structs.Peer{
+			PeerId:    pid.String(),
+			State:     eth.ConnectionState(state).String(),
+			Direction: eth.PeerDirection_UNKNOWN.String(),
+		}
+		if addr, err := status.Address(pid); err == nil && addr != nil {
+			p.LastSeenP2PAddress = addr.String()
+		}
+	}
+	scorers := status.Scorers()
+	gossipScore, behaviourPenalty, _, err := scorers.GossipScorer().GossipData(pid)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get gossip data")
+	}
+	return &structs.AdminPeer{
+		PeerId:    p.PeerId,
+		Enr:       p.Enr,
+		Address:   p.LastSeenP2PAddress,
+		State:     p.State,
+		Direction: p.Direction,
+		Trusted:   status.IsTrustedPeers(pid),
+		Bad:       status.IsBad(pid),
+		Scores: &structs.PeerScores{
+			Total:                  formatScore(scorers.Score(pid)),
+			BadResponses:           formatScore(scorers.BadResponsesScorer().Score(pid)),
+			BlockProvider:          formatScore(scorers.BlockProviderScorer().Score(pid)),
+			PeerStatus:             formatScore(scorers.PeerStatusScorer().Score(pid)),
+			Gossip:                 formatScore(gossipScore),
+			GossipBehaviourPenalty: formatScore(behaviourPenalty),
+		},
+	}, nil
+}
+
+func formatScore(score float64) string {
+	return strconv.FormatFloat(score, 'f', -1, 64)
+}
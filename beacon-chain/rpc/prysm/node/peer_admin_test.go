@@ -0,0 +1,239 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	corenet "github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	libp2ptest "github.com/libp2p/go-libp2p/p2p/host/peerstore/test"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
+	mockp2p "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/testing"
+	p2ptypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/types"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+type fakePeerAdmin struct {
+	status       *peers.Status
+	subnets      map[string]time.Time
+	staticPeers  map[peer.ID]time.Duration
+	disconnected map[peer.ID]p2ptypes.RPCGoodbyeCode
+	meshes       map[string][]peer.ID
+}
+
+func newFakePeerAdmin(status *peers.Status) *fakePeerAdmin {
+	return &fakePeerAdmin{
+		status:       status,
+		subnets:      make(map[string]time.Time),
+		staticPeers:  make(map[peer.ID]time.Duration),
+		disconnected: make(map[peer.ID]p2ptypes.RPCGoodbyeCode),
+		meshes:       make(map[string][]peer.ID),
+	}
+}
+
+func (f *fakePeerAdmin) BanPeer(pid peer.ID, d time.Duration) {
+	f.status.Ban(pid, time.Now().Add(d))
+}
+
+func (f *fakePeerAdmin) UnbanPeer(pid peer.ID) {
+	f.status.Unban(pid)
+}
+
+func (f *fakePeerAdmin) BanSubnet(subnet *net.IPNet, d time.Duration) {
+	f.subnets[subnet.String()] = time.Now().Add(d)
+}
+
+func (f *fakePeerAdmin) UnbanSubnet(subnet *net.IPNet) bool {
+	_, ok := f.subnets[subnet.String()]
+	delete(f.subnets, subnet.String())
+	return ok
+}
+
+func (f *fakePeerAdmin) BannedSubnets() map[string]time.Time {
+	return f.subnets
+}
+
+func (f *fakePeerAdmin) AddStaticPeer(info peer.AddrInfo, d time.Duration) error {
+	f.staticPeers[info.ID] = d
+	return nil
+}
+
+func (f *fakePeerAdmin) DisconnectWithGoodbye(_ context.Context, pid peer.ID, code p2ptypes.RPCGoodbyeCode) error {
+	f.disconnected[pid] = code
+	return nil
+}
+
+func (f *fakePeerAdmin) Meshes() map[string][]peer.ID {
+	return f.meshes
+}
+
+func setupPeerAdminServer(t *testing.T) (*Server, *fakePeerAdmin, []peer.ID) {
+	peerFetcher := &mockp2p.MockPeersProvider{}
+	peerFetcher.ClearPeers()
+	status := peerFetcher.Peers()
+	ids := libp2ptest.GeneratePeerIDs(2)
+	addr, err := ma.NewMultiaddr("/ip4/127.0.0.1/tcp/13000")
+	require.NoError(t, err)
+	status.Add(nil, ids[0], addr, corenet.DirOutbound)
+	status.SetConnectionState(ids[0], peers.PeerConnected)
+	status.Add(nil, ids[1], addr, corenet.DirUnknown)
+	status.SetConnectionState(ids[1], peers.PeerDisconnected)
+	admin := newFakePeerAdmin(status)
+	return &Server{PeersFetcher: peerFetcher, PeerAdmin: admin}, admin, ids
+}
+
+func TestListPeerScores(t *testing.T) {
+	s, admin, ids := setupPeerAdminServer(t)
+	admin.meshes["topic2"] = []peer.ID{ids[0]}
+	admin.meshes["topic1"] = []peer.ID{ids[0]}
+	s.PeersFetcher.Peers().Ban(ids[1], time.Now().Add(time.Hour))
+
+	t.Run("all peers", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/admin/peers", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.ListPeerScores(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.AdminPeersResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+		for _, p := range resp.Data {
+			require.NotNil(t, p.Scores)
+			switch p.PeerId {
+			case ids[0].String():
+				assert.Equal(t, "CONNECTED", p.State)
+				assert.Equal(t, "OUTBOUND", p.Direction)
+				assert.DeepEqual(t, []string{"topic1", "topic2"}, p.MeshTopics)
+				assert.Equal(t, false, p.Bad)
+				assert.Equal(t, "", p.BanExpiry)
+			case ids[1].String():
+				assert.Equal(t, "DISCONNECTED", p.State)
+				assert.Equal(t, "UNKNOWN", p.Direction)
+				assert.Equal(t, 0, len(p.MeshTopics))
+				assert.Equal(t, true, p.Bad)
+				assert.NotEqual(t, "", p.BanExpiry)
+			default:
+				t.Fatalf("Unexpected peer %s", p.PeerId)
+			}
+		}
+	})
+	t.Run("filtered by state", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/admin/peers?state=connected", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.ListPeerScores(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.AdminPeersResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, ids[0].String(), resp.Data[0].PeerId)
+	})
+	t.Run("not supported", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/admin/peers", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		(&Server{PeersFetcher: s.PeersFetcher}).ListPeerScores(writer, request)
+		assert.Equal(t, http.StatusNotImplemented, writer.Code)
+	})
+}
+
+func TestBanAndUnbanPeer(t *testing.T) {
+	s, admin, ids := setupPeerAdminServer(t)
+
+	ban := func(req *structs.BanPeerRequest) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		require.NoError(t, json.NewEncoder(&body).Encode(req))
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/admin/peers/bans", &body)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.BanPeer(writer, request)
+		return writer
+	}
+	unban := func(query string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodDelete, "http://example.com/prysm/v1/admin/peers/bans?"+query, nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.UnbanPeer(writer, request)
+		return writer
+	}
+
+	assert.Equal(t, http.StatusOK, ban(&structs.BanPeerRequest{PeerId: ids[0].String(), Duration: "3600"}).Code)
+	assert.Equal(t, http.StatusOK, ban(&structs.BanPeerRequest{Cidr: "10.0.0.0/8", Duration: "60"}).Code)
+	assert.Equal(t, http.StatusBadRequest, ban(&structs.BanPeerRequest{PeerId: ids[0].String(), Duration: "0"}).Code)
+	assert.Equal(t, http.StatusBadRequest, ban(&structs.BanPeerRequest{Duration: "60"}).Code)
+	assert.Equal(t, http.StatusBadRequest, ban(&structs.BanPeerRequest{PeerId: ids[0].String(), Cidr: "10.0.0.0/8", Duration: "60"}).Code)
+	assert.Equal(t, http.StatusBadRequest, ban(&structs.BanPeerRequest{Cidr: "10.0.0.0", Duration: "60"}).Code)
+
+	request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/admin/peers/bans", nil)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.ListPeerBans(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &structs.PeerBansResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	require.Equal(t, 1, len(resp.Data.Peers))
+	assert.Equal(t, ids[0].String(), resp.Data.Peers[0].PeerId)
+	require.Equal(t, 1, len(resp.Data.Subnets))
+	assert.Equal(t, "10.0.0.0/8", resp.Data.Subnets[0].Cidr)
+
+	assert.Equal(t, http.StatusOK, unban("peer_id="+ids[0].String()).Code)
+	assert.Equal(t, 0, len(s.PeersFetcher.Peers().Banned()))
+	assert.Equal(t, http.StatusOK, unban("cidr=10.0.0.0/8").Code)
+	assert.Equal(t, 0, len(admin.subnets))
+	assert.Equal(t, http.StatusNotFound, unban("cidr=10.0.0.0/8").Code)
+	assert.Equal(t, http.StatusBadRequest, unban("").Code)
+}
+
+func TestDisconnectPeer(t *testing.T) {
+	s, admin, ids := setupPeerAdminServer(t)
+
+	disconnect := func(req *structs.DisconnectPeerRequest) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		require.NoError(t, json.NewEncoder(&body).Encode(req))
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/admin/peers/disconnect", &body)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.DisconnectPeer(writer, request)
+		return writer
+	}
+
+	assert.Equal(t, http.StatusOK, disconnect(&structs.DisconnectPeerRequest{PeerId: ids[0].String()}).Code)
+	assert.Equal(t, p2ptypes.GoodbyeCodeGenericError, admin.disconnected[ids[0]])
+	assert.Equal(t, http.StatusOK, disconnect(&structs.DisconnectPeerRequest{PeerId: ids[0].String(), Code: "2"}).Code)
+	assert.Equal(t, p2ptypes.GoodbyeCodeWrongNetwork, admin.disconnected[ids[0]])
+	assert.Equal(t, http.StatusNotFound, disconnect(&structs.DisconnectPeerRequest{PeerId: ids[1].String()}).Code)
+	assert.Equal(t, http.StatusBadRequest, disconnect(&structs.DisconnectPeerRequest{PeerId: "foo"}).Code)
+	assert.Equal(t, http.StatusBadRequest, disconnect(&structs.DisconnectPeerRequest{PeerId: ids[0].String(), Code: "foo"}).Code)
+}
+
+func TestAddStaticPeer(t *testing.T) {
+	s, admin, _ := setupPeerAdminServer(t)
+
+	add := func(req *structs.AddStaticPeerRequest) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		require.NoError(t, json.NewEncoder(&body).Encode(req))
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/admin/peers/static", &body)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.AddStaticPeer(writer, request)
+		return writer
+	}
+
+	pid, err := peer.Decode("16Uiu2HAm7yD5fhhw1Kihg5pffaGbvKV3k7sqxRGHMZzkb7u9UUxQ")
+	require.NoError(t, err)
+	addr := "/ip4/127.0.0.1/tcp/13000/p2p/" + pid.String()
+	assert.Equal(t, http.StatusOK, add(&structs.AddStaticPeerRequest{Addr: addr, Duration: "600"}).Code)
+	assert.Equal(t, 10*time.Minute, admin.staticPeers[pid])
+	assert.Equal(t, http.StatusOK, add(&structs.AddStaticPeerRequest{Addr: addr}).Code)
+	assert.Equal(t, time.Duration(0), admin.staticPeers[pid])
+	assert.Equal(t, http.StatusBadRequest, add(&structs.AddStaticPeerRequest{Addr: "/ip4/127.0.0.1/tcp/13000"}).Code)
+}
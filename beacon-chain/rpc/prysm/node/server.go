@@ -3,19 +3,30 @@ package node
 import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/execution"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
 )
 
+// StorageUsageFetcher provides the storage usage of the beacon database.
+type StorageUsageFetcher interface {
+	StorageUsage() (*kv.StorageUsage, error)
+}
+
 type Server struct {
 	SyncChecker               sync.Checker
 	OptimisticModeFetcher     blockchain.OptimisticModeFetcher
 	BeaconDB                  db.ReadOnlyDatabase
 	PeersFetcher              p2p.PeersProvider
 	PeerManager               p2p.PeerManager
+	PeerAdmin                 p2p.PeerAdmin
+	GossipDiagnosticsProvider p2p.GossipDiagnosticsProvider
 	MetadataProvider          p2p.MetadataProvider
 	GenesisTimeFetcher        blockchain.TimeFetcher
 	HeadFetcher               blockchain.HeadFetcher
 	ExecutionChainInfoFetcher execution.ChainInfoFetcher
+	StorageUsageFetcher       StorageUsageFetcher
+	BlobStorage               *filesystem.BlobStorage
 }
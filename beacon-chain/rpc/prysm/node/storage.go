@@ -0,0 +1,79 @@
+package node
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+)
+
+// GetStorageUsage returns the space used by each bucket of the beacon database, by the blocks and states of each
+// fork, and by the blobs of each epoch. The totals are maintained in the background, so the request does not scan
+// the database or the blob storage.
+func (s *Server) GetStorageUsage(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.GetStorageUsage")
+	defer span.End()
+
+	if s.StorageUsageFetcher == nil {
+		httputil.HandleError(w, "Storage usage is not supported by the beacon database", http.StatusNotImplemented)
+		return
+	}
+	u, err := s.StorageUsageFetcher.StorageUsage()
+	if err != nil {
+		if errors.Is(err, kv.ErrStorageUsageNotReady) || errors.Is(err, kv.ErrStorageUsageNotTracked) {
+			httputil.HandleError(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		httputil.HandleError(w, "Could not get storage usage: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	resp := &structs.StorageUsage{
+		UpdatedAt: u.Updated.UTC().Format(time.RFC3339),
+		Buckets:   make([]*structs.BucketUsage, len(u.Buckets)),
+		Blocks:    forkUsageToStructs(u.Blocks),
+		States:    forkUsageToStructs(u.States),
+		Blobs:     make([]*structs.BlobEpochUsage, 0),
+	}
+	for i, b := range u.Buckets {
+		resp.Buckets[i] = &structs.BucketUsage{
+			Name:  b.Name,
+			Keys:  strconv.FormatUint(b.Keys, 10),
+			Bytes: strconv.FormatUint(b.Bytes, 10),
+		}
+	}
+	if s.BlobStorage != nil {
+		blobs, err := s.BlobStorage.UsageByEpoch()
+		if err != nil && !errors.Is(err, filesystem.ErrBlobStorageSummarizerUnavailable) {
+			httputil.HandleError(w, "Could not get blob storage usage: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		for _, e := range blobs {
+			resp.Blobs = append(resp.Blobs, &structs.BlobEpochUsage{
+				Epoch: strconv.FormatUint(uint64(e.Epoch), 10),
+				Roots: strconv.Itoa(e.Roots),
+				Blobs: strconv.Itoa(e.Blobs),
+				Bytes: strconv.FormatUint(e.Bytes, 10),
+			})
+		}
+	}
+	httputil.WriteJson(w, &structs.StorageUsageResponse{Data: resp})
+}
+
+func forkUsageToStructs(forks []kv.ForkUsage) []*structs.ForkUsage {
+	u := make([]*structs.ForkUsage, len(forks))
+	for i, f := range forks {
+		u[i] = &structs.ForkUsage{
+			Fork:  version.String(f.Version),
+			Count: strconv.FormatUint(f.Count, 10),
+			Bytes: strconv.FormatUint(f.Bytes, 10),
+		}
+	}
+	return u
+}
@@ -0,0 +1,85 @@
+package node
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+type mockStorageUsageFetcher struct {
+	usage *kv.StorageUsage
+	err   error
+}
+
+func (m *mockStorageUsageFetcher) StorageUsage() (*kv.StorageUsage, error) {
+	return m.usage, m.err
+}
+
+func TestGetStorageUsage(t *testing.T) {
+	bs := filesystem.NewEphemeralBlobStorage(t)
+	_, sidecars := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, 64, 2)
+	for _, sc := range sidecars {
+		require.NoError(t, bs.Save(blocks.NewVerifiedROBlob(sc)))
+	}
+	s := &Server{
+		StorageUsageFetcher: &mockStorageUsageFetcher{usage: &kv.StorageUsage{
+			Buckets: []kv.BucketUsage{{Name: "blocks", Keys: 10, Bytes: 1000}},
+			Blocks:  []kv.ForkUsage{{Version: version.Deneb, Count: 10, Bytes: 900}},
+			States:  []kv.ForkUsage{{Version: version.Deneb, Count: 1, Bytes: 5000}},
+			Updated: time.Unix(0, 0),
+		}},
+		BlobStorage: bs,
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/node/storage", nil)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.GetStorageUsage(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &structs.StorageUsageResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	assert.Equal(t, "1970-01-01T00:00:00Z", resp.Data.UpdatedAt)
+	require.Equal(t, 1, len(resp.Data.Buckets))
+	assert.DeepEqual(t, &structs.BucketUsage{Name: "blocks", Keys: "10", Bytes: "1000"}, resp.Data.Buckets[0])
+	require.Equal(t, 1, len(resp.Data.Blocks))
+	assert.DeepEqual(t, &structs.ForkUsage{Fork: "deneb", Count: "10", Bytes: "900"}, resp.Data.Blocks[0])
+	require.Equal(t, 1, len(resp.Data.States))
+	require.Equal(t, 1, len(resp.Data.Blobs))
+	assert.Equal(t, "2", resp.Data.Blobs[0].Epoch)
+	assert.Equal(t, "1", resp.Data.Blobs[0].Roots)
+	assert.Equal(t, "2", resp.Data.Blobs[0].Blobs)
+}
+
+func TestGetStorageUsage_NotReady(t *testing.T) {
+	s := &Server{StorageUsageFetcher: &mockStorageUsageFetcher{err: kv.ErrStorageUsageNotReady}}
+	request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/node/storage", nil)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.GetStorageUsage(writer, request)
+	assert.Equal(t, http.StatusServiceUnavailable, writer.Code)
+
+	s = &Server{StorageUsageFetcher: &mockStorageUsageFetcher{err: kv.ErrStorageUsageNotTracked}}
+	writer = httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.GetStorageUsage(writer, request)
+	assert.Equal(t, http.StatusServiceUnavailable, writer.Code)
+	assert.StringContains(t, kv.ErrStorageUsageNotTracked.Error(), writer.Body.String())
+
+	s = &Server{}
+	writer = httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.GetStorageUsage(writer, request)
+	assert.Equal(t, http.StatusNotImplemented, writer.Code)
+}
@@ -13,12 +13,14 @@ import (
 	"github.com/pkg/errors"
 	builderapi "github.com/prysmaticlabs/prysm/v5/api/client/builder"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/kzg"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/builder"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
 	blockfeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/block"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/operation"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/peerdas"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
@@ -302,7 +304,11 @@ func (vs *Server) ProposeBeaconBlock(ctx context.Context, req *ethpb.GenericSign
 		errChan <- nil
 	}()
 
-	if err := vs.broadcastAndReceiveBlobs(ctx, sidecars, root); err != nil {
+	if params.PeerDASActive(slots.ToEpoch(block.Block().Slot())) {
+		if err := vs.broadcastAndReceiveDataColumns(ctx, block, sidecars, root); err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not broadcast/receive data columns: %v", err)
+		}
+	} else if err := vs.broadcastAndReceiveBlobs(ctx, sidecars, root); err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not broadcast/receive blobs: %v", err)
 	}
 
@@ -399,6 +405,45 @@ func (vs *Server) broadcastAndReceiveBlobs(ctx context.Context, sidecars []*ethp
 	return eg.Wait()
 }
 
+// broadcastAndReceiveDataColumns computes the data column sidecars of a block from its blob sidecars,
+// then handles their broadcasting and reception.
+func (vs *Server) broadcastAndReceiveDataColumns(ctx context.Context, block interfaces.ReadOnlySignedBeaconBlock, sidecars []*ethpb.BlobSidecar, root [32]byte) error {
+	if len(sidecars) == 0 {
+		return nil
+	}
+	blobs := make([]kzg.Blob, len(sidecars))
+	for i, sc := range sidecars {
+		if len(sc.Blob) != len(blobs[i]) {
+			return errors.Errorf("blob %d has length %d, expected %d", i, len(sc.Blob), len(blobs[i]))
+		}
+		copy(blobs[i][:], sc.Blob)
+	}
+	columns, err := peerdas.DataColumnSidecars(block, blobs)
+	if err != nil {
+		return errors.Wrap(err, "could not compute data column sidecars")
+	}
+
+	eg, eCtx := errgroup.WithContext(ctx)
+	for _, sc := range columns {
+		column := sc
+		eg.Go(func() error {
+			subnet := peerdas.ComputeSubnetForDataColumnSidecar(column.ColumnIndex)
+			if err := vs.P2P.BroadcastDataColumn(eCtx, subnet, column); err != nil {
+				return errors.Wrap(err, "broadcast data column failed")
+			}
+			roColumn, err := blocks.NewRODataColumnWithRoot(column, root)
+			if err != nil {
+				return errors.Wrap(err, "RODataColumn creation failed")
+			}
+			if err := vs.DataColumnReceiver.ReceiveDataColumn(ctx, blocks.NewVerifiedRODataColumn(roColumn)); err != nil {
+				return errors.Wrap(err, "receive data column failed")
+			}
+			return nil
+		})
+	}
+	return eg.Wait()
+}
+
 // PrepareBeaconProposer caches and updates the fee recipient for the given proposer.
 func (vs *Server) PrepareBeaconProposer(
 	_ context.Context, request *ethpb.PrepareBeaconProposerRequest,
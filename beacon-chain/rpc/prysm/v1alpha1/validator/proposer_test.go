@@ -3,6 +3,7 @@ package validator
 import (
 	"context"
 	"math/big"
+	"sync"
 	"testing"
 	"time"
 
@@ -3126,3 +3127,37 @@ func TestProposer_ElectraBlobsAndProofs(t *testing.T) {
 	require.Equal(t, 10, len(blobs))
 	require.Equal(t, 10, len(proofs))
 }
+
+type mockDataColumnReceiver struct {
+	sync.Mutex
+	columns []blocks.VerifiedRODataColumn
+}
+
+func (m *mockDataColumnReceiver) ReceiveDataColumn(_ context.Context, dc blocks.VerifiedRODataColumn) error {
+	m.Lock()
+	defer m.Unlock()
+	m.columns = append(m.columns, dc)
+	return nil
+}
+
+func TestServer_broadcastAndReceiveDataColumns(t *testing.T) {
+	blk, blobs := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, 1, 2)
+	sidecars := make([]*ethpb.BlobSidecar, len(blobs))
+	for i := range blobs {
+		sidecars[i] = blobs[i].BlobSidecar
+	}
+	broadcaster := &mockp2p.MockBroadcaster{}
+	receiver := &mockDataColumnReceiver{}
+	vs := &Server{P2P: broadcaster, DataColumnReceiver: receiver}
+
+	require.NoError(t, vs.broadcastAndReceiveDataColumns(context.Background(), blk, sidecars, blk.Root()))
+	require.Equal(t, true, broadcaster.BroadcastCalled.Load())
+	require.Equal(t, int(params.BeaconConfig().NumberOfColumns), len(receiver.columns))
+	for _, c := range receiver.columns {
+		require.Equal(t, blk.Root(), c.BlockRoot())
+		require.Equal(t, len(blobs), len(c.DataColumn))
+	}
+
+	sidecars[0].Blob = sidecars[0].Blob[1:]
+	require.ErrorContains(t, "blob 0 has length", vs.broadcastAndReceiveDataColumns(context.Background(), blk, sidecars, blk.Root()))
+}
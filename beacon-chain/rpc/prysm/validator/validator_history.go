@@ -0,0 +1,120 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/dutyhistory"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/shared"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// maxValidatorHistoryEpochs is the largest number of epochs which can be requested at once.
+const maxValidatorHistoryEpochs = 8192
+
+// GetValidatorHistory returns the saved duty records of a validator, identified by its index or public key, from
+// from_epoch to to_epoch, both included. to_epoch defaults to the epoch of the head. Records are only saved by
+// nodes running with the --validator-history flag, and epochs without a record are left out.
+func (s *Server) GetValidatorHistory(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "validator.GetValidatorHistory")
+	defer span.End()
+
+	idx, ok := s.validatorIndex(ctx, w, r.PathValue("validator_id"))
+	if !ok {
+		return
+	}
+	_, from, ok := shared.UintFromQuery(w, r, "from_epoch", true)
+	if !ok {
+		return
+	}
+	rawTo, to, ok := shared.UintFromQuery(w, r, "to_epoch", false)
+	if !ok {
+		return
+	}
+	if rawTo == "" {
+		to = uint64(slots.ToEpoch(s.ChainInfoFetcher.HeadSlot()))
+	}
+	if from > to {
+		httputil.HandleError(w, fmt.Sprintf("from_epoch %d is after to_epoch %d", from, to), http.StatusBadRequest)
+		return
+	}
+	if to-from >= maxValidatorHistoryEpochs {
+		httputil.HandleError(w, fmt.Sprintf("At most %d epochs can be requested", maxValidatorHistoryEpochs), http.StatusBadRequest)
+		return
+	}
+
+	history, err := s.BeaconDB.ValidatorHistory(ctx, idx, primitives.Epoch(from), primitives.Epoch(to))
+	if err != nil {
+		httputil.HandleError(w, "Could not get validator history: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data := make([]*structs.ValidatorEpochRecord, len(history))
+	for i, h := range history {
+		data[i] = validatorEpochRecordFromHistory(h)
+	}
+	httputil.WriteJson(w, &structs.GetValidatorHistoryResponse{
+		ValidatorIndex: strconv.FormatUint(uint64(idx), 10),
+		Data:           data,
+	})
+}
+
+// validatorIndex returns the index of a validator given as an index or as a public key. Public keys are looked up
+// in the head state.
+func (s *Server) validatorIndex(ctx context.Context, w http.ResponseWriter, id string) (primitives.ValidatorIndex, bool) {
+	if id == "" {
+		httputil.HandleError(w, "validator_id is required in URL params", http.StatusBadRequest)
+		return 0, false
+	}
+	if !strings.HasPrefix(id, "0x") {
+		idx, err := strconv.ParseUint(id, 10, 64)
+		if err != nil {
+			httputil.HandleError(w, "Invalid validator index: "+err.Error(), http.StatusBadRequest)
+			return 0, false
+		}
+		return primitives.ValidatorIndex(idx), true
+	}
+	pubkey, err := hexutil.Decode(id)
+	if err != nil || len(pubkey) != fieldparams.BLSPubkeyLength {
+		httputil.HandleError(w, "Invalid validator public key "+id, http.StatusBadRequest)
+		return 0, false
+	}
+	st, err := s.ChainInfoFetcher.HeadStateReadOnly(ctx)
+	if err != nil {
+		httputil.HandleError(w, "Could not get head state: "+err.Error(), http.StatusInternalServerError)
+		return 0, false
+	}
+	idx, ok := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(pubkey))
+	if !ok {
+		httputil.HandleError(w, "Unknown validator public key "+id, http.StatusNotFound)
+		return 0, false
+	}
+	return idx, true
+}
+
+func validatorEpochRecordFromHistory(h *dutyhistory.EpochRecord) *structs.ValidatorEpochRecord {
+	r := h.Record
+	return &structs.ValidatorEpochRecord{
+		Epoch:            fmt.Sprintf("%d", h.Epoch),
+		Active:           r.Active,
+		CorrectSource:    r.CorrectSource,
+		CorrectTarget:    r.CorrectTarget,
+		CorrectHead:      r.CorrectHead,
+		Included:         r.Included,
+		InclusionDelay:   fmt.Sprintf("%d", r.InclusionDelay),
+		DutiesKnown:      r.DutiesKnown,
+		BlocksProposed:   fmt.Sprintf("%d", r.Proposed),
+		BlocksMissed:     fmt.Sprintf("%d", r.MissedProposals),
+		SyncParticipated: fmt.Sprintf("%d", r.SyncParticipated),
+		SyncMissed:       fmt.Sprintf("%d", r.SyncMissed),
+	}
+}
@@ -0,0 +1,116 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/dutyhistory"
+	dbTest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestServer_GetValidatorHistory(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbTest.SetupDB(t)
+	st, _ := util.DeterministicGenesisState(t, 8)
+	require.NoError(t, st.SetSlot(12*params.BeaconConfig().SlotsPerEpoch))
+	for epoch := primitives.Epoch(5); epoch < 12; epoch++ {
+		records := make([]dutyhistory.Record, 8)
+		for i := range records {
+			records[i] = dutyhistory.Record{
+				Active:         true,
+				CorrectSource:  true,
+				Included:       true,
+				InclusionDelay: primitives.Slot(epoch % 3),
+				DutiesKnown:    true,
+			}
+		}
+		records[2].Proposed = 1
+		require.NoError(t, beaconDB.SaveValidatorHistory(ctx, epoch, records))
+	}
+	s := &Server{
+		BeaconDB:         beaconDB,
+		ChainInfoFetcher: &mock.ChainService{State: st},
+	}
+
+	request := func(id, query string) (*httptest.ResponseRecorder, *structs.GetValidatorHistoryResponse) {
+		req := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/validators/"+id+"/history?"+query, nil)
+		req.SetPathValue("validator_id", id)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetValidatorHistory(writer, req)
+		resp := &structs.GetValidatorHistoryResponse{}
+		if writer.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		}
+		return writer, resp
+	}
+
+	t.Run("by index", func(t *testing.T) {
+		writer, resp := request("2", "from_epoch=6&to_epoch=8")
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, "2", resp.ValidatorIndex)
+		require.Equal(t, 3, len(resp.Data))
+		assert.DeepEqual(t, &structs.ValidatorEpochRecord{
+			Epoch:            "6",
+			Active:           true,
+			CorrectSource:    true,
+			Included:         true,
+			InclusionDelay:   "0",
+			DutiesKnown:      true,
+			BlocksProposed:   "1",
+			BlocksMissed:     "0",
+			SyncParticipated: "0",
+			SyncMissed:       "0",
+		}, resp.Data[0])
+		assert.Equal(t, "8", resp.Data[2].Epoch)
+		assert.Equal(t, "2", resp.Data[2].InclusionDelay)
+	})
+	t.Run("by public key up to head", func(t *testing.T) {
+		val, err := st.ValidatorAtIndexReadOnly(3)
+		require.NoError(t, err)
+		pk := val.PublicKey()
+		writer, resp := request(hexutil.Encode(pk[:]), "from_epoch=0")
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, "3", resp.ValidatorIndex)
+		require.Equal(t, 7, len(resp.Data))
+		assert.Equal(t, "5", resp.Data[0].Epoch)
+		assert.Equal(t, "11", resp.Data[6].Epoch)
+	})
+	t.Run("no records", func(t *testing.T) {
+		writer, resp := request("20", "from_epoch=0&to_epoch=20")
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, 0, len(resp.Data))
+	})
+	t.Run("unknown public key", func(t *testing.T) {
+		writer, _ := request(hexutil.Encode(make([]byte, 48)), "from_epoch=0")
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+	})
+	t.Run("invalid id", func(t *testing.T) {
+		writer, _ := request("foo", "from_epoch=0")
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+	t.Run("missing from epoch", func(t *testing.T) {
+		writer, _ := request("2", "to_epoch=8")
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+	t.Run("inverted range", func(t *testing.T) {
+		writer, _ := request("2", "from_epoch=9&to_epoch=8")
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+	t.Run("range too long", func(t *testing.T) {
+		writer, _ := request("2", "from_epoch=0&to_epoch=10000")
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
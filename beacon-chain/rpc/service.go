@@ -33,6 +33,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/voluntaryexits"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/core"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/events"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/rewards"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/lookup"
 	beaconv1alpha1 "github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/prysm/v1alpha1/beacon"
@@ -87,6 +88,7 @@ type Service struct {
 	connectedRPCClients  map[net.Addr]bool
 	clientConnectionLock sync.Mutex
 	validatorServer      *validatorv1alpha1.Server
+	eventHistory         *events.EventHistory
 }
 
 // Config options for the beacon node RPC server.
@@ -108,6 +110,7 @@ type Config struct {
 	AttestationReceiver       blockchain.AttestationReceiver
 	BlockReceiver             blockchain.BlockReceiver
 	BlobReceiver              blockchain.BlobReceiver
+	DataColumnReceiver        blockchain.DataColumnReceiver
 	ExecutionChainService     execution.Chain
 	ChainStartFetcher         execution.ChainStartFetcher
 	ExecutionChainInfoFetcher execution.ChainInfoFetcher
@@ -115,6 +118,9 @@ type Config struct {
 	GenesisFetcher            blockchain.GenesisFetcher
 	MockEth1Votes             bool
 	EnableDebugRPCEndpoints   bool
+	EnableGraphQL             bool
+	GraphQLMaxDepth           int
+	GraphQLMaxCost            uint64
 	AttestationsPool          attestations.Pool
 	ExitPool                  voluntaryexits.PoolManager
 	SlashingsPool             slashings.PoolManager
@@ -139,6 +145,7 @@ type Config struct {
 	ClockWaiter               startup.ClockWaiter
 	BlobStorage               *filesystem.BlobStorage
 	TrackedValidatorsCache    *cache.TrackedValidatorsCache
+	ValidatorIndexCache       *cache.ValidatorIndexCache
 	PayloadIDCache            *cache.PayloadIDCache
 }
 
@@ -152,6 +159,7 @@ func NewService(ctx context.Context, cfg *Config) *Service {
 		cancel:              cancel,
 		incomingAttestation: make(chan *ethpbv1alpha1.Attestation, params.BeaconConfig().DefaultBufferSize),
 		connectedRPCClients: make(map[net.Addr]bool),
+		eventHistory:        events.NewEventHistory(events.DefaultEventHistoryDepth),
 	}
 
 	address := net.JoinHostPort(s.cfg.Host, s.cfg.Port)
@@ -252,6 +260,7 @@ func NewService(ctx context.Context, cfg *Config) *Service {
 		P2P:                    s.cfg.Broadcaster,
 		BlockReceiver:          s.cfg.BlockReceiver,
 		BlobReceiver:           s.cfg.BlobReceiver,
+		DataColumnReceiver:     s.cfg.DataColumnReceiver,
 		MockEth1Votes:          s.cfg.MockEth1Votes,
 		Eth1BlockFetcher:       s.cfg.ExecutionChainService,
 		PendingDepositsFetcher: s.cfg.PendingDepositFetcher,
@@ -344,9 +353,11 @@ func NewService(ctx context.Context, cfg *Config) *Service {
 var _ stategen.CanonicalChecker = blockchain.ChainInfoFetcher(nil)
 var _ stategen.CurrentSlotter = blockchain.ChainInfoFetcher(nil)
 
-// Start the gRPC server.
+// Start the gRPC server and the recording of the event stream history.
 func (s *Service) Start() {
 	grpcprometheus.EnableHandlingTimeHistogram()
+	// The history stops recording once the service is stopped.
+	go s.eventHistory.Run(s.ctx, s.cfg.StateNotifier, s.cfg.OperationNotifier)
 	go func() {
 		if s.listener != nil {
 			if err := s.grpcServer.Serve(s.listener); err != nil {
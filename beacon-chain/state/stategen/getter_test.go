@@ -8,6 +8,7 @@ import (
 	testDB "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
 	doublylinkedtree "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/doubly-linked-tree"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
+	consensusblocks "github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
@@ -72,6 +73,63 @@ func TestStateByRoot_ColdState(t *testing.T) {
 	require.Equal(t, uint64(0), bal[0])
 }
 
+func TestStateByRoot_ColdStateAfterPruning(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+
+	service := New(beaconDB, doublylinkedtree.New())
+	beaconState, pks := util.DeterministicGenesisState(t, 32)
+	genesisStateRoot, err := beaconState.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	genesis := blocks.NewGenesisBlock(genesisStateRoot[:])
+	util.SaveBlock(t, ctx, beaconDB, genesis)
+	gRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveState(ctx, beaconState, gRoot))
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, gRoot))
+
+	roots := make(map[primitives.Slot][32]byte)
+	want := make(map[primitives.Slot][32]byte)
+	for slot := primitives.Slot(1); slot <= 8; slot++ {
+		b, err := util.GenerateFullBlock(beaconState, pks, util.DefaultBlockGenConfig(), slot)
+		require.NoError(t, err)
+		r, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		util.SaveBlock(t, ctx, beaconDB, b)
+		require.NoError(t, beaconDB.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: slot, Root: r[:]}))
+		wb, err := consensusblocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		beaconState, err = executeStateTransitionStateGen(ctx, beaconState, wb)
+		require.NoError(t, err)
+		roots[slot] = r
+		want[slot], err = beaconState.HashTreeRoot(ctx)
+		require.NoError(t, err)
+		if slot == 3 {
+			// The archived point below the prune cutoff.
+			require.NoError(t, beaconDB.SaveState(ctx, beaconState, r))
+		}
+	}
+	finalizedRoot := roots[8]
+	require.NoError(t, beaconDB.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 1, Root: finalizedRoot[:]}))
+	service.finalizedInfo.slot = 8
+
+	_, err = beaconDB.DeleteHistoricalDataBeforeSlot(ctx, 6, 100)
+	require.NoError(t, err)
+	earliest, err := beaconDB.EarliestAvailableSlot(ctx)
+	require.NoError(t, err)
+	require.Equal(t, primitives.Slot(3), earliest)
+	assert.Equal(t, false, beaconDB.HasBlock(ctx, roots[2]))
+
+	for slot := earliest + 1; slot <= 8; slot++ {
+		st, err := service.StateByRoot(ctx, roots[slot])
+		require.NoError(t, err)
+		assert.Equal(t, slot, st.Slot())
+		stRoot, err := st.HashTreeRoot(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, want[slot], stRoot)
+	}
+}
+
 func TestStateByRootIfCachedNoCopy_HotState(t *testing.T) {
 	ctx := context.Background()
 	beaconDB := testDB.SetupDB(t)
@@ -5,7 +5,10 @@ import (
 	"encoding/hex"
 	"fmt"
 
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
 	"github.com/sirupsen/logrus"
@@ -13,7 +16,8 @@ import (
 
 // MigrateToCold advances the finalized info in between the cold and hot state sections.
 // It moves the recent finalized states from the hot section to the cold section and
-// only preserves the ones that are on archived point.
+// only preserves the ones that are on archived point, or at the slots of the state diff
+// hierarchy when the DB saves state diffs.
 func (s *State) MigrateToCold(ctx context.Context, fRoot [32]byte) error {
 	ctx, span := trace.StartSpan(ctx, "stateGen.MigrateToCold")
 	defer span.End()
@@ -37,6 +41,14 @@ func (s *State) MigrateToCold(ctx context.Context, fRoot [32]byte) error {
 		return nil
 	}
 
+	// Archival nodes with state diffs save the states of the state diff hierarchy instead of archived points.
+	if s.beaconDB.StateDiffInterval() > 0 {
+		if err := s.migrateStateDiffs(ctx, oldFSlot, fSlot); err != nil {
+			return err
+		}
+		return s.updateFinalizedInfo(fSlot, fRoot)
+	}
+
 	// Start at previous finalized slot, stop at current finalized slot (it will be handled in the next migration).
 	// If the slot is on archived point, save the state of that slot to the DB.
 	for slot := oldFSlot; slot < fSlot; slot++ {
@@ -85,15 +97,7 @@ func (s *State) MigrateToCold(ctx context.Context, fRoot [32]byte) error {
 				// If you are migrating a state and its already part of the hot state cache saved to the db,
 				// you can just remove it from the hot state cache as it becomes redundant.
 				s.saveHotStateDB.lock.Lock()
-				roots := s.saveHotStateDB.blockRootsOfSavedStates
-				for i := 0; i < len(roots); i++ {
-					if aRoot == roots[i] {
-						s.saveHotStateDB.blockRootsOfSavedStates = append(roots[:i], roots[i+1:]...)
-						// There shouldn't be duplicated roots in `blockRootsOfSavedStates`.
-						// Break here is ok.
-						break
-					}
-				}
+				s.forgetSavedHotState(aRoot)
 				s.saveHotStateDB.lock.Unlock()
 				continue
 			}
@@ -109,7 +113,11 @@ func (s *State) MigrateToCold(ctx context.Context, fRoot [32]byte) error {
 		}
 	}
 
-	// Update finalized info in memory.
+	return s.updateFinalizedInfo(fSlot, fRoot)
+}
+
+// updateFinalizedInfo updates the finalized info in memory.
+func (s *State) updateFinalizedInfo(fSlot primitives.Slot, fRoot [32]byte) error {
 	fInfo, ok, err := s.epochBoundaryStateCache.getByBlockRoot(fRoot)
 	if err != nil {
 		return err
@@ -117,6 +125,161 @@ func (s *State) MigrateToCold(ctx context.Context, fRoot [32]byte) error {
 	if ok {
 		s.SaveFinalizedState(fSlot, fRoot, fInfo.state)
 	}
+	return nil
+}
+
+// forgetSavedHotState removes a block root from the roots of the hot states saved to the DB, so that its state is
+// not deleted along with them. The hot state DB lock must be held.
+func (s *State) forgetSavedHotState(root [32]byte) bool {
+	roots := s.saveHotStateDB.blockRootsOfSavedStates
+	for i := 0; i < len(roots); i++ {
+		if root == roots[i] {
+			s.saveHotStateDB.blockRootsOfSavedStates = append(roots[:i], roots[i+1:]...)
+			// There shouldn't be duplicated roots in `blockRootsOfSavedStates`.
+			// Break here is ok.
+			return true
+		}
+	}
+	return false
+}
+
+// migrateStateDiffs saves the finalized states between the given slots at the slots of the state diff hierarchy.
+// The states of the levels which other states are computed against are saved before returning. The states of the
+// finest level, which is every slot with the default hierarchy, are only ever saved as diffs against them, and are
+// replayed and saved in the background, off the migration lock.
+func (s *State) migrateStateDiffs(ctx context.Context, from, to primitives.Slot) error {
+	interval := s.beaconDB.StateDiffInterval()
+	baseInterval := s.beaconDB.StateDiffBaseInterval()
+
+	// The last state saved in the state diff hierarchy, which the next one is replayed from.
+	var prev state.BeaconState
+	var prevRoot [32]byte
+	var finest []primitives.Slot
+	var err error
+	for slot := from + (interval-from%interval)%interval; slot < to; slot += interval {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if baseInterval > 0 && slot%baseInterval != 0 {
+			finest = append(finest, slot)
+			continue
+		}
+		if prev, prevRoot, err = s.saveStateDiff(ctx, slot, prev, prevRoot); err != nil {
+			return err
+		}
+	}
+	if len(finest) == 0 {
+		return nil
+	}
 
+	s.finestStateDiffs.wg.Add(1)
+	go func() {
+		defer s.finestStateDiffs.wg.Done()
+		// Ranges are saved one at a time, so that their replays do not compete with each other.
+		s.finestStateDiffs.lock.Lock()
+		defer s.finestStateDiffs.lock.Unlock()
+		var prev state.BeaconState
+		var prevRoot [32]byte
+		var err error
+		for _, slot := range finest {
+			if ctx.Err() != nil {
+				return
+			}
+			if prev, prevRoot, err = s.saveStateDiff(ctx, slot, prev, prevRoot); err != nil {
+				log.WithError(err).WithField("slot", slot).Error("Could not save state diff")
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// waitForStateDiffs waits for the states of the finest level of the state diff hierarchy being saved in the
+// background to be saved.
+func (s *State) waitForStateDiffs() {
+	s.finestStateDiffs.wg.Wait()
+}
+
+// saveStateDiff saves the finalized state at a slot of the state diff hierarchy to the DB, and returns it along with
+// the root of its latest block. The state at a skipped slot is the state of the last block before it, advanced
+// through the empty slots. The state is replayed from the previously saved state when given, so that saving the
+// state at every slot replays each block once.
+func (s *State) saveStateDiff(
+	ctx context.Context,
+	slot primitives.Slot,
+	prev state.BeaconState,
+	prevRoot [32]byte,
+) (state.BeaconState, [32]byte, error) {
+	if s.beaconDB.HasStateDiff(ctx, slot) {
+		return nil, [32]byte{}, nil
+	}
+	cached, exists, err := s.epochBoundaryStateCache.getBySlot(slot)
+	if err != nil {
+		return nil, [32]byte{}, fmt.Errorf("could not get epoch boundary state for slot %d", slot)
+	}
+	var root [32]byte
+	var st state.BeaconState
+	if exists {
+		root = cached.root
+		st = cached.state.Copy()
+	} else {
+		_, roots, err := s.beaconDB.HighestRootsBelowSlot(ctx, slot+1)
+		if err != nil {
+			return nil, [32]byte{}, err
+		}
+		// Given the block has been finalized, the db should not have more than one block in a given slot.
+		if len(roots) != 1 {
+			return nil, [32]byte{}, errUnknownBlock
+		}
+		root = roots[0]
+		switch {
+		case prev != nil && root == prevRoot:
+			st = prev
+		case prev != nil:
+			blks, err := s.loadBlocks(ctx, prev.Slot()+1, slot, root)
+			if err != nil {
+				return nil, [32]byte{}, errors.Wrapf(err, "could not load blocks up to slot %d", slot)
+			}
+			if st, err = s.replayBlocks(ctx, prev, blks, slot); err != nil {
+				return nil, [32]byte{}, errors.Wrapf(err, "could not replay blocks up to slot %d", slot)
+			}
+		default:
+			if st, err = s.StateByRoot(ctx, root); err != nil {
+				return nil, [32]byte{}, err
+			}
+			st = st.Copy()
+		}
+	}
+	if st.Slot() < slot {
+		st, err = ReplayProcessSlots(ctx, st, slot)
+		if err != nil {
+			return nil, [32]byte{}, errors.Wrapf(err, "could not process slots up to %d", slot)
+		}
+	}
+	if err := s.beaconDB.SaveStateDiff(ctx, st, root); err != nil {
+		return nil, [32]byte{}, err
+	}
+	if err := s.deleteSavedHotState(ctx, root); err != nil {
+		return nil, [32]byte{}, err
+	}
+	log.WithFields(
+		logrus.Fields{
+			"slot": slot,
+			"root": hex.EncodeToString(bytesutil.Trunc(root[:])),
+		}).Debug("Saved state diff in DB")
+	return st, root, nil
+}
+
+// deleteSavedHotState deletes the state of a block which was saved in full to the DB as a hot state, once it is
+// saved in the state diff hierarchy, which makes the full state redundant.
+func (s *State) deleteSavedHotState(ctx context.Context, root [32]byte) error {
+	s.saveHotStateDB.lock.Lock()
+	defer s.saveHotStateDB.lock.Unlock()
+	if !s.forgetSavedHotState(root) {
+		return nil
+	}
+	if err := s.beaconDB.DeleteState(ctx, root); err != nil && !errors.Is(err, db.ErrDeleteJustifiedAndFinalized) {
+		return errors.Wrap(err, "could not delete saved hot state")
+	}
 	return nil
 }
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
 	testDB "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
 	doublylinkedtree "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/doubly-linked-tree"
 	consensusblocks "github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
@@ -225,3 +226,137 @@ func TestMigrateToCold_ParallelCalls(t *testing.T) {
 	assert.DeepEqual(t, [][32]byte{r7}, service.saveHotStateDB.blockRootsOfSavedStates, "Did not remove all saved hot state roots")
 	require.LogsContain(t, hook, "Saved state in DB")
 }
+
+func TestMigrateToCold_StateDiffs(t *testing.T) {
+	ctx := context.Background()
+	beaconDB, err := kv.NewKVStore(ctx, t.TempDir(), kv.WithStateDiffs([]uint64{3, 1}))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, beaconDB.Close())
+	})
+
+	service := New(beaconDB, doublylinkedtree.New())
+	beaconState, pks := util.DeterministicGenesisState(t, 32)
+	genState := beaconState.Copy()
+	genesisStateRoot, err := beaconState.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	genesis := blocks.NewGenesisBlock(genesisStateRoot[:])
+	util.SaveBlock(t, ctx, beaconDB, genesis)
+	gRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveState(ctx, beaconState, gRoot))
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, gRoot))
+
+	b1, err := util.GenerateFullBlock(beaconState, pks, util.DefaultBlockGenConfig(), 1)
+	require.NoError(t, err)
+	wB1, err := consensusblocks.NewSignedBeaconBlock(b1)
+	require.NoError(t, err)
+	beaconState, err = executeStateTransitionStateGen(ctx, beaconState, wB1)
+	require.NoError(t, err)
+	r1, err := b1.Block.HashTreeRoot()
+	require.NoError(t, err)
+	util.SaveBlock(t, ctx, beaconDB, b1)
+	require.NoError(t, beaconDB.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: 1, Root: r1[:]}))
+	// Slot 2 is skipped, so its state is the state of block 1 advanced by a slot.
+	want, err := ReplayProcessSlots(ctx, beaconState.Copy(), 2)
+	require.NoError(t, err)
+
+	b4, err := util.GenerateFullBlock(beaconState, pks, util.DefaultBlockGenConfig(), 4)
+	require.NoError(t, err)
+	r4, err := b4.Block.HashTreeRoot()
+	require.NoError(t, err)
+	util.SaveBlock(t, ctx, beaconDB, b4)
+	require.NoError(t, beaconDB.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: 4, Root: r4[:]}))
+
+	service.finalizedInfo = &finalizedInfo{
+		slot:  0,
+		root:  gRoot,
+		state: genState,
+	}
+	require.NoError(t, service.MigrateToCold(ctx, r4))
+	// The state at slot 2 is at the finest level, which is saved in the background.
+	service.waitForStateDiffs()
+
+	assert.Equal(t, true, beaconDB.HasStateDiff(ctx, 0))
+	assert.Equal(t, true, beaconDB.HasStateDiff(ctx, 2))
+	assert.Equal(t, false, beaconDB.HasStateDiff(ctx, 4))
+	assert.Equal(t, false, beaconDB.HasArchivedPoint(ctx, 1))
+	got, err := beaconDB.State(ctx, r1)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Slot(2), got.Slot())
+	wantRoot, err := want.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	gotRoot, err := got.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, wantRoot, gotRoot)
+}
+
+func TestMigrateToCold_StateDiffsEverySlot(t *testing.T) {
+	ctx := context.Background()
+	beaconDB, err := kv.NewKVStore(ctx, t.TempDir(), kv.WithStateDiffs([]uint64{3, 0}))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, beaconDB.Close())
+	})
+
+	service := New(beaconDB, doublylinkedtree.New())
+	beaconState, pks := util.DeterministicGenesisState(t, 32)
+	genState := beaconState.Copy()
+	genesisStateRoot, err := beaconState.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	genesis := blocks.NewGenesisBlock(genesisStateRoot[:])
+	util.SaveBlock(t, ctx, beaconDB, genesis)
+	gRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveState(ctx, beaconState, gRoot))
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, gRoot))
+
+	// Blocks at slots 1 and 3, with slot 2 skipped.
+	roots := make(map[primitives.Slot][32]byte)
+	want := make(map[primitives.Slot][32]byte)
+	for _, slot := range []primitives.Slot{1, 3, 4} {
+		b, err := util.GenerateFullBlock(beaconState, pks, util.DefaultBlockGenConfig(), slot)
+		require.NoError(t, err)
+		r, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		util.SaveBlock(t, ctx, beaconDB, b)
+		require.NoError(t, beaconDB.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: slot, Root: r[:]}))
+		wb, err := consensusblocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		beaconState, err = executeStateTransitionStateGen(ctx, beaconState, wb)
+		require.NoError(t, err)
+		roots[slot] = r
+		want[slot], err = beaconState.HashTreeRoot(ctx)
+		require.NoError(t, err)
+		if slot == 3 {
+			// The state of block 3 was saved in full as a hot state during a long period of non-finality.
+			require.NoError(t, beaconDB.SaveState(ctx, beaconState, r))
+			service.saveHotStateDB.blockRootsOfSavedStates = [][32]byte{r}
+		}
+	}
+
+	service.finalizedInfo = &finalizedInfo{
+		slot:  0,
+		root:  gRoot,
+		state: genState,
+	}
+	require.NoError(t, service.MigrateToCold(ctx, roots[4]))
+	// Only the state of slot 0 is a base, the states of the other slots are saved in the background.
+	assert.Equal(t, true, beaconDB.HasStateDiff(ctx, 0))
+	service.waitForStateDiffs()
+
+	// The full hot state is redundant once saved in the state diff hierarchy.
+	assert.Equal(t, 0, len(service.saveHotStateDB.blockRootsOfSavedStates))
+	for slot := primitives.Slot(0); slot < 4; slot++ {
+		assert.Equal(t, true, beaconDB.HasStateDiff(ctx, slot))
+	}
+	// The state of every block is saved at the slot of the block, and needs no replay.
+	for _, slot := range []primitives.Slot{1, 3} {
+		got, err := beaconDB.State(ctx, roots[slot])
+		require.NoError(t, err)
+		assert.Equal(t, slot, got.Slot())
+		gotRoot, err := got.HashTreeRoot(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, want[slot], gotRoot)
+	}
+}
@@ -55,9 +55,16 @@ type State struct {
 	saveHotStateDB          *saveHotStateDbConfig
 	avb                     coverage.AvailableBlocker
 	migrationLock           *sync.Mutex
+	finestStateDiffs        finestStateDiffs
 	fc                      forkchoice.ForkChoicer
 }
 
+// finestStateDiffs tracks the states of the finest level of the state diff hierarchy being saved in the background.
+type finestStateDiffs struct {
+	lock sync.Mutex
+	wg   sync.WaitGroup
+}
+
 // This tracks the config in the event of long non-finality,
 // how often does the node save hot states to db? what are
 // the saved hot states in db?... etc
@@ -0,0 +1,349 @@
+package backfill
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/das"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filters"
+	p2ptypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/types"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// blobGapScanInterval is the time between two scans of the blob archive for blocks with missing blobs.
+	blobGapScanInterval = time.Hour
+	// blobGapScanSlots is the number of slots of blocks read from the db at once while scanning the blob archive,
+	// and the number of blocks from the gap index retried at once. The missing blobs of these blocks are requested
+	// at once, so it must keep the request within MAX_REQUEST_BLOB_SIDECARS.
+	blobGapScanSlots = 64
+	// blobGapRequestAttempts is the number of peers asked for the missing blobs of a range before moving on.
+	blobGapRequestAttempts = 3
+)
+
+// blobArchiveScan is the outcome of a scan of the blob archive.
+type blobArchiveScan struct {
+	blocks     uint64 // finalized blocks with blob commitments since the Deneb fork, up to the scan cursor
+	incomplete int    // blocks in the gap index, which are still missing some of their blobs
+	filled     int    // blocks whose missing blobs were downloaded during the scan
+}
+
+// completeness returns the fraction of the blocks with blob commitments which have all their blobs stored.
+func (a *blobArchiveScan) completeness() float64 {
+	if a.blocks == 0 {
+		return 1
+	}
+	return float64(a.blocks-uint64(a.incomplete)) / float64(a.blocks)
+}
+
+// fillBlobGaps periodically looks for the finalized blocks since the Deneb fork which are missing some of their
+// blobs in the archive, such as the blocks synced before the node was switched to blob archive mode, and downloads
+// the missing blobs from peers. Each block is only scanned once: the scan resumes from a cursor persisted in the db,
+// and the blocks whose blobs could not be downloaded are kept in a gap index which is retried on every scan.
+func (s *Service) fillBlobGaps(ctx context.Context) {
+	if s.initSyncWaiter != nil {
+		if err := s.initSyncWaiter(); err != nil {
+			return
+		}
+	}
+	ctxMap, err := sync.ContextByteVersionsForValRoot(s.clock.GenesisValidatorsRoot())
+	if err != nil {
+		log.WithError(err).Error("Could not start filling blob archive gaps")
+		return
+	}
+	for {
+		scan, err := s.scanBlobArchive(ctx, ctxMap)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.WithError(err).Error("Could not scan blob archive for missing blobs")
+		} else {
+			blobArchiveCompleteness.Set(scan.completeness())
+			blobArchiveIncompleteBlocks.Set(float64(scan.incomplete))
+			log.WithFields(logrus.Fields{
+				"blocks":     scan.blocks,
+				"filled":     scan.filled,
+				"incomplete": scan.incomplete,
+			}).Info("Scanned blob archive for missing blobs")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(blobGapScanInterval):
+		}
+	}
+}
+
+// scanBlobArchive retries the blocks in the gap index, then walks the finalized blocks between the scan cursor and
+// the finalized checkpoint, downloading the blobs missing from the archive. The cursor is saved along with the
+// blocks still missing blobs after each range, so a scan interrupted by a restart resumes where it stopped.
+func (s *Service) scanBlobArchive(ctx context.Context, ctxMap sync.ContextByteVersions) (*blobArchiveScan, error) {
+	bdb := s.store.store
+	cursor, nblocks, err := bdb.BlobArchiveScanCursor(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read blob archive scan cursor")
+	}
+	deneb, err := sync.BlobArchiveMinSlot()
+	if err != nil {
+		return nil, err
+	}
+	if cursor < deneb {
+		cursor = deneb
+	}
+	current := s.clock.CurrentSlot()
+	scan := &blobArchiveScan{blocks: nblocks}
+	if scan.filled, err = s.retryBlobGaps(ctx, ctxMap, current); err != nil {
+		return nil, err
+	}
+
+	finalized, err := bdb.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read finalized checkpoint")
+	}
+	end, err := slots.EpochStart(finalized.Epoch)
+	if err != nil {
+		return nil, err
+	}
+	for start := cursor; start < end; start += blobGapScanSlots {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		last := start + blobGapScanSlots - 1
+		if last >= end {
+			last = end - 1
+		}
+		blks, roots, err := bdb.Blocks(ctx, filters.NewFilter().SetStartSlot(start).SetEndSlot(last))
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not read blocks from slot %d to %d", start, last)
+		}
+		var incomplete verifiedROBlocks
+		var missing []blobSummary
+		for i := range blks {
+			// Blobs of orphaned blocks cannot be expected from peers.
+			if blks[i].Version() < version.Deneb || !bdb.IsFinalizedBlock(ctx, roots[i]) {
+				continue
+			}
+			blk, err := blocks.NewROBlockWithRoot(blks[i], roots[i])
+			if err != nil {
+				return nil, err
+			}
+			commitments, err := blk.Block().Body().BlobKzgCommitments()
+			if err != nil {
+				return nil, err
+			}
+			if len(commitments) == 0 {
+				continue
+			}
+			scan.blocks++
+			m, err := s.missingBlobs(blk)
+			if err != nil {
+				return nil, err
+			}
+			if len(m) == 0 {
+				continue
+			}
+			incomplete = append(incomplete, blk)
+			missing = append(missing, m...)
+		}
+		var gaps [][32]byte
+		if len(missing) > 0 {
+			remaining := s.fetchMissingBlobs(ctx, ctxMap, current, incomplete, missing)
+			scan.filled += len(incomplete) - len(remaining)
+			for _, blk := range remaining {
+				gaps = append(gaps, blk.Root())
+			}
+		}
+		if err := bdb.SaveBlobArchiveScan(ctx, last+1, scan.blocks, gaps); err != nil {
+			return nil, errors.Wrap(err, "could not save blob archive scan progress")
+		}
+	}
+
+	gaps, err := bdb.BlobArchiveGaps(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read blob archive gaps")
+	}
+	scan.incomplete = len(gaps)
+	return scan, nil
+}
+
+// retryBlobGaps downloads the missing blobs of the blocks in the gap index, removing the blocks which are complete
+// from it. It returns the number of blocks completed.
+func (s *Service) retryBlobGaps(ctx context.Context, ctxMap sync.ContextByteVersions, current primitives.Slot) (int, error) {
+	bdb := s.store.store
+	roots, err := bdb.BlobArchiveGaps(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not read blob archive gaps")
+	}
+	filled := 0
+	for start := 0; start < len(roots); start += blobGapScanSlots {
+		if ctx.Err() != nil {
+			return filled, ctx.Err()
+		}
+		end := start + blobGapScanSlots
+		if end > len(roots) {
+			end = len(roots)
+		}
+		var (
+			done       [][32]byte
+			incomplete verifiedROBlocks
+			missing    []blobSummary
+		)
+		for _, r := range roots[start:end] {
+			b, err := bdb.Block(ctx, r)
+			if err != nil && !errors.Is(err, db.ErrNotFound) {
+				return filled, errors.Wrapf(err, "could not read block %#x", r)
+			}
+			// Blocks removed from the db since they were found missing blobs are no longer tracked.
+			if err != nil || b == nil || b.IsNil() {
+				done = append(done, r)
+				continue
+			}
+			blk, err := blocks.NewROBlockWithRoot(b, r)
+			if err != nil {
+				return filled, err
+			}
+			m, err := s.missingBlobs(blk)
+			if err != nil {
+				return filled, err
+			}
+			if len(m) == 0 {
+				done = append(done, r)
+				continue
+			}
+			incomplete = append(incomplete, blk)
+			missing = append(missing, m...)
+		}
+		if len(missing) > 0 {
+			remaining := s.fetchMissingBlobs(ctx, ctxMap, current, incomplete, missing)
+			still := make(map[[32]byte]bool, len(remaining))
+			for _, blk := range remaining {
+				still[blk.Root()] = true
+			}
+			for _, blk := range incomplete {
+				if !still[blk.Root()] {
+					done = append(done, blk.Root())
+					filled++
+				}
+			}
+		}
+		if len(done) == 0 {
+			continue
+		}
+		if err := bdb.DeleteBlobArchiveGaps(ctx, done); err != nil {
+			return filled, errors.Wrap(err, "could not update blob archive gaps")
+		}
+	}
+	return filled, nil
+}
+
+// missingBlobs returns the summaries of the blobs of the block which are not in blob storage.
+func (s *Service) missingBlobs(blk blocks.ROBlock) ([]blobSummary, error) {
+	commitments, err := blk.Block().Body().BlobKzgCommitments()
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read commitments of block %#x", blk.Root())
+	}
+	if len(commitments) == 0 {
+		return nil, nil
+	}
+	stored, err := s.blobStore.Indices(blk.Root())
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read stored blobs of block %#x", blk.Root())
+	}
+	var missing []blobSummary
+	for i := range commitments {
+		if i < len(stored) && stored[i] {
+			continue
+		}
+		missing = append(missing, blobSummary{
+			blockRoot:  blk.Root(),
+			index:      uint64(i),
+			commitment: bytesutil.ToBytes48(commitments[i]),
+			signature:  blk.Signature(),
+		})
+	}
+	return missing, nil
+}
+
+// fetchMissingBlobs requests the missing blobs of the given blocks by root, returning the blocks which are still
+// missing blobs. Only the missing blobs are verified and saved. Blocks left incomplete by a peer are requested from
+// the next one.
+func (s *Service) fetchMissingBlobs(
+	ctx context.Context,
+	ctxMap sync.ContextByteVersions,
+	current primitives.Slot,
+	blks verifiedROBlocks,
+	missing []blobSummary,
+) verifiedROBlocks {
+	for attempt := 0; attempt < blobGapRequestAttempts && len(missing) > 0; attempt++ {
+		pids, err := s.pa.Assign(nil, 1)
+		if err != nil || len(pids) == 0 {
+			log.WithError(err).Debug("No peer to request missing archive blobs from")
+			return blks
+		}
+		pid := pids[0]
+		req := make(p2ptypes.BlobSidecarsByRootReq, len(missing))
+		byID := make(map[[32]byte]map[uint64]blobSummary)
+		for i, m := range missing {
+			req[i] = &eth.BlobIdentifier{BlockRoot: m.blockRoot[:], Index: m.index}
+			if byID[m.blockRoot] == nil {
+				byID[m.blockRoot] = make(map[uint64]blobSummary)
+			}
+			byID[m.blockRoot][m.index] = m
+		}
+		sidecars, err := sync.SendBlobSidecarByRoot(ctx, s.clock, s.p2p, pid, ctxMap, &req)
+		if err != nil {
+			log.WithError(err).WithField("peer", pid).Debug("Could not request missing archive blobs")
+			continue
+		}
+
+		bbv := newBlobBatchVerifier(s.newBlobVerifier)
+		bsync := &blobSync{
+			current: current,
+			bbv:     bbv,
+			store:   das.NewLazilyPersistentStore(s.blobStore, bbv, das.WithFullHistory()),
+		}
+		// Peers may only return some of the requested blobs, so each sidecar is matched by its identifier.
+		for _, sc := range sidecars {
+			m, ok := byID[sc.BlockRoot()][sc.Index]
+			if !ok {
+				err = errors.Wrapf(errUnexpectedResponseContent, "unrequested blob root=%#x index=%d", sc.BlockRoot(), sc.Index)
+				break
+			}
+			if err = bsync.validate(m, sc); err != nil {
+				break
+			}
+		}
+		if err != nil {
+			log.WithError(err).WithField("peer", pid).Debug("Invalid missing archive blobs response")
+			s.p2p.Peers().Scorers().BadResponsesScorer().Increment(pid)
+			continue
+		}
+
+		var remaining verifiedROBlocks
+		for _, blk := range blks {
+			if err := bsync.store.IsDataAvailable(ctx, current, blk); err != nil {
+				remaining = append(remaining, blk)
+			}
+		}
+		blks = remaining
+		missing = missing[:0]
+		for _, blk := range blks {
+			m, err := s.missingBlobs(blk)
+			if err != nil {
+				return blks
+			}
+			missing = append(missing, m...)
+		}
+	}
+	return blks
+}
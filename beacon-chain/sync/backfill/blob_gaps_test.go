@@ -0,0 +1,120 @@
+package backfill
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/das"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestScanBlobArchive(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.DenebForkEpoch = 0
+	params.OverrideBeaconConfig(cfg)
+
+	blks, blobs := testBlobGen(t, 10, 4)
+	bs := filesystem.NewEphemeralBlobStorage(t)
+	// The first block has all its blobs, the second one some of them, the third one none.
+	for _, b := range blobs[0] {
+		require.NoError(t, bs.Save(verification.FakeVerifyForTest(t, b)))
+	}
+	require.NoError(t, bs.Save(verification.FakeVerifyForTest(t, blobs[1][1])))
+	mdb := &mockBackfillDB{orphaned: map[[32]byte]bool{blks[3].Root(): true}, finalized: &ethpb.Checkpoint{Epoch: 1}}
+	require.NoError(t, mdb.SaveROBlocks(context.Background(), blks, false))
+
+	secondsPerSlot := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+	s := &Service{
+		store:           &Store{store: mdb},
+		blobStore:       bs,
+		clock:           startup.NewClock(time.Now().Add(-200*secondsPerSlot), [32]byte{}),
+		pa:              mockAssigner{err: errors.New("no peers")},
+		newBlobVerifier: testNewBlobVerifier(),
+	}
+
+	missing, err := s.missingBlobs(blks[1])
+	require.NoError(t, err)
+	require.Equal(t, 2, len(missing))
+	require.Equal(t, uint64(0), missing[0].index)
+	require.Equal(t, uint64(2), missing[1].index)
+	require.Equal(t, blks[1].Root(), missing[0].blockRoot)
+
+	// The orphaned block is left out, and the missing blobs cannot be fetched without peers, so the incomplete
+	// blocks are added to the gap index and the cursor moves past the finalized blocks.
+	scan, err := s.scanBlobArchive(context.Background(), nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), scan.blocks)
+	require.Equal(t, 2, scan.incomplete)
+	require.Equal(t, 0, scan.filled)
+	require.Equal(t, float64(1)/3, scan.completeness())
+	require.Equal(t, 2, len(mdb.blobGaps))
+	require.Equal(t, true, mdb.blobGaps[blks[1].Root()] && mdb.blobGaps[blks[2].Root()])
+	require.Equal(t, params.BeaconConfig().SlotsPerEpoch, mdb.blobScanCursor)
+
+	// The next scan does not check the blocks below the cursor again, only the ones in the gap index.
+	require.NoError(t, bs.Remove(blks[0].Root()))
+	scan, err = s.scanBlobArchive(context.Background(), nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), scan.blocks)
+	require.Equal(t, 2, scan.incomplete)
+
+	// Once the blobs are stored, the gaps are removed from the index and the archive is complete.
+	for _, bl := range [][]blocks.ROBlob{blobs[1], blobs[2]} {
+		for _, b := range bl {
+			require.NoError(t, bs.Save(verification.FakeVerifyForTest(t, b)))
+		}
+	}
+	scan, err = s.scanBlobArchive(context.Background(), nil)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(mdb.blobGaps))
+	require.Equal(t, float64(1), scan.completeness())
+	require.Equal(t, float64(1), (&blobArchiveScan{}).completeness())
+}
+
+func TestFillPartialBlobGap(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.DenebForkEpoch = 0
+	params.OverrideBeaconConfig(cfg)
+
+	blks, blobs := testBlobGen(t, 10, 1)
+	blk := blks[0]
+	bs := filesystem.NewEphemeralBlobStorage(t)
+	// The block has its second blob stored, the first and third ones are missing.
+	require.NoError(t, bs.Save(verification.FakeVerifyForTest(t, blobs[0][1])))
+	nbv := func(b blocks.ROBlob, _ []verification.Requirement) verification.BlobVerifier {
+		return &verification.MockBlobVerifier{CbVerifiedROBlob: func() (blocks.VerifiedROBlob, error) {
+			return blocks.NewVerifiedROBlob(b), nil
+		}}
+	}
+	s := &Service{blobStore: bs, newBlobVerifier: nbv}
+	missing, err := s.missingBlobs(blk)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(missing))
+
+	// Only the missing blobs are verified and persisted, which is enough to complete the block.
+	bbv := newBlobBatchVerifier(nbv)
+	bsync := &blobSync{
+		current: 100,
+		bbv:     bbv,
+		store:   das.NewLazilyPersistentStore(bs, bbv, das.WithFullHistory()),
+	}
+	require.NoError(t, bsync.validate(missing[0], blobs[0][0]))
+	require.NoError(t, bsync.validate(missing[1], blobs[0][2]))
+	require.NoError(t, bsync.store.IsDataAvailable(context.Background(), 100, blk))
+	stored, err := bs.Indices(blk.Root())
+	require.NoError(t, err)
+	require.Equal(t, true, stored[0] && stored[1] && stored[2])
+	missing, err = s.missingBlobs(blk)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(missing))
+}
@@ -32,22 +32,31 @@ type blobSyncConfig struct {
 	retentionStart primitives.Slot
 	nbv            verification.NewBlobVerifier
 	store          *filesystem.BlobStorage
+	columns        *columnSyncConfig
 }
 
 func newBlobSync(current primitives.Slot, vbs verifiedROBlocks, cfg *blobSyncConfig) (*blobSync, error) {
-	expected, err := vbs.blobIdents(cfg.retentionStart)
+	expected, err := vbs.blobIdents(cfg.retentionStart, cfg.columns)
 	if err != nil {
 		return nil, err
 	}
 	bbv := newBlobBatchVerifier(cfg.nbv)
-	as := das.NewLazilyPersistentStore(cfg.store, bbv)
+	var opts []das.StoreOption
+	if cfg.store.ArchiveMode() {
+		opts = append(opts, das.WithFullHistory())
+	}
+	if cc := cfg.columns; cc != nil {
+		cbv := verification.NewDataColumnBatchVerifier(cc.ncv, verification.BackfillColumnSidecarRequirements)
+		opts = append(opts, das.WithDataColumns(cc.store, cbv, cc.custody))
+	}
+	as := das.NewLazilyPersistentStore(cfg.store, bbv, opts...)
 	return &blobSync{current: current, expected: expected, bbv: bbv, store: as}, nil
 }
 
 type blobVerifierMap map[[32]byte][fieldparams.MaxBlobsPerBlock]verification.BlobVerifier
 
 type blobSync struct {
-	store    das.AvailabilityStore
+	store    *das.LazilyPersistentStore
 	expected []blobSummary
 	next     int
 	bbv      *blobBatchVerifier
@@ -64,6 +73,12 @@ func (bs *blobSync) validateNext(rb blocks.ROBlob) error {
 	}
 	next := bs.expected[bs.next]
 	bs.next += 1
+	return bs.validate(next, rb)
+}
+
+// validate checks that the given BlobSidecar is the one described by the summary, runs the verifications of the
+// sidecar and stashes it in the availability store.
+func (bs *blobSync) validate(next blobSummary, rb blocks.ROBlob) error {
 	// Get the super cheap verifications out of the way before we init a verifier.
 	if next.blockRoot != rb.BlockRoot() {
 		return errors.Wrapf(errUnexpectedResponseContent, "next expected root=%#x, saw=%#x", next.blockRoot, rb.BlockRoot())
@@ -112,7 +127,9 @@ func (bbv *blobBatchVerifier) newVerifier(rb blocks.ROBlob) verification.BlobVer
 	return m[rb.Index]
 }
 
-func (bbv *blobBatchVerifier) VerifiedROBlobs(_ context.Context, blk blocks.ROBlock, _ []blocks.ROBlob) ([]blocks.VerifiedROBlob, error) {
+// VerifiedROBlobs returns the verified form of the given sidecars of the block. The availability store only passes
+// the sidecars which are not on disk yet, so that a block missing only some of its blobs can be completed.
+func (bbv *blobBatchVerifier) VerifiedROBlobs(_ context.Context, blk blocks.ROBlock, scs []blocks.ROBlob) ([]blocks.VerifiedROBlob, error) {
 	m, ok := bbv.verifiers[blk.Root()]
 	if !ok {
 		return nil, errors.Wrapf(verification.ErrMissingVerification, "no record of verifiers for root %#x", blk.Root())
@@ -121,17 +138,17 @@ func (bbv *blobBatchVerifier) VerifiedROBlobs(_ context.Context, blk blocks.ROBl
 	if err != nil {
 		return nil, errors.Wrapf(errUnexpectedCommitment, "error reading commitments from block root %#x", blk.Root())
 	}
-	vbs := make([]blocks.VerifiedROBlob, len(c))
-	for i := range c {
-		if m[i] == nil {
-			return nil, errors.Wrapf(errBatchVerifierMismatch, "do not have verifier for block root %#x idx %d", blk.Root(), i)
+	vbs := make([]blocks.VerifiedROBlob, len(scs))
+	for i, sc := range scs {
+		if sc.Index >= uint64(len(c)) || m[sc.Index] == nil {
+			return nil, errors.Wrapf(errBatchVerifierMismatch, "do not have verifier for block root %#x idx %d", blk.Root(), sc.Index)
 		}
-		vb, err := m[i].VerifiedROBlob()
+		vb, err := m[sc.Index].VerifiedROBlob()
 		if err != nil {
 			return nil, err
 		}
-		if !bytes.Equal(vb.KzgCommitment, c[i]) {
-			return nil, errors.Wrapf(errBatchVerifierMismatch, "commitments do not match, verified=%#x da check=%#x for root %#x", vb.KzgCommitment, c[i], vb.BlockRoot())
+		if !bytes.Equal(vb.KzgCommitment, c[sc.Index]) {
+			return nil, errors.Wrapf(errBatchVerifierMismatch, "commitments do not match, verified=%#x da check=%#x for root %#x", vb.KzgCommitment, c[sc.Index], vb.BlockRoot())
 		}
 		vbs[i] = vb
 	}
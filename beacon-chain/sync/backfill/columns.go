@@ -0,0 +1,204 @@
+package backfill
+
+import (
+	"context"
+	"sort"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/peerdas"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/verify"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+var errMissingColumns = errors.New("peers unable to serve the custody data columns of the batch")
+
+// columnSyncConfig holds what backfill needs to download the data columns of the blocks of the PeerDAS fork. It is
+// nil when the node does not store data columns, in which case the blobs of these blocks are downloaded instead.
+type columnSyncConfig struct {
+	store   *filesystem.DataColumnStorage
+	custody map[uint64]bool
+	ncv     verification.NewDataColumnVerifier
+}
+
+func newColumnSyncConfig(store *filesystem.DataColumnStorage, ini *verification.Initializer, pid peer.ID) (*columnSyncConfig, error) {
+	if store == nil || !params.PeerDASEnabled() {
+		return nil, nil
+	}
+	nodeID, err := peerdas.ConvertPeerIDToNodeID(pid)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not convert peer ID to node ID")
+	}
+	custody, err := peerdas.CustodyColumns(nodeID, peerdas.CustodySubnetCount())
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute custody columns")
+	}
+	return &columnSyncConfig{store: store, custody: custody, ncv: newDataColumnVerifierFromInitializer(ini)}, nil
+}
+
+// usesColumns tells if the data of the block is downloaded as data columns rather than blobs.
+func (cc *columnSyncConfig) usesColumns(b blocks.ROBlock) bool {
+	return cc != nil && b.Version() >= version.Deneb && params.PeerDASActive(slots.ToEpoch(b.Block().Slot()))
+}
+
+// missingColumns returns the custody columns which are not stored yet for each block of the batch that has commitments
+// within the data column retention period, by slot.
+func (cc *columnSyncConfig) missingColumns(current primitives.Slot, vbs verifiedROBlocks) (map[primitives.Slot]map[uint64]bool, error) {
+	missing := make(map[primitives.Slot]map[uint64]bool)
+	for _, b := range vbs {
+		slot := b.Block().Slot()
+		if !cc.usesColumns(b) || !cc.store.WithinRetentionPeriod(slots.ToEpoch(slot), slots.ToEpoch(current)) {
+			continue
+		}
+		c, err := b.Block().Body().BlobKzgCommitments()
+		if err != nil {
+			return nil, errors.Wrapf(err, "unexpected error checking commitments for block root %#x", b.Root())
+		}
+		if len(c) == 0 {
+			continue
+		}
+		stored := cc.store.Indices(b.Root())
+		for idx := range cc.custody {
+			if stored[idx] {
+				continue
+			}
+			if missing[slot] == nil {
+				missing[slot] = make(map[uint64]bool)
+			}
+			missing[slot][idx] = true
+		}
+	}
+	return missing, nil
+}
+
+// columnRangeRequest returns the by-range request of the given columns, spanning the slots of the blocks missing them.
+func columnRangeRequest(missing map[primitives.Slot]map[uint64]bool, columns []uint64) *eth.DataColumnSidecarsByRangeRequest {
+	var low, high primitives.Slot
+	first := true
+	for slot := range missing {
+		if first || slot < low {
+			low = slot
+		}
+		if first || slot > high {
+			high = slot
+		}
+		first = false
+	}
+	return &eth.DataColumnSidecarsByRangeRequest{
+		StartSlot: low,
+		Count:     uint64(high-low) + 1,
+		Columns:   columns,
+	}
+}
+
+// fetchColumns downloads the missing custody columns of the blocks of the batch by range, from the connected peers
+// custodying them, and stashes them in the availability store of the batch. A column a peer did not fully return is
+// requested from another peer, until every missing column is received.
+func (w *p2pWorker) fetchColumns(ctx context.Context, current primitives.Slot, vbs verifiedROBlocks, bs *blobSync) error {
+	missing, err := w.cc.missingColumns(current, vbs)
+	if err != nil || len(missing) == 0 {
+		return err
+	}
+	bySlot := make(map[primitives.Slot]blocks.ROBlock, len(missing))
+	remaining := make(map[uint64]bool)
+	for _, b := range vbs {
+		if m, ok := missing[b.Block().Slot()]; ok {
+			bySlot[b.Block().Slot()] = b
+			for idx := range m {
+				remaining[idx] = true
+			}
+		}
+	}
+
+	peers := w.p2p.Peers().Connected()
+	custody := make(map[peer.ID]map[uint64]bool, len(peers))
+	for _, pid := range peers {
+		columns, err := w.p2p.CustodyColumnsFromRemotePeer(pid)
+		if err != nil {
+			log.WithError(err).WithField("peer", pid).Debug("Could not compute peer custody columns")
+			continue
+		}
+		custody[pid] = columns
+	}
+	tried := make(map[uint64]map[peer.ID]bool)
+	for len(remaining) > 0 {
+		assigned := sync.AssignDataColumns(remaining, peers, custody, tried)
+		if len(assigned) == 0 {
+			break
+		}
+		for pid, columns := range assigned {
+			cols, err := w.requestColumns(ctx, pid, columnRangeRequest(missing, columns), bySlot)
+			if err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				log.WithError(err).WithField("peer", pid).Debug("Could not download data columns by range")
+				continue
+			}
+			for _, col := range cols {
+				if !missing[col.Slot()][col.ColumnIndex] {
+					continue
+				}
+				if err := bs.store.PersistColumns(current, col); err != nil {
+					return err
+				}
+				delete(missing[col.Slot()], col.ColumnIndex)
+				if len(missing[col.Slot()]) == 0 {
+					delete(missing, col.Slot())
+				}
+			}
+		}
+		remaining = make(map[uint64]bool)
+		for _, m := range missing {
+			for idx := range m {
+				remaining[idx] = true
+			}
+		}
+	}
+	if len(remaining) > 0 {
+		return errors.Wrapf(errMissingColumns, "columns %v", sortedColumns(remaining))
+	}
+	return nil
+}
+
+// requestColumns sends a by-range request for data columns to the peer, and checks that the columns belong to the
+// blocks of the batch at their slot. The whole response is dropped when one of them does not.
+func (w *p2pWorker) requestColumns(ctx context.Context, pid peer.ID, req *eth.DataColumnSidecarsByRangeRequest, bySlot map[primitives.Slot]blocks.ROBlock) ([]blocks.RODataColumn, error) {
+	cols, err := sync.SendDataColumnSidecarsByRangeRequest(ctx, w.c, w.p2p, pid, w.cm, req)
+	if err != nil {
+		return nil, err
+	}
+	for _, col := range cols {
+		b, ok := bySlot[col.Slot()]
+		if !ok {
+			continue
+		}
+		if err := verify.ColumnAlignsWithBlock(col, b); err != nil {
+			return nil, err
+		}
+	}
+	return cols, nil
+}
+
+func sortedColumns(m map[uint64]bool) []uint64 {
+	columns := make([]uint64, 0, len(m))
+	for c := range m {
+		columns = append(columns, c)
+	}
+	sort.Slice(columns, func(i, j int) bool { return columns[i] < columns[j] })
+	return columns
+}
+
+func newDataColumnVerifierFromInitializer(ini *verification.Initializer) verification.NewDataColumnVerifier {
+	return func(dc blocks.RODataColumn, reqs []verification.Requirement) verification.DataColumnVerifier {
+		return ini.NewDataColumnVerifier(dc, reqs)
+	}
+}
@@ -0,0 +1,82 @@
+package backfill
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func testNewDataColumnVerifier() verification.NewDataColumnVerifier {
+	return func(dc blocks.RODataColumn, _ []verification.Requirement) verification.DataColumnVerifier {
+		return &verification.MockDataColumnVerifier{CbVerifiedRODataColumn: func() (blocks.VerifiedRODataColumn, error) {
+			return blocks.NewVerifiedRODataColumn(dc), nil
+		}}
+	}
+}
+
+func TestColumnSync(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.DenebForkEpoch = 0
+	cfg.Eip7594ForkEpoch = 1
+	params.OverrideBeaconConfig(cfg)
+
+	current := primitives.Slot(128)
+	fork := params.BeaconConfig().SlotsPerEpoch
+	var vbs verifiedROBlocks
+	columns := make(map[primitives.Slot][]blocks.RODataColumn)
+	for _, b := range []struct {
+		slot   primitives.Slot
+		nblobs int
+	}{{slot: fork - 1, nblobs: 2}, {slot: fork, nblobs: 1}, {slot: fork + 1, nblobs: 0}, {slot: fork + 2, nblobs: 3}} {
+		blk, cols := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, b.slot, b.nblobs)
+		vbs = append(vbs, blk)
+		columns[b.slot] = cols
+	}
+	cs := filesystem.NewEphemeralDataColumnStorage(t)
+	cc := &columnSyncConfig{store: cs, custody: map[uint64]bool{1: true, 2: true}, ncv: testNewDataColumnVerifier()}
+	require.NoError(t, cs.Save(blocks.NewVerifiedRODataColumn(columns[fork][1])))
+
+	// Only the blobs of the block before the PeerDAS fork are expected when columns are used.
+	idents, err := vbs.blobIdents(0, nil)
+	require.NoError(t, err)
+	require.Equal(t, 6, len(idents))
+	idents, err = vbs.blobIdents(0, cc)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(idents))
+	require.Equal(t, vbs[0].Root(), idents[0].blockRoot)
+
+	missing, err := cc.missingColumns(current, vbs)
+	require.NoError(t, err)
+	require.DeepEqual(t, map[primitives.Slot]map[uint64]bool{fork: {2: true}, fork + 2: {1: true, 2: true}}, missing)
+	req := columnRangeRequest(missing, []uint64{1, 2})
+	require.Equal(t, fork, req.StartSlot)
+	require.Equal(t, uint64(3), req.Count)
+	require.DeepEqual(t, []uint64{1, 2}, req.Columns)
+
+	// The block of the PeerDAS fork is only available once its missing custody columns are persisted.
+	bs, err := newBlobSync(current, vbs, &blobSyncConfig{
+		retentionStart: 0,
+		nbv:            testNewBlobVerifier(),
+		store:          filesystem.NewEphemeralBlobStorage(t),
+		columns:        cc,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, bs.blobsNeeded())
+	require.NoError(t, bs.store.PersistColumns(current, columns[fork+2][1]))
+	require.NotNil(t, bs.store.IsDataAvailable(context.Background(), current, vbs[3]))
+	require.NoError(t, bs.store.PersistColumns(current, columns[fork][2], columns[fork+2][1], columns[fork+2][2]))
+	require.NoError(t, bs.store.IsDataAvailable(context.Background(), current, vbs[1]))
+	require.NoError(t, bs.store.IsDataAvailable(context.Background(), current, vbs[2]))
+	require.NoError(t, bs.store.IsDataAvailable(context.Background(), current, vbs[3]))
+	missing, err = cc.missingColumns(current, vbs)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(missing))
+}
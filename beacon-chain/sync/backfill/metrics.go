@@ -21,6 +21,24 @@ var (
 			Help: "Number of batches that are ready to be imported once they can be connected to the existing chain.",
 		},
 	)
+	blobArchiveLowestSlot = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "backfill_blob_archive_lowest_slot",
+			Help: "Lowest slot backfilled with blobs while in blob archive mode.",
+		},
+	)
+	blobArchiveCompleteness = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "backfill_blob_archive_completeness",
+			Help: "Fraction of the finalized blocks with blob commitments since the Deneb fork which have all their blobs stored, in blob archive mode.",
+		},
+	)
+	blobArchiveIncompleteBlocks = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "backfill_blob_archive_incomplete_blocks",
+			Help: "Number of finalized blocks since the Deneb fork which are missing some of their blobs, in blob archive mode.",
+		},
+	)
 	backfillRemainingBatches = promauto.NewGauge(
 		prometheus.GaugeOpts{
 			Name: "backfill_remaining_batches",
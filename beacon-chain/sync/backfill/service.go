@@ -38,6 +38,8 @@ type Service struct {
 	pa              PeerAssigner
 	batchImporter   batchImporter
 	blobStore       *filesystem.BlobStorage
+	columnStore     *filesystem.DataColumnStorage
+	columns         *columnSyncConfig
 	initSyncWaiter  func() error
 }
 
@@ -102,6 +104,15 @@ func WithInitSyncWaiter(w func() error) ServiceOption {
 	}
 }
 
+// WithDataColumnStorage sets the storage of data columns. With it, backfill downloads the custody columns of the blocks
+// of the PeerDAS fork rather than their blobs.
+func WithDataColumnStorage(cs *filesystem.DataColumnStorage) ServiceOption {
+	return func(s *Service) error {
+		s.columnStore = cs
+		return nil
+	}
+}
+
 // InitializerWaiter is an interface that is satisfied by verification.InitializerWaiter.
 // Using this interface enables node init to satisfy this requirement for the backfill service
 // while also allowing backfill to mock it in tests.
@@ -154,6 +165,9 @@ func NewService(ctx context.Context, su *Store, bStore *filesystem.BlobStorage,
 			return nil, err
 		}
 	}
+	if bStore.ArchiveMode() {
+		s.ms = archiveMinimumSlot(s.ms)
+	}
 	s.pool = newP2PBatchWorkerPool(p, s.nWorkers)
 
 	return s, nil
@@ -270,6 +284,17 @@ func (s *Service) Start() {
 		log.WithError(err).Error("Could not initialize blob verifier in backfill service")
 		return
 	}
+	s.columns, err = newColumnSyncConfig(s.columnStore, v, s.p2p.PeerID())
+	if err != nil {
+		log.WithError(err).Error("Could not initialize data column sync in backfill service")
+		return
+	}
+
+	if s.blobStore.ArchiveMode() {
+		// Blocks synced before the node was in blob archive mode may be missing their blobs, whether or not the
+		// node was checkpoint synced.
+		go s.fillBlobGaps(s.ctx)
+	}
 
 	if s.store.isGenesisSync() {
 		log.Info("Backfill short-circuit; node synced from genesis")
@@ -296,7 +321,8 @@ func (s *Service) Start() {
 			return
 		}
 	}
-	s.pool.spawn(ctx, s.nWorkers, clock, s.pa, s.verifier, s.ctxMap, s.newBlobVerifier, s.blobStore)
+	s.pool.spawn(ctx, s.nWorkers, clock, s.pa, s.verifier, s.ctxMap, s.newBlobVerifier, s.blobStore, s.columns)
+	s.updateArchiveMetrics()
 	s.batchSeq = newBatchSequencer(s.nWorkers, s.ms(s.clock.CurrentSlot()), primitives.Slot(status.LowSlot), primitives.Slot(s.batchSize))
 	if err = s.initBatches(); err != nil {
 		log.WithError(err).Error("Non-recoverable error in backfill service")
@@ -311,6 +337,7 @@ func (s *Service) Start() {
 			return
 		}
 		s.importBatches(ctx)
+		s.updateArchiveMetrics()
 		batchesWaiting.Set(float64(s.batchSeq.countWithState(batchImportable)))
 		if err := s.batchSeq.moveMinimum(s.ms(s.clock.CurrentSlot())); err != nil {
 			log.WithError(err).Error("Non-recoverable error while adjusting backfill minimum slot")
@@ -358,6 +385,32 @@ func minimumBackfillSlot(current primitives.Slot) primitives.Slot {
 	return current - offset
 }
 
+// archiveMinimumSlot extends the minimum backfill slot down to the start of the Deneb fork, so that nodes in blob
+// archive mode backfill the blobs for every block since Deneb.
+func archiveMinimumSlot(ms minimumSlotter) minimumSlotter {
+	return func(current primitives.Slot) primitives.Slot {
+		m := ms(current)
+		deneb, err := sync.BlobArchiveMinSlot()
+		if err != nil || deneb >= m {
+			return m
+		}
+		if deneb == 0 {
+			// Slot 0 is the genesis block, see minimumBackfillSlot.
+			return 1
+		}
+		return deneb
+	}
+}
+
+// updateArchiveMetrics reports the lowest slot backfilled when the node is in blob archive mode. The completeness
+// of the archive is reported by fillBlobGaps, from the blobs actually stored.
+func (s *Service) updateArchiveMetrics() {
+	if !s.blobStore.ArchiveMode() {
+		return
+	}
+	blobArchiveLowestSlot.Set(float64(s.store.status().LowSlot))
+}
+
 func newBlobVerifierFromInitializer(ini *verification.Initializer) verification.NewBlobVerifier {
 	return func(b blocks.ROBlob, reqs []verification.Requirement) verification.BlobVerifier {
 		return ini.NewBlobVerifier(b, reqs)
@@ -132,3 +132,22 @@ func TestBackfillMinSlotDefault(t *testing.T) {
 		require.Equal(t, specMin, s.ms(current))
 	})
 }
+
+func TestArchiveMinimumSlot(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.DenebForkEpoch = 10
+	params.OverrideBeaconConfig(cfg)
+	deneb := primitives.Slot(10 * params.BeaconConfig().SlotsPerEpoch)
+
+	ms := archiveMinimumSlot(mockMinimumSlotter{min: deneb + 100}.minimumSlot)
+	require.Equal(t, deneb, ms(deneb+1000))
+	// A minimum below the Deneb fork is kept as is.
+	ms = archiveMinimumSlot(mockMinimumSlotter{min: deneb - 1}.minimumSlot)
+	require.Equal(t, deneb-1, ms(deneb+1000))
+
+	cfg.DenebForkEpoch = 0
+	params.OverrideBeaconConfig(cfg)
+	ms = archiveMinimumSlot(mockMinimumSlotter{min: 100}.minimumSlot)
+	require.Equal(t, primitives.Slot(1), ms(1000))
+}
@@ -7,12 +7,14 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/das"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filters"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v5/proto/dbval"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 )
 
 var errBatchDisconnected = errors.New("highest block root in backfill batch doesn't match next parent_root")
@@ -176,6 +178,13 @@ type BeaconDB interface {
 	BackfillFinalizedIndex(ctx context.Context, blocks []blocks.ROBlock, finalizedChildRoot [32]byte) error
 	OriginCheckpointBlockRoot(context.Context) ([32]byte, error)
 	Block(context.Context, [32]byte) (interfaces.ReadOnlySignedBeaconBlock, error)
+	Blocks(ctx context.Context, f *filters.QueryFilter) ([]interfaces.ReadOnlySignedBeaconBlock, [][32]byte, error)
+	IsFinalizedBlock(ctx context.Context, blockRoot [32]byte) bool
 	SaveROBlocks(ctx context.Context, blks []blocks.ROBlock, cache bool) error
 	StateOrError(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error)
+	FinalizedCheckpoint(ctx context.Context) (*ethpb.Checkpoint, error)
+	BlobArchiveScanCursor(ctx context.Context) (primitives.Slot, uint64, error)
+	BlobArchiveGaps(ctx context.Context) ([][32]byte, error)
+	SaveBlobArchiveScan(ctx context.Context, cursor primitives.Slot, blocks uint64, gaps [][32]byte) error
+	DeleteBlobArchiveGaps(ctx context.Context, roots [][32]byte) error
 }
@@ -8,12 +8,14 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/das"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filters"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	blocktest "github.com/prysmaticlabs/prysm/v5/consensus-types/blocks/testing"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/proto/dbval"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 	"github.com/prysmaticlabs/prysm/v5/testing/util"
 )
@@ -30,6 +32,11 @@ type mockBackfillDB struct {
 	err                       error
 	states                    map[[32]byte]state.BeaconState
 	blocks                    map[[32]byte]blocks.ROBlock
+	orphaned                  map[[32]byte]bool
+	finalized                 *ethpb.Checkpoint
+	blobScanCursor            primitives.Slot
+	blobScanBlocks            uint64
+	blobGaps                  map[[32]byte]bool
 }
 
 var _ BeaconDB = &mockBackfillDB{}
@@ -85,6 +92,63 @@ func (d *mockBackfillDB) SaveROBlocks(ctx context.Context, blks []blocks.ROBlock
 	return nil
 }
 
+func (d *mockBackfillDB) Blocks(_ context.Context, f *filters.QueryFilter) ([]interfaces.ReadOnlySignedBeaconBlock, [][32]byte, error) {
+	q := f.Filters()
+	start, _ := q[filters.StartSlot].(primitives.Slot)
+	end, _ := q[filters.EndSlot].(primitives.Slot)
+	var blks []interfaces.ReadOnlySignedBeaconBlock
+	var roots [][32]byte
+	for root, b := range d.blocks {
+		if b.Block().Slot() < start || b.Block().Slot() > end {
+			continue
+		}
+		blks = append(blks, b)
+		roots = append(roots, root)
+	}
+	return blks, roots, nil
+}
+
+func (d *mockBackfillDB) IsFinalizedBlock(_ context.Context, blockRoot [32]byte) bool {
+	return !d.orphaned[blockRoot]
+}
+
+func (d *mockBackfillDB) FinalizedCheckpoint(_ context.Context) (*ethpb.Checkpoint, error) {
+	if d.finalized == nil {
+		return &ethpb.Checkpoint{Root: make([]byte, 32)}, nil
+	}
+	return d.finalized, nil
+}
+
+func (d *mockBackfillDB) BlobArchiveScanCursor(_ context.Context) (primitives.Slot, uint64, error) {
+	return d.blobScanCursor, d.blobScanBlocks, nil
+}
+
+func (d *mockBackfillDB) BlobArchiveGaps(_ context.Context) ([][32]byte, error) {
+	roots := make([][32]byte, 0, len(d.blobGaps))
+	for r := range d.blobGaps {
+		roots = append(roots, r)
+	}
+	return roots, nil
+}
+
+func (d *mockBackfillDB) SaveBlobArchiveScan(_ context.Context, cursor primitives.Slot, blocks uint64, gaps [][32]byte) error {
+	d.blobScanCursor, d.blobScanBlocks = cursor, blocks
+	if d.blobGaps == nil {
+		d.blobGaps = make(map[[32]byte]bool)
+	}
+	for _, r := range gaps {
+		d.blobGaps[r] = true
+	}
+	return nil
+}
+
+func (d *mockBackfillDB) DeleteBlobArchiveGaps(_ context.Context, roots [][32]byte) error {
+	for _, r := range roots {
+		delete(d.blobGaps, r)
+	}
+	return nil
+}
+
 func (d *mockBackfillDB) BackfillFinalizedIndex(ctx context.Context, blocks []blocks.ROBlock, finalizedChildRoot [32]byte) error {
 	return nil
 }
@@ -22,7 +22,9 @@ var errUnknownDomain = errors.New("runtime error looking up signing domain for f
 // verifiedROBlocks represents a slice of blocks that have passed signature verification.
 type verifiedROBlocks []blocks.ROBlock
 
-func (v verifiedROBlocks) blobIdents(retentionStart primitives.Slot) ([]blobSummary, error) {
+// blobIdents returns the blobs expected for the blocks within the blob retention period. The blocks whose data is
+// downloaded as data columns are skipped.
+func (v verifiedROBlocks) blobIdents(retentionStart primitives.Slot, cc *columnSyncConfig) ([]blobSummary, error) {
 	// early return if the newest block is outside the retention window
 	if len(v) > 0 && v[len(v)-1].Block().Slot() < retentionStart {
 		return nil, nil
@@ -32,7 +34,7 @@ func (v verifiedROBlocks) blobIdents(retentionStart primitives.Slot) ([]blobSumm
 		if v[i].Block().Slot() < retentionStart {
 			continue
 		}
-		if v[i].Block().Version() < version.Deneb {
+		if v[i].Block().Version() < version.Deneb || cc.usesColumns(v[i]) {
 			continue
 		}
 		c, err := v[i].Block().Body().BlobKzgCommitments()
@@ -10,6 +10,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 )
 
 type workerId int
@@ -24,6 +25,7 @@ type p2pWorker struct {
 	cm   sync.ContextByteVersions
 	nbv  verification.NewBlobVerifier
 	bfs  *filesystem.BlobStorage
+	cc   *columnSyncConfig
 }
 
 func (w *p2pWorker) run(ctx context.Context) {
@@ -45,7 +47,7 @@ func (w *p2pWorker) run(ctx context.Context) {
 
 func (w *p2pWorker) handleBlocks(ctx context.Context, b batch) batch {
 	cs := w.c.CurrentSlot()
-	blobRetentionStart, err := sync.BlobRPCMinValidSlot(cs)
+	blobRetentionStart, err := blobRetentionStart(cs, w.bfs.ArchiveMode())
 	if err != nil {
 		return b.withRetryableError(errors.Wrap(err, "configuration issue, could not compute minimum blob retention slot"))
 	}
@@ -73,10 +75,18 @@ func (w *p2pWorker) handleBlocks(ctx context.Context, b batch) batch {
 	}
 	backfillBlocksApproximateBytes.Add(float64(bdl))
 	log.WithFields(b.logFields()).WithField("dlbytes", bdl).Debug("Backfill batch block bytes downloaded")
-	bs, err := newBlobSync(cs, vb, &blobSyncConfig{retentionStart: blobRetentionStart, nbv: w.nbv, store: w.bfs})
+	bs, err := newBlobSync(cs, vb, &blobSyncConfig{retentionStart: blobRetentionStart, nbv: w.nbv, store: w.bfs, columns: w.cc})
 	if err != nil {
 		return b.withRetryableError(err)
 	}
+	if w.cc != nil {
+		// Unlike blobs, the custody columns are spread over many peers, so they are downloaded along with the blocks
+		// rather than from the peer of a later blob sync.
+		if err := w.fetchColumns(ctx, cs, vb, bs); err != nil {
+			log.WithError(err).WithFields(b.logFields()).Debug("Batch data column download failed")
+			return b.withRetryableError(err)
+		}
+	}
 	return b.withResults(vb, bs)
 }
 
@@ -101,7 +111,16 @@ func (w *p2pWorker) handleBlobs(ctx context.Context, b batch) batch {
 	return b.postBlobSync()
 }
 
-func newP2pWorker(id workerId, p p2p.P2P, todo, done chan batch, c *startup.Clock, v *verifier, cm sync.ContextByteVersions, nbv verification.NewBlobVerifier, bfs *filesystem.BlobStorage) *p2pWorker {
+// blobRetentionStart returns the lowest slot that backfill downloads blobs for. In blob archive mode this is the
+// start of the Deneb fork, so that the archive is filled with every blob peers are able to serve.
+func blobRetentionStart(current primitives.Slot, archive bool) (primitives.Slot, error) {
+	if archive {
+		return sync.BlobArchiveMinSlot()
+	}
+	return sync.BlobRPCMinValidSlot(current)
+}
+
+func newP2pWorker(id workerId, p p2p.P2P, todo, done chan batch, c *startup.Clock, v *verifier, cm sync.ContextByteVersions, nbv verification.NewBlobVerifier, bfs *filesystem.BlobStorage, cc *columnSyncConfig) *p2pWorker {
 	return &p2pWorker{
 		id:   id,
 		todo: todo,
@@ -112,5 +131,6 @@ func newP2pWorker(id workerId, p p2p.P2P, todo, done chan batch, c *startup.Cloc
 		cm:   cm,
 		nbv:  nbv,
 		bfs:  bfs,
+		cc:   cc,
 	}
 }
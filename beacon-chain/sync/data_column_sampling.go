@@ -0,0 +1,206 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/peerdas"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/das"
+	p2ptypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/types"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/crypto/rand"
+	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// dataColumnSamplingTimeout bounds the sampling of the data columns of a block, so that a block whose data is
+	// not available does not hold up the processing of the following ones.
+	dataColumnSamplingTimeout = 4 * time.Second
+	// dataColumnRequestTimeout bounds each round of data column requests sent to peers.
+	dataColumnRequestTimeout = time.Second
+	// dataColumnRequestRounds is the number of rounds of data column requests, each column still missing after a
+	// round being requested from another peer in the next one.
+	dataColumnRequestRounds = 3
+)
+
+// Sets up the sampler used to check data column availability of gossiped blocks, on networks
+// that schedule the PeerDAS fork and when data column storage is configured.
+func (s *Service) initDataColumnSampler() error {
+	if s.cfg.dataColumnStorage == nil || !params.PeerDASEnabled() {
+		return nil
+	}
+	nodeID, err := peerdas.ConvertPeerIDToNodeID(s.cfg.p2p.PeerID())
+	if err != nil {
+		return errors.Wrap(err, "could not convert peer ID to node ID")
+	}
+	custody, err := peerdas.CustodyColumns(nodeID, peerdas.CustodySubnetCount())
+	if err != nil {
+		return errors.Wrap(err, "could not compute custody columns")
+	}
+	bv := verification.NewDataColumnBatchVerifier(s.newDataColumnVerifier, verification.ByRootColumnSidecarRequirements)
+	s.dataColumnSampler = das.NewDataColumnSampler(s.cfg.dataColumnStorage, s, bv, custody)
+	return nil
+}
+
+// sampleDataColumns fetches the custody columns and samples of a block which are not stored yet, and waits for the
+// ones peers did not return to arrive through gossip, up to dataColumnSamplingTimeout.
+func (s *Service) sampleDataColumns(ctx context.Context, blk interfaces.ReadOnlySignedBeaconBlock, root [32]byte) error {
+	roBlock, err := blocks.NewROBlockWithRoot(blk, root)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, dataColumnSamplingTimeout)
+	defer cancel()
+	if err := s.dataColumnSampler.IsDataAvailable(ctx, s.cfg.clock.CurrentSlot(), roBlock); err != nil {
+		return errors.Wrap(err, "data columns not available")
+	}
+	return nil
+}
+
+// FetchDataColumns requests the given data columns of a block from connected peers custodying them. Each round of
+// requests is sent to all the peers at once and bounded by dataColumnRequestTimeout, and the columns still missing
+// after a round are requested from other peers in the next one. Columns that no peer returned are simply absent
+// from the result.
+func (s *Service) FetchDataColumns(ctx context.Context, root [32]byte, indices map[uint64]bool) ([]blocks.RODataColumn, error) {
+	remaining := make(map[uint64]bool, len(indices))
+	for idx := range indices {
+		remaining[idx] = true
+	}
+	peers := s.cfg.p2p.Peers().Connected()
+	rand.NewGenerator().Shuffle(len(peers), func(i, j int) {
+		peers[i], peers[j] = peers[j], peers[i]
+	})
+	custody := make(map[peer.ID]map[uint64]bool, len(peers))
+	for _, pid := range peers {
+		columns, err := s.cfg.p2p.CustodyColumnsFromRemotePeer(pid)
+		if err != nil {
+			log.WithError(err).WithField("peer", pid).Debug("Could not compute peer custody columns")
+			continue
+		}
+		custody[pid] = columns
+	}
+
+	var fetched []blocks.RODataColumn
+	tried := make(map[uint64]map[peer.ID]bool)
+	for round := 0; round < dataColumnRequestRounds && len(remaining) > 0; round++ {
+		if err := ctx.Err(); err != nil {
+			return fetched, err
+		}
+		reqs := assignDataColumnRequests(root, remaining, peers, custody, tried)
+		if len(reqs) == 0 {
+			break
+		}
+		for _, c := range s.sendDataColumnRequests(ctx, root, reqs) {
+			if remaining[c.ColumnIndex] {
+				delete(remaining, c.ColumnIndex)
+				fetched = append(fetched, c)
+			}
+		}
+	}
+	return fetched, nil
+}
+
+// assignDataColumnRequests builds the by-root requests of the remaining columns of a block, as assigned to peers by
+// AssignDataColumns.
+func assignDataColumnRequests(
+	root [32]byte,
+	remaining map[uint64]bool,
+	peers []peer.ID,
+	custody map[peer.ID]map[uint64]bool,
+	tried map[uint64]map[peer.ID]bool,
+) map[peer.ID]p2ptypes.DataColumnSidecarsByRootReq {
+	reqs := make(map[peer.ID]p2ptypes.DataColumnSidecarsByRootReq)
+	for pid, columns := range AssignDataColumns(remaining, peers, custody, tried) {
+		for _, idx := range columns {
+			reqs[pid] = append(reqs[pid], &eth.DataColumnIdentifier{BlockRoot: root[:], ColumnIndex: idx})
+		}
+	}
+	return reqs
+}
+
+// AssignDataColumns spreads the given columns over the peers custodying them, asking each column from a single peer
+// which was not asked for it before, and balancing the number of columns per peer. The assignments are recorded in
+// tried, so that the columns a peer failed to return are assigned to other peers on the next call. Columns that no
+// peer left can serve are not assigned.
+func AssignDataColumns(
+	columns map[uint64]bool,
+	peers []peer.ID,
+	custody map[peer.ID]map[uint64]bool,
+	tried map[uint64]map[peer.ID]bool,
+) map[peer.ID][]uint64 {
+	assigned := make(map[peer.ID][]uint64)
+	for _, idx := range sortedColumnIndices(columns) {
+		var best peer.ID
+		found := false
+		for _, pid := range peers {
+			if !custody[pid][idx] || tried[idx][pid] {
+				continue
+			}
+			if !found || len(assigned[pid]) < len(assigned[best]) {
+				best, found = pid, true
+			}
+		}
+		if !found {
+			continue
+		}
+		if tried[idx] == nil {
+			tried[idx] = make(map[peer.ID]bool)
+		}
+		tried[idx][best] = true
+		assigned[best] = append(assigned[best], idx)
+	}
+	return assigned
+}
+
+// sendDataColumnRequests sends the requests to their peers in parallel, returning the columns received before
+// dataColumnRequestTimeout.
+func (s *Service) sendDataColumnRequests(ctx context.Context, root [32]byte, reqs map[peer.ID]p2ptypes.DataColumnSidecarsByRootReq) []blocks.RODataColumn {
+	ctx, cancel := context.WithTimeout(ctx, dataColumnRequestTimeout)
+	defer cancel()
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		fetched []blocks.RODataColumn
+	)
+	for pid, req := range reqs {
+		wg.Add(1)
+		go func(pid peer.ID, req p2ptypes.DataColumnSidecarsByRootReq) {
+			defer wg.Done()
+			columns, err := SendDataColumnSidecarsByRoot(ctx, s.cfg.clock, s.cfg.p2p, pid, s.ctxMap, &req)
+			if err != nil {
+				if errors.Is(err, ErrInvalidFetchedData) {
+					s.cfg.p2p.Peers().Scorers().BadResponsesScorer().Increment(pid)
+				}
+				log.WithError(err).WithFields(logrus.Fields{
+					"peer":    pid,
+					"root":    fmt.Sprintf("%#x", root),
+					"columns": len(req),
+				}).Debug("Could not fetch data columns from peer")
+				return
+			}
+			mu.Lock()
+			fetched = append(fetched, columns...)
+			mu.Unlock()
+		}(pid, req)
+	}
+	wg.Wait()
+	return fetched
+}
+
+func sortedColumnIndices(m map[uint64]bool) []uint64 {
+	indices := make([]uint64, 0, len(m))
+	for idx := range m {
+		indices = append(indices, idx)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	return indices
+}
@@ -0,0 +1,46 @@
+package sync
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestAssignDataColumnRequests(t *testing.T) {
+	root := [32]byte{1}
+	a, b, c := peer.ID("a"), peer.ID("b"), peer.ID("c")
+	peers := []peer.ID{a, b, c}
+	custody := map[peer.ID]map[uint64]bool{
+		a: {1: true, 2: true, 3: true, 4: true},
+		b: {1: true, 2: true, 3: true, 4: true},
+		c: {5: true},
+	}
+	remaining := map[uint64]bool{1: true, 2: true, 3: true, 4: true, 5: true, 6: true}
+	tried := make(map[uint64]map[peer.ID]bool)
+
+	// The columns are spread over the custodying peers, and the ones no peer custodies are left out.
+	reqs := assignDataColumnRequests(root, remaining, peers, custody, tried)
+	require.Equal(t, 3, len(reqs))
+	require.Equal(t, 2, len(reqs[a]))
+	require.Equal(t, 2, len(reqs[b]))
+	require.Equal(t, 1, len(reqs[c]))
+	assert.Equal(t, uint64(1), reqs[a][0].ColumnIndex)
+	assert.Equal(t, uint64(3), reqs[a][1].ColumnIndex)
+	assert.Equal(t, uint64(2), reqs[b][0].ColumnIndex)
+	assert.Equal(t, uint64(4), reqs[b][1].ColumnIndex)
+	assert.DeepEqual(t, root[:], reqs[c][0].BlockRoot)
+
+	// The next round asks each column from a peer not asked for it yet.
+	reqs = assignDataColumnRequests(root, remaining, peers, custody, tried)
+	require.Equal(t, 2, len(reqs))
+	assert.Equal(t, uint64(2), reqs[a][0].ColumnIndex)
+	assert.Equal(t, uint64(4), reqs[a][1].ColumnIndex)
+	assert.Equal(t, uint64(1), reqs[b][0].ColumnIndex)
+	assert.Equal(t, uint64(3), reqs[b][1].ColumnIndex)
+
+	// Once every custodying peer was asked, there is nothing left to request.
+	reqs = assignDataColumnRequests(root, remaining, peers, custody, tried)
+	require.Equal(t, 0, len(reqs))
+}
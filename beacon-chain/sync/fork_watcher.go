@@ -1,6 +1,8 @@
 package sync
 
 import (
+	"strings"
+
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
@@ -66,6 +68,25 @@ func (s *Service) registerForUpcomingFork(currEpoch primitives.Epoch) error {
 		if nextEpoch == params.BeaconConfig().DenebForkEpoch {
 			s.registerRPCHandlersDeneb()
 		}
+		if nextEpoch == params.BeaconConfig().Eip7594ForkEpoch {
+			s.registerRPCHandlersPeerDAS()
+		}
+		return nil
+	}
+	// PeerDAS does not come with a fork digest of its own, so when it is not scheduled alongside
+	// a fork the data column topics are registered under the current digest.
+	if currEpoch+1 == params.BeaconConfig().Eip7594ForkEpoch {
+		digest, err := s.currentForkDigest()
+		if err != nil {
+			return errors.Wrap(err, "could not retrieve current fork digest")
+		}
+		for _, t := range s.subHandler.allTopics() {
+			if strings.Contains(t, p2p.GossipDataColumnSidecarMessage) {
+				return nil
+			}
+		}
+		s.subscribeDataColumnSubnets(digest)
+		s.registerRPCHandlersPeerDAS()
 	}
 	return nil
 }
@@ -51,6 +51,9 @@ const (
 	// peerFilterCapacityWeight defines how peer's capacity affects peer's score. Provided as
 	// percentage, i.e. 0.3 means capacity will determine 30% of peer's score.
 	peerFilterCapacityWeight = 0.2
+	// peerFilterThroughputWeight defines how measured throughput affects the score of a peer, once
+	// the peer served some requests.
+	peerFilterThroughputWeight = 0.4
 	// backtrackingMaxHops how many hops (during search for common ancestor in backtracking) to do
 	// before giving up.
 	backtrackingMaxHops = 128
@@ -78,6 +81,9 @@ type blocksFetcherConfig struct {
 	peerFilterCapacityWeight float64
 	mode                     syncMode
 	bs                       filesystem.BlobStorageSummarizer
+	archive                  bool
+	dcs                      *filesystem.DataColumnStorage
+	custody                  map[uint64]bool
 }
 
 // blocksFetcher is a service to fetch chain data from peers.
@@ -94,9 +100,13 @@ type blocksFetcher struct {
 	p2p             p2p.P2P
 	db              db.ReadOnlyDatabase
 	bs              filesystem.BlobStorageSummarizer
+	archive         bool // fetch blobs since the Deneb fork, for a node in blob archive mode
+	dcs             *filesystem.DataColumnStorage
+	custody         map[uint64]bool // data columns custodied by the node, fetched for the blocks of the PeerDAS fork
 	blocksPerPeriod uint64
 	rateLimiter     *leakybucket.Collector
 	peerLocks       map[peer.ID]*peerLock
+	throughput      *throughputTracker
 	fetchRequests   chan *fetchRequestParams
 	fetchResponses  chan *fetchRequestResponse
 	capacityWeight  float64       // how remaining capacity affects peer selection
@@ -153,9 +163,13 @@ func newBlocksFetcher(ctx context.Context, cfg *blocksFetcherConfig) *blocksFetc
 		p2p:             cfg.p2p,
 		db:              cfg.db,
 		bs:              cfg.bs,
+		archive:         cfg.archive,
+		dcs:             cfg.dcs,
+		custody:         cfg.custody,
 		blocksPerPeriod: uint64(blocksPerPeriod),
 		rateLimiter:     rateLimiter,
 		peerLocks:       make(map[peer.ID]*peerLock),
+		throughput:      newThroughputTracker(uint64(blocksPerPeriod)),
 		fetchRequests:   make(chan *fetchRequestParams, maxPendingRequests),
 		fetchResponses:  make(chan *fetchRequestResponse, maxPendingRequests),
 		capacityWeight:  capacityWeight,
@@ -206,6 +220,7 @@ func (f *blocksFetcher) stop() {
 	}()
 	f.cancel()
 	<-f.quit // make sure that loop() is done
+	f.throughput.reset()
 }
 
 // requestResponses exposes a channel into which fetcher pushes generated request responses.
@@ -224,7 +239,7 @@ func (f *blocksFetcher) loop() {
 		close(f.fetchResponses)
 	}()
 
-	// Periodically remove stale peer locks.
+	// Periodically remove stale peer locks and measurements.
 	go func() {
 		ticker := time.NewTicker(peerLocksPollingInterval)
 		defer ticker.Stop()
@@ -232,6 +247,7 @@ func (f *blocksFetcher) loop() {
 			select {
 			case <-ticker.C:
 				f.removeStalePeerLocks(peerLockMaxAge)
+				f.throughput.prune(peerThroughputMaxAge)
 			case <-f.ctx.Done():
 				return
 			}
@@ -322,6 +338,13 @@ func (f *blocksFetcher) handleRequest(ctx context.Context, start primitives.Slot
 		}
 		response.bwb = bwb
 	}
+	if response.err == nil {
+		bwb, err := f.fetchDataColumnsFromPeers(ctx, response.bwb, append([]peer.ID{response.pid}, peers...))
+		if err != nil {
+			response.err = err
+		}
+		response.bwb = bwb
+	}
 	return response
 }
 
@@ -335,30 +358,44 @@ func (f *blocksFetcher) fetchBlocksFromPeer(
 	defer span.End()
 
 	peers = f.filterPeers(ctx, peers, peersPercentagePerRequest)
-	req := &p2ppb.BeaconBlocksByRangeRequest{
-		StartSlot: start,
-		Count:     count,
-		Step:      1,
-	}
-	bestPeers := f.hasSufficientBandwidth(peers, req.Count)
+	bestPeers := f.hasSufficientBandwidth(peers, count)
 	// We append the best peers to the front so that higher capacity
 	// peers are dialed first.
 	peers = append(bestPeers, peers...)
 	peers = dedupPeers(peers)
-	for i := 0; i < len(peers); i++ {
-		p := peers[i]
-		blocks, err := f.requestBlocks(ctx, req, p)
-		if err != nil {
-			log.WithField("peer", p).WithError(err).Debug("Could not request blocks by range from peer")
-			continue
+	// A peer is sent no more concurrent fetches than its pipeline depth allows. Busy peers are skipped, and when
+	// only busy peers are left, the fetch waits for one of them to complete a fetch before trying them again.
+	for len(peers) > 0 {
+		released := f.throughput.releases()
+		var busy []peer.ID
+		for _, p := range peers {
+			if err := f.throughput.reserve(p); err != nil {
+				busy = append(busy, p)
+				continue
+			}
+			blocks, err := f.requestBlocksInBatches(ctx, start, count, p)
+			f.throughput.release(p)
+			if err != nil {
+				log.WithField("peer", p).WithError(err).Debug("Could not request blocks by range from peer")
+				continue
+			}
+			f.p2p.Peers().Scorers().BlockProviderScorer().Touch(p)
+			robs, err := sortedBlockWithVerifiedBlobSlice(blocks)
+			if err != nil {
+				log.WithField("peer", p).WithError(err).Debug("invalid BeaconBlocksByRange response")
+				continue
+			}
+			return robs, p, err
 		}
-		f.p2p.Peers().Scorers().BlockProviderScorer().Touch(p)
-		robs, err := sortedBlockWithVerifiedBlobSlice(blocks)
-		if err != nil {
-			log.WithField("peer", p).WithError(err).Debug("invalid BeaconBlocksByRange response")
-			continue
+		if len(busy) == 0 {
+			break
+		}
+		select {
+		case <-released:
+		case <-ctx.Done():
+			return nil, "", ctx.Err()
 		}
-		return robs, p, err
+		peers = busy
 	}
 	return nil, "", errNoPeersAvailable
 }
@@ -529,19 +566,30 @@ func missingCommitError(root [32]byte, slot primitives.Slot, missing [][]byte) e
 		"block root %#x at slot %d missing %d commitments %s", root, slot, len(missing), strings.Join(missStr, ","))
 }
 
-// fetchBlobsFromPeer fetches blocks from a single randomly selected peer.
+// blobWindowStart returns the lowest slot of the blocks whose blobs are fetched. A node in blob archive mode keeps
+// the blobs of every block since the Deneb fork, so it also fetches them outside of the data availability window.
+func (f *blocksFetcher) blobWindowStart() (primitives.Slot, error) {
+	if f.archive {
+		return prysmsync.BlobArchiveMinSlot()
+	}
+	return prysmsync.BlobRPCMinValidSlot(f.clock.CurrentSlot())
+}
+
+// fetchBlobsFromPeer fetches the blobs of the blocks, starting with the peer which served the blocks. The data of the
+// blocks of the PeerDAS fork is fetched as data columns by fetchDataColumnsFromPeers instead.
 func (f *blocksFetcher) fetchBlobsFromPeer(ctx context.Context, bwb []blocks2.BlockWithROBlobs, pid peer.ID, peers []peer.ID) ([]blocks2.BlockWithROBlobs, error) {
 	ctx, span := trace.StartSpan(ctx, "initialsync.fetchBlobsFromPeer")
 	defer span.End()
 	if slots.ToEpoch(f.clock.CurrentSlot()) < params.BeaconConfig().DenebForkEpoch {
 		return bwb, nil
 	}
-	blobWindowStart, err := prysmsync.BlobRPCMinValidSlot(f.clock.CurrentSlot())
+	blobWindowStart, err := f.blobWindowStart()
 	if err != nil {
 		return nil, err
 	}
+	blobBlocks := bwb[:f.firstColumnBlock(bwb)]
 	// Construct request message based on observed interval of blocks in need of blobs.
-	req := countCommitments(bwb, blobWindowStart).blobRange(f.bs).Request()
+	req := countCommitments(blobBlocks, blobWindowStart).blobRange(f.bs).Request()
 	if req == nil {
 		return bwb, nil
 	}
@@ -561,16 +609,40 @@ func (f *blocksFetcher) fetchBlobsFromPeer(ctx context.Context, bwb []blocks2.Bl
 			continue
 		}
 		f.p2p.Peers().Scorers().BlockProviderScorer().Touch(p)
-		robs, err := verifyAndPopulateBlobs(bwb, blobs, req, f.bs)
-		if err != nil {
+		if _, err := verifyAndPopulateBlobs(blobBlocks, blobs, req, f.bs); err != nil {
 			log.WithField("peer", p).WithError(err).Debug("Invalid BeaconBlobsByRange response")
 			continue
 		}
-		return robs, err
+		return bwb, nil
 	}
 	return nil, errNoPeersAvailable
 }
 
+// requestBlocksInBatches requests a range of blocks from a peer, split into batches sized to the
+// measured bandwidth of the peer.
+func (f *blocksFetcher) requestBlocksInBatches(
+	ctx context.Context,
+	start primitives.Slot, count uint64,
+	pid peer.ID,
+) ([]interfaces.ReadOnlySignedBeaconBlock, error) {
+	var blocks []interfaces.ReadOnlySignedBeaconBlock
+	for requested := uint64(0); requested < count; {
+		size := math.Min(f.throughput.batchSize(pid), count-requested)
+		req := &p2ppb.BeaconBlocksByRangeRequest{
+			StartSlot: start.Add(requested),
+			Count:     size,
+			Step:      1,
+		}
+		batch, err := f.requestBlocks(ctx, req, pid)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, batch...)
+		requested += size
+	}
+	return blocks, nil
+}
+
 // requestBlocks is a wrapper for handling BeaconBlocksByRangeRequest requests/streams.
 func (f *blocksFetcher) requestBlocks(
 	ctx context.Context,
@@ -598,7 +670,23 @@ func (f *blocksFetcher) requestBlocks(
 	}
 	f.rateLimiter.Add(pid.String(), int64(req.Count))
 	l.Unlock()
-	return prysmsync.SendBeaconBlocksByRangeRequest(ctx, f.chain, f.p2p, pid, req, nil)
+	rs := f.throughput.started(pid)
+	// The time of the first block separates the latency of the peer from the transfer of the blocks.
+	var first time.Time
+	onBlock := func(interfaces.ReadOnlySignedBeaconBlock) error {
+		if first.IsZero() {
+			first = f.throughput.now()
+		}
+		return nil
+	}
+	blocks, err := prysmsync.SendBeaconBlocksByRangeRequest(ctx, f.chain, f.p2p, pid, req, onBlock)
+	if ctx.Err() != nil {
+		// A cancelled request tells nothing about the peer.
+		f.throughput.cancelled(pid)
+		return blocks, err
+	}
+	f.throughput.finished(pid, rs, first, req.Count, len(blocks), err)
+	return blocks, err
 }
 
 func (f *blocksFetcher) requestBlobs(ctx context.Context, req *p2ppb.BlobSidecarsByRangeRequest, pid peer.ID) ([]blocks.ROBlob, error) {
@@ -625,7 +713,11 @@ func (f *blocksFetcher) requestBlobs(ctx context.Context, req *p2ppb.BlobSidecar
 	}
 	f.rateLimiter.Add(pid.String(), int64(req.Count))
 	l.Unlock()
-	return prysmsync.SendBlobsByRangeRequest(ctx, f.clock, f.p2p, pid, f.ctxMap, req)
+	blobs, err := prysmsync.SendBlobsByRangeRequest(ctx, f.clock, f.p2p, pid, f.ctxMap, req)
+	if err != nil && ctx.Err() == nil {
+		f.throughput.failed(pid)
+	}
+	return blobs, err
 }
 
 // requestBlocksByRoot is a wrapper for handling BeaconBlockByRootsReq requests/streams.
@@ -0,0 +1,257 @@
+package initialsync
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	prysmsync "github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/verify"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	blocks2 "github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	p2ppb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+var errMissingDataColumns = errors.New("peers unable to serve the custody data columns of blocks with kzg commitments")
+
+// firstColumnBlock returns the index of the first block whose data is fetched as data columns rather than blobs. The
+// blocks are sorted by slot, so the following ones belong to the PeerDAS fork as well. Without data column storage,
+// the data of every block is fetched as blobs.
+func (f *blocksFetcher) firstColumnBlock(bwb []blocks2.BlockWithROBlobs) int {
+	if f.dcs == nil {
+		return len(bwb)
+	}
+	return sort.Search(len(bwb), func(i int) bool {
+		return params.PeerDASActive(slots.ToEpoch(bwb[i].Block.Block().Slot()))
+	})
+}
+
+// columnRange describes the custody columns missing from the blocks of a batch.
+type columnRange struct {
+	low     primitives.Slot
+	high    primitives.Slot
+	missing map[int]map[uint64]bool // missing custody columns, by index of the block in the batch
+	columns map[uint64]bool         // custody columns missing from any of the blocks
+}
+
+// missingColumns finds the blocks with commitments, at or after windowStart, which are missing some of the custody
+// columns from the data column storage. It returns nil when no column is missing.
+func missingColumns(bwb []blocks2.BlockWithROBlobs, windowStart primitives.Slot, dcs *filesystem.DataColumnStorage, custody map[uint64]bool) *columnRange {
+	var r *columnRange
+	for i := range bwb {
+		b := bwb[i].Block
+		slot := b.Block().Slot()
+		if b.Version() < version.Deneb || slot < windowStart {
+			continue
+		}
+		commits, err := b.Block().Body().BlobKzgCommitments()
+		if err != nil || len(commits) == 0 {
+			continue
+		}
+		stored := dcs.Indices(b.Root())
+		missing := make(map[uint64]bool)
+		for c := range custody {
+			if !stored[c] {
+				missing[c] = true
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+		if r == nil {
+			r = &columnRange{low: slot, missing: make(map[int]map[uint64]bool), columns: make(map[uint64]bool)}
+		}
+		r.high = slot
+		r.missing[i] = missing
+		for c := range missing {
+			r.columns[c] = true
+		}
+	}
+	return r
+}
+
+// request returns the by-range request of the given columns of the blocks in the range.
+func (r *columnRange) request(columns []uint64) *p2ppb.DataColumnSidecarsByRangeRequest {
+	return &p2ppb.DataColumnSidecarsByRangeRequest{
+		StartSlot: r.low,
+		Count:     uint64(r.high.FlooredSubSlot(r.low)) + 1,
+		Columns:   columns,
+	}
+}
+
+// complete tells if the column has been received for every block missing it.
+func (r *columnRange) complete(idx uint64, received map[int]map[uint64]blocks2.RODataColumn) bool {
+	for i, missing := range r.missing {
+		if _, ok := received[i][idx]; missing[idx] && !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// fetchDataColumnsFromPeers fetches the custody columns of the blocks of the PeerDAS fork which are not stored yet, from
+// the given peers custodying them. Each column is requested by range from a single peer, and the columns a peer did
+// not fully return are requested from another peer, until every missing custody column of the blocks is received.
+func (f *blocksFetcher) fetchDataColumnsFromPeers(ctx context.Context, bwb []blocks2.BlockWithROBlobs, peers []peer.ID) ([]blocks2.BlockWithROBlobs, error) {
+	ctx, span := trace.StartSpan(ctx, "initialsync.fetchDataColumnsFromPeers")
+	defer span.End()
+	first := f.firstColumnBlock(bwb)
+	if first == len(bwb) {
+		return bwb, nil
+	}
+	windowStart, err := prysmsync.DataColumnRPCMinValidSlot(f.clock.CurrentSlot())
+	if err != nil {
+		return nil, err
+	}
+	columnBlocks := bwb[first:]
+	r := missingColumns(columnBlocks, windowStart, f.dcs, f.custody)
+	if r == nil {
+		return bwb, nil
+	}
+
+	peers = dedupPeers(peers)
+	custody := make(map[peer.ID]map[uint64]bool, len(peers))
+	for _, pid := range peers {
+		columns, err := f.p2p.CustodyColumnsFromRemotePeer(pid)
+		if err != nil {
+			log.WithError(err).WithField("peer", pid).Debug("Could not compute peer custody columns")
+			continue
+		}
+		custody[pid] = columns
+	}
+	received := make(map[int]map[uint64]blocks2.RODataColumn, len(r.missing))
+	remaining := make(map[uint64]bool, len(r.columns))
+	for c := range r.columns {
+		remaining[c] = true
+	}
+	tried := make(map[uint64]map[peer.ID]bool)
+	for len(remaining) > 0 {
+		assigned := prysmsync.AssignDataColumns(remaining, peers, custody, tried)
+		if len(assigned) == 0 {
+			break
+		}
+		f.requestAssignedDataColumns(ctx, r, columnBlocks, assigned, received)
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		for c := range remaining {
+			if r.complete(c, received) {
+				delete(remaining, c)
+			}
+		}
+	}
+	if len(remaining) > 0 {
+		return nil, errors.Wrapf(errMissingDataColumns, "slots %d-%d, columns %v", r.low, r.high, sortedColumns(remaining))
+	}
+	for i, missing := range r.missing {
+		columns := make([]blocks2.RODataColumn, 0, len(missing))
+		for _, c := range sortedColumns(missing) {
+			columns = append(columns, received[i][c])
+		}
+		columnBlocks[i].Columns = columns
+	}
+	return bwb, nil
+}
+
+// requestAssignedDataColumns sends the by-range requests of the assigned columns to their peers in parallel, adding
+// the columns of the blocks missing them to received. The response of a peer with a column which does not belong to
+// the block of its slot is dropped.
+func (f *blocksFetcher) requestAssignedDataColumns(
+	ctx context.Context,
+	r *columnRange,
+	bwb []blocks2.BlockWithROBlobs,
+	assigned map[peer.ID][]uint64,
+	received map[int]map[uint64]blocks2.RODataColumn,
+) {
+	bySlot := make(map[primitives.Slot]int, len(r.missing))
+	for i := range r.missing {
+		bySlot[bwb[i].Block.Block().Slot()] = i
+	}
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for pid, columns := range assigned {
+		wg.Add(1)
+		go func(pid peer.ID, req *p2ppb.DataColumnSidecarsByRangeRequest) {
+			defer wg.Done()
+			resp, err := f.requestDataColumns(ctx, req, pid)
+			if err != nil {
+				log.WithField("peer", pid).WithError(err).Debug("Could not request data columns by range from peer")
+				return
+			}
+			for _, col := range resp {
+				i, ok := bySlot[col.Slot()]
+				if !ok {
+					continue
+				}
+				if err := verify.ColumnAlignsWithBlock(col, bwb[i].Block); err != nil {
+					log.WithField("peer", pid).WithError(err).Debug("Invalid DataColumnSidecarsByRange response")
+					return
+				}
+			}
+			f.p2p.Peers().Scorers().BlockProviderScorer().Touch(pid)
+			mu.Lock()
+			defer mu.Unlock()
+			for _, col := range resp {
+				i, ok := bySlot[col.Slot()]
+				if !ok || !r.missing[i][col.ColumnIndex] {
+					continue
+				}
+				if received[i] == nil {
+					received[i] = make(map[uint64]blocks2.RODataColumn)
+				}
+				received[i][col.ColumnIndex] = col
+			}
+		}(pid, r.request(columns))
+	}
+	wg.Wait()
+}
+
+// requestDataColumns is a wrapper for handling DataColumnSidecarsByRangeRequest requests/streams.
+func (f *blocksFetcher) requestDataColumns(ctx context.Context, req *p2ppb.DataColumnSidecarsByRangeRequest, pid peer.ID) ([]blocks2.RODataColumn, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	l := f.peerLock(pid)
+	l.Lock()
+	log.WithFields(logrus.Fields{
+		"peer":     pid,
+		"start":    req.StartSlot,
+		"count":    req.Count,
+		"columns":  len(req.Columns),
+		"capacity": f.rateLimiter.Remaining(pid.String()),
+		"score":    f.p2p.Peers().Scorers().BlockProviderScorer().FormatScorePretty(pid),
+	}).Debug("Requesting data columns")
+	// Like blob requests, data column requests are accounted for as if they were block requests.
+	if f.rateLimiter.Remaining(pid.String()) < int64(req.Count) {
+		if err := f.waitForBandwidth(pid, req.Count); err != nil {
+			l.Unlock()
+			return nil, err
+		}
+	}
+	f.rateLimiter.Add(pid.String(), int64(req.Count))
+	l.Unlock()
+	columns, err := prysmsync.SendDataColumnSidecarsByRangeRequest(ctx, f.clock, f.p2p, pid, f.ctxMap, req)
+	if err != nil && ctx.Err() == nil {
+		f.throughput.failed(pid)
+	}
+	return columns, err
+}
+
+func sortedColumns(m map[uint64]bool) []uint64 {
+	columns := make([]uint64, 0, len(m))
+	for c := range m {
+		columns = append(columns, c)
+	}
+	sort.Slice(columns, func(i, j int) bool { return columns[i] < columns[j] })
+	return columns
+}
@@ -0,0 +1,83 @@
+package initialsync
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestBlocksFetcher_firstColumnBlock(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.DenebForkEpoch = 0
+	cfg.Eip7594ForkEpoch = 1
+	params.OverrideBeaconConfig(cfg)
+
+	bwb := make([]blocks.BlockWithROBlobs, 0, 3)
+	for _, slot := range []primitives.Slot{1, params.BeaconConfig().SlotsPerEpoch, params.BeaconConfig().SlotsPerEpoch + 1} {
+		blk, _ := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, slot, 1)
+		bwb = append(bwb, blocks.BlockWithROBlobs{Block: blk})
+	}
+
+	f := &blocksFetcher{}
+	require.Equal(t, 3, f.firstColumnBlock(bwb))
+	f.dcs = filesystem.NewEphemeralDataColumnStorage(t)
+	require.Equal(t, 1, f.firstColumnBlock(bwb))
+	require.Equal(t, 0, f.firstColumnBlock(bwb[1:]))
+	require.Equal(t, 1, f.firstColumnBlock(bwb[:1]))
+}
+
+func TestMissingColumns(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.DenebForkEpoch = 0
+	cfg.Eip7594ForkEpoch = 0
+	params.OverrideBeaconConfig(cfg)
+
+	custody := map[uint64]bool{1: true, 2: true}
+	dcs := filesystem.NewEphemeralDataColumnStorage(t)
+	var bwb []blocks.BlockWithROBlobs
+	var columns [][]blocks.RODataColumn
+	for _, b := range []struct {
+		slot   primitives.Slot
+		nblobs int
+	}{{slot: 1, nblobs: 1}, {slot: 2, nblobs: 0}, {slot: 3, nblobs: 1}, {slot: 4, nblobs: 2}, {slot: 6, nblobs: 1}, {slot: 7, nblobs: 1}} {
+		blk, cols := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, b.slot, b.nblobs)
+		bwb = append(bwb, blocks.BlockWithROBlobs{Block: blk})
+		columns = append(columns, cols)
+	}
+	// The block at slot 3 has every custody column, the block at slot 4 only has column 1.
+	require.NoError(t, dcs.Save(blocks.NewVerifiedRODataColumn(columns[2][1])))
+	require.NoError(t, dcs.Save(blocks.NewVerifiedRODataColumn(columns[2][2])))
+	require.NoError(t, dcs.Save(blocks.NewVerifiedRODataColumn(columns[3][1])))
+	// The block at slot 7 has every custody column as well.
+	require.NoError(t, dcs.Save(blocks.NewVerifiedRODataColumn(columns[5][1])))
+	require.NoError(t, dcs.Save(blocks.NewVerifiedRODataColumn(columns[5][2])))
+
+	// The block at slot 1 is before the window.
+	r := missingColumns(bwb, 2, dcs, custody)
+	require.NotNil(t, r)
+	require.Equal(t, primitives.Slot(4), r.low)
+	require.Equal(t, primitives.Slot(6), r.high)
+	require.DeepEqual(t, map[int]map[uint64]bool{3: {2: true}, 4: {1: true, 2: true}}, r.missing)
+	require.DeepEqual(t, custody, r.columns)
+
+	req := r.request([]uint64{1, 2})
+	require.Equal(t, primitives.Slot(4), req.StartSlot)
+	require.Equal(t, uint64(3), req.Count)
+	require.DeepEqual(t, []uint64{1, 2}, req.Columns)
+
+	received := map[int]map[uint64]blocks.RODataColumn{4: {1: columns[4][1]}}
+	require.Equal(t, true, r.complete(1, received))
+	require.Equal(t, false, r.complete(2, received))
+	received[3] = map[uint64]blocks.RODataColumn{2: columns[3][2]}
+	received[4][2] = columns[4][2]
+	require.Equal(t, true, r.complete(2, received))
+
+	require.Equal(t, (*columnRange)(nil), missingColumns(bwb, 7, dcs, custody))
+}
@@ -100,9 +100,15 @@ func (f *blocksFetcher) filterPeers(ctx context.Context, peers []peer.ID, peersP
 		return peers
 	}
 
+	// Leave out the peers dropped for being slow or failing, unless no other peer is left.
+	if usable := f.throughput.usable(peers); len(usable) > 0 {
+		peers = usable
+	}
+
 	// Sort peers using both block provider score and, custom, capacity based score (see
 	// peerFilterCapacityWeight if you want to give different weights to provider's and capacity
-	// scores).
+	// scores). Once a peer served some requests, its measured throughput is accounted for as
+	// well (see peerFilterThroughputWeight).
 	// Scores produced are used as weights, so peers are ordered probabilistically i.e. peer with
 	// a higher score has higher chance to end up higher in the list.
 	scorer := f.p2p.Peers().Scorers().BlockProviderScorer()
@@ -115,6 +121,10 @@ func (f *blocksFetcher) filterPeers(ctx context.Context, peers []peer.ID, peersP
 		}
 		capScore := remaining / capacity
 		overallScore := blockProviderScore*(1.0-f.capacityWeight) + capScore*f.capacityWeight
+		// Peers yet to be measured keep their score, so that they get a chance to be measured.
+		if throughputScore, ok := f.throughput.score(peerID); ok {
+			overallScore = overallScore*(1.0-peerFilterThroughputWeight) + throughputScore*peerFilterThroughputWeight
+		}
 		return math.Round(overallScore*scorers.ScoreRoundingFactor) / scorers.ScoreRoundingFactor
 	})
 
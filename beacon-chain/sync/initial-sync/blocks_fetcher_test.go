@@ -1303,3 +1303,26 @@ func TestBlockFetcher_HasSufficientBandwidth(t *testing.T) {
 	}
 	assert.Equal(t, 2, len(receivedPeers))
 }
+
+func TestBlocksFetcher_blobWindowStart(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.DenebForkEpoch = 1
+	params.OverrideBeaconConfig(cfg)
+
+	current := primitives.Slot(params.BeaconConfig().MinEpochsForBlobsSidecarsRequest+100) * params.BeaconConfig().SlotsPerEpoch
+	genesis := time.Now().Add(-time.Duration(uint64(current)*params.BeaconConfig().SecondsPerSlot) * time.Second)
+	clock := startup.NewClock(genesis, [32]byte{})
+
+	f := newBlocksFetcher(context.Background(), &blocksFetcherConfig{clock: clock})
+	want, err := beaconsync.BlobRPCMinValidSlot(clock.CurrentSlot())
+	require.NoError(t, err)
+	got, err := f.blobWindowStart()
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	f = newBlocksFetcher(context.Background(), &blocksFetcherConfig{clock: clock, archive: true})
+	got, err = f.blobWindowStart()
+	require.NoError(t, err)
+	assert.Equal(t, params.BeaconConfig().SlotsPerEpoch, got)
+}
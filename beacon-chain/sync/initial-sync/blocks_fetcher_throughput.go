@@ -0,0 +1,484 @@
+package initialsync
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	prysmTime "github.com/prysmaticlabs/prysm/v5/time"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// targetTransferDuration is how long the transfer of the blocks of a single blocks by range request is meant to
+	// take, once the peer starts responding. Request sizes are adapted to the bandwidth of each peer, so that its
+	// responses are transferred within that time. The latency of the peer is left out, it is hidden by sending the
+	// peer concurrent requests instead.
+	targetTransferDuration = 2 * time.Second
+	// minBlocksPerRequest is the smallest number of slots requested at once, used for slow and newly dropped peers.
+	minBlocksPerRequest = 8
+	// maxPeerPipelineDepth caps the number of concurrent requests to a single peer.
+	maxPeerPipelineDepth = 4
+	// maxLookaheadSteps caps the number of forward steps loaded into the queue, when peers can sustain more
+	// concurrent requests than lookaheadSteps.
+	maxLookaheadSteps = 16
+	// throughputSmoothing is the weight of the latest request in the moving averages of a peer.
+	throughputSmoothing = 0.3
+	// maxPeerFailures is the number of consecutive failed requests after which a peer is dropped.
+	maxPeerFailures = 2
+	// slowPeerMinSamples is the number of requests a peer must serve before its throughput is compared to others.
+	slowPeerMinSamples = 3
+	// slowPeerThreshold defines a slow peer, as a fraction of the throughput of the fastest peer.
+	slowPeerThreshold = 0.1
+	// peerDropPeriod is how long a dropped peer is left out of peer selection. It is then probed with small requests.
+	peerDropPeriod = time.Minute
+	// peerActivePeriod is the period within which a peer must have served a request to count toward the
+	// pipeline depth of the queue.
+	peerActivePeriod = time.Minute
+	// peerThroughputMaxAge is maximum time before the measurements of a peer are purged.
+	peerThroughputMaxAge = 10 * time.Minute
+)
+
+var errPeerBusy = errors.New("peer is already sent as many concurrent requests as allowed")
+
+var (
+	peerSyncRate = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "initial_sync_peer_blocks_per_second",
+		Help: "The effective sync rate of a peer, as a moving average of the blocks received per second of request.",
+	}, []string{"peer"})
+	peerRequestLatency = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "initial_sync_peer_request_latency_seconds",
+		Help: "The moving average of the time until a peer starts responding to a blocks by range request.",
+	}, []string{"peer"})
+	peerBandwidth = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "initial_sync_peer_bandwidth_slots_per_second",
+		Help: "The moving average of the slots a peer transfers per second, once it starts responding.",
+	}, []string{"peer"})
+	peerBatchSize = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "initial_sync_peer_batch_size",
+		Help: "The number of slots currently requested at once from a peer.",
+	}, []string{"peer"})
+	peerPipelineDepth = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "initial_sync_peer_pipeline_depth",
+		Help: "The number of concurrent requests currently allowed to a peer.",
+	}, []string{"peer"})
+	droppedSyncPeers = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "initial_sync_dropped_peers_total",
+		Help: "The number of times a peer was left out of initial sync, by reason.",
+	}, []string{"reason"})
+)
+
+// peerThroughput holds the measurements of the requests served by a peer.
+type peerThroughput struct {
+	bandwidth       float64       // moving average of the slots transferred per second once responding, sizes the requests
+	latency         time.Duration // moving average of the time until the peer starts responding, sets the pipeline depth
+	slotsPerSecond  float64       // moving average of the slots covered per second of non-empty request, ranks the peer
+	blocksPerSecond float64       // moving average of the blocks received per second, the effective sync rate
+	samples         int
+	ranked          int // requests averaged into slotsPerSecond
+	failures        int // consecutive failures
+	inflight        int // fetches reserved
+	requests        int // requests being measured
+	depth           int // concurrent fetches allowed
+	batchSize       uint64
+	droppedUntil    time.Time
+	updated         time.Time
+}
+
+// requestStart is the beginning of a measured request to a peer.
+type requestStart struct {
+	at time.Time
+	// queued tells if other requests to the peer were in flight, in which case the peer may be serving them first.
+	queued bool
+}
+
+// throughputTracker measures the bandwidth and latency of each peer, which drive the size of the requests to the
+// peer, the number of concurrent requests it is sent and how it ranks in peer selection. Peers which keep failing or
+// are much slower than others are dropped for a while.
+type throughputTracker struct {
+	sync.Mutex
+	now      func() time.Time
+	maxBatch uint64
+	peers    map[peer.ID]*peerThroughput
+	// released is closed, then replaced, whenever a fetch reserved from a peer is released.
+	released chan struct{}
+}
+
+func newThroughputTracker(maxBatch uint64) *throughputTracker {
+	return &throughputTracker{
+		now:      prysmTime.Now,
+		maxBatch: maxBatch,
+		peers:    make(map[peer.ID]*peerThroughput),
+		released: make(chan struct{}),
+	}
+}
+
+// peer returns the measurements of a peer, creating them if needed. The lock must be held.
+func (t *throughputTracker) peer(pid peer.ID) *peerThroughput {
+	p, ok := t.peers[pid]
+	if !ok {
+		// Unknown peers are sent full batches, one at a time, until measured.
+		p = &peerThroughput{depth: 1, batchSize: t.maxBatch, updated: t.now()}
+		t.peers[pid] = p
+	}
+	return p
+}
+
+func (t *throughputTracker) minBatch() uint64 {
+	if t.maxBatch < minBlocksPerRequest {
+		return t.maxBatch
+	}
+	return minBlocksPerRequest
+}
+
+// reserve takes one of the concurrent fetches allowed to a peer by its pipeline depth, failing with errPeerBusy when
+// they are all in flight. The fetch must be released once done.
+func (t *throughputTracker) reserve(pid peer.ID) error {
+	t.Lock()
+	defer t.Unlock()
+	p := t.peer(pid)
+	if p.inflight >= p.depth {
+		return errPeerBusy
+	}
+	p.inflight++
+	p.updated = t.now()
+	return nil
+}
+
+// release gives back a fetch taken with reserve, waking up the fetches waiting for a peer.
+func (t *throughputTracker) release(pid peer.ID) {
+	t.Lock()
+	defer t.Unlock()
+	if p := t.peer(pid); p.inflight > 0 {
+		p.inflight--
+	}
+	close(t.released)
+	t.released = make(chan struct{})
+}
+
+// releases returns a channel which is closed once a fetch reserved from any peer is released. It is taken before
+// trying to reserve peers, so that no release is missed while waiting.
+func (t *throughputTracker) releases() <-chan struct{} {
+	t.Lock()
+	defer t.Unlock()
+	return t.released
+}
+
+// started marks the beginning of a measured request to a peer.
+func (t *throughputTracker) started(pid peer.ID) requestStart {
+	t.Lock()
+	defer t.Unlock()
+	now := t.now()
+	p := t.peer(pid)
+	queued := p.requests > 0
+	p.requests++
+	p.updated = now
+	return requestStart{at: now, queued: queued}
+}
+
+// cancelled marks the end of a request which was cancelled, and says nothing about the peer.
+func (t *throughputTracker) cancelled(pid peer.ID) {
+	t.Lock()
+	defer t.Unlock()
+	if p := t.peer(pid); p.requests > 0 {
+		p.requests--
+	}
+}
+
+// finished records the outcome of a request for the given number of slots, which returned the given number of
+// blocks, the first of them at the given time, adapting the batch size and pipeline depth of the peer.
+//
+// The latency of the peer is the time until it starts responding, and its bandwidth the slots it covers per second
+// from then on. They are measured apart, so that the requests to a peer are sized to its bandwidth whatever its
+// latency, rather than shrinking along with the throughput of each request when the peer is slow to respond.
+func (t *throughputTracker) finished(pid peer.ID, rs requestStart, first time.Time, slots uint64, blocks int, err error) {
+	t.Lock()
+	defer t.Unlock()
+	now := t.now()
+	p := t.peer(pid)
+	if p.requests > 0 {
+		p.requests--
+	}
+	p.updated = now
+	if err != nil {
+		t.fail(pid, p)
+		return
+	}
+
+	elapsed := now.Sub(rs.at)
+	if elapsed <= 0 {
+		elapsed = time.Millisecond
+	}
+	// Without blocks, the whole request is spent waiting for the peer.
+	latency := elapsed
+	if blocks > 0 && !first.Before(rs.at) {
+		latency = first.Sub(rs.at)
+	}
+	transfer := elapsed - latency
+	if transfer <= 0 {
+		transfer = time.Millisecond
+	}
+	blocksPerSecond := float64(blocks) / elapsed.Seconds()
+	if p.samples == 0 {
+		p.blocksPerSecond = blocksPerSecond
+	} else {
+		p.blocksPerSecond += throughputSmoothing * (blocksPerSecond - p.blocksPerSecond)
+	}
+	// Empty responses are left out of the ranking, or a peer answering every request at once without blocks would
+	// rank above the peers which serve them.
+	if blocks > 0 {
+		slotsPerSecond := float64(slots) / elapsed.Seconds()
+		if p.ranked == 0 {
+			p.slotsPerSecond = slotsPerSecond
+		} else {
+			p.slotsPerSecond += throughputSmoothing * (slotsPerSecond - p.slotsPerSecond)
+		}
+		p.ranked++
+	}
+	// A request sent while others were in flight may wait for them to be served, which is not latency.
+	if !rs.queued || p.latency == 0 {
+		if p.latency == 0 {
+			p.latency = latency
+		} else {
+			p.latency += time.Duration(throughputSmoothing * float64(latency-p.latency))
+		}
+	}
+	// Empty responses transfer nothing, which says nothing about the bandwidth.
+	if blocks > 0 {
+		bandwidth := float64(slots) / transfer.Seconds()
+		if p.bandwidth == 0 {
+			p.bandwidth = bandwidth
+		} else {
+			p.bandwidth += throughputSmoothing * (bandwidth - p.bandwidth)
+		}
+	}
+	p.samples++
+	p.failures = 0
+	t.adapt(p)
+
+	if p.ranked >= slowPeerMinSamples && p.slotsPerSecond < slowPeerThreshold*t.fastest(now) {
+		t.drop(pid, p, "slow")
+		return
+	}
+	t.updateMetrics(pid, p)
+}
+
+// adapt sizes the requests to a peer so that their blocks are transferred within the target duration, and allows as
+// many concurrent requests to the peer as needed to keep it transferring while the next requests wait for its
+// latency. The lock must be held.
+func (t *throughputTracker) adapt(p *peerThroughput) {
+	if p.bandwidth > 0 {
+		p.batchSize = t.clampBatch(uint64(p.bandwidth * targetTransferDuration.Seconds()))
+	}
+	transfer := targetTransferDuration
+	if p.bandwidth > 0 {
+		transfer = time.Duration(float64(p.batchSize) / p.bandwidth * float64(time.Second))
+	}
+	// While a request transfers its blocks, the next ones wait for the latency of the peer.
+	want := maxPeerPipelineDepth
+	if transfer > 0 {
+		want = 1 + int(math.Round(float64(p.latency)/float64(transfer)))
+	}
+	if want > maxPeerPipelineDepth {
+		want = maxPeerPipelineDepth
+	}
+	// The depth moves one step at a time, so that a single early or late answer does not swing it.
+	switch {
+	case want > p.depth:
+		p.depth++
+	case want < p.depth:
+		p.depth--
+	}
+}
+
+func (t *throughputTracker) clampBatch(size uint64) uint64 {
+	if size < t.minBatch() {
+		return t.minBatch()
+	}
+	if size > t.maxBatch {
+		return t.maxBatch
+	}
+	return size
+}
+
+// failed records a failed request which was not measured, such as a blobs request.
+func (t *throughputTracker) failed(pid peer.ID) {
+	t.Lock()
+	defer t.Unlock()
+	p := t.peer(pid)
+	p.updated = t.now()
+	t.fail(pid, p)
+}
+
+// fail shrinks the requests to a peer after a failure, dropping the peer once it failed too many times in a row.
+// The lock must be held.
+func (t *throughputTracker) fail(pid peer.ID, p *peerThroughput) {
+	p.failures++
+	p.depth = 1
+	p.batchSize = t.clampBatch(p.batchSize / 2)
+	if p.failures >= maxPeerFailures {
+		t.drop(pid, p, "failures")
+		return
+	}
+	t.updateMetrics(pid, p)
+}
+
+// drop leaves a peer out of peer selection for a while. Its measurements are reset, so that it is probed with small
+// requests once back. The lock must be held.
+func (t *throughputTracker) drop(pid peer.ID, p *peerThroughput, reason string) {
+	log.WithFields(logrus.Fields{
+		"peer":           pid,
+		"reason":         reason,
+		"slotsPerSecond": p.slotsPerSecond,
+		"bandwidth":      p.bandwidth,
+		"latency":        p.latency,
+	}).Debug("Dropping peer from initial sync")
+	droppedSyncPeers.WithLabelValues(reason).Inc()
+	p.droppedUntil = t.now().Add(peerDropPeriod)
+	p.bandwidth, p.slotsPerSecond, p.blocksPerSecond, p.latency = 0, 0, 0, 0
+	p.samples, p.ranked, p.failures = 0, 0, 0
+	p.depth = 1
+	p.batchSize = t.minBatch()
+	t.updateMetrics(pid, p)
+}
+
+// fastest returns the highest throughput among the ranked peers which are not dropped. The lock must be held.
+func (t *throughputTracker) fastest(now time.Time) float64 {
+	var fastest float64
+	for _, p := range t.peers {
+		if p.ranked > 0 && !now.Before(p.droppedUntil) && p.slotsPerSecond > fastest {
+			fastest = p.slotsPerSecond
+		}
+	}
+	return fastest
+}
+
+func (t *throughputTracker) updateMetrics(pid peer.ID, p *peerThroughput) {
+	peerSyncRate.WithLabelValues(pid.String()).Set(p.blocksPerSecond)
+	peerRequestLatency.WithLabelValues(pid.String()).Set(p.latency.Seconds())
+	peerBandwidth.WithLabelValues(pid.String()).Set(p.bandwidth)
+	peerBatchSize.WithLabelValues(pid.String()).Set(float64(p.batchSize))
+	peerPipelineDepth.WithLabelValues(pid.String()).Set(float64(p.depth))
+}
+
+func deleteMetrics(pid peer.ID) {
+	peerSyncRate.DeleteLabelValues(pid.String())
+	peerRequestLatency.DeleteLabelValues(pid.String())
+	peerBandwidth.DeleteLabelValues(pid.String())
+	peerBatchSize.DeleteLabelValues(pid.String())
+	peerPipelineDepth.DeleteLabelValues(pid.String())
+}
+
+// batchSize returns the number of slots to request at once from a peer.
+func (t *throughputTracker) batchSize(pid peer.ID) uint64 {
+	t.Lock()
+	defer t.Unlock()
+	return t.peer(pid).batchSize
+}
+
+// usable filters out the dropped peers.
+func (t *throughputTracker) usable(peers []peer.ID) []peer.ID {
+	t.Lock()
+	defer t.Unlock()
+	now := t.now()
+	usable := make([]peer.ID, 0, len(peers))
+	for _, pid := range peers {
+		if p, ok := t.peers[pid]; ok && now.Before(p.droppedUntil) {
+			continue
+		}
+		usable = append(usable, pid)
+	}
+	return usable
+}
+
+// score returns the throughput of a peer relative to the fastest peer, if the peer served blocks.
+func (t *throughputTracker) score(pid peer.ID) (float64, bool) {
+	t.Lock()
+	defer t.Unlock()
+	p, ok := t.peers[pid]
+	if !ok || p.ranked == 0 {
+		return 0, false
+	}
+	fastest := t.fastest(t.now())
+	if fastest == 0 {
+		return 0, false
+	}
+	score := p.slotsPerSecond / fastest
+	if score > 1 {
+		score = 1
+	}
+	return score, true
+}
+
+// pipelineDepth returns the number of concurrent requests the active peers can sustain, which is the number of
+// forward steps the queue should load, bounded to [lookaheadSteps, maxLookaheadSteps].
+func (t *throughputTracker) pipelineDepth() int {
+	t.Lock()
+	defer t.Unlock()
+	now := t.now()
+	depth := 0
+	for _, p := range t.peers {
+		if p.active(now) {
+			depth += p.depth
+		}
+	}
+	if depth < lookaheadSteps {
+		return lookaheadSteps
+	}
+	if depth > maxLookaheadSteps {
+		return maxLookaheadSteps
+	}
+	return depth
+}
+
+// requestSize returns the number of slots the queue asks for in a single fetch: the mean batch size of the active
+// peers, so that a fetch is usually served by a single request. Until peers are measured, it is the max batch.
+func (t *throughputTracker) requestSize() uint64 {
+	t.Lock()
+	defer t.Unlock()
+	now := t.now()
+	var total uint64
+	var n uint64
+	for _, p := range t.peers {
+		if p.active(now) {
+			total += p.batchSize
+			n++
+		}
+	}
+	if n == 0 {
+		return t.maxBatch
+	}
+	return t.clampBatch(total / n)
+}
+
+// active tells if the peer was measured and served a request recently, without being dropped since.
+func (p *peerThroughput) active(now time.Time) bool {
+	return p.samples > 0 && now.Sub(p.updated) < peerActivePeriod && !now.Before(p.droppedUntil)
+}
+
+// prune removes the measurements of the peers which were not sent a request within the given age.
+func (t *throughputTracker) prune(age time.Duration) {
+	t.Lock()
+	defer t.Unlock()
+	now := t.now()
+	for pid, p := range t.peers {
+		if p.inflight == 0 && p.requests == 0 && now.Sub(p.updated) >= age {
+			delete(t.peers, pid)
+			deleteMetrics(pid)
+		}
+	}
+}
+
+// reset removes the measurements of all peers, once sync is over.
+func (t *throughputTracker) reset() {
+	t.Lock()
+	defer t.Unlock()
+	for pid := range t.peers {
+		delete(t.peers, pid)
+		deleteMetrics(pid)
+	}
+}
@@ -0,0 +1,317 @@
+package initialsync
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	libp2pcore "github.com/libp2p/go-libp2p/core"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	p2pm "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
+	p2pt "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
+	beaconsync "github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	leakybucket "github.com/prysmaticlabs/prysm/v5/container/leaky-bucket"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+// newTestThroughputTracker returns a tracker with a clock advanced by hand.
+func newTestThroughputTracker(maxBatch uint64) (*throughputTracker, *time.Time) {
+	now := time.Now()
+	t := newThroughputTracker(maxBatch)
+	t.now = func() time.Time { return now }
+	return t, &now
+}
+
+// serve records a successful request of the given number of slots and blocks, whose first block arrives after the
+// given latency, and the rest within the given transfer duration.
+func serve(tr *throughputTracker, now *time.Time, pid peer.ID, slots uint64, blocks int, latency, transfer time.Duration) {
+	rs := tr.started(pid)
+	*now = now.Add(latency)
+	first := *now
+	*now = now.Add(transfer)
+	tr.finished(pid, rs, first, slots, blocks, nil)
+}
+
+func TestThroughputTracker_BatchSize(t *testing.T) {
+	tr, now := newTestThroughputTracker(64)
+	fast, slow := peer.ID("fast"), peer.ID("slow")
+
+	// Unknown peers are sent full batches.
+	assert.Equal(t, uint64(64), tr.batchSize(fast))
+
+	serve(tr, now, fast, 64, 64, 100*time.Millisecond, 400*time.Millisecond)
+	assert.Equal(t, uint64(64), tr.batchSize(fast), "Batch size must not exceed the max batch")
+
+	// 64 slots transferred in 8s is 8 slots/s, so 16 slots fit in the target transfer duration.
+	serve(tr, now, slow, 64, 60, 0, 8*time.Second)
+	assert.Equal(t, uint64(16), tr.batchSize(slow))
+	for i := 0; i < 5; i++ {
+		serve(tr, now, slow, 16, 16, 0, 16*time.Second)
+	}
+	assert.Equal(t, minBlocksPerRequest, int(tr.batchSize(slow)), "Batch size must not go below the min batch")
+
+	// A peer slow to respond, but transferring 32 slots/s, keeps being sent full batches.
+	tr, now = newTestThroughputTracker(64)
+	far := peer.ID("far")
+	for i := 0; i < 5; i++ {
+		serve(tr, now, far, 64, 64, 10*time.Second, 2*time.Second)
+		assert.Equal(t, uint64(64), tr.batchSize(far), "Latency must not shrink the batches")
+	}
+	assert.Equal(t, 10*time.Second, tr.peers[far].latency)
+	assert.Equal(t, 32.0, tr.peers[far].bandwidth)
+
+	// Empty responses leave the bandwidth as is.
+	serve(tr, now, far, 64, 0, 0, 5*time.Second)
+	assert.Equal(t, 32.0, tr.peers[far].bandwidth)
+
+	// The max batch caps the min batch.
+	small, _ := newTestThroughputTracker(4)
+	assert.Equal(t, uint64(4), small.minBatch())
+}
+
+func TestThroughputTracker_Latency(t *testing.T) {
+	tr, now := newTestThroughputTracker(64)
+	pid := peer.ID("a")
+	serve(tr, now, pid, 64, 64, time.Second, time.Second)
+	assert.Equal(t, time.Second, tr.peers[pid].latency)
+
+	// A request sent while another one is in flight may wait for it, which is not latency.
+	rs := tr.started(pid)
+	queued := tr.started(pid)
+	assert.Equal(t, false, rs.queued)
+	assert.Equal(t, true, queued.queued)
+	*now = now.Add(100 * time.Millisecond)
+	tr.finished(pid, rs, *now, 64, 64, nil)
+	*now = now.Add(3 * time.Second)
+	tr.finished(pid, queued, *now, 64, 64, nil)
+	assert.Equal(t, 730*time.Millisecond, tr.peers[pid].latency.Round(time.Millisecond))
+	assert.Equal(t, 0, tr.peers[pid].requests)
+
+	// A response without blocks is all latency.
+	serve(tr, now, pid, 64, 0, 0, 1630*time.Millisecond)
+	assert.Equal(t, time.Second, tr.peers[pid].latency.Round(time.Millisecond))
+}
+
+func TestThroughputTracker_PipelineDepth(t *testing.T) {
+	tr, now := newTestThroughputTracker(64)
+	pid := peer.ID("a")
+
+	require.NoError(t, tr.reserve(pid))
+	require.ErrorIs(t, tr.reserve(pid), errPeerBusy, "Unknown peers are sent one fetch at a time")
+	tr.release(pid)
+	require.NoError(t, tr.reserve(pid))
+	tr.release(pid)
+
+	// Transferring a batch takes 1s, and the peer takes 3s to respond, so 3 more requests wait while one transfers.
+	for i := 0; i < 2*maxPeerPipelineDepth; i++ {
+		serve(tr, now, pid, 64, 64, 3*time.Second, time.Second)
+	}
+	assert.Equal(t, maxPeerPipelineDepth, tr.peers[pid].depth)
+	for i := 0; i < maxPeerPipelineDepth; i++ {
+		require.NoError(t, tr.reserve(pid))
+	}
+	require.ErrorIs(t, tr.reserve(pid), errPeerBusy)
+	for i := 0; i < maxPeerPipelineDepth; i++ {
+		tr.release(pid)
+	}
+
+	// Quick answers shallow the pipeline.
+	for i := 0; i < 10; i++ {
+		serve(tr, now, pid, 64, 64, 0, time.Second)
+	}
+	assert.Equal(t, 1, tr.peers[pid].depth)
+}
+
+func TestThroughputTracker_Releases(t *testing.T) {
+	tr, _ := newTestThroughputTracker(64)
+	pid := peer.ID("a")
+	require.NoError(t, tr.reserve(pid))
+	released := tr.releases()
+	select {
+	case <-released:
+		t.Fatal("No fetch was released")
+	default:
+	}
+	tr.release(pid)
+	select {
+	case <-released:
+	default:
+		t.Fatal("The release was not signalled")
+	}
+	assert.Equal(t, 0, tr.peers[pid].inflight)
+	assert.NotEqual(t, released, tr.releases())
+}
+
+func TestThroughputTracker_QueuePipelineDepth(t *testing.T) {
+	tr, now := newTestThroughputTracker(64)
+	assert.Equal(t, lookaheadSteps, tr.pipelineDepth())
+
+	pids := []peer.ID{"a", "b", "c", "d", "e", "f"}
+	for i := 0; i < 2; i++ {
+		for _, pid := range pids {
+			serve(tr, now, pid, 64, 64, 2*time.Second, time.Second)
+		}
+	}
+	assert.Equal(t, 3, tr.peers[pids[0]].depth)
+	assert.Equal(t, maxLookaheadSteps, tr.pipelineDepth())
+
+	// Peers which were not sent requests for a while do not count.
+	*now = now.Add(peerActivePeriod)
+	serve(tr, now, "a", 64, 64, 2*time.Second, time.Second)
+	assert.Equal(t, lookaheadSteps, tr.pipelineDepth())
+}
+
+func TestThroughputTracker_RequestSize(t *testing.T) {
+	tr, now := newTestThroughputTracker(64)
+	assert.Equal(t, uint64(64), tr.requestSize(), "Unmeasured peers are asked for full batches")
+
+	serve(tr, now, "slow", 64, 64, time.Second, 8*time.Second)
+	serve(tr, now, "fast", 64, 64, time.Second, 500*time.Millisecond)
+	assert.Equal(t, uint64(40), tr.requestSize())
+
+	*now = now.Add(peerActivePeriod)
+	assert.Equal(t, uint64(64), tr.requestSize())
+}
+
+func TestThroughputTracker_DropFailingPeer(t *testing.T) {
+	tr, now := newTestThroughputTracker(64)
+	pid, other := peer.ID("a"), peer.ID("b")
+	serve(tr, now, pid, 64, 64, 100*time.Millisecond, 400*time.Millisecond)
+
+	tr.finished(pid, tr.started(pid), time.Time{}, 64, 0, errors.New("timeout"))
+	assert.Equal(t, uint64(32), tr.batchSize(pid))
+	assert.Equal(t, 1, tr.peers[pid].depth)
+	assert.DeepEqual(t, []peer.ID{pid, other}, tr.usable([]peer.ID{pid, other}))
+
+	tr.failed(pid)
+	assert.DeepEqual(t, []peer.ID{other}, tr.usable([]peer.ID{pid, other}))
+	assert.Equal(t, uint64(minBlocksPerRequest), tr.batchSize(pid))
+	_, ok := tr.score(pid)
+	assert.Equal(t, false, ok, "Dropped peers must be measured again")
+
+	*now = now.Add(peerDropPeriod)
+	assert.DeepEqual(t, []peer.ID{pid, other}, tr.usable([]peer.ID{pid, other}))
+}
+
+func TestThroughputTracker_DropSlowPeer(t *testing.T) {
+	tr, now := newTestThroughputTracker(64)
+	fast, slow := peer.ID("fast"), peer.ID("slow")
+	serve(tr, now, fast, 64, 64, 0, 500*time.Millisecond)
+
+	for i := 0; i < slowPeerMinSamples-1; i++ {
+		serve(tr, now, slow, 8, 8, time.Second, time.Second)
+	}
+	score, ok := tr.score(slow)
+	require.Equal(t, true, ok)
+	assert.Equal(t, 0.03125, score)
+	score, ok = tr.score(fast)
+	require.Equal(t, true, ok)
+	assert.Equal(t, 1.0, score)
+	assert.DeepEqual(t, []peer.ID{fast, slow}, tr.usable([]peer.ID{fast, slow}))
+
+	serve(tr, now, slow, 8, 8, time.Second, time.Second)
+	assert.DeepEqual(t, []peer.ID{fast}, tr.usable([]peer.ID{fast, slow}))
+}
+
+func TestThroughputTracker_EmptyResponses(t *testing.T) {
+	tr, now := newTestThroughputTracker(64)
+	empty, honest := peer.ID("empty"), peer.ID("honest")
+
+	// A peer answering every request at once without blocks is not ranked, and does not get serving peers dropped.
+	for i := 0; i < 5; i++ {
+		serve(tr, now, empty, 64, 0, time.Millisecond, 0)
+	}
+	_, ok := tr.score(empty)
+	assert.Equal(t, false, ok)
+	for i := 0; i < 5; i++ {
+		serve(tr, now, honest, 64, 64, 500*time.Millisecond, 2*time.Second)
+	}
+	score, ok := tr.score(honest)
+	require.Equal(t, true, ok)
+	assert.Equal(t, 1.0, score)
+	assert.DeepEqual(t, []peer.ID{empty, honest}, tr.usable([]peer.ID{empty, honest}))
+
+	// Empty responses of a ranked peer leave its rank as is.
+	serve(tr, now, honest, 64, 0, time.Millisecond, 0)
+	assert.Equal(t, 25.6, tr.peers[honest].slotsPerSecond)
+	assert.Equal(t, 5, tr.peers[honest].ranked)
+}
+
+func TestThroughputTracker_Prune(t *testing.T) {
+	tr, now := newTestThroughputTracker(64)
+	serve(tr, now, "a", 64, 64, 0, time.Second)
+	require.NoError(t, tr.reserve("b"))
+	*now = now.Add(peerThroughputMaxAge)
+	serve(tr, now, "c", 64, 64, 0, time.Second)
+
+	tr.prune(peerThroughputMaxAge)
+	_, ok := tr.peers["a"]
+	assert.Equal(t, false, ok)
+	_, ok = tr.peers["b"]
+	assert.Equal(t, true, ok, "Peers with fetches in flight must be kept")
+	_, ok = tr.peers["c"]
+	assert.Equal(t, true, ok)
+
+	tr.reset()
+	assert.Equal(t, 0, len(tr.peers))
+}
+
+func TestBlocksFetcher_requestBlocksInBatches(t *testing.T) {
+	p1 := p2pt.NewTestP2P(t)
+	p2 := p2pt.NewTestP2P(t)
+	p1.Connect(p2)
+
+	var mu sync.Mutex
+	var counts []uint64
+	protocol := libp2pcore.ProtocolID(p2pm.RPCBlocksByRangeTopicV1 + p1.Encoding().ProtocolSuffix())
+	p2.BHost.SetStreamHandler(protocol, func(stream network.Stream) {
+		req := &ethpb.BeaconBlocksByRangeRequest{}
+		assert.NoError(t, p2.Encoding().DecodeWithMaxLength(stream, req))
+		mu.Lock()
+		counts = append(counts, req.Count)
+		mu.Unlock()
+		for i := req.StartSlot; i < req.StartSlot.Add(req.Count); i++ {
+			blk := util.NewBeaconBlock()
+			blk.Block.Slot = i
+			wsb, err := blocks.NewSignedBeaconBlock(blk)
+			require.NoError(t, err)
+			assert.NoError(t, beaconsync.WriteBlockChunk(stream, startup.NewClock(time.Now(), [32]byte{}), p2.Encoding(), wsb))
+		}
+		assert.NoError(t, stream.Close())
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fetcher := newBlocksFetcher(ctx, &blocksFetcherConfig{p2p: p1, chain: &mock.ChainService{Genesis: time.Now(), ValidatorsRoot: [32]byte{}}})
+	fetcher.rateLimiter = leakybucket.NewCollector(0.000001, 640, 1*time.Second, false)
+	// The peer was measured at 8 slots/s.
+	fetcher.throughput.peers[p2.PeerID()] = &peerThroughput{depth: 1, batchSize: 16, bandwidth: 8, slotsPerSecond: 8, samples: 1}
+
+	blks, err := fetcher.requestBlocksInBatches(ctx, 100, 40, p2.PeerID())
+	require.NoError(t, err)
+	require.Equal(t, 40, len(blks))
+	for i, blk := range blks {
+		assert.Equal(t, primitives.Slot(100+i), blk.Block().Slot())
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	// The first batch is sized to the measured bandwidth, the next ones to the bandwidth measured since.
+	require.Equal(t, true, len(counts) > 1)
+	assert.Equal(t, uint64(16), counts[0])
+	var total uint64
+	for _, c := range counts {
+		total += c
+	}
+	assert.Equal(t, uint64(40), total)
+	assert.Equal(t, 1+len(counts), fetcher.throughput.peers[p2.PeerID()].samples)
+}
@@ -278,12 +278,16 @@ func (f *blocksFetcher) findForkWithPeer(ctx context.Context, pid peer.ID, slot
 		if err != nil {
 			return nil, errors.Wrap(err, "invalid blocks received in findForkWithPeer")
 		}
-		// We need to fetch the blobs for the given alt-chain if any exist, so that we can try to verify and import
-		// the blocks.
+		// We need to fetch the blobs or data columns for the given alt-chain if any exist, so that we can try to
+		// verify and import the blocks. The columns are requested from the connected peers custodying them.
 		bwb, err := f.fetchBlobsFromPeer(ctx, altBlocks, pid, []peer.ID{pid})
 		if err != nil {
 			return nil, errors.Wrap(err, "unable to retrieve blobs for blocks found in findForkWithPeer")
 		}
+		bwb, err = f.fetchDataColumnsFromPeers(ctx, bwb, append([]peer.ID{pid}, f.p2p.Peers().Connected()...))
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to retrieve data columns for blocks found in findForkWithPeer")
+		}
 		// The caller will use the BlocksWith VerifiedBlobs in bwb as the starting point for
 		// round-robin syncing the alternate chain.
 		return &forkData{peer: pid, bwb: bwb}, nil
@@ -306,6 +310,10 @@ func (f *blocksFetcher) findAncestor(ctx context.Context, pid peer.ID, b interfa
 			if err != nil {
 				return nil, errors.Wrap(err, "unable to retrieve blobs for blocks found in findAncestor")
 			}
+			bwb, err = f.fetchDataColumnsFromPeers(ctx, bwb, append([]peer.ID{pid}, f.p2p.Peers().Connected()...))
+			if err != nil {
+				return nil, errors.Wrap(err, "unable to retrieve data columns for blocks found in findAncestor")
+			}
 			return &forkData{
 				peer: pid,
 				bwb:  bwb,
@@ -71,6 +71,9 @@ type blocksQueueConfig struct {
 	db                  db.ReadOnlyDatabase
 	mode                syncMode
 	bs                  filesystem.BlobStorageSummarizer
+	archive             bool
+	dcs                 *filesystem.DataColumnStorage
+	custody             map[uint64]bool
 }
 
 // blocksQueue is a priority queue that serves as a intermediary between block fetchers (producers)
@@ -107,12 +110,15 @@ func newBlocksQueue(ctx context.Context, cfg *blocksQueueConfig) *blocksQueue {
 			log.Warn("rpc fetcher starting without blob availability cache, duplicate blobs may be requested.")
 		}
 		blocksFetcher = newBlocksFetcher(ctx, &blocksFetcherConfig{
-			ctxMap: cfg.ctxMap,
-			chain:  cfg.chain,
-			p2p:    cfg.p2p,
-			db:     cfg.db,
-			clock:  cfg.clock,
-			bs:     cfg.bs,
+			ctxMap:  cfg.ctxMap,
+			chain:   cfg.chain,
+			p2p:     cfg.p2p,
+			db:      cfg.db,
+			clock:   cfg.clock,
+			bs:      cfg.bs,
+			archive: cfg.archive,
+			dcs:     cfg.dcs,
+			custody: cfg.custody,
 		})
 	}
 	highestExpectedSlot := cfg.highestExpectedSlot
@@ -190,9 +196,9 @@ func (q *blocksQueue) loop() {
 	if startSlot > startBackSlots {
 		startSlot -= startBackSlots
 	}
-	blocksPerRequest := q.blocksFetcher.blocksPerPeriod
+	blocksPerRequest := q.blocksFetcher.throughput.requestSize()
 	for i := startSlot; i < startSlot.Add(blocksPerRequest*lookaheadSteps); i += primitives.Slot(blocksPerRequest) {
-		q.smm.addStateMachine(i)
+		q.smm.addStateMachine(i, blocksPerRequest)
 	}
 
 	ticker := time.NewTicker(pollingInterval)
@@ -235,17 +241,22 @@ func (q *blocksQueue) loop() {
 					}
 				}
 				// Do garbage collection, and advance sliding window forward.
-				if q.chain.HeadSlot() >= fsm.start.Add(blocksPerRequest-1) {
+				if q.chain.HeadSlot() >= fsm.start.Add(fsm.count-1) {
 					highestStartSlot, err := q.smm.highestStartSlot()
 					if err != nil {
 						log.WithError(err).Debug("Cannot obtain highest epoch state number")
 						continue
 					}
+					nextSlot := highestStartSlot.Add(q.smm.machines[highestStartSlot].count)
 					if err := q.smm.removeStateMachine(fsm.start); err != nil {
 						log.WithError(err).Debug("Can not remove state machine")
 					}
-					if len(q.smm.machines) < lookaheadSteps {
-						q.smm.addStateMachine(highestStartSlot.Add(blocksPerRequest))
+					// Load as many forward steps as the peers can currently serve concurrently, each sized for
+					// the peers to serve it in a single request.
+					for len(q.smm.machines) < q.blocksFetcher.throughput.pipelineDepth() {
+						count := q.blocksFetcher.throughput.requestSize()
+						q.smm.addStateMachine(nextSlot, count)
+						nextSlot = nextSlot.Add(count)
 					}
 				}
 			}
@@ -305,8 +316,7 @@ func (q *blocksQueue) onScheduleEvent(ctx context.Context) eventHandlerFn {
 			m.setState(stateSkipped)
 			return m.state, errSlotIsTooHigh
 		}
-		blocksPerRequest := q.blocksFetcher.blocksPerPeriod
-		if err := q.blocksFetcher.scheduleRequest(ctx, m.start, blocksPerRequest); err != nil {
+		if err := q.blocksFetcher.scheduleRequest(ctx, m.start, m.count); err != nil {
 			return m.state, err
 		}
 		return stateScheduled, nil
@@ -487,7 +487,7 @@ func TestBlocksQueue_onDataReceivedEvent(t *testing.T) {
 		})
 
 		// Mark previous machine as skipped - to test effect of re-requesting.
-		queue.smm.addStateMachine(250)
+		queue.smm.addStateMachine(250, queue.blocksFetcher.blocksPerPeriod)
 		queue.smm.machines[250].state = stateSkipped
 		assert.Equal(t, stateSkipped, queue.smm.machines[250].state)
 
@@ -632,8 +632,8 @@ func TestBlocksQueue_onReadyToSendEvent(t *testing.T) {
 		})
 		wsb, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlock())
 		require.NoError(t, err)
-		queue.smm.addStateMachine(256)
-		queue.smm.addStateMachine(320)
+		queue.smm.addStateMachine(256, queue.blocksFetcher.blocksPerPeriod)
+		queue.smm.addStateMachine(320, queue.blocksFetcher.blocksPerPeriod)
 		queue.smm.machines[256].state = stateDataParsed
 		queue.smm.machines[256].pid = pidDataParsed
 		rwsb, err := blocks.NewROBlock(wsb)
@@ -661,13 +661,13 @@ func TestBlocksQueue_onReadyToSendEvent(t *testing.T) {
 		})
 		wsb, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlock())
 		require.NoError(t, err)
-		queue.smm.addStateMachine(128)
+		queue.smm.addStateMachine(128, queue.blocksFetcher.blocksPerPeriod)
 		queue.smm.machines[128].state = stateNew
-		queue.smm.addStateMachine(192)
+		queue.smm.addStateMachine(192, queue.blocksFetcher.blocksPerPeriod)
 		queue.smm.machines[192].state = stateScheduled
-		queue.smm.addStateMachine(256)
+		queue.smm.addStateMachine(256, queue.blocksFetcher.blocksPerPeriod)
 		queue.smm.machines[256].state = stateDataParsed
-		queue.smm.addStateMachine(320)
+		queue.smm.addStateMachine(320, queue.blocksFetcher.blocksPerPeriod)
 		queue.smm.machines[320].state = stateDataParsed
 		queue.smm.machines[320].pid = pidDataParsed
 		rwsb, err := blocks.NewROBlock(wsb)
@@ -696,9 +696,9 @@ func TestBlocksQueue_onReadyToSendEvent(t *testing.T) {
 		})
 		wsb, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlock())
 		require.NoError(t, err)
-		queue.smm.addStateMachine(256)
+		queue.smm.addStateMachine(256, queue.blocksFetcher.blocksPerPeriod)
 		queue.smm.machines[256].state = stateSkipped
-		queue.smm.addStateMachine(320)
+		queue.smm.addStateMachine(320, queue.blocksFetcher.blocksPerPeriod)
 		queue.smm.machines[320].state = stateDataParsed
 		queue.smm.machines[320].pid = pidDataParsed
 		rwsb, err := blocks.NewROBlock(wsb)
@@ -768,11 +768,11 @@ func TestBlocksQueue_onProcessSkippedEvent(t *testing.T) {
 			highestExpectedSlot: primitives.Slot(blockBatchLimit),
 		})
 
-		queue.smm.addStateMachine(256)
+		queue.smm.addStateMachine(256, queue.blocksFetcher.blocksPerPeriod)
 		// Machine is not skipped for too long. Do not mark as new just yet.
 		queue.smm.machines[256].updated = prysmTime.Now().Add(-1 * (skippedMachineTimeout / 2))
 		queue.smm.machines[256].state = stateSkipped
-		queue.smm.addStateMachine(320)
+		queue.smm.addStateMachine(320, queue.blocksFetcher.blocksPerPeriod)
 		queue.smm.machines[320].state = stateScheduled
 		handlerFn := queue.onProcessSkippedEvent(ctx)
 		updatedState, err := handlerFn(queue.smm.machines[256], nil)
@@ -787,11 +787,11 @@ func TestBlocksQueue_onProcessSkippedEvent(t *testing.T) {
 			highestExpectedSlot: primitives.Slot(blockBatchLimit),
 		})
 
-		queue.smm.addStateMachine(256)
+		queue.smm.addStateMachine(256, queue.blocksFetcher.blocksPerPeriod)
 		// Machine is skipped for too long. Reset.
 		queue.smm.machines[256].updated = prysmTime.Now().Add(-1 * skippedMachineTimeout)
 		queue.smm.machines[256].state = stateSkipped
-		queue.smm.addStateMachine(320)
+		queue.smm.addStateMachine(320, queue.blocksFetcher.blocksPerPeriod)
 		queue.smm.machines[320].state = stateScheduled
 		handlerFn := queue.onProcessSkippedEvent(ctx)
 		updatedState, err := handlerFn(queue.smm.machines[256], nil)
@@ -806,11 +806,11 @@ func TestBlocksQueue_onProcessSkippedEvent(t *testing.T) {
 			highestExpectedSlot: primitives.Slot(blockBatchLimit),
 		})
 
-		queue.smm.addStateMachine(192)
+		queue.smm.addStateMachine(192, queue.blocksFetcher.blocksPerPeriod)
 		queue.smm.machines[192].state = stateSkipped
-		queue.smm.addStateMachine(256)
+		queue.smm.addStateMachine(256, queue.blocksFetcher.blocksPerPeriod)
 		queue.smm.machines[256].state = stateScheduled
-		queue.smm.addStateMachine(320)
+		queue.smm.addStateMachine(320, queue.blocksFetcher.blocksPerPeriod)
 		queue.smm.machines[320].state = stateSkipped
 		handlerFn := queue.onProcessSkippedEvent(ctx)
 		updatedState, err := handlerFn(queue.smm.machines[320], nil)
@@ -825,11 +825,11 @@ func TestBlocksQueue_onProcessSkippedEvent(t *testing.T) {
 			highestExpectedSlot: primitives.Slot(blockBatchLimit),
 		})
 
-		queue.smm.addStateMachine(192)
+		queue.smm.addStateMachine(192, queue.blocksFetcher.blocksPerPeriod)
 		queue.smm.machines[192].state = stateSkipped
-		queue.smm.addStateMachine(256)
+		queue.smm.addStateMachine(256, queue.blocksFetcher.blocksPerPeriod)
 		queue.smm.machines[256].state = stateSkipped
-		queue.smm.addStateMachine(320)
+		queue.smm.addStateMachine(320, queue.blocksFetcher.blocksPerPeriod)
 		queue.smm.machines[320].state = stateSkipped
 		// Mode 1: Stop on finalized epoch.
 		handlerFn := queue.onProcessSkippedEvent(ctx)
@@ -862,7 +862,7 @@ func TestBlocksQueue_onProcessSkippedEvent(t *testing.T) {
 		startSlot := queue.chain.HeadSlot()
 		blocksPerRequest := queue.blocksFetcher.blocksPerPeriod
 		for i := startSlot; i < startSlot.Add(blocksPerRequest*lookaheadSteps); i += primitives.Slot(blocksPerRequest) {
-			queue.smm.addStateMachine(i).setState(stateSkipped)
+			queue.smm.addStateMachine(i, blocksPerRequest).setState(stateSkipped)
 		}
 
 		handlerFn := queue.onProcessSkippedEvent(ctx)
@@ -891,7 +891,7 @@ func TestBlocksQueue_onProcessSkippedEvent(t *testing.T) {
 		blocksPerRequest := queue.blocksFetcher.blocksPerPeriod
 		var machineSlots []primitives.Slot
 		for i := startSlot; i < startSlot.Add(blocksPerRequest*lookaheadSteps); i += primitives.Slot(blocksPerRequest) {
-			queue.smm.addStateMachine(i).setState(stateSkipped)
+			queue.smm.addStateMachine(i, blocksPerRequest).setState(stateSkipped)
 			machineSlots = append(machineSlots, i)
 		}
 		for _, slot := range machineSlots {
@@ -942,7 +942,7 @@ func TestBlocksQueue_onProcessSkippedEvent(t *testing.T) {
 		blocksPerRequest := queue.blocksFetcher.blocksPerPeriod
 		var machineSlots []primitives.Slot
 		for i := startSlot; i < startSlot.Add(blocksPerRequest*lookaheadSteps); i += primitives.Slot(blocksPerRequest) {
-			queue.smm.addStateMachine(i).setState(stateSkipped)
+			queue.smm.addStateMachine(i, blocksPerRequest).setState(stateSkipped)
 			machineSlots = append(machineSlots, i)
 		}
 		for _, slot := range machineSlots {
@@ -1105,7 +1105,7 @@ func TestBlocksQueue_stuckInUnfavourableFork(t *testing.T) {
 		blocksPerRequest := queue.blocksFetcher.blocksPerPeriod
 		machineSlots := make([]primitives.Slot, 0)
 		for i := startSlot; i < startSlot.Add(blocksPerRequest*lookaheadSteps); i += primitives.Slot(blocksPerRequest) {
-			queue.smm.addStateMachine(i).setState(stateSkipped)
+			queue.smm.addStateMachine(i, blocksPerRequest).setState(stateSkipped)
 			machineSlots = append(machineSlots, i)
 		}
 		for _, slot := range machineSlots {
@@ -1124,7 +1124,7 @@ func TestBlocksQueue_stuckInUnfavourableFork(t *testing.T) {
 		hook.Reset()
 
 		// The last machine got removed (it was for non-skipped slot, which fails).
-		queue.smm.addStateMachine(machineSlots[len(machineSlots)-1])
+		queue.smm.addStateMachine(machineSlots[len(machineSlots)-1], blocksPerRequest)
 		assert.Equal(t, lookaheadSteps, len(queue.smm.machines))
 		for _, slot := range machineSlots {
 			fsm, ok := queue.smm.findStateMachine(slot)
@@ -1155,7 +1155,7 @@ func TestBlocksQueue_stuckInUnfavourableFork(t *testing.T) {
 		blocksPerRequest := queue.blocksFetcher.blocksPerPeriod
 		machineSlots := make([]primitives.Slot, 0)
 		for i := startSlot; i < startSlot.Add(blocksPerRequest*lookaheadSteps); i += primitives.Slot(blocksPerRequest) {
-			queue.smm.addStateMachine(i).setState(stateSkipped)
+			queue.smm.addStateMachine(i, blocksPerRequest).setState(stateSkipped)
 			machineSlots = append(machineSlots, i)
 		}
 		for _, slot := range machineSlots {
@@ -1174,7 +1174,7 @@ func TestBlocksQueue_stuckInUnfavourableFork(t *testing.T) {
 		hook.Reset()
 
 		// The last machine got removed (it was for non-skipped slot, which fails).
-		queue.smm.addStateMachine(machineSlots[len(machineSlots)-1])
+		queue.smm.addStateMachine(machineSlots[len(machineSlots)-1], blocksPerRequest)
 		assert.Equal(t, lookaheadSteps, len(queue.smm.machines))
 		for _, slot := range machineSlots {
 			fsm, ok := queue.smm.findStateMachine(slot)
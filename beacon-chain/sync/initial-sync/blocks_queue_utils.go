@@ -19,11 +19,11 @@ func (q *blocksQueue) resetFromFork(fork *forkData) error {
 	}
 	firstBlock := fork.bwb[0].Block.Block()
 
-	blocksPerRequest := q.blocksFetcher.blocksPerPeriod
+	blocksPerRequest := q.blocksFetcher.throughput.requestSize()
 	if err := q.smm.removeAllStateMachines(); err != nil {
 		return err
 	}
-	fsm := q.smm.addStateMachine(firstBlock.Slot())
+	fsm := q.smm.addStateMachine(firstBlock.Slot(), uint64(len(fork.bwb)))
 	fsm.pid = fork.peer
 	fsm.bwb = fork.bwb
 	fsm.state = stateDataParsed
@@ -31,7 +31,7 @@ func (q *blocksQueue) resetFromFork(fork *forkData) error {
 	// The rest of machines are in skipped state.
 	startSlot := firstBlock.Slot().Add(uint64(len(fork.bwb)))
 	for i := startSlot; i < startSlot.Add(blocksPerRequest*(lookaheadSteps-1)); i += primitives.Slot(blocksPerRequest) {
-		fsm := q.smm.addStateMachine(i)
+		fsm := q.smm.addStateMachine(i, blocksPerRequest)
 		fsm.state = stateSkipped
 	}
 	return nil
@@ -42,12 +42,12 @@ func (q *blocksQueue) resetFromFork(fork *forkData) error {
 // long periods with skipped slots).
 func (q *blocksQueue) resetFromSlot(ctx context.Context, startSlot primitives.Slot) error {
 	// Shift start position of all the machines except for the last one.
-	blocksPerRequest := q.blocksFetcher.blocksPerPeriod
+	blocksPerRequest := q.blocksFetcher.throughput.requestSize()
 	if err := q.smm.removeAllStateMachines(); err != nil {
 		return err
 	}
 	for i := startSlot; i < startSlot.Add(blocksPerRequest*(lookaheadSteps-1)); i += primitives.Slot(blocksPerRequest) {
-		q.smm.addStateMachine(i)
+		q.smm.addStateMachine(i, blocksPerRequest)
 	}
 
 	// Replace the last (currently activated) state machine to start with best known non-skipped slot.
@@ -67,6 +67,6 @@ func (q *blocksQueue) resetFromSlot(ctx context.Context, startSlot primitives.Sl
 	if nonSkippedSlot > q.highestExpectedSlot {
 		nonSkippedSlot = startSlot.Add(blocksPerRequest * (lookaheadSteps - 1))
 	}
-	q.smm.addStateMachine(nonSkippedSlot)
+	q.smm.addStateMachine(nonSkippedSlot, blocksPerRequest)
 	return nil
 }
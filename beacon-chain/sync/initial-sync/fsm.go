@@ -44,6 +44,7 @@ type stateMachineManager struct {
 type stateMachine struct {
 	smm     *stateMachineManager
 	start   primitives.Slot
+	count   uint64
 	state   stateID
 	pid     peer.ID
 	bwb     []blocks.BlockWithROBlobs
@@ -72,11 +73,12 @@ func (smm *stateMachineManager) addEventHandler(event eventID, state stateID, fn
 	}
 }
 
-// addStateMachine allocates memory for new FSM.
-func (smm *stateMachineManager) addStateMachine(startSlot primitives.Slot) *stateMachine {
+// addStateMachine allocates memory for new FSM, requesting count slots from the start slot.
+func (smm *stateMachineManager) addStateMachine(startSlot primitives.Slot, count uint64) *stateMachine {
 	smm.machines[startSlot] = &stateMachine{
 		smm:     smm,
 		start:   startSlot,
+		count:   count,
 		state:   stateNew,
 		bwb:     []blocks.BlockWithROBlobs{},
 		updated: prysmTime.Now(),
@@ -23,7 +23,7 @@ func BenchmarkStateMachine_trigger(b *testing.B) {
 	sm.addEventHandler(eventTick, stateDataParsed, handlerFn)
 	sm.addEventHandler(eventTick, stateSkipped, handlerFn)
 	sm.addEventHandler(eventTick, stateSent, handlerFn)
-	sm.addStateMachine(64)
+	sm.addStateMachine(64, 64)
 
 	b.ReportAllocs()
 	b.ResetTimer()
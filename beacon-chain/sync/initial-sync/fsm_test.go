@@ -175,7 +175,7 @@ func TestStateMachine_trigger(t *testing.T) {
 				}
 			}
 			for _, epoch := range tt.epochs {
-				smm.addStateMachine(primitives.Slot(epoch * 32))
+				smm.addStateMachine(primitives.Slot(epoch*32), 64)
 			}
 			state := smm.machines[primitives.Slot(tt.args.epoch*32)]
 			err := state.trigger(tt.args.name, tt.args.data)
@@ -222,8 +222,8 @@ func TestStateMachineManager_QueueLoop(t *testing.T) {
 		return stateScheduled, nil
 	})
 	assert.Equal(t, 4, len(smm.handlers), "Unexpected number of state events")
-	smm.addStateMachine(64)
-	smm.addStateMachine(512)
+	smm.addStateMachine(64, 64)
+	smm.addStateMachine(512, 64)
 
 	assertState := func(startSlot primitives.Slot, state stateID) {
 		fsm, ok := smm.findStateMachine(startSlot)
@@ -259,7 +259,7 @@ func TestStateMachineManager_removeStateMachine(t *testing.T) {
 	if _, ok := smm.findStateMachine(64); ok {
 		t.Error("unexpected machine found")
 	}
-	smm.addStateMachine(64)
+	smm.addStateMachine(64, 64)
 	if _, ok := smm.findStateMachine(64); !ok {
 		t.Error("expected machine not found")
 	}
@@ -273,9 +273,9 @@ func TestStateMachineManager_removeStateMachine(t *testing.T) {
 
 func TestStateMachineManager_removeAllStateMachines(t *testing.T) {
 	smm := newStateMachineManager()
-	smm.addStateMachine(64)
-	smm.addStateMachine(128)
-	smm.addStateMachine(196)
+	smm.addStateMachine(64, 64)
+	smm.addStateMachine(128, 64)
+	smm.addStateMachine(196, 64)
 	keys := []primitives.Slot{64, 128, 196}
 	assert.DeepEqual(t, smm.keys, keys, "Keys not sorted")
 	assert.Equal(t, 3, len(smm.machines), "Unexpected list size")
@@ -291,14 +291,14 @@ func TestStateMachineManager_findStateMachine(t *testing.T) {
 	if _, ok := smm.findStateMachine(64); ok {
 		t.Errorf("unexpected returned value: want: %v, got: %v", false, ok)
 	}
-	smm.addStateMachine(64)
+	smm.addStateMachine(64, 64)
 	if fsm, ok := smm.findStateMachine(64); !ok || fsm == nil {
 		t.Errorf("unexpected returned value: want: %v, got: %v", true, ok)
 	}
-	smm.addStateMachine(512)
-	smm.addStateMachine(196)
-	smm.addStateMachine(256)
-	smm.addStateMachine(128)
+	smm.addStateMachine(512, 64)
+	smm.addStateMachine(196, 64)
+	smm.addStateMachine(256, 64)
+	smm.addStateMachine(128, 64)
 	if fsm, ok := smm.findStateMachine(128); !ok || fsm.start != 128 {
 		t.Errorf("unexpected start slot: %v, want: %v", fsm.start, 122)
 	}
@@ -313,9 +313,9 @@ func TestStateMachineManager_highestStartSlot(t *testing.T) {
 	smm := newStateMachineManager()
 	_, err := smm.highestStartSlot()
 	assert.ErrorContains(t, "no state machine exist", err)
-	smm.addStateMachine(64)
-	smm.addStateMachine(128)
-	smm.addStateMachine(196)
+	smm.addStateMachine(64, 64)
+	smm.addStateMachine(128, 64)
+	smm.addStateMachine(196, 64)
 	start, err := smm.highestStartSlot()
 	assert.NoError(t, err)
 	assert.Equal(t, primitives.Slot(196), start, "Incorrect highest start slot")
@@ -342,7 +342,7 @@ func TestStateMachineManager_allMachinesInState(t *testing.T) {
 			name: "single machine default state",
 			smmGen: func() *stateMachineManager {
 				smm := newStateMachineManager()
-				smm.addStateMachine(64)
+				smm.addStateMachine(64, 64)
 				return smm
 			},
 			expectedStates:   []stateID{stateNew},
@@ -352,7 +352,7 @@ func TestStateMachineManager_allMachinesInState(t *testing.T) {
 			name: "single machine updated state",
 			smmGen: func() *stateMachineManager {
 				smm := newStateMachineManager()
-				m1 := smm.addStateMachine(64)
+				m1 := smm.addStateMachine(64, 64)
 				m1.setState(stateSkipped)
 				return smm
 			},
@@ -363,13 +363,13 @@ func TestStateMachineManager_allMachinesInState(t *testing.T) {
 			name: "multiple machines false",
 			smmGen: func() *stateMachineManager {
 				smm := newStateMachineManager()
-				smm.addStateMachine(64)
-				smm.addStateMachine(128)
-				smm.addStateMachine(196)
+				smm.addStateMachine(64, 64)
+				smm.addStateMachine(128, 64)
+				smm.addStateMachine(196, 64)
 				for _, fsm := range smm.machines {
 					fsm.setState(stateSkipped)
 				}
-				smm.addStateMachine(256)
+				smm.addStateMachine(256, 64)
 				return smm
 			},
 			expectedStates:   []stateID{},
@@ -379,9 +379,9 @@ func TestStateMachineManager_allMachinesInState(t *testing.T) {
 			name: "multiple machines true",
 			smmGen: func() *stateMachineManager {
 				smm := newStateMachineManager()
-				smm.addStateMachine(64)
-				smm.addStateMachine(128)
-				smm.addStateMachine(196)
+				smm.addStateMachine(64, 64)
+				smm.addStateMachine(128, 64)
+				smm.addStateMachine(196, 64)
 				for _, fsm := range smm.machines {
 					fsm.setState(stateSkipped)
 				}
@@ -416,17 +416,17 @@ func TestStateMachine_isFirstLast(t *testing.T) {
 		assert.Equal(t, want, m.isLast(), "isLast() returned unexpected value")
 	}
 	smm := newStateMachineManager()
-	m1 := smm.addStateMachine(64)
+	m1 := smm.addStateMachine(64, 64)
 	checkFirst(m1, true)
 	checkLast(m1, true)
 
-	m2 := smm.addStateMachine(128)
+	m2 := smm.addStateMachine(128, 64)
 	checkFirst(m1, true)
 	checkLast(m1, false)
 	checkFirst(m2, false)
 	checkLast(m2, true)
 
-	m3 := smm.addStateMachine(512)
+	m3 := smm.addStateMachine(512, 64)
 	checkFirst(m1, true)
 	checkLast(m1, false)
 	checkFirst(m2, false)
@@ -435,7 +435,7 @@ func TestStateMachine_isFirstLast(t *testing.T) {
 	checkLast(m3, true)
 
 	// Add machine with lower start slot - shouldn't be marked as last.
-	m4 := smm.addStateMachine(196)
+	m4 := smm.addStateMachine(196, 64)
 	checkFirst(m1, true)
 	checkLast(m1, false)
 	checkFirst(m2, false)
@@ -446,7 +446,7 @@ func TestStateMachine_isFirstLast(t *testing.T) {
 	checkLast(m4, false)
 
 	// Add machine with lowest start slot - should be marked as first.
-	m5 := smm.addStateMachine(32)
+	m5 := smm.addStateMachine(32, 64)
 	checkFirst(m1, false)
 	checkLast(m1, false)
 	checkFirst(m2, false)
@@ -13,7 +13,6 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/das"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
-	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
@@ -87,6 +86,9 @@ func (s *Service) startBlocksQueue(ctx context.Context, highestSlot primitives.S
 		highestExpectedSlot: highestSlot,
 		mode:                mode,
 		bs:                  summarizer,
+		archive:             s.cfg.BlobStorage.ArchiveMode(),
+		dcs:                 s.cfg.DataColumnStorage,
+		custody:             s.custodyColumns,
 	}
 	queue := newBlocksQueue(ctx, cfg)
 	if err := queue.start(); err != nil {
@@ -172,8 +174,7 @@ func (s *Service) processFetchedDataRegSync(
 	if len(bwb) == 0 {
 		return
 	}
-	bv := verification.NewBlobBatchVerifier(s.newBlobVerifier, verification.InitsyncSidecarRequirements)
-	avs := das.NewLazilyPersistentStore(s.cfg.BlobStorage, bv)
+	avs := s.availabilityStore()
 	batchFields := logrus.Fields{
 		"firstSlot":        data.bwb[0].Block.Block().Slot(),
 		"firstUnprocessed": bwb[0].Block.Block().Slot(),
@@ -183,6 +184,10 @@ func (s *Service) processFetchedDataRegSync(
 			log.WithError(err).WithFields(batchFields).WithFields(syncFields(b.Block)).Warn("Batch failure due to BlobSidecar issues")
 			return
 		}
+		if err := avs.PersistColumns(s.clock.CurrentSlot(), b.Columns...); err != nil {
+			log.WithError(err).WithFields(batchFields).WithFields(syncFields(b.Block)).Warn("Batch failure due to DataColumnSidecar issues")
+			return
+		}
 		if err := s.processBlock(ctx, genesis, b, s.cfg.Chain.ReceiveBlock, avs); err != nil {
 			switch {
 			case errors.Is(err, errParentDoesNotExist):
@@ -331,16 +336,15 @@ func (s *Service) processBatchedBlocks(ctx context.Context, genesis time.Time,
 			errParentDoesNotExist, first.Block().ParentRoot(), first.Block().Slot())
 	}
 
-	bv := verification.NewBlobBatchVerifier(s.newBlobVerifier, verification.InitsyncSidecarRequirements)
-	avs := das.NewLazilyPersistentStore(s.cfg.BlobStorage, bv)
+	avs := s.availabilityStore()
 	s.logBatchSyncStatus(genesis, first, len(bwb))
 	for _, bb := range bwb {
-		if len(bb.Blobs) == 0 {
-			continue
-		}
 		if err := avs.Persist(s.clock.CurrentSlot(), bb.Blobs...); err != nil {
 			return err
 		}
+		if err := avs.PersistColumns(s.clock.CurrentSlot(), bb.Columns...); err != nil {
+			return err
+		}
 	}
 
 	return bFunc(ctx, blocks.BlockWithROBlobsSlice(bwb).ROBlocks(), avs)
@@ -15,6 +15,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
 	blockfeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/block"
 	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/peerdas"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/das"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
@@ -53,6 +54,7 @@ type Config struct {
 	ClockWaiter         startup.ClockWaiter
 	InitialSyncComplete chan struct{}
 	BlobStorage         *filesystem.BlobStorage
+	DataColumnStorage   *filesystem.DataColumnStorage
 }
 
 // Service service.
@@ -68,6 +70,9 @@ type Service struct {
 	verifierWaiter  *verification.InitializerWaiter
 	newBlobVerifier verification.NewBlobVerifier
 	ctxMap          sync.ContextByteVersions
+	// newDataColumnVerifier and custodyColumns are only set when the node stores data columns.
+	newDataColumnVerifier verification.NewDataColumnVerifier
+	custodyColumns        map[uint64]bool
 }
 
 // Option is a functional option for the initial-sync Service.
@@ -148,6 +153,10 @@ func (s *Service) Start() {
 		return
 	}
 	s.newBlobVerifier = newBlobVerifierFromInitializer(v)
+	if err := s.initDataColumns(v); err != nil {
+		log.WithError(err).Error("Could not initialize data column sync")
+		return
+	}
 
 	gt := clock.GenesisTime()
 	if gt.IsZero() {
@@ -306,6 +315,41 @@ func missingBlobRequest(blk blocks.ROBlock, store *filesystem.BlobStorage) (p2pt
 	return req, nil
 }
 
+// availabilityStore returns the store used to check and save the blobs of synced blocks. In blob archive mode the
+// blobs of every block since the Deneb fork are checked, not only those within the data availability window. When the
+// node stores data columns, the custody columns of the blocks of the PeerDAS fork are checked instead of their blobs.
+func (s *Service) availabilityStore() *das.LazilyPersistentStore {
+	bv := verification.NewBlobBatchVerifier(s.newBlobVerifier, verification.InitsyncSidecarRequirements)
+	var opts []das.StoreOption
+	if s.cfg.BlobStorage.ArchiveMode() {
+		opts = append(opts, das.WithFullHistory())
+	}
+	if s.newDataColumnVerifier != nil {
+		cv := verification.NewDataColumnBatchVerifier(s.newDataColumnVerifier, verification.InitsyncColumnSidecarRequirements)
+		opts = append(opts, das.WithDataColumns(s.cfg.DataColumnStorage, cv, s.custodyColumns))
+	}
+	return das.NewLazilyPersistentStore(s.cfg.BlobStorage, bv, opts...)
+}
+
+// initDataColumns sets up the verification of data columns and the columns custodied by the node, on networks that
+// schedule the PeerDAS fork and when data column storage is configured.
+func (s *Service) initDataColumns(ini *verification.Initializer) error {
+	if s.cfg.DataColumnStorage == nil || !params.PeerDASEnabled() {
+		return nil
+	}
+	nodeID, err := peerdas.ConvertPeerIDToNodeID(s.cfg.P2P.PeerID())
+	if err != nil {
+		return errors.Wrap(err, "could not convert peer ID to node ID")
+	}
+	custody, err := peerdas.CustodyColumns(nodeID, peerdas.CustodySubnetCount())
+	if err != nil {
+		return errors.Wrap(err, "could not compute custody columns")
+	}
+	s.custodyColumns = custody
+	s.newDataColumnVerifier = newDataColumnVerifierFromInitializer(ini)
+	return nil
+}
+
 func (s *Service) fetchOriginBlobs(pids []peer.ID) error {
 	r, err := s.cfg.DB.OriginCheckpointBlockRoot(s.ctx)
 	if errors.Is(err, db.ErrNotFoundOriginBlockRoot) {
@@ -316,7 +360,7 @@ func (s *Service) fetchOriginBlobs(pids []peer.ID) error {
 		log.WithField("root", fmt.Sprintf("%#x", r)).Error("Block for checkpoint sync origin root not found in db")
 		return err
 	}
-	if !params.WithinDAPeriod(slots.ToEpoch(blk.Block().Slot()), slots.ToEpoch(s.clock.CurrentSlot())) {
+	if !s.cfg.BlobStorage.ArchiveMode() && !params.WithinDAPeriod(slots.ToEpoch(blk.Block().Slot()), slots.ToEpoch(s.clock.CurrentSlot())) {
 		return nil
 	}
 	rob, err := blocks.NewROBlockWithRoot(blk, r)
@@ -340,8 +384,7 @@ func (s *Service) fetchOriginBlobs(pids []peer.ID) error {
 		if len(sidecars) != len(req) {
 			continue
 		}
-		bv := verification.NewBlobBatchVerifier(s.newBlobVerifier, verification.InitsyncSidecarRequirements)
-		avs := das.NewLazilyPersistentStore(s.cfg.BlobStorage, bv)
+		avs := s.availabilityStore()
 		current := s.clock.CurrentSlot()
 		if err := avs.Persist(current, sidecars...); err != nil {
 			return err
@@ -368,3 +411,9 @@ func newBlobVerifierFromInitializer(ini *verification.Initializer) verification.
 		return ini.NewBlobVerifier(b, reqs)
 	}
 }
+
+func newDataColumnVerifierFromInitializer(ini *verification.Initializer) verification.NewDataColumnVerifier {
+	return func(dc blocks.RODataColumn, reqs []verification.Requirement) verification.DataColumnVerifier {
+		return ini.NewDataColumnVerifier(dc, reqs)
+	}
+}
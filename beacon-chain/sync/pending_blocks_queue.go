@@ -196,7 +196,8 @@ func (s *Service) hasPeer() bool {
 var errNoPeersForPending = errors.New("no suitable peers to process pending block queue, delaying")
 
 // processAndBroadcastBlock validates, processes, and broadcasts a block.
-// part of the function is to request missing blobs from peers if the block contains kzg commitments.
+// part of the function is to request missing blobs, or data columns with PeerDAS, from peers if the block contains
+// kzg commitments.
 func (s *Service) processAndBroadcastBlock(ctx context.Context, b interfaces.ReadOnlySignedBeaconBlock, blkRoot [32]byte) error {
 	if err := s.validateBeaconBlock(ctx, b, blkRoot); err != nil {
 		if !errors.Is(ErrOptimisticParent, err) {
@@ -205,6 +206,12 @@ func (s *Service) processAndBroadcastBlock(ctx context.Context, b interfaces.Rea
 		}
 	}
 
+	// With PeerDAS, the custody columns the chain waits for are fetched along with the samples.
+	if s.dataColumnSampler != nil && params.PeerDASActive(slots.ToEpoch(b.Block().Slot())) {
+		if err := s.sampleDataColumns(ctx, b, blkRoot); err != nil {
+			return err
+		}
+	}
 	request, err := s.pendingBlobsRequestForBlock(blkRoot, b)
 	if err != nil {
 		return err
@@ -174,6 +174,9 @@ func (s *Service) pendingBlobsRequestForBlock(root [32]byte, b interfaces.ReadOn
 	if b.Version() < version.Deneb {
 		return nil, nil // Block before deneb has no blob.
 	}
+	if params.PeerDASActive(slots.ToEpoch(b.Block().Slot())) {
+		return nil, nil // Blobs are sampled as data columns with PeerDAS.
+	}
 	cc, err := b.Block().Body().BlobKzgCommitments()
 	if err != nil {
 		return nil, err
@@ -79,7 +79,13 @@ func (s *Service) blobSidecarsByRangeRPCHandler(ctx context.Context, msg interfa
 	if err := s.rateLimiter.validateRequest(stream, 1); err != nil {
 		return err
 	}
-	rp, err := validateBlobsByRange(r, s.cfg.chain.CurrentSlot())
+	current := s.cfg.chain.CurrentSlot()
+	minStart, err := s.blobRPCMinServedSlot(current)
+	if err != nil {
+		s.writeErrorResponseToStream(responseCodeServerError, p2ptypes.ErrGeneric.Error(), stream)
+		return errors.Wrapf(err, "unexpected error computing min served blob slot, current_slot=%d", current)
+	}
+	rp, err := validateBlobsByRange(r, current, minStart)
 	if err != nil {
 		s.writeErrorResponseToStream(responseCodeInvalidRequest, err.Error(), stream)
 		s.cfg.p2p.Peers().Scorers().BadResponsesScorer().Increment(stream.Conn().RemotePeer())
@@ -140,11 +146,29 @@ func BlobRPCMinValidSlot(current primitives.Slot) (primitives.Slot, error) {
 	return slots.EpochStart(minStart)
 }
 
+// BlobArchiveMinSlot returns the first slot of the Deneb fork. Nodes in blob archive mode keep and serve blobs
+// starting from this slot, rather than from BlobRPCMinValidSlot.
+func BlobArchiveMinSlot() (primitives.Slot, error) {
+	if params.BeaconConfig().DenebForkEpoch == math.MaxUint64 {
+		return primitives.Slot(math.MaxUint64), nil
+	}
+	return slots.EpochStart(params.BeaconConfig().DenebForkEpoch)
+}
+
+// blobRPCMinServedSlot returns the lowest slot that this node will serve blobs for over RPC.
+func (s *Service) blobRPCMinServedSlot(current primitives.Slot) (primitives.Slot, error) {
+	if s.cfg.blobStorage.ArchiveMode() {
+		return BlobArchiveMinSlot()
+	}
+	return BlobRPCMinValidSlot(current)
+}
+
 func blobBatchLimit() uint64 {
 	return uint64(flags.Get().BlockBatchLimit / fieldparams.MaxBlobsPerBlock)
 }
 
-func validateBlobsByRange(r *pb.BlobSidecarsByRangeRequest, current primitives.Slot) (rangeParams, error) {
+// validateBlobsByRange checks the request against the current slot, clamping the start of the range to minStartSlot.
+func validateBlobsByRange(r *pb.BlobSidecarsByRangeRequest, current, minStartSlot primitives.Slot) (rangeParams, error) {
 	if r.Count == 0 {
 		return rangeParams{}, errors.Wrap(p2ptypes.ErrInvalidRequest, "invalid request Count parameter")
 	}
@@ -176,10 +200,7 @@ func validateBlobsByRange(r *pb.BlobSidecarsByRangeRequest, current primitives.S
 	// [max(current_epoch - MIN_EPOCHS_FOR_BLOB_SIDECARS_REQUESTS, DENEB_FORK_EPOCH), current_epoch]
 	// where current_epoch is defined by the current wall-clock time,
 	// and clients MUST support serving requests of blobs on this range.
-	minStartSlot, err := BlobRPCMinValidSlot(current)
-	if err != nil {
-		return rangeParams{}, errors.Wrap(p2ptypes.ErrInvalidRequest, "BlobRPCMinValidSlot error")
-	}
+	// minStartSlot is usually the start of that range, unless the node is in blob archive mode.
 	if rp.start > maxStart {
 		return rangeParams{}, errors.Wrap(p2ptypes.ErrInvalidRequest, "start > maxStart")
 	}
@@ -3,6 +3,7 @@ package sync
 import (
 	"testing"
 
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
@@ -272,7 +273,9 @@ func TestBlobsByRangeValidation(t *testing.T) {
 	}
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
-			rp, err := validateBlobsByRange(c.req, c.current)
+			minStart, err := BlobRPCMinValidSlot(c.current)
+			require.NoError(t, err)
+			rp, err := validateBlobsByRange(c.req, c.current, minStart)
 			if c.err != nil {
 				require.ErrorIs(t, err, c.err)
 				return
@@ -349,3 +352,33 @@ func TestBlobRPCMinValidSlot(t *testing.T) {
 		})
 	}
 }
+
+func TestBlobRPCMinServedSlot(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.DenebForkEpoch = 1
+	params.OverrideBeaconConfig(cfg)
+	denebSlot, err := slots.EpochStart(params.BeaconConfig().DenebForkEpoch)
+	require.NoError(t, err)
+	current := types.Slot(params.BeaconConfig().MinEpochsForBlobsSidecarsRequest+100) * params.BeaconConfig().SlotsPerEpoch
+	specMin, err := BlobRPCMinValidSlot(current)
+	require.NoError(t, err)
+	require.Equal(t, true, specMin > denebSlot)
+
+	s := &Service{cfg: &config{blobStorage: filesystem.NewEphemeralBlobStorage(t)}}
+	m, err := s.blobRPCMinServedSlot(current)
+	require.NoError(t, err)
+	require.Equal(t, specMin, m)
+
+	archive, err := filesystem.NewBlobStorage(filesystem.WithBasePath(t.TempDir()), filesystem.WithBlobArchiveMode(true))
+	require.NoError(t, err)
+	s = &Service{cfg: &config{blobStorage: archive}}
+	m, err = s.blobRPCMinServedSlot(current)
+	require.NoError(t, err)
+	require.Equal(t, denebSlot, m)
+
+	// Archive nodes serve ranges that start before the spec minimum.
+	rp, err := validateBlobsByRange(&ethpb.BlobSidecarsByRangeRequest{StartSlot: denebSlot + 10, Count: 10}, current, m)
+	require.NoError(t, err)
+	require.Equal(t, denebSlot+10, rp.start)
+}
@@ -50,7 +50,7 @@ func (s *Service) blobSidecarByRootRPCHandler(ctx context.Context, msg interface
 
 	// Compute the oldest slot we'll allow a peer to request, based on the current slot.
 	cs := s.cfg.clock.CurrentSlot()
-	minReqSlot, err := BlobRPCMinValidSlot(cs)
+	minReqSlot, err := s.blobRPCMinServedSlot(cs)
 	if err != nil {
 		return errors.Wrapf(err, "unexpected error computing min valid blob request slot, current_slot=%d", cs)
 	}
@@ -0,0 +1,123 @@
+package sync
+
+import (
+	"math"
+	"testing"
+
+	p2ptypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/types"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	types "github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+func TestDataColumnsByRangeValidation(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	repositionFutureEpochs(cfg)
+	cfg.Eip7594ForkEpoch = cfg.DenebForkEpoch
+	params.OverrideBeaconConfig(cfg)
+
+	forkSlot, err := slots.EpochStart(params.BeaconConfig().Eip7594ForkEpoch)
+	require.NoError(t, err)
+	current := forkSlot + 100
+	cases := []struct {
+		name  string
+		req   *ethpb.DataColumnSidecarsByRangeRequest
+		start types.Slot
+		end   types.Slot
+		size  uint64
+		err   error
+	}{
+		{
+			name: "zero count",
+			req:  &ethpb.DataColumnSidecarsByRangeRequest{StartSlot: forkSlot, Count: 0, Columns: []uint64{0}},
+			err:  p2ptypes.ErrInvalidRequest,
+		},
+		{
+			name: "no columns",
+			req:  &ethpb.DataColumnSidecarsByRangeRequest{StartSlot: forkSlot, Count: 10},
+			err:  p2ptypes.ErrInvalidRequest,
+		},
+		{
+			name: "column out of bounds",
+			req:  &ethpb.DataColumnSidecarsByRangeRequest{StartSlot: forkSlot, Count: 10, Columns: []uint64{fieldparams.NumberOfColumns}},
+			err:  p2ptypes.ErrInvalidRequest,
+		},
+		{
+			name:  "in range",
+			req:   &ethpb.DataColumnSidecarsByRangeRequest{StartSlot: forkSlot + 10, Count: 10, Columns: []uint64{0, 1}},
+			start: forkSlot + 10,
+			end:   forkSlot + 19,
+			size:  10,
+		},
+		{
+			name:  "start before fork is clamped",
+			req:   &ethpb.DataColumnSidecarsByRangeRequest{StartSlot: forkSlot - 5, Count: 10, Columns: []uint64{3}},
+			start: forkSlot,
+			end:   forkSlot + 4,
+			size:  10,
+		},
+		{
+			name:  "end after current is clamped",
+			req:   &ethpb.DataColumnSidecarsByRangeRequest{StartSlot: current - 5, Count: 10, Columns: []uint64{3}},
+			start: current - 5,
+			end:   current,
+			size:  10,
+		},
+		{
+			name:  "start after current is a noop",
+			req:   &ethpb.DataColumnSidecarsByRangeRequest{StartSlot: current + 1, Count: 10, Columns: []uint64{3}},
+			start: current,
+			end:   current,
+			size:  0,
+		},
+	}
+	minStart, err := DataColumnRPCMinValidSlot(current)
+	require.NoError(t, err)
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rp, err := validateDataColumnsByRange(c.req, current, minStart)
+			if c.err != nil {
+				require.ErrorIs(t, err, c.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, c.start, rp.start)
+			require.Equal(t, c.end, rp.end)
+			require.Equal(t, c.size, rp.size)
+		})
+	}
+}
+
+func TestDataColumnRPCMinValidSlot(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	t.Run("peerdas not scheduled", func(t *testing.T) {
+		got, err := DataColumnRPCMinValidSlot(100)
+		require.NoError(t, err)
+		require.Equal(t, types.Slot(math.MaxUint64), got)
+	})
+
+	cfg := params.BeaconConfig().Copy()
+	cfg.Eip7594ForkEpoch = 10
+	params.OverrideBeaconConfig(cfg)
+	forkSlot, err := slots.EpochStart(cfg.Eip7594ForkEpoch)
+	require.NoError(t, err)
+	minReqEpochs := cfg.MinEpochsForDataColumnSidecarsRequest
+	t.Run("before expiry starts", func(t *testing.T) {
+		current, err := slots.EpochStart(cfg.Eip7594ForkEpoch + minReqEpochs)
+		require.NoError(t, err)
+		got, err := DataColumnRPCMinValidSlot(current)
+		require.NoError(t, err)
+		require.Equal(t, forkSlot, got)
+	})
+	t.Run("after expiry starts", func(t *testing.T) {
+		current, err := slots.EpochStart(cfg.Eip7594ForkEpoch + minReqEpochs + 1)
+		require.NoError(t, err)
+		got, err := DataColumnRPCMinValidSlot(current)
+		require.NoError(t, err)
+		require.Equal(t, forkSlot+cfg.SlotsPerEpoch, got)
+	})
+}
@@ -0,0 +1,90 @@
+package sync
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
+	p2ptest "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/testing"
+	p2ptypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/types"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestDataColumnSidecarsByRoot(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	cfg.BellatrixForkEpoch = 0
+	cfg.CapellaForkEpoch = 0
+	cfg.DenebForkEpoch = 0
+	cfg.Eip7594ForkEpoch = 0
+	params.OverrideBeaconConfig(cfg)
+	params.BeaconConfig().InitializeForkSchedule()
+
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	p1.Connect(p2)
+
+	chain := &mock.ChainService{Genesis: time.Now().Add(-oneEpoch()), ValidatorsRoot: [32]byte{}}
+	clock := startup.NewClock(chain.Genesis, chain.ValidatorsRoot)
+	store := filesystem.NewEphemeralDataColumnStorage(t)
+	server := &Service{
+		cfg:         &config{p2p: p2, chain: chain, clock: clock, dataColumnStorage: store},
+		rateLimiter: newRateLimiter(p2),
+	}
+
+	blk, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 1, 1)
+	root := blk.Root()
+	require.NoError(t, store.Save(blocks.NewVerifiedRODataColumn(columns[0])))
+	require.NoError(t, store.Save(blocks.NewVerifiedRODataColumn(columns[2])))
+
+	pcl := protocol.ID(p2p.RPCDataColumnSidecarsByRootTopicV1 + p2.Encoding().ProtocolSuffix())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		req := new(p2ptypes.DataColumnSidecarsByRootReq)
+		assert.NoError(t, p2.Encoding().DecodeWithMaxLength(stream, req))
+		assert.NoError(t, server.dataColumnSidecarByRootRPCHandler(context.Background(), req, stream))
+	})
+
+	ctxMap, err := ContextByteVersionsForValRoot(chain.ValidatorsRoot)
+	require.NoError(t, err)
+	// Column 7 is not stored by the peer and is left out of the response.
+	req := p2ptypes.DataColumnSidecarsByRootReq{
+		{BlockRoot: root[:], ColumnIndex: 0},
+		{BlockRoot: root[:], ColumnIndex: 2},
+		{BlockRoot: root[:], ColumnIndex: 7},
+	}
+	got, err := SendDataColumnSidecarsByRoot(context.Background(), clock, p1, p2.PeerID(), ctxMap, &req)
+	require.NoError(t, err)
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+	require.Equal(t, 2, len(got))
+	require.Equal(t, uint64(0), got[0].ColumnIndex)
+	require.Equal(t, uint64(2), got[1].ColumnIndex)
+	require.Equal(t, root, got[1].BlockRoot())
+}
+
+func TestDataColumnValidatorFromRootReq(t *testing.T) {
+	blk, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 1, 1)
+	root := blk.Root()
+	req := p2ptypes.DataColumnSidecarsByRootReq([]*ethpb.DataColumnIdentifier{{BlockRoot: root[:], ColumnIndex: 3}})
+	vf := dataColumnValidatorFromRootReq(&req)
+	require.NoError(t, vf(columns[3]))
+	require.ErrorIs(t, vf(columns[4]), errUnrequestedDataColumn)
+	require.ErrorIs(t, vf(columns[4]), ErrInvalidFetchedData)
+}
@@ -7,8 +7,10 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/peerdas"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/wrapper"
 	"github.com/prysmaticlabs/prysm/v5/network/forks"
 	pb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
@@ -61,7 +63,18 @@ func (s *Service) metaDataHandler(_ context.Context, _ interface{}, stream libp2
 	case p2p.SchemaVersionV2:
 		// We have a v0 metadata object saved locally, so we
 		// convert it to a v1 metadata object.
-		if currMd.Version() != version.Altair {
+		switch currMd.Version() {
+		case version.Altair:
+		case version.Deneb:
+			// We have a v2 metadata object saved locally, so we
+			// drop the custody subnet count to get a v1 metadata object.
+			currMd = wrapper.WrappedMetadataV1(
+				&pb.MetaDataV1{
+					Attnets:   currMd.AttnetsBitfield(),
+					SeqNumber: currMd.SequenceNumber(),
+					Syncnets:  currMd.MetadataObjV2().Syncnets,
+				})
+		default:
 			currMd = wrapper.WrappedMetadataV1(
 				&pb.MetaDataV1{
 					Attnets:   currMd.AttnetsBitfield(),
@@ -69,6 +82,22 @@ func (s *Service) metaDataHandler(_ context.Context, _ interface{}, stream libp2
 					Syncnets:  bitfield.Bitvector4{byte(0x00)},
 				})
 		}
+	case p2p.SchemaVersionV3:
+		// We have an older metadata object saved locally, so we
+		// convert it to a v2 metadata object with our custody subnet count.
+		if currMd.Version() != version.Deneb {
+			syncnets := bitfield.Bitvector4{byte(0x00)}
+			if currMd.Version() == version.Altair {
+				syncnets = currMd.MetadataObjV1().Syncnets
+			}
+			currMd = wrapper.WrappedMetadataV2(
+				&pb.MetaDataV2{
+					Attnets:            currMd.AttnetsBitfield(),
+					SeqNumber:          currMd.SequenceNumber(),
+					Syncnets:           syncnets,
+					CustodySubnetCount: peerdas.CustodySubnetCount(),
+				})
+		}
 	}
 	if _, err := stream.Write([]byte{responseCodeSuccess}); err != nil {
 		return err
@@ -112,6 +141,11 @@ func (s *Service) sendMetaDataRequest(ctx context.Context, id peer.ID) (metadata
 	if err != nil {
 		return nil, err
 	}
+	// PeerDAS does not come with a fork digest of its own, so the metadata
+	// object is picked from the epoch instead.
+	if params.PeerDASActive(slots.ToEpoch(s.cfg.clock.CurrentSlot())) {
+		msg = wrapper.WrappedMetadataV2(&pb.MetaDataV2{})
+	}
 	// Defensive check to ensure valid objects are being sent.
 	topicVersion := ""
 	switch msg.Version() {
@@ -119,6 +153,8 @@ func (s *Service) sendMetaDataRequest(ctx context.Context, id peer.ID) (metadata
 		topicVersion = p2p.SchemaVersionV1
 	case version.Altair:
 		topicVersion = p2p.SchemaVersionV2
+	case version.Deneb:
+		topicVersion = p2p.SchemaVersionV3
 	}
 	if err := validateVersion(topicVersion, stream); err != nil {
 		return nil, err
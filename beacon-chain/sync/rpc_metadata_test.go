@@ -229,3 +229,74 @@ func TestMetadataRPCHandler_SendsMetadataAltair(t *testing.T) {
 		t.Error("Peer is disconnected despite receiving a valid ping")
 	}
 }
+
+func TestMetadataRPCHandler_SendsMetadataPeerDAS(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	bCfg := params.BeaconConfig().Copy()
+	bCfg.AltairForkEpoch = 0
+	bCfg.Eip7594ForkEpoch = 0
+	params.OverrideBeaconConfig(bCfg)
+	params.BeaconConfig().InitializeForkSchedule()
+
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	p1.Connect(p2)
+	assert.Equal(t, 1, len(p1.BHost.Network().Peers()), "Expected peers to be connected")
+	bitfield := [8]byte{'A', 'B'}
+	p2.LocalMetadata = wrapper.WrappedMetadataV1(&pb.MetaDataV1{
+		SeqNumber: 2,
+		Attnets:   bitfield[:],
+		Syncnets:  []byte{0x1},
+	})
+
+	d := db.SetupDB(t)
+	chain := &mock.ChainService{Genesis: time.Now().Add(-5 * oneEpoch()), ValidatorsRoot: [32]byte{}}
+	r := &Service{
+		cfg: &config{
+			beaconDB: d,
+			p2p:      p1,
+			chain:    chain,
+			clock:    startup.NewClock(chain.Genesis, chain.ValidatorsRoot),
+		},
+		rateLimiter: newRateLimiter(p1),
+	}
+	chain2 := &mock.ChainService{Genesis: time.Now().Add(-5 * oneEpoch()), ValidatorsRoot: [32]byte{}}
+	r2 := &Service{
+		cfg: &config{
+			beaconDB: d,
+			p2p:      p2,
+			chain:    chain2,
+			clock:    startup.NewClock(chain2.Genesis, chain2.ValidatorsRoot),
+		},
+		rateLimiter: newRateLimiter(p2),
+	}
+
+	pcl := protocol.ID(p2p.RPCMetaDataTopicV3 + r.cfg.p2p.Encoding().ProtocolSuffix())
+	topic := string(pcl)
+	r.rateLimiter.limiterMap[topic] = leakybucket.NewCollector(2, 2, time.Second, false)
+	r2.rateLimiter.limiterMap[topic] = leakybucket.NewCollector(2, 2, time.Second, false)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		assert.NoError(t, r2.metaDataHandler(context.Background(), new(interface{}), stream))
+	})
+
+	md, err := r.sendMetaDataRequest(context.Background(), p2.BHost.ID())
+	require.NoError(t, err)
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+
+	// The altair metadata of the peer is upgraded with its custody subnet count.
+	want := &pb.MetaDataV2{
+		SeqNumber:          2,
+		Attnets:            bitfield[:],
+		Syncnets:           []byte{0x1},
+		CustodySubnetCount: params.BeaconConfig().CustodyRequirement,
+	}
+	if !equality.DeepEqual(md.InnerObject(), want) {
+		t.Fatalf("MetadataV2 unequal, received %v but wanted %v", md, want)
+	}
+}
@@ -27,6 +27,7 @@ import (
 
 var errBlobChunkedReadFailure = errors.New("failed to read stream of chunk-encoded blobs")
 var errBlobUnmarshal = errors.New("Could not unmarshal chunk-encoded blob")
+var errDataColumnChunkedReadFailure = errors.New("failed to read stream of chunk-encoded data columns")
 
 // Any error from the following declaration block should result in peer downscoring.
 var (
@@ -40,6 +41,10 @@ var (
 	errBlobResponseOutOfBounds        = errors.Wrap(ErrInvalidFetchedData, "received BlobSidecar with slot outside BlobSidecarsByRangeRequest bounds")
 	errChunkResponseBlockMismatch     = errors.Wrap(ErrInvalidFetchedData, "blob block details do not match")
 	errChunkResponseParentMismatch    = errors.Wrap(ErrInvalidFetchedData, "parent root for response element doesn't match previous element root")
+	errMaxRequestDataColumnsExceeded  = errors.Wrap(ErrInvalidFetchedData, "peer exceeded req data column chunk tx limit")
+	errUnrequestedDataColumn          = errors.Wrap(ErrInvalidFetchedData, "received DataColumnSidecar in response that was not requested")
+	errDataColumnResponseOutOfBounds  = errors.Wrap(ErrInvalidFetchedData, "received DataColumnSidecar with slot outside DataColumnSidecarsByRangeRequest bounds")
+	errDataColumnSlotNotAsc           = errors.Wrap(ErrInvalidFetchedData, "data column slot lower than previous data column slot")
 )
 
 // BeaconBlockProcessor defines a block processing function, which allows to start utilizing
@@ -375,3 +380,170 @@ func readChunkedBlobSidecar(stream network.Stream, encoding encoder.NetworkEncod
 
 	return rob, nil
 }
+
+// SendDataColumnSidecarsByRoot requests the data column sidecars identified by the request from the given peer.
+// Only sidecars that were requested are accepted, though peers are free to respond with a subset of them.
+func SendDataColumnSidecarsByRoot(
+	ctx context.Context, tor blockchain.TemporalOracle, p2pApi p2p.P2P, pid peer.ID,
+	ctxMap ContextByteVersions, req *p2ptypes.DataColumnSidecarsByRootReq,
+) ([]blocks.RODataColumn, error) {
+	if uint64(len(*req)) > params.BeaconConfig().MaxRequestDataColumnSidecars {
+		return nil, errors.Wrapf(p2ptypes.ErrMaxDataColumnReqExceeded, "length=%d", len(*req))
+	}
+
+	topic, err := p2p.TopicFromMessage(p2p.DataColumnSidecarsByRootName, slots.ToEpoch(tor.CurrentSlot()))
+	if err != nil {
+		return nil, err
+	}
+	log.WithField("topic", topic).Debug("Sending data column sidecar request")
+	stream, err := p2pApi.Send(ctx, req, topic, pid)
+	if err != nil {
+		return nil, err
+	}
+	defer closeStream(stream, log)
+
+	vf := dataColumnValidatorFromRootReq(req)
+	max := uint64(len(*req))
+	sidecars := make([]blocks.RODataColumn, 0, max)
+	// Attempt an extra read beyond max to check if the peer is sending more sidecars than requested.
+	for i := uint64(0); i < max+1; i++ {
+		sc, err := readChunkedDataColumnSidecar(stream, p2pApi.Encoding(), ctxMap, vf)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		if i == max {
+			return nil, errMaxRequestDataColumnsExceeded
+		}
+		sidecars = append(sidecars, sc)
+	}
+	return sidecars, nil
+}
+
+// SendDataColumnSidecarsByRangeRequest requests the data column sidecars with the given indices, for the blocks in the
+// slot range of the request, from the given peer. Peers are free to respond with a subset of them, but only the
+// requested columns of blocks within the range are accepted, in ascending slot order.
+func SendDataColumnSidecarsByRangeRequest(
+	ctx context.Context, tor blockchain.TemporalOracle, p2pApi p2p.SenderEncoder, pid peer.ID,
+	ctxMap ContextByteVersions, req *pb.DataColumnSidecarsByRangeRequest,
+) ([]blocks.RODataColumn, error) {
+	if len(req.Columns) == 0 {
+		return nil, nil
+	}
+	topic, err := p2p.TopicFromMessage(p2p.DataColumnSidecarsByRangeName, slots.ToEpoch(tor.CurrentSlot()))
+	if err != nil {
+		return nil, err
+	}
+	log.WithFields(logrus.Fields{
+		"topic":     topic,
+		"startSlot": req.StartSlot,
+		"count":     req.Count,
+		"columns":   len(req.Columns),
+	}).Debug("Sending data column by range request")
+	stream, err := p2pApi.Send(ctx, req, topic, pid)
+	if err != nil {
+		return nil, err
+	}
+	defer closeStream(stream, log)
+
+	max := params.BeaconConfig().MaxRequestDataColumnSidecars
+	if max/uint64(len(req.Columns)) > req.Count {
+		max = req.Count * uint64(len(req.Columns))
+	}
+	vf := dataColumnValidatorFromRangeReq(req)
+	sidecars := make([]blocks.RODataColumn, 0)
+	// Attempt an extra read beyond max to check if the peer is sending more sidecars than requested.
+	for i := uint64(0); i < max+1; i++ {
+		sc, err := readChunkedDataColumnSidecar(stream, p2pApi.Encoding(), ctxMap, vf)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		if i == max {
+			return nil, errMaxRequestDataColumnsExceeded
+		}
+		sidecars = append(sidecars, sc)
+	}
+	return sidecars, nil
+}
+
+type dataColumnResponseValidation func(blocks.RODataColumn) error
+
+// dataColumnValidatorFromRangeReq accepts the requested columns of the blocks in the range of the request, which peers
+// send by ascending slot.
+func dataColumnValidatorFromRangeReq(req *pb.DataColumnSidecarsByRangeRequest) dataColumnResponseValidation {
+	end := req.StartSlot + primitives.Slot(req.Count)
+	columns := make(map[uint64]bool, len(req.Columns))
+	for _, c := range req.Columns {
+		columns[c] = true
+	}
+	var prev primitives.Slot
+	return func(sc blocks.RODataColumn) error {
+		if sc.Slot() < req.StartSlot || sc.Slot() >= end {
+			return errors.Wrapf(errDataColumnResponseOutOfBounds, "req start,end:%d,%d, resp:%d", req.StartSlot, end, sc.Slot())
+		}
+		if !columns[sc.ColumnIndex] {
+			return errors.Wrapf(errUnrequestedDataColumn, "root=%#x index=%d", sc.BlockRoot(), sc.ColumnIndex)
+		}
+		if sc.Slot() < prev {
+			return errors.Wrapf(errDataColumnSlotNotAsc, "previous slot %d, slot %d", prev, sc.Slot())
+		}
+		prev = sc.Slot()
+		return nil
+	}
+}
+
+func dataColumnValidatorFromRootReq(req *p2ptypes.DataColumnSidecarsByRootReq) dataColumnResponseValidation {
+	columnIds := make(map[[32]byte]map[uint64]bool)
+	for _, sc := range *req {
+		blockRoot := bytesutil.ToBytes32(sc.BlockRoot)
+		if columnIds[blockRoot] == nil {
+			columnIds[blockRoot] = make(map[uint64]bool)
+		}
+		columnIds[blockRoot][sc.ColumnIndex] = true
+	}
+	return func(sc blocks.RODataColumn) error {
+		if !columnIds[sc.BlockRoot()][sc.ColumnIndex] {
+			return errors.Wrapf(errUnrequestedDataColumn, "root=%#x index=%d", sc.BlockRoot(), sc.ColumnIndex)
+		}
+		return nil
+	}
+}
+
+func readChunkedDataColumnSidecar(stream network.Stream, encoding encoder.NetworkEncoding, ctxMap ContextByteVersions, vf dataColumnResponseValidation) (blocks.RODataColumn, error) {
+	var dc blocks.RODataColumn
+	code, msg, err := ReadStatusCode(stream, encoding)
+	if err != nil {
+		return dc, err
+	}
+	if code != 0 {
+		return dc, errors.Wrap(errDataColumnChunkedReadFailure, msg)
+	}
+	ctxb, err := readContextFromStream(stream)
+	if err != nil {
+		return dc, errors.Wrap(err, "error reading chunk context bytes from stream")
+	}
+	v, found := ctxMap[bytesutil.ToBytes4(ctxb)]
+	if !found {
+		return dc, errors.Wrapf(errDataColumnChunkedReadFailure, "unrecognized fork digest %#x", ctxb)
+	}
+	if v < version.Deneb {
+		return dc, fmt.Errorf("unexpected context bytes for DataColumnSidecar, ctx=%#x, v=%s", ctxb, version.String(v))
+	}
+	pb := &ethpb.DataColumnSidecar{}
+	if err := encoding.DecodeWithMaxLength(stream, pb); err != nil {
+		return dc, errors.Wrap(err, "failed to decode the protobuf-encoded DataColumnSidecar message from RPC chunk stream")
+	}
+	rodc, err := blocks.NewRODataColumn(pb)
+	if err != nil {
+		return dc, errors.Wrap(err, "unexpected error initializing RODataColumn")
+	}
+	if err := vf(rodc); err != nil {
+		return dc, errors.Wrap(err, "validation failure decoding data column RPC response")
+	}
+	return rodc, nil
+}
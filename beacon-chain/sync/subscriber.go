@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"reflect"
 	"runtime/debug"
+	"slices"
 	"strings"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/altair"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/peerdas"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
 	"github.com/prysmaticlabs/prysm/v5/cmd/beacon-chain/flags"
@@ -145,6 +147,37 @@ func (s *Service) registerSubscribers(epoch primitives.Epoch, digest [4]byte) {
 			params.BeaconConfig().BlobsidecarSubnetCount,
 		)
 	}
+
+	// New Gossip Topic with PeerDAS
+	if params.PeerDASActive(epoch) {
+		s.subscribeDataColumnSubnets(digest)
+	}
+}
+
+// subscribeDataColumnSubnets subscribes to the data column subnets this node custodies, or to all of them
+// when the node is configured to subscribe to every data subnet.
+func (s *Service) subscribeDataColumnSubnets(digest [4]byte) {
+	subnetCount := params.BeaconConfig().DataColumnSidecarSubnetCount
+	if flags.Get().SubscribeAllDataSubnets {
+		s.subscribeStaticWithSubnets(p2p.DataColumnSubnetTopicFormat, s.validateDataColumn, s.dataColumnSubscriber, digest, subnetCount)
+		return
+	}
+	nodeID, err := peerdas.ConvertPeerIDToNodeID(s.cfg.p2p.PeerID())
+	if err != nil {
+		log.WithError(err).Error("Could not convert peer ID to node ID")
+		return
+	}
+	custody, err := peerdas.CustodyColumnSubnets(nodeID, peerdas.CustodySubnetCount())
+	if err != nil {
+		log.WithError(err).Error("Could not compute custody subnets")
+		return
+	}
+	subnets := make([]uint64, 0, len(custody))
+	for i := range custody {
+		subnets = append(subnets, i)
+	}
+	slices.Sort(subnets)
+	s.subscribeStaticWithSubnetSet(p2p.DataColumnSubnetTopicFormat, s.validateDataColumn, s.dataColumnSubscriber, digest, subnets)
 }
 
 // subscribe to a given topic with a given validator and subscription handler.
@@ -324,6 +357,16 @@ func (s *Service) wrapAndReportValidation(topic string, v wrappedVal) (string, p
 // subscribe to a static subnet with the given topic and index. A given validator and subscription handler is
 // used to handle messages from the subnet. The base protobuf message is used to initialize new messages for decoding.
 func (s *Service) subscribeStaticWithSubnets(topic string, validator wrappedVal, handle subHandler, digest [4]byte, subnetCount uint64) {
+	subnets := make([]uint64, 0, subnetCount)
+	for i := uint64(0); i < subnetCount; i++ {
+		subnets = append(subnets, i)
+	}
+	s.subscribeStaticWithSubnetSet(topic, validator, handle, digest, subnets)
+}
+
+// subscribe to a fixed set of subnets with the given topic, searching the network for peers
+// on any of them that lack peers every slot.
+func (s *Service) subscribeStaticWithSubnetSet(topic string, validator wrappedVal, handle subHandler, digest [4]byte, subnets []uint64) {
 	genRoot := s.cfg.clock.GenesisValidatorsRoot()
 	_, e, err := forks.RetrieveForkDataFromDigest(digest, genRoot[:])
 	if err != nil {
@@ -335,7 +378,7 @@ func (s *Service) subscribeStaticWithSubnets(topic string, validator wrappedVal,
 		// Impossible condition as it would mean topic does not exist.
 		panic(fmt.Sprintf("%s is not mapped to any message in GossipTopicMappings", topic))
 	}
-	for i := uint64(0); i < subnetCount; i++ {
+	for _, i := range subnets {
 		s.subscribeWithBase(s.addDigestAndIndexToTopic(topic, digest, i), validator, handle)
 	}
 	genesis := s.cfg.clock.GenesisTime()
@@ -359,7 +402,7 @@ func (s *Service) subscribeStaticWithSubnets(topic string, validator wrappedVal,
 				if !valid {
 					log.Warnf("Attestation subnets with digest %#x are no longer valid, unsubscribing from all of them.", digest)
 					// Unsubscribes from all our current subnets.
-					for i := uint64(0); i < subnetCount; i++ {
+					for _, i := range subnets {
 						fullTopic := fmt.Sprintf(topic, digest, i) + s.cfg.p2p.Encoding().ProtocolSuffix()
 						s.unSubscribeFromTopic(fullTopic)
 					}
@@ -367,7 +410,7 @@ func (s *Service) subscribeStaticWithSubnets(topic string, validator wrappedVal,
 					return
 				}
 				// Check every slot that there are enough peers
-				for i := uint64(0); i < subnetCount; i++ {
+				for _, i := range subnets {
 					if !s.validPeersExist(s.addDigestAndIndexToTopic(topic, digest, i)) {
 						log.Debugf("No peers found subscribed to attestation gossip subnet with "+
 							"committee index %d. Searching network for peers subscribed to the subnet.", i)
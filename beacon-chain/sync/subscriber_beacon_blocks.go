@@ -9,6 +9,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition/interop"
 	"github.com/prysmaticlabs/prysm/v5/config/features"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v5/io/file"
@@ -34,7 +35,14 @@ func (s *Service) beaconBlockSubscriber(ctx context.Context, msg proto.Message)
 		return err
 	}
 
-	go s.reconstructAndBroadcastBlobs(ctx, signed)
+	if s.dataColumnSampler != nil && params.PeerDASActive(slots.ToEpoch(block.Slot())) {
+		// With PeerDAS, availability is established by sampling data columns from peers before the block is imported.
+		if err := s.sampleDataColumns(ctx, signed, root); err != nil {
+			return err
+		}
+	} else {
+		go s.reconstructAndBroadcastBlobs(ctx, signed)
+	}
 
 	if err := s.cfg.chain.ReceiveBlock(ctx, signed, root, nil); err != nil {
 		if blockchain.IsInvalidBlock(err) {
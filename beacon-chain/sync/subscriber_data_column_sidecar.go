@@ -0,0 +1,20 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"google.golang.org/protobuf/proto"
+)
+
+func (s *Service) dataColumnSubscriber(ctx context.Context, msg proto.Message) error {
+	dc, ok := msg.(blocks.VerifiedRODataColumn)
+	if !ok {
+		return fmt.Errorf("message was not type blocks.VerifiedRODataColumn, type=%T", msg)
+	}
+
+	s.setSeenDataColumnIndex(dc.Slot(), dc.ProposerIndex(), dc.ColumnIndex)
+
+	return s.cfg.chain.ReceiveDataColumn(ctx, dc)
+}
@@ -0,0 +1,39 @@
+package verify
+
+import (
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+)
+
+var (
+	errDataColumnVerification          = errors.New("unable to verify data columns")
+	ErrIncorrectDataColumnIndex        = errors.New("incorrect data column index")
+	ErrDataColumnBlockMisaligned       = errors.Wrap(errDataColumnVerification, "root of block header in data column sidecar does not match block root")
+	ErrMismatchedDataColumnCommitments = errors.Wrap(errDataColumnVerification, "commitments of data column sidecar do not match block commitments")
+)
+
+// ColumnAlignsWithBlock verifies that the data column sidecar belongs to the block and carries its commitments. The
+// inclusion proof and the cell proofs are left to the verification of the sidecar.
+func ColumnAlignsWithBlock(col blocks.RODataColumn, block blocks.ROBlock) error {
+	if col.ColumnIndex >= fieldparams.NumberOfColumns {
+		return errors.Wrapf(ErrIncorrectDataColumnIndex, "index %d exceeds NUMBER_OF_COLUMNS %d", col.ColumnIndex, fieldparams.NumberOfColumns)
+	}
+	if col.BlockRoot() != block.Root() {
+		return ErrDataColumnBlockMisaligned
+	}
+	commits, err := block.Block().Body().BlobKzgCommitments()
+	if err != nil {
+		return err
+	}
+	if len(commits) != len(col.KzgCommitments) {
+		return errors.Wrapf(ErrMismatchedDataColumnCommitments, "%d commitments in column %d, %d in block root %#x", len(col.KzgCommitments), col.ColumnIndex, len(commits), block.Root())
+	}
+	for i := range commits {
+		if bytesutil.ToBytes48(commits[i]) != bytesutil.ToBytes48(col.KzgCommitments[i]) {
+			return errors.Wrapf(ErrMismatchedDataColumnCommitments, "commitment %d of column %d for block root %#x", i, col.ColumnIndex, block.Root())
+		}
+	}
+	return nil
+}
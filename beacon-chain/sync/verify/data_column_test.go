@@ -0,0 +1,45 @@
+package verify
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestColumnAlignsWithBlock(t *testing.T) {
+	blk, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 0, 2)
+	require.NoError(t, ColumnAlignsWithBlock(columns[3], blk))
+
+	other, _ := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 1, 2)
+	require.ErrorIs(t, ColumnAlignsWithBlock(columns[3], other), ErrDataColumnBlockMisaligned)
+
+	tweaked := func(f func(*ethpb.DataColumnSidecar)) blocks.RODataColumn {
+		pb := &ethpb.DataColumnSidecar{
+			ColumnIndex:                  columns[3].ColumnIndex,
+			DataColumn:                   columns[3].DataColumn,
+			KzgCommitments:               columns[3].KzgCommitments,
+			KzgProof:                     columns[3].KzgProof,
+			SignedBlockHeader:            columns[3].SignedBlockHeader,
+			KzgCommitmentsInclusionProof: columns[3].KzgCommitmentsInclusionProof,
+		}
+		f(pb)
+		col, err := blocks.NewRODataColumnWithRoot(pb, blk.Root())
+		require.NoError(t, err)
+		return col
+	}
+	col := tweaked(func(pb *ethpb.DataColumnSidecar) {
+		pb.KzgCommitments = pb.KzgCommitments[1:]
+	})
+	require.ErrorIs(t, ColumnAlignsWithBlock(col, blk), ErrMismatchedDataColumnCommitments)
+	col = tweaked(func(pb *ethpb.DataColumnSidecar) {
+		pb.KzgCommitments = [][]byte{pb.KzgCommitments[1], pb.KzgCommitments[0]}
+	})
+	require.ErrorIs(t, ColumnAlignsWithBlock(col, blk), ErrMismatchedDataColumnCommitments)
+	col = tweaked(func(pb *ethpb.DataColumnSidecar) {
+		pb.ColumnIndex = 128
+	})
+	require.ErrorIs(t, ColumnAlignsWithBlock(col, blk), ErrIncorrectDataColumnIndex)
+}
@@ -92,3 +92,67 @@ func (batch *BlobBatchVerifier) verifyOneBlob(sc blocks.ROBlob) (blocks.Verified
 
 	return bv.VerifiedROBlob()
 }
+
+// NewDataColumnBatchVerifier initializes a data column batch verifier. Like NewBlobBatchVerifier, it requires the
+// caller to specify the verification Requirements and a NewDataColumnVerifier callback for the individual columns.
+func NewDataColumnBatchVerifier(newVerifier NewDataColumnVerifier, reqs []Requirement) *DataColumnBatchVerifier {
+	return &DataColumnBatchVerifier{
+		newVerifier: newVerifier,
+		reqs:        reqs,
+	}
+}
+
+// DataColumnBatchVerifier verifies the data column sidecars of a single block that were obtained by root,
+// for example while sampling. The block itself is assumed to have been validated already, so the sidecars
+// only need to be checked against it.
+type DataColumnBatchVerifier struct {
+	newVerifier NewDataColumnVerifier
+	reqs        []Requirement
+}
+
+// VerifiedRODataColumns satisfies the das.DataColumnBatchVerifier interface, used by das.DataColumnSampler.
+func (batch *DataColumnBatchVerifier) VerifiedRODataColumns(ctx context.Context, blk blocks.ROBlock, scs []blocks.RODataColumn) ([]blocks.VerifiedRODataColumn, error) {
+	if len(scs) == 0 {
+		return nil, nil
+	}
+	blkSig := blk.Signature()
+	for i := range scs {
+		colSig := bytesutil.ToBytes96(scs[i].SignedBlockHeader.Signature)
+		if blkSig != colSig {
+			return nil, ErrBatchSignatureMismatch
+		}
+		if blk.Root() != scs[i].BlockRoot() {
+			return nil, ErrBatchBlockRootMismatch
+		}
+	}
+	vs := make([]blocks.VerifiedRODataColumn, len(scs))
+	for i := range scs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		vc, err := batch.verifyOneColumn(scs[i])
+		if err != nil {
+			return nil, err
+		}
+		vs[i] = vc
+	}
+	return vs, nil
+}
+
+func (batch *DataColumnBatchVerifier) verifyOneColumn(sc blocks.RODataColumn) (blocks.VerifiedRODataColumn, error) {
+	vc := blocks.VerifiedRODataColumn{}
+	cv := batch.newVerifier(sc, batch.reqs)
+	// The block signature is compared with the one in the sidecar header before calling verifyOneColumn.
+	cv.SatisfyRequirement(RequireValidProposerSignature)
+
+	if err := cv.DataColumnIndexInBounds(); err != nil {
+		return vc, err
+	}
+	if err := cv.SidecarInclusionProven(); err != nil {
+		return vc, err
+	}
+	if err := cv.SidecarKzgProofVerified(); err != nil {
+		return vc, err
+	}
+	return cv.VerifiedRODataColumn()
+}
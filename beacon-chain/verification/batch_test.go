@@ -187,3 +187,46 @@ func TestBatchVerifier(t *testing.T) {
 		})
 	}
 }
+
+func TestDataColumnBatchVerifier(t *testing.T) {
+	ctx := context.Background()
+	blk, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 0, 2)
+	columns = columns[:4]
+	nv := func(err error) NewDataColumnVerifier {
+		return func(dc blocks.RODataColumn, _ []Requirement) DataColumnVerifier {
+			return &MockDataColumnVerifier{
+				ErrSidecarKzgProofVerified: err,
+				CbVerifiedRODataColumn: func() (blocks.VerifiedRODataColumn, error) {
+					return blocks.NewVerifiedRODataColumn(dc), nil
+				},
+			}
+		}
+	}
+
+	t.Run("happy path", func(t *testing.T) {
+		bv := NewDataColumnBatchVerifier(nv(nil), ByRootColumnSidecarRequirements)
+		vcs, err := bv.VerifiedRODataColumns(ctx, blk, columns)
+		require.NoError(t, err)
+		require.Equal(t, len(columns), len(vcs))
+		for i := range vcs {
+			require.Equal(t, columns[i].ColumnIndex, vcs[i].ColumnIndex)
+		}
+	})
+	t.Run("no columns", func(t *testing.T) {
+		bv := NewDataColumnBatchVerifier(nv(nil), ByRootColumnSidecarRequirements)
+		vcs, err := bv.VerifiedRODataColumns(ctx, blk, nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, len(vcs))
+	})
+	t.Run("invalid kzg proof", func(t *testing.T) {
+		bv := NewDataColumnBatchVerifier(nv(ErrSidecarKzgProofInvalid), ByRootColumnSidecarRequirements)
+		_, err := bv.VerifiedRODataColumns(ctx, blk, columns)
+		require.ErrorIs(t, err, ErrSidecarKzgProofInvalid)
+	})
+	t.Run("block root mismatch", func(t *testing.T) {
+		other, _ := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 1, 2)
+		bv := NewDataColumnBatchVerifier(nv(nil), ByRootColumnSidecarRequirements)
+		_, err := bv.VerifiedRODataColumns(ctx, other, columns)
+		require.ErrorIs(t, err, ErrBatchBlockRootMismatch)
+	})
+}
@@ -0,0 +1,103 @@
+package verification
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestDataColumnIndexInBounds(t *testing.T) {
+	ini := &Initializer{}
+	_, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 0, 1)
+	dc := columns[0]
+	v := ini.NewDataColumnVerifier(dc, GossipColumnSidecarRequirements)
+	require.NoError(t, v.DataColumnIndexInBounds())
+	require.Equal(t, true, v.results.executed(RequireDataColumnIndexInBounds))
+	require.NoError(t, v.results.result(RequireDataColumnIndexInBounds))
+
+	dc.ColumnIndex = fieldparams.NumberOfColumns
+	v = ini.NewDataColumnVerifier(dc, GossipColumnSidecarRequirements)
+	require.ErrorIs(t, v.DataColumnIndexInBounds(), ErrDataColumnIndexInvalid)
+	require.Equal(t, true, v.results.executed(RequireDataColumnIndexInBounds))
+	require.NotNil(t, v.results.result(RequireDataColumnIndexInBounds))
+}
+
+func TestDataColumnSidecarInclusionProven(t *testing.T) {
+	_, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 1, 2)
+	dc := columns[0]
+
+	ini := Initializer{}
+	v := ini.NewDataColumnVerifier(dc, GossipColumnSidecarRequirements)
+	require.NoError(t, v.SidecarInclusionProven())
+	require.Equal(t, true, v.results.executed(RequireSidecarInclusionProven))
+	require.NoError(t, v.results.result(RequireSidecarInclusionProven))
+
+	// Invert bits of the first byte of the body root to mess up the proof
+	byte0 := dc.SignedBlockHeader.Header.BodyRoot[0]
+	dc.SignedBlockHeader.Header.BodyRoot[0] = byte0 ^ 255
+	v = ini.NewDataColumnVerifier(dc, GossipColumnSidecarRequirements)
+	require.ErrorIs(t, v.SidecarInclusionProven(), ErrSidecarInclusionProofInvalid)
+	require.Equal(t, true, v.results.executed(RequireSidecarInclusionProven))
+	require.NotNil(t, v.results.result(RequireSidecarInclusionProven))
+}
+
+func TestDataColumnSidecarKzgProofVerified(t *testing.T) {
+	_, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 1, 1)
+	dc := columns[0]
+	passes := func(c blocks.RODataColumn) (bool, error) {
+		require.Equal(t, dc.ColumnIndex, c.ColumnIndex)
+		return true, nil
+	}
+	v := &RODataColumnVerifier{verifyDataColumnCommitment: passes, results: newResults(), dataColumn: dc}
+	require.NoError(t, v.SidecarKzgProofVerified())
+	require.Equal(t, true, v.results.executed(RequireSidecarKzgProofVerified))
+	require.NoError(t, v.results.result(RequireSidecarKzgProofVerified))
+
+	fails := func(c blocks.RODataColumn) (bool, error) {
+		return false, errors.New("bad column")
+	}
+	v = &RODataColumnVerifier{verifyDataColumnCommitment: fails, results: newResults(), dataColumn: dc}
+	require.ErrorIs(t, v.SidecarKzgProofVerified(), ErrSidecarKzgProofInvalid)
+	require.Equal(t, true, v.results.executed(RequireSidecarKzgProofVerified))
+	require.NotNil(t, v.results.result(RequireSidecarKzgProofVerified))
+
+	// The placeholder cells generated for the test are not backed by real KZG proofs.
+	ini := Initializer{}
+	v = ini.NewDataColumnVerifier(dc, GossipColumnSidecarRequirements)
+	require.ErrorIs(t, v.SidecarKzgProofVerified(), ErrSidecarKzgProofInvalid)
+}
+
+func TestDataColumnRequirementSatisfaction(t *testing.T) {
+	_, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 1, 1)
+	ini := Initializer{}
+	v := ini.NewDataColumnVerifier(columns[0], GossipColumnSidecarRequirements)
+
+	_, err := v.VerifiedRODataColumn()
+	require.ErrorIs(t, err, ErrDataColumnInvalid)
+	var me VerificationMultiError
+	require.Equal(t, true, errors.As(err, &me))
+	for _, v := range me.Failures() {
+		require.ErrorIs(t, v, ErrMissingVerification)
+	}
+
+	for _, r := range GossipColumnSidecarRequirements {
+		v.SatisfyRequirement(r)
+	}
+	vc, err := v.VerifiedRODataColumn()
+	require.NoError(t, err)
+	require.Equal(t, columns[0].BlockRoot(), vc.BlockRoot())
+}
+
+func TestAllColumnRequirementsHaveStrings(t *testing.T) {
+	for i := range allColumnSidecarRequirements {
+		require.NotEqual(t, unknownRequirementName, allColumnSidecarRequirements[i].String())
+	}
+	// Columns reuse the blob requirements, except for the structural check.
+	require.DeepEqual(t, ByRootColumnSidecarRequirements, []Requirement{
+		RequireDataColumnIndexInBounds, RequireSidecarInclusionProven, RequireSidecarKzgProofVerified,
+	})
+}
@@ -42,3 +42,11 @@ func FakeVerifySliceForTest(t *testing.T, b []blocks.ROBlob) []blocks.VerifiedRO
 	}
 	return vbs
 }
+
+// FakeVerifyDataColumnForTest can be used by tests that need a VerifiedRODataColumn but don't want to do all the
+// expensive set up to perform full validation.
+func FakeVerifyDataColumnForTest(t *testing.T, dc blocks.RODataColumn) blocks.VerifiedRODataColumn {
+	// log so that t is truly required
+	t.Log("producing fake VerifiedRODataColumn for a test")
+	return blocks.NewVerifiedRODataColumn(dc)
+}
@@ -72,3 +72,70 @@ func (m *MockBlobVerifier) SidecarProposerExpected(_ context.Context) (err error
 func (*MockBlobVerifier) SatisfyRequirement(_ Requirement) {}
 
 var _ BlobVerifier = &MockBlobVerifier{}
+
+type MockDataColumnVerifier struct {
+	ErrDataColumnIndexInBounds      error
+	ErrSlotTooEarly                 error
+	ErrSlotAboveFinalized           error
+	ErrValidProposerSignature       error
+	ErrSidecarParentSeen            error
+	ErrSidecarParentValid           error
+	ErrSidecarParentSlotLower       error
+	ErrSidecarDescendsFromFinalized error
+	ErrSidecarInclusionProven       error
+	ErrSidecarKzgProofVerified      error
+	ErrSidecarProposerExpected      error
+	CbVerifiedRODataColumn          func() (blocks.VerifiedRODataColumn, error)
+}
+
+func (m *MockDataColumnVerifier) VerifiedRODataColumn() (blocks.VerifiedRODataColumn, error) {
+	return m.CbVerifiedRODataColumn()
+}
+
+func (m *MockDataColumnVerifier) DataColumnIndexInBounds() (err error) {
+	return m.ErrDataColumnIndexInBounds
+}
+
+func (m *MockDataColumnVerifier) NotFromFutureSlot() (err error) {
+	return m.ErrSlotTooEarly
+}
+
+func (m *MockDataColumnVerifier) SlotAboveFinalized() (err error) {
+	return m.ErrSlotAboveFinalized
+}
+
+func (m *MockDataColumnVerifier) ValidProposerSignature(_ context.Context) (err error) {
+	return m.ErrValidProposerSignature
+}
+
+func (m *MockDataColumnVerifier) SidecarParentSeen(_ func([32]byte) bool) (err error) {
+	return m.ErrSidecarParentSeen
+}
+
+func (m *MockDataColumnVerifier) SidecarParentValid(_ func([32]byte) bool) (err error) {
+	return m.ErrSidecarParentValid
+}
+
+func (m *MockDataColumnVerifier) SidecarParentSlotLower() (err error) {
+	return m.ErrSidecarParentSlotLower
+}
+
+func (m *MockDataColumnVerifier) SidecarDescendsFromFinalized() (err error) {
+	return m.ErrSidecarDescendsFromFinalized
+}
+
+func (m *MockDataColumnVerifier) SidecarInclusionProven() (err error) {
+	return m.ErrSidecarInclusionProven
+}
+
+func (m *MockDataColumnVerifier) SidecarKzgProofVerified() (err error) {
+	return m.ErrSidecarKzgProofVerified
+}
+
+func (m *MockDataColumnVerifier) SidecarProposerExpected(_ context.Context) (err error) {
+	return m.ErrSidecarProposerExpected
+}
+
+func (*MockDataColumnVerifier) SatisfyRequirement(_ Requirement) {}
+
+var _ DataColumnVerifier = &MockDataColumnVerifier{}
@@ -5,6 +5,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v5/cmd"
 	"github.com/prysmaticlabs/prysm/v5/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/urfave/cli/v2"
 )
 
@@ -20,5 +21,13 @@ func FlagOptions(c *cli.Context) ([]blockchain.Option, error) {
 		blockchain.WithMaxGoroutines(maxRoutines),
 		blockchain.WithWeakSubjectivityCheckpoint(wsCheckpt),
 	}
+	if c.Bool(flags.ValidatorHistory.Name) {
+		retention := primitives.Epoch(c.Uint64(flags.ValidatorHistoryRetentionEpochs.Name))
+		opts = append(opts, blockchain.WithValidatorHistory(retention))
+	}
+	if c.Bool(flags.RewardSummaries.Name) {
+		retention := primitives.Epoch(c.Uint64(flags.RewardSummariesRetentionEpochs.Name))
+		opts = append(opts, blockchain.WithRewardSummaries(retention))
+	}
 	return opts, nil
 }
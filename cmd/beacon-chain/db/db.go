@@ -21,6 +21,7 @@ var Commands = &cli.Command{
 			Description: `restores a database from a backup file`,
 			Flags: cmd.WrapFlags([]cli.Flag{
 				cmd.RestoreSourceFileFlag,
+				cmd.RestoreIncrementalFileFlag,
 				cmd.RestoreTargetDirFlag,
 			}),
 			Before: tos.VerifyTosAcceptedOrPrompt,
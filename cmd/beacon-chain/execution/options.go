@@ -1,14 +1,13 @@
 package execution
 
 import (
-	"encoding/hex"
 	"fmt"
 	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/execution"
 	"github.com/prysmaticlabs/prysm/v5/cmd/beacon-chain/flags"
-	"github.com/prysmaticlabs/prysm/v5/io/file"
+	"github.com/prysmaticlabs/prysm/v5/cmd/beacon-chain/jwt"
 	"github.com/urfave/cli/v2"
 )
 
@@ -47,21 +46,10 @@ func parseJWTSecretFromFile(c *cli.Context) ([]byte, error) {
 	if jwtSecretFile == "" {
 		return nil, nil
 	}
-	enc, err := file.ReadFileAsBytes(jwtSecretFile)
+	secret, err := jwt.ReadSecret(jwtSecretFile)
 	if err != nil {
 		return nil, err
 	}
-	strData := strings.TrimSpace(string(enc))
-	if strData == "" {
-		return nil, fmt.Errorf("provided JWT secret in file %s cannot be empty", jwtSecretFile)
-	}
-	secret, err := hex.DecodeString(strings.TrimPrefix(strData, "0x"))
-	if err != nil {
-		return nil, err
-	}
-	if len(secret) < 32 {
-		return nil, errors.New("provided JWT secret should be a hex string of at least 32 bytes")
-	}
 	log.Infof("Finished reading JWT secret from %s", jwtSecretFile)
 	return secret, nil
 }
@@ -153,6 +153,32 @@ var (
 		Value:   strings.Join(DefaultHTTPCorsDomains, ", "),
 		Aliases: []string{"grpc-gateway-corsdomain"},
 	}
+	// HTTPAuthTokensFile specifies the static bearer tokens accepted by the HTTP server.
+	HTTPAuthTokensFile = &cli.StringFlag{
+		Name: "http-auth-tokens-file",
+		Usage: "Path to a YAML file listing the name, token and role (read-only, validator-duty or admin) of the " +
+			"clients of the HTTP server. When set, requests must carry one of the tokens, or a JWT accepted with " +
+			"--http-auth-jwt-secret, as bearer token.",
+	}
+	// HTTPAuthJWTSecret specifies the secret signing the JWTs accepted by the HTTP server.
+	HTTPAuthJWTSecret = &cli.StringFlag{
+		Name: "http-auth-jwt-secret",
+		Usage: "Path to a hex encoded secret, as created by generate-auth-secret, signing the JWTs accepted as bearer " +
+			"tokens by the HTTP server. Tokens carrying a client name and role are created by generate-api-token.",
+	}
+	// HTTPRateLimit specifies the rate of requests allowed to each client of the HTTP server.
+	HTTPRateLimit = &cli.Float64Flag{
+		Name: "http-rate-limit",
+		Usage: "Requests per second allowed to each client of the HTTP server, with bursts of twice the rate. Clients " +
+			"are identified by token, or by IP address without authentication. Zero disables the limit.",
+	}
+	// HTTPRateLimitHeavy specifies the rate of requests to expensive routes allowed to each client of the HTTP server.
+	HTTPRateLimitHeavy = &cli.Float64Flag{
+		Name: "http-rate-limit-heavy",
+		Usage: "Requests per second allowed to each client of the HTTP server on routes loading states, rewards, " +
+			"blob sidecars or debug data, and on GraphQL queries. These requests are not counted by " +
+			"--http-rate-limit. Zero disables the limit.",
+	}
 
 	// MinSyncPeers specifies the required number of successful peer handshakes in order
 	// to start syncing with external peers.
@@ -214,6 +240,11 @@ var (
 		Name:  "subscribe-all-subnets",
 		Usage: "Subscribe to all possible attestation and sync subnets.",
 	}
+	// SubscribeAllDataSubnets enables subscription to all data column subnets.
+	SubscribeAllDataSubnets = &cli.BoolFlag{
+		Name:  "subscribe-all-data-subnets",
+		Usage: "Custody and subscribe to all data column subnets once PeerDAS is active, instead of the minimum custody requirement.",
+	}
 	// HistoricalSlasherNode is a set of beacon node flags required for performing historical detection with a slasher.
 	HistoricalSlasherNode = &cli.BoolFlag{
 		Name:  "historical-slasher-node",
@@ -296,4 +327,53 @@ var (
 		Usage: "Directory for the slasher database",
 		Value: cmd.DefaultDataDir(),
 	}
+	// ValidatorHistory enables the saving of the duty records of every validator for each epoch.
+	ValidatorHistory = &cli.BoolFlag{
+		Name: "validator-history",
+		Usage: "Saves the attestation, proposal and sync committee outcomes of every validator for each epoch, " +
+			"which are served by the /prysm/v1/validators/{id}/history endpoint.",
+	}
+	// ValidatorHistoryRetentionEpochs defines the number of epochs of validator duty records kept.
+	ValidatorHistoryRetentionEpochs = &cli.Uint64Flag{
+		Name:  "validator-history-retention-epochs",
+		Usage: "Number of most recent epochs of validator duty records kept when --validator-history is set. 0 keeps every record.",
+		Value: 4096,
+	}
+	// RewardSummaries enables the saving of the rewards of every validator for each epoch.
+	RewardSummaries = &cli.BoolFlag{
+		Name: "reward-summaries",
+		Usage: "Saves the attestation and sync committee rewards of every validator for each epoch, so that the rewards " +
+			"endpoints can serve old epochs without replaying states.",
+	}
+	// RewardSummariesRetentionEpochs defines the number of epochs of validator rewards kept.
+	RewardSummariesRetentionEpochs = &cli.Uint64Flag{
+		Name:  "reward-summaries-retention-epochs",
+		Usage: "Number of most recent epochs of validator rewards kept when --reward-summaries is set. 0 keeps every reward.",
+		Value: 4096,
+	}
+	// ValidatorQueryIndex enables the index of validators by withdrawal credentials and status.
+	ValidatorQueryIndex = &cli.BoolFlag{
+		Name: "validator-query-index",
+		Usage: "Keeps an in-memory index of validators by withdrawal credentials and status, rebuilt at each epoch " +
+			"transition, which is served by the /prysm/v1/beacon/validators/query endpoint.",
+	}
+	// EnableGraphQL enables the GraphQL endpoint of the HTTP server.
+	EnableGraphQL = &cli.BoolFlag{
+		Name:  "graphql",
+		Usage: "Serves GraphQL queries over blocks, states, operation pools and blob sidecars at /prysm/v1/graphql.",
+	}
+	// GraphQLMaxDepth specifies the maximum depth of a GraphQL query.
+	GraphQLMaxDepth = &cli.IntFlag{
+		Name:  "graphql-max-depth",
+		Usage: "Maximum depth of the selections of a GraphQL query, introspection included. Zero disables the limit.",
+		Value: 15,
+	}
+	// GraphQLMaxCost specifies the maximum cost of a GraphQL query.
+	GraphQLMaxCost = &cli.Uint64Flag{
+		Name: "graphql-max-cost",
+		Usage: "Maximum cost of a GraphQL query. Loading a state costs 10000, a block 10, a blob 100, the committee " +
+			"shuffling of an epoch 1000, a page of up to 1000 validators, balances or committee members 100 and any other " +
+			"object 1. Zero disables the limit.",
+		Value: 200000,
+	}
 )
@@ -9,6 +9,7 @@ import (
 // beacon node.
 type GlobalFlags struct {
 	SubscribeToAllSubnets      bool
+	SubscribeAllDataSubnets    bool
 	MinimumSyncPeers           int
 	MinimumPeersPerSubnet      int
 	MaxConcurrentDials         int
@@ -41,6 +42,10 @@ func ConfigureGlobalFlags(ctx *cli.Context) {
 		log.Warn("Subscribing to All Attestation Subnets")
 		cfg.SubscribeToAllSubnets = true
 	}
+	if ctx.Bool(SubscribeAllDataSubnets.Name) {
+		log.Warn("Subscribing to all data column subnets")
+		cfg.SubscribeAllDataSubnets = true
+	}
 	cfg.BlockBatchLimit = ctx.Int(BlockBatchLimit.Name)
 	cfg.BlockBatchLimitBurstFactor = ctx.Int(BlockBatchLimitBurstFactor.Name)
 	cfg.BlobBatchLimit = ctx.Int(BlobBatchLimit.Name)
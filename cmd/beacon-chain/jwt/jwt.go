@@ -1,9 +1,14 @@
 package jwt
 
 import (
+	"encoding/hex"
+	"fmt"
 	"path/filepath"
+	"strings"
 
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/api"
+	"github.com/prysmaticlabs/prysm/v5/api/server/auth"
 	"github.com/prysmaticlabs/prysm/v5/cmd"
 	"github.com/prysmaticlabs/prysm/v5/io/file"
 	"github.com/sirupsen/logrus"
@@ -29,6 +34,81 @@ var Commands = &cli.Command{
 	},
 }
 
+var (
+	apiTokenSecretFlag = &cli.StringFlag{
+		Name:     "secret-file",
+		Usage:    "Path to the hex encoded secret given to the beacon node with --http-auth-jwt-secret",
+		Required: true,
+	}
+	apiTokenSubjectFlag = &cli.StringFlag{
+		Name:     "subject",
+		Usage:    "Name of the API client, which identifies it in rate limits",
+		Required: true,
+	}
+	apiTokenRoleFlag = &cli.StringFlag{
+		Name:  "role",
+		Usage: "Role of the API client: read-only, validator-duty or admin",
+		Value: string(auth.RoleReadOnly),
+	}
+	apiTokenTTLFlag = &cli.DurationFlag{
+		Name:  "ttl",
+		Usage: "Lifetime of the token, the token does not expire if not set",
+	}
+)
+
+// APITokenCommand creates a JWT for a client of the beacon node HTTP API.
+var APITokenCommand = &cli.Command{
+	Name:        "generate-api-token",
+	Usage:       "creates a JWT carrying a role for a client of the beacon node HTTP API, signed with a secret created by generate-auth-secret",
+	Description: `creates a JWT carrying a role for a client of the beacon node HTTP API, signed with a secret created by generate-auth-secret. The beacon node accepts the token when run with --http-auth-jwt-secret set to the same secret file`,
+	Flags: cmd.WrapFlags([]cli.Flag{
+		apiTokenSecretFlag,
+		apiTokenSubjectFlag,
+		apiTokenRoleFlag,
+		apiTokenTTLFlag,
+	}),
+	Action: func(cliCtx *cli.Context) error {
+		token, err := generateAPIToken(cliCtx)
+		if err != nil {
+			logrus.WithError(err).Fatal("Could not generate API token")
+		}
+		fmt.Println(token)
+		return nil
+	},
+}
+
+// ReadSecret reads a hex encoded secret of at least 32 bytes from a file, as written by generate-auth-secret.
+func ReadSecret(path string) ([]byte, error) {
+	enc, err := file.ReadFileAsBytes(path)
+	if err != nil {
+		return nil, err
+	}
+	strData := strings.TrimSpace(string(enc))
+	if strData == "" {
+		return nil, fmt.Errorf("provided JWT secret in file %s cannot be empty", path)
+	}
+	secret, err := hex.DecodeString(strings.TrimPrefix(strData, "0x"))
+	if err != nil {
+		return nil, err
+	}
+	if len(secret) < 32 {
+		return nil, errors.New("provided JWT secret should be a hex string of at least 32 bytes")
+	}
+	return secret, nil
+}
+
+func generateAPIToken(c *cli.Context) (string, error) {
+	secret, err := ReadSecret(c.String(apiTokenSecretFlag.Name))
+	if err != nil {
+		return "", errors.Wrap(err, "could not read secret")
+	}
+	role, err := auth.ParseRole(c.String(apiTokenRoleFlag.Name))
+	if err != nil {
+		return "", err
+	}
+	return auth.NewToken(secret, c.String(apiTokenSubjectFlag.Name), role, c.Duration(apiTokenTTLFlag.Name))
+}
+
 func generateAuthSecretInFile(c *cli.Context) error {
 	fileName := secretFileName
 	specifiedFilePath := c.String(cmd.JwtOutputFileFlag.Name)
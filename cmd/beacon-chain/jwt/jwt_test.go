@@ -2,11 +2,16 @@ package jwt
 
 import (
 	"flag"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/api/server/auth"
 	"github.com/prysmaticlabs/prysm/v5/cmd"
 	"github.com/prysmaticlabs/prysm/v5/io/file"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
@@ -84,3 +89,50 @@ func checkAuthFileIntegrity(t testing.TB, fPath string) {
 	require.NoError(t, err)
 	require.Equal(t, 32, len(decoded))
 }
+
+func Test_generateAPIToken(t *testing.T) {
+	secretPath := filepath.Join(t.TempDir(), "secret.hex")
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.String(cmd.JwtOutputFileFlag.Name, secretPath, "")
+	require.NoError(t, generateAuthSecretInFile(cli.NewContext(&app, set, nil)))
+	secret, err := ReadSecret(secretPath)
+	require.NoError(t, err)
+
+	set = flag.NewFlagSet("test", 0)
+	set.String(apiTokenSecretFlag.Name, secretPath, "")
+	set.String(apiTokenSubjectFlag.Name, "dashboard", "")
+	set.String(apiTokenRoleFlag.Name, "admin", "")
+	set.Duration(apiTokenTTLFlag.Name, time.Hour, "")
+	token, err := generateAPIToken(cli.NewContext(&app, set, nil))
+	require.NoError(t, err)
+
+	a, err := auth.NewAuthenticator(auth.WithJWTSecret(secret))
+	require.NoError(t, err)
+	r := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/node/version", nil)
+	r.Header.Set("Authorization", "Bearer "+token)
+	client, err := a.Authenticate(r)
+	require.NoError(t, err)
+	require.Equal(t, "dashboard", client.Name)
+	require.Equal(t, auth.RoleAdmin, client.Role)
+
+	require.NoError(t, set.Set(apiTokenRoleFlag.Name, "root"))
+	_, err = generateAPIToken(cli.NewContext(&app, set, nil))
+	require.ErrorContains(t, "unknown role", err)
+}
+
+func TestReadSecret(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "secret.hex")
+	require.NoError(t, file.WriteFile(path, []byte(" 0x"+strings.Repeat("ab", 32)+"\n")))
+	secret, err := ReadSecret(path)
+	require.NoError(t, err)
+	require.Equal(t, 32, len(secret))
+
+	require.NoError(t, file.WriteFile(path, []byte("")))
+	_, err = ReadSecret(path)
+	require.ErrorContains(t, "cannot be empty", err)
+	require.NoError(t, file.WriteFile(path, []byte(strings.Repeat("ab", 16))))
+	_, err = ReadSecret(path)
+	require.ErrorContains(t, "at least 32 bytes", err)
+}
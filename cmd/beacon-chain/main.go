@@ -52,6 +52,10 @@ var appFlags = []cli.Flag{
 	flags.HTTPServerHost,
 	flags.HTTPServerPort,
 	flags.HTTPServerCorsDomain,
+	flags.HTTPAuthTokensFile,
+	flags.HTTPAuthJWTSecret,
+	flags.HTTPRateLimit,
+	flags.HTTPRateLimitHeavy,
 	flags.MinSyncPeers,
 	flags.ContractDeploymentBlock,
 	flags.SetGCPercent,
@@ -65,6 +69,7 @@ var appFlags = []cli.Flag{
 	flags.SlotsPerArchivedPoint,
 	flags.DisableDebugRPCEndpoints,
 	flags.SubscribeToAllSubnets,
+	flags.SubscribeAllDataSubnets,
 	flags.HistoricalSlasherNode,
 	flags.ChainID,
 	flags.NetworkID,
@@ -84,6 +89,7 @@ var appFlags = []cli.Flag{
 	flags.MinBuilderBid,
 	flags.MinBuilderDiff,
 	cmd.BackupWebhookOutputDir,
+	cmd.EnableBackupWebhookFlag,
 	cmd.MinimalConfigFlag,
 	cmd.E2EConfigFlag,
 	cmd.RPCMaxPageSizeFlag,
@@ -115,6 +121,7 @@ var appFlags = []cli.Flag{
 	cmd.DisableMonitoringFlag,
 	cmd.ClearDB,
 	cmd.ForceClearDB,
+	cmd.DBEngineFlag,
 	cmd.LogFormat,
 	cmd.MaxGoroutines,
 	debug.PProfFlag,
@@ -132,6 +139,7 @@ var appFlags = []cli.Flag{
 	cmd.GrpcMaxCallRecvMsgSizeFlag,
 	cmd.AcceptTosFlag,
 	cmd.RestoreSourceFileFlag,
+	cmd.RestoreIncrementalFileFlag,
 	cmd.RestoreTargetDirFlag,
 	cmd.ValidatorMonitorIndicesFlag,
 	cmd.ApiTimeoutFlag,
@@ -141,9 +149,25 @@ var appFlags = []cli.Flag{
 	genesis.StatePath,
 	genesis.BeaconAPIURL,
 	flags.SlasherDirFlag,
+	flags.ValidatorHistory,
+	flags.ValidatorHistoryRetentionEpochs,
+	flags.RewardSummaries,
+	flags.RewardSummariesRetentionEpochs,
+	flags.ValidatorQueryIndex,
+	flags.EnableGraphQL,
+	flags.GraphQLMaxDepth,
+	flags.GraphQLMaxCost,
 	flags.JwtId,
 	storage.BlobStoragePathFlag,
 	storage.BlobRetentionEpochFlag,
+	storage.BlobRemoteURLFlag,
+	storage.BlobArchiveFlag,
+	storage.DataColumnStoragePathFlag,
+	storage.BeaconDBPruningFlag,
+	storage.BeaconDBRetentionEpochsFlag,
+	storage.BeaconDBUsageFlag,
+	storage.StateDiffsFlag,
+	storage.StateDiffExponentsFlag,
 	bflags.EnableExperimentalBackfill,
 	bflags.BackfillBatchSize,
 	bflags.BackfillWorkerCount,
@@ -238,6 +262,7 @@ func main() {
 		Commands: []*cli.Command{
 			dbcommands.Commands,
 			jwtcommands.Commands,
+			jwtcommands.APITokenCommand,
 		},
 		Flags:  appFlags,
 		Before: before,
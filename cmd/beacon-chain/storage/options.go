@@ -5,6 +5,8 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/pruner"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/node"
 	"github.com/prysmaticlabs/prysm/v5/cmd"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
@@ -24,6 +26,61 @@ var (
 		Value:   uint64(params.BeaconConfig().MinEpochsForBlobsSidecarsRequest),
 		Aliases: []string{"extend-blob-retention-epoch"},
 	}
+	// BlobRemoteURLFlag configures a remote backend that every blob is written through to, and which serves
+	// blobs that have been pruned from the local blob storage.
+	BlobRemoteURLFlag = &cli.StringFlag{
+		Name: "blob-remote-url",
+		Usage: "Url of a remote store that blobs are written through to and served from after they are pruned from --blob-path. " +
+			"Supported forms are file:///path/to/dir and s3://bucket/prefix?endpoint=https://host&region=us-east-1. " +
+			"S3 credentials are read from the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY environment variables.",
+	}
+	// BlobArchiveFlag disables blob pruning and makes backfill download blobs for every block since the deneb fork.
+	BlobArchiveFlag = &cli.BoolFlag{
+		Name: "blob-archive",
+		Usage: "Keeps every blob since the deneb fork instead of pruning blobs outside of the retention period. " +
+			"Checkpoint synced nodes will backfill blobs back to the deneb fork, and all stored blobs are served over p2p and the beacon API.",
+	}
+	// DataColumnStoragePathFlag sets the location of PeerDAS data column sidecar storage.
+	DataColumnStoragePathFlag = &cli.PathFlag{
+		Name:  "data-column-path",
+		Usage: "Location for PeerDAS data column storage. Default location will be a 'data-columns' directory next to the beacon db.",
+	}
+	// BeaconDBPruningFlag enables the background deletion of finalized blocks and states which are older
+	// than the retention period given by BeaconDBRetentionEpochsFlag.
+	BeaconDBPruningFlag = &cli.BoolFlag{
+		Name: "beacon-db-pruning",
+		Usage: "Enables deletion of finalized blocks, states and their indices from the beacon database once they are " +
+			"older than the retention period. Requests for pruned history will fail, so this should not be used on archival nodes.",
+	}
+	// BeaconDBRetentionEpochsFlag sets the number of epochs of history behind the finalized checkpoint
+	// to keep when pruning is enabled.
+	BeaconDBRetentionEpochsFlag = &cli.Uint64Flag{
+		Name: "beacon-db-retention-epochs",
+		Usage: "Number of epochs of block and state history to retain behind the finalized checkpoint when --beacon-db-pruning is enabled. " +
+			"The node will exit with an error at startup if the value is less than MIN_EPOCHS_FOR_BLOCK_REQUESTS (33024 epochs on mainnet).",
+	}
+	// BeaconDBUsageFlag enables tracking the storage usage of the beacon database.
+	BeaconDBUsageFlag = &cli.BoolFlag{
+		Name: "beacon-db-usage",
+		Usage: "Tracks the storage usage of each bucket of the beacon database, and of the blocks and states of each fork, " +
+			"and reports it at /prysm/v1/node/storage and in the db_beacon_* metrics. The database is read once at startup, " +
+			"and the usage is then updated as the database is written.",
+	}
+	// StateDiffsFlag saves finalized states as a hierarchy of snapshots and diffs instead of full states at archived points.
+	StateDiffsFlag = &cli.BoolFlag{
+		Name: "enable-state-diffs",
+		Usage: "Saves finalized states as full snapshots at coarse intervals and diffs against them at finer intervals, " +
+			"so that archival nodes can serve any historical state without replaying blocks from a distant archived point. " +
+			"Existing archived states are migrated on startup.",
+	}
+	// StateDiffExponentsFlag sets the levels of the state diff hierarchy.
+	StateDiffExponentsFlag = &cli.IntSliceFlag{
+		Name: "state-diff-exponents",
+		Usage: "Levels of the state diff hierarchy when --enable-state-diffs is set, as powers of two slots in strictly decreasing order. " +
+			"The first level is saved as full snapshots, and the last level sets the interval of the saved states. A last level of 0, " +
+			"the default, saves the state at every slot so that no historical state needs block replay.",
+		Value: cli.NewIntSlice(stateDiffExponentsDefault()...),
+	}
 )
 
 // BeaconNodeOptions sets configuration values on the node.BeaconNode value at node startup.
@@ -35,9 +92,36 @@ func BeaconNodeOptions(c *cli.Context) ([]node.Option, error) {
 	if err != nil {
 		return nil, err
 	}
-	opts := []node.Option{node.WithBlobStorageOptions(
+	blobOpts := []filesystem.BlobStorageOption{
 		filesystem.WithBlobRetentionEpochs(e), filesystem.WithBasePath(blobStoragePath(c)),
-	)}
+	}
+	if u := c.String(BlobRemoteURLFlag.Name); u != "" {
+		remote, err := filesystem.NewBlobBackend(u)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid value for --%s", BlobRemoteURLFlag.Name)
+		}
+		blobOpts = append(blobOpts, filesystem.WithRemoteBackend(remote))
+	}
+	if c.Bool(BlobArchiveFlag.Name) {
+		blobOpts = append(blobOpts, filesystem.WithBlobArchiveMode(true))
+	}
+	columnOpts := []filesystem.DataColumnStorageOption{
+		filesystem.WithDataColumnBasePath(dataColumnStoragePath(c)),
+	}
+	opts := []node.Option{node.WithBlobStorageOptions(blobOpts...), node.WithDataColumnStorageOptions(columnOpts...)}
+	if c.Bool(BeaconDBPruningFlag.Name) {
+		opts = append(opts, node.WithPrunerOptions(prunerOptions(c)...))
+	}
+	if c.Bool(BeaconDBUsageFlag.Name) {
+		opts = append(opts, node.WithStorageUsage())
+	}
+	if c.Bool(StateDiffsFlag.Name) {
+		exps, err := stateDiffExponents(c)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, node.WithStateDiffExponents(exps))
+	}
 	return opts, nil
 }
 
@@ -50,6 +134,14 @@ func blobStoragePath(c *cli.Context) string {
 	return blobsPath
 }
 
+func dataColumnStoragePath(c *cli.Context) string {
+	columnsPath := c.Path(DataColumnStoragePathFlag.Name)
+	if columnsPath == "" {
+		columnsPath = path.Join(c.String(cmd.DataDirFlag.Name), "data-columns")
+	}
+	return columnsPath
+}
+
 var errInvalidBlobRetentionEpochs = errors.New("value is smaller than spec minimum")
 
 // blobRetentionEpoch returns the spec default MIN_EPOCHS_FOR_BLOB_SIDECARS_REQUEST
@@ -69,3 +161,37 @@ func blobRetentionEpoch(cliCtx *cli.Context) (primitives.Epoch, error) {
 
 	return re, nil
 }
+
+// prunerOptions translates the pruning related flags into options for the pruner service.
+// The retention period is validated by the pruner service itself.
+func prunerOptions(c *cli.Context) []pruner.ServiceOption {
+	var opts []pruner.ServiceOption
+	if c.IsSet(BeaconDBRetentionEpochsFlag.Name) {
+		opts = append(opts, pruner.WithRetentionEpochs(primitives.Epoch(c.Uint64(BeaconDBRetentionEpochsFlag.Name))))
+	}
+	return opts
+}
+
+func stateDiffExponentsDefault() []int {
+	exps := make([]int, len(kv.DefaultStateDiffExponents))
+	for i, e := range kv.DefaultStateDiffExponents {
+		exps[i] = int(e)
+	}
+	return exps
+}
+
+// stateDiffExponents returns the levels of the state diff hierarchy. Their order is validated by the database.
+func stateDiffExponents(c *cli.Context) ([]uint64, error) {
+	values := c.IntSlice(StateDiffExponentsFlag.Name)
+	if len(values) == 0 {
+		return kv.DefaultStateDiffExponents, nil
+	}
+	exps := make([]uint64, len(values))
+	for i, v := range values {
+		if v < 0 {
+			return nil, errors.Errorf("invalid value for --%s: %d is negative", StateDiffExponentsFlag.Name, v)
+		}
+		exps[i] = uint64(v)
+	}
+	return exps, nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
 	"github.com/prysmaticlabs/prysm/v5/cmd"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
@@ -61,3 +62,70 @@ func TestConfigureBlobRetentionEpoch(t *testing.T) {
 	_, err = blobRetentionEpoch(cliCtx)
 	require.ErrorIs(t, err, errInvalidBlobRetentionEpochs)
 }
+
+func TestBeaconNodeOptions_BlobRemoteURL(t *testing.T) {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.String(cmd.DataDirFlag.Name, t.TempDir(), "")
+	set.String(BlobRemoteURLFlag.Name, "", "")
+	cliCtx := cli.NewContext(&app, set, nil)
+
+	require.NoError(t, set.Set(BlobRemoteURLFlag.Name, "file://"+t.TempDir()))
+	opts, err := BeaconNodeOptions(cliCtx)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(opts))
+
+	require.NoError(t, set.Set(BlobRemoteURLFlag.Name, "ftp://example.com/blobs"))
+	_, err = BeaconNodeOptions(cliCtx)
+	require.ErrorContains(t, "invalid value for --blob-remote-url", err)
+}
+
+func TestBeaconNodeOptions_BlobArchive(t *testing.T) {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.String(cmd.DataDirFlag.Name, t.TempDir(), "")
+	set.Bool(BlobArchiveFlag.Name, false, "")
+	require.NoError(t, set.Set(BlobArchiveFlag.Name, "true"))
+	cliCtx := cli.NewContext(&app, set, nil)
+
+	opts, err := BeaconNodeOptions(cliCtx)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(opts))
+}
+
+func TestStateDiffExponents(t *testing.T) {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	cliCtx := cli.NewContext(&app, set, nil)
+
+	exps, err := stateDiffExponents(cliCtx)
+	require.NoError(t, err)
+	require.DeepEqual(t, kv.DefaultStateDiffExponents, exps)
+
+	set.Var(cli.NewIntSlice(), StateDiffExponentsFlag.Name, "")
+	require.NoError(t, set.Set(StateDiffExponentsFlag.Name, "13,5"))
+	exps, err = stateDiffExponents(cliCtx)
+	require.NoError(t, err)
+	require.DeepEqual(t, []uint64{13, 5}, exps)
+
+	require.NoError(t, set.Set(StateDiffExponentsFlag.Name, "-1"))
+	_, err = stateDiffExponents(cliCtx)
+	require.ErrorContains(t, "is negative", err)
+}
+
+func TestBeaconNodeOptions_BeaconDBUsage(t *testing.T) {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.String(cmd.DataDirFlag.Name, t.TempDir(), "")
+	set.Bool(BeaconDBUsageFlag.Name, false, "")
+	cliCtx := cli.NewContext(&app, set, nil)
+
+	opts, err := BeaconNodeOptions(cliCtx)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(opts))
+
+	require.NoError(t, set.Set(BeaconDBUsageFlag.Name, "true"))
+	opts, err = BeaconNodeOptions(cliCtx)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(opts))
+}
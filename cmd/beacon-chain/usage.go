@@ -68,13 +68,17 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.DisableMonitoringFlag,
 			cmd.MaxGoroutines,
 			cmd.ForceClearDB,
+			cmd.DBEngineFlag,
 			cmd.ClearDB,
 			cmd.ConfigFileFlag,
 			cmd.ChainConfigFileFlag,
 			cmd.GrpcMaxCallRecvMsgSizeFlag,
 			cmd.AcceptTosFlag,
 			cmd.RestoreSourceFileFlag,
+			cmd.RestoreIncrementalFileFlag,
 			cmd.RestoreTargetDirFlag,
+			cmd.EnableBackupWebhookFlag,
+			cmd.BackupWebhookOutputDir,
 			cmd.ValidatorMonitorIndicesFlag,
 			cmd.ApiTimeoutFlag,
 		},
@@ -106,6 +110,10 @@ var appHelpFlagGroups = []flagGroup{
 			flags.HTTPServerHost,
 			flags.HTTPServerPort,
 			flags.HTTPServerCorsDomain,
+			flags.HTTPAuthTokensFile,
+			flags.HTTPAuthJWTSecret,
+			flags.HTTPRateLimit,
+			flags.HTTPRateLimitHeavy,
 			flags.ExecutionEngineEndpoint,
 			flags.ExecutionEngineHeaders,
 			flags.ExecutionJWTSecretFlag,
@@ -117,6 +125,7 @@ var appHelpFlagGroups = []flagGroup{
 			flags.BlobBatchLimitBurstFactor,
 			flags.DisableDebugRPCEndpoints,
 			flags.SubscribeToAllSubnets,
+			flags.SubscribeAllDataSubnets,
 			flags.HistoricalSlasherNode,
 			flags.ChainID,
 			flags.NetworkID,
@@ -129,6 +138,14 @@ var appHelpFlagGroups = []flagGroup{
 			flags.MaxBuilderConsecutiveMissedSlots,
 			flags.EngineEndpointTimeoutSeconds,
 			flags.SlasherDirFlag,
+			flags.ValidatorHistory,
+			flags.ValidatorHistoryRetentionEpochs,
+			flags.RewardSummaries,
+			flags.RewardSummariesRetentionEpochs,
+			flags.ValidatorQueryIndex,
+			flags.EnableGraphQL,
+			flags.GraphQLMaxDepth,
+			flags.GraphQLMaxCost,
 			flags.LocalBlockValueBoost,
 			flags.MinBuilderBid,
 			flags.MinBuilderDiff,
@@ -140,6 +157,14 @@ var appHelpFlagGroups = []flagGroup{
 			genesis.BeaconAPIURL,
 			storage.BlobStoragePathFlag,
 			storage.BlobRetentionEpochFlag,
+			storage.BlobRemoteURLFlag,
+			storage.BlobArchiveFlag,
+			storage.DataColumnStoragePathFlag,
+			storage.BeaconDBPruningFlag,
+			storage.BeaconDBRetentionEpochsFlag,
+			storage.BeaconDBUsageFlag,
+			storage.StateDiffsFlag,
+			storage.StateDiffExponentsFlag,
 			backfill.EnableExperimentalBackfill,
 			backfill.BackfillWorkerCount,
 			backfill.BackfillBatchSize,
@@ -192,12 +217,6 @@ var appHelpFlagGroups = []flagGroup{
 			flags.InteropNumValidatorsFlag,
 		},
 	},
-	{
-		Name: "deprecated",
-		Flags: []cli.Flag{
-			cmd.BackupWebhookOutputDir,
-		},
-	},
 }
 
 func init() {
@@ -201,6 +201,13 @@ var (
 		Name:  "clear-db",
 		Usage: "Prompt for clearing any previously stored data at the data directory.",
 	}
+	// DBEngineFlag specifies the storage engine of a new beacon node database.
+	DBEngineFlag = &cli.StringFlag{
+		Name: "db-engine",
+		Usage: "The storage engine of a new beacon node database, either bolt or pebble. An existing database keeps " +
+			"the engine it was created with, use prysmctl db convert to move it to another engine.",
+		Value: "bolt",
+	}
 	// LogFormat specifies the log output format.
 	LogFormat = &cli.StringFlag{
 		Name:  "log-format",
@@ -261,6 +268,12 @@ var (
 		Name:  "restore-source-file",
 		Usage: "Filepath to the backed-up database file which will be used to restore the database",
 	}
+	// RestoreIncrementalFileFlag specifies the filepaths of incremental backup archives which are applied, in order,
+	// on top of the streamed backup archive given as the restore source file.
+	RestoreIncrementalFileFlag = &cli.StringSliceFlag{
+		Name:  "restore-incremental-file",
+		Usage: "Filepath to an incremental backup archive to apply on top of the restored database. May be repeated, in the order the backups were taken",
+	}
 	// RestoreTargetDirFlag specifies the target directory of the restored database.
 	RestoreTargetDirFlag = &cli.StringFlag{
 		Name:  "restore-target-dir",
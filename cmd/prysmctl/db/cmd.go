@@ -10,6 +10,10 @@ var Commands = []*cli.Command{
 			queryCmd,
 			bucketsCmd,
 			spanCmd,
+			exportEraCmd,
+			importEraCmd,
+			verifyCmd,
+			convertCmd,
 		},
 	},
 }
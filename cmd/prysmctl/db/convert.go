@@ -0,0 +1,55 @@
+package db
+
+import (
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var convertFlags = struct {
+	Path    string
+	OutPath string
+	Engine  string
+}{}
+
+var convertCmd = &cli.Command{
+	Name:  "convert",
+	Usage: "copy the beacon db into a new database stored in another engine, or into a compacted bolt database",
+	Action: func(cliCtx *cli.Context) error {
+		if err := convertAction(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not convert database")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "path",
+			Usage:       "path to directory containing the beacon db, which must not be in use",
+			Destination: &convertFlags.Path,
+			Required:    true,
+		},
+		&cli.StringFlag{
+			Name:        "out",
+			Usage:       "path to the directory to write the new database to, which must not hold a beacon db",
+			Destination: &convertFlags.OutPath,
+			Required:    true,
+		},
+		&cli.StringFlag{
+			Name:        "engine",
+			Usage:       "the engine of the new database, either bolt or pebble",
+			Destination: &convertFlags.Engine,
+			Required:    true,
+		},
+	},
+}
+
+func convertAction(cliCtx *cli.Context) error {
+	n, err := kv.ConvertEngine(cliCtx.Context, convertFlags.Path, convertFlags.OutPath, convertFlags.Engine)
+	if err != nil {
+		return errors.Wrapf(err, "could not convert db at path %s", convertFlags.Path)
+	}
+	log.WithField("keys", n).WithField("path", convertFlags.OutPath).Info("Converted database, " +
+		"replace the beaconchaindata directory of the node with the new directory to use it")
+	return nil
+}
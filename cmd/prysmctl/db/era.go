@@ -0,0 +1,197 @@
+package db
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/era"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var eraFlags = struct {
+	Path     string
+	Dir      string
+	Network  string
+	StartEra uint64
+	EndEra   uint64
+}{}
+
+var eraPathFlag = &cli.StringFlag{
+	Name:        "path",
+	Usage:       "path to directory containing beaconchain.db",
+	Destination: &eraFlags.Path,
+	Required:    true,
+}
+
+var eraNetworkFlag = &cli.StringFlag{
+	Name:        "network",
+	Usage:       "network the database belongs to (mainnet, sepolia, holesky)",
+	Destination: &eraFlags.Network,
+	Value:       params.MainnetName,
+}
+
+var exportEraCmd = &cli.Command{
+	Name:  "export-era",
+	Usage: "write finalized blocks and states from the beacon db to era files, one file per era",
+	Action: func(cliCtx *cli.Context) error {
+		if err := exportEraAction(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not export era files")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		eraPathFlag,
+		eraNetworkFlag,
+		&cli.StringFlag{
+			Name:        "out",
+			Usage:       "directory the era files are written to",
+			Destination: &eraFlags.Dir,
+			Required:    true,
+		},
+		&cli.Uint64Flag{
+			Name:        "start-era",
+			Usage:       "first era to export",
+			Destination: &eraFlags.StartEra,
+		},
+		&cli.Uint64Flag{
+			Name:        "end-era",
+			Usage:       "last era to export, defaults to the last finalized era",
+			Destination: &eraFlags.EndEra,
+		},
+	},
+}
+
+var importEraCmd = &cli.Command{
+	Name:  "import-era",
+	Usage: "import blocks and states from a directory of era files into the beacon db, in era order",
+	Action: func(cliCtx *cli.Context) error {
+		if err := importEraAction(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not import era files")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		eraPathFlag,
+		eraNetworkFlag,
+		&cli.StringFlag{
+			Name:        "dir",
+			Usage:       "directory containing the .era files to import",
+			Destination: &eraFlags.Dir,
+			Required:    true,
+		},
+	},
+}
+
+func setEraNetwork(name string) error {
+	if name == params.MainnetName {
+		return nil
+	}
+	cfg, err := params.ByName(name)
+	if err != nil {
+		return errors.Wrapf(err, "unknown network %s", name)
+	}
+	return params.SetActive(cfg.Copy())
+}
+
+func exportEraAction(cliCtx *cli.Context) error {
+	if err := setEraNetwork(eraFlags.Network); err != nil {
+		return err
+	}
+	ctx := context.Background()
+	d, err := kv.NewKVStore(ctx, eraFlags.Path)
+	if err != nil {
+		return errors.Wrapf(err, "could not open db at path %s", eraFlags.Path)
+	}
+	defer func() {
+		if err := d.Close(); err != nil {
+			log.WithError(err).Error("Could not close db")
+		}
+	}()
+	cp, err := d.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return err
+	}
+	fSlot, err := slots.EpochStart(cp.Epoch)
+	if err != nil {
+		return err
+	}
+	end := uint64(fSlot / params.BeaconConfig().SlotsPerHistoricalRoot)
+	if cliCtx.IsSet("end-era") {
+		if eraFlags.EndEra > end {
+			return errors.Wrapf(era.ErrNotFinalized, "end era %d is after the last finalized era %d", eraFlags.EndEra, end)
+		}
+		end = eraFlags.EndEra
+	}
+	if eraFlags.StartEra > end {
+		return errors.Errorf("start era %d is after end era %d", eraFlags.StartEra, end)
+	}
+	if err := file.MkdirAll(eraFlags.Dir); err != nil {
+		return err
+	}
+	for e := eraFlags.StartEra; e <= end; e++ {
+		if _, err := era.Export(ctx, d, e, eraFlags.Dir); err != nil {
+			return errors.Wrapf(err, "could not export era %d", e)
+		}
+	}
+	return nil
+}
+
+func importEraAction(_ *cli.Context) error {
+	if err := setEraNetwork(eraFlags.Network); err != nil {
+		return err
+	}
+	files, err := filepath.Glob(filepath.Join(eraFlags.Dir, "*.era"))
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return errors.Errorf("no era files found in %s", eraFlags.Dir)
+	}
+	// Era numbers are zero padded in the file name, so lexical order is era order.
+	sort.Strings(files)
+	ctx := context.Background()
+	d, err := kv.NewKVStore(ctx, eraFlags.Path)
+	if err != nil {
+		return errors.Wrapf(err, "could not open db at path %s", eraFlags.Path)
+	}
+	defer func() {
+		if err := d.Close(); err != nil {
+			log.WithError(err).Error("Could not close db")
+		}
+	}()
+	for _, p := range files {
+		if err := importEraFile(ctx, d, p); err != nil {
+			return errors.Wrapf(err, "could not import %s", p)
+		}
+	}
+	return nil
+}
+
+func importEraFile(ctx context.Context, d *kv.Store, p string) error {
+	f, err := os.Open(p) // #nosec G304 -- the era directory is chosen by the user.
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.WithError(err).WithField("file", p).Error("Could not close era file")
+		}
+	}()
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	ef, err := era.Open(f, fi.Size())
+	if err != nil {
+		return err
+	}
+	return era.Import(ctx, d, ef)
+}
@@ -0,0 +1,132 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var verifyFlags = struct {
+	Path     string
+	BlobPath string
+	Repair   bool
+}{}
+
+var verifyCmd = &cli.Command{
+	Name:  "verify",
+	Usage: "check the consistency of blocks, states, state summaries, their indices and blob files in the beacon db",
+	Action: func(cliCtx *cli.Context) error {
+		if err := verifyAction(cliCtx); err != nil {
+			log.WithError(err).Fatal("Database verification failed")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "path",
+			Usage:       "path to directory containing beaconchain.db",
+			Destination: &verifyFlags.Path,
+			Required:    true,
+		},
+		&cli.StringFlag{
+			Name:        "blob-path",
+			Usage:       "path to the blob storage directory, to check for blobs with no matching block",
+			Destination: &verifyFlags.BlobPath,
+		},
+		&cli.BoolFlag{
+			Name:        "repair",
+			Usage:       "rebuild the block slot, parent root and finalized block roots indices from the blocks and finalized checkpoint, then verify again",
+			Destination: &verifyFlags.Repair,
+		},
+	},
+}
+
+func verifyAction(cliCtx *cli.Context) error {
+	ctx := cliCtx.Context
+	d, err := kv.NewKVStore(ctx, verifyFlags.Path)
+	if err != nil {
+		return errors.Wrapf(err, "could not open db at path %s", verifyFlags.Path)
+	}
+	defer func() {
+		if err := d.Close(); err != nil {
+			log.WithError(err).Error("Could not close db")
+		}
+	}()
+
+	r, err := verifyDB(ctx, d, verifyFlags.BlobPath)
+	if err != nil {
+		return err
+	}
+	if !r.OK() && verifyFlags.Repair {
+		if err := d.RepairIndices(ctx); err != nil {
+			return err
+		}
+		if r, err = verifyDB(ctx, d, verifyFlags.BlobPath); err != nil {
+			return err
+		}
+	}
+	if !r.OK() {
+		return errors.Errorf("found %d issues", r.Total())
+	}
+	return nil
+}
+
+// verifyDB checks the database and the blob storage at blobPath, if set, and prints the issues found.
+func verifyDB(ctx context.Context, d *kv.Store, blobPath string) (*kv.IntegrityReport, error) {
+	r, err := d.VerifyIntegrity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if blobPath != "" {
+		if err := verifyBlobs(ctx, d, blobPath, r); err != nil {
+			return nil, err
+		}
+	}
+	printReport(r)
+	return r, nil
+}
+
+// orphanBlobsCheck is the name used in reports for blob directories which have no matching block.
+const orphanBlobsCheck = "blob-block"
+
+func verifyBlobs(ctx context.Context, d *kv.Store, blobPath string, r *kv.IntegrityReport) error {
+	bs, err := filesystem.NewBlobStorage(filesystem.WithBasePath(blobPath))
+	if err != nil {
+		return errors.Wrapf(err, "could not open blob storage at %s", blobPath)
+	}
+	roots, err := bs.StoredRoots()
+	if err != nil {
+		return errors.Wrap(err, "could not list blob storage")
+	}
+	for _, root := range roots {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !d.HasBlock(ctx, root) {
+			r.Add(orphanBlobsCheck, root[:], "blobs are stored for a block which is not in the database")
+		}
+	}
+	return nil
+}
+
+func printReport(r *kv.IntegrityReport) {
+	for _, issue := range r.Issues {
+		fmt.Println(issue.String())
+	}
+	fmt.Printf("checked %d blocks, %d state summaries and %d states\n", r.Blocks, r.Summaries, r.States)
+	if r.OK() {
+		fmt.Println("no issues found")
+		return
+	}
+	if len(r.Issues) < r.Total() {
+		fmt.Printf("showing the first %d of %d issues\n", len(r.Issues), r.Total())
+	}
+	for _, c := range r.Checks() {
+		fmt.Printf("%s: %d\n", c, r.Counts[c])
+	}
+}
@@ -0,0 +1,183 @@
+package p2p
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	base "github.com/prysmaticlabs/prysm/v5/api/client"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	"github.com/urfave/cli/v2"
+)
+
+const gossipDiagnosticsPath = "/prysm/v1/node/gossip"
+
+var gossipFlags = struct {
+	Topic  string
+	Watch  time.Duration
+	Output string
+	Input  string
+}{}
+
+var gossipCmd = &cli.Command{
+	Name: "gossip",
+	Usage: "Show the gossipsub mesh of each topic, the message delivery rates over a sliding window and the gossip " +
+		"score components of the peers of a running beacon node, or of a snapshot file",
+	Action: func(cliCtx *cli.Context) error {
+		if err := cliActionGossip(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not show gossip diagnostics")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		beaconNodeHostFlag,
+		authTokenFlag,
+		httpTimeoutFlag,
+		&cli.StringFlag{
+			Name:        "topic",
+			Usage:       "only show the topics containing the given string, ex: beacon_attestation_5",
+			Destination: &gossipFlags.Topic,
+		},
+		&cli.DurationFlag{
+			Name:        "watch",
+			Usage:       "refresh the diagnostics at the given interval (uses duration format, ex: 5s) until interrupted",
+			Destination: &gossipFlags.Watch,
+		},
+		&cli.StringFlag{
+			Name:        "output",
+			Usage:       "write a snapshot of the diagnostics to the given file instead of showing them",
+			Destination: &gossipFlags.Output,
+		},
+		&cli.StringFlag{
+			Name:        "input",
+			Usage:       "show the diagnostics of a snapshot file written with --output instead of a beacon node",
+			Destination: &gossipFlags.Input,
+		},
+	},
+}
+
+func cliActionGossip(cliCtx *cli.Context) error {
+	f := gossipFlags
+	if f.Input != "" {
+		b, err := file.ReadFileAsBytes(f.Input)
+		if err != nil {
+			return errors.Wrap(err, "could not read snapshot file")
+		}
+		resp := &structs.GossipDiagnosticsResponse{}
+		if err := json.Unmarshal(b, resp); err != nil {
+			return errors.Wrap(err, "could not decode snapshot file")
+		}
+		if resp.Data == nil {
+			return errors.New("snapshot file holds no diagnostics")
+		}
+		return renderGossipDiagnostics(os.Stdout, resp.Data)
+	}
+	if f.Output != "" && f.Watch > 0 {
+		return errors.New("--output and --watch cannot be used together")
+	}
+
+	c, err := newBeaconAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := cliCtx.Context
+	if f.Output != "" {
+		resp, err := fetchGossipDiagnostics(ctx, c)
+		if err != nil {
+			return err
+		}
+		b, err := json.MarshalIndent(resp, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := file.WriteFile(f.Output, b); err != nil {
+			return errors.Wrap(err, "could not write snapshot file")
+		}
+		log.WithField("path", f.Output).Info("Wrote gossip diagnostics snapshot")
+		return nil
+	}
+	for {
+		resp, err := fetchGossipDiagnostics(ctx, c)
+		if err != nil {
+			return err
+		}
+		if f.Watch > 0 {
+			// Clear the terminal between refreshes.
+			fmt.Print("\033[H\033[2J")
+		}
+		if err := renderGossipDiagnostics(os.Stdout, resp.Data); err != nil {
+			return err
+		}
+		if f.Watch <= 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(f.Watch):
+		}
+	}
+}
+
+func fetchGossipDiagnostics(ctx context.Context, c *base.Client) (*structs.GossipDiagnosticsResponse, error) {
+	q := url.Values{}
+	if gossipFlags.Topic != "" {
+		q.Set("topic", gossipFlags.Topic)
+	}
+	b, err := c.Get(ctx, gossipDiagnosticsPath, base.WithQuery(q))
+	if err != nil {
+		return nil, err
+	}
+	resp := &structs.GossipDiagnosticsResponse{}
+	if err := json.Unmarshal(b, resp); err != nil {
+		return nil, errors.Wrap(err, "could not decode gossip diagnostics")
+	}
+	if resp.Data == nil {
+		return nil, errors.New("no gossip diagnostics in response")
+	}
+	return resp, nil
+}
+
+func renderGossipDiagnostics(out io.Writer, d *structs.GossipDiagnostics) error {
+	fmt.Fprintf(out, "Gossip diagnostics at %s over the last %ss, peer scores from %s\n",
+		d.Time, d.WindowSeconds, orNone(d.ScoresTime))
+	fmt.Fprintf(out, "IWANT message IDs received: %s, sent: %s\n\n", d.IwantReceived, d.IwantSent)
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TOPIC\tMESH\tDELIVERED\tDUPLICATES\tDUPLICATES/S\tLATE\tLATE/S\tREJECTED\tIHAVE RECEIVED\tIHAVE SENT")
+	for _, t := range d.Topics {
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", t.Topic, len(t.Mesh), t.Delivered, t.Duplicates,
+			t.DuplicatesPerSecond, t.Late, t.LatePerSecond, t.Rejected, t.IhaveReceived, t.IhaveSent)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out, "\nMESH")
+	for _, t := range d.Topics {
+		if len(t.Mesh) > 0 {
+			fmt.Fprintf(out, "%s: %s\n", t.Topic, strings.Join(t.Mesh, ", "))
+		}
+	}
+
+	fmt.Fprintln(out)
+	w = tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PEER\tSCORE\tAPP SPECIFIC\tIP COLOCATION\tBEHAVIOUR PENALTY\tTOPIC\tTIME IN MESH (S)\tFIRST DELIVERIES\tMESH DELIVERIES\tINVALID DELIVERIES")
+	for _, p := range d.PeerScores {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t-\t-\t-\t-\t-\n", p.PeerId, p.Score, p.AppSpecificScore,
+			p.IpColocationFactor, p.BehaviourPenalty)
+		for _, t := range p.Topics {
+			fmt.Fprintf(w, "\t\t\t\t\t%s\t%s\t%s\t%s\t%s\n", t.Topic, t.TimeInMeshSeconds, t.FirstMessageDeliveries,
+				t.MeshMessageDeliveries, t.InvalidMessageDeliveries)
+		}
+	}
+	return w.Flush()
+}
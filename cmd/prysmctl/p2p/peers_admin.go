@@ -0,0 +1,313 @@
+package p2p
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	base "github.com/prysmaticlabs/prysm/v5/api/client"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	adminPeersPath      = "/prysm/v1/admin/peers"
+	adminBansPath       = "/prysm/v1/admin/peers/bans"
+	adminDisconnectPath = "/prysm/v1/admin/peers/disconnect"
+	adminStaticPath     = "/prysm/v1/admin/peers/static"
+)
+
+// beaconAPIFlags are the flags of the commands which use the HTTP API of a running beacon node.
+var beaconAPIFlags = struct {
+	BeaconNodeHost string
+	AuthToken      string
+	Timeout        time.Duration
+}{}
+
+var peersAdminFlags = struct {
+	States   cli.StringSlice
+	PeerID   string
+	CIDR     string
+	Addr     string
+	Duration time.Duration
+	Code     uint64
+}{}
+
+var beaconNodeHostFlag = &cli.StringFlag{
+	Name:        "beacon-node-host",
+	Usage:       "host:port of the HTTP API of the beacon node",
+	Destination: &beaconAPIFlags.BeaconNodeHost,
+	Value:       "localhost:3500",
+}
+
+var authTokenFlag = &cli.StringFlag{
+	Name:        "auth-token",
+	Usage:       "bearer token of the HTTP API of the beacon node, if authentication is enabled",
+	Destination: &beaconAPIFlags.AuthToken,
+}
+
+var httpTimeoutFlag = &cli.DurationFlag{
+	Name:        "http-timeout",
+	Usage:       "timeout for http requests made to the beacon node",
+	Destination: &beaconAPIFlags.Timeout,
+	Value:       time.Minute,
+}
+
+var peerIDFlag = &cli.StringFlag{
+	Name:        "peer-id",
+	Usage:       "ID of the peer",
+	Destination: &peersAdminFlags.PeerID,
+}
+
+var cidrFlag = &cli.StringFlag{
+	Name:        "cidr",
+	Usage:       "IP subnet in CIDR notation, ex: 10.0.0.0/8",
+	Destination: &peersAdminFlags.CIDR,
+}
+
+var peersCmd = &cli.Command{
+	Name:  "peers",
+	Usage: "commands for managing the peers of a running beacon node through its HTTP API",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "list",
+			Usage:  "List the peers of the beacon node with the components of their score and their gossip meshes",
+			Action: beaconAPIAction(listPeers),
+			Flags: []cli.Flag{
+				beaconNodeHostFlag,
+				authTokenFlag,
+				httpTimeoutFlag,
+				&cli.StringSliceFlag{
+					Name:        "state",
+					Usage:       "only list the peers in the given connection state(s), ex: connected",
+					Destination: &peersAdminFlags.States,
+				},
+			},
+		},
+		{
+			Name:   "bans",
+			Usage:  "List the banned peers and IP subnets",
+			Action: beaconAPIAction(listBans),
+			Flags:  []cli.Flag{beaconNodeHostFlag, authTokenFlag, httpTimeoutFlag},
+		},
+		{
+			Name:   "ban",
+			Usage:  "Ban a peer or an IP subnet for a duration, disconnecting the matching peers",
+			Action: beaconAPIAction(banPeer),
+			Flags: []cli.Flag{
+				beaconNodeHostFlag,
+				authTokenFlag,
+				httpTimeoutFlag,
+				peerIDFlag,
+				cidrFlag,
+				&cli.DurationFlag{
+					Name:        "duration",
+					Usage:       "duration of the ban (uses duration format, ex: 1h30m)",
+					Destination: &peersAdminFlags.Duration,
+					Value:       time.Hour,
+				},
+			},
+		},
+		{
+			Name:   "unban",
+			Usage:  "Lift the ban of a peer or an IP subnet",
+			Action: beaconAPIAction(unbanPeer),
+			Flags:  []cli.Flag{beaconNodeHostFlag, authTokenFlag, httpTimeoutFlag, peerIDFlag, cidrFlag},
+		},
+		{
+			Name:   "disconnect",
+			Usage:  "Send a goodbye message to a connected peer and disconnect from it",
+			Action: beaconAPIAction(disconnectPeer),
+			Flags: []cli.Flag{
+				beaconNodeHostFlag,
+				authTokenFlag,
+				httpTimeoutFlag,
+				peerIDFlag,
+				&cli.Uint64Flag{
+					Name:        "code",
+					Usage:       "goodbye code sent to the peer, (default 3: generic error)",
+					Destination: &peersAdminFlags.Code,
+					Value:       3,
+				},
+			},
+		},
+		{
+			Name:   "add-static",
+			Usage:  "Add a static peer that the beacon node keeps a connection to",
+			Action: beaconAPIAction(addStaticPeer),
+			Flags: []cli.Flag{
+				beaconNodeHostFlag,
+				authTokenFlag,
+				httpTimeoutFlag,
+				&cli.StringFlag{
+					Name:        "addr",
+					Usage:       "multiaddress of the peer, including its peer ID",
+					Destination: &peersAdminFlags.Addr,
+				},
+				&cli.DurationFlag{
+					Name:        "duration",
+					Usage:       "how long the peer stays static (uses duration format, ex: 1h30m). If unset, until the beacon node restarts",
+					Destination: &peersAdminFlags.Duration,
+				},
+			},
+		},
+	},
+}
+
+// beaconAPIAction runs an action against the HTTP API of the beacon node given by the flags.
+func beaconAPIAction(action func(ctx context.Context, c *base.Client) error) cli.ActionFunc {
+	return func(cliCtx *cli.Context) error {
+		c, err := newBeaconAPIClient()
+		if err != nil {
+			return err
+		}
+		if err := action(cliCtx.Context, c); err != nil {
+			log.WithError(err).Fatal("Could not complete the beacon node request")
+		}
+		return nil
+	}
+}
+
+func newBeaconAPIClient() (*base.Client, error) {
+	opts := []base.ClientOpt{base.WithTimeout(beaconAPIFlags.Timeout)}
+	if beaconAPIFlags.AuthToken != "" {
+		opts = append(opts, base.WithRequestOptions(base.WithAuthorizationToken(beaconAPIFlags.AuthToken)))
+	}
+	return base.NewClient(beaconAPIFlags.BeaconNodeHost, opts...)
+}
+
+func listPeers(ctx context.Context, c *base.Client) error {
+	q := url.Values{}
+	for _, st := range peersAdminFlags.States.Value() {
+		q.Add("state", st)
+	}
+	b, err := c.Get(ctx, adminPeersPath, base.WithQuery(q))
+	if err != nil {
+		return err
+	}
+	resp := &structs.AdminPeersResponse{}
+	if err := json.Unmarshal(b, resp); err != nil {
+		return errors.Wrap(err, "could not decode peers")
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PEER\tSTATE\tDIRECTION\tTRUSTED\tBAD\tSCORE\tBAD RESPONSES\tBLOCK PROVIDER\tPEER STATUS\tGOSSIP\tBANNED UNTIL\tMESH TOPICS")
+	for _, p := range resp.Data {
+		scores := p.Scores
+		if scores == nil {
+			scores = &structs.PeerScores{}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%t\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			p.PeerId, p.State, p.Direction, p.Trusted, p.Bad, scores.Total, scores.BadResponses, scores.BlockProvider,
+			scores.PeerStatus, scores.Gossip, orNone(p.BanExpiry), orNone(strings.Join(p.MeshTopics, ",")))
+	}
+	return w.Flush()
+}
+
+func listBans(ctx context.Context, c *base.Client) error {
+	b, err := c.Get(ctx, adminBansPath)
+	if err != nil {
+		return err
+	}
+	resp := &structs.PeerBansResponse{}
+	if err := json.Unmarshal(b, resp); err != nil {
+		return errors.Wrap(err, "could not decode bans")
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "BANNED\tUNTIL")
+	if resp.Data != nil {
+		for _, p := range resp.Data.Peers {
+			fmt.Fprintf(w, "%s\t%s\n", p.PeerId, p.Expiry)
+		}
+		for _, s := range resp.Data.Subnets {
+			fmt.Fprintf(w, "%s\t%s\n", s.Cidr, s.Expiry)
+		}
+	}
+	return w.Flush()
+}
+
+func banPeer(ctx context.Context, c *base.Client) error {
+	seconds := int64(peersAdminFlags.Duration / time.Second)
+	if seconds <= 0 {
+		return errors.New("ban duration must be at least one second")
+	}
+	req := &structs.BanPeerRequest{
+		PeerId:   peersAdminFlags.PeerID,
+		Cidr:     peersAdminFlags.CIDR,
+		Duration: strconv.FormatInt(seconds, 10),
+	}
+	if err := postJSON(ctx, c, adminBansPath, req); err != nil {
+		return err
+	}
+	log.WithField("until", time.Now().Add(peersAdminFlags.Duration).Format(time.RFC3339)).Info("Banned " + banTarget())
+	return nil
+}
+
+func unbanPeer(ctx context.Context, c *base.Client) error {
+	q := url.Values{}
+	if peersAdminFlags.PeerID != "" {
+		q.Set("peer_id", peersAdminFlags.PeerID)
+	}
+	if peersAdminFlags.CIDR != "" {
+		q.Set("cidr", peersAdminFlags.CIDR)
+	}
+	if _, err := c.Request(ctx, http.MethodDelete, adminBansPath, nil, base.WithQuery(q)); err != nil {
+		return err
+	}
+	log.Info("Lifted the ban of " + banTarget())
+	return nil
+}
+
+func disconnectPeer(ctx context.Context, c *base.Client) error {
+	req := &structs.DisconnectPeerRequest{
+		PeerId: peersAdminFlags.PeerID,
+		Code:   strconv.FormatUint(peersAdminFlags.Code, 10),
+	}
+	if err := postJSON(ctx, c, adminDisconnectPath, req); err != nil {
+		return err
+	}
+	log.WithField("peer", peersAdminFlags.PeerID).Info("Disconnected from peer")
+	return nil
+}
+
+func addStaticPeer(ctx context.Context, c *base.Client) error {
+	req := &structs.AddStaticPeerRequest{Addr: peersAdminFlags.Addr}
+	if peersAdminFlags.Duration > 0 {
+		req.Duration = strconv.FormatInt(int64(peersAdminFlags.Duration/time.Second), 10)
+	}
+	if err := postJSON(ctx, c, adminStaticPath, req); err != nil {
+		return err
+	}
+	log.WithField("addr", peersAdminFlags.Addr).Info("Added static peer")
+	return nil
+}
+
+func postJSON(ctx context.Context, c *base.Client, path string, req interface{}) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	_, err = c.Request(ctx, http.MethodPost, path, body)
+	return err
+}
+
+func banTarget() string {
+	if peersAdminFlags.CIDR != "" {
+		return "subnet " + peersAdminFlags.CIDR
+	}
+	return "peer " + peersAdminFlags.PeerID
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
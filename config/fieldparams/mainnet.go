@@ -33,6 +33,11 @@ const (
 	BlobSize                              = 131072        // defined to match blob.size in bazel ssz codegen
 	BlobSidecarSize                       = 131928        // defined to match blob sidecar size in bazel ssz codegen
 	KzgCommitmentInclusionProofDepth      = 17            // Merkle proof depth for blob_kzg_commitments list item
+	KzgCommitmentsInclusionProofDepth     = 4             // Merkle proof depth for blob_kzg_commitments list root in the block body.
+	NumberOfColumns                       = 128           // NumberOfColumns defines the number of columns in the extended data matrix.
+	CellsPerExtBlob                       = 128           // CellsPerExtBlob defines the number of cells in an extended blob.
+	FieldElementsPerCell                  = 64            // FieldElementsPerCell defines the number of field elements in a cell.
+	BytesPerCell                          = 2048          // BytesPerCell defines the byte length of a cell.
 	ExecutionBranchDepth                  = 4             // ExecutionBranchDepth defines the number of leaves in a merkle proof of the execution payload header.
 	SyncCommitteeBranchDepth              = 5             // SyncCommitteeBranchDepth defines the number of leaves in a merkle proof of a sync committee.
 	SyncCommitteeBranchDepthElectra       = 6             // SyncCommitteeBranchDepthElectra defines the number of leaves in a merkle proof of a sync committee.
@@ -80,6 +80,62 @@ func MerkleProofKZGCommitment(body interfaces.ReadOnlyBeaconBlockBody, index int
 	return proof, nil
 }
 
+// VerifyKZGCommitmentsInclusionProof verifies the Merkle proof in a data column
+// sidecar showing that its KZG commitments list is part of the beacon block body.
+func VerifyKZGCommitmentsInclusionProof(dc RODataColumn) error {
+	if dc.SignedBlockHeader == nil || dc.SignedBlockHeader.Header == nil {
+		return errNilBlockHeader
+	}
+	root := dc.SignedBlockHeader.Header.BodyRoot
+	if len(root) != field_params.RootLength {
+		return errInvalidBodyRoot
+	}
+	if len(dc.KzgCommitments) == 0 {
+		return errInvalidInclusionProof
+	}
+	leaf, err := commitmentsRoot(dc.KzgCommitments)
+	if err != nil {
+		return err
+	}
+	verified := trie.VerifyMerkleProof(root, leaf[:], kzgPosition, dc.KzgCommitmentsInclusionProof)
+	if !verified {
+		return errInvalidInclusionProof
+	}
+	return nil
+}
+
+// MerkleProofKZGCommitments constructs a Merkle proof of inclusion of the KZG
+// commitments list into the Beacon Block with the given `body`.
+func MerkleProofKZGCommitments(body interfaces.ReadOnlyBeaconBlockBody) ([][]byte, error) {
+	if body.Version() < version.Deneb {
+		return nil, errUnsupportedBeaconBlockBody
+	}
+	membersRoots, err := topLevelRoots(body)
+	if err != nil {
+		return nil, err
+	}
+	sparse, err := trie.GenerateTrieFromItems(membersRoots, logBodyLength)
+	if err != nil {
+		return nil, err
+	}
+	proof, err := sparse.MerkleProof(kzgPosition)
+	if err != nil {
+		return nil, err
+	}
+	// sparse.MerkleProof always includes the length of the slice this is
+	// why we remove the last element that is not needed in the proof
+	return proof[:len(proof)-1], nil
+}
+
+// commitmentsRoot computes the hash tree root of a list of KZG commitments.
+func commitmentsRoot(commitments [][]byte) ([32]byte, error) {
+	sparse, err := trie.GenerateTrieFromItems(leavesFromCommitments(commitments), field_params.LogMaxBlobCommitments)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return sparse.HashTreeRoot()
+}
+
 // leavesFromCommitments hashes each commitment to construct a slice of roots
 func leavesFromCommitments(commitments [][]byte) [][]byte {
 	leaves := make([][]byte, len(commitments))
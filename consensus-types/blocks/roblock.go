@@ -101,6 +101,8 @@ func (s ROBlockSlice) Len() int {
 type BlockWithROBlobs struct {
 	Block ROBlock
 	Blobs []ROBlob
+	// Columns are the data column sidecars fetched for a block of the PeerDAS fork, in place of its blobs.
+	Columns []RODataColumn
 }
 
 // BlockWithROBlobsSlice gives convenient access to getting a slice of just the ROBlocks,
@@ -0,0 +1,96 @@
+package blocks
+
+import (
+	"testing"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestRODataColumnNilChecks(t *testing.T) {
+	cases := []struct {
+		name   string
+		dcfunc func(t *testing.T) *ethpb.DataColumnSidecar
+		err    error
+	}{
+		{
+			name: "nil data column",
+			dcfunc: func(t *testing.T) *ethpb.DataColumnSidecar {
+				return nil
+			},
+			err: errNilDataColumn,
+		},
+		{
+			name: "nil signed block header",
+			dcfunc: func(t *testing.T) *ethpb.DataColumnSidecar {
+				return &ethpb.DataColumnSidecar{}
+			},
+			err: errNilBlockHeader,
+		},
+		{
+			name: "nil inner header",
+			dcfunc: func(t *testing.T) *ethpb.DataColumnSidecar {
+				return &ethpb.DataColumnSidecar{
+					SignedBlockHeader: &ethpb.SignedBeaconBlockHeader{},
+				}
+			},
+			err: errNilBlockHeader,
+		},
+		{
+			name: "nil signature",
+			dcfunc: func(t *testing.T) *ethpb.DataColumnSidecar {
+				return &ethpb.DataColumnSidecar{
+					SignedBlockHeader: &ethpb.SignedBeaconBlockHeader{
+						Header: &ethpb.BeaconBlockHeader{
+							ParentRoot: make([]byte, fieldparams.RootLength),
+							StateRoot:  make([]byte, fieldparams.RootLength),
+							BodyRoot:   make([]byte, fieldparams.RootLength),
+						},
+					},
+				}
+			},
+			err: errMissingBlockSignature,
+		},
+		{
+			name: "valid",
+			dcfunc: func(t *testing.T) *ethpb.DataColumnSidecar {
+				return &ethpb.DataColumnSidecar{
+					SignedBlockHeader: &ethpb.SignedBeaconBlockHeader{
+						Header: &ethpb.BeaconBlockHeader{
+							Slot:       3,
+							ParentRoot: bytesutil.PadTo([]byte("parent"), fieldparams.RootLength),
+							StateRoot:  make([]byte, fieldparams.RootLength),
+							BodyRoot:   make([]byte, fieldparams.RootLength),
+						},
+						Signature: make([]byte, fieldparams.BLSSignatureLength),
+					},
+				}
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			dc := c.dcfunc(t)
+			ro, err := NewRODataColumn(dc)
+			if c.err != nil {
+				require.ErrorIs(t, err, c.err)
+				_, err = NewRODataColumnWithRoot(dc, [32]byte{1})
+				require.ErrorIs(t, err, c.err)
+				return
+			}
+			require.NoError(t, err)
+			hr, err := dc.SignedBlockHeader.Header.HashTreeRoot()
+			require.NoError(t, err)
+			assert.Equal(t, hr, ro.BlockRoot())
+			assert.Equal(t, dc.SignedBlockHeader.Header.Slot, ro.Slot())
+			assert.Equal(t, bytesutil.ToBytes32(dc.SignedBlockHeader.Header.ParentRoot), ro.ParentRoot())
+
+			ro, err = NewRODataColumnWithRoot(dc, [32]byte{1})
+			require.NoError(t, err)
+			assert.Equal(t, [32]byte{1}, ro.BlockRoot())
+		})
+	}
+}
@@ -52,3 +52,15 @@ func ConvertToInterfacePubkey(pubkey *ecdsa.PublicKey) (crypto.PubKey, error) {
 	yVal.Zero()
 	return newKey, nil
 }
+
+func ConvertFromInterfacePubKey(pubkey crypto.PubKey) (*ecdsa.PublicKey, error) {
+	secpKey, ok := pubkey.(*crypto.Secp256k1PublicKey)
+	if !ok {
+		return nil, errors.New("could not cast to Secp256k1PublicKey")
+	}
+	rawKey, err := secpKey.Raw()
+	if err != nil {
+		return nil, err
+	}
+	return gcrypto.DecompressPubkey(rawKey)
+}
@@ -29,6 +29,19 @@ func TestConvertToInterfacePubkey(t *testing.T) {
 	assert.DeepEqual(t, origRawKey, rawKey)
 }
 
+func TestConvertFromInterfacePubKey(t *testing.T) {
+	privKey, err := gcrypto.GenerateKey()
+	require.NoError(t, err)
+	pubkey, ok := privKey.Public().(*ecdsa.PublicKey)
+	require.Equal(t, true, ok)
+
+	altPubkey, err := ConvertToInterfacePubkey(pubkey)
+	require.NoError(t, err)
+	converted, err := ConvertFromInterfacePubKey(altPubkey)
+	require.NoError(t, err)
+	assert.DeepEqual(t, gcrypto.FromECDSAPub(pubkey), gcrypto.FromECDSAPub(converted))
+}
+
 func TestConvertToInterfacePrivkey_HandlesShorterKeys(t *testing.T) {
 	priv, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
 	assert.NoError(t, err)
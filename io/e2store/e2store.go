@@ -0,0 +1,131 @@
+// Package e2store implements the e2store container format used by era files. An e2store file is a sequence of
+// type-length-value entries, each with an 8 byte header:
+//
+//	type (2 bytes) | length (4 bytes, little endian) | reserved (2 bytes, zero) | data (length bytes)
+//
+// See https://github.com/status-im/nimbus-eth2/blob/stable/docs/e2store.md for the full specification.
+package e2store
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+// HeaderSize is the size in bytes of the header preceding the data of every entry.
+const HeaderSize = 8
+
+// Type identifies the kind of data held by an entry.
+type Type [2]byte
+
+// VersionType is the type of the empty entry that starts every e2store file.
+var VersionType = Type{0x65, 0x32}
+
+var (
+	ErrInvalidHeader = errors.New("invalid e2store entry header")
+	ErrEntryTooLarge = errors.New("e2store entry exceeds maximum length")
+)
+
+// Entry is a single record read from an e2store file.
+type Entry struct {
+	Type Type
+	Data []byte
+	// Offset is the position of the entry header from the start of the file.
+	Offset int64
+}
+
+// Writer appends entries to an underlying io.Writer, keeping track of the offset of every entry written.
+type Writer struct {
+	w      io.Writer
+	offset int64
+}
+
+// NewWriter creates a Writer. The first entry written is expected to be at offset 0 of the file.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// Offset returns the position at which the next entry will be written.
+func (w *Writer) Offset() int64 {
+	return w.offset
+}
+
+// WriteEntry writes an entry with the given type and data, returning the offset of the entry header.
+func (w *Writer) WriteEntry(t Type, data []byte) (int64, error) {
+	if uint64(len(data)) > math.MaxUint32 {
+		return 0, errors.Wrapf(ErrEntryTooLarge, "length=%d", len(data))
+	}
+	var header [HeaderSize]byte
+	copy(header[0:2], t[:])
+	binary.LittleEndian.PutUint32(header[2:6], uint32(len(data)))
+	start := w.offset
+	if _, err := w.w.Write(header[:]); err != nil {
+		return 0, err
+	}
+	if _, err := w.w.Write(data); err != nil {
+		return 0, err
+	}
+	w.offset += int64(HeaderSize + len(data))
+	return start, nil
+}
+
+// Reader reads entries sequentially from an underlying io.Reader.
+type Reader struct {
+	r      io.Reader
+	offset int64
+}
+
+// NewReader creates a Reader positioned at the start of an e2store file.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: r}
+}
+
+// Next reads the next entry. io.EOF is returned once all entries have been read.
+func (r *Reader) Next() (*Entry, error) {
+	var header [HeaderSize]byte
+	if _, err := io.ReadFull(r.r, header[:]); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, errors.Wrap(ErrInvalidHeader, "truncated header")
+		}
+		return nil, err
+	}
+	t, length, err := parseHeader(header)
+	if err != nil {
+		return nil, err
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r.r, data); err != nil {
+		return nil, errors.Wrapf(err, "could not read %d byte entry at offset %d", length, r.offset)
+	}
+	e := &Entry{Type: t, Data: data, Offset: r.offset}
+	r.offset += int64(HeaderSize) + int64(length)
+	return e, nil
+}
+
+// ReadEntryAt reads the entry whose header begins at the given offset.
+func ReadEntryAt(r io.ReaderAt, offset int64) (*Entry, error) {
+	var header [HeaderSize]byte
+	if _, err := r.ReadAt(header[:], offset); err != nil {
+		return nil, errors.Wrapf(err, "could not read entry header at offset %d", offset)
+	}
+	t, length, err := parseHeader(header)
+	if err != nil {
+		return nil, err
+	}
+	data := make([]byte, length)
+	if _, err := r.ReadAt(data, offset+HeaderSize); err != nil {
+		return nil, errors.Wrapf(err, "could not read %d byte entry at offset %d", length, offset)
+	}
+	return &Entry{Type: t, Data: data, Offset: offset}, nil
+}
+
+func parseHeader(header [HeaderSize]byte) (Type, uint32, error) {
+	var t Type
+	copy(t[:], header[0:2])
+	if header[6] != 0 || header[7] != 0 {
+		return t, 0, errors.Wrapf(ErrInvalidHeader, "reserved bytes are not zero, type=%#x", t)
+	}
+	return t, binary.LittleEndian.Uint32(header[2:6]), nil
+}
@@ -0,0 +1,65 @@
+package e2store
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestWriterReader(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := NewWriter(buf)
+	entries := []struct {
+		t    Type
+		data []byte
+	}{
+		{t: VersionType},
+		{t: Type{0x01, 0x00}, data: []byte("block")},
+		{t: Type{0x02, 0x00}, data: bytes.Repeat([]byte{0xff}, 1024)},
+	}
+	offsets := make([]int64, len(entries))
+	for i, e := range entries {
+		off, err := w.WriteEntry(e.t, e.data)
+		require.NoError(t, err)
+		offsets[i] = off
+	}
+	require.Equal(t, int64(0), offsets[0])
+	require.Equal(t, int64(HeaderSize), offsets[1])
+	require.Equal(t, int64(buf.Len()), w.Offset())
+
+	r := NewReader(bytes.NewReader(buf.Bytes()))
+	for i, e := range entries {
+		got, err := r.Next()
+		require.NoError(t, err)
+		require.Equal(t, e.t, got.Type)
+		require.Equal(t, len(e.data), len(got.Data))
+		require.Equal(t, offsets[i], got.Offset)
+	}
+	_, err := r.Next()
+	require.ErrorIs(t, err, io.EOF)
+
+	got, err := ReadEntryAt(bytes.NewReader(buf.Bytes()), offsets[1])
+	require.NoError(t, err)
+	require.DeepEqual(t, entries[1].data, got.Data)
+}
+
+func TestReader_InvalidHeader(t *testing.T) {
+	header := []byte{0x65, 0x32, 0, 0, 0, 0, 1, 0}
+	_, err := NewReader(bytes.NewReader(header)).Next()
+	require.ErrorIs(t, err, ErrInvalidHeader)
+	_, err = ReadEntryAt(bytes.NewReader(header), 0)
+	require.ErrorIs(t, err, ErrInvalidHeader)
+
+	_, err = NewReader(bytes.NewReader(header[:5])).Next()
+	require.ErrorIs(t, err, ErrInvalidHeader)
+}
+
+func TestReader_Truncated(t *testing.T) {
+	buf := &bytes.Buffer{}
+	_, err := NewWriter(buf).WriteEntry(Type{0x01, 0x00}, []byte("truncated"))
+	require.NoError(t, err)
+	_, err = NewReader(bytes.NewReader(buf.Bytes()[:buf.Len()-1])).Next()
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
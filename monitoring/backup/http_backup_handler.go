@@ -3,7 +3,9 @@ package backup
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
+	"time"
 
 	"github.com/sirupsen/logrus"
 )
@@ -13,14 +15,41 @@ type Exporter interface {
 	Backup(ctx context.Context, outputPath string, permissionOverride bool) error
 }
 
+// StreamExporter defines the methods of an exporter which can write a backup as a compressed archive,
+// optionally holding only the changes since its previous archive.
+type StreamExporter interface {
+	BackupStream(ctx context.Context, w io.Writer, incremental bool) error
+	// ResetIncrementalBackups stops tracking the changes made since the previous archive, until the next one.
+	ResetIncrementalBackups(ctx context.Context) error
+}
+
 // Handler for accepting requests to initiate a new database backup.
+// With the stream query parameter, the backup is written to the response as a gzip compressed tar archive
+// instead of to the output directory, and the incremental query parameter limits the archive to the changes
+// since the previous streamed backup. Streaming requires the exporter to implement StreamExporter.
+//
+// Once a streamed backup has been taken, every write to the database also records the changed key for the next
+// incremental backup. The reset_incremental query parameter stops this tracking, and the next streamed backup is
+// then a full backup.
 func Handler(bk Exporter, outputDir string) func(http.ResponseWriter, *http.Request) {
 	log := logrus.WithField("prefix", "db")
 
 	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if _, reset := query["reset_incremental"]; reset {
+			resetIncremental(w, r, bk, log)
+			return
+		}
+		_, stream := query["stream"]
+		_, incremental := query["incremental"]
+		if stream || incremental {
+			streamBackup(w, r, bk, incremental, log)
+			return
+		}
+
 		log.Debug("Creating database backup from HTTP webhook")
 
-		_, permissionOverride := r.URL.Query()["permissionOverride"]
+		_, permissionOverride := query["permissionOverride"]
 
 		if err := bk.Backup(context.Background(), outputDir, permissionOverride); err != nil {
 			log.WithError(err).Error("Failed to create backup")
@@ -34,3 +63,56 @@ func Handler(bk Exporter, outputDir string) func(http.ResponseWriter, *http.Requ
 		}
 	}
 }
+
+func streamBackup(w http.ResponseWriter, r *http.Request, bk Exporter, incremental bool, log *logrus.Entry) {
+	se, ok := bk.(StreamExporter)
+	if !ok {
+		http.Error(w, "streaming backups are not supported by this database", http.StatusNotImplemented)
+		return
+	}
+	log.WithField("incremental", incremental).Debug("Streaming database backup from HTTP webhook")
+
+	name := fmt.Sprintf("backup_%s.tar.gz", time.Now().UTC().Format("20060102T150405Z"))
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+	sw := &startedWriter{w: w}
+	if err := se.BackupStream(r.Context(), sw, incremental); err != nil {
+		log.WithError(err).Error("Failed to stream backup")
+		// Once the archive has started, the status can no longer be changed. A failed backup then shows
+		// up as a truncated gzip stream to the caller, and is not used as the parent of the next backup.
+		if !sw.started {
+			w.Header().Del("Content-Disposition")
+			http.Error(w, "failed to create backup", http.StatusInternalServerError)
+		}
+	}
+}
+
+func resetIncremental(w http.ResponseWriter, r *http.Request, bk Exporter, log *logrus.Entry) {
+	se, ok := bk.(StreamExporter)
+	if !ok {
+		http.Error(w, "incremental backups are not supported by this database", http.StatusNotImplemented)
+		return
+	}
+	log.Debug("Resetting incremental database backups from HTTP webhook")
+
+	if err := se.ResetIncrementalBackups(r.Context()); err != nil {
+		log.WithError(err).Error("Failed to reset incremental backups")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	if _, err := fmt.Fprint(w, "OK"); err != nil {
+		log.WithError(err).Error("Failed to write OK")
+	}
+}
+
+// startedWriter records whether anything has been written to the response.
+type startedWriter struct {
+	w       io.Writer
+	started bool
+}
+
+func (s *startedWriter) Write(p []byte) (int, error) {
+	s.started = true
+	return s.w.Write(p)
+}
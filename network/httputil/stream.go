@@ -0,0 +1,231 @@
+package httputil
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api"
+	log "github.com/sirupsen/logrus"
+)
+
+const listBufferSize = 64 * 1024
+
+// JsonListWriter streams a JSON object with a list field, such as the data of a response built from the validators
+// of a state. Each element is encoded and written as soon as it is produced, so the response is never held in memory.
+// The list field is written after the fields of the metadata object, which gives the same output as
+// WriteJson for a response struct declaring the list as its last field.
+type JsonListWriter struct {
+	w       http.ResponseWriter
+	objects []jsonListObject
+	buf     *bufio.Writer
+	enc     *json.Encoder
+	started bool
+	count   int
+	failed  bool
+}
+
+// jsonListObject is a JSON object with the fields of metadata, followed by field, which holds the next object or
+// the list for the innermost object.
+type jsonListObject struct {
+	field    string
+	metadata any
+}
+
+// NewJsonListWriter returns a writer for a JSON object with the fields of metadata, which must encode to a JSON
+// object, and a list named field. Nothing is written to w before the first element or Close.
+func NewJsonListWriter(w http.ResponseWriter, field string, metadata any) *JsonListWriter {
+	return &JsonListWriter{w: w, objects: []jsonListObject{{field: field, metadata: metadata}}}
+}
+
+// NewNestedJsonListWriter returns a writer for a JSON object with the fields of metadata and an object named field,
+// which has the fields of listMetadata and a list named listField. This is the layout of responses whose data object
+// holds a list along with other fields. Both metadata values must encode to a JSON object.
+func NewNestedJsonListWriter(w http.ResponseWriter, field string, metadata any, listField string, listMetadata any) *JsonListWriter {
+	return &JsonListWriter{w: w, objects: []jsonListObject{
+		{field: field, metadata: metadata},
+		{field: listField, metadata: listMetadata},
+	}}
+}
+
+// start writes the response header and everything up to the opening bracket of the list.
+func (lw *JsonListWriter) start() bool {
+	if lw.started || lw.failed {
+		return !lw.failed
+	}
+	var prefix []byte
+	for i, o := range lw.objects {
+		md, err := json.Marshal(o.metadata)
+		if err == nil && (len(md) < 2 || md[0] != '{' || md[len(md)-1] != '}') {
+			err = errors.New("metadata is not a JSON object")
+		}
+		if err != nil {
+			HandleError(lw.w, "Could not marshal response metadata: "+err.Error(), http.StatusInternalServerError)
+			lw.failed = true
+			return false
+		}
+		field, err := json.Marshal(o.field)
+		if err != nil {
+			HandleError(lw.w, "Could not marshal response field: "+err.Error(), http.StatusInternalServerError)
+			lw.failed = true
+			return false
+		}
+		prefix = append(prefix, md[:len(md)-1]...)
+		if len(md) > 2 {
+			prefix = append(prefix, ',')
+		}
+		prefix = append(append(prefix, field...), ':')
+		if i == len(lw.objects)-1 {
+			prefix = append(prefix, '[')
+		}
+	}
+
+	lw.started = true
+	lw.w.Header().Set("Content-Type", api.JsonMediaType)
+	lw.w.WriteHeader(http.StatusOK)
+	lw.buf = bufio.NewWriterSize(lw.w, listBufferSize)
+	lw.enc = json.NewEncoder(lw.buf)
+	return lw.write(prefix)
+}
+
+func (lw *JsonListWriter) write(b []byte) bool {
+	if _, err := lw.buf.Write(b); err != nil {
+		log.WithError(err).Error("Could not write response message")
+		lw.failed = true
+		return false
+	}
+	return true
+}
+
+// Write appends v to the list. The response header is written with the first element, so errors found before
+// then can still be returned with HandleError. It returns false if the response could not be written, in which
+// case the handler should stop producing elements.
+func (lw *JsonListWriter) Write(v any) bool {
+	if !lw.start() {
+		return false
+	}
+	if lw.count > 0 && !lw.write([]byte{','}) {
+		return false
+	}
+	if err := lw.enc.Encode(v); err != nil {
+		log.WithError(err).Error("Could not write response message")
+		lw.failed = true
+		return false
+	}
+	lw.count++
+	return true
+}
+
+// Close closes the list and the objects holding it and flushes the response.
+func (lw *JsonListWriter) Close() {
+	if !lw.start() {
+		return
+	}
+	suffix := append([]byte{']'}, bytes.Repeat([]byte{'}'}, len(lw.objects))...)
+	if !lw.write(append(suffix, '\n')) {
+		return
+	}
+	if err := lw.buf.Flush(); err != nil {
+		log.WithError(err).Error("Could not write response message")
+		lw.failed = true
+	}
+}
+
+// HandleError writes an error response if nothing has been written yet. Once the response has started, the error
+// is logged and the handler is aborted, see abortStream, so HandleError does not return.
+func (lw *JsonListWriter) HandleError(message string, code int) {
+	lw.failed = true
+	if !lw.started {
+		HandleError(lw.w, message, code)
+		return
+	}
+	abortStream(lw.w, lw.buf, lw.count, message)
+}
+
+// abortStream flushes the elements written so far and aborts the handler with http.ErrAbortHandler, so that the
+// server breaks off the response instead of ending it. Clients then get a read error rather than a response with
+// a 200 status which looks complete, as the status can no longer be changed once the response has started.
+func abortStream(w http.ResponseWriter, buf *bufio.Writer, count int, message string) {
+	if err := buf.Flush(); err != nil {
+		log.WithError(err).Error("Could not write response message")
+	} else if err := http.NewResponseController(w).Flush(); err != nil {
+		log.WithError(err).Error("Could not write response message")
+	}
+	log.WithField("elements", count).Error("Could not complete streamed response: " + message)
+	panic(http.ErrAbortHandler)
+}
+
+// SszListWriter streams the ssz encoding of a list of fixed size elements, such as a response built from the
+// validators of a state. The encoding of such a list is the concatenation of the encodings of its elements, so each
+// element is written as soon as it is encoded and the response is never held in memory.
+type SszListWriter struct {
+	w        http.ResponseWriter
+	header   http.Header
+	fileName string
+	buf      *bufio.Writer
+	started  bool
+	count    int
+	failed   bool
+}
+
+// NewSszListWriter returns a writer for the ssz encoding of a list of fixed size elements, sent as fileName with the
+// fields of header added to the response header. Nothing is written to w before the first element or Close.
+func NewSszListWriter(w http.ResponseWriter, header http.Header, fileName string) *SszListWriter {
+	return &SszListWriter{w: w, header: header, fileName: fileName}
+}
+
+// start writes the response header.
+func (lw *SszListWriter) start() bool {
+	if lw.started || lw.failed {
+		return !lw.failed
+	}
+	lw.started = true
+	for k, v := range lw.header {
+		lw.w.Header()[k] = v
+	}
+	lw.w.Header().Set("Content-Type", api.OctetStreamMediaType)
+	lw.w.Header().Set("Content-Disposition", "attachment; filename="+lw.fileName)
+	lw.w.WriteHeader(http.StatusOK)
+	lw.buf = bufio.NewWriterSize(lw.w, listBufferSize)
+	return true
+}
+
+// Write appends the ssz encoding of an element to the list. The response header is written with the first element,
+// so errors found before then can still be returned with HandleError. It returns false if the response could not be
+// written, in which case the handler should stop producing elements.
+func (lw *SszListWriter) Write(enc []byte) bool {
+	if !lw.start() {
+		return false
+	}
+	if _, err := lw.buf.Write(enc); err != nil {
+		log.WithError(err).Error("Could not write response message")
+		lw.failed = true
+		return false
+	}
+	lw.count++
+	return true
+}
+
+// Close flushes the response.
+func (lw *SszListWriter) Close() {
+	if !lw.start() {
+		return
+	}
+	if err := lw.buf.Flush(); err != nil {
+		log.WithError(err).Error("Could not write response message")
+		lw.failed = true
+	}
+}
+
+// HandleError writes an error response if nothing has been written yet. Once the response has started, the error
+// is logged and the handler is aborted, see abortStream, so HandleError does not return.
+func (lw *SszListWriter) HandleError(message string, code int) {
+	lw.failed = true
+	if !lw.started {
+		HandleError(lw.w, message, code)
+		return
+	}
+	abortStream(lw.w, lw.buf, lw.count, message)
+}
@@ -0,0 +1,197 @@
+package httputil
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/api"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+type testListElement struct {
+	Index string `json:"index"`
+}
+
+type testListMetadata struct {
+	ExecutionOptimistic bool `json:"execution_optimistic"`
+	Finalized           bool `json:"finalized"`
+}
+
+type testListResponse struct {
+	ExecutionOptimistic bool               `json:"execution_optimistic"`
+	Finalized           bool               `json:"finalized"`
+	Data                []*testListElement `json:"data"`
+}
+
+func TestJsonListWriter(t *testing.T) {
+	t.Run("same output as WriteJson", func(t *testing.T) {
+		resp := &testListResponse{Finalized: true, Data: []*testListElement{{Index: "1"}, {Index: "2"}, {Index: "3"}}}
+		want := httptest.NewRecorder()
+		WriteJson(want, resp)
+
+		writer := httptest.NewRecorder()
+		lw := NewJsonListWriter(writer, "data", &testListMetadata{Finalized: true})
+		for _, e := range resp.Data {
+			require.Equal(t, true, lw.Write(e))
+		}
+		lw.Close()
+		assert.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, api.JsonMediaType, writer.Header().Get("Content-Type"))
+		got := &testListResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), got))
+		assert.DeepEqual(t, resp, got)
+		// Elements are separated by the newline of the encoder, otherwise the output is identical.
+		compacted := []byte(nil)
+		for _, b := range writer.Body.Bytes()[:writer.Body.Len()-1] {
+			if b != '\n' {
+				compacted = append(compacted, b)
+			}
+		}
+		assert.Equal(t, want.Body.String(), string(compacted)+"\n")
+	})
+	t.Run("nested list", func(t *testing.T) {
+		type nestedData struct {
+			Ideal []string           `json:"ideal"`
+			Total []*testListElement `json:"total"`
+		}
+		writer := httptest.NewRecorder()
+		lw := NewNestedJsonListWriter(writer, "data", &testListMetadata{Finalized: true}, "total", &struct {
+			Ideal []string `json:"ideal"`
+		}{Ideal: []string{"a"}})
+		require.Equal(t, true, lw.Write(&testListElement{Index: "1"}))
+		require.Equal(t, true, lw.Write(&testListElement{Index: "2"}))
+		lw.Close()
+		got := &struct {
+			testListMetadata
+			Data nestedData `json:"data"`
+		}{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), got))
+		assert.Equal(t, true, got.Finalized)
+		assert.DeepEqual(t, nestedData{Ideal: []string{"a"}, Total: []*testListElement{{Index: "1"}, {Index: "2"}}}, got.Data)
+
+		writer = httptest.NewRecorder()
+		NewNestedJsonListWriter(writer, "data", &testListMetadata{}, "total", &struct{}{}).Close()
+		assert.Equal(t, `{"execution_optimistic":false,"finalized":false,"data":{"total":[]}}`+"\n", writer.Body.String())
+	})
+	t.Run("empty list", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		NewJsonListWriter(writer, "data", &testListMetadata{ExecutionOptimistic: true}).Close()
+		assert.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, `{"execution_optimistic":true,"finalized":false,"data":[]}`+"\n", writer.Body.String())
+	})
+	t.Run("no metadata", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		lw := NewJsonListWriter(writer, "data", struct{}{})
+		require.Equal(t, true, lw.Write(1))
+		lw.Close()
+		assert.Equal(t, `{"data":[1`+"\n"+`]}`+"\n", writer.Body.String())
+	})
+	t.Run("invalid metadata", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		lw := NewJsonListWriter(writer, "data", []int{1})
+		assert.Equal(t, false, lw.Write(1))
+		lw.Close()
+		assert.Equal(t, http.StatusInternalServerError, writer.Code)
+		assert.StringContains(t, "metadata is not a JSON object", writer.Body.String())
+	})
+	t.Run("error before first element", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		lw := NewJsonListWriter(writer, "data", &testListMetadata{})
+		lw.HandleError("Could not get validator status", http.StatusInternalServerError)
+		lw.Close()
+		assert.Equal(t, http.StatusInternalServerError, writer.Code)
+		e := &DefaultJsonError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.Equal(t, "Could not get validator status", e.Message)
+	})
+	t.Run("error after first element", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		lw := NewJsonListWriter(writer, "data", &testListMetadata{})
+		require.Equal(t, true, lw.Write(&testListElement{Index: "1"}))
+		requireAborted(t, func() {
+			lw.HandleError("Could not get validator status", http.StatusInternalServerError)
+		})
+		assert.Equal(t, false, lw.Write(&testListElement{Index: "2"}))
+		lw.Close()
+		assert.Equal(t, http.StatusOK, writer.Code)
+		// The elements written so far are flushed, and the response is not terminated.
+		assert.Equal(t, `{"execution_optimistic":false,"finalized":false,"data":[{"index":"1"}`+"\n", writer.Body.String())
+	})
+	t.Run("client sees aborted response", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			lw := NewJsonListWriter(w, "data", &testListMetadata{})
+			lw.Write(&testListElement{Index: "1"})
+			lw.HandleError("Could not get validator status", http.StatusInternalServerError)
+			lw.Close()
+		}))
+		defer srv.Close()
+		resp, err := http.Get(srv.URL)
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, resp.Body.Close())
+		}()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		_, err = io.ReadAll(resp.Body)
+		require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	})
+}
+
+// requireAborted runs fn, which must abort the handler with http.ErrAbortHandler.
+func requireAborted(t *testing.T, fn func()) {
+	defer func() {
+		assert.Equal(t, http.ErrAbortHandler, recover())
+	}()
+	fn()
+	t.Fatal("Handler was not aborted")
+}
+
+func TestSszListWriter(t *testing.T) {
+	header := http.Header{}
+	header.Set(api.VersionHeader, "deneb")
+	t.Run("elements", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		lw := NewSszListWriter(writer, header, "list.ssz")
+		require.Equal(t, true, lw.Write([]byte{1, 2}))
+		require.Equal(t, true, lw.Write([]byte{3, 4}))
+		lw.Close()
+		assert.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, api.OctetStreamMediaType, writer.Header().Get("Content-Type"))
+		assert.Equal(t, "attachment; filename=list.ssz", writer.Header().Get("Content-Disposition"))
+		assert.Equal(t, "deneb", writer.Header().Get(api.VersionHeader))
+		assert.DeepEqual(t, []byte{1, 2, 3, 4}, writer.Body.Bytes())
+	})
+	t.Run("empty list", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		NewSszListWriter(writer, header, "list.ssz").Close()
+		assert.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, 0, writer.Body.Len())
+	})
+	t.Run("error before first element", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		lw := NewSszListWriter(writer, header, "list.ssz")
+		lw.HandleError("Could not get validator status", http.StatusInternalServerError)
+		lw.Close()
+		assert.Equal(t, http.StatusInternalServerError, writer.Code)
+		assert.Equal(t, "", writer.Header().Get(api.VersionHeader))
+		e := &DefaultJsonError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.Equal(t, "Could not get validator status", e.Message)
+	})
+	t.Run("error after first element", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		lw := NewSszListWriter(writer, header, "list.ssz")
+		require.Equal(t, true, lw.Write([]byte{1, 2}))
+		requireAborted(t, func() {
+			lw.HandleError("Could not get validator status", http.StatusInternalServerError)
+		})
+		assert.Equal(t, false, lw.Write([]byte{3, 4}))
+		lw.Close()
+		assert.Equal(t, http.StatusOK, writer.Code)
+		// The elements written so far are flushed, and nothing else is written.
+		assert.DeepEqual(t, []byte{1, 2}, writer.Body.Bytes())
+	})
+}
@@ -36,6 +36,8 @@ mainnet = {
     "pending_partial_withdrawals_limit": "134217728",
     "pending_consolidations_limit": "262144",
     "max_consolidation_requests_per_payload.size": "1",
+    "bytes_per_cell.size": "2048",
+    "kzg_commitments_inclusion_proof_depth.size": "4",
 }
 
 minimal = {
@@ -68,6 +70,8 @@ minimal = {
     "pending_partial_withdrawals_limit": "64",
     "pending_consolidations_limit": "64",
     "max_consolidation_requests_per_payload.size": "1",
+    "bytes_per_cell.size": "2048",
+    "kzg_commitments_inclusion_proof_depth.size": "4",
 }
 
 ###### Rules definitions #######
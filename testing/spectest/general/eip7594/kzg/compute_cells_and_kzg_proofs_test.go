@@ -0,0 +1,65 @@
+package kzg
+
+import (
+	"path"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ghodss/yaml"
+	kzgPrysm "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/kzg"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/spectest/utils"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestComputeCellsAndKZGProofs(t *testing.T) {
+	type input struct {
+		Blob string `json:"blob"`
+	}
+	type data struct {
+		Input  input      `json:"input"`
+		Output [][]string `json:"output"`
+	}
+
+	require.NoError(t, kzgPrysm.Start())
+	testFolders, testFolderPath := utils.TestFolders(t, "general", "eip7594", "kzg/compute_cells_and_kzg_proofs/kzg-mainnet")
+	if len(testFolders) == 0 {
+		t.Fatalf("No test folders found for %s/%s/%s", "general", "eip7594", "kzg/compute_cells_and_kzg_proofs/kzg-mainnet")
+	}
+	for _, folder := range testFolders {
+		t.Run(folder.Name(), func(t *testing.T) {
+			file, err := util.BazelFileBytes(path.Join(testFolderPath, folder.Name(), "data.yaml"))
+			require.NoError(t, err)
+			test := &data{}
+			require.NoError(t, yaml.Unmarshal(file, test))
+
+			blobBytes, err := hexutil.Decode(test.Input.Blob)
+			require.NoError(t, err)
+			if len(blobBytes) != len(kzgPrysm.Blob{}) {
+				require.IsNil(t, test.Output)
+				return
+			}
+			blob := kzgPrysm.Blob(blobBytes)
+			cellsAndProofs, err := kzgPrysm.ComputeCellsAndKZGProofs(&blob)
+			if test.Output == nil {
+				require.NotNil(t, err)
+				return
+			}
+			require.NoError(t, err)
+			requireCellsAndProofs(t, test.Output, cellsAndProofs)
+		})
+	}
+}
+
+// requireCellsAndProofs checks cells and proofs against the hex encoded cells and proofs of a test case output.
+func requireCellsAndProofs(t *testing.T, expected [][]string, actual kzgPrysm.CellsAndProofs) {
+	require.Equal(t, 2, len(expected))
+	require.Equal(t, len(expected[0]), len(actual.Cells))
+	for i, cell := range expected[0] {
+		require.Equal(t, cell, hexutil.Encode(actual.Cells[i][:]))
+	}
+	require.Equal(t, len(expected[1]), len(actual.Proofs))
+	for i, proof := range expected[1] {
+		require.Equal(t, proof, hexutil.Encode(actual.Proofs[i][:]))
+	}
+}
@@ -0,0 +1,50 @@
+package kzg
+
+import (
+	"path"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	kzgPrysm "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/kzg"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/spectest/utils"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestRecoverCellsAndKZGProofs(t *testing.T) {
+	type input struct {
+		CellIndices []uint64 `json:"cell_indices"`
+		Cells       []string `json:"cells"`
+	}
+	type data struct {
+		Input  input      `json:"input"`
+		Output [][]string `json:"output"`
+	}
+
+	require.NoError(t, kzgPrysm.Start())
+	testFolders, testFolderPath := utils.TestFolders(t, "general", "eip7594", "kzg/recover_cells_and_kzg_proofs/kzg-mainnet")
+	if len(testFolders) == 0 {
+		t.Fatalf("No test folders found for %s/%s/%s", "general", "eip7594", "kzg/recover_cells_and_kzg_proofs/kzg-mainnet")
+	}
+	for _, folder := range testFolders {
+		t.Run(folder.Name(), func(t *testing.T) {
+			file, err := util.BazelFileBytes(path.Join(testFolderPath, folder.Name(), "data.yaml"))
+			require.NoError(t, err)
+			test := &data{}
+			require.NoError(t, yaml.Unmarshal(file, test))
+
+			cells, ok := decodeCells(t, test.Input.Cells)
+			if !ok {
+				require.IsNil(t, test.Output)
+				return
+			}
+			cellsAndProofs, err := kzgPrysm.RecoverCellsAndKZGProofs(test.Input.CellIndices, cells)
+			if test.Output == nil {
+				require.NotNil(t, err)
+				return
+			}
+			require.NoError(t, err)
+			requireCellsAndProofs(t, test.Output, cellsAndProofs)
+		})
+	}
+}
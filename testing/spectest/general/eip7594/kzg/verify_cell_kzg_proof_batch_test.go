@@ -0,0 +1,88 @@
+package kzg
+
+import (
+	"path"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ghodss/yaml"
+	kzgPrysm "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/kzg"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/spectest/utils"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestVerifyCellKZGProofBatch(t *testing.T) {
+	type input struct {
+		Commitments []string `json:"commitments"`
+		CellIndices []uint64 `json:"cell_indices"`
+		Cells       []string `json:"cells"`
+		Proofs      []string `json:"proofs"`
+	}
+	type data struct {
+		Input  input `json:"input"`
+		Output *bool `json:"output"`
+	}
+
+	require.NoError(t, kzgPrysm.Start())
+	testFolders, testFolderPath := utils.TestFolders(t, "general", "eip7594", "kzg/verify_cell_kzg_proof_batch/kzg-mainnet")
+	if len(testFolders) == 0 {
+		t.Fatalf("No test folders found for %s/%s/%s", "general", "eip7594", "kzg/verify_cell_kzg_proof_batch/kzg-mainnet")
+	}
+	for _, folder := range testFolders {
+		t.Run(folder.Name(), func(t *testing.T) {
+			file, err := util.BazelFileBytes(path.Join(testFolderPath, folder.Name(), "data.yaml"))
+			require.NoError(t, err)
+			test := &data{}
+			require.NoError(t, yaml.Unmarshal(file, test))
+
+			commitments := make([]kzgPrysm.Commitment, 0, len(test.Input.Commitments))
+			for _, c := range test.Input.Commitments {
+				b, err := hexutil.Decode(c)
+				require.NoError(t, err)
+				if len(b) != len(kzgPrysm.Commitment{}) {
+					require.IsNil(t, test.Output)
+					return
+				}
+				commitments = append(commitments, kzgPrysm.Commitment(b))
+			}
+			cells, ok := decodeCells(t, test.Input.Cells)
+			if !ok {
+				require.IsNil(t, test.Output)
+				return
+			}
+			proofs := make([]kzgPrysm.Proof, 0, len(test.Input.Proofs))
+			for _, p := range test.Input.Proofs {
+				b, err := hexutil.Decode(p)
+				require.NoError(t, err)
+				if len(b) != len(kzgPrysm.Proof{}) {
+					require.IsNil(t, test.Output)
+					return
+				}
+				proofs = append(proofs, kzgPrysm.Proof(b))
+			}
+
+			valid, err := kzgPrysm.VerifyCellKZGProofBatch(commitments, test.Input.CellIndices, cells, proofs)
+			if test.Output == nil {
+				require.NotNil(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, *test.Output, valid)
+		})
+	}
+}
+
+// decodeCells decodes hex encoded cells, returning false if one of them is not the size of a cell.
+func decodeCells(t *testing.T, encoded []string) ([]kzgPrysm.Cell, bool) {
+	cells := make([]kzgPrysm.Cell, 0, len(encoded))
+	for _, c := range encoded {
+		b, err := hexutil.Decode(c)
+		require.NoError(t, err)
+		if len(b) != len(kzgPrysm.Cell{}) {
+			return nil, false
+		}
+		cells = append(cells, kzgPrysm.Cell(b))
+	}
+	return cells, true
+}
@@ -4,11 +4,13 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"path/filepath"
 	"testing"
 
 	"github.com/bazelbuild/rules_go/go/tools/bazel"
 	"github.com/ghodss/yaml"
 	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/io/file"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 )
@@ -30,13 +32,17 @@ func UnmarshalYaml(y []byte, dest interface{}) error {
 	return json.Unmarshal(j, dest)
 }
 
+// SpecTestsDirEnv is the environment variable giving the directory of the extracted consensus spec tests archives,
+// which holds their tests directory. It lets the spec tests run with go test, outside of bazel.
+const SpecTestsDirEnv = "CONSENSUS_SPEC_TESTS_DIR"
+
 // TestFolders sets the proper config and returns the result of ReadDir
 // on the passed in eth2-spec-tests directory along with its path.
 func TestFolders(t testing.TB, config, forkOrPhase, folderPath string) ([]os.DirEntry, string) {
 	testsFolderPath := path.Join("tests", config, forkOrPhase, folderPath)
-	filepath, err := bazel.Runfile(testsFolderPath)
+	dir, err := specTestsPath(testsFolderPath)
 	require.NoError(t, err)
-	testFolders, err := os.ReadDir(filepath)
+	testFolders, err := os.ReadDir(dir)
 	require.NoError(t, err)
 
 	if len(testFolders) == 0 {
@@ -44,9 +50,26 @@ func TestFolders(t testing.TB, config, forkOrPhase, folderPath string) ([]os.Dir
 	}
 	err = saveSpecTest(testsFolderPath)
 	require.NoError(t, err)
+	if os.Getenv(SpecTestsDirEnv) != "" {
+		// The files of the tests are read with the bazel helpers, which find them at an absolute path as well.
+		return testFolders, dir
+	}
 	return testFolders, testsFolderPath
 }
 
+// specTestsPath returns the path of the given spec tests folder, from SpecTestsDirEnv when it is set and from the
+// bazel runfiles otherwise.
+func specTestsPath(testsFolderPath string) (string, error) {
+	if dir := os.Getenv(SpecTestsDirEnv); dir != "" {
+		return filepath.Abs(filepath.Join(dir, testsFolderPath))
+	}
+	p, err := bazel.Runfile(testsFolderPath)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not find %s, run the spec tests with bazel or set %s", testsFolderPath, SpecTestsDirEnv)
+	}
+	return p, nil
+}
+
 func saveSpecTest(testFolder string) error {
 	baseDir := os.Getenv("SPEC_TEST_REPORT_OUTPUT_DIR")
 	if baseDir == "" {
@@ -0,0 +1,22 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestTestFolders_SpecTestsDirEnv(t *testing.T) {
+	dir := t.TempDir()
+	folder := filepath.Join(dir, "tests", "general", "eip7594", "kzg", "recover_cells_and_kzg_proofs", "kzg-mainnet")
+	require.NoError(t, os.MkdirAll(filepath.Join(folder, "case_0"), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(folder, "case_0", "data.yaml"), []byte("input: {}"), 0600))
+	t.Setenv(SpecTestsDirEnv, dir)
+
+	testFolders, testFolderPath := TestFolders(t, "general", "eip7594", "kzg/recover_cells_and_kzg_proofs/kzg-mainnet")
+	require.Equal(t, 1, len(testFolders))
+	require.Equal(t, "case_0", testFolders[0].Name())
+	require.Equal(t, folder, testFolderPath)
+}
@@ -0,0 +1,47 @@
+package util
+
+import (
+	"encoding/binary"
+	"testing"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+// GenerateTestDenebBlockWithColumns generates a block like GenerateTestDenebBlockWithSidecar, along with
+// one data column sidecar per column. The sidecars carry valid commitment inclusion proofs, but their
+// cells and cell proofs are placeholders that will not pass KZG verification.
+func GenerateTestDenebBlockWithColumns(t *testing.T, parent [32]byte, slot primitives.Slot, nblobs int, opts ...DenebBlockGeneratorOption) (blocks.ROBlock, []blocks.RODataColumn) {
+	blk, _ := GenerateTestDenebBlockWithSidecar(t, parent, slot, nblobs, opts...)
+	commitments, err := blk.Block().Body().BlobKzgCommitments()
+	require.NoError(t, err)
+	incProof, err := blocks.MerkleProofKZGCommitments(blk.Block().Body())
+	require.NoError(t, err)
+	sh, err := blk.Header()
+	require.NoError(t, err)
+
+	columns := make([]blocks.RODataColumn, fieldparams.NumberOfColumns)
+	for i := range columns {
+		cells := make([][]byte, len(commitments))
+		proofs := make([][]byte, len(commitments))
+		for j := range cells {
+			cells[j] = make([]byte, fieldparams.BytesPerCell)
+			binary.LittleEndian.PutUint64(cells[j], uint64(i))
+			proofs[j] = make([]byte, fieldparams.BLSPubkeyLength)
+		}
+		pb := &ethpb.DataColumnSidecar{
+			ColumnIndex:                  uint64(i),
+			DataColumn:                   cells,
+			KzgCommitments:               commitments,
+			KzgProof:                     proofs,
+			SignedBlockHeader:            sh,
+			KzgCommitmentsInclusionProof: incProof,
+		}
+		columns[i], err = blocks.NewRODataColumnWithRoot(pb, blk.Root())
+		require.NoError(t, err)
+	}
+	return blk, columns
+}